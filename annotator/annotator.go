@@ -90,7 +90,9 @@ type CheckboxFieldOptions struct{Checked bool ;};
 // If `OnlyIfMissing` is true, the field appearance is generated only for fields that do not have an
 // appearance stream specified.
 // If `RegenerateTextFields` is true, all text fields are regenerated (even if OnlyIfMissing is true).
-type FieldAppearance struct{OnlyIfMissing bool ;RegenerateTextFields bool ;_cad *AppearanceStyle ;};
+// If `RichTextRenderer` is set, the text fields with the RichText flag are rendered using their rich
+// value (RV), laid out by the renderer.
+type FieldAppearance struct{OnlyIfMissing bool ;RegenerateTextFields bool ;RichTextRenderer RichTextRenderer ;_cad *AppearanceStyle ;};
 
 // Style returns the appearance style of `fa`. If not specified, returns default style.
 func (_dgg FieldAppearance )Style ()AppearanceStyle {if _dgg ._cad !=nil {return *_dgg ._cad ;};return AppearanceStyle {AutoFontSizeFraction :0.65,CheckmarkRune :'✔',BorderSize :0.0,BorderColor :_fe .NewPdfColorDeviceGray (0),FillColor :_fe .NewPdfColorDeviceGray (1),MultilineLineHeight :1.2,MultilineVAlignMiddle :false ,DrawAlignmentReticle :false ,AllowMK :true };};
@@ -173,7 +175,7 @@ func CreateRectangleAnnotation (rectDef RectangleAnnotationDef )(*_fe .PdfAnnota
 
 // GenerateAppearanceDict generates an appearance dictionary for widget annotation `wa` for the `field` in `form`.
 // Implements interface model.FieldAppearanceGenerator.
-func (_gae FieldAppearance )GenerateAppearanceDict (form *_fe .PdfAcroForm ,field *_fe .PdfField ,wa *_fe .PdfAnnotationWidget )(*_fac .PdfObjectDictionary ,error ){_d .Log .Trace ("\u0047\u0065n\u0065\u0072\u0061\u0074e\u0041\u0070p\u0065\u0061\u0072\u0061\u006e\u0063\u0065\u0044i\u0063\u0074\u0020\u0066\u006f\u0072\u0020\u0025\u0076\u0020\u0020\u0056:\u0020\u0025\u002b\u0076",field .PartialName (),field .V );_ ,_eec :=field .GetContext ().(*_fe .PdfFieldText );_ffd ,_cee :=_fac .GetDict (wa .AP );if _cee &&_gae .OnlyIfMissing &&(!_eec ||!_gae .RegenerateTextFields ){_d .Log .Trace ("\u0041\u006c\u0072\u0065a\u0064\u0079\u0020\u0070\u006f\u0070\u0075\u006c\u0061\u0074e\u0064 \u002d\u0020\u0069\u0067\u006e\u006f\u0072i\u006e\u0067");return _ffd ,nil ;};if form .DR ==nil {form .DR =_fe .NewPdfPageResources ();};switch _dea :=field .GetContext ().(type ){case *_fe .PdfFieldText :_ggd :=_dea ;switch {case _ggd .Flags ().Has (_fe .FieldFlagPassword ):return nil ,nil ;case _ggd .Flags ().Has (_fe .FieldFlagFileSelect ):return nil ,nil ;case _ggd .Flags ().Has (_fe .FieldFlagComb ):if _ggd .MaxLen !=nil {_ef ,_egcd :=_cc (wa ,_ggd ,form .DR ,_gae .Style ());if _egcd !=nil {return nil ,_egcd ;};return _ef ,nil ;};};_abc ,_gd :=_fae (wa ,_ggd ,form .DR ,_gae .Style ());if _gd !=nil {return nil ,_gd ;};return _abc ,nil ;case *_fe .PdfFieldButton :_ecc :=_dea ;if _ecc .IsCheckbox (){_bef ,_ea :=_ffe (wa ,_ecc ,form .DR ,_gae .Style ());if _ea !=nil {return nil ,_ea ;};return _bef ,nil ;};_d .Log .Debug ("\u0054\u004f\u0044\u004f\u003a\u0020\u0055\u004e\u0048\u0041\u004e\u0044\u004c\u0045\u0044 \u0062u\u0074\u0074\u006f\u006e\u0020\u0074\u0079\u0070\u0065\u003a\u0020\u0025\u002b\u0076",_ecc .GetType ());case *_fe .PdfFieldChoice :_aaf :=_dea ;switch {case _aaf .Flags ().Has (_fe .FieldFlagCombo ):_ddf ,_cgc :=_egbc (form ,wa ,_aaf ,_gae .Style ());if _cgc !=nil {return nil ,_cgc ;};return _ddf ,nil ;default:_d .Log .Debug ("\u0054\u004f\u0044\u004f\u003a\u0020\u0055N\u0048\u0041\u004eD\u004c\u0045\u0044\u0020c\u0068\u006f\u0069\u0063\u0065\u0020\u0066\u0069\u0065\u006c\u0064\u0020\u0077\u0069\u0074\u0068\u0020\u0066\u006c\u0061\u0067\u0073\u003a\u0020\u0025\u0073",_aaf .Flags ().String ());};default:_d .Log .Debug ("\u0054\u004f\u0044\u004f\u003a\u0020\u0055\u004e\u0048\u0041N\u0044\u004c\u0045\u0044\u0020\u0066\u0069e\u006c\u0064\u0020\u0074\u0079\u0070\u0065\u003a\u0020\u0025\u0054",_dea );};return nil ,nil ;};
+func (_gae FieldAppearance )GenerateAppearanceDict (form *_fe .PdfAcroForm ,field *_fe .PdfField ,wa *_fe .PdfAnnotationWidget )(*_fac .PdfObjectDictionary ,error ){_d .Log .Trace ("\u0047\u0065n\u0065\u0072\u0061\u0074e\u0041\u0070p\u0065\u0061\u0072\u0061\u006e\u0063\u0065\u0044i\u0063\u0074\u0020\u0066\u006f\u0072\u0020\u0025\u0076\u0020\u0020\u0056:\u0020\u0025\u002b\u0076",field .PartialName (),field .V );_ ,_eec :=field .GetContext ().(*_fe .PdfFieldText );_ffd ,_cee :=_fac .GetDict (wa .AP );if _cee &&_gae .OnlyIfMissing &&(!_eec ||!_gae .RegenerateTextFields ){_d .Log .Trace ("\u0041\u006c\u0072\u0065a\u0064\u0079\u0020\u0070\u006f\u0070\u0075\u006c\u0061\u0074e\u0064 \u002d\u0020\u0069\u0067\u006e\u006f\u0072i\u006e\u0067");return _ffd ,nil ;};if form .DR ==nil {form .DR =_fe .NewPdfPageResources ();};switch _dea :=field .GetContext ().(type ){case *_fe .PdfFieldText :_ggd :=_dea ;if _gae .RichTextRenderer !=nil {if _bfgae ,_dbfec :=fieldRichText (_ggd );_dbfec {return genFieldRichTextAppearance (wa ,_ggd ,_bfgae ,_gae .RichTextRenderer ,_gae .Style ());};};switch {case _ggd .Flags ().Has (_fe .FieldFlagPassword ):return nil ,nil ;case _ggd .Flags ().Has (_fe .FieldFlagFileSelect ):return nil ,nil ;case _ggd .Flags ().Has (_fe .FieldFlagComb ):if _ggd .MaxLen !=nil {_ef ,_egcd :=_cc (wa ,_ggd ,form .DR ,_gae .Style ());if _egcd !=nil {return nil ,_egcd ;};return _ef ,nil ;};};_abc ,_gd :=_fae (wa ,_ggd ,form .DR ,_gae .Style ());if _gd !=nil {return nil ,_gd ;};return _abc ,nil ;case *_fe .PdfFieldButton :_ecc :=_dea ;if _ecc .IsCheckbox (){_bef ,_ea :=_ffe (wa ,_ecc ,form .DR ,_gae .Style ());if _ea !=nil {return nil ,_ea ;};return _bef ,nil ;};if _ecc .IsRadio (){_dbf ,_bagd :=genFieldRadioGroupAppearance (wa ,_ecc ,_gae .Style ());if _bagd !=nil {return nil ,_bagd ;};return _dbf ,nil ;};_d .Log .Debug ("\u0054\u004f\u0044\u004f\u003a\u0020\u0055\u004e\u0048\u0041\u004e\u0044\u004c\u0045\u0044 \u0062u\u0074\u0074\u006f\u006e\u0020\u0074\u0079\u0070\u0065\u003a\u0020\u0025\u002b\u0076",_ecc .GetType ());case *_fe .PdfFieldChoice :_aaf :=_dea ;switch {case _aaf .Flags ().Has (_fe .FieldFlagCombo ):_ddf ,_cgc :=_egbc (form ,wa ,_aaf ,_gae .Style ());if _cgc !=nil {return nil ,_cgc ;};return _ddf ,nil ;default:_cddg ,_fefe :=genFieldListBoxAppearance (form ,wa ,_aaf ,_gae .Style ());if _fefe !=nil {return nil ,_fefe ;};return _cddg ,nil ;};default:_d .Log .Debug ("\u0054\u004f\u0044\u004f\u003a\u0020\u0055\u004e\u0048\u0041N\u0044\u004c\u0045\u0044\u0020\u0066\u0069e\u006c\u0064\u0020\u0074\u0079\u0070\u0065\u003a\u0020\u0025\u0054",_dea );};return nil ,nil ;};
 
 // NewSignatureField returns a new signature field with a visible appearance
 // containing the specified signature lines and styled according to the
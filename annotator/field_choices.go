@@ -0,0 +1,496 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package annotator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/contentstream"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// RadioGroupFieldOptions defines optional parameters for a radio button group
+// form field.
+type RadioGroupFieldOptions struct {
+	// Choices is the list of export values of the radio buttons. Each value
+	// is used as the name of the "on" state of the corresponding button and
+	// must be unique.
+	Choices []string
+
+	// Selected is the export value of the selected radio button, which must
+	// be one of the choices. No button is selected if empty.
+	Selected string
+}
+
+// ListBoxFieldOptions defines optional parameters for a list box form field.
+type ListBoxFieldOptions struct {
+	// Choices is the list of unique string values that can be selected.
+	Choices []string
+
+	// Selected is the list of the selected values, which must be among the
+	// choices.
+	Selected []string
+
+	// MultiSelect specifies whether multiple values can be selected.
+	MultiSelect bool
+}
+
+// NewRadioGroupField generates a new radio button group field with partial
+// name `name` on specified `page` and with field specific options `opt`. A
+// radio button widget is created for each of the choices, at the location
+// specified by the corresponding entry of `rects`. Only one of the buttons
+// can be selected at a time.
+func NewRadioGroupField(page *model.PdfPage, name string, rects [][]float64, opt RadioGroupFieldOptions) (*model.PdfFieldButton, error) {
+	if page == nil {
+		return nil, errors.New("page not specified")
+	}
+	if len(name) <= 0 {
+		return nil, errors.New("required attribute not specified")
+	}
+	if len(rects) == 0 || len(rects) != len(opt.Choices) {
+		return nil, errors.New("invalid number of radio button locations")
+	}
+	for _, rect := range rects {
+		if len(rect) != 4 {
+			return nil, errors.New("invalid range")
+		}
+	}
+	indices, err := choiceIndices(opt.Choices)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := indices[opt.Selected]; opt.Selected != "" && !ok {
+		return nil, errors.New("selected value is not one of the radio button choices")
+	}
+
+	field := model.NewPdfField()
+	button := &model.PdfFieldButton{}
+	field.SetContext(button)
+	button.PdfField = field
+	button.T = core.MakeString(name)
+	button.SetType(model.ButtonTypeRadio)
+	button.Ff = core.MakeInteger(int64(button.Flags().Set(model.FieldFlagNoToggleToOff)))
+
+	state := "Off"
+	if opt.Selected != "" {
+		state = opt.Selected
+	}
+	button.V = core.MakeName(state)
+
+	style := FieldAppearance{}.Style()
+	for i, rect := range rects {
+		widget := model.NewPdfAnnotationWidget()
+		widget.Rect = core.MakeArrayFromFloats(rect)
+		widget.P = page.ToPdfObject()
+		widget.F = core.MakeInteger(4)
+		widget.Parent = button.ToPdfObject()
+
+		mk := core.MakeDict()
+		mk.Set("BC", core.MakeArrayFromFloats([]float64{0}))
+		mk.Set("BG", core.MakeArrayFromFloats([]float64{1}))
+		widget.MK = mk
+
+		bs := model.NewBorderStyle()
+		bs.SetBorderWidth(1)
+		widget.BS = bs.ToPdfObject()
+
+		ap, err := genFieldRadioAppearance(widget, opt.Choices[i], style)
+		if err != nil {
+			return nil, err
+		}
+		widget.AP = ap
+
+		widget.AS = core.MakeName("Off")
+		if opt.Choices[i] == opt.Selected {
+			widget.AS = core.MakeName(state)
+		}
+		button.Annotations = append(button.Annotations, widget)
+	}
+	return button, nil
+}
+
+// NewListBoxField generates a new scrollable list box field with partial
+// name `name` at location `rect` on specified `page` and with field specific
+// options `opt`.
+func NewListBoxField(page *model.PdfPage, name string, rect []float64, opt ListBoxFieldOptions) (*model.PdfFieldChoice, error) {
+	if page == nil {
+		return nil, errors.New("page not specified")
+	}
+	if len(name) <= 0 {
+		return nil, errors.New("required attribute not specified")
+	}
+	if len(rect) != 4 {
+		return nil, errors.New("invalid range")
+	}
+	if len(opt.Selected) > 1 && !opt.MultiSelect {
+		return nil, errors.New("multiple values selected in single selection list box")
+	}
+	choiceIndex, err := choiceIndices(opt.Choices)
+	if err != nil {
+		return nil, err
+	}
+
+	// The indices of the selected options.
+	var indices []int
+	selected := map[string]bool{}
+	for _, value := range opt.Selected {
+		i, ok := choiceIndex[value]
+		if !ok {
+			return nil, errors.New("selected value is not one of the list box choices")
+		}
+		if selected[value] {
+			return nil, errors.New("list box value selected more than once")
+		}
+		selected[value] = true
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	field := model.NewPdfField()
+	choice := &model.PdfFieldChoice{}
+	field.SetContext(choice)
+	choice.PdfField = field
+	choice.T = core.MakeString(name)
+	choice.Opt = core.MakeArray()
+	for _, value := range opt.Choices {
+		choice.Opt.Append(core.MakeString(value))
+	}
+	if opt.MultiSelect {
+		choice.SetFlag(model.FieldFlagMultiSelect)
+	}
+
+	// Set the value and the indices of the selected options.
+	switch len(opt.Selected) {
+	case 0:
+	case 1:
+		choice.V = core.MakeString(opt.Selected[0])
+	default:
+		values := core.MakeArray()
+		for _, value := range opt.Selected {
+			values.Append(core.MakeString(value))
+		}
+		choice.V = values
+	}
+	if len(indices) > 0 {
+		choice.I = core.MakeArray()
+		for _, i := range indices {
+			choice.I.Append(core.MakeInteger(int64(i)))
+		}
+	}
+
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats(rect)
+	widget.P = page.ToPdfObject()
+	widget.F = core.MakeInteger(4)
+	widget.Parent = choice.ToPdfObject()
+	choice.Annotations = append(choice.Annotations, widget)
+	return choice, nil
+}
+
+// choiceIndices returns the indices of the choices of a radio button group or
+// list box by value. An error is returned if a value is listed more than once.
+func choiceIndices(choices []string) (map[string]int, error) {
+	indices := make(map[string]int, len(choices))
+	for i, choice := range choices {
+		if _, ok := indices[choice]; ok {
+			return nil, fmt.Errorf("duplicate choice %q", choice)
+		}
+		indices[choice] = i
+	}
+	return indices, nil
+}
+
+// radioOnState returns the name of the "on" state of the radio button widget.
+// The state is looked up in the existing appearance of the widget, falling
+// back to the option of the field corresponding to the widget.
+func radioOnState(field *model.PdfFieldButton, wa *model.PdfAnnotationWidget) (string, bool) {
+	if ap, ok := core.GetDict(wa.AP); ok {
+		if states, ok := core.GetDict(ap.Get("N")); ok {
+			for _, key := range states.Keys() {
+				if key != "Off" {
+					return key.String(), true
+				}
+			}
+		}
+	}
+
+	if field.Opt != nil {
+		for i, w := range field.Annotations {
+			if w != wa {
+				continue
+			}
+			if str, ok := core.GetString(field.Opt.Get(i)); ok {
+				return str.Decoded(), true
+			}
+		}
+	}
+	return "", false
+}
+
+// genFieldRadioAppearance generates the appearance dictionary of a radio
+// button widget, containing the "Off" state and the `onState` state.
+func genFieldRadioAppearance(wa *model.PdfAnnotationWidget, onState string, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	array, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := rect.Width(), rect.Height()
+
+	if mk, ok := core.GetDict(wa.MK); ok {
+		bs, _ := core.GetDict(wa.BS)
+		if err := style.applyAppearanceCharacteristics(mk, bs, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	r := math.Min(width, height) / 2
+	cx, cy := width/2, height/2
+	drawButton := func(selected bool) (*model.XObjectForm, error) {
+		cc := contentstream.NewContentCreator()
+		cc.Add_q()
+		if style.FillColor != nil {
+			cc.SetNonStrokingColor(style.FillColor)
+			addCirclePath(cc, cx, cy, r)
+			cc.Add_f()
+		}
+		if style.BorderSize > 0 && style.BorderColor != nil {
+			cc.SetStrokingColor(style.BorderColor).Add_w(style.BorderSize)
+			addCirclePath(cc, cx, cy, r-style.BorderSize/2)
+			cc.Add_S()
+		}
+		if selected {
+			cc.Add_g(0)
+			addCirclePath(cc, cx, cy, r*0.4)
+			cc.Add_f()
+		}
+		cc.Add_Q()
+
+		form := model.NewXObjectForm()
+		form.BBox = core.MakeArrayFromFloats([]float64{0, 0, width, height})
+		if err := form.SetContentStream(cc.Bytes(), _faef()); err != nil {
+			return nil, err
+		}
+		return form, nil
+	}
+
+	off, err := drawButton(false)
+	if err != nil {
+		return nil, err
+	}
+	on, err := drawButton(true)
+	if err != nil {
+		return nil, err
+	}
+
+	states := core.MakeDict()
+	states.Set("Off", off.ToPdfObject())
+	states.Set(*core.MakeName(onState), on.ToPdfObject())
+
+	ap := core.MakeDict()
+	ap.Set("N", states)
+	return ap, nil
+}
+
+// addCirclePath appends a circle path to the content creator, approximated
+// using Bezier curves.
+func addCirclePath(cc *contentstream.ContentCreator, cx, cy, r float64) {
+	k := 0.5523 * r
+	cc.Add_m(cx+r, cy)
+	cc.Add_c(cx+r, cy+k, cx+k, cy+r, cx, cy+r)
+	cc.Add_c(cx-k, cy+r, cx-r, cy+k, cx-r, cy)
+	cc.Add_c(cx-r, cy-k, cx-k, cy-r, cx, cy-r)
+	cc.Add_c(cx+k, cy-r, cx+r, cy-k, cx+r, cy)
+	cc.Add_h()
+}
+
+// choiceOptions returns the export values and the display texts of the
+// options of the choice field.
+func choiceOptions(field *model.PdfFieldChoice) ([]string, []string) {
+	if field.Opt == nil {
+		return nil, nil
+	}
+
+	var values, texts []string
+	for _, obj := range field.Opt.Elements() {
+		value, text := obj, obj
+		if arr, ok := core.GetArray(obj); ok && arr.Len() == 2 {
+			value, text = arr.Get(0), arr.Get(1)
+		}
+
+		var strs [2]string
+		for i, o := range []core.PdfObject{value, text} {
+			if str, ok := core.GetString(o); ok {
+				strs[i] = str.Decoded()
+			} else if name, ok := core.GetName(o); ok {
+				strs[i] = name.String()
+			}
+		}
+		values = append(values, strs[0])
+		texts = append(texts, strs[1])
+	}
+	return values, texts
+}
+
+// choiceSelection returns the indices of the selected options of the choice
+// field. The selection is determined using the value of the field, falling
+// back to the selected indices (I) of the field.
+func choiceSelection(field *model.PdfFieldChoice, values []string) map[int]bool {
+	selected := map[int]bool{}
+
+	var selectedValues []string
+	switch t := core.TraceToDirectObject(field.V).(type) {
+	case *core.PdfObjectString:
+		selectedValues = append(selectedValues, t.Decoded())
+	case *core.PdfObjectName:
+		selectedValues = append(selectedValues, t.String())
+	case *core.PdfObjectArray:
+		for _, obj := range t.Elements() {
+			if str, ok := core.GetString(obj); ok {
+				selectedValues = append(selectedValues, str.Decoded())
+			}
+		}
+	}
+	for _, value := range selectedValues {
+		for i, v := range values {
+			if v == value {
+				selected[i] = true
+			}
+		}
+	}
+
+	if len(selected) == 0 && field.I != nil {
+		for _, obj := range field.I.Elements() {
+			if i, ok := core.GetIntVal(obj); ok {
+				selected[i] = true
+			}
+		}
+	}
+	return selected
+}
+
+// genFieldListBoxAppearance generates the appearance dictionary of a list box
+// widget. The visible options are drawn starting with the top index (TI) of
+// the field, with the selected options highlighted.
+func genFieldListBoxAppearance(form *model.PdfAcroForm, wa *model.PdfAnnotationWidget, field *model.PdfFieldChoice, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	array, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := rect.Width(), rect.Height()
+
+	daOps, err := contentstream.NewContentStreamParser(_ece(field.PdfField)).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if mk, ok := core.GetDict(wa.MK); ok {
+		bs, _ := core.GetDict(wa.BS)
+		if err := style.applyAppearanceCharacteristics(mk, bs, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// Process the default appearance of the field. The operands setting the
+	// text color are applied to each of the options.
+	resources := model.NewPdfPageResources()
+	daCC := contentstream.NewContentCreator()
+	font, _, err := style.processDA(field.PdfField, daOps, form.DR, resources, daCC)
+	if err != nil {
+		return nil, err
+	}
+	fontSize := font.Size
+	if fontSize <= 0 {
+		fontSize = 12
+	}
+	lineHeight := fontSize * style.MultilineLineHeight
+	if lineHeight <= 0 {
+		lineHeight = fontSize
+	}
+
+	values, texts := choiceOptions(field)
+	selected := choiceSelection(field, values)
+
+	topIndex := 0
+	if field.TI != nil {
+		topIndex = int(*field.TI)
+	}
+
+	cc := contentstream.NewContentCreator()
+	if style.BorderSize > 0 {
+		_aggd(cc, style, width, height)
+	}
+	cc.Add_BMC("Tx")
+	cc.Add_q()
+
+	inset := 1 + style.BorderSize
+	cc.Add_re(inset, inset, width-2*inset, height-2*inset).Add_W().Add_n()
+
+	encoder := font.Font.Encoder()
+	y := height - inset
+	for i := topIndex; i < len(texts) && y > inset; i++ {
+		y -= lineHeight
+		if selected[i] {
+			cc.Add_q().Add_rg(0.6, 0.75, 0.86)
+			cc.Add_re(inset, y, width-2*inset, lineHeight).Add_f()
+			cc.Add_Q()
+		}
+
+		text := texts[i]
+		if encoder != nil {
+			text = string(encoder.Encode(text))
+		}
+
+		cc.Add_BT()
+		for _, op := range *daCC.Operations() {
+			cc.AddOperand(*op)
+		}
+		cc.Add_Tf(*core.MakeName(font.Name), fontSize)
+		cc.Add_Td(inset+1, y+(lineHeight-fontSize)/2+0.22*fontSize)
+		cc.Add_Tj(*core.MakeString(text))
+		cc.Add_ET()
+	}
+	cc.Add_Q()
+	cc.Add_EMC()
+
+	xform := model.NewXObjectForm()
+	xform.Resources = resources
+	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, width, height})
+	if err := xform.SetContentStream(cc.Bytes(), _faef()); err != nil {
+		return nil, err
+	}
+
+	ap := core.MakeDict()
+	ap.Set("N", xform.ToPdfObject())
+	return ap, nil
+}
+
+// genFieldRadioGroupAppearance generates the appearance dictionary of a
+// widget of the radio button group field.
+func genFieldRadioGroupAppearance(wa *model.PdfAnnotationWidget, field *model.PdfFieldButton, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	state, ok := radioOnState(field, wa)
+	if !ok {
+		common.Log.Debug("ERROR: unable to determine radio button state")
+		return nil, errors.New("radio button state not found")
+	}
+	return genFieldRadioAppearance(wa, state, style)
+}
@@ -0,0 +1,235 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package annotator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/contentstream"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// FreeTextAnnotationDef defines a free text annotation displaying text
+// directly on the page, within the rectangle with a lower left corner at
+// (X,Y) and the specified Width and Height. The text is wrapped to fit the
+// rectangle. The rectangle can optionally have a border and a filling
+// color.
+//
+// When RichText (XHTML rich text) is set along with RichTextRenderer, the
+// rich text is displayed instead of Text, which only serves as the plain
+// text contents of the annotation.
+type FreeTextAnnotationDef struct {
+	X             float64
+	Y             float64
+	Width         float64
+	Height        float64
+	Text          string
+	Font          *model.PdfFont
+	FontSize      float64
+	TextColor     *model.PdfColorDeviceRGB
+	Alignment     RichTextAlignment
+	FillEnabled   bool
+	FillColor     *model.PdfColorDeviceRGB
+	BorderEnabled bool
+	BorderWidth   float64
+	BorderColor   *model.PdfColorDeviceRGB
+	Opacity       float64
+
+	RichText         string
+	RichTextRenderer RichTextRenderer
+}
+
+// CreateFreeTextAnnotation creates a free text annotation object that can be
+// added to the page annotations. The font defaults to Helvetica, the font
+// size to 12 and the text color to black.
+func CreateFreeTextAnnotation(textDef FreeTextAnnotationDef) (*model.PdfAnnotation, error) {
+	if textDef.Width <= 0 || textDef.Height <= 0 {
+		return nil, errors.New("invalid free text annotation size")
+	}
+
+	font, fontName, fontFamily := textDef.Font, "F1", ""
+	if font != nil {
+		fontFamily = font.BaseFont()
+	} else {
+		fontName, fontFamily = "Helv", "Helvetica"
+		var err error
+		if font, err = model.NewStandard14Font(model.HelveticaName); err != nil {
+			return nil, err
+		}
+	}
+	fontSize := textDef.FontSize
+	if fontSize <= 0 {
+		fontSize = 12
+	}
+	textColor := textDef.TextColor
+	if textColor == nil {
+		textColor = model.NewPdfColorDeviceRGB(0, 0, 0)
+	}
+	borderWidth := 0.0
+	if textDef.BorderEnabled {
+		borderWidth = textDef.BorderWidth
+	}
+
+	annot := model.NewPdfAnnotationFreeText()
+	annot.Contents = core.MakeString(textDef.Text)
+	annot.DA = core.MakeString(fmt.Sprintf("/%s %g Tf %g %g %g rg", fontName, fontSize, textColor.R(), textColor.G(), textColor.B()))
+	annot.DS = core.MakeString(fmt.Sprintf("font: %gpt %s; color: #%02x%02x%02x", fontSize, fontFamily,
+		int(textColor.R()*255+0.5), int(textColor.G()*255+0.5), int(textColor.B()*255+0.5)))
+	switch textDef.Alignment {
+	case RichTextAlignCenter:
+		annot.Q = core.MakeInteger(1)
+	case RichTextAlignRight:
+		annot.Q = core.MakeInteger(2)
+	default:
+		annot.Q = core.MakeInteger(0)
+	}
+	if textDef.FillEnabled {
+		annot.C = rgbArray(textDef.FillColor)
+	} else {
+		annot.C = core.MakeArray()
+	}
+	bs := model.NewBorderStyle()
+	bs.SetBorderWidth(borderWidth)
+	annot.BS = bs.ToPdfObject()
+	if textDef.Opacity < 1.0 {
+		annot.CA = core.MakeFloat(textDef.Opacity)
+	}
+
+	rect := &model.PdfRectangle{Llx: textDef.X, Lly: textDef.Y, Urx: textDef.X + textDef.Width, Ury: textDef.Y + textDef.Height}
+	annot.Rect = rect.ToPdfObject()
+
+	if textDef.RichText != "" && textDef.RichTextRenderer != nil {
+		annot.RC = core.MakeString(textDef.RichText)
+		if _, err := GenerateFreeTextRichAppearance(annot, textDef.RichTextRenderer); err != nil {
+			return nil, err
+		}
+		return annot.PdfAnnotation, nil
+	}
+
+	resources := model.NewPdfPageResources()
+	if err := resources.SetFontByName(core.PdfObjectName(fontName), font.ToPdfObject()); err != nil {
+		return nil, err
+	}
+	ap, _, err := annotationAppearance(resources, textDef.Opacity, "", func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		cc := contentstream.NewContentCreator()
+		cc.Add_q()
+		if gsName != "" {
+			cc.Add_gs(core.PdfObjectName(gsName))
+		}
+		if textDef.FillEnabled && textDef.FillColor != nil {
+			fill := textDef.FillColor
+			cc.Add_rg(fill.R(), fill.G(), fill.B())
+			cc.Add_re(rect.Llx, rect.Lly, textDef.Width, textDef.Height).Add_f()
+		}
+		if borderWidth > 0 && textDef.BorderColor != nil {
+			border := textDef.BorderColor
+			cc.Add_RG(border.R(), border.G(), border.B()).Add_w(borderWidth)
+			cc.Add_re(rect.Llx+borderWidth/2, rect.Lly+borderWidth/2, textDef.Width-borderWidth, textDef.Height-borderWidth).Add_S()
+		}
+
+		inset := borderWidth + 2
+		innerWidth, innerHeight := textDef.Width-2*inset, textDef.Height-2*inset
+		if innerWidth > 0 && innerHeight > 0 {
+			cc.Add_re(rect.Llx+inset, rect.Lly+inset, innerWidth, innerHeight).Add_W().Add_n()
+			cc.Add_rg(textColor.R(), textColor.G(), textColor.B())
+			drawTextLines(cc, font, fontName, fontSize, wrapText(textDef.Text, font, fontSize, innerWidth),
+				rect.Llx+inset, rect.Lly+textDef.Height-inset, innerWidth, textDef.Alignment)
+		}
+		cc.Add_Q()
+		return cc.Bytes(), rect, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	return annot.PdfAnnotation, nil
+}
+
+// textWidth returns the width of the text drawn using the font at the
+// specified size.
+func textWidth(text string, font *model.PdfFont, fontSize float64) float64 {
+	width := 0.0
+	for _, r := range text {
+		if metrics, ok := font.GetRuneMetrics(r); ok {
+			width += metrics.Wx
+		}
+	}
+	return width * fontSize / 1000
+}
+
+// wrapText splits the text into lines fitting the specified width. Lines
+// are broken at spaces, unless a single word does not fit.
+func wrapText(text string, font *model.PdfFont, fontSize, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(para)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := ""
+		for _, word := range words {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if textWidth(candidate, font, fontSize) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = ""
+			if textWidth(word, font, fontSize) <= width {
+				line = word
+				continue
+			}
+
+			// Break the words which do not fit on a line on their own.
+			for _, r := range word {
+				if line != "" && textWidth(line+string(r), font, fontSize) > width {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// drawTextLines draws the lines of text below the specified top position,
+// aligned within the specified width.
+func drawTextLines(cc *contentstream.ContentCreator, font *model.PdfFont, fontName string, fontSize float64, lines []string, x, top, width float64, alignment RichTextAlignment) {
+	lineHeight := 1.2 * fontSize
+	cc.Add_BT()
+	cc.Add_Tf(core.PdfObjectName(fontName), fontSize)
+	y := top - fontSize
+	for _, line := range lines {
+		offset := 0.0
+		switch alignment {
+		case RichTextAlignCenter:
+			offset = (width - textWidth(line, font, fontSize)) / 2
+		case RichTextAlignRight:
+			offset = width - textWidth(line, font, fontSize)
+		}
+		encoded, _ := font.StringToCharcodeBytes(line)
+		cc.Add_Tm(1, 0, 0, 1, x+offset, y)
+		cc.Add_Tj(*core.MakeStringFromBytes(encoded))
+		y -= lineHeight
+	}
+	cc.Add_ET()
+}
@@ -0,0 +1,633 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package annotator
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/contentstream"
+	"github.com/unidoc/unipdf/v3/contentstream/draw"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// TextMarkupAnnotationDef defines a text markup annotation (highlight,
+// underline, strikeout or squiggly underline) covering one or more areas of
+// text, e.g. one per line of the marked up text. The areas are typically
+// the bounding boxes of the text, including the descent of its glyphs.
+type TextMarkupAnnotationDef struct {
+	Rects   []model.PdfRectangle
+	Color   *model.PdfColorDeviceRGB
+	Opacity float64
+}
+
+// InkAnnotationDef defines a freehand "scribble" annotation composed of one
+// or more disjoint paths, drawn with the specified line width, color and
+// opacity.
+type InkAnnotationDef struct {
+	Paths     [][]draw.Point
+	LineColor *model.PdfColorDeviceRGB
+	LineWidth float64
+	Opacity   float64
+}
+
+// PolyLineAnnotationDef defines an open polygon connecting its points in
+// order. The line ending styles can be none (regular line), arrows or butts
+// at either end. The line also has a specified width, color and opacity.
+type PolyLineAnnotationDef struct {
+	Points           []draw.Point
+	LineColor        *model.PdfColorDeviceRGB
+	LineWidth        float64
+	Opacity          float64
+	LineEndingStyle1 draw.LineEndingStyle
+	LineEndingStyle2 draw.LineEndingStyle
+}
+
+// PolygonAnnotationDef defines a closed polygon with the specified vertices.
+// The polygon can optionally have a border and a filling color.
+type PolygonAnnotationDef struct {
+	Vertices      []draw.Point
+	FillEnabled   bool
+	FillColor     *model.PdfColorDeviceRGB
+	BorderEnabled bool
+	BorderWidth   float64
+	BorderColor   *model.PdfColorDeviceRGB
+	Opacity       float64
+}
+
+// CaretAnnotationDef defines a caret annotation, indicating the presence of
+// a text edit, drawn within the rectangle with a lower left corner at (X,Y)
+// and the specified Width and Height.
+type CaretAnnotationDef struct {
+	X       float64
+	Y       float64
+	Width   float64
+	Height  float64
+	Color   *model.PdfColorDeviceRGB
+	Opacity float64
+}
+
+// textMarkupKind represents the type of a text markup annotation.
+type textMarkupKind int
+
+const (
+	textMarkupHighlight textMarkupKind = iota
+	textMarkupUnderline
+	textMarkupStrikeOut
+	textMarkupSquiggly
+)
+
+// CreateHighlightAnnotation creates a highlight annotation object that can
+// be added to the page annotations. The highlighted areas are filled using
+// the multiply blend mode, so that the text stays readable. The color
+// defaults to yellow.
+func CreateHighlightAnnotation(def TextMarkupAnnotationDef) (*model.PdfAnnotation, error) {
+	annot := model.NewPdfAnnotationHighlight()
+	quadPoints, err := def.apply(annot.PdfAnnotation, annot.PdfAnnotationMarkup, textMarkupHighlight)
+	if err != nil {
+		return nil, err
+	}
+	annot.QuadPoints = quadPoints
+	return annot.PdfAnnotation, nil
+}
+
+// CreateUnderlineAnnotation creates an underline annotation object that can
+// be added to the page annotations. The color defaults to black.
+func CreateUnderlineAnnotation(def TextMarkupAnnotationDef) (*model.PdfAnnotation, error) {
+	annot := model.NewPdfAnnotationUnderline()
+	quadPoints, err := def.apply(annot.PdfAnnotation, annot.PdfAnnotationMarkup, textMarkupUnderline)
+	if err != nil {
+		return nil, err
+	}
+	annot.QuadPoints = quadPoints
+	return annot.PdfAnnotation, nil
+}
+
+// CreateStrikeOutAnnotation creates a strikeout annotation object that can
+// be added to the page annotations. The color defaults to black.
+func CreateStrikeOutAnnotation(def TextMarkupAnnotationDef) (*model.PdfAnnotation, error) {
+	annot := model.NewPdfAnnotationStrikeOut()
+	quadPoints, err := def.apply(annot.PdfAnnotation, annot.PdfAnnotationMarkup, textMarkupStrikeOut)
+	if err != nil {
+		return nil, err
+	}
+	annot.QuadPoints = quadPoints
+	return annot.PdfAnnotation, nil
+}
+
+// CreateSquigglyAnnotation creates a squiggly underline annotation object
+// that can be added to the page annotations. The color defaults to black.
+func CreateSquigglyAnnotation(def TextMarkupAnnotationDef) (*model.PdfAnnotation, error) {
+	annot := model.NewPdfAnnotationSquiggly()
+	quadPoints, err := def.apply(annot.PdfAnnotation, annot.PdfAnnotationMarkup, textMarkupSquiggly)
+	if err != nil {
+		return nil, err
+	}
+	annot.QuadPoints = quadPoints
+	return annot.PdfAnnotation, nil
+}
+
+// apply sets the color, opacity, appearance and rectangle of the text
+// markup annotation, and returns its quadrilaterals (QuadPoints).
+func (def TextMarkupAnnotationDef) apply(annot *model.PdfAnnotation, markup *model.PdfAnnotationMarkup, kind textMarkupKind) (*core.PdfObjectArray, error) {
+	if len(def.Rects) == 0 {
+		return nil, errors.New("text markup rectangles not specified")
+	}
+
+	color := def.Color
+	if color == nil {
+		if kind == textMarkupHighlight {
+			color = model.NewPdfColorDeviceRGB(1, 1, 0)
+		} else {
+			color = model.NewPdfColorDeviceRGB(0, 0, 0)
+		}
+	}
+	annot.C = rgbArray(color)
+	if def.Opacity < 1.0 {
+		markup.CA = core.MakeFloat(def.Opacity)
+	}
+
+	// The points of each quadrilateral are ordered as upper left, upper
+	// right, lower left and lower right, as expected by most viewers.
+	var quadPoints []float64
+	for _, r := range def.Rects {
+		r.Normalize()
+		quadPoints = append(quadPoints, r.Llx, r.Ury, r.Urx, r.Ury, r.Llx, r.Lly, r.Urx, r.Lly)
+	}
+
+	blendMode := ""
+	if kind == textMarkupHighlight {
+		blendMode = "Multiply"
+	}
+	ap, bbox, err := annotationAppearance(nil, def.Opacity, blendMode, func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		return def.draw(kind, color, gsName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	annot.Rect = bbox.ToPdfObject()
+	return core.MakeArrayFromFloats(quadPoints), nil
+}
+
+// draw returns the content stream of the text markup appearance and its
+// bounding box.
+func (def TextMarkupAnnotationDef) draw(kind textMarkupKind, color *model.PdfColorDeviceRGB, gsName string) ([]byte, *model.PdfRectangle, error) {
+	cc := contentstream.NewContentCreator()
+	cc.Add_q()
+	if gsName != "" {
+		cc.Add_gs(core.PdfObjectName(gsName))
+	}
+	cc.Add_rg(color.R(), color.G(), color.B())
+	cc.Add_RG(color.R(), color.G(), color.B())
+
+	var bbox *model.PdfRectangle
+	for _, r := range def.Rects {
+		r.Normalize()
+		width, height := r.Width(), r.Height()
+		bbox = unionRect(bbox, &r)
+
+		switch kind {
+		case textMarkupHighlight:
+			cc.Add_re(r.Llx, r.Lly, width, height).Add_f()
+		case textMarkupUnderline:
+			lw := math.Max(height/14, 0.5)
+			cc.Add_w(lw)
+			cc.Add_m(r.Llx, r.Lly+lw).Add_l(r.Urx, r.Lly+lw).Add_S()
+		case textMarkupStrikeOut:
+			lw := math.Max(height/14, 0.5)
+			cc.Add_w(lw)
+			cc.Add_m(r.Llx, r.Lly+height/2).Add_l(r.Urx, r.Lly+height/2).Add_S()
+		case textMarkupSquiggly:
+			lw := math.Max(height/24, 0.5)
+			amplitude := height / 10
+			halfPeriod := height / 8
+			if halfPeriod <= 0 {
+				continue
+			}
+			cc.Add_w(lw)
+			setRoundLineStyle(cc)
+			cc.Add_m(r.Llx, r.Lly+lw)
+			n := int(math.Ceil(width / halfPeriod))
+			for i := 1; i <= n; i++ {
+				y := r.Lly + lw
+				if i%2 == 1 {
+					y += amplitude
+				}
+				cc.Add_l(math.Min(r.Llx+float64(i)*halfPeriod, r.Urx), y)
+			}
+			cc.Add_S()
+		}
+	}
+	cc.Add_Q()
+	return cc.Bytes(), bbox, nil
+}
+
+// CreateInkAnnotation creates an ink annotation object that can be added to
+// the page annotations. The line color defaults to black.
+func CreateInkAnnotation(inkDef InkAnnotationDef) (*model.PdfAnnotation, error) {
+	var points []draw.Point
+	inkList := core.MakeArray()
+	for _, path := range inkDef.Paths {
+		if len(path) == 0 {
+			continue
+		}
+		var coords []float64
+		for _, p := range path {
+			coords = append(coords, p.X, p.Y)
+		}
+		inkList.Append(core.MakeArrayFromFloats(coords))
+		points = append(points, path...)
+	}
+	if len(points) == 0 {
+		return nil, errors.New("ink paths not specified")
+	}
+
+	color := inkDef.LineColor
+	if color == nil {
+		color = model.NewPdfColorDeviceRGB(0, 0, 0)
+	}
+
+	annot := model.NewPdfAnnotationInk()
+	annot.InkList = inkList
+	annot.C = rgbArray(color)
+	bs := model.NewBorderStyle()
+	bs.SetBorderWidth(inkDef.LineWidth)
+	annot.BS = bs.ToPdfObject()
+	if inkDef.Opacity < 1.0 {
+		annot.CA = core.MakeFloat(inkDef.Opacity)
+	}
+
+	ap, bbox, err := annotationAppearance(nil, inkDef.Opacity, "", func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		cc := contentstream.NewContentCreator()
+		cc.Add_q()
+		if gsName != "" {
+			cc.Add_gs(core.PdfObjectName(gsName))
+		}
+		cc.Add_RG(color.R(), color.G(), color.B())
+		cc.Add_w(inkDef.LineWidth)
+		setRoundLineStyle(cc)
+		for _, path := range inkDef.Paths {
+			if len(path) == 0 {
+				continue
+			}
+			cc.Add_m(path[0].X, path[0].Y)
+			if len(path) == 1 {
+				// Draw a dot using the round line cap.
+				cc.Add_l(path[0].X, path[0].Y)
+			}
+			for _, p := range path[1:] {
+				cc.Add_l(p.X, p.Y)
+			}
+			cc.Add_S()
+		}
+		cc.Add_Q()
+		return cc.Bytes(), pointsRect(points, inkDef.LineWidth/2), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	annot.Rect = bbox.ToPdfObject()
+	return annot.PdfAnnotation, nil
+}
+
+// CreatePolyLineAnnotation creates a polyline annotation object that can be
+// added to the page annotations. The line color defaults to black.
+func CreatePolyLineAnnotation(polyDef PolyLineAnnotationDef) (*model.PdfAnnotation, error) {
+	if len(polyDef.Points) < 2 {
+		return nil, errors.New("polyline requires at least 2 points")
+	}
+	color := polyDef.LineColor
+	if color == nil {
+		color = model.NewPdfColorDeviceRGB(0, 0, 0)
+	}
+
+	annot := model.NewPdfAnnotationPolyLine()
+	annot.Vertices = pointsArray(polyDef.Points)
+	annot.LE = core.MakeArray(lineEndingName(polyDef.LineEndingStyle1), lineEndingName(polyDef.LineEndingStyle2))
+	annot.C = rgbArray(color)
+	annot.IC = rgbArray(color)
+	bs := model.NewBorderStyle()
+	bs.SetBorderWidth(polyDef.LineWidth)
+	annot.BS = bs.ToPdfObject()
+	if polyDef.Opacity < 1.0 {
+		annot.CA = core.MakeFloat(polyDef.Opacity)
+	}
+
+	ap, bbox, err := annotationAppearance(nil, polyDef.Opacity, "", func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		return polyDef.draw(color, gsName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	annot.Rect = bbox.ToPdfObject()
+	return annot.PdfAnnotation, nil
+}
+
+// draw returns the content stream of the polyline appearance and its
+// bounding box.
+func (polyDef PolyLineAnnotationDef) draw(color *model.PdfColorDeviceRGB, gsName string) ([]byte, *model.PdfRectangle, error) {
+	lw := polyDef.LineWidth
+	size := lineEndingSize(lw)
+
+	points := make([]draw.Point, len(polyDef.Points))
+	copy(points, polyDef.Points)
+	last := len(points) - 1
+
+	// Shorten the line under the arrows, so that it does not stick out of
+	// their tips.
+	if polyDef.LineEndingStyle1 == draw.LineEndingStyleArrow {
+		points[0] = movePoint(points[0], points[1], size)
+	}
+	if polyDef.LineEndingStyle2 == draw.LineEndingStyleArrow {
+		points[last] = movePoint(points[last], points[last-1], size)
+	}
+
+	cc := contentstream.NewContentCreator()
+	cc.Add_q()
+	if gsName != "" {
+		cc.Add_gs(core.PdfObjectName(gsName))
+	}
+	cc.Add_RG(color.R(), color.G(), color.B())
+	cc.Add_rg(color.R(), color.G(), color.B())
+	cc.Add_w(lw)
+	cc.Add_m(points[0].X, points[0].Y)
+	for _, p := range points[1:] {
+		cc.Add_l(p.X, p.Y)
+	}
+	cc.Add_S()
+
+	drawLineEnding(cc, polyDef.LineEndingStyle1, polyDef.Points[0], polyDef.Points[1], size)
+	drawLineEnding(cc, polyDef.LineEndingStyle2, polyDef.Points[last], polyDef.Points[last-1], size)
+	cc.Add_Q()
+
+	margin := lw / 2
+	if polyDef.LineEndingStyle1 != draw.LineEndingStyleNone || polyDef.LineEndingStyle2 != draw.LineEndingStyleNone {
+		margin = math.Max(margin, size/2+lw)
+	}
+	return cc.Bytes(), pointsRect(polyDef.Points, margin), nil
+}
+
+// CreatePolygonAnnotation creates a polygon annotation object that can be
+// added to the page annotations.
+func CreatePolygonAnnotation(polyDef PolygonAnnotationDef) (*model.PdfAnnotation, error) {
+	if len(polyDef.Vertices) < 3 {
+		return nil, errors.New("polygon requires at least 3 vertices")
+	}
+
+	annot := model.NewPdfAnnotationPolygon()
+	annot.Vertices = pointsArray(polyDef.Vertices)
+	if polyDef.BorderEnabled {
+		annot.C = rgbArray(polyDef.BorderColor)
+		bs := model.NewBorderStyle()
+		bs.SetBorderWidth(polyDef.BorderWidth)
+		annot.BS = bs.ToPdfObject()
+	}
+	if polyDef.FillEnabled {
+		annot.IC = rgbArray(polyDef.FillColor)
+	}
+	if polyDef.Opacity < 1.0 {
+		annot.CA = core.MakeFloat(polyDef.Opacity)
+	}
+
+	ap, bbox, err := annotationAppearance(nil, polyDef.Opacity, "", func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		polygon := draw.Polygon{
+			Points:        [][]draw.Point{polyDef.Vertices},
+			FillEnabled:   polyDef.FillEnabled,
+			FillColor:     polyDef.FillColor,
+			BorderEnabled: polyDef.BorderEnabled,
+			BorderColor:   polyDef.BorderColor,
+			BorderWidth:   polyDef.BorderWidth,
+		}
+		content, _, err := polygon.Draw(gsName)
+		if err != nil {
+			return nil, nil, err
+		}
+		margin := 0.0
+		if polyDef.BorderEnabled {
+			margin = polyDef.BorderWidth / 2
+		}
+		return content, pointsRect(polyDef.Vertices, margin), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	annot.Rect = bbox.ToPdfObject()
+	return annot.PdfAnnotation, nil
+}
+
+// CreateCaretAnnotation creates a caret annotation object that can be added
+// to the page annotations. The color defaults to blue.
+func CreateCaretAnnotation(caretDef CaretAnnotationDef) (*model.PdfAnnotation, error) {
+	if caretDef.Width <= 0 || caretDef.Height <= 0 {
+		return nil, errors.New("invalid caret size")
+	}
+	color := caretDef.Color
+	if color == nil {
+		color = model.NewPdfColorDeviceRGB(0, 0, 1)
+	}
+
+	annot := model.NewPdfAnnotationCaret()
+	annot.Sy = core.MakeName("None")
+	annot.C = rgbArray(color)
+	if caretDef.Opacity < 1.0 {
+		annot.CA = core.MakeFloat(caretDef.Opacity)
+	}
+
+	ap, bbox, err := annotationAppearance(nil, caretDef.Opacity, "", func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		x, y, w, h := caretDef.X, caretDef.Y, caretDef.Width, caretDef.Height
+		cc := contentstream.NewContentCreator()
+		cc.Add_q()
+		if gsName != "" {
+			cc.Add_gs(core.PdfObjectName(gsName))
+		}
+		cc.Add_rg(color.R(), color.G(), color.B())
+		drawCaret(cc, x, y, w, h)
+		cc.Add_f()
+		cc.Add_Q()
+		return cc.Bytes(), &model.PdfRectangle{Llx: x, Lly: y, Urx: x + w, Ury: y + h}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	annot.Rect = bbox.ToPdfObject()
+	return annot.PdfAnnotation, nil
+}
+
+// drawCaret adds the path of a caret with curved sides, filling the
+// specified rectangle.
+func drawCaret(cc *contentstream.ContentCreator, x, y, w, h float64) {
+	cc.Add_m(x, y)
+	cc.Add_c(x+0.45*w, y+0.2*h, x+0.5*w, y+0.6*h, x+0.5*w, y+h)
+	cc.Add_c(x+0.5*w, y+0.6*h, x+0.55*w, y+0.2*h, x+w, y)
+	cc.Add_h()
+}
+
+// setRoundLineStyle sets the round line cap and line join styles.
+func setRoundLineStyle(cc *contentstream.ContentCreator) {
+	cc.AddOperand(contentstream.ContentStreamOperation{Operand: "J", Params: []core.PdfObject{core.MakeInteger(1)}})
+	cc.AddOperand(contentstream.ContentStreamOperation{Operand: "j", Params: []core.PdfObject{core.MakeInteger(1)}})
+}
+
+// annotationAppearance returns the appearance dictionary of an annotation,
+// whose normal appearance is a form XObject drawn by `draw` within the
+// bounding box it returns. When the opacity is lower than 1 or the blend
+// mode is set, they are applied using the gs1 graphics state, whose name
+// is passed to `draw`. The form resources default to empty resources.
+func annotationAppearance(resources *model.PdfPageResources, opacity float64, blendMode string, draw func(gsName string) ([]byte, *model.PdfRectangle, error)) (*core.PdfObjectDictionary, *model.PdfRectangle, error) {
+	xform := model.NewXObjectForm()
+	if resources == nil {
+		resources = model.NewPdfPageResources()
+	}
+	xform.Resources = resources
+
+	gsName := ""
+	if opacity < 1.0 || blendMode != "" {
+		gs := core.MakeDict()
+		if opacity < 1.0 {
+			gs.Set("ca", core.MakeFloat(opacity))
+			gs.Set("CA", core.MakeFloat(opacity))
+		}
+		if blendMode != "" {
+			gs.Set("BM", core.MakeName(blendMode))
+		}
+		if err := resources.AddExtGState("gs1", gs); err != nil {
+			common.Log.Debug("Unable to add extgstate gs1")
+			return nil, nil, err
+		}
+		gsName = "gs1"
+	}
+
+	content, bbox, err := draw(gsName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := xform.SetContentStream(content, nil); err != nil {
+		return nil, nil, err
+	}
+	xform.BBox = bbox.ToPdfObject()
+
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+	return apDict, bbox, nil
+}
+
+// rgbArray returns the array of the components of the color, or an empty
+// array (transparent) if the color is nil.
+func rgbArray(color *model.PdfColorDeviceRGB) *core.PdfObjectArray {
+	if color == nil {
+		return core.MakeArray()
+	}
+	return core.MakeArrayFromFloats([]float64{color.R(), color.G(), color.B()})
+}
+
+// pointsArray returns the flat array of the coordinates of the points.
+func pointsArray(points []draw.Point) *core.PdfObjectArray {
+	coords := make([]float64, 0, 2*len(points))
+	for _, p := range points {
+		coords = append(coords, p.X, p.Y)
+	}
+	return core.MakeArrayFromFloats(coords)
+}
+
+// pointsRect returns the bounding box of the points, expanded by `margin`
+// on all sides.
+func pointsRect(points []draw.Point, margin float64) *model.PdfRectangle {
+	r := &model.PdfRectangle{Llx: points[0].X, Lly: points[0].Y, Urx: points[0].X, Ury: points[0].Y}
+	for _, p := range points[1:] {
+		r.Llx = math.Min(r.Llx, p.X)
+		r.Lly = math.Min(r.Lly, p.Y)
+		r.Urx = math.Max(r.Urx, p.X)
+		r.Ury = math.Max(r.Ury, p.Y)
+	}
+	r.Llx -= margin
+	r.Lly -= margin
+	r.Urx += margin
+	r.Ury += margin
+	return r
+}
+
+// unionRect returns the smallest rectangle containing both rectangles. The
+// first rectangle can be nil.
+func unionRect(a, b *model.PdfRectangle) *model.PdfRectangle {
+	if a == nil {
+		r := *b
+		return &r
+	}
+	return &model.PdfRectangle{
+		Llx: math.Min(a.Llx, b.Llx),
+		Lly: math.Min(a.Lly, b.Lly),
+		Urx: math.Max(a.Urx, b.Urx),
+		Ury: math.Max(a.Ury, b.Ury),
+	}
+}
+
+// lineEndingName returns the name of the line ending style, as used in the
+// LE entry of line and polyline annotations.
+func lineEndingName(style draw.LineEndingStyle) *core.PdfObjectName {
+	switch style {
+	case draw.LineEndingStyleArrow:
+		return core.MakeName("ClosedArrow")
+	case draw.LineEndingStyleButt:
+		return core.MakeName("Butt")
+	}
+	return core.MakeName("None")
+}
+
+// lineEndingSize returns the length of the line endings drawn for lines of
+// the specified width.
+func lineEndingSize(lineWidth float64) float64 {
+	return math.Max(3*lineWidth, 6)
+}
+
+// movePoint returns the point at distance `d` from `p`, in the direction of
+// `towards`.
+func movePoint(p, towards draw.Point, d float64) draw.Point {
+	dx, dy := towards.X-p.X, towards.Y-p.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return p
+	}
+	d = math.Min(d, length)
+	return draw.Point{X: p.X + dx/length*d, Y: p.Y + dy/length*d}
+}
+
+// drawLineEnding draws the line ending at `end` of the line segment going
+// from `prev` to `end`. Arrows are filled and butts are stroked using the
+// current colors and line width.
+func drawLineEnding(cc *contentstream.ContentCreator, style draw.LineEndingStyle, end, prev draw.Point, size float64) {
+	dx, dy := end.X-prev.X, end.Y-prev.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	// Unit vectors along the segment and perpendicular to it.
+	ux, uy := dx/length, dy/length
+	px, py := -uy, ux
+
+	switch style {
+	case draw.LineEndingStyleArrow:
+		baseX, baseY := end.X-ux*size, end.Y-uy*size
+		cc.Add_m(end.X, end.Y)
+		cc.Add_l(baseX+px*size/2, baseY+py*size/2)
+		cc.Add_l(baseX-px*size/2, baseY-py*size/2)
+		cc.Add_h().Add_f()
+	case draw.LineEndingStyleButt:
+		cc.Add_m(end.X+px*size/2, end.Y+py*size/2)
+		cc.Add_l(end.X-px*size/2, end.Y-py*size/2)
+		cc.Add_S()
+	}
+}
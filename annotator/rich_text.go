@@ -0,0 +1,474 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package annotator
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/internal/annotutil"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// RichTextAlignment represents the horizontal alignment of a paragraph of
+// rich text.
+type RichTextAlignment int
+
+// Rich text paragraph alignments.
+const (
+	RichTextAlignLeft RichTextAlignment = iota
+	RichTextAlignCenter
+	RichTextAlignRight
+	RichTextAlignJustify
+)
+
+// RichTextStyle represents the text style of a span of rich text.
+type RichTextStyle struct {
+	// FontFamily is the name of the font family (e.g. Helvetica).
+	FontFamily string
+
+	// FontSize is the font size, in points.
+	FontSize float64
+
+	// Bold specifies whether the text is bold.
+	Bold bool
+
+	// Italic specifies whether the text is italic.
+	Italic bool
+
+	// Color is the color of the text.
+	Color *model.PdfColorDeviceRGB
+}
+
+// DefaultRichTextStyle returns the default style of rich text: black
+// Helvetica 12pt.
+func DefaultRichTextStyle() RichTextStyle {
+	return RichTextStyle{
+		FontFamily: "Helvetica",
+		FontSize:   12,
+		Color:      model.NewPdfColorDeviceRGB(0, 0, 0),
+	}
+}
+
+// RichTextSpan represents a run of text sharing the same style.
+type RichTextSpan struct {
+	Text  string
+	Style RichTextStyle
+}
+
+// RichTextParagraph represents a paragraph of rich text.
+type RichTextParagraph struct {
+	Alignment RichTextAlignment
+	Spans     []*RichTextSpan
+}
+
+// Text returns the plain text of the paragraph.
+func (p *RichTextParagraph) Text() string {
+	var sb strings.Builder
+	for _, span := range p.Spans {
+		sb.WriteString(span.Text)
+	}
+	return sb.String()
+}
+
+// RichText represents rich text content, such as the rich value (RV) of
+// text fields and the rich content (RC) of markup annotations.
+type RichText struct {
+	Paragraphs []*RichTextParagraph
+}
+
+// Text returns the plain text of the rich text, with the paragraphs
+// separated by line breaks.
+func (rt *RichText) Text() string {
+	lines := make([]string, len(rt.Paragraphs))
+	for i, p := range rt.Paragraphs {
+		lines[i] = p.Text()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RichTextRenderer lays out rich text within an area of the specified size.
+// Returns the content stream drawing the text, with the origin at the
+// bottom left corner of the area, along with the resources it uses.
+// The creator package provides an implementation based on styled paragraphs.
+type RichTextRenderer interface {
+	RenderRichText(rt *RichText, width, height float64) ([]byte, *model.PdfPageResources, error)
+}
+
+// richTextState represents the inherited style of the XHTML elements.
+type richTextState struct {
+	style RichTextStyle
+	align RichTextAlignment
+
+	// paragraphs is the number of paragraphs preceding the element.
+	paragraphs int
+}
+
+// richTextBlocks are the XHTML elements starting a new paragraph.
+var richTextBlocks = map[string]bool{
+	"p":   true,
+	"div": true,
+}
+
+// ParseRichText parses rich text content: an XHTML body element whose
+// paragraphs and spans are styled using a subset of CSS (font, font-family,
+// font-size, font-weight, font-style, color and text-align). The `defaultStyle`
+// CSS declarations (e.g. the DS entry of fields and annotations) are applied
+// to the default rich text style.
+func ParseRichText(content string, defaultStyle string) (*RichText, error) {
+	state := richTextState{style: DefaultRichTextStyle()}
+	state.applyCSS(defaultStyle)
+
+	dec := xml.NewDecoder(strings.NewReader(content))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	rt := &RichText{}
+	var para *RichTextParagraph
+	stack := []richTextState{state}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cur := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			switch name {
+			case "b", "strong":
+				cur.style.Bold = true
+			case "i", "em":
+				cur.style.Italic = true
+			}
+			for _, attr := range t.Attr {
+				if strings.EqualFold(attr.Name.Local, "style") {
+					cur.applyCSS(attr.Value)
+				}
+			}
+			if richTextBlocks[name] {
+				para = nil
+			}
+			cur.paragraphs = len(rt.Paragraphs)
+			if name == "br" {
+				if para == nil {
+					para = rt.newParagraph(cur.align)
+				}
+				para.appendText("\n", cur.style)
+			}
+			stack = append(stack, cur)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			if richTextBlocks[strings.ToLower(t.Name.Local)] {
+				if para == nil && len(rt.Paragraphs) == cur.paragraphs {
+					// Empty paragraphs result in blank lines.
+					rt.newParagraph(cur.align)
+				}
+				para = nil
+			}
+		case xml.CharData:
+			text := collapseWhitespace(string(t))
+			if para == nil {
+				text = strings.TrimLeft(text, " ")
+				if text == "" {
+					continue
+				}
+				para = rt.newParagraph(cur.align)
+			}
+			para.appendText(text, cur.style)
+		}
+	}
+
+	for _, p := range rt.Paragraphs {
+		p.trimTrailingSpace()
+	}
+	return rt, nil
+}
+
+// newParagraph appends a new paragraph to the rich text.
+func (rt *RichText) newParagraph(align RichTextAlignment) *RichTextParagraph {
+	p := &RichTextParagraph{Alignment: align}
+	rt.Paragraphs = append(rt.Paragraphs, p)
+	return p
+}
+
+// appendText appends text to the paragraph, merging it with the last span
+// if it has the same style.
+func (p *RichTextParagraph) appendText(text string, style RichTextStyle) {
+	if text == "" {
+		return
+	}
+	if n := len(p.Spans); n > 0 && p.Spans[n-1].Style.equals(style) {
+		p.Spans[n-1].Text += text
+		return
+	}
+	p.Spans = append(p.Spans, &RichTextSpan{Text: text, Style: style})
+}
+
+// trimTrailingSpace removes the trailing space of the paragraph.
+func (p *RichTextParagraph) trimTrailingSpace() {
+	for n := len(p.Spans); n > 0; n = len(p.Spans) {
+		last := p.Spans[n-1]
+		last.Text = strings.TrimRight(last.Text, " ")
+		if last.Text != "" {
+			return
+		}
+		p.Spans = p.Spans[:n-1]
+	}
+}
+
+// equals returns true if the styles are identical.
+func (s RichTextStyle) equals(o RichTextStyle) bool {
+	if s.FontFamily != o.FontFamily || s.FontSize != o.FontSize || s.Bold != o.Bold || s.Italic != o.Italic {
+		return false
+	}
+	if s.Color == nil || o.Color == nil {
+		return s.Color == o.Color
+	}
+	return *s.Color == *o.Color
+}
+
+// collapseWhitespace replaces the sequences of whitespace characters of the
+// text with a single space.
+func collapseWhitespace(text string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range text {
+		switch r {
+		case ' ', '\t', '\r', '\n':
+			if !space {
+				sb.WriteRune(' ')
+			}
+			space = true
+		default:
+			sb.WriteRune(r)
+			space = false
+		}
+	}
+	return sb.String()
+}
+
+// applyCSS applies the CSS declarations of `css` to the state.
+func (s *richTextState) applyCSS(css string) {
+	for _, decl := range strings.Split(css, ";") {
+		i := strings.Index(decl, ":")
+		if i < 0 {
+			continue
+		}
+		prop := strings.ToLower(strings.TrimSpace(decl[:i]))
+		value := strings.TrimSpace(decl[i+1:])
+
+		switch prop {
+		case "font":
+			s.applyFontShorthand(value)
+		case "font-family":
+			if family := parseFontFamily(value); family != "" {
+				s.style.FontFamily = family
+			}
+		case "font-size":
+			if size, ok := parseFontSize(value, s.style.FontSize); ok {
+				s.style.FontSize = size
+			}
+		case "font-weight":
+			s.style.Bold = isBoldWeight(value)
+		case "font-style":
+			s.style.Italic = isItalicStyle(value)
+		case "color":
+			if color, ok := parseCSSColor(value); ok {
+				s.style.Color = color
+			}
+		case "text-align":
+			switch strings.ToLower(value) {
+			case "left", "start":
+				s.align = RichTextAlignLeft
+			case "center":
+				s.align = RichTextAlignCenter
+			case "right", "end":
+				s.align = RichTextAlignRight
+			case "justify":
+				s.align = RichTextAlignJustify
+			}
+		}
+	}
+}
+
+// applyFontShorthand applies the value of the CSS font shorthand property
+// (e.g. "italic bold 12pt Helvetica,sans-serif").
+func (s *richTextState) applyFontShorthand(value string) {
+	fields := strings.Fields(value)
+	for i, field := range fields {
+		lower := strings.ToLower(field)
+		switch {
+		case lower == "normal":
+			continue
+		case isItalicStyle(lower):
+			s.style.Italic = true
+		case isBoldWeight(lower):
+			s.style.Bold = true
+		default:
+			size := field
+			if j := strings.Index(size, "/"); j >= 0 {
+				size = size[:j]
+			}
+			if fontSize, ok := parseFontSize(size, s.style.FontSize); ok {
+				s.style.FontSize = fontSize
+				if family := parseFontFamily(strings.Join(fields[i+1:], " ")); family != "" {
+					s.style.FontFamily = family
+				}
+				return
+			}
+		}
+	}
+}
+
+// parseFontFamily returns the first font family of a CSS font family list.
+func parseFontFamily(value string) string {
+	family := strings.Split(value, ",")[0]
+	return strings.Trim(strings.TrimSpace(family), "'\"")
+}
+
+// parseFontSize parses a CSS font size. Pixels are treated as points and
+// em/% units are relative to the current font size.
+func parseFontSize(value string, current float64) (float64, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(value, "pt"), strings.HasSuffix(value, "px"):
+		value = value[:len(value)-2]
+	case strings.HasSuffix(value, "em"):
+		value = value[:len(value)-2]
+		scale = current
+	case strings.HasSuffix(value, "%"):
+		value = value[:len(value)-1]
+		scale = current / 100
+	}
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil || size <= 0 {
+		return 0, false
+	}
+	return size * scale, true
+}
+
+// isBoldWeight returns true if the CSS font weight is bold.
+func isBoldWeight(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "bold", "bolder":
+		return true
+	}
+	weight, err := strconv.Atoi(strings.TrimSpace(value))
+	return err == nil && weight >= 600
+}
+
+// isItalicStyle returns true if the CSS font style is italic.
+func isItalicStyle(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "italic", "oblique":
+		return true
+	}
+	return false
+}
+
+// cssColors are the CSS named colors supported in rich text.
+var cssColors = map[string][3]float64{
+	"black":   {0, 0, 0},
+	"white":   {1, 1, 1},
+	"red":     {1, 0, 0},
+	"green":   {0, 0.5, 0},
+	"lime":    {0, 1, 0},
+	"blue":    {0, 0, 1},
+	"yellow":  {1, 1, 0},
+	"cyan":    {0, 1, 1},
+	"magenta": {1, 0, 1},
+	"gray":    {0.5, 0.5, 0.5},
+	"grey":    {0.5, 0.5, 0.5},
+	"silver":  {0.75, 0.75, 0.75},
+	"maroon":  {0.5, 0, 0},
+	"navy":    {0, 0, 0.5},
+	"olive":   {0.5, 0.5, 0},
+	"purple":  {0.5, 0, 0.5},
+	"teal":    {0, 0.5, 0.5},
+	"orange":  {1, 0.65, 0},
+}
+
+// parseCSSColor parses a CSS color: #RGB, #RRGGBB, rgb(r,g,b) or a named
+// color.
+func parseCSSColor(value string) (*model.PdfColorDeviceRGB, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if c, ok := cssColors[value]; ok {
+		return model.NewPdfColorDeviceRGB(c[0], c[1], c[2]), true
+	}
+
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return nil, false
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, false
+		}
+		return model.NewPdfColorDeviceRGB(float64(v>>16)/255, float64(v>>8&0xff)/255, float64(v&0xff)/255), true
+	}
+
+	if strings.HasPrefix(value, "rgb(") && strings.HasSuffix(value, ")") {
+		parts := strings.Split(value[4:len(value)-1], ",")
+		if len(parts) != 3 {
+			return nil, false
+		}
+		var c [3]float64
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			scale := 255.0
+			if strings.HasSuffix(part, "%") {
+				part = part[:len(part)-1]
+				scale = 100
+			}
+			v, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, false
+			}
+			c[i] = annotutil.ClampUnit(v / scale)
+		}
+		return model.NewPdfColorDeviceRGB(c[0], c[1], c[2]), true
+	}
+	return nil, false
+}
+
+// richTextContent returns the rich text content of the object, which is
+// either a text string or a text stream.
+func richTextContent(obj core.PdfObject) (string, bool) {
+	switch t := core.TraceToDirectObject(obj).(type) {
+	case *core.PdfObjectString:
+		return t.Decoded(), true
+	case *core.PdfObjectStream:
+		data, err := core.DecodeStream(t)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+	return "", false
+}
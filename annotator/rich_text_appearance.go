@@ -0,0 +1,210 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package annotator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/unidoc/unipdf/v3/contentstream"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// fieldRichText returns the parsed rich value (RV) of the text field, if the
+// field has the RichText flag and a rich value. The default style (DS) of
+// the field applies, falling back to the font size of its default
+// appearance (DA).
+func fieldRichText(field *model.PdfFieldText) (*RichText, bool) {
+	if !field.Flags().Has(model.FieldFlagRichText) || field.RV == nil {
+		return nil, false
+	}
+	content, ok := richTextContent(field.RV)
+	if !ok || content == "" {
+		return nil, false
+	}
+
+	var ds string
+	if field.DS != nil {
+		ds = field.DS.Decoded()
+	} else if size, ok := daFontSize(_ece(field.PdfField)); ok {
+		ds = fmt.Sprintf("font-size:%gpt", size)
+	}
+
+	rt, err := ParseRichText(content, ds)
+	if err != nil {
+		return nil, false
+	}
+	return rt, true
+}
+
+// daFontSize returns the font size set by the Tf operator of the default
+// appearance string. Returns false if not set, or if set to 0 (auto size).
+func daFontSize(da string) (float64, bool) {
+	ops, err := contentstream.NewContentStreamParser(da).Parse()
+	if err != nil {
+		return 0, false
+	}
+	for _, op := range *ops {
+		if op.Operand != "Tf" || len(op.Params) != 2 {
+			continue
+		}
+		size, err := core.GetNumberAsFloat(op.Params[1])
+		if err == nil && size > 0 {
+			return size, true
+		}
+	}
+	return 0, false
+}
+
+// richTextForm returns the form XObject drawing the rich text within an
+// area of the specified size, inset by `inset` on all sides. The content
+// drawn by `background` is placed under the text.
+func richTextForm(rt *RichText, renderer RichTextRenderer, width, height, inset float64, tag string, background func(cc *contentstream.ContentCreator)) (*model.XObjectForm, error) {
+	innerWidth, innerHeight := width-2*inset, height-2*inset
+	if innerWidth <= 0 || innerHeight <= 0 {
+		return nil, errors.New("rich text area too small")
+	}
+
+	content, resources, err := renderer.RenderRichText(rt, innerWidth, innerHeight)
+	if err != nil {
+		return nil, err
+	}
+	if resources == nil {
+		resources = model.NewPdfPageResources()
+	}
+
+	cc := contentstream.NewContentCreator()
+	if background != nil {
+		background(cc)
+	}
+	if tag != "" {
+		cc.Add_BMC(*core.MakeName(tag))
+	}
+	cc.Add_q()
+	cc.Add_re(inset, inset, innerWidth, innerHeight).Add_W().Add_n()
+	cc.Translate(inset, inset)
+
+	var buf bytes.Buffer
+	buf.Write(cc.Bytes())
+	buf.WriteString("\n")
+	buf.Write(content)
+	buf.WriteString("\nQ\n")
+	if tag != "" {
+		buf.WriteString("EMC\n")
+	}
+
+	xform := model.NewXObjectForm()
+	xform.Resources = resources
+	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, width, height})
+	if err := xform.SetContentStream(buf.Bytes(), _faef()); err != nil {
+		return nil, err
+	}
+	return xform, nil
+}
+
+// genFieldRichTextAppearance generates the appearance of a text field
+// displaying its rich value.
+func genFieldRichTextAppearance(wa *model.PdfAnnotationWidget, field *model.PdfFieldText, rt *RichText, renderer RichTextRenderer, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	array, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := rect.Width(), rect.Height()
+
+	if mk, ok := core.GetDict(wa.MK); ok {
+		bs, _ := core.GetDict(wa.BS)
+		if err := style.applyAppearanceCharacteristics(mk, bs, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	xform, err := richTextForm(rt, renderer, width, height, 1+style.BorderSize, "Tx", func(cc *contentstream.ContentCreator) {
+		if style.BorderSize > 0 {
+			_aggd(cc, style, width, height)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+	return apDict, nil
+}
+
+// GenerateFreeTextRichAppearance generates the appearance of a free text
+// annotation displaying its rich content (RC), laid out by `renderer`. The
+// default style (DS) of the annotation applies. The interior color (C) of
+// the annotation fills its background and its border is drawn using the
+// border style (BS) width. Returns the appearance dictionary, which is also
+// set as the AP entry of the annotation.
+func GenerateFreeTextRichAppearance(annot *model.PdfAnnotationFreeText, renderer RichTextRenderer) (*core.PdfObjectDictionary, error) {
+	if annot == nil || renderer == nil {
+		return nil, errors.New("annotation and renderer required")
+	}
+	content, ok := richTextContent(annot.RC)
+	if !ok {
+		return nil, errors.New("annotation does not have rich content")
+	}
+	var ds string
+	if s, ok := core.GetString(annot.DS); ok {
+		ds = s.Decoded()
+	}
+	rt, err := ParseRichText(content, ds)
+	if err != nil {
+		return nil, err
+	}
+
+	array, ok := core.GetArray(annot.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := rect.Width(), rect.Height()
+
+	borderWidth := 1.0
+	if bs, ok := core.GetDict(annot.BS); ok {
+		if w, err := core.GetNumberAsFloat(bs.Get("W")); err == nil {
+			borderWidth = w
+		}
+	}
+	var fill []float64
+	if c, ok := core.GetArray(annot.C); ok {
+		fill, _ = c.ToFloat64Array()
+	}
+
+	xform, err := richTextForm(rt, renderer, width, height, borderWidth+1, "", func(cc *contentstream.ContentCreator) {
+		if len(fill) == 3 {
+			cc.Add_q().Add_rg(fill[0], fill[1], fill[2])
+			cc.Add_re(0, 0, width, height).Add_f().Add_Q()
+		}
+		if borderWidth > 0 {
+			cc.Add_q().Add_w(borderWidth).Add_RG(0, 0, 0)
+			cc.Add_re(borderWidth/2, borderWidth/2, width-borderWidth, height-borderWidth).Add_S().Add_Q()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+	annot.AP = apDict
+	return apDict, nil
+}
@@ -0,0 +1,187 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package annotator
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/unidoc/unipdf/v3/contentstream"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// StampName represents the name of a rubber stamp annotation icon.
+type StampName string
+
+// Standard rubber stamp names.
+const (
+	StampApproved            StampName = "Approved"
+	StampExperimental        StampName = "Experimental"
+	StampNotApproved         StampName = "NotApproved"
+	StampAsIs                StampName = "AsIs"
+	StampExpired             StampName = "Expired"
+	StampNotForPublicRelease StampName = "NotForPublicRelease"
+	StampConfidential        StampName = "Confidential"
+	StampFinal               StampName = "Final"
+	StampSold                StampName = "Sold"
+	StampDepartmental        StampName = "Departmental"
+	StampForComment          StampName = "ForComment"
+	StampTopSecret           StampName = "TopSecret"
+	StampDraft               StampName = "Draft"
+	StampForPublicRelease    StampName = "ForPublicRelease"
+)
+
+// Label returns the text displayed by the stamp, i.e. the words of its name
+// in upper case (e.g. NOT APPROVED for NotApproved).
+func (name StampName) Label() string {
+	var sb strings.Builder
+	runes := []rune(string(name))
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && !unicode.IsUpper(runes[i-1]) {
+			sb.WriteRune(' ')
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
+
+// defaultColor returns the color of the stamp: green for approval stamps,
+// red for rejection and restriction stamps and blue for the others.
+func (name StampName) defaultColor() *model.PdfColorDeviceRGB {
+	switch name {
+	case StampApproved, StampFinal, StampSold, StampForPublicRelease:
+		return model.NewPdfColorDeviceRGB(0.09, 0.45, 0.16)
+	case StampNotApproved, StampExpired, StampConfidential, StampTopSecret, StampNotForPublicRelease:
+		return model.NewPdfColorDeviceRGB(0.75, 0.09, 0.09)
+	}
+	return model.NewPdfColorDeviceRGB(0.13, 0.25, 0.6)
+}
+
+// StampAnnotationDef defines a rubber stamp annotation displaying the label
+// of its name (e.g. APPROVED) within a rounded frame, in the rectangle with a
+// lower left corner at (X,Y) and the specified Width and Height. If the Width
+// or the Height is not set, the stamp is sized to fit its label.
+type StampAnnotationDef struct {
+	X       float64
+	Y       float64
+	Width   float64
+	Height  float64
+	Name    StampName
+	Color   *model.PdfColorDeviceRGB
+	Opacity float64
+}
+
+// CreateStampAnnotation creates a rubber stamp annotation object that can be
+// added to the page annotations. The name defaults to Draft, and the color
+// depends on the name.
+func CreateStampAnnotation(stampDef StampAnnotationDef) (*model.PdfAnnotation, error) {
+	name := stampDef.Name
+	if name == "" {
+		name = StampDraft
+	}
+	color := stampDef.Color
+	if color == nil {
+		color = name.defaultColor()
+	}
+	font, err := model.NewStandard14Font(model.HelveticaBoldName)
+	if err != nil {
+		return nil, err
+	}
+
+	label := name.Label()
+	width, height := stampDef.Width, stampDef.Height
+	if width <= 0 || height <= 0 {
+		height = 32
+		width = textWidth(label, font, 0.55*height) + height
+	}
+
+	annot := model.NewPdfAnnotationStamp()
+	annot.Name = core.MakeName(string(name))
+	annot.C = rgbArray(color)
+	if stampDef.Opacity < 1.0 {
+		annot.CA = core.MakeFloat(stampDef.Opacity)
+	}
+
+	resources := model.NewPdfPageResources()
+	if err := resources.SetFontByName("HeBo", font.ToPdfObject()); err != nil {
+		return nil, err
+	}
+	ap, bbox, err := annotationAppearance(resources, stampDef.Opacity, "", func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		x, y := stampDef.X, stampDef.Y
+		lw := height / 15
+
+		cc := contentstream.NewContentCreator()
+		cc.Add_q()
+		if gsName != "" {
+			cc.Add_gs(core.PdfObjectName(gsName))
+		}
+		cc.Add_RG(color.R(), color.G(), color.B()).Add_rg(color.R(), color.G(), color.B())
+		cc.Add_w(lw)
+		drawRoundedRect(cc, x+lw/2, y+lw/2, width-lw, height-lw, height/5)
+		cc.Add_S()
+
+		padding := height / 4
+		fontSize := 0.55 * height
+		if labelWidth := textWidth(label, font, 1); labelWidth > 0 {
+			fontSize = math.Min(fontSize, (width-2*padding)/labelWidth)
+		}
+		drawCenteredText(cc, font, "HeBo", fontSize, label, x+width/2, y+(height-capHeight(font, fontSize))/2)
+		cc.Add_Q()
+		return cc.Bytes(), &model.PdfRectangle{Llx: x, Lly: y, Urx: x + width, Ury: y + height}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	annot.Rect = bbox.ToPdfObject()
+	return annot.PdfAnnotation, nil
+}
+
+// drawRoundedRect adds the path of a rectangle with rounded corners of the
+// specified radius.
+func drawRoundedRect(cc *contentstream.ContentCreator, x, y, width, height, radius float64) {
+	radius = math.Min(radius, math.Min(width, height)/2)
+	// Distance of the control points approximating quarter circles.
+	k := radius * 0.5523
+	cc.Add_m(x+radius, y)
+	cc.Add_l(x+width-radius, y)
+	cc.Add_c(x+width-radius+k, y, x+width, y+radius-k, x+width, y+radius)
+	cc.Add_l(x+width, y+height-radius)
+	cc.Add_c(x+width, y+height-radius+k, x+width-radius+k, y+height, x+width-radius, y+height)
+	cc.Add_l(x+radius, y+height)
+	cc.Add_c(x+radius-k, y+height, x, y+height-radius+k, x, y+height-radius)
+	cc.Add_l(x, y+radius)
+	cc.Add_c(x, y+radius-k, x+radius-k, y, x+radius, y)
+	cc.Add_h()
+}
+
+// drawCenteredText draws the text horizontally centered on `cx`, on the
+// baseline `y`, using the current fill color.
+func drawCenteredText(cc *contentstream.ContentCreator, font *model.PdfFont, fontName string, fontSize float64, text string, cx, y float64) {
+	encoded, _ := font.StringToCharcodeBytes(text)
+	cc.Add_BT()
+	cc.Add_Tf(core.PdfObjectName(fontName), fontSize)
+	cc.Add_Td(cx-textWidth(text, font, fontSize)/2, y)
+	cc.Add_Tj(*core.MakeStringFromBytes(encoded))
+	cc.Add_ET()
+}
+
+// capHeight returns the height of the capital letters of the font at the
+// specified size.
+func capHeight(font *model.PdfFont, fontSize float64) float64 {
+	if desc, err := font.GetFontDescriptor(); err == nil && desc != nil {
+		if h, err := core.GetNumberAsFloat(desc.CapHeight); err == nil && h > 0 {
+			return h * fontSize / 1000
+		}
+	}
+	return 0.7 * fontSize
+}
@@ -0,0 +1,183 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package annotator
+
+import (
+	"github.com/unidoc/unipdf/v3/contentstream"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// TextIcon represents the name of the icon of a text (sticky note)
+// annotation.
+type TextIcon string
+
+// Standard text annotation icons.
+const (
+	TextIconComment      TextIcon = "Comment"
+	TextIconKey          TextIcon = "Key"
+	TextIconNote         TextIcon = "Note"
+	TextIconHelp         TextIcon = "Help"
+	TextIconNewParagraph TextIcon = "NewParagraph"
+	TextIconParagraph    TextIcon = "Paragraph"
+	TextIconInsert       TextIcon = "Insert"
+)
+
+// TextAnnotationDef defines a text annotation (sticky note), displayed as an
+// icon in the rectangle with a lower left corner at (X,Y) and the specified
+// Width and Height, which default to 20. The text of the note is set as the
+// contents of the annotation. When Open is true, viewers initially display
+// the note opened.
+type TextAnnotationDef struct {
+	X       float64
+	Y       float64
+	Width   float64
+	Height  float64
+	Icon    TextIcon
+	Text    string
+	Color   *model.PdfColorDeviceRGB
+	Opacity float64
+	Open    bool
+}
+
+// textIconSize is the size of the square in which the text annotation icons
+// are designed.
+const textIconSize = 20.0
+
+// CreateTextAnnotation creates a text annotation object that can be added to
+// the page annotations. The icon defaults to Note and the color to yellow.
+func CreateTextAnnotation(textDef TextAnnotationDef) (*model.PdfAnnotation, error) {
+	icon := textDef.Icon
+	if icon == "" {
+		icon = TextIconNote
+	}
+	color := textDef.Color
+	if color == nil {
+		color = model.NewPdfColorDeviceRGB(1, 0.82, 0)
+	}
+	width, height := textDef.Width, textDef.Height
+	if width <= 0 {
+		width = textIconSize
+	}
+	if height <= 0 {
+		height = textIconSize
+	}
+
+	annot := model.NewPdfAnnotationText()
+	annot.Name = core.MakeName(string(icon))
+	annot.Open = core.MakeBool(textDef.Open)
+	annot.C = rgbArray(color)
+	if textDef.Text != "" {
+		annot.Contents = core.MakeString(textDef.Text)
+	}
+	// The icon keeps its size and orientation when zooming and rotating the
+	// page (NoZoom and NoRotate flags).
+	annot.F = core.MakeInteger(24)
+	if textDef.Opacity < 1.0 {
+		annot.CA = core.MakeFloat(textDef.Opacity)
+	}
+
+	resources := model.NewPdfPageResources()
+	var font *model.PdfFont
+	switch icon {
+	case TextIconHelp, TextIconNewParagraph, TextIconParagraph:
+		var err error
+		if font, err = model.NewStandard14Font(model.HelveticaBoldName); err != nil {
+			return nil, err
+		}
+		if err := resources.SetFontByName("HeBo", font.ToPdfObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	ap, bbox, err := annotationAppearance(resources, textDef.Opacity, "", func(gsName string) ([]byte, *model.PdfRectangle, error) {
+		x, y := textDef.X, textDef.Y
+		cc := contentstream.NewContentCreator()
+		cc.Add_q()
+		if gsName != "" {
+			cc.Add_gs(core.PdfObjectName(gsName))
+		}
+		cc.Add_cm(width/textIconSize, 0, 0, height/textIconSize, x, y)
+		cc.Add_rg(color.R(), color.G(), color.B()).Add_RG(0, 0, 0).Add_w(0.6)
+		drawTextIcon(cc, icon, font)
+		cc.Add_Q()
+		return cc.Bytes(), &model.PdfRectangle{Llx: x, Lly: y, Urx: x + width, Ury: y + height}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	annot.AP = ap
+	annot.Rect = bbox.ToPdfObject()
+	return annot.PdfAnnotation, nil
+}
+
+// drawTextIcon draws the icon within a 20x20 square, filled with the current
+// fill color and outlined with the current stroke color. The glyphs of the
+// Help, NewParagraph and Paragraph icons are drawn using `font`, which is
+// registered as HeBo in the resources.
+func drawTextIcon(cc *contentstream.ContentCreator, icon TextIcon, font *model.PdfFont) {
+	switch icon {
+	case TextIconComment:
+		drawRoundedRect(cc, 1.5, 5.5, 17, 13, 3)
+		cc.Add_B()
+		// Tail of the speech bubble, covering the bottom edge of the bubble.
+		cc.Add_m(5, 6).Add_l(4, 2).Add_l(9, 6).Add_h().Add_f()
+		cc.Add_m(5, 5.5).Add_l(4, 2).Add_l(9, 5.5).Add_S()
+		cc.Add_m(5, 14.5).Add_l(15, 14.5)
+		cc.Add_m(5, 11.5).Add_l(15, 11.5)
+		cc.Add_m(5, 8.5).Add_l(12, 8.5).Add_S()
+	case TextIconKey:
+		cc.Add_m(9.5, 11).Add_l(18.5, 11).Add_l(18.5, 6).Add_l(16.5, 6).Add_l(16.5, 9)
+		cc.Add_l(15, 9).Add_l(15, 7).Add_l(13, 7).Add_l(13, 9).Add_l(9.5, 9).Add_h().Add_B()
+		drawEllipse(cc, 6, 10, 4.5, 4.5)
+		cc.Add_B()
+		cc.Add_q().Add_rg(1, 1, 1)
+		drawEllipse(cc, 4.5, 10, 1.3, 1.3)
+		cc.Add_B().Add_Q()
+	case TextIconHelp:
+		drawEllipse(cc, 10, 10, 8.5, 8.5)
+		cc.Add_B()
+		cc.Add_rg(0, 0, 0)
+		drawCenteredText(cc, font, "HeBo", 13, "?", 10, 10-capHeight(font, 13)/2)
+	case TextIconNewParagraph:
+		cc.Add_m(10, 19).Add_l(3.5, 9.5).Add_l(16.5, 9.5).Add_h().Add_B()
+		cc.Add_rg(0, 0, 0)
+		drawCenteredText(cc, font, "HeBo", 7.5, "NP", 10, 1.5)
+	case TextIconParagraph:
+		drawEllipse(cc, 10, 10, 8.5, 8.5)
+		cc.Add_B()
+		cc.Add_rg(0, 0, 0)
+		drawCenteredText(cc, font, "HeBo", 13, "¶", 10, 10-capHeight(font, 13)/2)
+	case TextIconInsert:
+		drawCaret(cc, 2, 2, 16, 16)
+		cc.Add_B()
+	default:
+		// Note: page with a folded corner and lines of text.
+		cc.Add_m(3.5, 1).Add_l(3.5, 19).Add_l(12.5, 19).Add_l(16.5, 15).Add_l(16.5, 1).Add_h().Add_B()
+		cc.Add_m(12.5, 19).Add_l(12.5, 15).Add_l(16.5, 15)
+		cc.Add_m(6, 12.5).Add_l(14, 12.5)
+		cc.Add_m(6, 9.5).Add_l(14, 9.5)
+		cc.Add_m(6, 6.5).Add_l(14, 6.5)
+		cc.Add_m(6, 3.5).Add_l(11, 3.5).Add_S()
+	}
+}
+
+// drawEllipse adds the path of an ellipse with the specified center and
+// radii.
+func drawEllipse(cc *contentstream.ContentCreator, cx, cy, rx, ry float64) {
+	// Distance of the control points approximating quarter ellipses.
+	kx, ky := rx*0.5523, ry*0.5523
+	cc.Add_m(cx+rx, cy)
+	cc.Add_c(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	cc.Add_c(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	cc.Add_c(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	cc.Add_c(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	cc.Add_h()
+}
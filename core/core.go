@@ -12,7 +12,7 @@
 // Package core defines and implements the primitive PDF object types in golang, and provides functionality
 // for parsing those from a PDF file stream. This includes I/O handling, cross references, repairs, encryption,
 // encoding and other core capabilities.
-package core ;import (_eg "bufio";_gcd "bytes";_bed "compress/lzw";_d "compress/zlib";_gd "crypto/md5";_ga "crypto/rand";_fc "encoding/hex";_c "errors";_gc "fmt";_fg "github.com/unidoc/unipdf/v3/common";_dfg "github.com/unidoc/unipdf/v3/core/security";_cd "github.com/unidoc/unipdf/v3/core/security/crypt";_afd "github.com/unidoc/unipdf/v3/internal/ccittfax";_ee "github.com/unidoc/unipdf/v3/internal/imageutil";_bf "github.com/unidoc/unipdf/v3/internal/jbig2";_gf "github.com/unidoc/unipdf/v3/internal/jbig2/bitmap";_cf "github.com/unidoc/unipdf/v3/internal/jbig2/decoder";_ca "github.com/unidoc/unipdf/v3/internal/jbig2/document";_ad "github.com/unidoc/unipdf/v3/internal/jbig2/errors";_df "github.com/unidoc/unipdf/v3/internal/strutils";_def "golang.org/x/image/tiff/lzw";_gad "golang.org/x/xerrors";_cg "image";_be "image/color";_af "image/jpeg";_de "io";_g "reflect";_a "regexp";_b "sort";_e "strconv";_afa "strings";_ge "time";);var _faag =_a .MustCompile ("\u0028\u005c\u0064\u002b\u0029\u005c\u0073\u002b\u0028\u005c\u0064\u002b)\u005c\u0073\u002a\u0024");
+package core ;import (_eg "bufio";_gcd "bytes";_bed "compress/lzw";_d "compress/zlib";_fc "encoding/hex";_c "errors";_gc "fmt";_fg "github.com/unidoc/unipdf/v3/common";_dfg "github.com/unidoc/unipdf/v3/core/security";_cd "github.com/unidoc/unipdf/v3/core/security/crypt";_afd "github.com/unidoc/unipdf/v3/internal/ccittfax";_ee "github.com/unidoc/unipdf/v3/internal/imageutil";_bf "github.com/unidoc/unipdf/v3/internal/jbig2";_gf "github.com/unidoc/unipdf/v3/internal/jbig2/bitmap";_cf "github.com/unidoc/unipdf/v3/internal/jbig2/decoder";_ca "github.com/unidoc/unipdf/v3/internal/jbig2/document";_ad "github.com/unidoc/unipdf/v3/internal/jbig2/errors";_df "github.com/unidoc/unipdf/v3/internal/strutils";_def "golang.org/x/image/tiff/lzw";_gad "golang.org/x/xerrors";_cg "image";_be "image/color";_af "image/jpeg";_de "io";_g "reflect";_a "regexp";_b "sort";_e "strconv";_afa "strings";_bdc "sync";);var _faag =_a .MustCompile ("\u0028\u005c\u0064\u002b\u0029\u005c\u0073\u002b\u0028\u005c\u0064\u002b)\u005c\u0073\u002a\u0024");
 
 // Clear resets the array to an empty state.
 func (_daef *PdfObjectArray )Clear (){_daef ._bcea =[]PdfObject {}};func (_fgfd *PdfCrypt )saveCryptFilters (_gdb *PdfObjectDictionary )error {if _fgfd ._age .V < 4{return _c .New ("\u0063\u0061\u006e\u0020\u006f\u006e\u006c\u0079\u0020\u0062\u0065 \u0075\u0073\u0065\u0064\u0020\u0077\u0069\u0074\u0068\u0020V\u003e\u003d\u0034");};_ebd :=MakeDict ();_gdb .Set ("\u0043\u0046",_ebd );for _gaa ,_cea :=range _fgfd ._dfb {if _gaa =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{continue ;};_eac :=_gdf (_cea ,"");_ebd .Set (PdfObjectName (_gaa ),_eac );};_gdb .Set ("\u0053\u0074\u0072\u0046",MakeName (_fgfd ._fcb ));_gdb .Set ("\u0053\u0074\u006d\u0046",MakeName (_fgfd ._gca ));return nil ;};
@@ -37,21 +37,18 @@ func GetArray (obj PdfObject )(_bcge *PdfObjectArray ,_fdfcc bool ){_bcge ,_fdfc
 func (_dadg *PdfObjectString )WriteString ()string {var _efabc _gcd .Buffer ;if _dadg ._eade {_gbbgb :=_fc .EncodeToString (_dadg .Bytes ());_efabc .WriteString ("\u003c");_efabc .WriteString (_gbbgb );_efabc .WriteString ("\u003e");return _efabc .String ();};_bfaca :=map[byte ]string {'\n':"\u005c\u006e",'\r':"\u005c\u0072",'\t':"\u005c\u0074",'\b':"\u005c\u0062",'\f':"\u005c\u0066",'(':"\u005c\u0028",')':"\u005c\u0029",'\\':"\u005c\u005c"};_efabc .WriteString ("\u0028");for _ffdaa :=0;_ffdaa < len (_dadg ._abgc );_ffdaa ++{_ecfad :=_dadg ._abgc [_ffdaa ];if _eegb ,_cfdf :=_bfaca [_ecfad ];_cfdf {_efabc .WriteString (_eegb );}else {_efabc .WriteByte (_ecfad );};};_efabc .WriteString ("\u0029");return _efabc .String ();};
 
 // PdfCryptNewEncrypt makes the document crypt handler based on a specified crypt filter.
-func PdfCryptNewEncrypt (cf _cd .Filter ,userPass ,ownerPass []byte ,perm _dfg .Permissions )(*PdfCrypt ,*EncryptInfo ,error ){_cbfg :=&PdfCrypt {_aaa :make (map[PdfObject ]bool ),_dfb :make (cryptFilters ),_cgc :_dfg .StdEncryptDict {P :perm ,EncryptMetadata :true }};var _cdb Version ;if cf !=nil {_cbd :=cf .PDFVersion ();_cdb .Major ,_cdb .Minor =_cbd [0],_cbd [1];V ,R :=cf .HandlerVersion ();_cbfg ._age .V =V ;_cbfg ._cgc .R =R ;_cbfg ._age .Length =cf .KeyLength ()*8;};const (_ab =_bfb ;);_cbfg ._dfb [_ab ]=cf ;if _cbfg ._age .V >=4{_cbfg ._gca =_ab ;_cbfg ._fcb =_ab ;};_geb :=_cbfg .newEncryptDict ();_fce :=_gd .Sum ([]byte (_ge .Now ().Format (_ge .RFC850 )));_gac :=string (_fce [:]);_eebd :=make ([]byte ,100);_ga .Read (_eebd );_fce =_gd .Sum (_eebd );_ffc :=string (_fce [:]);_fg .Log .Trace ("\u0052\u0061\u006e\u0064\u006f\u006d\u0020\u0062\u003a\u0020\u0025\u0020\u0078",_eebd );_fg .Log .Trace ("\u0047\u0065\u006e\u0020\u0049\u0064\u0020\u0030\u003a\u0020\u0025\u0020\u0078",_gac );_cbfg ._dg =_gac ;_aa :=_cbfg .generateParams (userPass ,ownerPass );if _aa !=nil {return nil ,nil ,_aa ;};_eda (&_cbfg ._cgc ,_geb );if _cbfg ._age .V >=4{if _eag :=_cbfg .saveCryptFilters (_geb );_eag !=nil {return nil ,nil ,_eag ;};};return _cbfg ,&EncryptInfo {Version :_cdb ,Encrypt :_geb ,ID0 :_gac ,ID1 :_ffc },nil ;};func _eebgb (_agaba *PdfObjectDictionary )(_adbdff *_ee .ImageBase ){var (_ccaf *PdfObjectInteger ;_begbe bool ;);if _ccaf ,_begbe =_agaba .Get ("\u0057\u0069\u0064t\u0068").(*PdfObjectInteger );_begbe {_adbdff =&_ee .ImageBase {Width :int (*_ccaf )};}else {return nil ;};if _ccaf ,_begbe =_agaba .Get ("\u0048\u0065\u0069\u0067\u0068\u0074").(*PdfObjectInteger );_begbe {_adbdff .Height =int (*_ccaf );};if _ccaf ,_begbe =_agaba .Get ("\u0042\u0069t\u0073\u0050\u0065r\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074").(*PdfObjectInteger );_begbe {_adbdff .BitsPerComponent =int (*_ccaf );};if _ccaf ,_begbe =_agaba .Get ("\u0043o\u006co\u0072\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074\u0073").(*PdfObjectInteger );_begbe {_adbdff .ColorComponents =int (*_ccaf );};return _adbdff ;};
+func PdfCryptNewEncrypt (cf _cd .Filter ,userPass ,ownerPass []byte ,perm _dfg .Permissions )(*PdfCrypt ,*EncryptInfo ,error ){return PdfCryptNewEncryptWithOpts (cf ,userPass ,ownerPass ,perm ,nil );};func _eebgb (_agaba *PdfObjectDictionary )(_adbdff *_ee .ImageBase ){var (_ccaf *PdfObjectInteger ;_begbe bool ;);if _ccaf ,_begbe =_agaba .Get ("\u0057\u0069\u0064t\u0068").(*PdfObjectInteger );_begbe {_adbdff =&_ee .ImageBase {Width :int (*_ccaf )};}else {return nil ;};if _ccaf ,_begbe =_agaba .Get ("\u0048\u0065\u0069\u0067\u0068\u0074").(*PdfObjectInteger );_begbe {_adbdff .Height =int (*_ccaf );};if _ccaf ,_begbe =_agaba .Get ("\u0042\u0069t\u0073\u0050\u0065r\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074").(*PdfObjectInteger );_begbe {_adbdff .BitsPerComponent =int (*_ccaf );};if _ccaf ,_begbe =_agaba .Get ("\u0043o\u006co\u0072\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074\u0073").(*PdfObjectInteger );_begbe {_adbdff .ColorComponents =int (*_ccaf );};return _adbdff ;};
 
 // Bytes returns the PdfObjectString content as a []byte array.
 func (_ceaac *PdfObjectString )Bytes ()[]byte {return []byte (_ceaac ._abgc )};
 
-// MakeDecodeParams makes a new instance of an encoding dictionary based on
-// the current encoder settings.
-func (_dbcc *JPXEncoder )MakeDecodeParams ()PdfObject {return nil };
 
 // ToInt64Slice returns a slice of all array elements as an int64 slice. An error is returned if the
 // array non-integer objects. Each element can only be PdfObjectInteger.
 func (_adccb *PdfObjectArray )ToInt64Slice ()([]int64 ,error ){var _bcgd []int64 ;for _ ,_cccb :=range _adccb .Elements (){if _cfee ,_fbae :=_cccb .(*PdfObjectInteger );_fbae {_bcgd =append (_bcgd ,int64 (*_cfee ));}else {return nil ,ErrTypeError ;};};return _bcgd ,nil ;};func (_egaga *PdfParser )parseName ()(PdfObjectName ,error ){var _egeg _gcd .Buffer ;_bfac :=false ;for {_dfaf ,_beec :=_egaga ._daba .Peek (1);if _beec ==_de .EOF {break ;};if _beec !=nil {return PdfObjectName (_egeg .String ()),_beec ;};if !_bfac {if _dfaf [0]=='/'{_bfac =true ;_egaga ._daba .ReadByte ();}else if _dfaf [0]=='%'{_egaga .readComment ();_egaga .skipSpaces ();}else {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020N\u0061\u006d\u0065\u0020\u0073\u0074\u0061\u0072\u0074\u0069\u006e\u0067\u0020w\u0069\u0074\u0068\u0020\u0025\u0073\u0020(\u0025\u0020\u0078\u0029",_dfaf ,_dfaf );return PdfObjectName (_egeg .String ()),_gc .Errorf ("\u0069n\u0076a\u006c\u0069\u0064\u0020\u006ea\u006d\u0065:\u0020\u0028\u0025\u0063\u0029",_dfaf [0]);};}else {if IsWhiteSpace (_dfaf [0]){break ;}else if (_dfaf [0]=='/')||(_dfaf [0]=='[')||(_dfaf [0]=='(')||(_dfaf [0]==']')||(_dfaf [0]=='<')||(_dfaf [0]=='>'){break ;}else if _dfaf [0]=='#'{_gaef ,_ddcc :=_egaga ._daba .Peek (3);if _ddcc !=nil {return PdfObjectName (_egeg .String ()),_ddcc ;};_adbg ,_ddcc :=_fc .DecodeString (string (_gaef [1:3]));if _ddcc !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004fR\u003a\u0020\u0049\u006ev\u0061\u006c\u0069d\u0020\u0068\u0065\u0078\u0020\u0066o\u006c\u006co\u0077\u0069\u006e\u0067 \u0027\u0023\u0027\u002c \u0063\u006f\u006e\u0074\u0069n\u0075\u0069\u006e\u0067\u0020\u0075\u0073i\u006e\u0067\u0020\u006c\u0069t\u0065\u0072\u0061\u006c\u0020\u002d\u0020\u004f\u0075t\u0070\u0075\u0074\u0020\u006d\u0061\u0079\u0020\u0062\u0065\u0020\u0069\u006e\u0063\u006f\u0072\u0072\u0065\u0063\u0074");_egeg .WriteByte ('#');_egaga ._daba .Discard (1);continue ;};_egaga ._daba .Discard (3);_egeg .Write (_adbg );}else {_efdf ,_ :=_egaga ._daba .ReadByte ();_egeg .WriteByte (_efdf );};};};return PdfObjectName (_egeg .String ()),nil ;};
 
 // LookupByNumber looks up a PdfObject by object number.  Returns an error on failure.
-func (_bgd *PdfParser )LookupByNumber (objNumber int )(PdfObject ,error ){_ade ,_ ,_bea :=_bgd .lookupByNumberWrapper (objNumber ,true );return _ade ,_bea ;};var _bbda =_a .MustCompile ("\u0025P\u0044F\u002d\u0028\u005c\u0064\u0029\u005c\u002e\u0028\u005c\u0064\u0029");
+func (_bgd *PdfParser )LookupByNumber (objNumber int )(PdfObject ,error ){_bgd .mu .Lock ();defer _bgd .mu .Unlock ();_ade ,_ ,_bea :=_bgd .lookupByNumberWrapper (objNumber ,true );return _ade ,_bea ;};var _bbda =_a .MustCompile ("\u0025P\u0044F\u002d\u0028\u005c\u0064\u0029\u005c\u002e\u0028\u005c\u0064\u0029");
 
 // NewASCIIHexEncoder makes a new ASCII hex encoder.
 func NewASCIIHexEncoder ()*ASCIIHexEncoder {_eae :=&ASCIIHexEncoder {};return _eae };
@@ -86,7 +83,7 @@ func (_ddbd *RunLengthEncoder )MakeStreamDict ()*PdfObjectDictionary {_defd :=Ma
 func (_dcda *RunLengthEncoder )EncodeBytes (data []byte )([]byte ,error ){_caea :=_gcd .NewReader (data );var _cadg []byte ;var _eef []byte ;_fcfb ,_dbb :=_caea .ReadByte ();if _dbb ==_de .EOF {return []byte {},nil ;}else if _dbb !=nil {return nil ,_dbb ;};_deef :=1;for {_ebcb ,_bgda :=_caea .ReadByte ();if _bgda ==_de .EOF {break ;}else if _bgda !=nil {return nil ,_bgda ;};if _ebcb ==_fcfb {if len (_eef )> 0{_eef =_eef [:len (_eef )-1];if len (_eef )> 0{_cadg =append (_cadg ,byte (len (_eef )-1));_cadg =append (_cadg ,_eef ...);};_deef =1;_eef =[]byte {};};_deef ++;if _deef >=127{_cadg =append (_cadg ,byte (257-_deef ),_fcfb );_deef =0;};}else {if _deef > 0{if _deef ==1{_eef =[]byte {_fcfb };}else {_cadg =append (_cadg ,byte (257-_deef ),_fcfb );};_deef =0;};_eef =append (_eef ,_ebcb );if len (_eef )>=127{_cadg =append (_cadg ,byte (len (_eef )-1));_cadg =append (_cadg ,_eef ...);_eef =[]byte {};};};_fcfb =_ebcb ;};if len (_eef )> 0{_cadg =append (_cadg ,byte (len (_eef )-1));_cadg =append (_cadg ,_eef ...);}else if _deef > 0{_cadg =append (_cadg ,byte (257-_deef ),_fcfb );};_cadg =append (_cadg ,128);return _cadg ,nil ;};
 
 // GetFilterName returns the name of the encoding filter.
-func (_dgdf *RawEncoder )GetFilterName ()string {return StreamEncodingFilterNameRaw };func (_afbd *PdfCrypt )checkAccessRights (_adb []byte )(bool ,_dfg .Permissions ,error ){_abb :=_afbd .securityHandler ();_faf ,_agbd ,_cfcd :=_abb .Authenticate (&_afbd ._cgc ,_adb );if _cfcd !=nil {return false ,0,_cfcd ;}else if _agbd ==0||len (_faf )==0{return false ,0,nil ;};return true ,_agbd ,nil ;};
+func (_dgdf *RawEncoder )GetFilterName ()string {return StreamEncodingFilterNameRaw };func (_afbd *PdfCrypt )checkAccessRights (_adb []byte )(bool ,_dfg .Permissions ,error ){if _afbd .pubKey !=nil {if !_afbd ._bddg {return false ,0,nil ;};return true ,_afbd ._cgc .P ,nil ;};_abb :=_afbd .securityHandler ();_faf ,_agbd ,_cfcd :=_abb .Authenticate (&_afbd ._cgc ,_adb );if _cfcd !=nil {return false ,0,_cfcd ;}else if _agbd ==0||len (_faf )==0{return false ,0,nil ;};return true ,_agbd ,nil ;};
 
 // DecodeStream decodes RunLengthEncoded stream object and give back decoded bytes.
 func (_dfdd *RunLengthEncoder )DecodeStream (streamObj *PdfObjectStream )([]byte ,error ){return _dfdd .DecodeBytes (streamObj .Stream );};
@@ -95,7 +92,7 @@ func (_dfdd *RunLengthEncoder )DecodeStream (streamObj *PdfObjectStream )([]byte
 func (_caga *CCITTFaxEncoder )DecodeStream (streamObj *PdfObjectStream )([]byte ,error ){return _caga .DecodeBytes (streamObj .Stream );};
 
 // NewEncoderFromStream creates a StreamEncoder based on the stream's dictionary.
-func NewEncoderFromStream (streamObj *PdfObjectStream )(StreamEncoder ,error ){_faeg :=TraceToDirectObject (streamObj .PdfObjectDictionary .Get ("\u0046\u0069\u006c\u0074\u0065\u0072"));if _faeg ==nil {return NewRawEncoder (),nil ;};if _ ,_eebac :=_faeg .(*PdfObjectNull );_eebac {return NewRawEncoder (),nil ;};_fdde ,_bfacdf :=_faeg .(*PdfObjectName );if !_bfacdf {_effge ,_aefg :=_faeg .(*PdfObjectArray );if !_aefg {return nil ,_gc .Errorf ("\u0066\u0069\u006c\u0074\u0065\u0072 \u006e\u006f\u0074\u0020\u0061\u0020\u004e\u0061\u006d\u0065\u0020\u006f\u0072 \u0041\u0072\u0072\u0061\u0079\u0020\u006fb\u006a\u0065\u0063\u0074");};if _effge .Len ()==0{return NewRawEncoder (),nil ;};if _effge .Len ()!=1{_cadc ,_edgaf :=_bdeg (streamObj );if _edgaf !=nil {_fg .Log .Error ("\u0046\u0061\u0069\u006c\u0065\u0064 \u0063\u0072\u0065\u0061\u0074\u0069\u006e\u0067\u0020\u006d\u0075\u006c\u0074i\u0020\u0065\u006e\u0063\u006f\u0064\u0065r\u003a\u0020\u0025\u0076",_edgaf );return nil ,_edgaf ;};_fg .Log .Trace ("\u004d\u0075\u006c\u0074\u0069\u0020\u0065\u006e\u0063:\u0020\u0025\u0073\u000a",_cadc );return _cadc ,nil ;};_faeg =_effge .Get (0);_fdde ,_aefg =_faeg .(*PdfObjectName );if !_aefg {return nil ,_gc .Errorf ("\u0066\u0069l\u0074\u0065\u0072\u0020a\u0072\u0072a\u0079\u0020\u006d\u0065\u006d\u0062\u0065\u0072 \u006e\u006f\u0074\u0020\u0061\u0020\u004e\u0061\u006d\u0065\u0020\u006fb\u006a\u0065\u0063\u0074");};};switch *_fdde {case StreamEncodingFilterNameFlate :return _cbgc (streamObj ,nil );case StreamEncodingFilterNameLZW :return _ead (streamObj ,nil );case StreamEncodingFilterNameDCT :return _bde (streamObj ,nil );case StreamEncodingFilterNameRunLength :return _bfbc (streamObj ,nil );case StreamEncodingFilterNameASCIIHex :return NewASCIIHexEncoder (),nil ;case StreamEncodingFilterNameASCII85 ,"\u0041\u0038\u0035":return NewASCII85Encoder (),nil ;case StreamEncodingFilterNameCCITTFax :return _efad (streamObj ,nil );case StreamEncodingFilterNameJBIG2 :return _ccdd (streamObj ,nil );case StreamEncodingFilterNameJPX :return NewJPXEncoder (),nil ;};_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020U\u006e\u0073\u0075\u0070\u0070\u006fr\u0074\u0065\u0064\u0020\u0065\u006e\u0063o\u0064\u0069\u006e\u0067\u0020\u006d\u0065\u0074\u0068\u006fd\u0021");return nil ,_gc .Errorf ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0065\u006e\u0063o\u0064i\u006e\u0067\u0020\u006d\u0065\u0074\u0068\u006f\u0064\u0020\u0028\u0025\u0073\u0029",*_fdde );};
+func NewEncoderFromStream (streamObj *PdfObjectStream )(StreamEncoder ,error ){_faeg :=TraceToDirectObject (streamObj .PdfObjectDictionary .Get ("\u0046\u0069\u006c\u0074\u0065\u0072"));if _faeg ==nil {return NewRawEncoder (),nil ;};if _ ,_eebac :=_faeg .(*PdfObjectNull );_eebac {return NewRawEncoder (),nil ;};_fdde ,_bfacdf :=_faeg .(*PdfObjectName );if !_bfacdf {_effge ,_aefg :=_faeg .(*PdfObjectArray );if !_aefg {return nil ,_gc .Errorf ("\u0066\u0069\u006c\u0074\u0065\u0072 \u006e\u006f\u0074\u0020\u0061\u0020\u004e\u0061\u006d\u0065\u0020\u006f\u0072 \u0041\u0072\u0072\u0061\u0079\u0020\u006fb\u006a\u0065\u0063\u0074");};if _effge .Len ()==0{return NewRawEncoder (),nil ;};if _effge .Len ()!=1{_cadc ,_edgaf :=_bdeg (streamObj );if _edgaf !=nil {_fg .Log .Error ("\u0046\u0061\u0069\u006c\u0065\u0064 \u0063\u0072\u0065\u0061\u0074\u0069\u006e\u0067\u0020\u006d\u0075\u006c\u0074i\u0020\u0065\u006e\u0063\u006f\u0064\u0065r\u003a\u0020\u0025\u0076",_edgaf );return nil ,_edgaf ;};_fg .Log .Trace ("\u004d\u0075\u006c\u0074\u0069\u0020\u0065\u006e\u0063:\u0020\u0025\u0073\u000a",_cadc );return _cadc ,nil ;};_faeg =_effge .Get (0);_fdde ,_aefg =_faeg .(*PdfObjectName );if !_aefg {return nil ,_gc .Errorf ("\u0066\u0069l\u0074\u0065\u0072\u0020a\u0072\u0072a\u0079\u0020\u006d\u0065\u006d\u0062\u0065\u0072 \u006e\u006f\u0074\u0020\u0061\u0020\u004e\u0061\u006d\u0065\u0020\u006fb\u006a\u0065\u0063\u0074");};};switch *_fdde {case StreamEncodingFilterNameFlate :return _cbgc (streamObj ,nil );case StreamEncodingFilterNameLZW :return _ead (streamObj ,nil );case StreamEncodingFilterNameDCT :return _bde (streamObj ,nil );case StreamEncodingFilterNameRunLength :return _bfbc (streamObj ,nil );case StreamEncodingFilterNameASCIIHex :return NewASCIIHexEncoder (),nil ;case StreamEncodingFilterNameASCII85 ,"\u0041\u0038\u0035":return NewASCII85Encoder (),nil ;case StreamEncodingFilterNameCCITTFax :return _efad (streamObj ,nil );case StreamEncodingFilterNameJBIG2 :return _ccdd (streamObj ,nil );case StreamEncodingFilterNameJPX :return newJPXEncoderFromStream (streamObj ),nil ;case "Crypt":return NewRawEncoder (),nil ;};_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020U\u006e\u0073\u0075\u0070\u0070\u006fr\u0074\u0065\u0064\u0020\u0065\u006e\u0063o\u0064\u0069\u006e\u0067\u0020\u006d\u0065\u0074\u0068\u006fd\u0021");return nil ,_gc .Errorf ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0065\u006e\u0063o\u0064i\u006e\u0067\u0020\u006d\u0065\u0074\u0068\u006f\u0064\u0020\u0028\u0025\u0073\u0029",*_fdde );};
 
 // EncodeBytes encodes slice of bytes into JBIG2 encoding format.
 // The input 'data' must be an image. In order to Decode it a user is responsible to
@@ -169,8 +166,6 @@ func MakeArrayFromFloats (vals []float64 )*PdfObjectArray {_ffda :=MakeArray ();
 // An error is returned upon failure.
 func DecodeStream (streamObj *PdfObjectStream )([]byte ,error ){_fg .Log .Trace ("\u0044\u0065\u0063\u006f\u0064\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d");_cadf ,_eeeec :=NewEncoderFromStream (streamObj );if _eeeec !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0053\u0074\u0072\u0065\u0061\u006d\u0020\u0064\u0065\u0063\u006f\u0064\u0069n\u0067\u0020\u0066\u0061\u0069\u006c\u0065d\u003a\u0020\u0025\u0076",_eeeec );return nil ,_eeeec ;};_fg .Log .Trace ("\u0045\u006e\u0063\u006f\u0064\u0065\u0072\u003a\u0020\u0025\u0023\u0076\u000a",_cadf );_aedd ,_eeeec :=_cadf .DecodeStream (streamObj );if _eeeec !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0053\u0074\u0072\u0065\u0061\u006d\u0020\u0064\u0065\u0063\u006f\u0064\u0069n\u0067\u0020\u0066\u0061\u0069\u006c\u0065d\u003a\u0020\u0025\u0076",_eeeec );return nil ,_eeeec ;};return _aedd ,nil ;};func _dccg ()string {return _fg .Version };
 
-// NewJPXEncoder returns a new instance of JPXEncoder.
-func NewJPXEncoder ()*JPXEncoder {return &JPXEncoder {}};
 
 // RunLengthEncoder represents Run length encoding.
 type RunLengthEncoder struct{};
@@ -234,7 +229,7 @@ func (_eeeg JBIG2EncoderSettings )Validate ()error {const _aagd ="\u0076a\u006ci
 
 // PdfCryptNewDecrypt makes the document crypt handler based on the encryption dictionary
 // and trailer dictionary. Returns an error on failure to process.
-func PdfCryptNewDecrypt (parser *PdfParser ,ed ,trailer *PdfObjectDictionary )(*PdfCrypt ,error ){_bec :=&PdfCrypt {_bddg :false ,_cc :make (map[PdfObject ]bool ),_aaa :make (map[PdfObject ]bool ),_cdbg :make (map[int ]struct{}),_afaf :parser };_fgc ,_dee :=ed .Get ("\u0046\u0069\u006c\u0074\u0065\u0072").(*PdfObjectName );if !_dee {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0043\u0072\u0079\u0070\u0074 \u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061r\u0079 \u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0064\u0020\u0046i\u006c\u0074\u0065\u0072\u0020\u0066\u0069\u0065\u006c\u0064\u0021");return _bec ,_c .New ("r\u0065\u0071\u0075\u0069\u0072\u0065d\u0020\u0063\u0072\u0079\u0070\u0074 \u0066\u0069\u0065\u006c\u0064\u0020\u0046i\u006c\u0074\u0065\u0072\u0020\u006d\u0069\u0073\u0073\u0069n\u0067");};if *_fgc !="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064"{_fg .Log .Debug ("\u0045\u0052R\u004f\u0052\u0020\u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020(%\u0073\u0029",*_fgc );return _bec ,_c .New ("\u0075n\u0073u\u0070\u0070\u006f\u0072\u0074e\u0064\u0020F\u0069\u006c\u0074\u0065\u0072");};_bec ._age .Filter =string (*_fgc );if _fecg ,_dae :=ed .Get ("\u0053u\u0062\u0046\u0069\u006c\u0074\u0065r").(*PdfObjectString );_dae {_bec ._age .SubFilter =_fecg .Str ();_fg .Log .Debug ("\u0055s\u0069n\u0067\u0020\u0073\u0075\u0062f\u0069\u006ct\u0065\u0072\u0020\u0025\u0073",_fecg );};if L ,_bcd :=ed .Get ("\u004c\u0065\u006e\u0067\u0074\u0068").(*PdfObjectInteger );_bcd {if (*L %8)!=0{_fg .Log .Debug ("\u0045\u0052\u0052O\u0052\u0020\u0049\u006ev\u0061\u006c\u0069\u0064\u0020\u0065\u006ec\u0072\u0079\u0070\u0074\u0069\u006f\u006e\u0020\u006c\u0065\u006e\u0067\u0074\u0068");return _bec ,_c .New ("\u0069n\u0076\u0061\u006c\u0069d\u0020\u0065\u006e\u0063\u0072y\u0070t\u0069o\u006e\u0020\u006c\u0065\u006e\u0067\u0074h");};_bec ._age .Length =int (*L );}else {_bec ._age .Length =40;};_bec ._age .V =0;if _bedb ,_cedf :=ed .Get ("\u0056").(*PdfObjectInteger );_cedf {V :=int (*_bedb );_bec ._age .V =V ;if V >=1&&V <=2{_bec ._dfb =_bgb (_bec ._age .Length );}else if V >=4&&V <=5{if _agb :=_bec .loadCryptFilters (ed );_agb !=nil {return _bec ,_agb ;};}else {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u0020\u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0065n\u0063\u0072\u0079\u0070\u0074\u0069\u006f\u006e\u0020\u0061lg\u006f\u0020\u0056 \u003d \u0025\u0064",V );return _bec ,_c .New ("u\u006e\u0073\u0075\u0070po\u0072t\u0065\u0064\u0020\u0061\u006cg\u006f\u0072\u0069\u0074\u0068\u006d");};};if _eagd :=_bfe (&_bec ._cgc ,ed );_eagd !=nil {return _bec ,_eagd ;};_cee :="";if _egee ,_bcc :=trailer .Get ("\u0049\u0044").(*PdfObjectArray );_bcc &&_egee .Len ()>=1{_efb ,_bcdg :=GetString (_egee .Get (0));if !_bcdg {return _bec ,_c .New ("\u0069n\u0076a\u006c\u0069\u0064\u0020\u0074r\u0061\u0069l\u0065\u0072\u0020\u0049\u0044");};_cee =_efb .Str ();}else {_fg .Log .Debug ("\u0054\u0072ai\u006c\u0065\u0072 \u0049\u0044\u0020\u0061rra\u0079 m\u0069\u0073\u0073\u0069\u006e\u0067\u0020or\u0020\u0069\u006e\u0076\u0061\u006c\u0069d\u0021");};_bec ._dg =_cee ;return _bec ,nil ;};
+func PdfCryptNewDecrypt (parser *PdfParser ,ed ,trailer *PdfObjectDictionary )(*PdfCrypt ,error ){_bec :=&PdfCrypt {_bddg :false ,_cc :make (map[PdfObject ]bool ),_aaa :make (map[PdfObject ]bool ),_cdbg :make (map[int ]struct{}),_afaf :parser };_fgc ,_dee :=ed .Get ("\u0046\u0069\u006c\u0074\u0065\u0072").(*PdfObjectName );if !_dee {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0043\u0072\u0079\u0070\u0074 \u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061r\u0079 \u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0064\u0020\u0046i\u006c\u0074\u0065\u0072\u0020\u0066\u0069\u0065\u006c\u0064\u0021");return _bec ,_c .New ("r\u0065\u0071\u0075\u0069\u0072\u0065d\u0020\u0063\u0072\u0079\u0070\u0074 \u0066\u0069\u0065\u006c\u0064\u0020\u0046i\u006c\u0074\u0065\u0072\u0020\u006d\u0069\u0073\u0073\u0069n\u0067");};if *_fgc !="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064"&&*_fgc !=pubKeyFilter {_fg .Log .Debug ("\u0045\u0052R\u004f\u0052\u0020\u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020(%\u0073\u0029",*_fgc );return _bec ,_c .New ("\u0075n\u0073u\u0070\u0070\u006f\u0072\u0074e\u0064\u0020F\u0069\u006c\u0074\u0065\u0072");};_bec ._age .Filter =string (*_fgc );if _fecg ,_dae :=ed .Get ("\u0053u\u0062\u0046\u0069\u006c\u0074\u0065r").(*PdfObjectString );_dae {_bec ._age .SubFilter =_fecg .Str ();_fg .Log .Debug ("\u0055s\u0069n\u0067\u0020\u0073\u0075\u0062f\u0069\u006ct\u0065\u0072\u0020\u0025\u0073",_fecg );};if L ,_bcd :=ed .Get ("\u004c\u0065\u006e\u0067\u0074\u0068").(*PdfObjectInteger );_bcd {if (*L %8)!=0{_fg .Log .Debug ("\u0045\u0052\u0052O\u0052\u0020\u0049\u006ev\u0061\u006c\u0069\u0064\u0020\u0065\u006ec\u0072\u0079\u0070\u0074\u0069\u006f\u006e\u0020\u006c\u0065\u006e\u0067\u0074\u0068");return _bec ,_c .New ("\u0069n\u0076\u0061\u006c\u0069d\u0020\u0065\u006e\u0063\u0072y\u0070t\u0069o\u006e\u0020\u006c\u0065\u006e\u0067\u0074h");};_bec ._age .Length =int (*L );}else {_bec ._age .Length =40;};_bec ._age .V =0;if _bedb ,_cedf :=ed .Get ("\u0056").(*PdfObjectInteger );_cedf {V :=int (*_bedb );_bec ._age .V =V ;if V >=1&&V <=2{_bec ._dfb =_bgb (_bec ._age .Length );}else if V >=4&&V <=5{if _agb :=_bec .loadCryptFilters (ed );_agb !=nil {return _bec ,_agb ;};}else {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u0020\u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0065n\u0063\u0072\u0079\u0070\u0074\u0069\u006f\u006e\u0020\u0061lg\u006f\u0020\u0056 \u003d \u0025\u0064",V );return _bec ,_c .New ("u\u006e\u0073\u0075\u0070po\u0072t\u0065\u0064\u0020\u0061\u006cg\u006f\u0072\u0069\u0074\u0068\u006d");};};if _bec ._age .Filter ==pubKeyFilter {if _eagd :=_bec .loadPubKeyDict (ed );_eagd !=nil {return _bec ,_eagd ;};}else if _eagd :=_bfe (&_bec ._cgc ,ed );_eagd !=nil {return _bec ,_eagd ;};_cee :="";if _egee ,_bcc :=trailer .Get ("\u0049\u0044").(*PdfObjectArray );_bcc &&_egee .Len ()>=1{_efb ,_bcdg :=GetString (_egee .Get (0));if !_bcdg {return _bec ,_c .New ("\u0069n\u0076a\u006c\u0069\u0064\u0020\u0074r\u0061\u0069l\u0065\u0072\u0020\u0049\u0044");};_cee =_efb .Str ();}else {_fg .Log .Debug ("\u0054\u0072ai\u006c\u0065\u0072 \u0049\u0044\u0020\u0061rra\u0079 m\u0069\u0073\u0073\u0069\u006e\u0067\u0020or\u0020\u0069\u006e\u0076\u0061\u006c\u0069d\u0021");};_bec ._dg =_cee ;return _bec ,nil ;};
 
 // String returns a string describing `stream`.
 func (_cdfb *PdfObjectStream )String ()string {return _gc .Sprintf ("O\u0062j\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065a\u006d\u0020\u0025\u0064: \u0025\u0073",_cdfb .ObjectNumber ,_cdfb .PdfObjectDictionary );};
@@ -270,7 +265,10 @@ func (_gcb *JBIG2Encoder )MakeDecodeParams ()PdfObject {return MakeDict ()};
 func (_edfc *FlateEncoder )SetPredictor (columns int ){_edfc .Predictor =11;_edfc .Columns =columns };const _cffg =10;
 
 // Resolve resolves a PdfObject to direct object, looking up and resolving references as needed (unlike TraceToDirect).
-func (_bdf *PdfParser )Resolve (obj PdfObject )(PdfObject ,error ){_ecf ,_gb :=obj .(*PdfObjectReference );if !_gb {return obj ,nil ;};_cfc :=_bdf .GetFileOffset ();defer func (){_bdf .SetFileOffset (_cfc )}();_eca ,_dfe :=_bdf .LookupByReference (*_ecf );if _dfe !=nil {return nil ,_dfe ;};_gg ,_ecff :=_eca .(*PdfIndirectObject );if !_ecff {return _eca ,nil ;};_eca =_gg .PdfObject ;_ ,_gb =_eca .(*PdfObjectReference );if _gb {return _gg ,_c .New ("\u006d\u0075lt\u0069\u0020\u0064e\u0070\u0074\u0068\u0020tra\u0063e \u0070\u006f\u0069\u006e\u0074\u0065\u0072 t\u006f\u0020\u0070\u006f\u0069\u006e\u0074e\u0072");};return _eca ,nil ;};func (_cdac *PdfParser )loadXrefs ()(*PdfObjectDictionary ,error ){_cdac ._cgbgg .ObjectMap =make (map[int ]XrefObject );_cdac ._eaeg =make (objectStreams );_bdbd ,_fdbb :=_cdac ._cdfe .Seek (0,_de .SeekEnd );if _fdbb !=nil {return nil ,_fdbb ;};_fg .Log .Trace ("\u0066s\u0069\u007a\u0065\u003a\u0020\u0025d",_bdbd );_cdac ._eecde =_bdbd ;_fdbb =_cdac .seekToEOFMarker (_bdbd );if _fdbb !=nil {_fg .Log .Debug ("\u0046\u0061i\u006c\u0065\u0064\u0020\u0073\u0065\u0065\u006b\u0020\u0074\u006f\u0020\u0065\u006f\u0066\u0020\u006d\u0061\u0072\u006b\u0065\u0072: \u0025\u0076",_fdbb );return nil ,_fdbb ;};_aedfa ,_fdbb :=_cdac ._cdfe .Seek (0,_de .SeekCurrent );if _fdbb !=nil {return nil ,_fdbb ;};var _ddeae int64 =64;_gdfff :=_aedfa -_ddeae ;if _gdfff < 0{_gdfff =0;};_ ,_fdbb =_cdac ._cdfe .Seek (_gdfff ,_de .SeekStart );if _fdbb !=nil {return nil ,_fdbb ;};_bbcf :=make ([]byte ,_ddeae );_ ,_fdbb =_cdac ._cdfe .Read (_bbcf );if _fdbb !=nil {_fg .Log .Debug ("\u0046\u0061i\u006c\u0065\u0064\u0020\u0072\u0065\u0061\u0064\u0069\u006e\u0067\u0020\u0077\u0068\u0069\u006c\u0065\u0020\u006c\u006f\u006f\u006b\u0069\u006e\u0067\u0020\u0066\u006f\u0072\u0020\u0073\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u003a\u0020\u0025\u0076",_fdbb );return nil ,_fdbb ;};_aggf :=_gfdf .FindStringSubmatch (string (_bbcf ));if len (_aggf )< 2{_fg .Log .Debug ("E\u0072\u0072\u006f\u0072\u003a\u0020s\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u0020n\u006f\u0074\u0020f\u006fu\u006e\u0064\u0021");return nil ,_c .New ("\u0073\u0074\u0061\u0072tx\u0072\u0065\u0066\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};if len (_aggf )> 2{_fg .Log .Debug ("\u0045\u0052\u0052O\u0052\u003a\u0020\u004du\u006c\u0074\u0069\u0070\u006c\u0065\u0020s\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u0020\u0028\u0025\u0073\u0029\u0021",_bbcf );return nil ,_c .New ("m\u0075\u006c\u0074\u0069\u0070\u006ce\u0020\u0073\u0074\u0061\u0072\u0074\u0078\u0072\u0065f\u0020\u0065\u006et\u0072i\u0065\u0073\u003f");};_cddd ,_ :=_e .ParseInt (_aggf [1],10,64);_fg .Log .Trace ("\u0073t\u0061r\u0074\u0078\u0072\u0065\u0066\u0020\u0061\u0074\u0020\u0025\u0064",_cddd );if _cddd > _bdbd {_fg .Log .Debug ("\u0045\u0052\u0052OR\u003a\u0020\u0058\u0072\u0065\u0066\u0020\u006f\u0066f\u0073e\u0074 \u006fu\u0074\u0073\u0069\u0064\u0065\u0020\u006f\u0066\u0020\u0066\u0069\u006c\u0065");_fg .Log .Debug ("\u0041\u0074\u0074\u0065\u006d\u0070\u0074\u0069\u006e\u0067\u0020\u0072e\u0070\u0061\u0069\u0072");_cddd ,_fdbb =_cdac .repairLocateXref ();if _fdbb !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0052\u0065\u0070\u0061\u0069\u0072\u0020\u0061\u0074\u0074\u0065\u006d\u0070t\u0020\u0066\u0061\u0069\u006c\u0065\u0064 \u0028\u0025\u0073\u0029");return nil ,_fdbb ;};};_cdac ._cdfe .Seek (_cddd ,_de .SeekStart );_cdac ._daba =_eg .NewReader (_cdac ._cdfe );_gefg ,_fdbb :=_cdac .parseXref ();if _fdbb !=nil {return nil ,_fdbb ;};_ecfb :=_gefg .Get ("\u0058R\u0065\u0066\u0053\u0074\u006d");if _ecfb !=nil {_bagd ,_dbfed :=_ecfb .(*PdfObjectInteger );if !_dbfed {return nil ,_c .New ("\u0058\u0052\u0065\u0066\u0053\u0074\u006d\u0020\u0021=\u0020\u0069\u006e\u0074");};_ ,_fdbb =_cdac .parseXrefStream (_bagd );if _fdbb !=nil {return nil ,_fdbb ;};};var _aba []int64 ;_ceba :=func (_bbg int64 ,_fefe []int64 )bool {for _ ,_eccf :=range _fefe {if _eccf ==_bbg {return true ;};};return false ;};_ecfb =_gefg .Get ("\u0050\u0072\u0065\u0076");for _ecfb !=nil {_fgfe ,_ebde :=_ecfb .(*PdfObjectInteger );if !_ebde {_fg .Log .Debug ("\u0049\u006ev\u0061\u006c\u0069\u0064\u0020P\u0072\u0065\u0076\u0020\u0072e\u0066\u0065\u0072\u0065\u006e\u0063\u0065\u003a\u0020\u004e\u006f\u0074\u0020\u0061\u0020\u002a\u0050\u0064\u0066\u004f\u0062\u006a\u0065\u0063\u0074\u0049\u006e\u0074\u0065\u0067\u0065\u0072\u0020\u0028\u0025\u0054\u0029",_ecfb );return _gefg ,nil ;};_geab :=*_fgfe ;_fg .Log .Trace ("\u0041\u006eot\u0068\u0065\u0072 \u0050\u0072\u0065\u0076 xr\u0065f \u0074\u0061\u0062\u006c\u0065\u0020\u006fbj\u0065\u0063\u0074\u0020\u0061\u0074\u0020%\u0064",_geab );_cdac ._cdfe .Seek (int64 (_geab ),_de .SeekStart );_cdac ._daba =_eg .NewReader (_cdac ._cdfe );_gcdb ,_bce :=_cdac .parseXref ();if _bce !=nil {_fg .Log .Debug ("\u0057\u0061\u0072\u006e\u0069\u006e\u0067\u003a\u0020\u0045\u0072\u0072\u006f\u0072\u0020-\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u006c\u006f\u0061\u0064\u0069n\u0067\u0020\u0061\u006e\u006f\u0074\u0068\u0065\u0072\u0020\u0028\u0050re\u0076\u0029\u0020\u0074\u0072\u0061\u0069\u006c\u0065\u0072");_fg .Log .Debug ("\u0041\u0074t\u0065\u006d\u0070\u0074i\u006e\u0067 \u0074\u006f\u0020\u0063\u006f\u006e\u0074\u0069n\u0075\u0065\u0020\u0062\u0079\u0020\u0069\u0067\u006e\u006f\u0072\u0069n\u0067\u0020\u0069\u0074");break ;};_ecfb =_gcdb .Get ("\u0050\u0072\u0065\u0076");if _ecfb !=nil {_ccee :=*(_ecfb .(*PdfObjectInteger ));if _ceba (int64 (_ccee ),_aba ){_fg .Log .Debug ("\u0050\u0072ev\u0065\u006e\u0074i\u006e\u0067\u0020\u0063irc\u0075la\u0072\u0020\u0078\u0072\u0065\u0066\u0020re\u0066\u0065\u0072\u0065\u006e\u0063\u0069n\u0067");break ;};_aba =append (_aba ,int64 (_ccee ));};};return _gefg ,nil ;};var _gedfe =_a .MustCompile ("\u005e\u005b\\\u002b\u002d\u002e\u005d*\u0028\u005b0\u002d\u0039\u002e\u005d\u002b\u0029\u005b\u0065E\u005d\u005b\u005c\u002b\u002d\u002e\u005d\u002a\u0028\u005b\u0030\u002d9\u002e\u005d\u002b\u0029");
+func (_bdf *PdfParser )Resolve (obj PdfObject )(PdfObject ,error ){if _ ,_gb :=obj .(*PdfObjectReference );!_gb {return obj ,nil ;};_bdf .mu .Lock ();defer _bdf .mu .Unlock ();return _bdf .resolve (obj );};
+
+// resolve resolves `obj` like Resolve, without locking the parser.
+func (_bdf *PdfParser )resolve (obj PdfObject )(PdfObject ,error ){_ecf ,_gb :=obj .(*PdfObjectReference );if !_gb {return obj ,nil ;};_cfc :=_bdf .GetFileOffset ();defer func (){_bdf .SetFileOffset (_cfc )}();_eca ,_dfe :=_bdf .lookupByReference (*_ecf );if _dfe !=nil {return nil ,_dfe ;};_gg ,_ecff :=_eca .(*PdfIndirectObject );if !_ecff {return _eca ,nil ;};_eca =_gg .PdfObject ;_ ,_gb =_eca .(*PdfObjectReference );if _gb {return _gg ,_c .New ("\u006d\u0075lt\u0069\u0020\u0064e\u0070\u0074\u0068\u0020tra\u0063e \u0070\u006f\u0069\u006e\u0074\u0065\u0072 t\u006f\u0020\u0070\u006f\u0069\u006e\u0074e\u0072");};return _eca ,nil ;};func (_cdac *PdfParser )loadXrefs ()(*PdfObjectDictionary ,error ){_cdac ._cgbgg .ObjectMap =make (map[int ]XrefObject );_cdac ._eaeg =make (objectStreams );_bdbd ,_fdbb :=_cdac ._cdfe .Seek (0,_de .SeekEnd );if _fdbb !=nil {return nil ,_fdbb ;};_fg .Log .Trace ("\u0066s\u0069\u007a\u0065\u003a\u0020\u0025d",_bdbd );_cdac ._eecde =_bdbd ;_fdbb =_cdac .seekToEOFMarker (_bdbd );if _fdbb !=nil {_fg .Log .Debug ("\u0046\u0061i\u006c\u0065\u0064\u0020\u0073\u0065\u0065\u006b\u0020\u0074\u006f\u0020\u0065\u006f\u0066\u0020\u006d\u0061\u0072\u006b\u0065\u0072: \u0025\u0076",_fdbb );return nil ,_fdbb ;};_aedfa ,_fdbb :=_cdac ._cdfe .Seek (0,_de .SeekCurrent );if _fdbb !=nil {return nil ,_fdbb ;};var _ddeae int64 =64;_gdfff :=_aedfa -_ddeae ;if _gdfff < 0{_gdfff =0;};_ ,_fdbb =_cdac ._cdfe .Seek (_gdfff ,_de .SeekStart );if _fdbb !=nil {return nil ,_fdbb ;};_bbcf :=make ([]byte ,_ddeae );_ ,_fdbb =_de .ReadFull (_cdac ._cdfe ,_bbcf );if _fdbb !=nil {_fg .Log .Debug ("\u0046\u0061i\u006c\u0065\u0064\u0020\u0072\u0065\u0061\u0064\u0069\u006e\u0067\u0020\u0077\u0068\u0069\u006c\u0065\u0020\u006c\u006f\u006f\u006b\u0069\u006e\u0067\u0020\u0066\u006f\u0072\u0020\u0073\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u003a\u0020\u0025\u0076",_fdbb );return nil ,_fdbb ;};_aggf :=_gfdf .FindStringSubmatch (string (_bbcf ));if len (_aggf )< 2{_fg .Log .Debug ("E\u0072\u0072\u006f\u0072\u003a\u0020s\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u0020n\u006f\u0074\u0020f\u006fu\u006e\u0064\u0021");return nil ,_c .New ("\u0073\u0074\u0061\u0072tx\u0072\u0065\u0066\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};if len (_aggf )> 2{_fg .Log .Debug ("\u0045\u0052\u0052O\u0052\u003a\u0020\u004du\u006c\u0074\u0069\u0070\u006c\u0065\u0020s\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u0020\u0028\u0025\u0073\u0029\u0021",_bbcf );return nil ,_c .New ("m\u0075\u006c\u0074\u0069\u0070\u006ce\u0020\u0073\u0074\u0061\u0072\u0074\u0078\u0072\u0065f\u0020\u0065\u006et\u0072i\u0065\u0073\u003f");};_cddd ,_ :=_e .ParseInt (_aggf [1],10,64);_fg .Log .Trace ("\u0073t\u0061r\u0074\u0078\u0072\u0065\u0066\u0020\u0061\u0074\u0020\u0025\u0064",_cddd );if _cddd > _bdbd {_fg .Log .Debug ("\u0045\u0052\u0052OR\u003a\u0020\u0058\u0072\u0065\u0066\u0020\u006f\u0066f\u0073e\u0074 \u006fu\u0074\u0073\u0069\u0064\u0065\u0020\u006f\u0066\u0020\u0066\u0069\u006c\u0065");_fg .Log .Debug ("\u0041\u0074\u0074\u0065\u006d\u0070\u0074\u0069\u006e\u0067\u0020\u0072e\u0070\u0061\u0069\u0072");_cddd ,_fdbb =_cdac .repairLocateXref ();if _fdbb !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0052\u0065\u0070\u0061\u0069\u0072\u0020\u0061\u0074\u0074\u0065\u006d\u0070t\u0020\u0066\u0061\u0069\u006c\u0065\u0064 \u0028\u0025\u0073\u0029");return nil ,_fdbb ;};};_cdac ._cdfe .Seek (_cddd ,_de .SeekStart );_cdac ._daba =_eg .NewReader (_cdac ._cdfe );_gefg ,_fdbb :=_cdac .parseXref ();if _fdbb !=nil {return nil ,_fdbb ;};_ecfb :=_gefg .Get ("\u0058R\u0065\u0066\u0053\u0074\u006d");if _ecfb !=nil {_bagd ,_dbfed :=_ecfb .(*PdfObjectInteger );if !_dbfed {return nil ,_c .New ("\u0058\u0052\u0065\u0066\u0053\u0074\u006d\u0020\u0021=\u0020\u0069\u006e\u0074");};_ ,_fdbb =_cdac .parseXrefStream (_bagd );if _fdbb !=nil {return nil ,_fdbb ;};};var _aba []int64 ;_ceba :=func (_bbg int64 ,_fefe []int64 )bool {for _ ,_eccf :=range _fefe {if _eccf ==_bbg {return true ;};};return false ;};_ecfb =_gefg .Get ("\u0050\u0072\u0065\u0076");for _ecfb !=nil {_fgfe ,_ebde :=_ecfb .(*PdfObjectInteger );if !_ebde {_fg .Log .Debug ("\u0049\u006ev\u0061\u006c\u0069\u0064\u0020P\u0072\u0065\u0076\u0020\u0072e\u0066\u0065\u0072\u0065\u006e\u0063\u0065\u003a\u0020\u004e\u006f\u0074\u0020\u0061\u0020\u002a\u0050\u0064\u0066\u004f\u0062\u006a\u0065\u0063\u0074\u0049\u006e\u0074\u0065\u0067\u0065\u0072\u0020\u0028\u0025\u0054\u0029",_ecfb );return _gefg ,nil ;};_geab :=*_fgfe ;_fg .Log .Trace ("\u0041\u006eot\u0068\u0065\u0072 \u0050\u0072\u0065\u0076 xr\u0065f \u0074\u0061\u0062\u006c\u0065\u0020\u006fbj\u0065\u0063\u0074\u0020\u0061\u0074\u0020%\u0064",_geab );_cdac ._cdfe .Seek (int64 (_geab ),_de .SeekStart );_cdac ._daba =_eg .NewReader (_cdac ._cdfe );_gcdb ,_bce :=_cdac .parseXref ();if _bce !=nil {_fg .Log .Debug ("\u0057\u0061\u0072\u006e\u0069\u006e\u0067\u003a\u0020\u0045\u0072\u0072\u006f\u0072\u0020-\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u006c\u006f\u0061\u0064\u0069n\u0067\u0020\u0061\u006e\u006f\u0074\u0068\u0065\u0072\u0020\u0028\u0050re\u0076\u0029\u0020\u0074\u0072\u0061\u0069\u006c\u0065\u0072");_fg .Log .Debug ("\u0041\u0074t\u0065\u006d\u0070\u0074i\u006e\u0067 \u0074\u006f\u0020\u0063\u006f\u006e\u0074\u0069n\u0075\u0065\u0020\u0062\u0079\u0020\u0069\u0067\u006e\u006f\u0072\u0069n\u0067\u0020\u0069\u0074");break ;};_ecfb =_gcdb .Get ("\u0050\u0072\u0065\u0076");if _ecfb !=nil {_ccee :=*(_ecfb .(*PdfObjectInteger ));if _ceba (int64 (_ccee ),_aba ){_fg .Log .Debug ("\u0050\u0072ev\u0065\u006e\u0074i\u006e\u0067\u0020\u0063irc\u0075la\u0072\u0020\u0078\u0072\u0065\u0066\u0020re\u0066\u0065\u0072\u0065\u006e\u0063\u0069n\u0067");break ;};_aba =append (_aba ,int64 (_ccee ));};};return _gefg ,nil ;};var _gedfe =_a .MustCompile ("\u005e\u005b\\\u002b\u002d\u002e\u005d*\u0028\u005b0\u002d\u0039\u002e\u005d\u002b\u0029\u005b\u0065E\u005d\u005b\u005c\u002b\u002d\u002e\u005d\u002a\u0028\u005b\u0030\u002d9\u002e\u005d\u002b\u0029");
 
 // EncryptInfo contains an information generated by the document encrypter.
 type EncryptInfo struct{Version ;
@@ -319,7 +317,7 @@ func (_abfc *PdfObjectBool )String ()string {if *_abfc {return "\u0074\u0072\u00
 // Traverses through all the subobjects (recursive).
 //
 // Does not look up references..  That should be done prior to calling.
-func (_fbd *PdfCrypt )Decrypt (obj PdfObject ,parentObjNum ,parentGenNum int64 )error {if _fbd .isDecrypted (obj ){return nil ;};switch _bedc :=obj .(type ){case *PdfIndirectObject :_fbd ._cc [_bedc ]=true ;_fg .Log .Trace ("\u0044\u0065\u0063\u0072\u0079\u0070\u0074\u0069\u006e\u0067 \u0069\u006e\u0064\u0069\u0072\u0065\u0063t\u0020\u0025\u0064\u0020\u0025\u0064\u0020\u006f\u0062\u006a\u0021",_bedc .ObjectNumber ,_bedc .GenerationNumber );_agf :=_bedc .ObjectNumber ;_bfd :=_bedc .GenerationNumber ;_afg :=_fbd .Decrypt (_bedc .PdfObject ,_agf ,_bfd );if _afg !=nil {return _afg ;};return nil ;case *PdfObjectStream :_fbd ._cc [_bedc ]=true ;_dad :=_bedc .PdfObjectDictionary ;if _fbd ._cgc .R !=5{if _abba ,_egag :=_dad .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );_egag &&*_abba =="\u0058\u0052\u0065\u0066"{return nil ;};};_dafd :=_bedc .ObjectNumber ;_cbag :=_bedc .GenerationNumber ;_fg .Log .Trace ("\u0044e\u0063\u0072\u0079\u0070t\u0069\u006e\u0067\u0020\u0073t\u0072e\u0061m\u0020\u0025\u0064\u0020\u0025\u0064\u0020!",_dafd ,_cbag );_dagf :=_bfb ;if _fbd ._age .V >=4{_dagf =_fbd ._gca ;_fg .Log .Trace ("\u0074\u0068\u0069\u0073.s\u0074\u0072\u0065\u0061\u006d\u0046\u0069\u006c\u0074\u0065\u0072\u0020\u003d\u0020%\u0073",_fbd ._gca );if _eeec ,_aaee :=_dad .Get ("\u0046\u0069\u006c\u0074\u0065\u0072").(*PdfObjectArray );_aaee {if _ecfd ,_feeg :=GetName (_eeec .Get (0));_feeg {if *_ecfd =="\u0043\u0072\u0079p\u0074"{_dagf ="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079";if _cda ,_ecfdd :=_dad .Get ("D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073").(*PdfObjectDictionary );_ecfdd {if _eab ,_aaec :=_cda .Get ("\u004e\u0061\u006d\u0065").(*PdfObjectName );_aaec {if _ ,_gec :=_fbd ._dfb [string (*_eab )];_gec {_fg .Log .Trace ("\u0055\u0073\u0069\u006eg \u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020%\u0073",*_eab );_dagf =string (*_eab );};};};};};};_fg .Log .Trace ("\u0077\u0069\u0074\u0068\u0020\u0025\u0073\u0020\u0066i\u006c\u0074\u0065\u0072",_dagf );if _dagf =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{return nil ;};};_dadd :=_fbd .Decrypt (_dad ,_dafd ,_cbag );if _dadd !=nil {return _dadd ;};_bafe ,_dadd :=_fbd .makeKey (_dagf ,uint32 (_dafd ),uint32 (_cbag ),_fbd ._gbb );if _dadd !=nil {return _dadd ;};_bedc .Stream ,_dadd =_fbd .decryptBytes (_bedc .Stream ,_dagf ,_bafe );if _dadd !=nil {return _dadd ;};_dad .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (int64 (len (_bedc .Stream ))));return nil ;case *PdfObjectString :_fg .Log .Trace ("\u0044e\u0063r\u0079\u0070\u0074\u0069\u006eg\u0020\u0073t\u0072\u0069\u006e\u0067\u0021");_cfb :=_bfb ;if _fbd ._age .V >=4{_fg .Log .Trace ("\u0077\u0069\u0074\u0068\u0020\u0025\u0073\u0020\u0066i\u006c\u0074\u0065\u0072",_fbd ._fcb );if _fbd ._fcb =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{return nil ;};_cfb =_fbd ._fcb ;};_ccbb ,_eec :=_fbd .makeKey (_cfb ,uint32 (parentObjNum ),uint32 (parentGenNum ),_fbd ._gbb );if _eec !=nil {return _eec ;};_cbc :=_bedc .Str ();_gaaa :=make ([]byte ,len (_cbc ));for _ggf :=0;_ggf < len (_cbc );_ggf ++{_gaaa [_ggf ]=_cbc [_ggf ];};_fg .Log .Trace ("\u0044e\u0063\u0072\u0079\u0070\u0074\u0020\u0073\u0074\u0072\u0069\u006eg\u003a\u0020\u0025\u0073\u0020\u003a\u0020\u0025\u0020\u0078",_gaaa ,_gaaa );_gaaa ,_eec =_fbd .decryptBytes (_gaaa ,_cfb ,_ccbb );if _eec !=nil {return _eec ;};_bedc ._abgc =string (_gaaa );return nil ;case *PdfObjectArray :for _ ,_ccdf :=range _bedc .Elements (){_cgb :=_fbd .Decrypt (_ccdf ,parentObjNum ,parentGenNum );if _cgb !=nil {return _cgb ;};};return nil ;case *PdfObjectDictionary :_fdf :=false ;if _fgfg :=_bedc .Get ("\u0054\u0079\u0070\u0065");_fgfg !=nil {_acd ,_afde :=_fgfg .(*PdfObjectName );if _afde &&*_acd =="\u0053\u0069\u0067"{_fdf =true ;};};for _ ,_gfff :=range _bedc .Keys (){_aag :=_bedc .Get (_gfff );if _fdf &&string (_gfff )=="\u0043\u006f\u006e\u0074\u0065\u006e\u0074\u0073"{continue ;};if string (_gfff )!="\u0050\u0061\u0072\u0065\u006e\u0074"&&string (_gfff )!="\u0050\u0072\u0065\u0076"&&string (_gfff )!="\u004c\u0061\u0073\u0074"{_dd :=_fbd .Decrypt (_aag ,parentObjNum ,parentGenNum );if _dd !=nil {return _dd ;};};};return nil ;};return nil ;};type objectStreams map[int ]objectStream ;
+func (_fbd *PdfCrypt )Decrypt (obj PdfObject ,parentObjNum ,parentGenNum int64 )error {if _fbd .isDecrypted (obj ){return nil ;};switch _bedc :=obj .(type ){case *PdfIndirectObject :_fbd ._cc [_bedc ]=true ;_fg .Log .Trace ("\u0044\u0065\u0063\u0072\u0079\u0070\u0074\u0069\u006e\u0067 \u0069\u006e\u0064\u0069\u0072\u0065\u0063t\u0020\u0025\u0064\u0020\u0025\u0064\u0020\u006f\u0062\u006a\u0021",_bedc .ObjectNumber ,_bedc .GenerationNumber );_agf :=_bedc .ObjectNumber ;_bfd :=_bedc .GenerationNumber ;_afg :=_fbd .Decrypt (_bedc .PdfObject ,_agf ,_bfd );if _afg !=nil {return _afg ;};return nil ;case *PdfObjectStream :_fbd ._cc [_bedc ]=true ;_dad :=_bedc .PdfObjectDictionary ;if _fbd ._cgc .R !=5{if _abba ,_egag :=_dad .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );_egag &&*_abba =="\u0058\u0052\u0065\u0066"{return nil ;};};_dafd :=_bedc .ObjectNumber ;_cbag :=_bedc .GenerationNumber ;_fg .Log .Trace ("\u0044e\u0063\u0072\u0079\u0070t\u0069\u006e\u0067\u0020\u0073t\u0072e\u0061m\u0020\u0025\u0064\u0020\u0025\u0064\u0020!",_dafd ,_cbag );_dagf ,_dadd :=_fbd .streamFilter (_dad );if _dadd !=nil {return _dadd ;};_fg .Log .Trace ("with %s filter",_dagf );if _dagf ==identityFilter {return nil ;};if _fbd ._gbb ==nil {delete (_fbd ._cc ,_bedc );return nil ;};_dadd =_fbd .Decrypt (_dad ,_dafd ,_cbag );if _dadd !=nil {return _dadd ;};_bafe ,_dadd :=_fbd .makeKey (_dagf ,uint32 (_dafd ),uint32 (_cbag ),_fbd ._gbb );if _dadd !=nil {return _dadd ;};_bedc .Stream ,_dadd =_fbd .decryptBytes (_bedc .Stream ,_dagf ,_bafe );if _dadd !=nil {return _dadd ;};_dad .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (int64 (len (_bedc .Stream ))));return nil ;case *PdfObjectString :_fg .Log .Trace ("\u0044e\u0063r\u0079\u0070\u0074\u0069\u006eg\u0020\u0073t\u0072\u0069\u006e\u0067\u0021");_cfb :=_bfb ;if _fbd ._age .V >=4{_fg .Log .Trace ("\u0077\u0069\u0074\u0068\u0020\u0025\u0073\u0020\u0066i\u006c\u0074\u0065\u0072",_fbd ._fcb );if _fbd ._fcb =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{return nil ;};_cfb =_fbd ._fcb ;};_ccbb ,_eec :=_fbd .makeKey (_cfb ,uint32 (parentObjNum ),uint32 (parentGenNum ),_fbd ._gbb );if _eec !=nil {return _eec ;};_cbc :=_bedc .Str ();_gaaa :=make ([]byte ,len (_cbc ));for _ggf :=0;_ggf < len (_cbc );_ggf ++{_gaaa [_ggf ]=_cbc [_ggf ];};_fg .Log .Trace ("\u0044e\u0063\u0072\u0079\u0070\u0074\u0020\u0073\u0074\u0072\u0069\u006eg\u003a\u0020\u0025\u0073\u0020\u003a\u0020\u0025\u0020\u0078",_gaaa ,_gaaa );_gaaa ,_eec =_fbd .decryptBytes (_gaaa ,_cfb ,_ccbb );if _eec !=nil {return _eec ;};_bedc ._abgc =string (_gaaa );return nil ;case *PdfObjectArray :for _ ,_ccdf :=range _bedc .Elements (){_cgb :=_fbd .Decrypt (_ccdf ,parentObjNum ,parentGenNum );if _cgb !=nil {return _cgb ;};};return nil ;case *PdfObjectDictionary :_fdf :=false ;if _fgfg :=_bedc .Get ("\u0054\u0079\u0070\u0065");_fgfg !=nil {_acd ,_afde :=_fgfg .(*PdfObjectName );if _afde &&*_acd =="\u0053\u0069\u0067"{_fdf =true ;};};for _ ,_gfff :=range _bedc .Keys (){_aag :=_bedc .Get (_gfff );if _fdf &&string (_gfff )=="\u0043\u006f\u006e\u0074\u0065\u006e\u0074\u0073"{continue ;};if string (_gfff )!="\u0050\u0061\u0072\u0065\u006e\u0074"&&string (_gfff )!="\u0050\u0072\u0065\u0076"&&string (_gfff )!="\u004c\u0061\u0073\u0074"{_dd :=_fbd .Decrypt (_aag ,parentObjNum ,parentGenNum );if _dd !=nil {return _dd ;};};};return nil ;};return nil ;};type objectStreams map[int ]objectStream ;
 
 // DecodeStream decodes a LZW encoded stream and returns the result as a
 // slice of bytes.
@@ -329,7 +327,7 @@ func (_egcc *LZWEncoder )DecodeStream (streamObj *PdfObjectStream )([]byte ,erro
 func (_gaf *FlateEncoder )DecodeStream (streamObj *PdfObjectStream )([]byte ,error ){_fg .Log .Trace ("\u0046l\u0061t\u0065\u0044\u0065\u0063\u006fd\u0065\u0020s\u0074\u0072\u0065\u0061\u006d");_fg .Log .Trace ("\u0050\u0072\u0065\u0064\u0069\u0063\u0074\u006f\u0072\u003a\u0020\u0025\u0064",_gaf .Predictor );if _gaf .BitsPerComponent !=8{return nil ,_gc .Errorf ("\u0069\u006ev\u0061\u006c\u0069\u0064\u0020\u0042\u0069\u0074\u0073\u0050\u0065\u0072\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074\u003d\u0025\u0064\u0020\u0028\u006f\u006e\u006c\u0079\u0020\u0038\u0020\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0029",_gaf .BitsPerComponent );};_cfef ,_ccbe :=_gaf .DecodeBytes (streamObj .Stream );if _ccbe !=nil {return nil ,_ccbe ;};_cfef ,_ccbe =_gaf .postDecodePredict (_cfef );if _ccbe !=nil {return nil ,_ccbe ;};return _gaf .cleanImageData (_cfef );};
 
 // MakeStreamDict makes a new instance of an encoding dictionary for a stream object.
-func (_bgbcg *MultiEncoder )MakeStreamDict ()*PdfObjectDictionary {_abbb :=MakeDict ();_abbb .Set ("\u0046\u0069\u006c\u0074\u0065\u0072",_bgbcg .GetFilterArray ());for _ ,_gade :=range _bgbcg ._gba {_gedf :=_gade .MakeStreamDict ();for _ ,_afacd :=range _gedf .Keys (){_egda :=_gedf .Get (_afacd );if _afacd !="\u0046\u0069\u006c\u0074\u0065\u0072"&&_afacd !="D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073"{_abbb .Set (_afacd ,_egda );};};};_beba :=_bgbcg .MakeDecodeParams ();if _beba !=nil {_abbb .Set ("D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073",_beba );};return _abbb ;};func (_acf *PdfParser )repairSeekXrefMarker ()error {_bfea ,_cec :=_acf ._cdfe .Seek (0,_de .SeekEnd );if _cec !=nil {return _cec ;};_bcceg :=_a .MustCompile ("\u005cs\u0078\u0072\u0065\u0066\u005c\u0073*");var _edcab int64 ;var _fgfb int64 =1000;for _edcab < _bfea {if _bfea <=(_fgfb +_edcab ){_fgfb =_bfea -_edcab ;};_ ,_gbdg :=_acf ._cdfe .Seek (-_edcab -_fgfb ,_de .SeekEnd );if _gbdg !=nil {return _gbdg ;};_ggeaa :=make ([]byte ,_fgfb );_acf ._cdfe .Read (_ggeaa );_fg .Log .Trace ("\u004c\u006f\u006fki\u006e\u0067\u0020\u0066\u006f\u0072\u0020\u0078\u0072\u0065\u0066\u0020\u003a\u0020\u0022\u0025\u0073\u0022",string (_ggeaa ));_cdfd :=_bcceg .FindAllStringIndex (string (_ggeaa ),-1);if _cdfd !=nil {_bdbbc :=_cdfd [len (_cdfd )-1];_fg .Log .Trace ("\u0049\u006e\u0064\u003a\u0020\u0025\u0020\u0064",_cdfd );_acf ._cdfe .Seek (-_edcab -_fgfb +int64 (_bdbbc [0]),_de .SeekEnd );_acf ._daba =_eg .NewReader (_acf ._cdfe );for {_eefa ,_gaceb :=_acf ._daba .Peek (1);if _gaceb !=nil {return _gaceb ;};_fg .Log .Trace ("\u0042\u003a\u0020\u0025\u0064\u0020\u0025\u0063",_eefa [0],_eefa [0]);if !IsWhiteSpace (_eefa [0]){break ;};_acf ._daba .Discard (1);};return nil ;};_fg .Log .Debug ("\u0057\u0061\u0072\u006e\u0069\u006eg\u003a\u0020\u0045\u004f\u0046\u0020\u006d\u0061\u0072\u006b\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075n\u0064\u0021\u0020\u002d\u0020\u0063\u006f\u006e\u0074\u0069\u006e\u0075\u0065\u0020s\u0065e\u006b\u0069\u006e\u0067");_edcab +=_fgfb ;};_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u003a\u0020\u0058\u0072\u0065\u0066\u0020\u0074a\u0062\u006c\u0065\u0020\u006d\u0061r\u006b\u0065\u0072\u0020\u0077\u0061\u0073\u0020\u006e\u006f\u0074\u0020\u0066o\u0075\u006e\u0064\u002e");return _c .New ("\u0078r\u0065f\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u0020");};
+func (_bgbcg *MultiEncoder )MakeStreamDict ()*PdfObjectDictionary {_abbb :=MakeDict ();_abbb .Set ("\u0046\u0069\u006c\u0074\u0065\u0072",_bgbcg .GetFilterArray ());for _ ,_gade :=range _bgbcg ._gba {_gedf :=_gade .MakeStreamDict ();for _ ,_afacd :=range _gedf .Keys (){_egda :=_gedf .Get (_afacd );if _afacd !="\u0046\u0069\u006c\u0074\u0065\u0072"&&_afacd !="D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073"{_abbb .Set (_afacd ,_egda );};};};_beba :=_bgbcg .MakeDecodeParams ();if _beba !=nil {_abbb .Set ("D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073",_beba );};return _abbb ;};func (_acf *PdfParser )repairSeekXrefMarker ()error {_bfea ,_cec :=_acf ._cdfe .Seek (0,_de .SeekEnd );if _cec !=nil {return _cec ;};_bcceg :=_a .MustCompile ("\u005cs\u0078\u0072\u0065\u0066\u005c\u0073*");var _edcab int64 ;var _fgfb int64 =1000;for _edcab < _bfea {if _bfea <=(_fgfb +_edcab ){_fgfb =_bfea -_edcab ;};_ ,_gbdg :=_acf ._cdfe .Seek (-_edcab -_fgfb ,_de .SeekEnd );if _gbdg !=nil {return _gbdg ;};_ggeaa :=make ([]byte ,_fgfb );_de .ReadFull (_acf ._cdfe ,_ggeaa );_fg .Log .Trace ("\u004c\u006f\u006fki\u006e\u0067\u0020\u0066\u006f\u0072\u0020\u0078\u0072\u0065\u0066\u0020\u003a\u0020\u0022\u0025\u0073\u0022",string (_ggeaa ));_cdfd :=_bcceg .FindAllStringIndex (string (_ggeaa ),-1);if _cdfd !=nil {_bdbbc :=_cdfd [len (_cdfd )-1];_fg .Log .Trace ("\u0049\u006e\u0064\u003a\u0020\u0025\u0020\u0064",_cdfd );_acf ._cdfe .Seek (-_edcab -_fgfb +int64 (_bdbbc [0]),_de .SeekEnd );_acf ._daba =_eg .NewReader (_acf ._cdfe );for {_eefa ,_gaceb :=_acf ._daba .Peek (1);if _gaceb !=nil {return _gaceb ;};_fg .Log .Trace ("\u0042\u003a\u0020\u0025\u0064\u0020\u0025\u0063",_eefa [0],_eefa [0]);if !IsWhiteSpace (_eefa [0]){break ;};_acf ._daba .Discard (1);};return nil ;};_fg .Log .Debug ("\u0057\u0061\u0072\u006e\u0069\u006eg\u003a\u0020\u0045\u004f\u0046\u0020\u006d\u0061\u0072\u006b\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075n\u0064\u0021\u0020\u002d\u0020\u0063\u006f\u006e\u0074\u0069\u006e\u0075\u0065\u0020s\u0065e\u006b\u0069\u006e\u0067");_edcab +=_fgfb ;};_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u003a\u0020\u0058\u0072\u0065\u0066\u0020\u0074a\u0062\u006c\u0065\u0020\u006d\u0061r\u006b\u0065\u0072\u0020\u0077\u0061\u0073\u0020\u006e\u006f\u0074\u0020\u0066o\u0075\u006e\u0064\u002e");return _c .New ("\u0078r\u0065f\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u0020");};
 
 // WriteString outputs the object as it is to be written to file.
 func (_dcbc *PdfObjectInteger )WriteString ()string {return _e .FormatInt (int64 (*_dcbc ),10)};
@@ -356,7 +354,7 @@ func MakeArrayFromIntegers64 (vals []int64 )*PdfObjectArray {_aceg :=MakeArray (
 func NewParser (rs _de .ReadSeeker )(*PdfParser ,error ){_dbeg :=&PdfParser {_cdfe :rs ,ObjCache :make (objectCache ),_bcaa :map[int64 ]bool {}};_ggc ,_ecgcg ,_cbef :=_dbeg .parsePdfVersion ();if _cbef !=nil {_fg .Log .Error ("U\u006e\u0061\u0062\u006c\u0065\u0020t\u006f\u0020\u0070\u0061\u0072\u0073\u0065\u0020\u0076e\u0072\u0073\u0069o\u006e:\u0020\u0025\u0076",_cbef );return nil ,_cbef ;};_dbeg ._adagd .Major =_ggc ;_dbeg ._adagd .Minor =_ecgcg ;if _dbeg ._eaeb ,_cbef =_dbeg .loadXrefs ();_cbef !=nil {_fg .Log .Debug ("\u0045\u0052RO\u0052\u003a\u0020F\u0061\u0069\u006c\u0065d t\u006f l\u006f\u0061\u0064\u0020\u0078\u0072\u0065f \u0074\u0061\u0062\u006c\u0065\u0021\u0020%\u0073",_cbef );return nil ,_cbef ;};_fg .Log .Trace ("T\u0072\u0061\u0069\u006c\u0065\u0072\u003a\u0020\u0025\u0073",_dbeg ._eaeb );if len (_dbeg ._cgbgg .ObjectMap )==0{return nil ,_gc .Errorf ("\u0065\u006d\u0070\u0074\u0079\u0020\u0058\u0052\u0045\u0046\u0020t\u0061\u0062\u006c\u0065\u0020\u002d\u0020\u0049\u006e\u0076a\u006c\u0069\u0064");};return _dbeg ,nil ;};
 
 // GetFilterName returns the name of the encoding filter.
-func (_add *ASCII85Encoder )GetFilterName ()string {return StreamEncodingFilterNameASCII85 };func (_efab *PdfParser )resolveReference (_gbbfg *PdfObjectReference )(PdfObject ,bool ,error ){_dfag ,_abff :=_efab .ObjCache [int (_gbbfg .ObjectNumber )];if _abff {return _dfag ,true ,nil ;};_bfaeg ,_ffce :=_efab .LookupByReference (*_gbbfg );if _ffce !=nil {return nil ,false ,_ffce ;};_efab .ObjCache [int (_gbbfg .ObjectNumber )]=_bfaeg ;return _bfaeg ,false ,nil ;};
+func (_add *ASCII85Encoder )GetFilterName ()string {return StreamEncodingFilterNameASCII85 };func (_efab *PdfParser )resolveReference (_gbbfg *PdfObjectReference )(PdfObject ,bool ,error ){_efab .mu .Lock ();defer _efab .mu .Unlock ();_dfag ,_abff :=_efab .cachedObject (int (_gbbfg .ObjectNumber ));if _abff {return _dfag ,true ,nil ;};_bfaeg ,_ffce :=_efab .lookupByReference (*_gbbfg );if _ffce !=nil {return nil ,false ,_ffce ;};_efab .cacheObject (int (_gbbfg .ObjectNumber ),_bfaeg );return _bfaeg ,false ,nil ;};
 
 // ToGoImage converts the JBIG2Image to the golang image.Image.
 func (_eced *JBIG2Image )ToGoImage ()(_cg .Image ,error ){const _dfgfd ="J\u0042I\u0047\u0032\u0049\u006d\u0061\u0067\u0065\u002eT\u006f\u0047\u006f\u0049ma\u0067\u0065";if _eced .Data ==nil {return nil ,_ad .Error (_dfgfd ,"\u0069\u006d\u0061\u0067e \u0064\u0061\u0074\u0061\u0020\u006e\u006f\u0074\u0020\u0064\u0065\u0066\u0069\u006ee\u0064");};if _eced .Width ==0||_eced .Height ==0{return nil ,_ad .Error (_dfgfd ,"\u0069\u006d\u0061\u0067\u0065\u0020h\u0065\u0069\u0067\u0068\u0074\u0020\u006f\u0072\u0020\u0077\u0069\u0064\u0074h\u0020\u006e\u006f\u0074\u0020\u0064\u0065f\u0069\u006e\u0065\u0064");};_edbd ,_edff :=_ee .NewImage (_eced .Width ,_eced .Height ,1,1,_eced .Data ,nil ,nil );if _edff !=nil {return nil ,_edff ;};return _edbd ,nil ;};func (_adg *PdfParser )lookupByNumberWrapper (_ecd int ,_ef bool )(PdfObject ,bool ,error ){_eff ,_cb ,_bac :=_adg .lookupByNumber (_ecd ,_ef );if _bac !=nil {return nil ,_cb ,_bac ;};if !_cb &&_adg ._abd !=nil &&!_adg ._abd .isDecrypted (_eff ){_bdd :=_adg ._abd .Decrypt (_eff ,0,0);if _bdd !=nil {return nil ,_cb ,_bdd ;};};return _eff ,_cb ,nil ;};
@@ -411,7 +409,13 @@ DefaultPageSettings JBIG2EncoderSettings ;};
 func (_cgd *LZWEncoder )MakeStreamDict ()*PdfObjectDictionary {_gceg :=MakeDict ();_gceg .Set ("\u0046\u0069\u006c\u0074\u0065\u0072",MakeName (_cgd .GetFilterName ()));_bbag :=_cgd .MakeDecodeParams ();if _bbag !=nil {_gceg .Set ("D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073",_bbag );};_gceg .Set ("E\u0061\u0072\u006c\u0079\u0043\u0068\u0061\u006e\u0067\u0065",MakeInteger (int64 (_cgd .EarlyChange )));return _gceg ;};func _bfead (_dabf ,_ffcf PdfObject ,_gbcdf int )bool {if _gbcdf > _cffg {_fg .Log .Error ("\u0054\u0072ac\u0065\u0020\u0064e\u0070\u0074\u0068\u0020lev\u0065l \u0062\u0065\u0079\u006f\u006e\u0064\u0020%d\u0020\u002d\u0020\u0065\u0072\u0072\u006fr\u0021",_cffg );return false ;};if _dabf ==nil &&_ffcf ==nil {return true ;}else if _dabf ==nil ||_ffcf ==nil {return false ;};if _g .TypeOf (_dabf )!=_g .TypeOf (_ffcf ){return false ;};switch _gcfa :=_dabf .(type ){case *PdfObjectNull ,*PdfObjectReference :return true ;case *PdfObjectName :return *_gcfa ==*(_ffcf .(*PdfObjectName ));case *PdfObjectString :return *_gcfa ==*(_ffcf .(*PdfObjectString ));case *PdfObjectInteger :return *_gcfa ==*(_ffcf .(*PdfObjectInteger ));case *PdfObjectBool :return *_gcfa ==*(_ffcf .(*PdfObjectBool ));case *PdfObjectFloat :return *_gcfa ==*(_ffcf .(*PdfObjectFloat ));case *PdfIndirectObject :return _bfead (TraceToDirectObject (_dabf ),TraceToDirectObject (_ffcf ),_gbcdf +1);case *PdfObjectArray :_ffeea :=_ffcf .(*PdfObjectArray );if len ((*_gcfa )._bcea )!=len ((*_ffeea )._bcea ){return false ;};for _fcfc ,_ffdba :=range (*_gcfa )._bcea {if !_bfead (_ffdba ,(*_ffeea )._bcea [_fcfc ],_gbcdf +1){return false ;};};return true ;case *PdfObjectDictionary :_fcgf :=_ffcf .(*PdfObjectDictionary );_fddf ,_ddefa :=(*_gcfa )._acacc ,(*_fcgf )._acacc ;if len (_fddf )!=len (_ddefa ){return false ;};for _cgcf ,_cgbefd :=range _fddf {_begdc ,_bdda :=_ddefa [_cgcf ];if !_bdda ||!_bfead (_cgbefd ,_begdc ,_gbcdf +1){return false ;};};return true ;case *PdfObjectStream :_aeac :=_ffcf .(*PdfObjectStream );return _bfead ((*_gcfa ).PdfObjectDictionary ,(*_aeac ).PdfObjectDictionary ,_gbcdf +1);default:_fg .Log .Error ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0055\u006e\u006b\u006e\u006f\u0077\u006e\u0020\u0074\u0079\u0070\u0065\u003a\u0020\u0025\u0054\u0020\u002d\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u006e\u0065\u0076\u0065\u0072\u0020\u0068\u0061\u0070\u0070\u0065\u006e\u0021",_dabf );};return false ;};func (_bdegg *PdfParser )skipComments ()error {if _ ,_dcdc :=_bdegg .skipSpaces ();_dcdc !=nil {return _dcdc ;};_gacf :=true ;for {_ccgg ,_gfgf :=_bdegg ._daba .Peek (1);if _gfgf !=nil {_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u0020\u0025\u0073",_gfgf .Error ());return _gfgf ;};if _gacf &&_ccgg [0]!='%'{return nil ;};_gacf =false ;if (_ccgg [0]!='\r')&&(_ccgg [0]!='\n'){_bdegg ._daba .ReadByte ();}else {break ;};};return _bdegg .skipComments ();};func (_edab *PdfParser )inspect ()(map[string ]int ,error ){_fg .Log .Trace ("\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u0049\u004e\u0053P\u0045\u0043\u0054\u0020\u002d\u002d\u002d\u002d\u002d\u002d-\u002d\u002d\u002d");_fg .Log .Trace ("X\u0072\u0065\u0066\u0020\u0074\u0061\u0062\u006c\u0065\u003a");_cagbd :=map[string ]int {};_fegd :=0;_eafc :=0;var _dcebf []int ;for _ffbf :=range _edab ._cgbgg .ObjectMap {_dcebf =append (_dcebf ,_ffbf );};_b .Ints (_dcebf );_fbad :=0;for _ ,_begc :=range _dcebf {_bbdb :=_edab ._cgbgg .ObjectMap [_begc ];if _bbdb .ObjectNumber ==0{continue ;};_fegd ++;_fg .Log .Trace ("\u003d\u003d\u003d\u003d\u003d\u003d\u003d\u003d\u003d\u003d");_fg .Log .Trace ("\u004c\u006f\u006f\u006bi\u006e\u0067\u0020\u0075\u0070\u0020\u006f\u0062\u006a\u0065c\u0074 \u006e\u0075\u006d\u0062\u0065\u0072\u003a \u0025\u0064",_bbdb .ObjectNumber );_fedfa ,_efec :=_edab .LookupByNumber (_bbdb .ObjectNumber );if _efec !=nil {_fg .Log .Trace ("\u0045\u0052\u0052\u004f\u0052\u003a \u0046\u0061\u0069\u006c\u0020\u0074\u006f\u0020\u006c\u006f\u006f\u006b\u0075p\u0020\u006f\u0062\u006a\u0020\u0025\u0064 \u0028\u0025\u0073\u0029",_bbdb .ObjectNumber ,_efec );_eafc ++;continue ;};_fg .Log .Trace ("\u006fb\u006a\u003a\u0020\u0025\u0073",_fedfa );_deba ,_gbceg :=_fedfa .(*PdfIndirectObject );if _gbceg {_fg .Log .Trace ("\u0049N\u0044 \u004f\u004f\u0042\u004a\u0020\u0025\u0064\u003a\u0020\u0025\u0073",_bbdb .ObjectNumber ,_deba );_eegfb ,_bdcg :=_deba .PdfObject .(*PdfObjectDictionary );if _bdcg {if _ebee ,_dabb :=_eegfb .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );_dabb {_egcf :=string (*_ebee );_fg .Log .Trace ("\u002d\u002d\u002d\u003e\u0020\u004f\u0062\u006a\u0020\u0074\u0079\u0070e\u003a\u0020\u0025\u0073",_egcf );_ ,_eeda :=_cagbd [_egcf ];if _eeda {_cagbd [_egcf ]++;}else {_cagbd [_egcf ]=1;};}else if _dede ,_gcfbd :=_eegfb .Get ("\u0053u\u0062\u0074\u0079\u0070\u0065").(*PdfObjectName );_gcfbd {_gcbee :=string (*_dede );_fg .Log .Trace ("-\u002d-\u003e\u0020\u004f\u0062\u006a\u0020\u0073\u0075b\u0074\u0079\u0070\u0065: \u0025\u0073",_gcbee );_ ,_befa :=_cagbd [_gcbee ];if _befa {_cagbd [_gcbee ]++;}else {_cagbd [_gcbee ]=1;};};if _dggg ,_fbag :=_eegfb .Get ("\u0053").(*PdfObjectName );_fbag &&*_dggg =="\u004a\u0061\u0076\u0061\u0053\u0063\u0072\u0069\u0070\u0074"{_ ,_bcac :=_cagbd ["\u004a\u0061\u0076\u0061\u0053\u0063\u0072\u0069\u0070\u0074"];if _bcac {_cagbd ["\u004a\u0061\u0076\u0061\u0053\u0063\u0072\u0069\u0070\u0074"]++;}else {_cagbd ["\u004a\u0061\u0076\u0061\u0053\u0063\u0072\u0069\u0070\u0074"]=1;};};};}else if _gccag ,_gddg :=_fedfa .(*PdfObjectStream );_gddg {if _cggab ,_fddb :=_gccag .PdfObjectDictionary .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );_fddb {_fg .Log .Trace ("\u002d\u002d\u003e\u0020\u0053\u0074\u0072\u0065\u0061\u006d\u0020o\u0062\u006a\u0065\u0063\u0074\u0020\u0074\u0079\u0070\u0065:\u0020\u0025\u0073",*_cggab );_abec :=string (*_cggab );_cagbd [_abec ]++;};}else {_fdae ,_cebaa :=_fedfa .(*PdfObjectDictionary );if _cebaa {_dgge ,_fedec :=_fdae .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );if _fedec {_ffea :=string (*_dgge );_fg .Log .Trace ("\u002d-\u002d \u006f\u0062\u006a\u0020\u0074\u0079\u0070\u0065\u0020\u0025\u0073",_ffea );_cagbd [_ffea ]++;};};_fg .Log .Trace ("\u0044\u0049\u0052\u0045\u0043\u0054\u0020\u004f\u0042\u004a\u0020\u0025d\u003a\u0020\u0025\u0073",_bbdb .ObjectNumber ,_fedfa );};_fbad ++;};_fg .Log .Trace ("\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u0045\u004fF\u0020\u0049\u004e\u0053\u0050\u0045\u0043T\u0020\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u002d");_fg .Log .Trace ("\u003d=\u003d\u003d\u003d\u003d\u003d");_fg .Log .Trace ("\u004f\u0062j\u0065\u0063\u0074 \u0063\u006f\u0075\u006e\u0074\u003a\u0020\u0025\u0064",_fegd );_fg .Log .Trace ("\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u006c\u006f\u006f\u006b\u0075p\u003a\u0020\u0025\u0064",_eafc );for _dfdf ,_bdef :=range _cagbd {_fg .Log .Trace ("\u0025\u0073\u003a\u0020\u0025\u0064",_dfdf ,_bdef );};_fg .Log .Trace ("\u003d=\u003d\u003d\u003d\u003d\u003d");if len (_edab ._cgbgg .ObjectMap )< 1{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0054\u0068\u0069\u0073 \u0064\u006f\u0063\u0075\u006d\u0065\u006e\u0074 \u0069s\u0020\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0028\u0078\u0072\u0065\u0066\u0020\u0074\u0061\u0062l\u0065\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0021\u0029");return nil ,_gc .Errorf ("\u0069\u006ev\u0061\u006c\u0069\u0064 \u0064\u006fc\u0075\u006d\u0065\u006e\u0074\u0020\u0028\u0078r\u0065\u0066\u0020\u0074\u0061\u0062\u006c\u0065\u0020\u006d\u0069\u0073s\u0069\u006e\u0067\u0029");};_bgaa ,_dffd :=_cagbd ["\u0046\u006f\u006e\u0074"];if !_dffd ||_bgaa < 2{_fg .Log .Trace ("\u0054\u0068\u0069s \u0064\u006f\u0063\u0075\u006d\u0065\u006e\u0074\u0020i\u0073 \u0070r\u006fb\u0061\u0062\u006c\u0079\u0020\u0073\u0063\u0061\u006e\u006e\u0065\u0064\u0021");}else {_fg .Log .Trace ("\u0054\u0068\u0069\u0073\u0020\u0064\u006f\u0063\u0075\u006d\u0065\u006e\u0074\u0020\u0069\u0073\u0020\u0076\u0061\u006c\u0069\u0064\u0020\u0066o\u0072\u0020\u0065\u0078\u0074r\u0061\u0063t\u0069\u006f\u006e\u0021");};return _cagbd ,nil ;};
 
 // PdfParser parses a PDF file and provides access to the object structure of the PDF.
-type PdfParser struct{_adagd Version ;_cdfe _de .ReadSeeker ;_daba *_eg .Reader ;_eecde int64 ;_cgbgg XrefTable ;_cfed int64 ;_fagf *xrefType ;_eaeg objectStreams ;_eaeb *PdfObjectDictionary ;_abd *PdfCrypt ;_gacd bool ;ObjCache objectCache ;_dce map[int ]bool ;_bcaa map[int64 ]bool ;};func (_fda *PdfParser )lookupByNumber (_geg int ,_baf bool )(PdfObject ,bool ,error ){_eeb ,_afb :=_fda .ObjCache [_geg ];if _afb {_fg .Log .Trace ("\u0052\u0065\u0074\u0075\u0072\u006e\u0069\u006e\u0067\u0020\u0063a\u0063\u0068\u0065\u0064\u0020\u006f\u0062\u006a\u0065\u0063t\u0020\u0025\u0064",_geg );return _eeb ,false ,nil ;};if _fda ._dce ==nil {_fda ._dce =map[int ]bool {};};if _fda ._dce [_geg ]{_fg .Log .Debug ("ER\u0052\u004f\u0052\u003a\u0020\u004c\u006fok\u0075\u0070\u0020\u006f\u0066\u0020\u0025\u0064\u0020\u0069\u0073\u0020\u0061\u006c\u0072e\u0061\u0064\u0079\u0020\u0069\u006e\u0020\u0070\u0072\u006f\u0067\u0072\u0065\u0073\u0073\u0020\u002d\u0020\u0072\u0065c\u0075\u0072\u0073\u0069\u0076\u0065 \u006c\u006f\u006f\u006b\u0075\u0070\u0020\u0061\u0074t\u0065m\u0070\u0074\u0020\u0062\u006c\u006f\u0063\u006b\u0065\u0064",_geg );return nil ,false ,_c .New ("\u0072\u0065\u0063\u0075\u0072\u0073\u0069\u0076\u0065\u0020\u006c\u006f\u006f\u006b\u0075p\u0020a\u0074\u0074\u0065\u006d\u0070\u0074\u0020\u0062\u006c\u006f\u0063\u006b\u0065\u0064");};_fda ._dce [_geg ]=true ;defer delete (_fda ._dce ,_geg );_bb ,_afb :=_fda ._cgbgg .ObjectMap [_geg ];if !_afb {_fg .Log .Trace ("\u0055\u006e\u0061\u0062l\u0065\u0020\u0074\u006f\u0020\u006c\u006f\u0063\u0061t\u0065\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0069\u006e\u0020\u0078\u0072\u0065\u0066\u0073\u0021 \u002d\u0020\u0052\u0065\u0074u\u0072\u006e\u0069\u006e\u0067\u0020\u006e\u0075\u006c\u006c\u0020\u006f\u0062\u006a\u0065\u0063\u0074");var _ffd PdfObjectNull ;return &_ffd ,false ,nil ;};_fg .Log .Trace ("L\u006fo\u006b\u0075\u0070\u0020\u006f\u0062\u006a\u0020n\u0075\u006d\u0062\u0065r \u0025\u0064",_geg );if _bb .XType ==XrefTypeTableEntry {_fg .Log .Trace ("\u0078r\u0065f\u006f\u0062\u006a\u0020\u006fb\u006a\u0020n\u0075\u006d\u0020\u0025\u0064",_bb .ObjectNumber );_fg .Log .Trace ("\u0078\u0072\u0065\u0066\u006f\u0062\u006a\u0020\u0067e\u006e\u0020\u0025\u0064",_bb .Generation );_fg .Log .Trace ("\u0078\u0072\u0065\u0066\u006f\u0062\u006a\u0020\u006f\u0066\u0066\u0073e\u0074\u0020\u0025\u0064",_bb .Offset );_fda ._cdfe .Seek (_bb .Offset ,_de .SeekStart );_fda ._daba =_eg .NewReader (_fda ._cdfe );_cbf ,_gde :=_fda .ParseIndirectObject ();if _gde !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0046\u0061\u0069\u006ce\u0064\u0020\u0072\u0065\u0061\u0064\u0069n\u0067\u0020\u0078\u0072\u0065\u0066\u0020\u0028\u0025\u0073\u0029",_gde );if _baf {_fg .Log .Debug ("\u0041\u0074t\u0065\u006d\u0070\u0074i\u006e\u0067 \u0074\u006f\u0020\u0072\u0065\u0070\u0061\u0069r\u0020\u0078\u0072\u0065\u0066\u0073\u0020\u0028\u0074\u006f\u0070\u0020d\u006f\u0077\u006e\u0029");_bca ,_bef :=_fda .repairRebuildXrefsTopDown ();if _bef !=nil {_fg .Log .Debug ("\u0045R\u0052\u004f\u0052\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020r\u0065\u0070\u0061\u0069\u0072\u0020\u0028\u0025\u0073\u0029",_bef );return nil ,false ,_bef ;};_fda ._cgbgg =*_bca ;return _fda .lookupByNumber (_geg ,false );};return nil ,false ,_gde ;};if _baf {_gfd ,_ ,_ :=_dc (_cbf );if int (_gfd )!=_geg {_fg .Log .Debug ("\u0049n\u0076\u0061\u006c\u0069d\u0020\u0078\u0072\u0065\u0066s\u003a \u0052e\u0062\u0075\u0069\u006c\u0064\u0069\u006eg");_bba :=_fda .rebuildXrefTable ();if _bba !=nil {return nil ,false ,_bba ;};_fda .ObjCache =objectCache {};return _fda .lookupByNumberWrapper (_geg ,false );};};_fg .Log .Trace ("\u0052\u0065\u0074\u0075\u0072\u006e\u0069\u006e\u0067\u0020\u006f\u0062\u006a");_fda .ObjCache [_geg ]=_cbf ;return _cbf ,false ,nil ;}else if _bb .XType ==XrefTypeObjectStream {_fg .Log .Trace ("\u0078r\u0065\u0066\u0020\u0066\u0072\u006f\u006d\u0020\u006f\u0062\u006ae\u0063\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0021");_fg .Log .Trace ("\u003e\u004c\u006f\u0061\u0064\u0020\u0076\u0069\u0061\u0020\u004f\u0053\u0021");_fg .Log .Trace ("\u004f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d \u0061\u0076\u0061\u0069\u006c\u0061b\u006c\u0065\u0020\u0069\u006e\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020%\u0064\u002f\u0025\u0064",_bb .OsObjNumber ,_bb .OsObjIndex );if _bb .OsObjNumber ==_geg {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u0020\u0043i\u0072\u0063\u0075\u006c\u0061\u0072\u0020\u0072\u0065f\u0065\u0072\u0065n\u0063e\u0021\u003f\u0021");return nil ,true ,_c .New ("\u0078\u0072\u0065f \u0063\u0069\u0072\u0063\u0075\u006c\u0061\u0072\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065");};if _ ,_edf :=_fda ._cgbgg .ObjectMap [_bb .OsObjNumber ];_edf {_cbb ,_ced :=_fda .lookupObjectViaOS (_bb .OsObjNumber ,_geg );if _ced !=nil {_fg .Log .Debug ("\u0045R\u0052\u004f\u0052\u0020\u0052\u0065\u0074\u0075\u0072\u006e\u0069n\u0067\u0020\u0045\u0052\u0052\u0020\u0028\u0025\u0073\u0029",_ced );return nil ,true ,_ced ;};_fg .Log .Trace ("\u003c\u004c\u006f\u0061\u0064\u0065\u0064\u0020\u0076i\u0061\u0020\u004f\u0053");_fda .ObjCache [_geg ]=_cbb ;if _fda ._abd !=nil {_fda ._abd ._cc [_cbb ]=true ;};return _cbb ,true ,nil ;};_fg .Log .Debug ("\u003f\u003f\u0020\u0042\u0065\u006c\u006f\u006eg\u0073\u0020\u0074o \u0061\u0020\u006e\u006f\u006e\u002dc\u0072\u006f\u0073\u0073\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065\u0064 \u006f\u0062\u006a\u0065\u0063\u0074\u0020\u002e.\u002e\u0021");return nil ,true ,_c .New ("\u006f\u0073\u0020\u0062\u0065\u006c\u006fn\u0067\u0073\u0020t\u006f\u0020\u0061\u0020n\u006f\u006e\u0020\u0063\u0072\u006f\u0073\u0073\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074");};return nil ,false ,_c .New ("\u0075\u006e\u006b\u006e\u006f\u0077\u006e\u0020\u0078\u0072\u0065\u0066 \u0074\u0079\u0070\u0065");};
+// The object lookups and the resolution of references are safe for concurrent use.
+type PdfParser struct{_adagd Version ;_cdfe _de .ReadSeeker ;_daba *_eg .Reader ;_eecde int64 ;_cgbgg XrefTable ;_cfed int64 ;_fagf *xrefType ;_eaeg objectStreams ;_eaeb *PdfObjectDictionary ;_abd *PdfCrypt ;_gacd bool ;
+
+// ObjCache holds the objects loaded by the parser, by object number. It is guarded by the
+// parser lock like the other state of the parser: it must not be accessed while the parser
+// may be used concurrently, e.g. by extractor.ProcessPages.
+ObjCache objectCache ;_dce map[int ]bool ;_bcaa map[int64 ]bool ;repairReport *RepairReport ;mu _bdc .Mutex ;cache cacheBudget ;};func (_fda *PdfParser )lookupByNumber (_geg int ,_baf bool )(PdfObject ,bool ,error ){_eeb ,_afb :=_fda .cachedObject (_geg );if _afb {_fg .Log .Trace ("\u0052\u0065\u0074\u0075\u0072\u006e\u0069\u006e\u0067\u0020\u0063a\u0063\u0068\u0065\u0064\u0020\u006f\u0062\u006a\u0065\u0063t\u0020\u0025\u0064",_geg );return _eeb ,false ,nil ;};if _fda ._dce ==nil {_fda ._dce =map[int ]bool {};};if _fda ._dce [_geg ]{_fg .Log .Debug ("ER\u0052\u004f\u0052\u003a\u0020\u004c\u006fok\u0075\u0070\u0020\u006f\u0066\u0020\u0025\u0064\u0020\u0069\u0073\u0020\u0061\u006c\u0072e\u0061\u0064\u0079\u0020\u0069\u006e\u0020\u0070\u0072\u006f\u0067\u0072\u0065\u0073\u0073\u0020\u002d\u0020\u0072\u0065c\u0075\u0072\u0073\u0069\u0076\u0065 \u006c\u006f\u006f\u006b\u0075\u0070\u0020\u0061\u0074t\u0065m\u0070\u0074\u0020\u0062\u006c\u006f\u0063\u006b\u0065\u0064",_geg );return nil ,false ,_c .New ("\u0072\u0065\u0063\u0075\u0072\u0073\u0069\u0076\u0065\u0020\u006c\u006f\u006f\u006b\u0075p\u0020a\u0074\u0074\u0065\u006d\u0070\u0074\u0020\u0062\u006c\u006f\u0063\u006b\u0065\u0064");};_fda ._dce [_geg ]=true ;defer delete (_fda ._dce ,_geg );_bb ,_afb :=_fda ._cgbgg .ObjectMap [_geg ];if !_afb {_fg .Log .Trace ("\u0055\u006e\u0061\u0062l\u0065\u0020\u0074\u006f\u0020\u006c\u006f\u0063\u0061t\u0065\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0069\u006e\u0020\u0078\u0072\u0065\u0066\u0073\u0021 \u002d\u0020\u0052\u0065\u0074u\u0072\u006e\u0069\u006e\u0067\u0020\u006e\u0075\u006c\u006c\u0020\u006f\u0062\u006a\u0065\u0063\u0074");var _ffd PdfObjectNull ;return &_ffd ,false ,nil ;};_fg .Log .Trace ("L\u006fo\u006b\u0075\u0070\u0020\u006f\u0062\u006a\u0020n\u0075\u006d\u0062\u0065r \u0025\u0064",_geg );if _bb .XType ==XrefTypeTableEntry {_fg .Log .Trace ("\u0078r\u0065f\u006f\u0062\u006a\u0020\u006fb\u006a\u0020n\u0075\u006d\u0020\u0025\u0064",_bb .ObjectNumber );_fg .Log .Trace ("\u0078\u0072\u0065\u0066\u006f\u0062\u006a\u0020\u0067e\u006e\u0020\u0025\u0064",_bb .Generation );_fg .Log .Trace ("\u0078\u0072\u0065\u0066\u006f\u0062\u006a\u0020\u006f\u0066\u0066\u0073e\u0074\u0020\u0025\u0064",_bb .Offset );_fda ._cdfe .Seek (_bb .Offset ,_de .SeekStart );_fda ._daba =_eg .NewReader (_fda ._cdfe );_cbf ,_gde :=_fda .ParseIndirectObject ();if _gde !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0046\u0061\u0069\u006ce\u0064\u0020\u0072\u0065\u0061\u0064\u0069n\u0067\u0020\u0078\u0072\u0065\u0066\u0020\u0028\u0025\u0073\u0029",_gde );if _baf {_fg .Log .Debug ("\u0041\u0074t\u0065\u006d\u0070\u0074i\u006e\u0067 \u0074\u006f\u0020\u0072\u0065\u0070\u0061\u0069r\u0020\u0078\u0072\u0065\u0066\u0073\u0020\u0028\u0074\u006f\u0070\u0020d\u006f\u0077\u006e\u0029");_bca ,_bef :=_fda .repairRebuildXrefsTopDown ();if _bef !=nil {_fg .Log .Debug ("\u0045R\u0052\u004f\u0052\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020r\u0065\u0070\u0061\u0069\u0072\u0020\u0028\u0025\u0073\u0029",_bef );return nil ,false ,_bef ;};_fda ._cgbgg =*_bca ;return _fda .lookupByNumber (_geg ,false );};return nil ,false ,_gde ;};if _baf {_gfd ,_ ,_ :=_dc (_cbf );if int (_gfd )!=_geg {_fg .Log .Debug ("\u0049n\u0076\u0061\u006c\u0069d\u0020\u0078\u0072\u0065\u0066s\u003a \u0052e\u0062\u0075\u0069\u006c\u0064\u0069\u006eg");_bba :=_fda .rebuildXrefTable ();if _bba !=nil {return nil ,false ,_bba ;};_fda .resetObjectCache ();return _fda .lookupByNumberWrapper (_geg ,false );};};_fg .Log .Trace ("\u0052\u0065\u0074\u0075\u0072\u006e\u0069\u006e\u0067\u0020\u006f\u0062\u006a");_fda .cacheObject (_geg ,_cbf );return _cbf ,false ,nil ;}else if _bb .XType ==XrefTypeObjectStream {_fg .Log .Trace ("\u0078r\u0065\u0066\u0020\u0066\u0072\u006f\u006d\u0020\u006f\u0062\u006ae\u0063\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0021");_fg .Log .Trace ("\u003e\u004c\u006f\u0061\u0064\u0020\u0076\u0069\u0061\u0020\u004f\u0053\u0021");_fg .Log .Trace ("\u004f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d \u0061\u0076\u0061\u0069\u006c\u0061b\u006c\u0065\u0020\u0069\u006e\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020%\u0064\u002f\u0025\u0064",_bb .OsObjNumber ,_bb .OsObjIndex );if _bb .OsObjNumber ==_geg {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u0020\u0043i\u0072\u0063\u0075\u006c\u0061\u0072\u0020\u0072\u0065f\u0065\u0072\u0065n\u0063e\u0021\u003f\u0021");return nil ,true ,_c .New ("\u0078\u0072\u0065f \u0063\u0069\u0072\u0063\u0075\u006c\u0061\u0072\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065");};if _ ,_edf :=_fda ._cgbgg .ObjectMap [_bb .OsObjNumber ];_edf {_cbb ,_ced :=_fda .lookupObjectViaOS (_bb .OsObjNumber ,_geg );if _ced !=nil {_fg .Log .Debug ("\u0045R\u0052\u004f\u0052\u0020\u0052\u0065\u0074\u0075\u0072\u006e\u0069n\u0067\u0020\u0045\u0052\u0052\u0020\u0028\u0025\u0073\u0029",_ced );return nil ,true ,_ced ;};_fg .Log .Trace ("\u003c\u004c\u006f\u0061\u0064\u0065\u0064\u0020\u0076i\u0061\u0020\u004f\u0053");_fda .cacheObject (_geg ,_cbb );if _fda ._abd !=nil {_fda ._abd ._cc [_cbb ]=true ;};return _cbb ,true ,nil ;};_fg .Log .Debug ("\u003f\u003f\u0020\u0042\u0065\u006c\u006f\u006eg\u0073\u0020\u0074o \u0061\u0020\u006e\u006f\u006e\u002dc\u0072\u006f\u0073\u0073\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065\u0064 \u006f\u0062\u006a\u0065\u0063\u0074\u0020\u002e.\u002e\u0021");return nil ,true ,_c .New ("\u006f\u0073\u0020\u0062\u0065\u006c\u006fn\u0067\u0073\u0020t\u006f\u0020\u0061\u0020n\u006f\u006e\u0020\u0063\u0072\u006f\u0073\u0073\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074");};return nil ,false ,_c .New ("\u0075\u006e\u006b\u006e\u006f\u0077\u006e\u0020\u0078\u0072\u0065\u0066 \u0074\u0079\u0070\u0065");};
 
 // GetFilterName returns the name of the encoding filter.
 func (_agad *DCTEncoder )GetFilterName ()string {return StreamEncodingFilterNameDCT };func (_fga *PdfParser )parseXrefStream (_cddf *PdfObjectInteger )(*PdfObjectDictionary ,error ){if _cddf !=nil {_fg .Log .Trace ("\u0058\u0052\u0065f\u0053\u0074\u006d\u0020x\u0072\u0065\u0066\u0020\u0074\u0061\u0062l\u0065\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0061\u0074\u0020\u0025\u0064",_cddf );_fga ._cdfe .Seek (int64 (*_cddf ),_de .SeekStart );_fga ._daba =_eg .NewReader (_fga ._cdfe );};_fgca :=_fga .GetFileOffset ();_aabba ,_fegb :=_fga .ParseIndirectObject ();if _fegb !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0046\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020\u0072\u0065\u0061d\u0020\u0078\u0072\u0065\u0066\u0020\u006fb\u006a\u0065\u0063\u0074");return nil ,_c .New ("\u0066\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020\u0072e\u0061\u0064\u0020\u0078\u0072\u0065\u0066\u0020\u006f\u0062j\u0065\u0063\u0074");};_fg .Log .Trace ("\u0058R\u0065f\u0053\u0074\u006d\u0020\u006fb\u006a\u0065c\u0074\u003a\u0020\u0025\u0073",_aabba );_afab ,_cade :=_aabba .(*PdfObjectStream );if !_cade {_fg .Log .Debug ("\u0045R\u0052\u004fR\u003a\u0020\u0058R\u0065\u0066\u0053\u0074\u006d\u0020\u0070o\u0069\u006e\u0074\u0069\u006e\u0067 \u0074\u006f\u0020\u006e\u006f\u006e\u002d\u0073\u0074\u0072\u0065a\u006d\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0021");return nil ,_c .New ("\u0058\u0052\u0065\u0066\u0053\u0074\u006d\u0020\u0070\u006f\u0069\u006e\u0074i\u006e\u0067\u0020\u0074\u006f\u0020a\u0020\u006e\u006f\u006e\u002d\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006fb\u006a\u0065\u0063\u0074");};_gdfg :=_afab .PdfObjectDictionary ;_ffee ,_cade :=_afab .PdfObjectDictionary .Get ("\u0053\u0069\u007a\u0065").(*PdfObjectInteger );if !_cade {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u004d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0073\u0069\u007a\u0065\u0020f\u0072\u006f\u006d\u0020\u0078\u0072\u0065f\u0020\u0073\u0074\u006d");return nil ,_c .New ("\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0053\u0069\u007ae\u0020\u0066\u0072\u006f\u006d\u0020\u0078\u0072\u0065\u0066 \u0073\u0074\u006d");};if int64 (*_ffee )> 8388607{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0078\u0072\u0065\u0066\u0020\u0053\u0069\u007a\u0065\u0020\u0065x\u0063\u0065\u0065\u0064\u0065\u0064\u0020l\u0069\u006d\u0069\u0074\u002c\u0020\u006f\u0076\u0065\u0072\u00208\u0033\u0038\u0038\u0036\u0030\u0037\u0020\u0028\u0025\u0064\u0029",*_ffee );return nil ,_c .New ("\u0072\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b\u0020e\u0072\u0072\u006f\u0072");};_afgb :=_afab .PdfObjectDictionary .Get ("\u0057");_abbe ,_cade :=_afgb .(*PdfObjectArray );if !_cade {return nil ,_c .New ("\u0069n\u0076\u0061\u006c\u0069\u0064\u0020\u0057\u0020\u0069\u006e\u0020x\u0072\u0065\u0066\u0020\u0073\u0074\u0072\u0065\u0061\u006d");};_bdac :=_abbe .Len ();if _bdac !=3{_fg .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0078\u0072\u0065\u0066\u0020\u0073\u0074\u006d\u0020\u0028\u006c\u0065\u006e\u0028\u0057\u0029\u0020\u0021\u003d\u0020\u0033\u0020\u002d\u0020\u0025\u0064\u0029",_bdac );return nil ,_c .New ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0078\u0072\u0065f\u0020s\u0074\u006d\u0020\u006c\u0065\u006e\u0028\u0057\u0029\u0020\u0021\u003d\u0020\u0033");};var _gebe []int64 ;for _acga :=0;_acga < 3;_acga ++{_agbf ,_fcc :=GetInt (_abbe .Get (_acga ));if !_fcc {return nil ,_c .New ("i\u006e\u0076\u0061\u006cid\u0020w\u0020\u006f\u0062\u006a\u0065c\u0074\u0020\u0074\u0079\u0070\u0065");};_gebe =append (_gebe ,int64 (*_agbf ));};_gdcec ,_fegb :=DecodeStream (_afab );if _fegb !=nil {_fg .Log .Debug ("\u0045\u0052\u0052OR\u003a\u0020\u0055\u006e\u0061\u0062\u006c\u0065\u0020t\u006f \u0064e\u0063o\u0064\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u003a\u0020\u0025\u0076",_fegb );return nil ,_fegb ;};_bfaga :=int (_gebe [0]);_ebfaa :=int (_gebe [0]+_gebe [1]);_dcgc :=int (_gebe [0]+_gebe [1]+_gebe [2]);_dgbc :=int (_gebe [0]+_gebe [1]+_gebe [2]);if _bfaga < 0||_ebfaa < 0||_dcgc < 0{_fg .Log .Debug ("\u0045\u0072\u0072\u006fr\u0020\u0073\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u003c \u0030 \u0028\u0025\u0064\u002c\u0025\u0064\u002c%\u0064\u0029",_bfaga ,_ebfaa ,_dcgc );return nil ,_c .New ("\u0072\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b\u0020e\u0072\u0072\u006f\u0072");};if _dgbc ==0{_fg .Log .Debug ("\u004e\u006f\u0020\u0078\u0072\u0065\u0066\u0020\u006f\u0062\u006a\u0065\u0063t\u0073\u0020\u0069\u006e\u0020\u0073t\u0072\u0065\u0061\u006d\u0020\u0028\u0064\u0065\u006c\u0074\u0061\u0062\u0020=\u003d\u0020\u0030\u0029");return _gdfg ,nil ;};_ccdge :=len (_gdcec )/_dgbc ;_cdbgac :=0;_fbea :=_afab .PdfObjectDictionary .Get ("\u0049\u006e\u0064e\u0078");var _cebdd []int ;if _fbea !=nil {_fg .Log .Trace ("\u0049n\u0064\u0065\u0078\u003a\u0020\u0025b",_fbea );_bcae ,_gagf :=_fbea .(*PdfObjectArray );if !_gagf {_fg .Log .Debug ("\u0049\u006e\u0076\u0061\u006ci\u0064\u0020\u0049\u006e\u0064\u0065\u0078\u0020\u006f\u0062\u006a\u0065\u0063t\u0020\u0028\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0062\u0065\u0020\u0061\u006e\u0020\u0061\u0072\u0072\u0061\u0079\u0029");return nil ,_c .New ("i\u006ev\u0061\u006c\u0069\u0064\u0020\u0049\u006e\u0064e\u0078\u0020\u006f\u0062je\u0063\u0074");};if _bcae .Len ()%2!=0{_fg .Log .Debug ("\u0057\u0041\u0052\u004eI\u004e\u0047\u0020\u0046\u0061\u0069\u006c\u0075\u0072e\u0020\u006c\u006f\u0061\u0064\u0069\u006e\u0067\u0020\u0078\u0072\u0065\u0066\u0020\u0073\u0074\u006d\u0020i\u006e\u0064\u0065\u0078\u0020n\u006f\u0074\u0020\u006d\u0075\u006c\u0074\u0069\u0070\u006c\u0065\u0020\u006f\u0066\u0020\u0032\u002e");return nil ,_c .New ("\u0072\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b\u0020e\u0072\u0072\u006f\u0072");};_cdbgac =0;_gega ,_bbcd :=_bcae .ToIntegerArray ();if _bbcd !=nil {_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072 \u0067\u0065\u0074\u0074\u0069\u006e\u0067\u0020\u0069\u006e\u0064\u0065\u0078 \u0061\u0072\u0072\u0061\u0079\u0020\u0061\u0073\u0020\u0069\u006e\u0074\u0065\u0067\u0065\u0072\u0073\u003a\u0020\u0025\u0076",_bbcd );return nil ,_bbcd ;};for _ebcg :=0;_ebcg < len (_gega );_ebcg +=2{_dggfd :=_gega [_ebcg ];_aacf :=_gega [_ebcg +1];for _ffad :=0;_ffad < _aacf ;_ffad ++{_cebdd =append (_cebdd ,_dggfd +_ffad );};_cdbgac +=_aacf ;};}else {for _fede :=0;_fede < int (*_ffee );_fede ++{_cebdd =append (_cebdd ,_fede );};_cdbgac =int (*_ffee );};if _ccdge ==_cdbgac +1{_fg .Log .Debug ("\u0049n\u0063\u006f\u006d\u0070ati\u0062\u0069\u006c\u0069t\u0079\u003a\u0020\u0049\u006e\u0064\u0065\u0078\u0020\u006di\u0073\u0073\u0069\u006e\u0067\u0020\u0063\u006f\u0076\u0065\u0072\u0061\u0067\u0065\u0020\u006f\u0066\u0020\u0031\u0020\u006f\u0062\u006ae\u0063\u0074\u0020\u002d\u0020\u0061\u0070\u0070en\u0064\u0069\u006eg\u0020\u006f\u006e\u0065\u0020-\u0020M\u0061\u0079\u0020\u006c\u0065\u0061\u0064\u0020\u0074o\u0020\u0070\u0072\u006f\u0062\u006c\u0065\u006d\u0073");_aeaf :=_cdbgac -1;for _ ,_cfgd :=range _cebdd {if _cfgd > _aeaf {_aeaf =_cfgd ;};};_cebdd =append (_cebdd ,_aeaf +1);_cdbgac ++;};if _ccdge !=len (_cebdd ){_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020x\u0072\u0065\u0066 \u0073\u0074\u006d:\u0020\u006eu\u006d\u0020\u0065\u006e\u0074\u0072i\u0065s \u0021\u003d\u0020\u006c\u0065\u006e\u0028\u0069\u006e\u0064\u0069\u0063\u0065\u0073\u0029\u0020\u0028\u0025\u0064\u0020\u0021\u003d\u0020\u0025\u0064\u0029",_ccdge ,len (_cebdd ));return nil ,_c .New ("\u0078\u0072ef\u0020\u0073\u0074m\u0020\u006e\u0075\u006d en\u0074ri\u0065\u0073\u0020\u0021\u003d\u0020\u006cen\u0028\u0069\u006e\u0064\u0069\u0063\u0065s\u0029");};_fg .Log .Trace ("\u004f\u0062j\u0065\u0063\u0074s\u0020\u0063\u006f\u0075\u006e\u0074\u0020\u0025\u0064",_cdbgac );_fg .Log .Trace ("\u0049\u006e\u0064i\u0063\u0065\u0073\u003a\u0020\u0025\u0020\u0064",_cebdd );_afdfd :=func (_bedce []byte )int64 {var _dcdf int64 ;for _bgfb :=0;_bgfb < len (_bedce );_bgfb ++{_dcdf +=int64 (_bedce [_bgfb ])*(1<<uint (8*(len (_bedce )-_bgfb -1)));};return _dcdf ;};_fg .Log .Trace ("\u0044e\u0063\u006f\u0064\u0065d\u0020\u0073\u0074\u0072\u0065a\u006d \u006ce\u006e\u0067\u0074\u0068\u003a\u0020\u0025d",len (_gdcec ));_bfdd :=0;for _ffbd :=0;_ffbd < len (_gdcec );_ffbd +=_dgbc {_ebcgb :=_gfab (len (_gdcec ),_ffbd ,_ffbd +_bfaga );if _ebcgb !=nil {_fg .Log .Debug ("\u0049\u006e\u0076al\u0069\u0064\u0020\u0073\u006c\u0069\u0063\u0065\u0020\u0072\u0061\u006e\u0067\u0065\u003a\u0020\u0025\u0076",_ebcgb );return nil ,_ebcgb ;};_dgefa :=_gdcec [_ffbd :_ffbd +_bfaga ];_ebcgb =_gfab (len (_gdcec ),_ffbd +_bfaga ,_ffbd +_ebfaa );if _ebcgb !=nil {_fg .Log .Debug ("\u0049\u006e\u0076al\u0069\u0064\u0020\u0073\u006c\u0069\u0063\u0065\u0020\u0072\u0061\u006e\u0067\u0065\u003a\u0020\u0025\u0076",_ebcgb );return nil ,_ebcgb ;};_gcdf :=_gdcec [_ffbd +_bfaga :_ffbd +_ebfaa ];_ebcgb =_gfab (len (_gdcec ),_ffbd +_ebfaa ,_ffbd +_dcgc );if _ebcgb !=nil {_fg .Log .Debug ("\u0049\u006e\u0076al\u0069\u0064\u0020\u0073\u006c\u0069\u0063\u0065\u0020\u0072\u0061\u006e\u0067\u0065\u003a\u0020\u0025\u0076",_ebcgb );return nil ,_ebcgb ;};_efge :=_gdcec [_ffbd +_ebfaa :_ffbd +_dcgc ];_ebda :=_afdfd (_dgefa );_cbfc :=_afdfd (_gcdf );_fagfe :=_afdfd (_efge );if _gebe [0]==0{_ebda =1;};if _bfdd >=len (_cebdd ){_fg .Log .Debug ("X\u0052\u0065\u0066\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u002d\u0020\u0054\u0072\u0079\u0069\u006e\u0067\u0020\u0074\u006f\u0020\u0061\u0063\u0063e\u0073s\u0020\u0069\u006e\u0064e\u0078\u0020o\u0075\u0074\u0020\u006f\u0066\u0020\u0062\u006f\u0075\u006e\u0064\u0073\u0020\u002d\u0020\u0062\u0072\u0065\u0061\u006b\u0069\u006e\u0067");break ;};_aadd :=_cebdd [_bfdd ];_bfdd ++;_fg .Log .Trace ("%\u0064\u002e\u0020\u0070\u0031\u003a\u0020\u0025\u0020\u0078",_aadd ,_dgefa );_fg .Log .Trace ("%\u0064\u002e\u0020\u0070\u0032\u003a\u0020\u0025\u0020\u0078",_aadd ,_gcdf );_fg .Log .Trace ("%\u0064\u002e\u0020\u0070\u0033\u003a\u0020\u0025\u0020\u0078",_aadd ,_efge );_fg .Log .Trace ("\u0025d\u002e \u0078\u0072\u0065\u0066\u003a \u0025\u0064 \u0025\u0064\u0020\u0025\u0064",_aadd ,_ebda ,_cbfc ,_fagfe );if _ebda ==0{_fg .Log .Trace ("-\u0020\u0046\u0072\u0065\u0065\u0020o\u0062\u006a\u0065\u0063\u0074\u0020-\u0020\u0063\u0061\u006e\u0020\u0070\u0072o\u0062\u0061\u0062\u006c\u0079\u0020\u0069\u0067\u006e\u006fr\u0065");}else if _ebda ==1{_fg .Log .Trace ("\u002d\u0020I\u006e\u0020\u0075\u0073e\u0020\u002d \u0075\u006e\u0063\u006f\u006d\u0070\u0072\u0065s\u0073\u0065\u0064\u0020\u0076\u0069\u0061\u0020\u006f\u0066\u0066\u0073e\u0074\u0020\u0025\u0062",_gcdf );if _cbfc ==_fgca {_fg .Log .Debug ("\u0055\u0070d\u0061\u0074\u0069\u006e\u0067\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0066\u006f\u0072\u0020\u0058\u0052\u0065\u0066\u0020\u0074\u0061\u0062\u006c\u0065\u0020\u0025\u0064\u0020\u002d\u003e\u0020\u0025\u0064",_aadd ,_afab .ObjectNumber );_aadd =int (_afab .ObjectNumber );};if _dcad ,_gcfdb :=_fga ._cgbgg .ObjectMap [_aadd ];!_gcfdb ||int (_fagfe )> _dcad .Generation {_feebc :=XrefObject {ObjectNumber :_aadd ,XType :XrefTypeTableEntry ,Offset :_cbfc ,Generation :int (_fagfe )};_fga ._cgbgg .ObjectMap [_aadd ]=_feebc ;};}else if _ebda ==2{_fg .Log .Trace ("\u002d\u0020\u0049\u006e \u0075\u0073\u0065\u0020\u002d\u0020\u0063\u006f\u006d\u0070r\u0065s\u0073\u0065\u0064\u0020\u006f\u0062\u006ae\u0063\u0074");if _ ,_dbd :=_fga ._cgbgg .ObjectMap [_aadd ];!_dbd {_ebgda :=XrefObject {ObjectNumber :_aadd ,XType :XrefTypeObjectStream ,OsObjNumber :int (_cbfc ),OsObjIndex :int (_fagfe )};_fga ._cgbgg .ObjectMap [_aadd ]=_ebgda ;_fg .Log .Trace ("\u0065\u006e\u0074\u0072\u0079\u003a\u0020\u0025\u002b\u0076",_ebgda );};}else {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052:\u0020\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u002d\u0049\u004e\u0056\u0041L\u0049\u0044\u0020\u0054\u0059\u0050\u0045\u0020\u0058\u0072\u0065\u0066\u0053\u0074\u006d\u0020\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u003f\u002d\u002d\u002d\u002d\u002d\u002d-");continue ;};};if _fga ._fagf ==nil {_fcfg :=XrefTypeObjectStream ;_fga ._fagf =&_fcfg ;};return _gdfg ,nil ;};func (_egf *FlateEncoder )postDecodePredict (_gcg []byte )([]byte ,error ){if _egf .Predictor > 1{if _egf .Predictor ==2{_fg .Log .Trace ("\u0054\u0069\u0066\u0066\u0020\u0065\u006e\u0063\u006f\u0064\u0069\u006e\u0067");_fg .Log .Trace ("\u0043\u006f\u006c\u006f\u0072\u0073\u003a\u0020\u0025\u0064",_egf .Colors );_beg :=_egf .Columns *_egf .Colors ;if _beg < 1{return []byte {},nil ;};_faa :=len (_gcg )/_beg ;if len (_gcg )%_beg !=0{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020T\u0049\u0046\u0046 \u0065\u006e\u0063\u006fd\u0069\u006e\u0067\u003a\u0020\u0049\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0072\u006f\u0077\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u002e\u002e\u002e");return nil ,_gc .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0072\u006f\u0077 \u006c\u0065\u006e\u0067\u0074\u0068\u0020\u0028\u0025\u0064/\u0025\u0064\u0029",len (_gcg ),_beg );};if _beg %_egf .Colors !=0{return nil ,_gc .Errorf ("\u0069\u006ev\u0061\u006c\u0069\u0064 \u0072\u006fw\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u0020(\u0025\u0064\u0029\u0020\u0066\u006f\u0072\u0020\u0063\u006f\u006c\u006fr\u0073\u0020\u0025\u0064",_beg ,_egf .Colors );};if _beg > len (_gcg ){_fg .Log .Debug ("\u0052\u006fw\u0020\u006c\u0065\u006e\u0067t\u0068\u0020\u0063\u0061\u006en\u006f\u0074\u0020\u0062\u0065\u0020\u006c\u006f\u006e\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u0064\u0061\u0074\u0061\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u0020\u0028\u0025\u0064\u002f\u0025\u0064\u0029",_beg ,len (_gcg ));return nil ,_c .New ("\u0072\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b\u0020e\u0072\u0072\u006f\u0072");};_fg .Log .Trace ("i\u006e\u0070\u0020\u006fut\u0044a\u0074\u0061\u0020\u0028\u0025d\u0029\u003a\u0020\u0025\u0020\u0078",len (_gcg ),_gcg );_daad :=_gcd .NewBuffer (nil );for _ceb :=0;_ceb < _faa ;_ceb ++{_eccd :=_gcg [_beg *_ceb :_beg *(_ceb +1)];for _ecfde :=_egf .Colors ;_ecfde < _beg ;_ecfde ++{_eccd [_ecfde ]+=_eccd [_ecfde -_egf .Colors ];};_daad .Write (_eccd );};_aec :=_daad .Bytes ();_fg .Log .Trace ("\u0050O\u0075t\u0044\u0061\u0074\u0061\u0020(\u0025\u0064)\u003a\u0020\u0025\u0020\u0078",len (_aec ),_aec );return _aec ,nil ;}else if _egf .Predictor >=10&&_egf .Predictor <=15{_fg .Log .Trace ("\u0050\u004e\u0047 \u0045\u006e\u0063\u006f\u0064\u0069\u006e\u0067");_agcg :=_egf .Columns *_egf .Colors +1;_bggb :=len (_gcg )/_agcg ;if len (_gcg )%_agcg !=0{return nil ,_gc .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0072\u006f\u0077 \u006c\u0065\u006e\u0067\u0074\u0068\u0020\u0028\u0025\u0064/\u0025\u0064\u0029",len (_gcg ),_agcg );};if _agcg > len (_gcg ){_fg .Log .Debug ("\u0052\u006fw\u0020\u006c\u0065\u006e\u0067t\u0068\u0020\u0063\u0061\u006en\u006f\u0074\u0020\u0062\u0065\u0020\u006c\u006f\u006e\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u0064\u0061\u0074\u0061\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u0020\u0028\u0025\u0064\u002f\u0025\u0064\u0029",_agcg ,len (_gcg ));return nil ,_c .New ("\u0072\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b\u0020e\u0072\u0072\u006f\u0072");};_fadg :=_gcd .NewBuffer (nil );_fg .Log .Trace ("P\u0072\u0065\u0064\u0069ct\u006fr\u0020\u0063\u006f\u006c\u0075m\u006e\u0073\u003a\u0020\u0025\u0064",_egf .Columns );_fg .Log .Trace ("\u004ce\u006e\u0067\u0074\u0068:\u0020\u0025\u0064\u0020\u002f \u0025d\u0020=\u0020\u0025\u0064\u0020\u0072\u006f\u0077s",len (_gcg ),_agcg ,_bggb );_egg :=make ([]byte ,_agcg );for _ddf :=0;_ddf < _agcg ;_ddf ++{_egg [_ddf ]=0;};_gga :=_egf .Colors ;for _cdcd :=0;_cdcd < _bggb ;_cdcd ++{_gcge :=_gcg [_agcg *_cdcd :_agcg *(_cdcd +1)];_bdfeb :=_gcge [0];switch _bdfeb {case _adbd :case _daff :for _ggfd :=1+_gga ;_ggfd < _agcg ;_ggfd ++{_gcge [_ggfd ]+=_gcge [_ggfd -_gga ];};case _ecfa :for _eebe :=1;_eebe < _agcg ;_eebe ++{_gcge [_eebe ]+=_egg [_eebe ];};case _daaa :for _gffc :=1;_gffc < _gga +1;_gffc ++{_gcge [_gffc ]+=_egg [_gffc ]/2;};for _gedc :=_gga +1;_gedc < _agcg ;_gedc ++{_gcge [_gedc ]+=byte ((int (_gcge [_gedc -_gga ])+int (_egg [_gedc ]))/2);};case _dafa :for _dde :=1;_dde < _agcg ;_dde ++{var _ded ,_gfe ,_aced byte ;_gfe =_egg [_dde ];if _dde >=_gga +1{_ded =_gcge [_dde -_gga ];_aced =_egg [_dde -_gga ];};_gcge [_dde ]+=_becgc (_ded ,_gfe ,_aced );};default:_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0049\u006e\u0076\u0061\u006c\u0069d\u0020\u0066\u0069\u006c\u0074\u0065r\u0020\u0062\u0079\u0074\u0065\u0020\u0028\u0025\u0064\u0029\u0020\u0040\u0072o\u0077\u0020\u0025\u0064",_bdfeb ,_cdcd );return nil ,_gc .Errorf ("\u0069n\u0076\u0061\u006c\u0069\u0064\u0020\u0066\u0069\u006c\u0074\u0065r\u0020\u0062\u0079\u0074\u0065\u0020\u0028\u0025\u0064\u0029",_bdfeb );};copy (_egg ,_gcge );_fadg .Write (_gcge [1:]);};_fdg :=_fadg .Bytes ();return _fdg ,nil ;}else {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0070r\u0065\u0064\u0069\u0063\u0074\u006f\u0072 \u0028\u0025\u0064\u0029",_egf .Predictor );return nil ,_gc .Errorf ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064 \u0070\u0072\u0065\u0064\u0069\u0063\u0074\u006f\u0072\u0020(\u0025\u0064\u0029",_egf .Predictor );};};return _gcg ,nil ;};func (_dccc *FlateEncoder )cleanImageData (_bab []byte )([]byte ,error ){if _dccc ._aef ==nil {return _bab ,nil ;};if _dccc ._aef .BitsPerComponent >=8{return _bab ,nil ;};_dgb :=_dccc ._aef .BitsPerComponent *_dccc ._aef .Width *_dccc ._aef .ColorComponents *_dccc ._aef .Height /8;_bab =_bab [:_dgb ];var _gdfb error ;_bab ,_gdfb =_ee .AddDataPadding (_dccc ._aef .Width ,_dccc ._aef .Height ,_dccc ._aef .BitsPerComponent ,_dccc ._aef .ColorComponents ,_bab );if _gdfb !=nil {return nil ,_gdfb ;};return _bab ,nil ;};
@@ -435,8 +439,6 @@ func (_cdgee Version )String ()string {return _gc .Sprintf ("\u00250\u0064\u002e
 // NewCCITTFaxEncoder makes a new CCITTFax encoder.
 func NewCCITTFaxEncoder ()*CCITTFaxEncoder {return &CCITTFaxEncoder {Columns :1728,EndOfBlock :true }};
 
-// EncodeBytes JPX encodes the passed in slice of bytes.
-func (_ggda *JPXEncoder )EncodeBytes (data []byte )([]byte ,error ){_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u003a\u0020\u0041t\u0074\u0065\u006dpt\u0069\u006e\u0067\u0020\u0074\u006f \u0075\u0073\u0065\u0020\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064 \u0065\u006e\u0063\u006f\u0064\u0069\u006e\u0067 \u0025\u0073",_ggda .GetFilterName ());return data ,ErrNoJPXDecode ;};
 
 // StreamEncoder represents the interface for all PDF stream encoders.
 type StreamEncoder interface{GetFilterName ()string ;MakeDecodeParams ()PdfObject ;MakeStreamDict ()*PdfObjectDictionary ;UpdateParams (_dge *PdfObjectDictionary );EncodeBytes (_ecg []byte )([]byte ,error );DecodeBytes (_dcc []byte )([]byte ,error );DecodeStream (_daea *PdfObjectStream )([]byte ,error );};
@@ -474,7 +476,7 @@ func (_eabf *ASCIIHexEncoder )MakeDecodeParams ()PdfObject {return nil };func (_
 // GetStringVal returns the string value represented by the PdfObject directly or indirectly if
 // contained within an indirect object. On type mismatch the found bool flag returned is false and
 // an empty string is returned.
-func GetStringVal (obj PdfObject )(_caae string ,_bdag bool ){_gfgfe ,_bdag :=TraceToDirectObject (obj ).(*PdfObjectString );if _bdag {return _gfgfe .Str (),true ;};return ;};func (_fefge *PdfParser )seekToEOFMarker (_ebbac int64 )error {var _dbfe int64 ;var _ecgb int64 =2048;for _dbfe < _ebbac -4{if _ebbac <=(_ecgb +_dbfe ){_ecgb =_ebbac -_dbfe ;};_ ,_becb :=_fefge ._cdfe .Seek (-_dbfe -_ecgb ,_de .SeekEnd );if _becb !=nil {return _becb ;};_effac :=make ([]byte ,_ecgb );_fefge ._cdfe .Read (_effac );_fg .Log .Trace ("\u004c\u006f\u006f\u006bi\u006e\u0067\u0020\u0066\u006f\u0072\u0020\u0045\u004f\u0046 \u006da\u0072\u006b\u0065\u0072\u003a\u0020\u0022%\u0073\u0022",string (_effac ));_fgcb :=_fcbd .FindAllStringIndex (string (_effac ),-1);if _fgcb !=nil {_debf :=_fgcb [len (_fgcb )-1];_fg .Log .Trace ("\u0049\u006e\u0064\u003a\u0020\u0025\u0020\u0064",_fgcb );_fefge ._cdfe .Seek (-_dbfe -_ecgb +int64 (_debf [0]),_de .SeekEnd );return nil ;};_fg .Log .Debug ("\u0057\u0061\u0072\u006e\u0069\u006eg\u003a\u0020\u0045\u004f\u0046\u0020\u006d\u0061\u0072\u006b\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075n\u0064\u0021\u0020\u002d\u0020\u0063\u006f\u006e\u0074\u0069\u006e\u0075\u0065\u0020s\u0065e\u006b\u0069\u006e\u0067");_dbfe +=_ecgb -4;};_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u003a\u0020\u0045\u004f\u0046\u0020\u006d\u0061\u0072\u006be\u0072 \u0077\u0061\u0073\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u002e");return _c .New ("\u0045\u004f\u0046\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};func (_ebed *PdfParser )parseNull ()(PdfObjectNull ,error ){_ ,_ffdf :=_ebed ._daba .Discard (4);return PdfObjectNull {},_ffdf ;};
+func GetStringVal (obj PdfObject )(_caae string ,_bdag bool ){_gfgfe ,_bdag :=TraceToDirectObject (obj ).(*PdfObjectString );if _bdag {return _gfgfe .Str (),true ;};return ;};func (_fefge *PdfParser )seekToEOFMarker (_ebbac int64 )error {var _dbfe int64 ;var _ecgb int64 =2048;for _dbfe < _ebbac -4{if _ebbac <=(_ecgb +_dbfe ){_ecgb =_ebbac -_dbfe ;};_ ,_becb :=_fefge ._cdfe .Seek (-_dbfe -_ecgb ,_de .SeekEnd );if _becb !=nil {return _becb ;};_effac :=make ([]byte ,_ecgb );_de .ReadFull (_fefge ._cdfe ,_effac );_fg .Log .Trace ("\u004c\u006f\u006f\u006bi\u006e\u0067\u0020\u0066\u006f\u0072\u0020\u0045\u004f\u0046 \u006da\u0072\u006b\u0065\u0072\u003a\u0020\u0022%\u0073\u0022",string (_effac ));_fgcb :=_fcbd .FindAllStringIndex (string (_effac ),-1);if _fgcb !=nil {_debf :=_fgcb [len (_fgcb )-1];_fg .Log .Trace ("\u0049\u006e\u0064\u003a\u0020\u0025\u0020\u0064",_fgcb );_fefge ._cdfe .Seek (-_dbfe -_ecgb +int64 (_debf [0]),_de .SeekEnd );return nil ;};_fg .Log .Debug ("\u0057\u0061\u0072\u006e\u0069\u006eg\u003a\u0020\u0045\u004f\u0046\u0020\u006d\u0061\u0072\u006b\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075n\u0064\u0021\u0020\u002d\u0020\u0063\u006f\u006e\u0074\u0069\u006e\u0075\u0065\u0020s\u0065e\u006b\u0069\u006e\u0067");_dbfe +=_ecgb -4;};_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u003a\u0020\u0045\u004f\u0046\u0020\u006d\u0061\u0072\u006be\u0072 \u0077\u0061\u0073\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u002e");return _c .New ("\u0045\u004f\u0046\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};func (_ebed *PdfParser )parseNull ()(PdfObjectNull ,error ){_ ,_ffdf :=_ebed ._daba .Discard (4);return PdfObjectNull {},_ffdf ;};
 
 // EncodeImage encodes 'img' golang image.Image into jbig2 encoded bytes document using default encoder settings.
 func (_cbdd *JBIG2Encoder )EncodeImage (img _cg .Image )([]byte ,error ){return _cbdd .encodeImage (img )};
@@ -519,7 +521,7 @@ func (_bddc *PdfParser )SetFileOffset (offset int64 ){if offset < 0{offset =0;};
 func (_eegg *RawEncoder )UpdateParams (params *PdfObjectDictionary ){};
 
 // IsFloatDigit checks if a character can be a part of a float number string.
-func IsFloatDigit (c byte )bool {return ('0'<=c &&c <='9')||c =='.'};func _dc (_cba PdfObject )(int64 ,int64 ,error ){if _bgeb ,_fff :=_cba .(*PdfIndirectObject );_fff {return _bgeb .ObjectNumber ,_bgeb .GenerationNumber ,nil ;};if _cgg ,_cdc :=_cba .(*PdfObjectStream );_cdc {return _cgg .ObjectNumber ,_cgg .GenerationNumber ,nil ;};return 0,0,_c .New ("\u006e\u006ft\u0020\u0061\u006e\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u002f\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006f\u0062je\u0063\u0074");};func _bcdfg (_ebga _de .ReadSeeker ,_bacb int64 )(*offsetReader ,error ){_beea :=&offsetReader {_eefca :_ebga ,_egcgd :_bacb };_ ,_bedgc :=_beea .Seek (0,_de .SeekStart );return _beea ,_bedgc ;};func (_fbcae *PdfParser )traceStreamLength (_deed PdfObject )(PdfObject ,error ){_bdegc ,_gefe :=_deed .(*PdfObjectReference );if _gefe {_eaec ,_bebg :=_fbcae ._bcaa [_bdegc .ObjectNumber ];if _bebg &&_eaec {_fg .Log .Debug ("\u0053t\u0072\u0065a\u006d\u0020\u004c\u0065n\u0067\u0074\u0068 \u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065 u\u006e\u0072\u0065s\u006f\u006cv\u0065\u0064\u0020\u0028\u0069\u006cl\u0065\u0067a\u006c\u0029");return nil ,_c .New ("\u0069\u006c\u006c\u0065ga\u006c\u0020\u0072\u0065\u0063\u0075\u0072\u0073\u0069\u0076\u0065\u0020\u006c\u006fo\u0070");};_fbcae ._bcaa [_bdegc .ObjectNumber ]=true ;};_gaca ,_cgbc :=_fbcae .Resolve (_deed );if _cgbc !=nil {return nil ,_cgbc ;};_fg .Log .Trace ("\u0053\u0074\u0072\u0065\u0061\u006d\u0020\u006c\u0065\u006e\u0067\u0074h\u003f\u0020\u0025\u0073",_gaca );if _gefe {_fbcae ._bcaa [_bdegc .ObjectNumber ]=false ;};return _gaca ,nil ;};func (_bggf *PdfParser )parseXrefTable ()(*PdfObjectDictionary ,error ){var _bddd *PdfObjectDictionary ;_deecf ,_aedf :=_bggf .readTextLine ();if _aedf !=nil {return nil ,_aedf ;};_fg .Log .Trace ("\u0078\u0072\u0065\u0066 f\u0069\u0072\u0073\u0074\u0020\u006c\u0069\u006e\u0065\u003a\u0020\u0025\u0073",_deecf );_cdebf :=-1;_ebeb :=0;_bfbf :=false ;_ebdb :="";for {_bggf .skipSpaces ();_ ,_aege :=_bggf ._daba .Peek (1);if _aege !=nil {return nil ,_aege ;};_deecf ,_aege =_bggf .readTextLine ();if _aege !=nil {return nil ,_aege ;};_eaae :=_faag .FindStringSubmatch (_deecf );if len (_eaae )==0{_ecga :=len (_ebdb )> 0;_ebdb +=_deecf +"\u000a";if _ecga {_eaae =_faag .FindStringSubmatch (_ebdb );};};if len (_eaae )==3{_aabc ,_ :=_e .Atoi (_eaae [1]);_abge ,_ :=_e .Atoi (_eaae [2]);_cdebf =_aabc ;_ebeb =_abge ;_bfbf =true ;_ebdb ="";_fg .Log .Trace ("\u0078r\u0065\u0066 \u0073\u0075\u0062s\u0065\u0063\u0074\u0069\u006f\u006e\u003a \u0066\u0069\u0072\u0073\u0074\u0020o\u0062\u006a\u0065\u0063\u0074\u003a\u0020\u0025\u0064\u0020\u006fb\u006a\u0065\u0063\u0074\u0073\u003a\u0020\u0025\u0064",_cdebf ,_ebeb );continue ;};_gcbd :=_bbf .FindStringSubmatch (_deecf );if len (_gcbd )==4{if !_bfbf {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u0020\u0058r\u0065\u0066\u0020\u0069\u006e\u0076\u0061\u006c\u0069d\u0020\u0066\u006fr\u006da\u0074\u0021\u000a");return nil ,_c .New ("\u0078\u0072\u0065\u0066 i\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0066\u006f\u0072\u006d\u0061\u0074");};_fba ,_ :=_e .ParseInt (_gcbd [1],10,64);_dfee ,_ :=_e .Atoi (_gcbd [2]);_bfcb :=_gcbd [3];_ebdb ="";if _afa .ToLower (_bfcb )=="\u006e"&&_fba > 1{_fdeag ,_afgg :=_bggf ._cgbgg .ObjectMap [_cdebf ];if !_afgg ||_dfee > _fdeag .Generation {_bfae :=XrefObject {ObjectNumber :_cdebf ,XType :XrefTypeTableEntry ,Offset :_fba ,Generation :_dfee };_bggf ._cgbgg .ObjectMap [_cdebf ]=_bfae ;};};_cdebf ++;continue ;};if (len (_deecf )> 6)&&(_deecf [:7]=="\u0074r\u0061\u0069\u006c\u0065\u0072"){_fg .Log .Trace ("\u0046o\u0075n\u0064\u0020\u0074\u0072\u0061i\u006c\u0065r\u0020\u002d\u0020\u0025\u0073",_deecf );if len (_deecf )> 9{_cfea :=_bggf .GetFileOffset ();_bggf .SetFileOffset (_cfea -int64 (len (_deecf ))+7);};_bggf .skipSpaces ();_bggf .skipComments ();_fg .Log .Trace ("R\u0065\u0061\u0064\u0069ng\u0020t\u0072\u0061\u0069\u006c\u0065r\u0020\u0064\u0069\u0063\u0074\u0021");_fg .Log .Trace ("\u0070\u0065\u0065\u006b\u003a\u0020\u0022\u0025\u0073\u0022",_deecf );_bddd ,_aege =_bggf .ParseDict ();_fg .Log .Trace ("\u0045O\u0046\u0020\u0072\u0065a\u0064\u0069\u006e\u0067\u0020t\u0072a\u0069l\u0065\u0072\u0020\u0064\u0069\u0063\u0074!");if _aege !=nil {_fg .Log .Debug ("\u0045\u0072\u0072o\u0072\u0020\u0070\u0061r\u0073\u0069\u006e\u0067\u0020\u0074\u0072a\u0069\u006c\u0065\u0072\u0020\u0064\u0069\u0063\u0074\u0020\u0028\u0025\u0073\u0029",_aege );return nil ,_aege ;};break ;};if _deecf =="\u0025\u0025\u0045O\u0046"{_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020\u0065\u006e\u0064 \u006f\u0066\u0020\u0066\u0069\u006c\u0065 -\u0020\u0074\u0072\u0061i\u006c\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066ou\u006e\u0064 \u002d\u0020\u0065\u0072\u0072\u006f\u0072\u0021");return nil ,_c .New ("\u0065\u006e\u0064 \u006f\u0066\u0020\u0066i\u006c\u0065\u0020\u002d\u0020\u0074\u0072a\u0069\u006c\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};_fg .Log .Trace ("\u0078\u0072\u0065\u0066\u0020\u006d\u006f\u0072\u0065 \u003a\u0020\u0025\u0073",_deecf );};_fg .Log .Trace ("\u0045\u004f\u0046 p\u0061\u0072\u0073\u0069\u006e\u0067\u0020\u0078\u0072\u0065\u0066\u0020\u0074\u0061\u0062\u006c\u0065\u0021");if _bggf ._fagf ==nil {_eebfe :=XrefTypeTableEntry ;_bggf ._fagf =&_eebfe ;};return _bddd ,nil ;};
+func IsFloatDigit (c byte )bool {return ('0'<=c &&c <='9')||c =='.'};func _dc (_cba PdfObject )(int64 ,int64 ,error ){if _bgeb ,_fff :=_cba .(*PdfIndirectObject );_fff {return _bgeb .ObjectNumber ,_bgeb .GenerationNumber ,nil ;};if _cgg ,_cdc :=_cba .(*PdfObjectStream );_cdc {return _cgg .ObjectNumber ,_cgg .GenerationNumber ,nil ;};return 0,0,_c .New ("\u006e\u006ft\u0020\u0061\u006e\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u002f\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006f\u0062je\u0063\u0074");};func _bcdfg (_ebga _de .ReadSeeker ,_bacb int64 )(*offsetReader ,error ){_beea :=&offsetReader {_eefca :_ebga ,_egcgd :_bacb };_ ,_bedgc :=_beea .Seek (0,_de .SeekStart );return _beea ,_bedgc ;};func (_fbcae *PdfParser )traceStreamLength (_deed PdfObject )(PdfObject ,error ){_bdegc ,_gefe :=_deed .(*PdfObjectReference );if _gefe {_eaec ,_bebg :=_fbcae ._bcaa [_bdegc .ObjectNumber ];if _bebg &&_eaec {_fg .Log .Debug ("\u0053t\u0072\u0065a\u006d\u0020\u004c\u0065n\u0067\u0074\u0068 \u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063\u0065 u\u006e\u0072\u0065s\u006f\u006cv\u0065\u0064\u0020\u0028\u0069\u006cl\u0065\u0067a\u006c\u0029");return nil ,_c .New ("\u0069\u006c\u006c\u0065ga\u006c\u0020\u0072\u0065\u0063\u0075\u0072\u0073\u0069\u0076\u0065\u0020\u006c\u006fo\u0070");};_fbcae ._bcaa [_bdegc .ObjectNumber ]=true ;};_gaca ,_cgbc :=_fbcae .resolve (_deed );if _cgbc !=nil {return nil ,_cgbc ;};_fg .Log .Trace ("\u0053\u0074\u0072\u0065\u0061\u006d\u0020\u006c\u0065\u006e\u0067\u0074h\u003f\u0020\u0025\u0073",_gaca );if _gefe {_fbcae ._bcaa [_bdegc .ObjectNumber ]=false ;};return _gaca ,nil ;};func (_bggf *PdfParser )parseXrefTable ()(*PdfObjectDictionary ,error ){var _bddd *PdfObjectDictionary ;_deecf ,_aedf :=_bggf .readTextLine ();if _aedf !=nil {return nil ,_aedf ;};_fg .Log .Trace ("\u0078\u0072\u0065\u0066 f\u0069\u0072\u0073\u0074\u0020\u006c\u0069\u006e\u0065\u003a\u0020\u0025\u0073",_deecf );_cdebf :=-1;_ebeb :=0;_bfbf :=false ;_ebdb :="";for {_bggf .skipSpaces ();_ ,_aege :=_bggf ._daba .Peek (1);if _aege !=nil {return nil ,_aege ;};_deecf ,_aege =_bggf .readTextLine ();if _aege !=nil {return nil ,_aege ;};_eaae :=_faag .FindStringSubmatch (_deecf );if len (_eaae )==0{_ecga :=len (_ebdb )> 0;_ebdb +=_deecf +"\u000a";if _ecga {_eaae =_faag .FindStringSubmatch (_ebdb );};};if len (_eaae )==3{_aabc ,_ :=_e .Atoi (_eaae [1]);_abge ,_ :=_e .Atoi (_eaae [2]);_cdebf =_aabc ;_ebeb =_abge ;_bfbf =true ;_ebdb ="";_fg .Log .Trace ("\u0078r\u0065\u0066 \u0073\u0075\u0062s\u0065\u0063\u0074\u0069\u006f\u006e\u003a \u0066\u0069\u0072\u0073\u0074\u0020o\u0062\u006a\u0065\u0063\u0074\u003a\u0020\u0025\u0064\u0020\u006fb\u006a\u0065\u0063\u0074\u0073\u003a\u0020\u0025\u0064",_cdebf ,_ebeb );continue ;};_gcbd :=_bbf .FindStringSubmatch (_deecf );if len (_gcbd )==4{if !_bfbf {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u0020\u0058r\u0065\u0066\u0020\u0069\u006e\u0076\u0061\u006c\u0069d\u0020\u0066\u006fr\u006da\u0074\u0021\u000a");return nil ,_c .New ("\u0078\u0072\u0065\u0066 i\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0066\u006f\u0072\u006d\u0061\u0074");};_fba ,_ :=_e .ParseInt (_gcbd [1],10,64);_dfee ,_ :=_e .Atoi (_gcbd [2]);_bfcb :=_gcbd [3];_ebdb ="";if _afa .ToLower (_bfcb )=="\u006e"&&_fba > 1{_fdeag ,_afgg :=_bggf ._cgbgg .ObjectMap [_cdebf ];if !_afgg ||_dfee > _fdeag .Generation {_bfae :=XrefObject {ObjectNumber :_cdebf ,XType :XrefTypeTableEntry ,Offset :_fba ,Generation :_dfee };_bggf ._cgbgg .ObjectMap [_cdebf ]=_bfae ;};};_cdebf ++;continue ;};if (len (_deecf )> 6)&&(_deecf [:7]=="\u0074r\u0061\u0069\u006c\u0065\u0072"){_fg .Log .Trace ("\u0046o\u0075n\u0064\u0020\u0074\u0072\u0061i\u006c\u0065r\u0020\u002d\u0020\u0025\u0073",_deecf );if len (_deecf )> 9{_cfea :=_bggf .GetFileOffset ();_bggf .SetFileOffset (_cfea -int64 (len (_deecf ))+7);};_bggf .skipSpaces ();_bggf .skipComments ();_fg .Log .Trace ("R\u0065\u0061\u0064\u0069ng\u0020t\u0072\u0061\u0069\u006c\u0065r\u0020\u0064\u0069\u0063\u0074\u0021");_fg .Log .Trace ("\u0070\u0065\u0065\u006b\u003a\u0020\u0022\u0025\u0073\u0022",_deecf );_bddd ,_aege =_bggf .ParseDict ();_fg .Log .Trace ("\u0045O\u0046\u0020\u0072\u0065a\u0064\u0069\u006e\u0067\u0020t\u0072a\u0069l\u0065\u0072\u0020\u0064\u0069\u0063\u0074!");if _aege !=nil {_fg .Log .Debug ("\u0045\u0072\u0072o\u0072\u0020\u0070\u0061r\u0073\u0069\u006e\u0067\u0020\u0074\u0072a\u0069\u006c\u0065\u0072\u0020\u0064\u0069\u0063\u0074\u0020\u0028\u0025\u0073\u0029",_aege );return nil ,_aege ;};break ;};if _deecf =="\u0025\u0025\u0045O\u0046"{_fg .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020\u0065\u006e\u0064 \u006f\u0066\u0020\u0066\u0069\u006c\u0065 -\u0020\u0074\u0072\u0061i\u006c\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066ou\u006e\u0064 \u002d\u0020\u0065\u0072\u0072\u006f\u0072\u0021");return nil ,_c .New ("\u0065\u006e\u0064 \u006f\u0066\u0020\u0066i\u006c\u0065\u0020\u002d\u0020\u0074\u0072a\u0069\u006c\u0065\u0072\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};_fg .Log .Trace ("\u0078\u0072\u0065\u0066\u0020\u006d\u006f\u0072\u0065 \u003a\u0020\u0025\u0073",_deecf );};_fg .Log .Trace ("\u0045\u004f\u0046 p\u0061\u0072\u0073\u0069\u006e\u0067\u0020\u0078\u0072\u0065\u0066\u0020\u0074\u0061\u0062\u006c\u0065\u0021");if _bggf ._fagf ==nil {_eebfe :=XrefTypeTableEntry ;_bggf ._fagf =&_eebfe ;};return _bddd ,nil ;};
 
 // SetImage sets the image base for given flate encoder.
 func (_ebgd *FlateEncoder )SetImage (img *_ee .ImageBase ){_ebgd ._aef =img };
@@ -543,13 +545,11 @@ type PdfObjectFloat float64 ;func _bgfcc (_bfcg int )int {if _bfcg < 0{return -_
 func (_fddc *PdfObjectArray )ToFloat64Array ()([]float64 ,error ){var _daaef []float64 ;for _ ,_dadgf :=range _fddc .Elements (){switch _ebdea :=_dadgf .(type ){case *PdfObjectInteger :_daaef =append (_daaef ,float64 (*_ebdea ));case *PdfObjectFloat :_daaef =append (_daaef ,float64 (*_ebdea ));default:return nil ,ErrTypeError ;};};return _daaef ,nil ;};
 
 // NewFlateEncoder makes a new flate encoder with default parameters, predictor 1 and bits per component 8.
-func NewFlateEncoder ()*FlateEncoder {_bfec :=&FlateEncoder {};_bfec .Predictor =1;_bfec .BitsPerComponent =8;_bfec .Colors =1;_bfec .Columns =1;return _bfec ;};func (_gecb *PdfParser )repairLocateXref ()(int64 ,error ){_agcc :=int64 (1000);_gecb ._cdfe .Seek (-_agcc ,_de .SeekCurrent );_gdfa ,_bgbb :=_gecb ._cdfe .Seek (0,_de .SeekCurrent );if _bgbb !=nil {return 0,_bgbb ;};_cfda :=make ([]byte ,_agcc );_gecb ._cdfe .Read (_cfda );_cfeg :=_fafg .FindAllStringIndex (string (_cfda ),-1);if len (_cfeg )< 1{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0052\u0065\u0070a\u0069\u0072\u003a\u0020\u0078\u0072\u0065f\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u0021");return 0,_c .New ("\u0072\u0065\u0070\u0061ir\u003a\u0020\u0078\u0072\u0065\u0066\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075n\u0064");};_dda :=int64 (_cfeg [len (_cfeg )-1][0]);_dabd :=_gdfa +_dda ;return _dabd ,nil ;};
+func NewFlateEncoder ()*FlateEncoder {_bfec :=&FlateEncoder {};_bfec .Predictor =1;_bfec .BitsPerComponent =8;_bfec .Colors =1;_bfec .Columns =1;return _bfec ;};func (_gecb *PdfParser )repairLocateXref ()(int64 ,error ){_agcc :=int64 (1000);_gecb ._cdfe .Seek (-_agcc ,_de .SeekCurrent );_gdfa ,_bgbb :=_gecb ._cdfe .Seek (0,_de .SeekCurrent );if _bgbb !=nil {return 0,_bgbb ;};_cfda :=make ([]byte ,_agcc );_de .ReadFull (_gecb ._cdfe ,_cfda );_cfeg :=_fafg .FindAllStringIndex (string (_cfda ),-1);if len (_cfeg )< 1{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0052\u0065\u0070a\u0069\u0072\u003a\u0020\u0078\u0072\u0065f\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u0021");return 0,_c .New ("\u0072\u0065\u0070\u0061ir\u003a\u0020\u0078\u0072\u0065\u0066\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075n\u0064");};_dda :=int64 (_cfeg [len (_cfeg )-1][0]);_dabd :=_gdfa +_dda ;return _dabd ,nil ;};
 
 // DrawableImage is same as golang image/draw's Image interface that allow drawing images.
 type DrawableImage interface{ColorModel ()_be .Model ;Bounds ()_cg .Rectangle ;At (_dgc ,_gcga int )_be .Color ;Set (_degb ,_cabd int ,_eggg _be .Color );};
 
-// GetFilterName returns the name of the encoding filter.
-func (_faeb *JPXEncoder )GetFilterName ()string {return StreamEncodingFilterNameJPX };
 
 // Get returns the PdfObject corresponding to the specified key.
 // Returns a nil value if the key is not set.
@@ -566,7 +566,7 @@ func MakeDict ()*PdfObjectDictionary {_bdddb :=&PdfObjectDictionary {};_bdddb ._
 
 // Inspect analyzes the document object structure. Returns a map of object types (by name) with the instance count
 // as value.
-func (_dffg *PdfParser )Inspect ()(map[string ]int ,error ){return _dffg .inspect ()};func (_eeaaaf *PdfParser )parsePdfVersion ()(int ,int ,error ){var _gbe int64 =20;_aac :=make ([]byte ,_gbe );_eeaaaf ._cdfe .Seek (0,_de .SeekStart );_eeaaaf ._cdfe .Read (_aac );var _fgbec error ;var _gcfd ,_gdcef int ;if _gaed :=_bbda .FindStringSubmatch (string (_aac ));len (_gaed )< 3{if _gcfd ,_gdcef ,_fgbec =_eeaaaf .seekPdfVersionTopDown ();_fgbec !=nil {_fg .Log .Debug ("F\u0061\u0069\u006c\u0065\u0064\u0020\u0072\u0065\u0063\u006f\u0076\u0065\u0072\u0079\u0020\u002d\u0020\u0075n\u0061\u0062\u006c\u0065\u0020\u0074\u006f\u0020\u0066\u0069nd\u0020\u0076\u0065r\u0073i\u006f\u006e");return 0,0,_fgbec ;};_eeaaaf ._cdfe ,_fgbec =_bcdfg (_eeaaaf ._cdfe ,_eeaaaf .GetFileOffset ()-8);if _fgbec !=nil {return 0,0,_fgbec ;};}else {if _gcfd ,_fgbec =_e .Atoi (_gaed [1]);_fgbec !=nil {return 0,0,_fgbec ;};if _gdcef ,_fgbec =_e .Atoi (_gaed [2]);_fgbec !=nil {return 0,0,_fgbec ;};_eeaaaf .SetFileOffset (0);};_eeaaaf ._daba =_eg .NewReader (_eeaaaf ._cdfe );_fg .Log .Debug ("\u0050\u0064\u0066\u0020\u0076\u0065\u0072\u0073\u0069\u006f\u006e\u0020%\u0064\u002e\u0025\u0064",_gcfd ,_gdcef );return _gcfd ,_gdcef ,nil ;};
+func (_dffg *PdfParser )Inspect ()(map[string ]int ,error ){return _dffg .inspect ()};func (_eeaaaf *PdfParser )parsePdfVersion ()(int ,int ,error ){var _gbe int64 =20;_aac :=make ([]byte ,_gbe );_eeaaaf ._cdfe .Seek (0,_de .SeekStart );_de .ReadFull (_eeaaaf ._cdfe ,_aac );var _fgbec error ;var _gcfd ,_gdcef int ;if _gaed :=_bbda .FindStringSubmatch (string (_aac ));len (_gaed )< 3{if _gcfd ,_gdcef ,_fgbec =_eeaaaf .seekPdfVersionTopDown ();_fgbec !=nil {_fg .Log .Debug ("F\u0061\u0069\u006c\u0065\u0064\u0020\u0072\u0065\u0063\u006f\u0076\u0065\u0072\u0079\u0020\u002d\u0020\u0075n\u0061\u0062\u006c\u0065\u0020\u0074\u006f\u0020\u0066\u0069nd\u0020\u0076\u0065r\u0073i\u006f\u006e");return 0,0,_fgbec ;};_eeaaaf ._cdfe ,_fgbec =_bcdfg (_eeaaaf ._cdfe ,_eeaaaf .GetFileOffset ()-8);if _fgbec !=nil {return 0,0,_fgbec ;};}else {if _gcfd ,_fgbec =_e .Atoi (_gaed [1]);_fgbec !=nil {return 0,0,_fgbec ;};if _gdcef ,_fgbec =_e .Atoi (_gaed [2]);_fgbec !=nil {return 0,0,_fgbec ;};_eeaaaf .SetFileOffset (0);};_eeaaaf ._daba =_eg .NewReader (_eeaaaf ._cdfe );_fg .Log .Debug ("\u0050\u0064\u0066\u0020\u0076\u0065\u0072\u0073\u0069\u006f\u006e\u0020%\u0064\u002e\u0025\u0064",_gcfd ,_gdcef );return _gcfd ,_gdcef ,nil ;};
 
 // ParseNumber parses a numeric objects from a buffered stream.
 // Section 7.3.3.
@@ -596,9 +596,7 @@ func (_eabdg *PdfObjectNull )String ()string {return "\u006e\u0075\u006c\u006c"}
 // MakeStreamDict makes a new instance of an encoding dictionary for a stream object.
 func (_cffb *RawEncoder )MakeStreamDict ()*PdfObjectDictionary {return MakeDict ()};func _becgc (_deeb ,_afdf ,_fdcce uint8 )uint8 {_dgadc :=int (_fdcce );_fgbe :=int (_afdf )-_dgadc ;_cdbga :=int (_deeb )-_dgadc ;_dgadc =_abfg (_fgbe +_cdbga );_fgbe =_abfg (_fgbe );_cdbga =_abfg (_cdbga );if _fgbe <=_cdbga &&_fgbe <=_dgadc {return _deeb ;}else if _cdbga <=_dgadc {return _afdf ;};return _fdcce ;};
 
-// DecodeStream decodes a JPX encoded stream and returns the result as a
-// slice of bytes.
-func (_gdafd *JPXEncoder )DecodeStream (streamObj *PdfObjectStream )([]byte ,error ){_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u003a\u0020\u0041t\u0074\u0065\u006dpt\u0069\u006e\u0067\u0020\u0074\u006f \u0075\u0073\u0065\u0020\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064 \u0065\u006e\u0063\u006f\u0064\u0069\u006e\u0067 \u0025\u0073",_gdafd .GetFilterName ());return streamObj .Stream ,ErrNoJPXDecode ;};const (StreamEncodingFilterNameFlate ="F\u006c\u0061\u0074\u0065\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameLZW ="\u004cZ\u0057\u0044\u0065\u0063\u006f\u0064e";StreamEncodingFilterNameDCT ="\u0044C\u0054\u0044\u0065\u0063\u006f\u0064e";StreamEncodingFilterNameRunLength ="\u0052u\u006eL\u0065\u006e\u0067\u0074\u0068\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameASCIIHex ="\u0041\u0053\u0043\u0049\u0049\u0048\u0065\u0078\u0044e\u0063\u006f\u0064\u0065";StreamEncodingFilterNameASCII85 ="\u0041\u0053\u0043\u0049\u0049\u0038\u0035\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameCCITTFax ="\u0043\u0043\u0049\u0054\u0054\u0046\u0061\u0078\u0044e\u0063\u006f\u0064\u0065";StreamEncodingFilterNameJBIG2 ="J\u0042\u0049\u0047\u0032\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameJPX ="\u004aP\u0058\u0044\u0065\u0063\u006f\u0064e";StreamEncodingFilterNameRaw ="\u0052\u0061\u0077";);
+const (StreamEncodingFilterNameFlate ="F\u006c\u0061\u0074\u0065\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameLZW ="\u004cZ\u0057\u0044\u0065\u0063\u006f\u0064e";StreamEncodingFilterNameDCT ="\u0044C\u0054\u0044\u0065\u0063\u006f\u0064e";StreamEncodingFilterNameRunLength ="\u0052u\u006eL\u0065\u006e\u0067\u0074\u0068\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameASCIIHex ="\u0041\u0053\u0043\u0049\u0049\u0048\u0065\u0078\u0044e\u0063\u006f\u0064\u0065";StreamEncodingFilterNameASCII85 ="\u0041\u0053\u0043\u0049\u0049\u0038\u0035\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameCCITTFax ="\u0043\u0043\u0049\u0054\u0054\u0046\u0061\u0078\u0044e\u0063\u006f\u0064\u0065";StreamEncodingFilterNameJBIG2 ="J\u0042\u0049\u0047\u0032\u0044\u0065\u0063\u006f\u0064\u0065";StreamEncodingFilterNameJPX ="\u004aP\u0058\u0044\u0065\u0063\u006f\u0064e";StreamEncodingFilterNameRaw ="\u0052\u0061\u0077";);
 
 // GetBool returns the *PdfObjectBool object that is represented by a PdfObject directly or indirectly
 // within an indirect object. The bool flag indicates whether a match was found.
@@ -638,7 +636,7 @@ func (_eggf *CCITTFaxEncoder )MakeStreamDict ()*PdfObjectDictionary {_eeff :=Mak
 
 // GetFilterArray returns the names of the underlying encoding filters in an array that
 // can be used as /Filter entry.
-func (_ffgd *MultiEncoder )GetFilterArray ()*PdfObjectArray {_agacd :=make ([]PdfObject ,len (_ffgd ._gba ));for _fffd ,_ddg :=range _ffgd ._gba {_agacd [_fffd ]=MakeName (_ddg .GetFilterName ());};return MakeArray (_agacd ...);};type objectStream struct{N int ;_eee []byte ;_dff map[int ]int64 ;};func (_ffb *PdfCrypt )newEncryptDict ()*PdfObjectDictionary {_cgga :=MakeDict ();_cgga .Set ("\u0046\u0069\u006c\u0074\u0065\u0072",MakeName ("\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064"));_cgga .Set ("\u0056",MakeInteger (int64 (_ffb ._age .V )));_cgga .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (int64 (_ffb ._age .Length )));return _cgga ;};func (_gcc *PdfCrypt )loadCryptFilters (_cff *PdfObjectDictionary )error {_gcc ._dfb =cryptFilters {};_cgf :=_cff .Get ("\u0043\u0046");_cgf =TraceToDirectObject (_cgf );if _fb ,_ace :=_cgf .(*PdfObjectReference );_ace {_dfgf ,_afc :=_gcc ._afaf .LookupByReference (*_fb );if _afc !=nil {_fg .Log .Debug ("\u0045\u0072r\u006f\u0072\u0020\u006c\u006f\u006f\u006b\u0069\u006e\u0067\u0020\u0075\u0070\u0020\u0043\u0046\u0020\u0072\u0065\u0066\u0065\u0072en\u0063\u0065");return _afc ;};_cgf =TraceToDirectObject (_dfgf );};_fde ,_dec :=_cgf .(*PdfObjectDictionary );if !_dec {_fg .Log .Debug ("I\u006ev\u0061\u006c\u0069\u0064\u0020\u0043\u0046\u002c \u0074\u0079\u0070\u0065: \u0025\u0054",_cgf );return _c .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0043\u0046");};for _ ,_fgbd :=range _fde .Keys (){_adeg :=_fde .Get (_fgbd );if _cbaa ,_ffe :=_adeg .(*PdfObjectReference );_ffe {_abc ,_fea :=_gcc ._afaf .LookupByReference (*_cbaa );if _fea !=nil {_fg .Log .Debug ("\u0045\u0072ro\u0072\u0020\u006co\u006f\u006b\u0075\u0070 up\u0020di\u0063\u0074\u0069\u006f\u006e\u0061\u0072y \u0072\u0065\u0066\u0065\u0072\u0065\u006ec\u0065");return _fea ;};_adeg =TraceToDirectObject (_abc );};_ccb ,_feb :=_adeg .(*PdfObjectDictionary );if !_feb {return _gc .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0064\u0069\u0063\u0074\u0020\u0069\u006e \u0043\u0046\u0020\u0028\u006e\u0061\u006d\u0065\u0020\u0025\u0073\u0029\u0020-\u0020\u006e\u006f\u0074\u0020\u0061\u0020\u0064\u0069\u0063\u0074\u0069on\u0061\u0072\u0079\u0020\u0062\u0075\u0074\u0020\u0025\u0054",_fgbd ,_adeg );};if _fgbd =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u002d\u0020\u0043\u0061\u006e\u006e\u006f\u0074\u0020\u006f\u0076\u0065\u0072\u0077r\u0069\u0074\u0065\u0020\u0074\u0068\u0065\u0020\u0069d\u0065\u006e\u0074\u0069\u0074\u0079\u0020\u0066\u0069\u006c\u0074\u0065\u0072 \u002d\u0020\u0054\u0072\u0079\u0069n\u0067\u0020\u006ee\u0078\u0074");continue ;};var _bcgf _cd .FilterDict ;if _beb :=_fe (&_bcgf ,_ccb );_beb !=nil {return _beb ;};_ebe ,_dfea :=_cd .NewFilter (_bcgf );if _dfea !=nil {return _dfea ;};_gcc ._dfb [string (_fgbd )]=_ebe ;};_gcc ._dfb ["\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"]=_cd .NewIdentity ();_gcc ._fcb ="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079";if _fad ,_eba :=_cff .Get ("\u0053\u0074\u0072\u0046").(*PdfObjectName );_eba {if _ ,_gdec :=_gcc ._dfb [string (*_fad )];!_gdec {return _gc .Errorf ("\u0063\u0072\u0079\u0070t\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u0066o\u0072\u0020\u0053\u0074\u0072\u0046\u0020\u006e\u006f\u0074\u0020\u0073\u0070\u0065\u0063\u0069\u0066\u0069e\u0064\u0020\u0069\u006e\u0020C\u0046\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079\u0020\u0028\u0025\u0073\u0029",*_fad );};_gcc ._fcb =string (*_fad );};_gcc ._gca ="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079";if _gfb ,_db :=_cff .Get ("\u0053\u0074\u006d\u0046").(*PdfObjectName );_db {if _ ,_ageb :=_gcc ._dfb [string (*_gfb )];!_ageb {return _gc .Errorf ("\u0063\u0072\u0079\u0070t\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u0066o\u0072\u0020\u0053\u0074\u006d\u0046\u0020\u006e\u006f\u0074\u0020\u0073\u0070\u0065\u0063\u0069\u0066\u0069e\u0064\u0020\u0069\u006e\u0020C\u0046\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079\u0020\u0028\u0025\u0073\u0029",*_gfb );};_gcc ._gca =string (*_gfb );};return nil ;};
+func (_ffgd *MultiEncoder )GetFilterArray ()*PdfObjectArray {_agacd :=make ([]PdfObject ,len (_ffgd ._gba ));for _fffd ,_ddg :=range _ffgd ._gba {_agacd [_fffd ]=MakeName (_ddg .GetFilterName ());};return MakeArray (_agacd ...);};type objectStream struct{N int ;_eee []byte ;_dff map[int ]int64 ;};func (_ffb *PdfCrypt )newEncryptDict ()*PdfObjectDictionary {_cgga :=MakeDict ();_cgga .Set ("\u0046\u0069\u006c\u0074\u0065\u0072",MakeName ("\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064"));_cgga .Set ("\u0056",MakeInteger (int64 (_ffb ._age .V )));_cgga .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (int64 (_ffb ._age .Length )));return _cgga ;};func (_gcc *PdfCrypt )loadCryptFilters (_cff *PdfObjectDictionary )error {_gcc ._dfb =cryptFilters {};_cgf :=_cff .Get ("\u0043\u0046");_cgf =TraceToDirectObject (_cgf );if _fb ,_ace :=_cgf .(*PdfObjectReference );_ace {_dfgf ,_afc :=_gcc ._afaf .LookupByReference (*_fb );if _afc !=nil {_fg .Log .Debug ("\u0045\u0072r\u006f\u0072\u0020\u006c\u006f\u006f\u006b\u0069\u006e\u0067\u0020\u0075\u0070\u0020\u0043\u0046\u0020\u0072\u0065\u0066\u0065\u0072en\u0063\u0065");return _afc ;};_cgf =TraceToDirectObject (_dfgf );};_fde ,_dec :=_cgf .(*PdfObjectDictionary );if !_dec {_fg .Log .Debug ("I\u006ev\u0061\u006c\u0069\u0064\u0020\u0043\u0046\u002c \u0074\u0079\u0070\u0065: \u0025\u0054",_cgf );return _c .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0043\u0046");};for _ ,_fgbd :=range _fde .Keys (){_adeg :=_fde .Get (_fgbd );if _cbaa ,_ffe :=_adeg .(*PdfObjectReference );_ffe {_abc ,_fea :=_gcc ._afaf .LookupByReference (*_cbaa );if _fea !=nil {_fg .Log .Debug ("\u0045\u0072ro\u0072\u0020\u006co\u006f\u006b\u0075\u0070 up\u0020di\u0063\u0074\u0069\u006f\u006e\u0061\u0072y \u0072\u0065\u0066\u0065\u0072\u0065\u006ec\u0065");return _fea ;};_adeg =TraceToDirectObject (_abc );};_ccb ,_feb :=_adeg .(*PdfObjectDictionary );if !_feb {return _gc .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0064\u0069\u0063\u0074\u0020\u0069\u006e \u0043\u0046\u0020\u0028\u006e\u0061\u006d\u0065\u0020\u0025\u0073\u0029\u0020-\u0020\u006e\u006f\u0074\u0020\u0061\u0020\u0064\u0069\u0063\u0074\u0069on\u0061\u0072\u0079\u0020\u0062\u0075\u0074\u0020\u0025\u0054",_fgbd ,_adeg );};if _fgbd =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u002d\u0020\u0043\u0061\u006e\u006e\u006f\u0074\u0020\u006f\u0076\u0065\u0072\u0077r\u0069\u0074\u0065\u0020\u0074\u0068\u0065\u0020\u0069d\u0065\u006e\u0074\u0069\u0074\u0079\u0020\u0066\u0069\u006c\u0074\u0065\u0072 \u002d\u0020\u0054\u0072\u0079\u0069n\u0067\u0020\u006ee\u0078\u0074");continue ;};var _bcgf _cd .FilterDict ;if _beb :=_fe (&_bcgf ,_ccb );_beb !=nil {return _beb ;};_ebe ,_dfea :=_cd .NewFilter (_bcgf );if _dfea !=nil {return _dfea ;};_gcc ._dfb [string (_fgbd )]=_ebe ;};_gcc ._dfb ["\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"]=_cd .NewIdentity ();_gcc ._fcb ="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079";if _fad ,_eba :=_cff .Get ("\u0053\u0074\u0072\u0046").(*PdfObjectName );_eba {if _ ,_gdec :=_gcc ._dfb [string (*_fad )];!_gdec {return _gc .Errorf ("\u0063\u0072\u0079\u0070t\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u0066o\u0072\u0020\u0053\u0074\u0072\u0046\u0020\u006e\u006f\u0074\u0020\u0073\u0070\u0065\u0063\u0069\u0066\u0069e\u0064\u0020\u0069\u006e\u0020C\u0046\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079\u0020\u0028\u0025\u0073\u0029",*_fad );};_gcc ._fcb =string (*_fad );};_gcc ._gca ="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079";if _gfb ,_db :=_cff .Get ("\u0053\u0074\u006d\u0046").(*PdfObjectName );_db {if _ ,_ageb :=_gcc ._dfb [string (*_gfb )];!_ageb {return _gc .Errorf ("\u0063\u0072\u0079\u0070t\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u0066o\u0072\u0020\u0053\u0074\u006d\u0046\u0020\u006e\u006f\u0074\u0020\u0073\u0070\u0065\u0063\u0069\u0066\u0069e\u0064\u0020\u0069\u006e\u0020C\u0046\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079\u0020\u0028\u0025\u0073\u0029",*_gfb );};_gcc ._gca =string (*_gfb );};return _gcc .loadEmbeddedFileFilter (_cff );};
 
 // GetFloat returns the *PdfObjectFloat represented by the PdfObject directly or indirectly within an indirect
 // object. On type mismatch the found bool flag is false and a nil pointer is returned.
@@ -667,9 +665,7 @@ func GetStringBytes (obj PdfObject )(_fedf []byte ,_cdba bool ){_abffd ,_cdba :=
 // MakeInteger creates a PdfObjectInteger from an int64.
 func MakeInteger (val int64 )*PdfObjectInteger {_aaae :=PdfObjectInteger (val );return &_aaae };
 
-// JPXEncoder implements JPX encoder/decoder (dummy, for now)
-// FIXME: implement
-type JPXEncoder struct{};func (_bfeg *PdfCrypt )authenticate (_fee []byte )(bool ,error ){_bfeg ._bddg =false ;_aee :=_bfeg .securityHandler ();_bbe ,_afda ,_bdc :=_aee .Authenticate (&_bfeg ._cgc ,_fee );if _bdc !=nil {return false ,_bdc ;}else if _afda ==0||len (_bbe )==0{return false ,nil ;};_bfeg ._bddg =true ;_bfeg ._gbb =_bbe ;return true ,nil ;};
+func (_bfeg *PdfCrypt )authenticate (_fee []byte )(bool ,error ){if _bfeg .pubKey !=nil {return _bfeg ._bddg ,nil ;};_bfeg ._bddg =false ;_aee :=_bfeg .securityHandler ();_bbe ,_afda ,_bdc :=_aee .Authenticate (&_bfeg ._cgc ,_fee );if _bdc !=nil {return false ,_bdc ;}else if _afda ==0||len (_bbe )==0{return false ,nil ;};_bfeg ._bddg =true ;_bfeg ._gbb =_bbe ;return true ,nil ;};
 
 // NewParserFromString is used for testing purposes.
 func NewParserFromString (txt string )*PdfParser {_bgfbb :=_gcd .NewReader ([]byte (txt ));_aeda :=&PdfParser {ObjCache :objectCache {},_cdfe :_bgfbb ,_daba :_eg .NewReader (_bgfbb ),_eecde :int64 (len (txt )),_bcaa :map[int64 ]bool {}};_aeda ._cgbgg .ObjectMap =make (map[int ]XrefObject );return _aeda ;};func _gdf (_feab _cd .Filter ,_bbc _dfg .AuthEvent )*PdfObjectDictionary {if _bbc ==""{_bbc =_dfg .EventDocOpen ;};_ccc :=MakeDict ();_ccc .Set ("\u0054\u0079\u0070\u0065",MakeName ("C\u0072\u0079\u0070\u0074\u0046\u0069\u006c\u0074\u0065\u0072"));_ccc .Set ("\u0041u\u0074\u0068\u0045\u0076\u0065\u006et",MakeName (string (_bbc )));_ccc .Set ("\u0043\u0046\u004d",MakeName (_feab .Name ()));_ccc .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (int64 (_feab .KeyLength ())));return _ccc ;};
@@ -771,7 +767,10 @@ func (_dacb *PdfObjectDictionary )Keys ()[]PdfObjectName {if _dacb ==nil {return
 func (_cfd *JBIG2Encoder )MakeStreamDict ()*PdfObjectDictionary {_gcdd :=MakeDict ();_gcdd .Set ("\u0046\u0069\u006c\u0074\u0065\u0072",MakeName (_cfd .GetFilterName ()));return _gcdd ;};
 
 // ReadBytesAt reads byte content at specific offset and length within the PDF.
-func (_dbbe *PdfParser )ReadBytesAt (offset ,len int64 )([]byte ,error ){_eggc :=_dbbe .GetFileOffset ();_ ,_dffb :=_dbbe ._cdfe .Seek (offset ,_de .SeekStart );if _dffb !=nil {return nil ,_dffb ;};_gdbf :=make ([]byte ,len );_ ,_dffb =_de .ReadAtLeast (_dbbe ._cdfe ,_gdbf ,int (len ));if _dffb !=nil {return nil ,_dffb ;};_dbbe .SetFileOffset (_eggc );return _gdbf ,nil ;};
+func (_dbbe *PdfParser )ReadBytesAt (offset ,len int64 )([]byte ,error ){_dbbe .mu .Lock ();defer _dbbe .mu .Unlock ();return _dbbe .readBytesAt (offset ,len );};
+
+// readBytesAt reads bytes like ReadBytesAt, without locking the parser.
+func (_dbbe *PdfParser )readBytesAt (offset ,len int64 )([]byte ,error ){_eggc :=_dbbe .GetFileOffset ();_ ,_dffb :=_dbbe ._cdfe .Seek (offset ,_de .SeekStart );if _dffb !=nil {return nil ,_dffb ;};_gdbf :=make ([]byte ,len );_ ,_dffb =_de .ReadAtLeast (_dbbe ._cdfe ,_gdbf ,int (len ));if _dffb !=nil {return nil ,_dffb ;};_dbbe .SetFileOffset (_eggc );return _gdbf ,nil ;};
 
 // WriteString outputs the object as it is to be written to file.
 func (_fbcf *PdfIndirectObject )WriteString ()string {var _dced _afa .Builder ;_dced .WriteString (_e .FormatInt (_fbcf .ObjectNumber ,10));_dced .WriteString ("\u0020\u0030\u0020\u0052");return _dced .String ();};func _bde (_eeg *PdfObjectStream ,_cdbe *MultiEncoder )(*DCTEncoder ,error ){_cebb :=NewDCTEncoder ();_ecad :=_eeg .PdfObjectDictionary ;if _ecad ==nil {return _cebb ,nil ;};_dfga :=_eeg .Stream ;if _cdbe !=nil {_afea ,_efbg :=_cdbe .DecodeBytes (_dfga );if _efbg !=nil {return nil ,_efbg ;};_dfga =_afea ;};_gfg :=_gcd .NewReader (_dfga );_cdged ,_fcac :=_af .DecodeConfig (_gfg );if _fcac !=nil {_fg .Log .Debug ("\u0045\u0072\u0072or\u0020\u0064\u0065\u0063\u006f\u0064\u0069\u006e\u0067\u0020\u0066\u0069\u006c\u0065\u003a\u0020\u0025\u0073",_fcac );return nil ,_fcac ;};switch _cdged .ColorModel {case _be .RGBAModel :_cebb .BitsPerComponent =8;_cebb .ColorComponents =3;case _be .RGBA64Model :_cebb .BitsPerComponent =16;_cebb .ColorComponents =3;case _be .GrayModel :_cebb .BitsPerComponent =8;_cebb .ColorComponents =1;case _be .Gray16Model :_cebb .BitsPerComponent =16;_cebb .ColorComponents =1;case _be .CMYKModel :_cebb .BitsPerComponent =8;_cebb .ColorComponents =4;case _be .YCbCrModel :_cebb .BitsPerComponent =8;_cebb .ColorComponents =3;default:return nil ,_c .New ("\u0075\u006e\u0073up\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0063\u006f\u006c\u006f\u0072\u0020\u006d\u006f\u0064\u0065\u006c");};_cebb .Width =_cdged .Width ;_cebb .Height =_cdged .Height ;_fg .Log .Trace ("\u0044\u0043T\u0020\u0045\u006ec\u006f\u0064\u0065\u0072\u003a\u0020\u0025\u002b\u0076",_cebb );_cebb .Quality =DefaultJPEGQuality ;return _cebb ,nil ;};
@@ -779,8 +778,6 @@ func (_fbcf *PdfIndirectObject )WriteString ()string {var _dced _afa .Builder ;_
 // UpdateParams updates the parameter values of the encoder.
 func (_egc *FlateEncoder )UpdateParams (params *PdfObjectDictionary ){_afbe ,_bga :=GetNumberAsInt64 (params .Get ("\u0050r\u0065\u0064\u0069\u0063\u0074\u006fr"));if _bga ==nil {_egc .Predictor =int (_afbe );};_bfg ,_bga :=GetNumberAsInt64 (params .Get ("\u0042\u0069t\u0073\u0050\u0065r\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074"));if _bga ==nil {_egc .BitsPerComponent =int (_bfg );};_gbd ,_bga :=GetNumberAsInt64 (params .Get ("\u0057\u0069\u0064t\u0068"));if _bga ==nil {_egc .Columns =int (_gbd );};_egd ,_bga :=GetNumberAsInt64 (params .Get ("\u0043o\u006co\u0072\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074\u0073"));if _bga ==nil {_egc .Colors =int (_egd );};};const (JB2Generic JBIG2CompressionType =iota ;JB2SymbolCorrelation ;JB2SymbolRankHaus ;);
 
-// DecodeBytes decodes a slice of JPX encoded bytes and returns the result.
-func (_gfceb *JPXEncoder )DecodeBytes (encoded []byte )([]byte ,error ){_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u003a\u0020\u0041t\u0074\u0065\u006dpt\u0069\u006e\u0067\u0020\u0074\u006f \u0075\u0073\u0065\u0020\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064 \u0065\u006e\u0063\u006f\u0064\u0069\u006e\u0067 \u0025\u0073",_gfceb .GetFilterName ());return encoded ,ErrNoJPXDecode ;};
 
 // String returns a string describing `ind`.
 func (_fgeef *PdfIndirectObject )String ()string {return _gc .Sprintf ("\u0049\u004f\u0062\u006a\u0065\u0063\u0074\u003a\u0025\u0064",(*_fgeef ).ObjectNumber );};
@@ -832,19 +829,17 @@ String ()string ;
 
 // WriteString outputs the PDF primitive as written to file as expected by the standard.
 // TODO(dennwc): it should return a byte slice, or accept a writer
-WriteString ()string ;};func (_ae *PdfParser )lookupObjectViaOS (_cfe int ,_eb int )(PdfObject ,error ){var _fd *_gcd .Reader ;var _ac objectStream ;var _ag bool ;_ac ,_ag =_ae ._eaeg [_cfe ];if !_ag {_ege ,_bg :=_ae .LookupByNumber (_cfe );if _bg !=nil {_fg .Log .Debug ("\u004d\u0069ss\u0069\u006e\u0067 \u006f\u0062\u006a\u0065ct \u0073tr\u0065\u0061\u006d\u0020\u0077\u0069\u0074h \u006e\u0075\u006d\u0062\u0065\u0072\u0020%\u0064",_cfe );return nil ,_bg ;};_ec ,_ed :=_ege .(*PdfObjectStream );if !_ed {return nil ,_c .New ("i\u006e\u0076\u0061\u006cid\u0020o\u0062\u006a\u0065\u0063\u0074 \u0073\u0074\u0072\u0065\u0061\u006d");};if _ae ._abd !=nil &&!_ae ._abd .isDecrypted (_ec ){return nil ,_c .New ("\u006e\u0065\u0065\u0064\u0020\u0074\u006f\u0020\u0064\u0065\u0063r\u0079\u0070\u0074\u0020\u0074\u0068\u0065\u0020\u0073\u0074r\u0065\u0061\u006d");};_ecc :=_ec .PdfObjectDictionary ;_fg .Log .Trace ("\u0073o\u0020\u0064\u003a\u0020\u0025\u0073\n",_ecc .String ());_gff ,_ed :=_ecc .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );if !_ed {_fg .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u004f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0061\u006c\u0077\u0061\u0079\u0073\u0020\u0068\u0061\u0076\u0065\u0020\u0061\u0020\u0054\u0079\u0070\u0065");return nil ,_c .New ("\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065a\u006d\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020T\u0079\u0070\u0065");};if _afa .ToLower (string (*_gff ))!="\u006f\u0062\u006a\u0073\u0074\u006d"{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u004f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065a\u006d\u0020\u0074\u0079\u0070\u0065\u0020s\u0068\u0061\u006c\u006c\u0020\u0061\u006c\u0077\u0061\u0079\u0073 \u0062\u0065\u0020\u004f\u0062\u006a\u0053\u0074\u006d\u0020\u0021");return nil ,_c .New ("\u006f\u0062\u006a\u0065c\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0074y\u0070e\u0020\u0021\u003d\u0020\u004f\u0062\u006aS\u0074\u006d");};N ,_ed :=_ecc .Get ("\u004e").(*PdfObjectInteger );if !_ed {return nil ,_c .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u004e\u0020i\u006e\u0020\u0073\u0074\u0072\u0065\u0061m\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079");};_gcdc ,_ed :=_ecc .Get ("\u0046\u0069\u0072s\u0074").(*PdfObjectInteger );if !_ed {return nil ,_c .New ("\u0069\u006e\u0076al\u0069\u0064\u0020\u0046\u0069\u0072\u0073\u0074\u0020i\u006e \u0073t\u0072e\u0061\u006d\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079");};_fg .Log .Trace ("\u0074\u0079\u0070\u0065\u003a\u0020\u0025\u0073\u0020\u006eu\u006d\u0062\u0065\u0072\u0020\u006f\u0066 \u006f\u0062\u006a\u0065\u0063\u0074\u0073\u003a\u0020\u0025\u0064",_gff ,*N );_gcf ,_bg :=DecodeStream (_ec );if _bg !=nil {return nil ,_bg ;};_fg .Log .Trace ("D\u0065\u0063\u006f\u0064\u0065\u0064\u003a\u0020\u0025\u0073",_gcf );_cdf :=_ae .GetFileOffset ();defer func (){_ae .SetFileOffset (_cdf )}();_fd =_gcd .NewReader (_gcf );_ae ._daba =_eg .NewReader (_fd );_fg .Log .Trace ("\u0050a\u0072s\u0069\u006e\u0067\u0020\u006ff\u0066\u0073e\u0074\u0020\u006d\u0061\u0070");_bc :=map[int ]int64 {};for _ff :=0;_ff < int (*N );_ff ++{_ae .skipSpaces ();_bdg ,_cfeb :=_ae .parseNumber ();if _cfeb !=nil {return nil ,_cfeb ;};_fgd ,_ged :=_bdg .(*PdfObjectInteger );if !_ged {return nil ,_c .New ("\u0069\u006e\u0076al\u0069\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074 \u0073t\u0072e\u0061m\u0020\u006f\u0066\u0066\u0073\u0065\u0074\u0020\u0074\u0061\u0062\u006c\u0065");};_ae .skipSpaces ();_bdg ,_cfeb =_ae .parseNumber ();if _cfeb !=nil {return nil ,_cfeb ;};_adf ,_ged :=_bdg .(*PdfObjectInteger );if !_ged {return nil ,_c .New ("\u0069\u006e\u0076al\u0069\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074 \u0073t\u0072e\u0061m\u0020\u006f\u0066\u0066\u0073\u0065\u0074\u0020\u0074\u0061\u0062\u006c\u0065");};_fg .Log .Trace ("\u006f\u0062j\u0020\u0025\u0064 \u006f\u0066\u0066\u0073\u0065\u0074\u0020\u0025\u0064",*_fgd ,*_adf );_bc [int (*_fgd )]=int64 (*_gcdc +*_adf );};_ac =objectStream {N :int (*N ),_eee :_gcf ,_dff :_bc };_ae ._eaeg [_cfe ]=_ac ;}else {_fca :=_ae .GetFileOffset ();defer func (){_ae .SetFileOffset (_fca )}();_fd =_gcd .NewReader (_ac ._eee );_ae ._daba =_eg .NewReader (_fd );};_ea :=_ac ._dff [_eb ];_fg .Log .Trace ("\u0041\u0043\u0054\u0055AL\u0020\u006f\u0066\u0066\u0073\u0065\u0074\u005b\u0025\u0064\u005d\u0020\u003d\u0020%\u0064",_eb ,_ea );_fd .Seek (_ea ,_de .SeekStart );_ae ._daba =_eg .NewReader (_fd );_ce ,_ :=_ae ._daba .Peek (100);_fg .Log .Trace ("\u004f\u0042\u004a\u0020\u0070\u0065\u0065\u006b\u0020\u0022\u0025\u0073\u0022",string (_ce ));_fa ,_gce :=_ae .parseObject ();if _gce !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0046\u0061\u0069\u006c \u0074\u006f\u0020\u0072\u0065\u0061\u0064 \u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0028\u0025\u0073\u0029",_gce );return nil ,_gce ;};if _fa ==nil {return nil ,_c .New ("o\u0062\u006a\u0065\u0063t \u0063a\u006e\u006e\u006f\u0074\u0020b\u0065\u0020\u006e\u0075\u006c\u006c");};_bge :=PdfIndirectObject {};_bge .ObjectNumber =int64 (_eb );_bge .PdfObject =_fa ;return &_bge ,nil ;};
+WriteString ()string ;};func (_ae *PdfParser )lookupObjectViaOS (_cfe int ,_eb int )(PdfObject ,error ){var _fd *_gcd .Reader ;var _ac objectStream ;var _ag bool ;_ac ,_ag =_ae .cachedObjectStream (_cfe );if !_ag {_ege ,_ ,_bg :=_ae .lookupByNumberWrapper (_cfe ,true );if _bg !=nil {_fg .Log .Debug ("\u004d\u0069ss\u0069\u006e\u0067 \u006f\u0062\u006a\u0065ct \u0073tr\u0065\u0061\u006d\u0020\u0077\u0069\u0074h \u006e\u0075\u006d\u0062\u0065\u0072\u0020%\u0064",_cfe );return nil ,_bg ;};_ec ,_ed :=_ege .(*PdfObjectStream );if !_ed {return nil ,_c .New ("i\u006e\u0076\u0061\u006cid\u0020o\u0062\u006a\u0065\u0063\u0074 \u0073\u0074\u0072\u0065\u0061\u006d");};if _ae ._abd !=nil &&!_ae ._abd .isDecrypted (_ec ){return nil ,_c .New ("\u006e\u0065\u0065\u0064\u0020\u0074\u006f\u0020\u0064\u0065\u0063r\u0079\u0070\u0074\u0020\u0074\u0068\u0065\u0020\u0073\u0074r\u0065\u0061\u006d");};_ecc :=_ec .PdfObjectDictionary ;_fg .Log .Trace ("\u0073o\u0020\u0064\u003a\u0020\u0025\u0073\n",_ecc .String ());_gff ,_ed :=_ecc .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );if !_ed {_fg .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u004f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0061\u006c\u0077\u0061\u0079\u0073\u0020\u0068\u0061\u0076\u0065\u0020\u0061\u0020\u0054\u0079\u0070\u0065");return nil ,_c .New ("\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065a\u006d\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020T\u0079\u0070\u0065");};if _afa .ToLower (string (*_gff ))!="\u006f\u0062\u006a\u0073\u0074\u006d"{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u004f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0074\u0072\u0065a\u006d\u0020\u0074\u0079\u0070\u0065\u0020s\u0068\u0061\u006c\u006c\u0020\u0061\u006c\u0077\u0061\u0079\u0073 \u0062\u0065\u0020\u004f\u0062\u006a\u0053\u0074\u006d\u0020\u0021");return nil ,_c .New ("\u006f\u0062\u006a\u0065c\u0074\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0074y\u0070e\u0020\u0021\u003d\u0020\u004f\u0062\u006aS\u0074\u006d");};N ,_ed :=_ecc .Get ("\u004e").(*PdfObjectInteger );if !_ed {return nil ,_c .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u004e\u0020i\u006e\u0020\u0073\u0074\u0072\u0065\u0061m\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079");};_gcdc ,_ed :=_ecc .Get ("\u0046\u0069\u0072s\u0074").(*PdfObjectInteger );if !_ed {return nil ,_c .New ("\u0069\u006e\u0076al\u0069\u0064\u0020\u0046\u0069\u0072\u0073\u0074\u0020i\u006e \u0073t\u0072e\u0061\u006d\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079");};_fg .Log .Trace ("\u0074\u0079\u0070\u0065\u003a\u0020\u0025\u0073\u0020\u006eu\u006d\u0062\u0065\u0072\u0020\u006f\u0066 \u006f\u0062\u006a\u0065\u0063\u0074\u0073\u003a\u0020\u0025\u0064",_gff ,*N );_gcf ,_bg :=_ae .decodeStream (_ec );if _bg !=nil {return nil ,_bg ;};_fg .Log .Trace ("D\u0065\u0063\u006f\u0064\u0065\u0064\u003a\u0020\u0025\u0073",_gcf );_cdf :=_ae .GetFileOffset ();defer func (){_ae .SetFileOffset (_cdf )}();_fd =_gcd .NewReader (_gcf );_ae ._daba =_eg .NewReader (_fd );_fg .Log .Trace ("\u0050a\u0072s\u0069\u006e\u0067\u0020\u006ff\u0066\u0073e\u0074\u0020\u006d\u0061\u0070");_bc :=map[int ]int64 {};for _ff :=0;_ff < int (*N );_ff ++{_ae .skipSpaces ();_bdg ,_cfeb :=_ae .parseNumber ();if _cfeb !=nil {return nil ,_cfeb ;};_fgd ,_ged :=_bdg .(*PdfObjectInteger );if !_ged {return nil ,_c .New ("\u0069\u006e\u0076al\u0069\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074 \u0073t\u0072e\u0061m\u0020\u006f\u0066\u0066\u0073\u0065\u0074\u0020\u0074\u0061\u0062\u006c\u0065");};_ae .skipSpaces ();_bdg ,_cfeb =_ae .parseNumber ();if _cfeb !=nil {return nil ,_cfeb ;};_adf ,_ged :=_bdg .(*PdfObjectInteger );if !_ged {return nil ,_c .New ("\u0069\u006e\u0076al\u0069\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074 \u0073t\u0072e\u0061m\u0020\u006f\u0066\u0066\u0073\u0065\u0074\u0020\u0074\u0061\u0062\u006c\u0065");};_fg .Log .Trace ("\u006f\u0062j\u0020\u0025\u0064 \u006f\u0066\u0066\u0073\u0065\u0074\u0020\u0025\u0064",*_fgd ,*_adf );_bc [int (*_fgd )]=int64 (*_gcdc +*_adf );};_ac =objectStream {N :int (*N ),_eee :_gcf ,_dff :_bc };_ae .cacheObjectStream (_cfe ,_ac );}else {_fca :=_ae .GetFileOffset ();defer func (){_ae .SetFileOffset (_fca )}();_fd =_gcd .NewReader (_ac ._eee );_ae ._daba =_eg .NewReader (_fd );};_ea :=_ac ._dff [_eb ];_fg .Log .Trace ("\u0041\u0043\u0054\u0055AL\u0020\u006f\u0066\u0066\u0073\u0065\u0074\u005b\u0025\u0064\u005d\u0020\u003d\u0020%\u0064",_eb ,_ea );_fd .Seek (_ea ,_de .SeekStart );_ae ._daba =_eg .NewReader (_fd );_ce ,_ :=_ae ._daba .Peek (100);_fg .Log .Trace ("\u004f\u0042\u004a\u0020\u0070\u0065\u0065\u006b\u0020\u0022\u0025\u0073\u0022",string (_ce ));_fa ,_gce :=_ae .parseObject ();if _gce !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0046\u0061\u0069\u006c \u0074\u006f\u0020\u0072\u0065\u0061\u0064 \u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0028\u0025\u0073\u0029",_gce );return nil ,_gce ;};if _fa ==nil {return nil ,_c .New ("o\u0062\u006a\u0065\u0063t \u0063a\u006e\u006e\u006f\u0074\u0020b\u0065\u0020\u006e\u0075\u006c\u006c");};_bge :=PdfIndirectObject {};_bge .ObjectNumber =int64 (_eb );_bge .PdfObject =_fa ;_bge ._dgcf =_ae ;return &_bge ,nil ;};
 
 // DecodeBytes decodes a slice of ASCII encoded bytes and returns the result.
 func (_ccec *ASCIIHexEncoder )DecodeBytes (encoded []byte )([]byte ,error ){_dffe :=_gcd .NewReader (encoded );var _bgcf []byte ;for {_eabd ,_agef :=_dffe .ReadByte ();if _agef !=nil {return nil ,_agef ;};if _eabd =='>'{break ;};if IsWhiteSpace (_eabd ){continue ;};if (_eabd >='a'&&_eabd <='f')||(_eabd >='A'&&_eabd <='F')||(_eabd >='0'&&_eabd <='9'){_bgcf =append (_bgcf ,_eabd );}else {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0049\u006e\u0076\u0061\u006c\u0069d\u0020\u0061\u0073\u0063\u0069\u0069 \u0068\u0065\u0078\u0020\u0063\u0068\u0061\u0072\u0061\u0063\u0074\u0065\u0072 \u0028\u0025\u0063\u0029",_eabd );return nil ,_gc .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0061\u0073\u0063\u0069\u0069\u0020\u0068e\u0078 \u0063\u0068\u0061\u0072\u0061\u0063\u0074\u0065\u0072\u0020\u0028\u0025\u0063\u0029",_eabd );};};if len (_bgcf )%2==1{_bgcf =append (_bgcf ,'0');};_fg .Log .Trace ("\u0049\u006e\u0062\u006f\u0075\u006e\u0064\u0020\u0025\u0073",_bgcf );_gdbc :=make ([]byte ,_fc .DecodedLen (len (_bgcf )));_ ,_dggf :=_fc .Decode (_gdbc ,_bgcf );if _dggf !=nil {return nil ,_dggf ;};return _gdbc ,nil ;};
 
 // IsDecimalDigit checks if the character is a part of a decimal number string.
-func IsDecimalDigit (c byte )bool {return '0'<=c &&c <='9'};func _bdeg (_adag *PdfObjectStream )(*MultiEncoder ,error ){_gebgf :=NewMultiEncoder ();_dgaf :=_adag .PdfObjectDictionary ;if _dgaf ==nil {return _gebgf ,nil ;};var _fag *PdfObjectDictionary ;var _cbge []PdfObject ;_gdga :=_dgaf .Get ("D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073");if _gdga !=nil {_eefc ,_bggc :=_gdga .(*PdfObjectDictionary );if _bggc {_fag =_eefc ;};_geeg ,_acda :=_gdga .(*PdfObjectArray );if _acda {for _ ,_eeaa :=range _geeg .Elements (){_eeaa =TraceToDirectObject (_eeaa );if _gbcc ,_caf :=_eeaa .(*PdfObjectDictionary );_caf {_cbge =append (_cbge ,_gbcc );}else {_cbge =append (_cbge ,MakeDict ());};};};};_gdga =_dgaf .Get ("\u0046\u0069\u006c\u0074\u0065\u0072");if _gdga ==nil {return nil ,_gc .Errorf ("\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u006d\u0069s\u0073\u0069\u006e\u0067");};_cbeb ,_fade :=_gdga .(*PdfObjectArray );if !_fade {return nil ,_gc .Errorf ("m\u0075\u006c\u0074\u0069\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u0063\u0061\u006e\u0020\u006f\u006el\u0079\u0020\u0062\u0065\u0020\u006d\u0061\u0064\u0065\u0020fr\u006f\u006d\u0020a\u0072r\u0061\u0079");};for _edga ,_dega :=range _cbeb .Elements (){_dada ,_ece :=_dega .(*PdfObjectName );if !_ece {return nil ,_gc .Errorf ("\u006d\u0075l\u0074\u0069\u0020\u0066i\u006c\u0074e\u0072\u0020\u0061\u0072\u0072\u0061\u0079\u0020e\u006c\u0065\u006d\u0065\u006e\u0074\u0020\u006e\u006f\u0074\u0020\u0061 \u006e\u0061\u006d\u0065");};var _afgf PdfObject ;if _fag !=nil {_afgf =_fag ;}else {if len (_cbge )> 0{if _edga >=len (_cbge ){return nil ,_gc .Errorf ("\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0065\u006c\u0065\u006d\u0065n\u0074\u0073\u0020\u0069\u006e\u0020d\u0065\u0063\u006f\u0064\u0065\u0020\u0070\u0061\u0072\u0061\u006d\u0073\u0020a\u0072\u0072\u0061\u0079");};_afgf =_cbge [_edga ];};};var _dadb *PdfObjectDictionary ;if _eeaaa ,_feba :=_afgf .(*PdfObjectDictionary );_feba {_dadb =_eeaaa ;};_fg .Log .Trace ("\u004e\u0065\u0078t \u006e\u0061\u006d\u0065\u003a\u0020\u0025\u0073\u002c \u0064p\u003a \u0025v\u002c\u0020\u0064\u0050\u0061\u0072\u0061\u006d\u0073\u003a\u0020\u0025\u0076",*_dada ,_afgf ,_dadb );if *_dada ==StreamEncodingFilterNameFlate {_fadc ,_fddd :=_cbgc (_adag ,_dadb );if _fddd !=nil {return nil ,_fddd ;};_gebgf .AddEncoder (_fadc );}else if *_dada ==StreamEncodingFilterNameLZW {_ebcd ,_adbdd :=_ead (_adag ,_dadb );if _adbdd !=nil {return nil ,_adbdd ;};_gebgf .AddEncoder (_ebcd );}else if *_dada ==StreamEncodingFilterNameASCIIHex {_ceadd :=NewASCIIHexEncoder ();_gebgf .AddEncoder (_ceadd );}else if *_dada ==StreamEncodingFilterNameASCII85 {_cedd :=NewASCII85Encoder ();_gebgf .AddEncoder (_cedd );}else if *_dada ==StreamEncodingFilterNameDCT {_acba ,_dgf :=_bde (_adag ,_gebgf );if _dgf !=nil {return nil ,_dgf ;};_gebgf .AddEncoder (_acba );_fg .Log .Trace ("A\u0064d\u0065\u0064\u0020\u0044\u0043\u0054\u0020\u0065n\u0063\u006f\u0064\u0065r.\u002e\u002e");_fg .Log .Trace ("\u004du\u006ct\u0069\u0020\u0065\u006e\u0063o\u0064\u0065r\u003a\u0020\u0025\u0023\u0076",_gebgf );}else {_fg .Log .Error ("U\u006e\u0073\u0075\u0070po\u0072t\u0065\u0064\u0020\u0066\u0069l\u0074\u0065\u0072\u0020\u0025\u0073",*_dada );return nil ,_gc .Errorf ("\u0069\u006eva\u006c\u0069\u0064 \u0066\u0069\u006c\u0074er \u0069n \u006d\u0075\u006c\u0074\u0069\u0020\u0066il\u0074\u0065\u0072\u0020\u0061\u0072\u0072a\u0079");};};return _gebgf ,nil ;};
+func IsDecimalDigit (c byte )bool {return '0'<=c &&c <='9'};func _bdeg (_adag *PdfObjectStream )(*MultiEncoder ,error ){_gebgf :=NewMultiEncoder ();_dgaf :=_adag .PdfObjectDictionary ;if _dgaf ==nil {return _gebgf ,nil ;};var _fag *PdfObjectDictionary ;var _cbge []PdfObject ;_gdga :=_dgaf .Get ("D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073");if _gdga !=nil {_eefc ,_bggc :=_gdga .(*PdfObjectDictionary );if _bggc {_fag =_eefc ;};_geeg ,_acda :=_gdga .(*PdfObjectArray );if _acda {for _ ,_eeaa :=range _geeg .Elements (){_eeaa =TraceToDirectObject (_eeaa );if _gbcc ,_caf :=_eeaa .(*PdfObjectDictionary );_caf {_cbge =append (_cbge ,_gbcc );}else {_cbge =append (_cbge ,MakeDict ());};};};};_gdga =_dgaf .Get ("\u0046\u0069\u006c\u0074\u0065\u0072");if _gdga ==nil {return nil ,_gc .Errorf ("\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u006d\u0069s\u0073\u0069\u006e\u0067");};_cbeb ,_fade :=_gdga .(*PdfObjectArray );if !_fade {return nil ,_gc .Errorf ("m\u0075\u006c\u0074\u0069\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020\u0063\u0061\u006e\u0020\u006f\u006el\u0079\u0020\u0062\u0065\u0020\u006d\u0061\u0064\u0065\u0020fr\u006f\u006d\u0020a\u0072r\u0061\u0079");};for _edga ,_dega :=range _cbeb .Elements (){_dada ,_ece :=_dega .(*PdfObjectName );if !_ece {return nil ,_gc .Errorf ("\u006d\u0075l\u0074\u0069\u0020\u0066i\u006c\u0074e\u0072\u0020\u0061\u0072\u0072\u0061\u0079\u0020e\u006c\u0065\u006d\u0065\u006e\u0074\u0020\u006e\u006f\u0074\u0020\u0061 \u006e\u0061\u006d\u0065");};var _afgf PdfObject ;if _fag !=nil {_afgf =_fag ;}else {if len (_cbge )> 0{if _edga >=len (_cbge ){return nil ,_gc .Errorf ("\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0065\u006c\u0065\u006d\u0065n\u0074\u0073\u0020\u0069\u006e\u0020d\u0065\u0063\u006f\u0064\u0065\u0020\u0070\u0061\u0072\u0061\u006d\u0073\u0020a\u0072\u0072\u0061\u0079");};_afgf =_cbge [_edga ];};};var _dadb *PdfObjectDictionary ;if _eeaaa ,_feba :=_afgf .(*PdfObjectDictionary );_feba {_dadb =_eeaaa ;};_fg .Log .Trace ("\u004e\u0065\u0078t \u006e\u0061\u006d\u0065\u003a\u0020\u0025\u0073\u002c \u0064p\u003a \u0025v\u002c\u0020\u0064\u0050\u0061\u0072\u0061\u006d\u0073\u003a\u0020\u0025\u0076",*_dada ,_afgf ,_dadb );if *_dada =="Crypt"{continue ;};if *_dada ==StreamEncodingFilterNameFlate {_fadc ,_fddd :=_cbgc (_adag ,_dadb );if _fddd !=nil {return nil ,_fddd ;};_gebgf .AddEncoder (_fadc );}else if *_dada ==StreamEncodingFilterNameLZW {_ebcd ,_adbdd :=_ead (_adag ,_dadb );if _adbdd !=nil {return nil ,_adbdd ;};_gebgf .AddEncoder (_ebcd );}else if *_dada ==StreamEncodingFilterNameASCIIHex {_ceadd :=NewASCIIHexEncoder ();_gebgf .AddEncoder (_ceadd );}else if *_dada ==StreamEncodingFilterNameASCII85 {_cedd :=NewASCII85Encoder ();_gebgf .AddEncoder (_cedd );}else if *_dada ==StreamEncodingFilterNameDCT {_acba ,_dgf :=_bde (_adag ,_gebgf );if _dgf !=nil {return nil ,_dgf ;};_gebgf .AddEncoder (_acba );_fg .Log .Trace ("A\u0064d\u0065\u0064\u0020\u0044\u0043\u0054\u0020\u0065n\u0063\u006f\u0064\u0065r.\u002e\u002e");_fg .Log .Trace ("\u004du\u006ct\u0069\u0020\u0065\u006e\u0063o\u0064\u0065r\u003a\u0020\u0025\u0023\u0076",_gebgf );}else {_fg .Log .Error ("U\u006e\u0073\u0075\u0070po\u0072t\u0065\u0064\u0020\u0066\u0069l\u0074\u0065\u0072\u0020\u0025\u0073",*_dada );return nil ,_gc .Errorf ("\u0069\u006eva\u006c\u0069\u0064 \u0066\u0069\u006c\u0074er \u0069n \u006d\u0075\u006c\u0074\u0069\u0020\u0066il\u0074\u0065\u0072\u0020\u0061\u0072\u0072a\u0079");};};return _gebgf ,nil ;};
 
 // String returns a string describing `array`.
 func (_geca *PdfObjectArray )String ()string {_ecdg :="\u005b";for _cbbg ,_dfafe :=range _geca .Elements (){_ecdg +=_dfafe .String ();if _cbbg < (_geca .Len ()-1){_ecdg +="\u002c\u0020";};};_ecdg +="\u005d";return _ecdg ;};func (_aeaad *PdfParser )readComment ()(string ,error ){var _afff _gcd .Buffer ;_ ,_gcbe :=_aeaad .skipSpaces ();if _gcbe !=nil {return _afff .String (),_gcbe ;};_ccgf :=true ;for {_abda ,_daae :=_aeaad ._daba .Peek (1);if _daae !=nil {_fg .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u0020\u0025\u0073",_daae .Error ());return _afff .String (),_daae ;};if _ccgf &&_abda [0]!='%'{return _afff .String (),_c .New ("c\u006f\u006d\u006d\u0065\u006e\u0074 \u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0073\u0074a\u0072\u0074\u0020w\u0069t\u0068\u0020\u0025");};_ccgf =false ;if (_abda [0]!='\r')&&(_abda [0]!='\n'){_dbbd ,_ :=_aeaad ._daba .ReadByte ();_afff .WriteByte (_dbbd );}else {break ;};};return _afff .String (),nil ;};
 
-// UpdateParams updates the parameter values of the encoder.
-func (_bgf *JPXEncoder )UpdateParams (params *PdfObjectDictionary ){};
 
 // DecodeImages decodes the page images from the jbig2 'encoded' data input.
 // The jbig2 document may contain multiple pages, thus the function can return multiple
@@ -895,7 +890,7 @@ func (_febc *RunLengthEncoder )MakeDecodeParams ()PdfObject {return nil };
 
 // PdfCrypt provides PDF encryption/decryption support.
 // The PDF standard supports encryption of strings and streams (Section 7.6).
-type PdfCrypt struct{_age encryptDict ;_cgc _dfg .StdEncryptDict ;_dg string ;_gbb []byte ;_cc map[PdfObject ]bool ;_aaa map[PdfObject ]bool ;_bddg bool ;_dfb cryptFilters ;_gca string ;_fcb string ;_afaf *PdfParser ;_cdbg map[int ]struct{};};
+type PdfCrypt struct{_age encryptDict ;_cgc _dfg .StdEncryptDict ;_dg string ;_gbb []byte ;_cc map[PdfObject ]bool ;_aaa map[PdfObject ]bool ;_bddg bool ;_dfb cryptFilters ;_gca string ;_fcb string ;_afaf *PdfParser ;_cdbg map[int ]struct{};pubKey *_dfg .PubKeyEncryptDict ;};
 
 // NewASCII85Encoder makes a new ASCII85 encoder.
 func NewASCII85Encoder ()*ASCII85Encoder {_gdaeg :=&ASCII85Encoder {};return _gdaeg };
@@ -923,7 +918,10 @@ func EqualObjects (obj1 ,obj2 PdfObject )bool {return _bfead (obj1 ,obj2 ,0)};
 // Decrypt attempts to decrypt the PDF file with a specified password.  Also tries to
 // decrypt with an empty password.  Returns true if successful, false otherwise.
 // An error is returned when there is a problem with decrypting.
-func (_dbdg *PdfParser )Decrypt (password []byte )(bool ,error ){if _dbdg ._abd ==nil {return false ,_c .New ("\u0063\u0068\u0065\u0063k \u0065\u006e\u0063\u0072\u0079\u0070\u0074\u0069\u006f\u006e\u0020\u0066\u0069\u0072s\u0074");};_fedbg ,_cgbgb :=_dbdg ._abd .authenticate (password );if _cgbgb !=nil {return false ,_cgbgb ;};if !_fedbg {_fedbg ,_cgbgb =_dbdg ._abd .authenticate ([]byte (""));};return _fedbg ,_cgbgb ;};
+// The documents encrypting only their embedded files (see CryptFilterOpts) can be read without
+// the password: true is returned and the embedded files are left encrypted until the document
+// is decrypted with the password.
+func (_dbdg *PdfParser )Decrypt (password []byte )(bool ,error ){if _dbdg ._abd ==nil {return false ,_c .New ("\u0063\u0068\u0065\u0063k \u0065\u006e\u0063\u0072\u0079\u0070\u0074\u0069\u006f\u006e\u0020\u0066\u0069\u0072s\u0074");};_fedbg ,_cgbgb :=_dbdg ._abd .authenticate (password );if _cgbgb !=nil {return false ,_cgbgb ;};if !_fedbg {_fedbg ,_cgbgb =_dbdg ._abd .authenticate ([]byte (""));};if _cgbgb ==nil &&!_fedbg {_fedbg =_dbdg ._abd .openWithoutKey ();};return _fedbg ,_cgbgb ;};
 
 // GetFilterName returns the name of the encoding filter.
 func (_becf *ASCIIHexEncoder )GetFilterName ()string {return StreamEncodingFilterNameASCIIHex };var _fcbd =_a .MustCompile ("\u0025\u0025\u0045\u004f\u0046\u003f");
@@ -985,7 +983,7 @@ func (_dfce *PdfObjectString )Decoded ()string {if _dfce ==nil {return "";};_bdf
 
 // ParseIndirectObject parses an indirect object from the input stream. Can also be an object stream.
 // Returns the indirect object (*PdfIndirectObject) or the stream object (*PdfObjectStream).
-func (_bbcg *PdfParser )ParseIndirectObject ()(PdfObject ,error ){_defdg :=PdfIndirectObject {};_defdg ._dgcf =_bbcg ;_fg .Log .Trace ("\u002dR\u0065a\u0064\u0020\u0069\u006e\u0064i\u0072\u0065c\u0074\u0020\u006f\u0062\u006a");_gcea ,_dbfb :=_bbcg ._daba .Peek (20);if _dbfb !=nil {if _dbfb !=_de .EOF {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0020\u0074\u006f\u0020r\u0065a\u0064\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a");return &_defdg ,_dbfb ;};};_fg .Log .Trace ("\u0028\u0069\u006edi\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0020\u0070\u0065\u0065\u006b\u0020\u0022\u0025\u0073\u0022",string (_gcea ));_fedb :=_eed .FindStringSubmatchIndex (string (_gcea ));if len (_fedb )< 6{if _dbfb ==_de .EOF {return nil ,_dbfb ;};_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020U\u006e\u0061\u0062l\u0065\u0020\u0074\u006f \u0066\u0069\u006e\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065\u0020\u0028\u0025\u0073\u0029",string (_gcea ));return &_defdg ,_c .New ("\u0075\u006e\u0061b\u006c\u0065\u0020\u0074\u006f\u0020\u0064\u0065\u0074\u0065\u0063\u0074\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020s\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065");};_bbcg ._daba .Discard (_fedb [0]);_fg .Log .Trace ("O\u0066\u0066\u0073\u0065\u0074\u0073\u0020\u0025\u0020\u0064",_fedb );_gdbcc :=_fedb [1]-_fedb [0];_daddd :=make ([]byte ,_gdbcc );_ ,_dbfb =_bbcg .ReadAtLeast (_daddd ,_gdbcc );if _dbfb !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0075\u006e\u0061\u0062l\u0065\u0020\u0074\u006f\u0020\u0072\u0065\u0061\u0064\u0020-\u0020\u0025\u0073",_dbfb );return nil ,_dbfb ;};_fg .Log .Trace ("\u0074\u0065\u0078t\u006c\u0069\u006e\u0065\u003a\u0020\u0025\u0073",_daddd );_caeg :=_eed .FindStringSubmatch (string (_daddd ));if len (_caeg )< 3{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020U\u006e\u0061\u0062l\u0065\u0020\u0074\u006f \u0066\u0069\u006e\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065\u0020\u0028\u0025\u0073\u0029",string (_daddd ));return &_defdg ,_c .New ("\u0075\u006e\u0061b\u006c\u0065\u0020\u0074\u006f\u0020\u0064\u0065\u0074\u0065\u0063\u0074\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020s\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065");};_ecdd ,_ :=_e .Atoi (_caeg [1]);_fcbe ,_ :=_e .Atoi (_caeg [2]);_defdg .ObjectNumber =int64 (_ecdd );_defdg .GenerationNumber =int64 (_fcbe );for {_bced ,_dgab :=_bbcg ._daba .Peek (2);if _dgab !=nil {return &_defdg ,_dgab ;};_fg .Log .Trace ("I\u006ed\u002e\u0020\u0070\u0065\u0065\u006b\u003a\u0020%\u0073\u0020\u0028\u0025 x\u0029\u0021",string (_bced ),string (_bced ));if IsWhiteSpace (_bced [0]){_bbcg .skipSpaces ();}else if _bced [0]=='%'{_bbcg .skipComments ();}else if (_bced [0]=='<')&&(_bced [1]=='<'){_fg .Log .Trace ("\u0043\u0061\u006c\u006c\u0020\u0050\u0061\u0072\u0073e\u0044\u0069\u0063\u0074");_defdg .PdfObject ,_dgab =_bbcg .ParseDict ();_fg .Log .Trace ("\u0045\u004f\u0046\u0020Ca\u006c\u006c\u0020\u0050\u0061\u0072\u0073\u0065\u0044\u0069\u0063\u0074\u003a\u0020%\u0076",_dgab );if _dgab !=nil {return &_defdg ,_dgab ;};_fg .Log .Trace ("\u0050\u0061\u0072\u0073\u0065\u0064\u0020\u0064\u0069\u0063t\u0069\u006f\u006e\u0061\u0072\u0079\u002e.\u002e\u0020\u0066\u0069\u006e\u0069\u0073\u0068\u0065\u0064\u002e");}else if (_bced [0]=='/')||(_bced [0]=='(')||(_bced [0]=='[')||(_bced [0]=='<'){_defdg .PdfObject ,_dgab =_bbcg .parseObject ();if _dgab !=nil {return &_defdg ,_dgab ;};_fg .Log .Trace ("P\u0061\u0072\u0073\u0065\u0064\u0020o\u0062\u006a\u0065\u0063\u0074\u0020\u002e\u002e\u002e \u0066\u0069\u006ei\u0073h\u0065\u0064\u002e");}else if _bced [0]==']'{_fg .Log .Debug ("\u0057\u0041\u0052\u004e\u0049N\u0047\u003a\u0020\u0027\u005d\u0027 \u0063\u0068\u0061\u0072\u0061\u0063\u0074e\u0072\u0020\u006eo\u0074\u0020\u0062\u0065i\u006e\u0067\u0020\u0075\u0073\u0065d\u0020\u0061\u0073\u0020\u0061\u006e\u0020\u0061\u0072\u0072\u0061\u0079\u0020\u0065\u006e\u0064\u0069n\u0067\u0020\u006d\u0061\u0072\u006b\u0065\u0072\u002e\u0020\u0053\u006b\u0069\u0070\u0070\u0069\u006e\u0067\u002e");_bbcg ._daba .Discard (1);}else {if _bced [0]=='e'{_cefeg ,_ffgag :=_bbcg .readTextLine ();if _ffgag !=nil {return nil ,_ffgag ;};if len (_cefeg )>=6&&_cefeg [0:6]=="\u0065\u006e\u0064\u006f\u0062\u006a"{break ;};}else if _bced [0]=='s'{_bced ,_ =_bbcg ._daba .Peek (10);if string (_bced [:6])=="\u0073\u0074\u0072\u0065\u0061\u006d"{_fafa :=6;if len (_bced )> 6{if IsWhiteSpace (_bced [_fafa ])&&_bced [_fafa ]!='\r'&&_bced [_fafa ]!='\n'{_fg .Log .Debug ("\u004e\u006fn\u002d\u0063\u006f\u006e\u0066\u006f\u0072\u006d\u0061\u006e\u0074\u0020\u0050\u0044\u0046\u0020\u006e\u006f\u0074 \u0065\u006e\u0064\u0069\u006e\u0067 \u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006c\u0069\u006e\u0065\u0020\u0070\u0072o\u0070\u0065r\u006c\u0079\u0020\u0077i\u0074\u0068\u0020\u0045\u004fL\u0020\u006d\u0061\u0072\u006b\u0065\u0072");_fafa ++;};if _bced [_fafa ]=='\r'{_fafa ++;if _bced [_fafa ]=='\n'{_fafa ++;};}else if _bced [_fafa ]=='\n'{_fafa ++;};};_bbcg ._daba .Discard (_fafa );_cfa ,_dfgef :=_defdg .PdfObject .(*PdfObjectDictionary );if !_dfgef {return nil ,_c .New ("\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006di\u0073s\u0069\u006e\u0067\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079");};_fg .Log .Trace ("\u0053\u0074\u0072\u0065\u0061\u006d\u0020\u0064\u0069c\u0074\u0020\u0025\u0073",_cfa );_deecc ,_gbaa :=_bbcg .traceStreamLength (_cfa .Get ("\u004c\u0065\u006e\u0067\u0074\u0068"));if _gbaa !=nil {_fg .Log .Debug ("\u0046\u0061\u0069l\u0020\u0074\u006f\u0020t\u0072\u0061\u0063\u0065\u0020\u0073\u0074r\u0065\u0061\u006d\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u003a\u0020\u0025\u0076",_gbaa );return nil ,_gbaa ;};_fg .Log .Trace ("\u0053\u0074\u0072\u0065\u0061\u006d\u0020\u006c\u0065\u006e\u0067\u0074h\u003f\u0020\u0025\u0073",_deecc );_abac ,_bgac :=_deecc .(*PdfObjectInteger );if !_bgac {return nil ,_c .New ("\u0073\u0074re\u0061\u006d\u0020l\u0065\u006e\u0067\u0074h n\u0065ed\u0073\u0020\u0074\u006f\u0020\u0062\u0065 a\u006e\u0020\u0069\u006e\u0074\u0065\u0067e\u0072");};_dgdg :=*_abac ;if _dgdg < 0{return nil ,_c .New ("\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006e\u0065\u0065\u0064\u0073\u0020\u0074\u006f \u0062e\u0020\u006c\u006f\u006e\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u0030");};_afba :=_bbcg .GetFileOffset ();_edc :=_bbcg .xrefNextObjectOffset (_afba );if _afba +int64 (_dgdg )> _edc &&_edc > _afba {_fg .Log .Debug ("E\u0078\u0070\u0065\u0063te\u0064 \u0065\u006e\u0064\u0069\u006eg\u0020\u0061\u0074\u0020\u0025\u0064",_afba +int64 (_dgdg ));_fg .Log .Debug ("\u004e\u0065\u0078\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074 \u0073\u0074\u0061\u0072\u0074\u0069\u006e\u0067\u0020\u0061t\u0020\u0025\u0064",_edc );_afcf :=_edc -_afba -17;if _afcf < 0{return nil ,_c .New ("\u0069n\u0076\u0061l\u0069\u0064\u0020\u0073t\u0072\u0065\u0061m\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u002c\u0020go\u0069\u006e\u0067 \u0070\u0061s\u0074\u0020\u0062\u006f\u0075\u006ed\u0061\u0072i\u0065\u0073");};_fg .Log .Debug ("\u0041\u0074\u0074\u0065\u006d\u0070\u0074\u0069\u006e\u0067\u0020\u0061\u0020l\u0065\u006e\u0067\u0074\u0068\u0020c\u006f\u0072\u0072\u0065\u0063\u0074\u0069\u006f\u006e\u0020\u0074\u006f\u0020%\u0064\u002e\u002e\u002e",_afcf );_dgdg =PdfObjectInteger (_afcf );_cfa .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (_afcf ));};if int64 (_dgdg )> _bbcg ._eecde {_fg .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0053t\u0072\u0065\u0061\u006d\u0020l\u0065\u006e\u0067\u0074\u0068\u0020\u0063\u0061\u006e\u006e\u006f\u0074\u0020\u0062\u0065\u0020\u006c\u0061\u0072\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u0066\u0069\u006c\u0065\u0020\u0073\u0069\u007a\u0065");return nil ,_c .New ("\u0069n\u0076\u0061l\u0069\u0064\u0020\u0073t\u0072\u0065\u0061m\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u002c\u0020la\u0072\u0067\u0065r\u0020\u0074h\u0061\u006e\u0020\u0066\u0069\u006ce\u0020\u0073i\u007a\u0065");};_adga :=make ([]byte ,_dgdg );_ ,_gbaa =_bbcg .ReadAtLeast (_adga ,int (_dgdg ));if _gbaa !=nil {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052 s\u0074r\u0065\u0061\u006d\u0020\u0028%\u0064\u0029\u003a\u0020\u0025\u0058",len (_adga ),_adga );_fg .Log .Debug ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_gbaa );return nil ,_gbaa ;};_bfdc :=PdfObjectStream {};_bfdc .Stream =_adga ;_bfdc .PdfObjectDictionary =_defdg .PdfObject .(*PdfObjectDictionary );_bfdc .ObjectNumber =_defdg .ObjectNumber ;_bfdc .GenerationNumber =_defdg .GenerationNumber ;_bfdc .PdfObjectReference ._dgcf =_bbcg ;_bbcg .skipSpaces ();_bbcg ._daba .Discard (9);_bbcg .skipSpaces ();return &_bfdc ,nil ;};};_defdg .PdfObject ,_dgab =_bbcg .parseObject ();if _defdg .PdfObject ==nil {_fg .Log .Debug ("\u0049N\u0043\u004f\u004dP\u0041\u0054\u0049B\u0049LI\u0054\u0059\u003a\u0020\u0049\u006e\u0064i\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006e\u006f\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067\u0020\u0061n \u006fb\u006a\u0065\u0063\u0074\u0020\u002d \u0061\u0073\u0073\u0075\u006di\u006e\u0067\u0020\u006e\u0075\u006c\u006c\u0020\u006f\u0062\u006ae\u0063\u0074");_defdg .PdfObject =MakeNull ();};return &_defdg ,_dgab ;};};if _defdg .PdfObject ==nil {_fg .Log .Debug ("\u0049N\u0043\u004f\u004dP\u0041\u0054\u0049B\u0049LI\u0054\u0059\u003a\u0020\u0049\u006e\u0064i\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006e\u006f\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067\u0020\u0061n \u006fb\u006a\u0065\u0063\u0074\u0020\u002d \u0061\u0073\u0073\u0075\u006di\u006e\u0067\u0020\u006e\u0075\u006c\u006c\u0020\u006f\u0062\u006ae\u0063\u0074");_defdg .PdfObject =MakeNull ();};_fg .Log .Trace ("\u0052\u0065\u0074\u0075rn\u0069\u006e\u0067\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0021");return &_defdg ,nil ;};
+func (_bbcg *PdfParser )ParseIndirectObject ()(PdfObject ,error ){_defdg :=PdfIndirectObject {};_defdg ._dgcf =_bbcg ;_fg .Log .Trace ("\u002dR\u0065a\u0064\u0020\u0069\u006e\u0064i\u0072\u0065c\u0074\u0020\u006f\u0062\u006a");_gcea ,_dbfb :=_bbcg ._daba .Peek (20);if _dbfb !=nil {if _dbfb !=_de .EOF {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0020\u0074\u006f\u0020r\u0065a\u0064\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a");return &_defdg ,_dbfb ;};};_fg .Log .Trace ("\u0028\u0069\u006edi\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0020\u0070\u0065\u0065\u006b\u0020\u0022\u0025\u0073\u0022",string (_gcea ));_fedb :=_eed .FindStringSubmatchIndex (string (_gcea ));if len (_fedb )< 6{if _dbfb ==_de .EOF {return nil ,_dbfb ;};_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020U\u006e\u0061\u0062l\u0065\u0020\u0074\u006f \u0066\u0069\u006e\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065\u0020\u0028\u0025\u0073\u0029",string (_gcea ));return &_defdg ,_c .New ("\u0075\u006e\u0061b\u006c\u0065\u0020\u0074\u006f\u0020\u0064\u0065\u0074\u0065\u0063\u0074\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020s\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065");};_bbcg ._daba .Discard (_fedb [0]);_fg .Log .Trace ("O\u0066\u0066\u0073\u0065\u0074\u0073\u0020\u0025\u0020\u0064",_fedb );_gdbcc :=_fedb [1]-_fedb [0];_daddd :=make ([]byte ,_gdbcc );_ ,_dbfb =_bbcg .ReadAtLeast (_daddd ,_gdbcc );if _dbfb !=nil {_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0075\u006e\u0061\u0062l\u0065\u0020\u0074\u006f\u0020\u0072\u0065\u0061\u0064\u0020-\u0020\u0025\u0073",_dbfb );return nil ,_dbfb ;};_fg .Log .Trace ("\u0074\u0065\u0078t\u006c\u0069\u006e\u0065\u003a\u0020\u0025\u0073",_daddd );_caeg :=_eed .FindStringSubmatch (string (_daddd ));if len (_caeg )< 3{_fg .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020U\u006e\u0061\u0062l\u0065\u0020\u0074\u006f \u0066\u0069\u006e\u0064\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065\u0020\u0028\u0025\u0073\u0029",string (_daddd ));return &_defdg ,_c .New ("\u0075\u006e\u0061b\u006c\u0065\u0020\u0074\u006f\u0020\u0064\u0065\u0074\u0065\u0063\u0074\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020s\u0069\u0067\u006e\u0061\u0074\u0075\u0072\u0065");};_ecdd ,_ :=_e .Atoi (_caeg [1]);_fcbe ,_ :=_e .Atoi (_caeg [2]);_defdg .ObjectNumber =int64 (_ecdd );_defdg .GenerationNumber =int64 (_fcbe );for {_bced ,_dgab :=_bbcg ._daba .Peek (2);if _dgab !=nil {return &_defdg ,_dgab ;};_fg .Log .Trace ("I\u006ed\u002e\u0020\u0070\u0065\u0065\u006b\u003a\u0020%\u0073\u0020\u0028\u0025 x\u0029\u0021",string (_bced ),string (_bced ));if IsWhiteSpace (_bced [0]){_bbcg .skipSpaces ();}else if _bced [0]=='%'{_bbcg .skipComments ();}else if (_bced [0]=='<')&&(_bced [1]=='<'){_fg .Log .Trace ("\u0043\u0061\u006c\u006c\u0020\u0050\u0061\u0072\u0073e\u0044\u0069\u0063\u0074");_defdg .PdfObject ,_dgab =_bbcg .ParseDict ();_fg .Log .Trace ("\u0045\u004f\u0046\u0020Ca\u006c\u006c\u0020\u0050\u0061\u0072\u0073\u0065\u0044\u0069\u0063\u0074\u003a\u0020%\u0076",_dgab );if _dgab !=nil {return &_defdg ,_dgab ;};_fg .Log .Trace ("\u0050\u0061\u0072\u0073\u0065\u0064\u0020\u0064\u0069\u0063t\u0069\u006f\u006e\u0061\u0072\u0079\u002e.\u002e\u0020\u0066\u0069\u006e\u0069\u0073\u0068\u0065\u0064\u002e");}else if (_bced [0]=='/')||(_bced [0]=='(')||(_bced [0]=='[')||(_bced [0]=='<'){_defdg .PdfObject ,_dgab =_bbcg .parseObject ();if _dgab !=nil {return &_defdg ,_dgab ;};_fg .Log .Trace ("P\u0061\u0072\u0073\u0065\u0064\u0020o\u0062\u006a\u0065\u0063\u0074\u0020\u002e\u002e\u002e \u0066\u0069\u006ei\u0073h\u0065\u0064\u002e");}else if _bced [0]==']'{_fg .Log .Debug ("\u0057\u0041\u0052\u004e\u0049N\u0047\u003a\u0020\u0027\u005d\u0027 \u0063\u0068\u0061\u0072\u0061\u0063\u0074e\u0072\u0020\u006eo\u0074\u0020\u0062\u0065i\u006e\u0067\u0020\u0075\u0073\u0065d\u0020\u0061\u0073\u0020\u0061\u006e\u0020\u0061\u0072\u0072\u0061\u0079\u0020\u0065\u006e\u0064\u0069n\u0067\u0020\u006d\u0061\u0072\u006b\u0065\u0072\u002e\u0020\u0053\u006b\u0069\u0070\u0070\u0069\u006e\u0067\u002e");_bbcg ._daba .Discard (1);}else {if _bced [0]=='e'{_cefeg ,_ffgag :=_bbcg .readTextLine ();if _ffgag !=nil {return nil ,_ffgag ;};if len (_cefeg )>=6&&_cefeg [0:6]=="\u0065\u006e\u0064\u006f\u0062\u006a"{break ;};}else if _bced [0]=='s'{_bced ,_ =_bbcg ._daba .Peek (10);if string (_bced [:6])=="\u0073\u0074\u0072\u0065\u0061\u006d"{_fafa :=6;if len (_bced )> 6{if IsWhiteSpace (_bced [_fafa ])&&_bced [_fafa ]!='\r'&&_bced [_fafa ]!='\n'{_fg .Log .Debug ("\u004e\u006fn\u002d\u0063\u006f\u006e\u0066\u006f\u0072\u006d\u0061\u006e\u0074\u0020\u0050\u0044\u0046\u0020\u006e\u006f\u0074 \u0065\u006e\u0064\u0069\u006e\u0067 \u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006c\u0069\u006e\u0065\u0020\u0070\u0072o\u0070\u0065r\u006c\u0079\u0020\u0077i\u0074\u0068\u0020\u0045\u004fL\u0020\u006d\u0061\u0072\u006b\u0065\u0072");_fafa ++;};if _bced [_fafa ]=='\r'{_fafa ++;if _bced [_fafa ]=='\n'{_fafa ++;};}else if _bced [_fafa ]=='\n'{_fafa ++;};};_bbcg ._daba .Discard (_fafa );_cfa ,_dfgef :=_defdg .PdfObject .(*PdfObjectDictionary );if !_dfgef {return nil ,_c .New ("\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006di\u0073s\u0069\u006e\u0067\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079");};_fg .Log .Trace ("\u0053\u0074\u0072\u0065\u0061\u006d\u0020\u0064\u0069c\u0074\u0020\u0025\u0073",_cfa );if _bbcg .repairReport !=nil {_bbcg .repairStreamLength (_cfa ,_defdg .ObjectNumber ,_defdg .GenerationNumber );};_deecc ,_gbaa :=_bbcg .traceStreamLength (_cfa .Get ("\u004c\u0065\u006e\u0067\u0074\u0068"));if _gbaa !=nil {_fg .Log .Debug ("\u0046\u0061\u0069l\u0020\u0074\u006f\u0020t\u0072\u0061\u0063\u0065\u0020\u0073\u0074r\u0065\u0061\u006d\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u003a\u0020\u0025\u0076",_gbaa );return nil ,_gbaa ;};_fg .Log .Trace ("\u0053\u0074\u0072\u0065\u0061\u006d\u0020\u006c\u0065\u006e\u0067\u0074h\u003f\u0020\u0025\u0073",_deecc );_abac ,_bgac :=_deecc .(*PdfObjectInteger );if !_bgac {return nil ,_c .New ("\u0073\u0074re\u0061\u006d\u0020l\u0065\u006e\u0067\u0074h n\u0065ed\u0073\u0020\u0074\u006f\u0020\u0062\u0065 a\u006e\u0020\u0069\u006e\u0074\u0065\u0067e\u0072");};_dgdg :=*_abac ;if _dgdg < 0{return nil ,_c .New ("\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006e\u0065\u0065\u0064\u0073\u0020\u0074\u006f \u0062e\u0020\u006c\u006f\u006e\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u0030");};_afba :=_bbcg .GetFileOffset ();_edc :=_bbcg .xrefNextObjectOffset (_afba );if _afba +int64 (_dgdg )> _edc &&_edc > _afba {_fg .Log .Debug ("E\u0078\u0070\u0065\u0063te\u0064 \u0065\u006e\u0064\u0069\u006eg\u0020\u0061\u0074\u0020\u0025\u0064",_afba +int64 (_dgdg ));_fg .Log .Debug ("\u004e\u0065\u0078\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074 \u0073\u0074\u0061\u0072\u0074\u0069\u006e\u0067\u0020\u0061t\u0020\u0025\u0064",_edc );_afcf :=_edc -_afba -17;if _afcf < 0{return nil ,_c .New ("\u0069n\u0076\u0061l\u0069\u0064\u0020\u0073t\u0072\u0065\u0061m\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u002c\u0020go\u0069\u006e\u0067 \u0070\u0061s\u0074\u0020\u0062\u006f\u0075\u006ed\u0061\u0072i\u0065\u0073");};_fg .Log .Debug ("\u0041\u0074\u0074\u0065\u006d\u0070\u0074\u0069\u006e\u0067\u0020\u0061\u0020l\u0065\u006e\u0067\u0074\u0068\u0020c\u006f\u0072\u0072\u0065\u0063\u0074\u0069\u006f\u006e\u0020\u0074\u006f\u0020%\u0064\u002e\u002e\u002e",_afcf );_dgdg =PdfObjectInteger (_afcf );_cfa .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (_afcf ));};if int64 (_dgdg )> _bbcg ._eecde {_fg .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0053t\u0072\u0065\u0061\u006d\u0020l\u0065\u006e\u0067\u0074\u0068\u0020\u0063\u0061\u006e\u006e\u006f\u0074\u0020\u0062\u0065\u0020\u006c\u0061\u0072\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u0066\u0069\u006c\u0065\u0020\u0073\u0069\u007a\u0065");return nil ,_c .New ("\u0069n\u0076\u0061l\u0069\u0064\u0020\u0073t\u0072\u0065\u0061m\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u002c\u0020la\u0072\u0067\u0065r\u0020\u0074h\u0061\u006e\u0020\u0066\u0069\u006ce\u0020\u0073i\u007a\u0065");};_adga :=make ([]byte ,_dgdg );_ ,_gbaa =_bbcg .ReadAtLeast (_adga ,int (_dgdg ));if _gbaa !=nil {_fg .Log .Debug ("E\u0052\u0052\u004f\u0052 s\u0074r\u0065\u0061\u006d\u0020\u0028%\u0064\u0029\u003a\u0020\u0025\u0058",len (_adga ),_adga );_fg .Log .Debug ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_gbaa );return nil ,_gbaa ;};_bfdc :=PdfObjectStream {};_bfdc .Stream =_adga ;_bfdc .PdfObjectDictionary =_defdg .PdfObject .(*PdfObjectDictionary );_bfdc .ObjectNumber =_defdg .ObjectNumber ;_bfdc .GenerationNumber =_defdg .GenerationNumber ;_bfdc .PdfObjectReference ._dgcf =_bbcg ;_bbcg .skipSpaces ();_bbcg ._daba .Discard (9);_bbcg .skipSpaces ();return &_bfdc ,nil ;};};_defdg .PdfObject ,_dgab =_bbcg .parseObject ();if _defdg .PdfObject ==nil {_fg .Log .Debug ("\u0049N\u0043\u004f\u004dP\u0041\u0054\u0049B\u0049LI\u0054\u0059\u003a\u0020\u0049\u006e\u0064i\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006e\u006f\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067\u0020\u0061n \u006fb\u006a\u0065\u0063\u0074\u0020\u002d \u0061\u0073\u0073\u0075\u006di\u006e\u0067\u0020\u006e\u0075\u006c\u006c\u0020\u006f\u0062\u006ae\u0063\u0074");_defdg .PdfObject =MakeNull ();};return &_defdg ,_dgab ;};};if _defdg .PdfObject ==nil {_fg .Log .Debug ("\u0049N\u0043\u004f\u004dP\u0041\u0054\u0049B\u0049LI\u0054\u0059\u003a\u0020\u0049\u006e\u0064i\u0072\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006e\u006f\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067\u0020\u0061n \u006fb\u006a\u0065\u0063\u0074\u0020\u002d \u0061\u0073\u0073\u0075\u006di\u006e\u0067\u0020\u006e\u0075\u006c\u006c\u0020\u006f\u0062\u006ae\u0063\u0074");_defdg .PdfObject =MakeNull ();};_fg .Log .Trace ("\u0052\u0065\u0074\u0075rn\u0069\u006e\u0067\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074\u0021");return &_defdg ,nil ;};
 
 // WriteString outputs the object as it is to be written to file.
 func (_fcgb *PdfObjectName )WriteString ()string {var _gcda _gcd .Buffer ;if len (*_fcgb )> 127{_fg .Log .Debug ("\u0045R\u0052\u004f\u0052\u003a \u004e\u0061\u006d\u0065\u0020t\u006fo\u0020l\u006f\u006e\u0067\u0020\u0028\u0025\u0073)",*_fcgb );};_gcda .WriteString ("\u002f");for _cgaba :=0;_cgaba < len (*_fcgb );_cgaba ++{_bfad :=(*_fcgb )[_cgaba ];if !IsPrintable (_bfad )||_bfad =='#'||IsDelimiter (_bfad ){_gcda .WriteString (_gc .Sprintf ("\u0023\u0025\u002e2\u0078",_bfad ));}else {_gcda .WriteByte (_bfad );};};return _gcda .String ();};
@@ -1016,7 +1014,7 @@ func (_edaa *PdfObjectNull )WriteString ()string {return "\u006e\u0075\u006c\u00
 // Traverses through all the subobjects (recursive).
 //
 // Does not look up references..  That should be done prior to calling.
-func (_gaac *PdfCrypt )Encrypt (obj PdfObject ,parentObjNum ,parentGenNum int64 )error {if _gaac .isEncrypted (obj ){return nil ;};switch _aagf :=obj .(type ){case *PdfIndirectObject :_gaac ._aaa [_aagf ]=true ;_fg .Log .Trace ("\u0045\u006e\u0063\u0072\u0079\u0070\u0074\u0069\u006e\u0067 \u0069\u006e\u0064\u0069\u0072\u0065\u0063t\u0020\u0025\u0064\u0020\u0025\u0064\u0020\u006f\u0062\u006a\u0021",_aagf .ObjectNumber ,_aagf .GenerationNumber );_agc :=_aagf .ObjectNumber ;_edfb :=_aagf .GenerationNumber ;_cdg :=_gaac .Encrypt (_aagf .PdfObject ,_agc ,_edfb );if _cdg !=nil {return _cdg ;};return nil ;case *PdfObjectStream :_gaac ._aaa [_aagf ]=true ;_geef :=_aagf .PdfObjectDictionary ;if _gda ,_bddgg :=_geef .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );_bddgg &&*_gda =="\u0058\u0052\u0065\u0066"{return nil ;};_ecffe :=_aagf .ObjectNumber ;_bgbd :=_aagf .GenerationNumber ;_fg .Log .Trace ("\u0045n\u0063\u0072\u0079\u0070t\u0069\u006e\u0067\u0020\u0073t\u0072e\u0061m\u0020\u0025\u0064\u0020\u0025\u0064\u0020!",_ecffe ,_bgbd );_efd :=_bfb ;if _gaac ._age .V >=4{_efd =_gaac ._gca ;_fg .Log .Trace ("\u0074\u0068\u0069\u0073.s\u0074\u0072\u0065\u0061\u006d\u0046\u0069\u006c\u0074\u0065\u0072\u0020\u003d\u0020%\u0073",_gaac ._gca );if _gacgf ,_acc :=_geef .Get ("\u0046\u0069\u006c\u0074\u0065\u0072").(*PdfObjectArray );_acc {if _dga ,_aga :=GetName (_gacgf .Get (0));_aga {if *_dga =="\u0043\u0072\u0079p\u0074"{_efd ="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079";if _ceaa ,_gdg :=_geef .Get ("D\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073").(*PdfObjectDictionary );_gdg {if _dcg ,_fffe :=_ceaa .Get ("\u004e\u0061\u006d\u0065").(*PdfObjectName );_fffe {if _ ,_eabg :=_gaac ._dfb [string (*_dcg )];_eabg {_fg .Log .Trace ("\u0055\u0073\u0069\u006eg \u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0066\u0069\u006c\u0074\u0065\u0072\u0020%\u0073",*_dcg );_efd =string (*_dcg );};};};};};};_fg .Log .Trace ("\u0077\u0069\u0074\u0068\u0020\u0025\u0073\u0020\u0066i\u006c\u0074\u0065\u0072",_efd );if _efd =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{return nil ;};};_bbd :=_gaac .Encrypt (_aagf .PdfObjectDictionary ,_ecffe ,_bgbd );if _bbd !=nil {return _bbd ;};_aff ,_bbd :=_gaac .makeKey (_efd ,uint32 (_ecffe ),uint32 (_bgbd ),_gaac ._gbb );if _bbd !=nil {return _bbd ;};_aagf .Stream ,_bbd =_gaac .encryptBytes (_aagf .Stream ,_efd ,_aff );if _bbd !=nil {return _bbd ;};_geef .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (int64 (len (_aagf .Stream ))));return nil ;case *PdfObjectString :_fg .Log .Trace ("\u0045n\u0063r\u0079\u0070\u0074\u0069\u006eg\u0020\u0073t\u0072\u0069\u006e\u0067\u0021");_abg :=_bfb ;if _gaac ._age .V >=4{_fg .Log .Trace ("\u0077\u0069\u0074\u0068\u0020\u0025\u0073\u0020\u0066i\u006c\u0074\u0065\u0072",_gaac ._fcb );if _gaac ._fcb =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{return nil ;};_abg =_gaac ._fcb ;};_cac ,_aeed :=_gaac .makeKey (_abg ,uint32 (parentObjNum ),uint32 (parentGenNum ),_gaac ._gbb );if _aeed !=nil {return _aeed ;};_cead :=_aagf .Str ();_bdde :=make ([]byte ,len (_cead ));for _ffbe :=0;_ffbe < len (_cead );_ffbe ++{_bdde [_ffbe ]=_cead [_ffbe ];};_fg .Log .Trace ("\u0045n\u0063\u0072\u0079\u0070\u0074\u0020\u0073\u0074\u0072\u0069\u006eg\u003a\u0020\u0025\u0073\u0020\u003a\u0020\u0025\u0020\u0078",_bdde ,_bdde );_bdde ,_aeed =_gaac .encryptBytes (_bdde ,_abg ,_cac );if _aeed !=nil {return _aeed ;};_aagf ._abgc =string (_bdde );return nil ;case *PdfObjectArray :for _ ,_fdc :=range _aagf .Elements (){_fffee :=_gaac .Encrypt (_fdc ,parentObjNum ,parentGenNum );if _fffee !=nil {return _fffee ;};};return nil ;case *PdfObjectDictionary :_bcgfg :=false ;if _cab :=_aagf .Get ("\u0054\u0079\u0070\u0065");_cab !=nil {_cgbe ,_dgaa :=_cab .(*PdfObjectName );if _dgaa &&*_cgbe =="\u0053\u0069\u0067"{_bcgfg =true ;};};for _ ,_cdd :=range _aagf .Keys (){_dfef :=_aagf .Get (_cdd );if _bcgfg &&string (_cdd )=="\u0043\u006f\u006e\u0074\u0065\u006e\u0074\u0073"{continue ;};if string (_cdd )!="\u0050\u0061\u0072\u0065\u006e\u0074"&&string (_cdd )!="\u0050\u0072\u0065\u0076"&&string (_cdd )!="\u004c\u0061\u0073\u0074"{_fbb :=_gaac .Encrypt (_dfef ,parentObjNum ,parentGenNum );if _fbb !=nil {return _fbb ;};};};return nil ;};return nil ;};func (_dedd *PdfParser )parseString ()(*PdfObjectString ,error ){_dedd ._daba .ReadByte ();var _aebg _gcd .Buffer ;_gbfc :=1;for {_dgbd ,_dbbb :=_dedd ._daba .Peek (1);if _dbbb !=nil {return MakeString (_aebg .String ()),_dbbb ;};if _dgbd [0]=='\\'{_dedd ._daba .ReadByte ();_bcgg ,_efcef :=_dedd ._daba .ReadByte ();if _efcef !=nil {return MakeString (_aebg .String ()),_efcef ;};if IsOctalDigit (_bcgg ){_beef ,_fcea :=_dedd ._daba .Peek (2);if _fcea !=nil {return MakeString (_aebg .String ()),_fcea ;};var _afafe []byte ;_afafe =append (_afafe ,_bcgg );for _ ,_gfed :=range _beef {if IsOctalDigit (_gfed ){_afafe =append (_afafe ,_gfed );}else {break ;};};_dedd ._daba .Discard (len (_afafe )-1);_fg .Log .Trace ("\u004e\u0075\u006d\u0065ri\u0063\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0020\u0022\u0025\u0073\u0022",_afafe );_eeaac ,_fcea :=_e .ParseUint (string (_afafe ),8,32);if _fcea !=nil {return MakeString (_aebg .String ()),_fcea ;};_aebg .WriteByte (byte (_eeaac ));continue ;};switch _bcgg {case 'n':_aebg .WriteRune ('\n');case 'r':_aebg .WriteRune ('\r');case 't':_aebg .WriteRune ('\t');case 'b':_aebg .WriteRune ('\b');case 'f':_aebg .WriteRune ('\f');case '(':_aebg .WriteRune ('(');case ')':_aebg .WriteRune (')');case '\\':_aebg .WriteRune ('\\');};continue ;}else if _dgbd [0]=='('{_gbfc ++;}else if _dgbd [0]==')'{_gbfc --;if _gbfc ==0{_dedd ._daba .ReadByte ();break ;};};_bbef ,_ :=_dedd ._daba .ReadByte ();_aebg .WriteByte (_bbef );};return MakeString (_aebg .String ()),nil ;};
+func (_gaac *PdfCrypt )Encrypt (obj PdfObject ,parentObjNum ,parentGenNum int64 )error {if _gaac .isEncrypted (obj ){return nil ;};switch _aagf :=obj .(type ){case *PdfIndirectObject :_gaac ._aaa [_aagf ]=true ;_fg .Log .Trace ("\u0045\u006e\u0063\u0072\u0079\u0070\u0074\u0069\u006e\u0067 \u0069\u006e\u0064\u0069\u0072\u0065\u0063t\u0020\u0025\u0064\u0020\u0025\u0064\u0020\u006f\u0062\u006a\u0021",_aagf .ObjectNumber ,_aagf .GenerationNumber );_agc :=_aagf .ObjectNumber ;_edfb :=_aagf .GenerationNumber ;_cdg :=_gaac .Encrypt (_aagf .PdfObject ,_agc ,_edfb );if _cdg !=nil {return _cdg ;};return nil ;case *PdfObjectStream :_gaac ._aaa [_aagf ]=true ;_geef :=_aagf .PdfObjectDictionary ;if _gda ,_bddgg :=_geef .Get ("\u0054\u0079\u0070\u0065").(*PdfObjectName );_bddgg &&*_gda =="\u0058\u0052\u0065\u0066"{return nil ;};_ecffe :=_aagf .ObjectNumber ;_bgbd :=_aagf .GenerationNumber ;_fg .Log .Trace ("\u0045n\u0063\u0072\u0079\u0070t\u0069\u006e\u0067\u0020\u0073t\u0072e\u0061m\u0020\u0025\u0064\u0020\u0025\u0064\u0020!",_ecffe ,_bgbd );_efd ,_bbd :=_gaac .streamFilter (_geef );if _bbd !=nil {return _bbd ;};_fg .Log .Trace ("with %s filter",_efd );if _efd ==identityFilter {return nil ;};_bbd =_gaac .Encrypt (_aagf .PdfObjectDictionary ,_ecffe ,_bgbd );if _bbd !=nil {return _bbd ;};_aff ,_bbd :=_gaac .makeKey (_efd ,uint32 (_ecffe ),uint32 (_bgbd ),_gaac ._gbb );if _bbd !=nil {return _bbd ;};_aagf .Stream ,_bbd =_gaac .encryptBytes (_aagf .Stream ,_efd ,_aff );if _bbd !=nil {return _bbd ;};_geef .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",MakeInteger (int64 (len (_aagf .Stream ))));return nil ;case *PdfObjectString :_fg .Log .Trace ("\u0045n\u0063r\u0079\u0070\u0074\u0069\u006eg\u0020\u0073t\u0072\u0069\u006e\u0067\u0021");_abg :=_bfb ;if _gaac ._age .V >=4{_fg .Log .Trace ("\u0077\u0069\u0074\u0068\u0020\u0025\u0073\u0020\u0066i\u006c\u0074\u0065\u0072",_gaac ._fcb );if _gaac ._fcb =="\u0049\u0064\u0065\u006e\u0074\u0069\u0074\u0079"{return nil ;};_abg =_gaac ._fcb ;};_cac ,_aeed :=_gaac .makeKey (_abg ,uint32 (parentObjNum ),uint32 (parentGenNum ),_gaac ._gbb );if _aeed !=nil {return _aeed ;};_cead :=_aagf .Str ();_bdde :=make ([]byte ,len (_cead ));for _ffbe :=0;_ffbe < len (_cead );_ffbe ++{_bdde [_ffbe ]=_cead [_ffbe ];};_fg .Log .Trace ("\u0045n\u0063\u0072\u0079\u0070\u0074\u0020\u0073\u0074\u0072\u0069\u006eg\u003a\u0020\u0025\u0073\u0020\u003a\u0020\u0025\u0020\u0078",_bdde ,_bdde );_bdde ,_aeed =_gaac .encryptBytes (_bdde ,_abg ,_cac );if _aeed !=nil {return _aeed ;};_aagf ._abgc =string (_bdde );return nil ;case *PdfObjectArray :for _ ,_fdc :=range _aagf .Elements (){_fffee :=_gaac .Encrypt (_fdc ,parentObjNum ,parentGenNum );if _fffee !=nil {return _fffee ;};};return nil ;case *PdfObjectDictionary :_bcgfg :=false ;if _cab :=_aagf .Get ("\u0054\u0079\u0070\u0065");_cab !=nil {_cgbe ,_dgaa :=_cab .(*PdfObjectName );if _dgaa &&*_cgbe =="\u0053\u0069\u0067"{_bcgfg =true ;};};for _ ,_cdd :=range _aagf .Keys (){_dfef :=_aagf .Get (_cdd );if _bcgfg &&string (_cdd )=="\u0043\u006f\u006e\u0074\u0065\u006e\u0074\u0073"{continue ;};if string (_cdd )!="\u0050\u0061\u0072\u0065\u006e\u0074"&&string (_cdd )!="\u0050\u0072\u0065\u0076"&&string (_cdd )!="\u004c\u0061\u0073\u0074"{_fbb :=_gaac .Encrypt (_dfef ,parentObjNum ,parentGenNum );if _fbb !=nil {return _fbb ;};};};return nil ;};return nil ;};func (_dedd *PdfParser )parseString ()(*PdfObjectString ,error ){_dedd ._daba .ReadByte ();var _aebg _gcd .Buffer ;_gbfc :=1;for {_dgbd ,_dbbb :=_dedd ._daba .Peek (1);if _dbbb !=nil {return MakeString (_aebg .String ()),_dbbb ;};if _dgbd [0]=='\\'{_dedd ._daba .ReadByte ();_bcgg ,_efcef :=_dedd ._daba .ReadByte ();if _efcef !=nil {return MakeString (_aebg .String ()),_efcef ;};if IsOctalDigit (_bcgg ){_beef ,_fcea :=_dedd ._daba .Peek (2);if _fcea !=nil {return MakeString (_aebg .String ()),_fcea ;};var _afafe []byte ;_afafe =append (_afafe ,_bcgg );for _ ,_gfed :=range _beef {if IsOctalDigit (_gfed ){_afafe =append (_afafe ,_gfed );}else {break ;};};_dedd ._daba .Discard (len (_afafe )-1);_fg .Log .Trace ("\u004e\u0075\u006d\u0065ri\u0063\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0020\u0022\u0025\u0073\u0022",_afafe );_eeaac ,_fcea :=_e .ParseUint (string (_afafe ),8,32);if _fcea !=nil {return MakeString (_aebg .String ()),_fcea ;};_aebg .WriteByte (byte (_eeaac ));continue ;};switch _bcgg {case 'n':_aebg .WriteRune ('\n');case 'r':_aebg .WriteRune ('\r');case 't':_aebg .WriteRune ('\t');case 'b':_aebg .WriteRune ('\b');case 'f':_aebg .WriteRune ('\f');case '(':_aebg .WriteRune ('(');case ')':_aebg .WriteRune (')');case '\\':_aebg .WriteRune ('\\');};continue ;}else if _dgbd [0]=='('{_gbfc ++;}else if _dgbd [0]==')'{_gbfc --;if _gbfc ==0{_dedd ._daba .ReadByte ();break ;};};_bbef ,_ :=_dedd ._daba .ReadByte ();_aebg .WriteByte (_bbef );};return MakeString (_aebg .String ()),nil ;};
 
 // MakeEncodedString creates a PdfObjectString with encoded content, which can be either
 // UTF-16BE or PDFDocEncoding depending on whether `utf16BE` is true or false respectively.
@@ -1041,8 +1039,6 @@ func FlattenObject (obj PdfObject )PdfObject {return _gdffe (obj ,0)};
 // PdfObjectString represents the primitive PDF string object.
 type PdfObjectString struct{_abgc string ;_eade bool ;};
 
-// MakeStreamDict makes a new instance of an encoding dictionary for a stream object.
-func (_eacc *JPXEncoder )MakeStreamDict ()*PdfObjectDictionary {return MakeDict ()};
 
 // MakeName creates a PdfObjectName from a string.
 func MakeName (s string )*PdfObjectName {_ffff :=PdfObjectName (s );return &_ffff };
@@ -0,0 +1,253 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core/security"
+	"github.com/unidoc/unipdf/v3/core/security/crypt"
+)
+
+// identityFilter is the name of the predefined crypt filter leaving the data unencrypted.
+const identityFilter = "Identity"
+
+// CryptFilterOpts defines which objects of an encrypted document are encrypted, with the crypt
+// filters of the streams (StmF), the strings (StrF) and the embedded files (EFF). The crypt
+// filters require V 4 or 5: a document encrypted with RC4 uses V 4 and the V2 crypt filter
+// method when the options are set.
+type CryptFilterOpts struct {
+	// IdentityStreams leaves the streams unencrypted: StmF is the Identity crypt filter.
+	IdentityStreams bool
+
+	// IdentityStrings leaves the strings unencrypted: StrF is the Identity crypt filter.
+	IdentityStrings bool
+
+	// IdentityEmbeddedFiles leaves the embedded file streams unencrypted: EFF is the Identity
+	// crypt filter.
+	IdentityEmbeddedFiles bool
+
+	// UnencryptedMetadata leaves the metadata streams unencrypted (EncryptMetadata false), so
+	// that the XMP metadata can be read, e.g. indexed, without decrypting the document.
+	UnencryptedMetadata bool
+
+	// EmbeddedFilesOnly only encrypts the embedded files ("encrypt attachments only"): the
+	// streams, the strings and the metadata are unencrypted, and the password is required to
+	// open an embedded file (AuthEvent EFOpen) rather than the document. The other options are
+	// ignored.
+	EmbeddedFilesOnly bool
+}
+
+// PdfCryptNewEncryptWithOpts makes the document crypt handler based on a specified crypt
+// filter, with the crypt filters of the streams, the strings and the embedded files defined by
+// `opts`. A nil `opts` is equivalent to PdfCryptNewEncrypt.
+func PdfCryptNewEncryptWithOpts(cf crypt.Filter, userPass, ownerPass []byte, perm security.Permissions, opts *CryptFilterOpts) (*PdfCrypt, *EncryptInfo, error) {
+	crypter := &PdfCrypt{
+		_aaa: make(map[PdfObject]bool),
+		_dfb: make(cryptFilters),
+		_cgc: security.StdEncryptDict{P: perm, EncryptMetadata: true},
+	}
+	var version Version
+	if cf != nil {
+		v := cf.PDFVersion()
+		version.Major, version.Minor = v[0], v[1]
+		V, R := cf.HandlerVersion()
+		crypter._age.V = V
+		crypter._cgc.R = R
+		crypter._age.Length = cf.KeyLength() * 8
+	}
+	crypter._dfb[_bfb] = cf
+	crypter.applyFilterOpts(_bfb, opts, &version)
+
+	id0, id1, err := newDocumentIDs()
+	if err != nil {
+		return nil, nil, err
+	}
+	crypter._dg = id0
+	if err := crypter.generateParams(userPass, ownerPass); err != nil {
+		return nil, nil, err
+	}
+	ed := crypter.newEncryptDict()
+	_eda(&crypter._cgc, ed)
+	if crypter._age.V >= 4 {
+		if err := crypter.saveCryptFilters(ed); err != nil {
+			return nil, nil, err
+		}
+		crypter.saveFilterOpts(ed, _bfb, opts)
+	}
+	return crypter, &EncryptInfo{Version: version, Encrypt: ed, ID0: id0, ID1: id1}, nil
+}
+
+// applyFilterOpts sets the crypt filters of the streams, the strings and the embedded files to
+// the filter `name` or to the Identity filter according to `opts`, and upgrades the handler to
+// V 4 when needed.
+func (crypter *PdfCrypt) applyFilterOpts(name string, opts *CryptFilterOpts, version *Version) {
+	if opts != nil && crypter._age.V < 4 {
+		crypter._age.V = 4
+		if crypter.pubKey == nil {
+			crypter._cgc.R = 4
+		}
+		if version.Major == 1 && version.Minor < 5 {
+			version.Minor = 5
+		}
+	}
+	if crypter._age.V < 4 {
+		return
+	}
+	crypter._gca, crypter._fcb = name, name
+	if opts == nil {
+		return
+	}
+	if opts.EmbeddedFilesOnly {
+		crypter._gca, crypter._fcb = identityFilter, identityFilter
+		crypter._age.EFF = name
+		crypter._cgc.EncryptMetadata = false
+	} else {
+		if opts.IdentityStreams {
+			crypter._gca = identityFilter
+		}
+		if opts.IdentityStrings {
+			crypter._fcb = identityFilter
+		}
+		if opts.IdentityEmbeddedFiles {
+			crypter._age.EFF = identityFilter
+		}
+		crypter._cgc.EncryptMetadata = !opts.UnencryptedMetadata
+	}
+	if crypter.pubKey != nil {
+		crypter.pubKey.EncryptMetadata = crypter._cgc.EncryptMetadata
+	}
+}
+
+// saveFilterOpts saves the entries of the encryption dictionary `ed` set by the options `opts`
+// of the crypt filter `name`.
+func (crypter *PdfCrypt) saveFilterOpts(ed *PdfObjectDictionary, name string, opts *CryptFilterOpts) {
+	if crypter._age.EFF != "" {
+		ed.Set("EFF", MakeName(crypter._age.EFF))
+	}
+	if crypter.pubKey == nil && !crypter._cgc.EncryptMetadata {
+		ed.Set("EncryptMetadata", MakeBool(false))
+	}
+	if opts != nil && opts.EmbeddedFilesOnly {
+		if cf, ok := GetDict(ed.Get("CF")); ok {
+			if fd, ok := GetDict(cf.Get(PdfObjectName(name))); ok {
+				fd.Set("AuthEvent", MakeName(string(security.EventEFOpen)))
+			}
+		}
+	}
+}
+
+// loadEmbeddedFileFilter loads the crypt filter of the embedded files (EFF) from the
+// encryption dictionary `ed`. The embedded files use the crypt filter of the streams if EFF is
+// not set.
+func (crypter *PdfCrypt) loadEmbeddedFileFilter(ed *PdfObjectDictionary) error {
+	crypter._age.EFF = ""
+	name, ok := GetName(ed.Get("EFF"))
+	if !ok {
+		return nil
+	}
+	if _, ok := crypter._dfb[string(*name)]; !ok {
+		return fmt.Errorf("crypt filter for EFF not specified in CF dictionary (%s)", *name)
+	}
+	crypter._age.EFF = string(*name)
+	return nil
+}
+
+// streamFilter returns the name of the crypt filter of the stream with the dictionary `dict`.
+// With V 4 and 5, it is the filter named in the DecodeParms of the Crypt filter of the stream,
+// the Identity filter if it is not named, or else the filter of the embedded files (EFF) for
+// the embedded file streams and the filter of the streams (StmF) for the other streams. The
+// metadata streams are not encrypted when EncryptMetadata is false.
+func (crypter *PdfCrypt) streamFilter(dict *PdfObjectDictionary) (string, error) {
+	if crypter._age.V < 4 {
+		return _bfb, nil
+	}
+	if params, ok := crypter.cryptFilterParams(dict); ok {
+		name := identityFilter
+		if params != nil {
+			if n, ok := crypter.direct(params.Get("Name")).(*PdfObjectName); ok {
+				name = string(*n)
+			}
+		}
+		if _, ok := crypter._dfb[name]; !ok && name != identityFilter {
+			return "", fmt.Errorf("crypt filter of stream not specified in CF dictionary (%s)", name)
+		}
+		common.Log.Trace("Using stream filter %s", name)
+		return name, nil
+	}
+	if t, ok := crypter.direct(dict.Get("Type")).(*PdfObjectName); ok {
+		switch *t {
+		case "EmbeddedFile":
+			if crypter._age.EFF != "" {
+				return crypter._age.EFF, nil
+			}
+		case "Metadata":
+			if !crypter._cgc.EncryptMetadata {
+				return identityFilter, nil
+			}
+		}
+	}
+	return crypter._gca, nil
+}
+
+// cryptFilterParams returns the decode parameters of the Crypt filter of the stream with the
+// dictionary `dict`, nil if the filter has no parameters. The Crypt filter is the first filter
+// of the stream, if any.
+func (crypter *PdfCrypt) cryptFilterParams(dict *PdfObjectDictionary) (*PdfObjectDictionary, bool) {
+	filter := crypter.direct(dict.Get("Filter"))
+	params := crypter.direct(dict.Get("DecodeParms"))
+	if arr, ok := filter.(*PdfObjectArray); ok {
+		if arr.Len() == 0 {
+			return nil, false
+		}
+		filter = crypter.direct(arr.Get(0))
+		if paramsArr, ok := params.(*PdfObjectArray); ok {
+			params = nil
+			if paramsArr.Len() > 0 {
+				params = crypter.direct(paramsArr.Get(0))
+			}
+		}
+	}
+	if name, ok := filter.(*PdfObjectName); !ok || *name != "Crypt" {
+		return nil, false
+	}
+	paramsDict, _ := params.(*PdfObjectDictionary)
+	return paramsDict, true
+}
+
+// direct returns the direct object of `obj`. The references are looked up without locking the
+// parser, which is locked while the objects it loads are decrypted.
+func (crypter *PdfCrypt) direct(obj PdfObject) PdfObject {
+	if crypter._afaf == nil {
+		return TraceToDirectObject(obj)
+	}
+	return crypter._afaf.traceDirect(obj)
+}
+
+// embeddedFilesOnly returns true if only the embedded files of the document are encrypted, the
+// streams and the strings using the Identity crypt filter.
+func (crypter *PdfCrypt) embeddedFilesOnly() bool {
+	return crypter._age.V >= 4 && crypter._gca == identityFilter && crypter._fcb == identityFilter &&
+		crypter._age.EFF != "" && crypter._age.EFF != identityFilter
+}
+
+// openWithoutKey gives access to a document encrypting only its embedded files when the
+// authentication failed. The embedded file streams are not decrypted without the file
+// encryption key. Returns true if the document can be read.
+func (crypter *PdfCrypt) openWithoutKey() bool {
+	if !crypter.embeddedFilesOnly() {
+		return false
+	}
+	common.Log.Debug("Embedded files encrypted: document opened without the file encryption key")
+	crypter._bddg = true
+	crypter._gbb = nil
+	return true
+}
@@ -0,0 +1,237 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core/security"
+	"github.com/unidoc/unipdf/v3/core/security/crypt"
+)
+
+const (
+	// pubKeyFilter is the Filter of the documents encrypted with the public-key security handler.
+	pubKeyFilter = "Adobe.PubSec"
+
+	// pubKeySubFilter is the SubFilter used to encrypt documents with the public-key security
+	// handler: the recipients are stored in the crypt filters.
+	pubKeySubFilter = "adbe.pkcs7.s5"
+
+	// pubKeyCryptFilter is the name of the crypt filter of the documents encrypted with the
+	// public-key security handler.
+	pubKeyCryptFilter = "DefaultCryptFilter"
+)
+
+// PdfCryptNewEncryptPubKey makes the document crypt handler of the public-key security handler
+// (Adobe.PubSec) based on a specified crypt filter. The document is encrypted for the
+// certificates of `recipients`, which are granted their respective permissions. The crypt
+// filters of the streams, the strings and the embedded files are defined by `opts`, if not nil.
+func PdfCryptNewEncryptPubKey(cf crypt.Filter, recipients []security.PubKeyRecipient, opts *CryptFilterOpts) (*PdfCrypt, *EncryptInfo, error) {
+	if cf == nil {
+		return nil, nil, errors.New("crypt filter not specified")
+	}
+	crypter := &PdfCrypt{
+		_aaa:   make(map[PdfObject]bool),
+		_dfb:   cryptFilters{pubKeyCryptFilter: cf},
+		_cgc:   security.StdEncryptDict{P: security.PermOwner, EncryptMetadata: true},
+		pubKey: &security.PubKeyEncryptDict{EncryptMetadata: true},
+	}
+	var version Version
+	v := cf.PDFVersion()
+	version.Major, version.Minor = v[0], v[1]
+	// The recipients are stored in a crypt filter, which requires V 4 at least.
+	crypter._age.V, _ = cf.HandlerVersion()
+	if crypter._age.V < 4 {
+		crypter._age.V = 4
+	}
+	if version.Major == 1 && version.Minor < 5 {
+		version.Minor = 5
+	}
+	crypter._age.Filter = pubKeyFilter
+	crypter._age.SubFilter = pubKeySubFilter
+	crypter._age.Length = cf.KeyLength() * 8
+	crypter.applyFilterOpts(pubKeyCryptFilter, opts, &version)
+
+	handler := security.NewPubKeyHandler(crypter._age.Length)
+	key, err := handler.GenerateParams(crypter.pubKey, recipients)
+	if err != nil {
+		return nil, nil, err
+	}
+	crypter._gbb = key
+	crypter._bddg = true
+
+	ed := MakeDict()
+	ed.Set("Filter", MakeName(pubKeyFilter))
+	ed.Set("SubFilter", MakeName(pubKeySubFilter))
+	ed.Set("V", MakeInteger(int64(crypter._age.V)))
+	ed.Set("Length", MakeInteger(int64(crypter._age.Length)))
+	if err := crypter.saveCryptFilters(ed); err != nil {
+		return nil, nil, err
+	}
+	filterDict, ok := GetDict(ed.Get("CF"))
+	if !ok {
+		return nil, nil, errors.New("invalid CF")
+	}
+	if fd, ok := GetDict(filterDict.Get(pubKeyCryptFilter)); ok {
+		fd.Set("Recipients", crypter.pubKeyRecipients())
+		fd.Set("EncryptMetadata", MakeBool(crypter.pubKey.EncryptMetadata))
+	}
+	crypter.saveFilterOpts(ed, pubKeyCryptFilter, opts)
+
+	id0, id1, err := newDocumentIDs()
+	if err != nil {
+		return nil, nil, err
+	}
+	crypter._dg = id0
+	return crypter, &EncryptInfo{Version: version, Encrypt: ed, ID0: id0, ID1: id1}, nil
+}
+
+// newDocumentIDs generates the two parts of the ID of an encrypted document.
+func newDocumentIDs() (string, string, error) {
+	var b [32]byte
+	if _, err := io.ReadFull(rand.Reader, b[:]); err != nil {
+		return "", "", err
+	}
+	id0 := md5.Sum(b[:16])
+	id1 := md5.Sum(b[16:])
+	return string(id0[:]), string(id1[:]), nil
+}
+
+// pubKeyRecipients returns the Recipients array of the public-key security handler.
+func (crypter *PdfCrypt) pubKeyRecipients() *PdfObjectArray {
+	arr := MakeArray()
+	for _, r := range crypter.pubKey.Recipients {
+		arr.Append(MakeHexString(string(r)))
+	}
+	return arr
+}
+
+// loadPubKeyDict loads the fields of the public-key security handler from the encryption
+// dictionary `ed`. The Recipients are stored in the encryption dictionary for the SubFilters
+// adbe.pkcs7.s3 and adbe.pkcs7.s4 (V 1 and 2), and in the crypt filter of the streams, of the
+// strings or of the embedded files for adbe.pkcs7.s5 (V 4 and 5).
+func (crypter *PdfCrypt) loadPubKeyDict(ed *PdfObjectDictionary) error {
+	if name, ok := GetName(ed.Get("SubFilter")); ok {
+		crypter._age.SubFilter = name.String()
+	}
+	common.Log.Debug("Public-key security handler, SubFilter %s", crypter._age.SubFilter)
+
+	dict := ed
+	if crypter._age.V >= 4 {
+		filterName := crypter._gca
+		if filterName == identityFilter {
+			filterName = crypter._fcb
+		}
+		if filterName == identityFilter && crypter._age.EFF != "" {
+			filterName = crypter._age.EFF
+		}
+		cfDict, err := crypter.resolveDict(ed.Get("CF"))
+		if err != nil {
+			return err
+		}
+		if dict, err = crypter.resolveDict(cfDict.Get(PdfObjectName(filterName))); err != nil {
+			return fmt.Errorf("invalid crypt filter %s: %v", filterName, err)
+		}
+		if cf := crypter._dfb[filterName]; cf != nil {
+			crypter._age.Length = cf.KeyLength() * 8
+		}
+	}
+
+	obj, err := crypter._afaf.Resolve(dict.Get("Recipients"))
+	if err != nil {
+		return err
+	}
+	if obj == nil {
+		return errors.New("required Recipients missing")
+	}
+	arr, ok := GetArray(obj)
+	if !ok {
+		// A single recipient may be stored as a string.
+		arr = MakeArray(obj)
+	}
+	d := &security.PubKeyEncryptDict{EncryptMetadata: true}
+	for _, elem := range arr.Elements() {
+		elem, err := crypter._afaf.Resolve(elem)
+		if err != nil {
+			return err
+		}
+		s, ok := GetString(elem)
+		if !ok {
+			return fmt.Errorf("invalid Recipients entry: %T", elem)
+		}
+		d.Recipients = append(d.Recipients, s.Bytes())
+	}
+	if len(d.Recipients) == 0 {
+		return errors.New("required Recipients missing")
+	}
+	if b, ok := GetBool(dict.Get("EncryptMetadata")); ok {
+		d.EncryptMetadata = bool(*b)
+	}
+	crypter.pubKey = d
+	crypter._cgc.EncryptMetadata = d.EncryptMetadata
+	return nil
+}
+
+// resolveDict returns the dictionary `obj`, looking it up if it is a reference.
+func (crypter *PdfCrypt) resolveDict(obj PdfObject) (*PdfObjectDictionary, error) {
+	obj, err := crypter._afaf.Resolve(obj)
+	if err != nil {
+		return nil, err
+	}
+	dict, ok := GetDict(obj)
+	if !ok {
+		return nil, fmt.Errorf("not a dictionary: %T", obj)
+	}
+	return dict, nil
+}
+
+// authenticatePubKey authenticates the owner of the certificate `cert` and its private key `key`
+// as a recipient of the document. The permissions of the recipient are set on success.
+func (crypter *PdfCrypt) authenticatePubKey(cert *x509.Certificate, key crypto.PrivateKey) (bool, error) {
+	if crypter.pubKey == nil {
+		return false, errors.New("document not encrypted with the public-key security handler")
+	}
+	crypter._bddg = false
+	handler := security.NewPubKeyHandler(crypter._age.Length)
+	fileKey, perms, err := handler.Authenticate(crypter.pubKey, cert, key)
+	if err != nil {
+		return false, err
+	} else if fileKey == nil {
+		return false, nil
+	}
+	crypter._bddg = true
+	crypter._gbb = fileKey
+	crypter._cgc.P = perms
+	return true, nil
+}
+
+// IsPubKeyEncrypted returns true if the document is encrypted with the public-key security
+// handler (Adobe.PubSec), i.e. it is decrypted with DecryptWithKey rather than a password.
+func (parser *PdfParser) IsPubKeyEncrypted() bool {
+	return parser._abd != nil && parser._abd.pubKey != nil
+}
+
+// DecryptWithKey decrypts the document encrypted with the public-key security handler
+// (Adobe.PubSec) with the certificate `cert` and its private key `key`, typically an
+// *rsa.PrivateKey. Returns true if `cert` is a recipient of the document, false otherwise. The
+// access permissions are the permissions granted to the recipient.
+func (parser *PdfParser) DecryptWithKey(cert *x509.Certificate, key crypto.PrivateKey) (bool, error) {
+	if parser._abd == nil {
+		return false, errors.New("check encryption first")
+	}
+	return parser._abd.authenticatePubKey(cert, key)
+}
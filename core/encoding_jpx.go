@@ -0,0 +1,196 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/internal/jpeg2000"
+)
+
+// JPXEncoder implements JPX encoder/decoder (JPEG 2000).
+// The decoded data contains the color components of the image, interleaved and packed with
+// BitsPerComponent bits per sample. The alpha channel of images with SMaskInData is not part of
+// the decoded data, use DecodeJPXImage to access it.
+type JPXEncoder struct {
+	ColorComponents  int
+	BitsPerComponent int
+	Width            int
+	Height           int
+
+	// Quality is the quality of the lossy compression (1-100), with a scale similar to the one
+	// of the DCT encoder. Not used if Lossless is set.
+	Quality int
+
+	// Lossless selects the reversible compression, which preserves the samples exactly.
+	Lossless bool
+}
+
+// JPXImageInfo contains the properties of a JPEG 2000 image.
+type JPXImageInfo struct {
+	Width            int
+	Height           int
+	ColorComponents  int
+	BitsPerComponent int
+
+	// HasAlpha indicates that the image has an alpha channel. Premultiplied indicates that the
+	// color components are premultiplied by the alpha channel.
+	HasAlpha      bool
+	Premultiplied bool
+
+	// ColorSpace is the name of the device color space of the image (DeviceGray, DeviceRGB or
+	// DeviceCMYK), or empty if the color space is not specified by the image.
+	ColorSpace PdfObjectName
+
+	// ICCProfile contains the embedded ICC profile of the image, if any.
+	ICCProfile []byte
+}
+
+// JPXImage represents a decoded JPEG 2000 image.
+type JPXImage struct {
+	JPXImageInfo
+
+	// Data contains the samples of the color components and Alpha the samples of the alpha
+	// channel, if any, with the same number of bits per sample.
+	Data  []byte
+	Alpha []byte
+}
+
+// NewJPXEncoder returns a new instance of JPXEncoder with default parameters.
+func NewJPXEncoder() *JPXEncoder {
+	return &JPXEncoder{
+		ColorComponents:  3,
+		BitsPerComponent: 8,
+		Quality:          DefaultJPEGQuality,
+	}
+}
+
+// newJPXEncoderFromStream creates a JPX encoder with the parameters of the image contained in
+// `streamObj`.
+func newJPXEncoderFromStream(streamObj *PdfObjectStream) *JPXEncoder {
+	encoder := NewJPXEncoder()
+	info, err := DecodeJPXInfo(streamObj.Stream)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to read JPX image header: %v", err)
+		return encoder
+	}
+	encoder.Width = info.Width
+	encoder.Height = info.Height
+	encoder.ColorComponents = info.ColorComponents
+	encoder.BitsPerComponent = info.BitsPerComponent
+	return encoder
+}
+
+// GetFilterName returns the name of the encoding filter.
+func (enc *JPXEncoder) GetFilterName() string { return StreamEncodingFilterNameJPX }
+
+// MakeDecodeParams makes a new instance of an encoding dictionary based on
+// the current encoder settings.
+func (enc *JPXEncoder) MakeDecodeParams() PdfObject { return nil }
+
+// MakeStreamDict makes a new instance of an encoding dictionary for a stream object.
+// Has the Filter set. Some other parameters are generated elsewhere.
+func (enc *JPXEncoder) MakeStreamDict() *PdfObjectDictionary {
+	dict := MakeDict()
+	dict.Set("Filter", MakeName(enc.GetFilterName()))
+	return dict
+}
+
+// UpdateParams updates the parameter values of the encoder.
+func (enc *JPXEncoder) UpdateParams(params *PdfObjectDictionary) {
+	if val, err := GetNumberAsInt64(params.Get("ColorComponents")); err == nil {
+		enc.ColorComponents = int(val)
+	}
+	if val, err := GetNumberAsInt64(params.Get("BitsPerComponent")); err == nil {
+		enc.BitsPerComponent = int(val)
+	}
+	if val, err := GetNumberAsInt64(params.Get("Width")); err == nil {
+		enc.Width = int(val)
+	}
+	if val, err := GetNumberAsInt64(params.Get("Height")); err == nil {
+		enc.Height = int(val)
+	}
+	if val, err := GetNumberAsInt64(params.Get("Quality")); err == nil {
+		enc.Quality = int(val)
+	}
+}
+
+// DecodeBytes decodes a slice of JPX encoded bytes and returns the samples of the color
+// components of the image.
+func (enc *JPXEncoder) DecodeBytes(encoded []byte) ([]byte, error) {
+	img, err := DecodeJPXImage(encoded)
+	if err != nil {
+		common.Log.Debug("ERROR: JPX decoding failed: %v", err)
+		return nil, err
+	}
+	return img.Data, nil
+}
+
+// DecodeStream decodes a JPX encoded stream and returns the result as a
+// slice of bytes.
+func (enc *JPXEncoder) DecodeStream(streamObj *PdfObjectStream) ([]byte, error) {
+	return enc.DecodeBytes(streamObj.Stream)
+}
+
+// EncodeBytes JPX encodes the passed in slice of bytes, which contains the interleaved samples
+// of the Width x Height image with ColorComponents (1, 3 or 4) components of BitsPerComponent
+// bits.
+func (enc *JPXEncoder) EncodeBytes(data []byte) ([]byte, error) {
+	img := &jpeg2000.Image{
+		Config: jpeg2000.Config{
+			Width:            enc.Width,
+			Height:           enc.Height,
+			ColorComponents:  enc.ColorComponents,
+			BitsPerComponent: enc.BitsPerComponent,
+		},
+		Data: data,
+	}
+	return jpeg2000.Encode(img, &jpeg2000.EncodeOptions{Quality: enc.Quality, Lossless: enc.Lossless})
+}
+
+// DecodeJPXInfo returns the properties of the JPEG 2000 image `encoded`, without decoding its
+// samples.
+func DecodeJPXInfo(encoded []byte) (*JPXImageInfo, error) {
+	cfg, err := jpeg2000.DecodeConfig(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return newJPXImageInfo(cfg), nil
+}
+
+// DecodeJPXImage decodes the JPEG 2000 image `encoded`, including its alpha channel.
+func DecodeJPXImage(encoded []byte) (*JPXImage, error) {
+	img, err := jpeg2000.Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return &JPXImage{JPXImageInfo: *newJPXImageInfo(img.Config), Data: img.Data, Alpha: img.Alpha}, nil
+}
+
+// newJPXImageInfo returns the properties of the image decoded with the configuration `cfg`.
+func newJPXImageInfo(cfg jpeg2000.Config) *JPXImageInfo {
+	info := &JPXImageInfo{
+		Width:            cfg.Width,
+		Height:           cfg.Height,
+		ColorComponents:  cfg.ColorComponents,
+		BitsPerComponent: cfg.BitsPerComponent,
+		HasAlpha:         cfg.HasAlpha,
+		Premultiplied:    cfg.Premultiplied,
+		ICCProfile:       cfg.ICCProfile,
+	}
+	switch cfg.ColorSpace {
+	case jpeg2000.ColorSpaceGray:
+		info.ColorSpace = "DeviceGray"
+	case jpeg2000.ColorSpaceRGB:
+		info.ColorSpace = "DeviceRGB"
+	case jpeg2000.ColorSpaceCMYK:
+		info.ColorSpace = "DeviceCMYK"
+	}
+	return info
+}
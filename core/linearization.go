@@ -0,0 +1,228 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Linearization represents the linearization parameter dictionary of a linearized file
+// (see Annex F "Linearized PDF" of PDF32000_2008).
+type Linearization struct {
+	// ObjectNumber and Offset identify the linearization parameter dictionary, which is the
+	// first object of the file.
+	ObjectNumber int64
+	Offset       int64
+
+	// FileLength is the length of the file in bytes (L).
+	FileLength int64
+
+	// HintOffset and HintLength locate the primary hint stream (H).
+	HintOffset int64
+	HintLength int64
+
+	// OverflowHintOffset and OverflowHintLength locate the overflow hint stream, if any.
+	OverflowHintOffset int64
+	OverflowHintLength int64
+
+	// FirstPageObject is the object number of the first page's page object (O).
+	FirstPageObject int64
+
+	// FirstPageEnd is the offset of the end of the first page section (E).
+	FirstPageEnd int64
+
+	// NumPages is the number of pages in the document (N).
+	NumPages int
+
+	// MainXrefOffset is the offset of the white-space character preceding the first entry of
+	// the main cross-reference table or the offset of the main cross-reference stream (T).
+	MainXrefOffset int64
+
+	// FirstPage is the page number of the first page section, 0 unless specified (P).
+	FirstPage int
+}
+
+var reLinearizationObj = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj`)
+
+// GetLinearization returns the linearization parameters of the file, or nil if the file is
+// not linearized. The linearization parameter dictionary must be the first object of the
+// file and be contained within its first 1024 bytes.
+func (parser *PdfParser) GetLinearization() (*Linearization, error) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	return parser.getLinearization()
+}
+
+// getLinearization returns the linearization parameters of the file like GetLinearization,
+// without locking the parser.
+func (parser *PdfParser) getLinearization() (*Linearization, error) {
+	offset := parser.GetFileOffset()
+	defer parser.SetFileOffset(offset)
+
+	n := parser._eecde
+	if n > 1024 {
+		n = 1024
+	}
+	head, err := parser.readBytesAt(0, n)
+	if err != nil {
+		return nil, err
+	}
+	loc := reLinearizationObj.FindIndex(head)
+	if loc == nil {
+		return nil, nil
+	}
+	parser.SetFileOffset(int64(loc[0]))
+	obj, err := parser.ParseIndirectObject()
+	if err != nil {
+		return nil, err
+	}
+	ind, ok := obj.(*PdfIndirectObject)
+	if !ok {
+		return nil, nil
+	}
+	dict, ok := GetDict(ind.PdfObject)
+	if !ok || dict.Get("Linearized") == nil {
+		return nil, nil
+	}
+
+	lin := &Linearization{ObjectNumber: ind.ObjectNumber, Offset: int64(loc[0])}
+	integer := func(key PdfObjectName) (int64, error) {
+		val, err := GetNumberAsInt64(dict.Get(key))
+		if err != nil {
+			return 0, fmt.Errorf("invalid linearization parameter %s", key)
+		}
+		return val, nil
+	}
+	if lin.FileLength, err = integer("L"); err != nil {
+		return nil, err
+	}
+	if lin.FirstPageObject, err = integer("O"); err != nil {
+		return nil, err
+	}
+	if lin.FirstPageEnd, err = integer("E"); err != nil {
+		return nil, err
+	}
+	if lin.MainXrefOffset, err = integer("T"); err != nil {
+		return nil, err
+	}
+	numPages, err := integer("N")
+	if err != nil {
+		return nil, err
+	}
+	lin.NumPages = int(numPages)
+	if firstPage, ok := GetIntVal(dict.Get("P")); ok {
+		lin.FirstPage = firstPage
+	}
+
+	hint, ok := GetArray(dict.Get("H"))
+	if !ok || (hint.Len() != 2 && hint.Len() != 4) {
+		return nil, errors.New("invalid linearization parameter H")
+	}
+	h, err := hint.ToInt64Slice()
+	if err != nil {
+		return nil, errors.New("invalid linearization parameter H")
+	}
+	lin.HintOffset, lin.HintLength = h[0], h[1]
+	if len(h) == 4 {
+		lin.OverflowHintOffset, lin.OverflowHintLength = h[2], h[3]
+	}
+	return lin, nil
+}
+
+// ValidateLinearization returns the linearization parameters of the file, or nil if the file
+// is not linearized, after checking that they match the layout of the file. An error is
+// returned when the parameters are invalid, e.g. when the file was incrementally updated
+// after it was linearized.
+func (parser *PdfParser) ValidateLinearization() (*Linearization, error) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+
+	lin, err := parser.getLinearization()
+	if err != nil || lin == nil {
+		return nil, err
+	}
+	offset := parser.GetFileOffset()
+	defer parser.SetFileOffset(offset)
+
+	if lin.FileLength != parser._eecde {
+		return lin, fmt.Errorf("file length %d does not match linearized length %d",
+			parser._eecde, lin.FileLength)
+	}
+	if lin.NumPages < 1 {
+		return lin, errors.New("linearized document without pages")
+	}
+	if lin.FirstPageEnd <= lin.Offset || lin.FirstPageEnd > lin.FileLength {
+		return lin, fmt.Errorf("first page end %d outside of file", lin.FirstPageEnd)
+	}
+	if lin.HintOffset <= lin.Offset || lin.HintLength <= 0 ||
+		lin.HintOffset+lin.HintLength > lin.FileLength {
+		return lin, errors.New("primary hint stream outside of file")
+	}
+	parser.SetFileOffset(lin.HintOffset)
+	obj, err := parser.ParseIndirectObject()
+	if err != nil {
+		return lin, fmt.Errorf("invalid primary hint stream: %v", err)
+	}
+	if _, ok := obj.(*PdfObjectStream); !ok {
+		return lin, errors.New("primary hint stream is not a stream object")
+	}
+
+	// The last startxref of the file refers to the first page cross-reference section, which
+	// follows the linearization parameter dictionary and points to the main section.
+	if parser._cfed <= lin.Offset || parser._cfed >= lin.FirstPageEnd {
+		return lin, errors.New("first page cross-reference section not found")
+	}
+	dict, err := parser.xrefSectionDict(parser._cfed)
+	if err != nil {
+		return lin, err
+	}
+	prev, ok := GetIntVal(dict.Get("Prev"))
+	if !ok || int64(prev) <= parser._cfed {
+		return lin, errors.New("first page cross-reference section does not point to the main section")
+	}
+	if err := parser.checkMainXref(lin.MainXrefOffset, int64(prev)); err != nil {
+		return lin, err
+	}
+	return lin, nil
+}
+
+// checkMainXref checks that the linearization parameter T, `mainXref`, refers to the main
+// cross-reference section located at `prev`.
+func (parser *PdfParser) checkMainXref(mainXref, prev int64) error {
+	if mainXref == prev {
+		dict, err := parser.xrefSectionDict(mainXref)
+		if err != nil {
+			return err
+		}
+		if name, ok := GetName(dict.Get("Type")); !ok || *name != "XRef" {
+			return errors.New("main cross-reference stream not found")
+		}
+		return nil
+	}
+	// For cross-reference tables, T is the offset of the end-of-line marker preceding the
+	// first entry, which follows the xref keyword and the first subsection header.
+	if mainXref < prev || mainXref-prev > 64 || mainXref+21 > parser._eecde {
+		return fmt.Errorf("invalid main cross-reference offset %d", mainXref)
+	}
+	data, err := parser.readBytesAt(prev, mainXref-prev+21)
+	if err != nil {
+		return err
+	}
+	header := bytes.Fields(data[:mainXref-prev])
+	entry := bytes.Fields(data[mainXref-prev+1:])
+	if len(header) != 3 || string(header[0]) != "xref" || !IsWhiteSpace(data[mainXref-prev]) ||
+		len(entry) < 3 || len(entry[0]) != 10 || len(entry[1]) != 5 {
+		return fmt.Errorf("invalid main cross-reference offset %d", mainXref)
+	}
+	return nil
+}
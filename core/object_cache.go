@@ -0,0 +1,294 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"container/list"
+
+	"github.com/unidoc/unipdf/v3/common"
+)
+
+// The state of a PdfParser, i.e. the position in the input, the cross-reference table and the
+// object caches, is guarded by its mutex, which is locked by the exported methods loading
+// objects (LookupByNumber, Resolve, ReadBytesAt, ...). Loading an object can look up other
+// objects, e.g. the Length of a stream or the DecodeParms of an object stream: the nested
+// lookups use the unexported methods below, which do not lock the parser.
+
+// lookupByReference looks up the object of `ref` like LookupByReference, without locking the
+// parser.
+func (parser *PdfParser) lookupByReference(ref PdfObjectReference) (PdfObject, error) {
+	obj, _, err := parser.lookupByNumberWrapper(int(ref.ObjectNumber), true)
+	return obj, err
+}
+
+// traceDirect returns the direct object of `obj` like TraceToDirectObject, looking up the
+// references without locking the parser.
+func (parser *PdfParser) traceDirect(obj PdfObject) PdfObject {
+	for depth := 0; depth <= _cffg; depth++ {
+		switch t := obj.(type) {
+		case *PdfObjectReference:
+			resolved, _, err := parser.lookupByNumberWrapper(int(t.ObjectNumber), true)
+			if err != nil {
+				common.Log.Debug("ERROR resolving reference: %v - returning null object", err)
+				return MakeNull()
+			}
+			obj = resolved
+		case *PdfIndirectObject:
+			obj = t.PdfObject
+		default:
+			return obj
+		}
+	}
+	common.Log.Debug("ERROR: Trace depth level beyond %d - not going deeper!", _cffg)
+	return nil
+}
+
+// directCopy returns a copy of the arrays and dictionaries of `obj` with the references
+// replaced by the direct objects, looked up without locking the parser. The copy is limited to
+// `depth` levels.
+func (parser *PdfParser) directCopy(obj PdfObject, depth int) PdfObject {
+	obj = parser.traceDirect(obj)
+	if depth <= 0 {
+		return obj
+	}
+	switch t := obj.(type) {
+	case *PdfObjectArray:
+		arr := MakeArray()
+		for _, elem := range t.Elements() {
+			arr.Append(parser.directCopy(elem, depth-1))
+		}
+		return arr
+	case *PdfObjectDictionary:
+		dict := MakeDict()
+		for _, key := range t.Keys() {
+			dict.Set(key, parser.directCopy(t.Get(key), depth-1))
+		}
+		return dict
+	}
+	return obj
+}
+
+// decodeStream decodes `stream` like DecodeStream. The references of its Filter and
+// DecodeParms entries are looked up without locking the parser, and the stream is unchanged.
+func (parser *PdfParser) decodeStream(stream *PdfObjectStream) ([]byte, error) {
+	dict := MakeDict()
+	for _, key := range stream.Keys() {
+		val := stream.Get(key)
+		if key == "Filter" || key == "DecodeParms" {
+			val = parser.directCopy(val, 3)
+		}
+		dict.Set(key, val)
+	}
+	return DecodeStream(&PdfObjectStream{PdfObjectDictionary: dict, Stream: stream.Stream})
+}
+
+// cacheBudget tracks the estimated memory used by the object cache and the decoded object
+// streams of a parser, in least recently used order, when the cache size is limited.
+type cacheBudget struct {
+	limit   int64
+	size    int64
+	order   *list.List
+	entries map[cacheKey]*list.Element
+}
+
+// cacheKey identifies an entry of the parser caches: an object of ObjCache, or a decoded
+// object stream.
+type cacheKey struct {
+	num    int
+	stream bool
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	size int64
+}
+
+// SetObjectCacheLimit limits the memory used by the objects cached by the parser to about
+// `limit` bytes. When the limit is exceeded, the least recently used objects and decoded
+// object streams are evicted from the cache and are loaded again from the input when needed.
+// A limit of 0 (the default) disables the eviction.
+//
+// An object loaded again after being evicted is a distinct instance: the limit is intended for
+// reading documents, e.g. extracting the contents of huge documents opened with
+// model.NewPdfReaderLazy, and not for documents whose objects are modified.
+func (parser *PdfParser) SetObjectCacheLimit(limit int64) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+
+	if limit <= 0 {
+		parser.cache = cacheBudget{}
+		return
+	}
+	if parser.cache.order == nil {
+		parser.cache.order = list.New()
+		parser.cache.entries = map[cacheKey]*list.Element{}
+		for num, obj := range parser.ObjCache {
+			parser.cache.add(cacheKey{num: num}, objectSize(obj))
+		}
+		for num, objStream := range parser._eaeg {
+			parser.cache.add(cacheKey{num: num, stream: true}, int64(len(objStream._eee)))
+		}
+	}
+	parser.cache.limit = limit
+	parser.evictObjects()
+}
+
+// ObjectCacheSize returns the estimated memory used by the objects cached by the parser, in
+// bytes. The size is only tracked when the cache is limited with SetObjectCacheLimit.
+func (parser *PdfParser) ObjectCacheSize() int64 {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	return parser.cache.size
+}
+
+// cachedObject returns the object `num` from the object cache.
+func (parser *PdfParser) cachedObject(num int) (PdfObject, bool) {
+	obj, ok := parser.ObjCache[num]
+	if ok {
+		parser.cache.touch(cacheKey{num: num})
+	}
+	return obj, ok
+}
+
+// cacheObject adds `obj` to the object cache as object `num`.
+func (parser *PdfParser) cacheObject(num int, obj PdfObject) {
+	parser.ObjCache[num] = obj
+	if parser.cache.order != nil {
+		parser.cache.add(cacheKey{num: num}, objectSize(obj))
+		parser.evictObjects()
+	}
+}
+
+// cachedObjectStream returns the decoded object stream `num`.
+func (parser *PdfParser) cachedObjectStream(num int) (objectStream, bool) {
+	objStream, ok := parser._eaeg[num]
+	if ok {
+		parser.cache.touch(cacheKey{num: num, stream: true})
+	}
+	return objStream, ok
+}
+
+// cacheObjectStream adds the decoded object stream `num` to the parser caches.
+func (parser *PdfParser) cacheObjectStream(num int, objStream objectStream) {
+	parser._eaeg[num] = objStream
+	if parser.cache.order != nil {
+		parser.cache.add(cacheKey{num: num, stream: true}, int64(len(objStream._eee)))
+		parser.evictObjects()
+	}
+}
+
+// resetObjectCache empties the object cache and the decoded object streams, e.g. after the
+// cross-reference table is rebuilt.
+func (parser *PdfParser) resetObjectCache() {
+	parser.ObjCache = make(objectCache)
+	parser._eaeg = make(objectStreams)
+	if parser.cache.order != nil {
+		parser.cache = cacheBudget{
+			limit:   parser.cache.limit,
+			order:   list.New(),
+			entries: map[cacheKey]*list.Element{},
+		}
+	}
+}
+
+// evictObjects removes the least recently used entries from the parser caches until their
+// size is within the limit. The most recent entry is kept, even if it exceeds the limit alone.
+func (parser *PdfParser) evictObjects() {
+	budget := &parser.cache
+	for budget.size > budget.limit && budget.order.Len() > 1 {
+		elem := budget.order.Back()
+		key := elem.Value.(*cacheEntry).key
+		budget.remove(elem)
+		if key.stream {
+			delete(parser._eaeg, key.num)
+			continue
+		}
+		if obj, ok := parser.ObjCache[key.num]; ok {
+			delete(parser.ObjCache, key.num)
+			if parser._abd != nil {
+				// The object is decrypted again when loaded again.
+				delete(parser._abd._cc, obj)
+			}
+		}
+	}
+}
+
+func (b *cacheBudget) add(key cacheKey, size int64) {
+	if elem, ok := b.entries[key]; ok {
+		b.remove(elem)
+	}
+	b.entries[key] = b.order.PushFront(&cacheEntry{key: key, size: size})
+	b.size += size
+}
+
+func (b *cacheBudget) touch(key cacheKey) {
+	if b.order == nil {
+		return
+	}
+	if elem, ok := b.entries[key]; ok {
+		b.order.MoveToFront(elem)
+	}
+}
+
+func (b *cacheBudget) remove(elem *list.Element) {
+	entry := b.order.Remove(elem).(*cacheEntry)
+	delete(b.entries, entry.key)
+	b.size -= entry.size
+}
+
+// objectSize returns an estimate of the memory used by `obj`. The objects referenced by `obj`
+// are not included.
+func objectSize(obj PdfObject) int64 {
+	const overhead = 16
+	switch t := obj.(type) {
+	case *PdfIndirectObject:
+		return overhead + directObjectSize(t.PdfObject)
+	case *PdfObjectStream:
+		return overhead + int64(len(t.Stream)) + directObjectSize(t.PdfObjectDictionary)
+	}
+	return directObjectSize(obj)
+}
+
+// directObjectSize returns an estimate of the memory used by the direct object `obj`, indirect
+// objects contained in `obj` being counted as pointers.
+func directObjectSize(obj PdfObject) int64 {
+	const overhead = 16
+	switch t := obj.(type) {
+	case *PdfObjectDictionary:
+		if t == nil {
+			return overhead
+		}
+		size := int64(overhead)
+		for _, key := range t.Keys() {
+			size += overhead + int64(len(key)) + directObjectSize(t.Get(key))
+		}
+		return size
+	case *PdfObjectArray:
+		if t == nil {
+			return overhead
+		}
+		size := int64(overhead)
+		for _, elem := range t.Elements() {
+			size += directObjectSize(elem)
+		}
+		return size
+	case *PdfObjectString:
+		if t == nil {
+			return overhead
+		}
+		return overhead + int64(len(t.Str()))
+	case *PdfObjectName:
+		if t == nil {
+			return overhead
+		}
+		return overhead + int64(len(*t))
+	}
+	return overhead
+}
@@ -0,0 +1,131 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ReadCacheOpts defines the options of a ReadCache.
+type ReadCacheOpts struct {
+	// PageSize is the size of the pages read from the underlying reader, 64 KiB if not positive.
+	PageSize int
+
+	// MaxPages is the maximum number of pages kept in memory, 64 if not positive.
+	MaxPages int
+}
+
+// ReadCache is an io.ReaderAt reading a file by pages of fixed size from an underlying
+// io.ReaderAt, the range fetcher, and keeping the most recently used pages in memory. It
+// reduces the number of reads of the fetcher when the file is accessed through a slow or ranged
+// interface, e.g. an object storage, as the parser reads the file in many small parts.
+//
+// The fetcher is typically an implementation of io.ReaderAt issuing ranged requests. Its reads
+// are aligned to the page size, and the last page of the file is shorter. ReadCache is safe for
+// concurrent use.
+type ReadCache struct {
+	r        io.ReaderAt
+	size     int64
+	pageSize int64
+	maxPages int
+
+	mu    sync.Mutex
+	pages map[int64]*list.Element
+	order *list.List
+}
+
+type readCachePage struct {
+	index int64
+	data  []byte
+}
+
+// NewReadCache returns a ReadCache reading the file of `size` bytes from `r`, with the options
+// `opts` (the default options if nil).
+func NewReadCache(r io.ReaderAt, size int64, opts *ReadCacheOpts) *ReadCache {
+	if opts == nil {
+		opts = &ReadCacheOpts{}
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 64 * 1024
+	}
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 64
+	}
+	return &ReadCache{
+		r:        r,
+		size:     size,
+		pageSize: int64(pageSize),
+		maxPages: maxPages,
+		pages:    map[int64]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Size returns the size of the file.
+func (c *ReadCache) Size() int64 {
+	return c.size
+}
+
+// ReadAt reads len(p) bytes at offset `off` of the file into `p`, as specified by io.ReaderAt.
+func (c *ReadCache) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= c.size {
+			return n, io.EOF
+		}
+		data, err := c.page(pos / c.pageSize)
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:], data[pos%c.pageSize:])
+	}
+	return n, nil
+}
+
+// page returns the data of the page `index`, reading it from the fetcher if not cached.
+func (c *ReadCache) page(index int64) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.pages[index]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*readCachePage).data, nil
+	}
+
+	offset := index * c.pageSize
+	length := c.pageSize
+	if offset+length > c.size {
+		length = c.size - offset
+	}
+	data := make([]byte, length)
+	n, err := c.r.ReadAt(data, offset)
+	if n < len(data) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	c.pages[index] = c.order.PushFront(&readCachePage{index: index, data: data})
+	for c.order.Len() > c.maxPages {
+		oldest := c.order.Remove(c.order.Back()).(*readCachePage)
+		delete(c.pages, oldest.index)
+	}
+	return data, nil
+}
@@ -0,0 +1,644 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/common"
+)
+
+// ParserOpts defines the options of the PdfParser.
+type ParserOpts struct {
+	// Repair enables the repair mode, in which damaged files are loaded by rebuilding the
+	// cross-reference table from the object headers found in the file, recovering objects
+	// missing from the table and correcting wrong stream lengths. The repairs performed are
+	// reported by PdfParser.RepairReport.
+	Repair bool
+}
+
+// RepairReport describes the repairs performed by a PdfParser in repair mode.
+type RepairReport struct {
+	// XrefRebuilt indicates that the cross-reference table could not be loaded and was rebuilt
+	// by scanning the file for object headers. XrefError is the error which caused it.
+	XrefRebuilt bool
+	XrefError   error
+
+	// TrailerRebuilt indicates that the trailer dictionary was rebuilt from the trailer and
+	// cross-reference stream dictionaries found in the file.
+	TrailerRebuilt bool
+
+	// CatalogRecovered indicates that the Root entry of the trailer was missing or invalid
+	// and was replaced by the catalog found in the file.
+	CatalogRecovered bool
+
+	// RelocatedObjects contains the numbers of the objects whose cross-reference entries had
+	// wrong offsets, RecoveredObjects the numbers of the objects found in the file which were
+	// missing from the cross-reference table.
+	RelocatedObjects []int
+	RecoveredObjects []int
+
+	// StreamLengths contains the streams whose Length entry was wrong.
+	StreamLengths []StreamLengthRepair
+}
+
+// StreamLengthRepair describes a stream whose Length entry was corrected by locating the
+// endstream keyword.
+type StreamLengthRepair struct {
+	ObjectNumber     int64
+	GenerationNumber int64
+
+	// Length is the value of the Length entry, or -1 if it was missing or invalid. ActualLength
+	// is the length of the stream data.
+	Length       int64
+	ActualLength int64
+}
+
+// Repaired returns true if any repair was performed.
+func (r *RepairReport) Repaired() bool {
+	return r.XrefRebuilt || r.TrailerRebuilt || r.CatalogRecovered || len(r.RelocatedObjects) > 0 ||
+		len(r.RecoveredObjects) > 0 || len(r.StreamLengths) > 0
+}
+
+// String returns a description of the repairs, one per line.
+func (r *RepairReport) String() string {
+	if !r.Repaired() {
+		return "no repairs"
+	}
+	var lines []string
+	if r.XrefRebuilt {
+		lines = append(lines, fmt.Sprintf("cross-reference table rebuilt: %v", r.XrefError))
+	}
+	if r.TrailerRebuilt {
+		lines = append(lines, "trailer rebuilt")
+	}
+	if r.CatalogRecovered {
+		lines = append(lines, "document catalog recovered")
+	}
+	if len(r.RelocatedObjects) > 0 {
+		lines = append(lines, fmt.Sprintf("objects relocated: %v", r.RelocatedObjects))
+	}
+	if len(r.RecoveredObjects) > 0 {
+		lines = append(lines, fmt.Sprintf("objects recovered: %v", r.RecoveredObjects))
+	}
+	for _, s := range r.StreamLengths {
+		lines = append(lines, fmt.Sprintf("stream length of object %d %d corrected from %d to %d",
+			s.ObjectNumber, s.GenerationNumber, s.Length, s.ActualLength))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NewParserWithOpts creates a new parser for a PDF file via ReadSeeker `rs` with the options
+// `opts`. A nil `opts` is equivalent to NewParser.
+func NewParserWithOpts(rs io.ReadSeeker, opts *ParserOpts) (*PdfParser, error) {
+	if opts == nil || !opts.Repair {
+		return NewParser(rs)
+	}
+	parser := &PdfParser{
+		_cdfe:        rs,
+		ObjCache:     make(objectCache),
+		_bcaa:        map[int64]bool{},
+		repairReport: &RepairReport{},
+	}
+	major, minor, err := parser.parsePdfVersion()
+	if err != nil {
+		common.Log.Debug("ERROR: unable to parse version, assuming 1.7: %v", err)
+		major, minor = 1, 7
+		parser.SetFileOffset(0)
+	}
+	parser._adagd.Major = major
+	parser._adagd.Minor = minor
+	if err = parser.repair(); err != nil {
+		common.Log.Debug("ERROR: unable to repair file: %v", err)
+		return nil, err
+	}
+	return parser, nil
+}
+
+// RepairReport returns the repairs performed by the parser, or nil if the parser was not
+// created in repair mode.
+func (parser *PdfParser) RepairReport() *RepairReport {
+	return parser.repairReport
+}
+
+var (
+	repairObjHeaderRegexp  = regexp.MustCompile(`(\d{1,10})[\x00\t\n\f\r ]+(\d{1,5})[\x00\t\n\f\r ]+obj`)
+	repairObjAtRegexp      = regexp.MustCompile(`^[\x00\t\n\f\r ]*(\d{1,10})[\x00\t\n\f\r ]+(\d{1,5})[\x00\t\n\f\r ]+obj`)
+	repairStreamRegexp     = regexp.MustCompile(`>>[\x00\t\n\f\r ]*stream[\r\n]`)
+	repairTypeRegexp       = regexp.MustCompile(`/Type[\x00\t\n\f\r ]*/(Catalog|ObjStm|XRef)[\x00\t\n\f\r /<>\[\]]`)
+	repairTrailerRegexp    = regexp.MustCompile(`trailer[\x00\t\n\f\r ]*<<`)
+	repairEndstreamKeyword = []byte("endstream")
+)
+
+// scannedObject is an object header found by scanning a file.
+type scannedObject struct {
+	number     int
+	generation int
+	offset     int64
+
+	// typ is the Type of the object if it is a Catalog, ObjStm or XRef dictionary.
+	typ string
+}
+
+// objectScan contains the object headers and trailers found by scanning a file.
+type objectScan struct {
+	// objects contains the headers in file order, latest the last definition of each object.
+	objects []scannedObject
+	latest  map[int]scannedObject
+	offsets map[int64]scannedObject
+
+	// trailers contains the offsets of the trailer dictionaries.
+	trailers []int64
+}
+
+// isRegular returns true if `b` is a regular character, i.e. neither white-space nor delimiter.
+func isRegular(b byte) bool {
+	return !IsWhiteSpace(b) && !IsDelimiter(b)
+}
+
+// scanObjects scans `data` for object headers and trailer dictionaries, skipping the data of
+// streams.
+func scanObjects(data []byte) *objectScan {
+	scan := &objectScan{latest: map[int]scannedObject{}, offsets: map[int64]scannedObject{}}
+	type span struct{ start, end int }
+	var streams []span
+	pos := 0
+	loc := repairObjHeaderRegexp.FindSubmatchIndex(data)
+	for loc != nil {
+		start, end := pos+loc[0], pos+loc[1]
+		next := end
+		if (start > 0 && isRegular(data[start-1])) || (end < len(data) && isRegular(data[end])) {
+			pos = start + 1
+			loc = repairObjHeaderRegexp.FindSubmatchIndex(data[pos:])
+			continue
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		gen, _ := strconv.Atoi(string(data[pos+loc[4] : pos+loc[5]]))
+
+		// The object ends at the first endobj or at the next header, whichever comes first.
+		limit := len(data)
+		if i := bytes.Index(data[end:], []byte("endobj")); i >= 0 {
+			limit = end + i
+		}
+		nextLoc := repairObjHeaderRegexp.FindSubmatchIndex(data[end:limit])
+		if nextLoc != nil {
+			limit = end + nextLoc[0]
+		}
+		body := data[end:limit]
+		if s := repairStreamRegexp.FindIndex(body); s != nil {
+			body = body[:s[1]]
+			dataStart := end + s[1]
+			if i := bytes.Index(data[dataStart:], repairEndstreamKeyword); i >= 0 {
+				next = dataStart + i + len(repairEndstreamKeyword)
+			} else {
+				next = len(data)
+			}
+			streams = append(streams, span{dataStart, next})
+		}
+		obj := scannedObject{number: num, generation: gen, offset: int64(start)}
+		if m := repairTypeRegexp.FindSubmatch(body); m != nil {
+			obj.typ = string(m[1])
+		}
+		scan.objects = append(scan.objects, obj)
+		scan.offsets[obj.offset] = obj
+		if prev, ok := scan.latest[num]; !ok || gen >= prev.generation {
+			scan.latest[num] = obj
+		}
+
+		pos = next
+		loc = repairObjHeaderRegexp.FindSubmatchIndex(data[pos:])
+	}
+
+	for _, loc := range repairTrailerRegexp.FindAllIndex(data, -1) {
+		inStream := false
+		for _, s := range streams {
+			if loc[0] >= s.start && loc[0] < s.end {
+				inStream = true
+				break
+			}
+		}
+		if !inStream {
+			scan.trailers = append(scan.trailers, int64(loc[0]))
+		}
+	}
+	return scan
+}
+
+// objectHeaderAt returns the object number and generation of the object header at `offset`.
+func objectHeaderAt(data []byte, offset int64) (int, int, bool) {
+	if offset < 0 || offset >= int64(len(data)) {
+		return 0, 0, false
+	}
+	end := offset + 32
+	if end > int64(len(data)) {
+		end = int64(len(data))
+	}
+	m := repairObjAtRegexp.FindSubmatch(data[offset:end])
+	if m == nil {
+		return 0, 0, false
+	}
+	num, _ := strconv.Atoi(string(m[1]))
+	gen, _ := strconv.Atoi(string(m[2]))
+	return num, gen, true
+}
+
+// repair loads the cross-reference table and the trailer of the file, repairing them with
+// the objects found by scanning the file.
+func (parser *PdfParser) repair() error {
+	report := parser.repairReport
+	size, err := parser._cdfe.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err = parser._cdfe.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(parser._cdfe)
+	if err != nil {
+		return err
+	}
+	scan := scanObjects(data)
+
+	trailer, err := parser.loadXrefs()
+	parser._eecde = size
+	// Prevent the parser from replacing the repaired table when looking up objects.
+	parser._gacd = true
+	if err != nil {
+		common.Log.Debug("ERROR: unable to load xrefs, rebuilding: %v", err)
+		if len(scan.latest) == 0 {
+			return errors.New("unable to repair: no objects found")
+		}
+		report.XrefRebuilt = true
+		report.XrefError = err
+		report.TrailerRebuilt = true
+		objectMap := make(map[int]XrefObject, len(scan.latest))
+		for num, obj := range scan.latest {
+			objectMap[num] = XrefObject{
+				XType:        XrefTypeTableEntry,
+				ObjectNumber: num,
+				Generation:   obj.generation,
+				Offset:       obj.offset,
+			}
+		}
+		parser.installXrefTable(objectMap)
+		xtype := XrefTypeTableEntry
+		trailer = parser.rebuildTrailer(scan)
+		for _, obj := range scan.objects {
+			if obj.typ == "XRef" {
+				xtype = XrefTypeObjectStream
+				break
+			}
+		}
+		parser._fagf = &xtype
+	} else {
+		objectMap := parser._cgbgg.ObjectMap
+		for num, entry := range objectMap {
+			if entry.XType != XrefTypeTableEntry {
+				continue
+			}
+			if n, _, ok := objectHeaderAt(data, entry.Offset); ok && n == num {
+				continue
+			}
+			obj, ok := scan.latest[num]
+			if !ok {
+				continue
+			}
+			entry.Offset = obj.offset
+			entry.Generation = obj.generation
+			objectMap[num] = entry
+			report.RelocatedObjects = append(report.RelocatedObjects, num)
+		}
+		for num, obj := range scan.latest {
+			if _, ok := objectMap[num]; ok || obj.typ == "XRef" {
+				continue
+			}
+			objectMap[num] = XrefObject{
+				XType:        XrefTypeTableEntry,
+				ObjectNumber: num,
+				Generation:   obj.generation,
+				Offset:       obj.offset,
+			}
+			report.RecoveredObjects = append(report.RecoveredObjects, num)
+		}
+		parser.installXrefTable(objectMap)
+	}
+	if trailer == nil {
+		trailer = MakeDict()
+		report.TrailerRebuilt = true
+	}
+	parser._eaeb = trailer
+
+	// Positions of the object definitions, compressed objects are at the position of their
+	// object stream.
+	positions := map[int]int64{}
+	for num, entry := range parser._cgbgg.ObjectMap {
+		if entry.XType == XrefTypeTableEntry {
+			positions[num] = entry.Offset
+		}
+	}
+	for num, entry := range parser._cgbgg.ObjectMap {
+		if entry.XType == XrefTypeObjectStream {
+			positions[num] = positions[entry.OsObjNumber]
+		}
+	}
+	if trailer.Get("Encrypt") == nil {
+		parser.recoverCompressedObjects(scan, positions)
+	} else {
+		common.Log.Debug("Encrypted file: compressed objects not recovered")
+	}
+
+	if !parser.isCatalog(trailer.Get("Root")) {
+		root := parser.findCatalog(scan, positions)
+		if root == nil {
+			return errors.New("unable to repair: document catalog not found")
+		}
+		trailer.Set("Root", root)
+		report.CatalogRecovered = true
+	}
+	maxNum := 0
+	for num := range parser._cgbgg.ObjectMap {
+		if num > maxNum {
+			maxNum = num
+		}
+	}
+	if sz, ok := GetIntVal(trailer.Get("Size")); !ok || sz <= maxNum {
+		trailer.Set("Size", MakeInteger(int64(maxNum+1)))
+	}
+
+	sort.Ints(report.RelocatedObjects)
+	sort.Ints(report.RecoveredObjects)
+	parser.resetObjectCache()
+	parser.SetFileOffset(0)
+	return nil
+}
+
+// installXrefTable replaces the cross-reference table of the parser by `objectMap`.
+func (parser *PdfParser) installXrefTable(objectMap map[int]XrefObject) {
+	parser._cgbgg = XrefTable{ObjectMap: objectMap}
+	parser.resetObjectCache()
+}
+
+// rebuildTrailer merges the trailer and cross-reference stream dictionaries found in the file
+// into a new trailer, the later dictionaries taking precedence.
+func (parser *PdfParser) rebuildTrailer(scan *objectScan) *PdfObjectDictionary {
+	type source struct {
+		offset int64
+		xref   bool
+	}
+	var sources []source
+	for _, offset := range scan.trailers {
+		sources = append(sources, source{offset: offset})
+	}
+	for _, obj := range scan.objects {
+		if obj.typ == "XRef" {
+			sources = append(sources, source{offset: obj.offset, xref: true})
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].offset < sources[j].offset })
+
+	trailer := MakeDict()
+	for _, src := range sources {
+		var dict *PdfObjectDictionary
+		if src.xref {
+			parser.SetFileOffset(src.offset)
+			obj, err := parser.ParseIndirectObject()
+			if err != nil {
+				common.Log.Debug("ERROR: unable to parse xref stream at %d: %v", src.offset, err)
+				continue
+			}
+			if stream, ok := obj.(*PdfObjectStream); ok {
+				dict = stream.PdfObjectDictionary
+			}
+		} else {
+			parser.SetFileOffset(src.offset + int64(len("trailer")))
+			parser.skipSpaces()
+			d, err := parser.ParseDict()
+			if err != nil {
+				common.Log.Debug("ERROR: unable to parse trailer at %d: %v", src.offset, err)
+				continue
+			}
+			dict = d
+		}
+		if dict == nil {
+			continue
+		}
+		for _, key := range []PdfObjectName{"Root", "Info", "ID", "Encrypt"} {
+			if val := dict.Get(key); val != nil {
+				trailer.Set(key, val)
+			}
+		}
+	}
+	return trailer
+}
+
+// recoverCompressedObjects adds the objects contained in the object streams of the file to
+// the cross-reference table. When the table was rebuilt, the latest definition of each object
+// is used, otherwise only the objects missing from the table are added.
+func (parser *PdfParser) recoverCompressedObjects(scan *objectScan, positions map[int]int64) {
+	report := parser.repairReport
+	objectMap := parser._cgbgg.ObjectMap
+	for _, obj := range scan.objects {
+		if obj.typ != "ObjStm" {
+			continue
+		}
+		if entry, ok := objectMap[obj.number]; !ok || entry.XType != XrefTypeTableEntry || entry.Offset != obj.offset {
+			continue
+		}
+		parser.SetFileOffset(obj.offset)
+		parsed, err := parser.ParseIndirectObject()
+		if err != nil {
+			common.Log.Debug("ERROR: unable to parse object stream %d: %v", obj.number, err)
+			continue
+		}
+		stream, ok := parsed.(*PdfObjectStream)
+		if !ok {
+			continue
+		}
+		n, ok1 := GetIntVal(stream.Get("N"))
+		first, ok2 := GetIntVal(stream.Get("First"))
+		if !ok1 || !ok2 {
+			continue
+		}
+		decoded, err := DecodeStream(stream)
+		if err != nil || first < 0 || first > len(decoded) {
+			common.Log.Debug("ERROR: unable to decode object stream %d: %v", obj.number, err)
+			continue
+		}
+		fields := strings.Fields(string(decoded[:first]))
+		for i := 0; i < n && 2*i+1 < len(fields); i++ {
+			num, err := strconv.Atoi(fields[2*i])
+			if err != nil || num == obj.number {
+				continue
+			}
+			entry, exists := objectMap[num]
+			if exists && (!parser.repairReport.XrefRebuilt || positions[num] > obj.offset) {
+				continue
+			}
+			if exists && entry.XType == XrefTypeObjectStream && entry.OsObjNumber == obj.number {
+				continue
+			}
+			objectMap[num] = XrefObject{
+				XType:        XrefTypeObjectStream,
+				ObjectNumber: num,
+				OsObjNumber:  obj.number,
+				OsObjIndex:   i,
+			}
+			positions[num] = obj.offset
+			if !exists && !report.XrefRebuilt {
+				report.RecoveredObjects = append(report.RecoveredObjects, num)
+			}
+		}
+	}
+	parser.installXrefTable(objectMap)
+}
+
+// isCatalog returns true if `obj` is a reference to the document catalog.
+func (parser *PdfParser) isCatalog(obj PdfObject) bool {
+	ref, ok := obj.(*PdfObjectReference)
+	if !ok {
+		return false
+	}
+	resolved, err := parser.LookupByReference(*ref)
+	if err != nil {
+		return false
+	}
+	if ind, ok := resolved.(*PdfIndirectObject); ok {
+		resolved = ind.PdfObject
+	}
+	dict, ok := resolved.(*PdfObjectDictionary)
+	if !ok {
+		return false
+	}
+	name, ok := GetName(dict.Get("Type"))
+	return ok && *name == "Catalog" && dict.Get("Pages") != nil
+}
+
+// findCatalog returns a reference to the latest document catalog defined in the file.
+func (parser *PdfParser) findCatalog(scan *objectScan, positions map[int]int64) *PdfObjectReference {
+	var candidates []XrefObject
+	for _, entry := range parser._cgbgg.ObjectMap {
+		if entry.XType == XrefTypeTableEntry && scan.offsets[entry.Offset].typ != "Catalog" {
+			continue
+		}
+		candidates = append(candidates, entry)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		pi, pj := positions[candidates[i].ObjectNumber], positions[candidates[j].ObjectNumber]
+		if pi != pj {
+			return pi > pj
+		}
+		return candidates[i].ObjectNumber > candidates[j].ObjectNumber
+	})
+	for _, entry := range candidates {
+		ref := &PdfObjectReference{
+			_dgcf:            parser,
+			ObjectNumber:     int64(entry.ObjectNumber),
+			GenerationNumber: int64(entry.Generation),
+		}
+		if parser.isCatalog(ref) {
+			return ref
+		}
+	}
+	return nil
+}
+
+// repairStreamLength checks the Length entry of the stream dictionary `dict`, whose data
+// starts at the current offset, and replaces it by the actual length of the data if the
+// endstream keyword does not follow the data.
+func (parser *PdfParser) repairStreamLength(dict *PdfObjectDictionary, objNum, genNum int64) {
+	offset := parser.GetFileOffset()
+	defer parser.SetFileOffset(offset)
+	length := int64(-1)
+	if obj, err := parser.traceStreamLength(dict.Get("Length")); err == nil {
+		if val, ok := obj.(*PdfObjectInteger); ok && *val >= 0 {
+			length = int64(*val)
+		}
+	}
+	if length >= 0 && parser.isEndstreamAt(offset+length) {
+		return
+	}
+	end, err := parser.findEndstream(offset)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to find endstream of object %d: %v", objNum, err)
+		return
+	}
+	common.Log.Debug("Stream length of object %d corrected from %d to %d", objNum, length, end-offset)
+	dict.Set("Length", MakeInteger(end-offset))
+	report := parser.repairReport
+	for _, s := range report.StreamLengths {
+		if s.ObjectNumber == objNum && s.GenerationNumber == genNum {
+			return
+		}
+	}
+	report.StreamLengths = append(report.StreamLengths, StreamLengthRepair{
+		ObjectNumber:     objNum,
+		GenerationNumber: genNum,
+		Length:           length,
+		ActualLength:     end - offset,
+	})
+}
+
+// isEndstreamAt returns true if the endstream keyword, optionally preceded by white-space,
+// is at `offset`.
+func (parser *PdfParser) isEndstreamAt(offset int64) bool {
+	n := parser._eecde - offset
+	if n > 32 {
+		n = 32
+	}
+	if offset < 0 || n < int64(len(repairEndstreamKeyword)) {
+		return false
+	}
+	b, err := parser.readBytesAt(offset, n)
+	if err != nil {
+		return false
+	}
+	return bytes.HasPrefix(bytes.TrimLeft(b, "\x00\t\n\f\r "), repairEndstreamKeyword)
+}
+
+// findEndstream returns the offset of the end of the stream data starting at `offset`, i.e.
+// the offset of the first endstream keyword after `offset` without the preceding end-of-line
+// marker.
+func (parser *PdfParser) findEndstream(offset int64) (int64, error) {
+	const chunkSize = 64 * 1024
+	// Consecutive chunks overlap so that a keyword found in a chunk is always preceded by its
+	// end-of-line marker in the same chunk.
+	overlap := int64(len(repairEndstreamKeyword) + 2)
+	for pos := offset; pos < parser._eecde; pos += chunkSize - overlap {
+		n := parser._eecde - pos
+		if n > chunkSize {
+			n = chunkSize
+		}
+		chunk, err := parser.readBytesAt(pos, n)
+		if err != nil {
+			return 0, err
+		}
+		if i := bytes.Index(chunk, repairEndstreamKeyword); i >= 0 {
+			if i > 0 && chunk[i-1] == '\n' {
+				i--
+			}
+			if i > 0 && chunk[i-1] == '\r' {
+				i--
+			}
+			return pos + int64(i), nil
+		}
+		if n < chunkSize {
+			break
+		}
+	}
+	return 0, errors.New("endstream not found")
+}
@@ -0,0 +1,362 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/unidoc/unipdf/v3/common"
+)
+
+// Revision represents a revision of a PDF file, i.e. the original document or one of its
+// incremental updates (see section 7.5.6 "Incremental Updates" of PDF32000_2008).
+type Revision struct {
+	// Number is the index of the revision, 0 for the original document.
+	Number int
+
+	// XrefOffset is the offset of the cross-reference section of the revision.
+	XrefOffset int64
+
+	// Start and End delimit the bytes of the file added by the revision, which end with the
+	// end-of-file marker. The document as of the revision consists of the bytes [0, End).
+	Start int64
+	End   int64
+}
+
+// GetRevisions returns the revisions of the file, ordered from the original document to the
+// latest incremental update. The revisions are found by following the chain of
+// cross-reference sections from the last one.
+func (parser *PdfParser) GetRevisions() ([]Revision, error) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+
+	offset := parser.GetFileOffset()
+	defer parser.SetFileOffset(offset)
+
+	var revisions []Revision
+	visited := map[int64]bool{}
+	for xrefOffset := parser._cfed; xrefOffset > 0 && !visited[xrefOffset]; {
+		visited[xrefOffset] = true
+		dict, err := parser.xrefSectionDict(xrefOffset)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to parse xref section at %d: %v", xrefOffset, err)
+			break
+		}
+		prev, hasPrev := GetIntVal(dict.Get("Prev"))
+		// The first page cross-reference section of linearized files points to the main section
+		// which follows it, both belong to the same revision.
+		if !hasPrev || int64(prev) < xrefOffset {
+			end, err := parser.revisionEnd(xrefOffset)
+			if err != nil {
+				return nil, err
+			}
+			revisions = append(revisions, Revision{XrefOffset: xrefOffset, End: end})
+		}
+		if !hasPrev {
+			break
+		}
+		xrefOffset = int64(prev)
+	}
+	if len(revisions) == 0 {
+		return nil, errors.New("no revisions found")
+	}
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].End < revisions[j].End })
+	var result []Revision
+	for _, rev := range revisions {
+		if len(result) > 0 && result[len(result)-1].End == rev.End {
+			continue
+		}
+		if len(result) > 0 {
+			rev.Start = result[len(result)-1].End
+		}
+		rev.Number = len(result)
+		result = append(result, rev)
+	}
+	return result, nil
+}
+
+// xrefSectionDict returns the trailer dictionary of the cross-reference table or the
+// dictionary of the cross-reference stream at `offset`. Like the other helpers reading the
+// cross-reference sections, it moves the position in the input and must be called with the
+// parser locked.
+func (parser *PdfParser) xrefSectionDict(offset int64) (*PdfObjectDictionary, error) {
+	n := parser._eecde - offset
+	if n > 20 {
+		n = 20
+	}
+	if n <= 0 {
+		return nil, errors.New("xref offset outside of file")
+	}
+	head, err := parser.readBytesAt(offset, n)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(bytes.TrimLeft(head, "\x00\t\n\f\r "), []byte("xref")) {
+		parser.SetFileOffset(offset)
+		obj, err := parser.ParseIndirectObject()
+		if err != nil {
+			return nil, err
+		}
+		stream, ok := obj.(*PdfObjectStream)
+		if !ok {
+			return nil, errors.New("xref stream is not a stream object")
+		}
+		return stream.PdfObjectDictionary, nil
+	}
+	pos, err := parser.findKeyword(offset, []byte("trailer"))
+	if err != nil {
+		return nil, err
+	}
+	parser.SetFileOffset(pos + int64(len("trailer")))
+	parser.skipSpaces()
+	return parser.ParseDict()
+}
+
+// revisionEnd returns the offset following the end-of-file marker of the revision whose
+// cross-reference section is at `xrefOffset`, including its end-of-line marker.
+func (parser *PdfParser) revisionEnd(xrefOffset int64) (int64, error) {
+	pos, err := parser.findKeyword(xrefOffset, []byte("%%EOF"))
+	if err != nil {
+		return parser._eecde, nil
+	}
+	end := pos + int64(len("%%EOF"))
+	n := parser._eecde - end
+	if n > 2 {
+		n = 2
+	}
+	if n > 0 {
+		eol, err := parser.readBytesAt(end, n)
+		if err != nil {
+			return 0, err
+		}
+		if eol[0] == '\r' {
+			end++
+			eol = eol[1:]
+		}
+		if len(eol) > 0 && eol[0] == '\n' {
+			end++
+		}
+	}
+	return end, nil
+}
+
+// findKeyword returns the offset of the first occurrence of `keyword` after `offset`.
+func (parser *PdfParser) findKeyword(offset int64, keyword []byte) (int64, error) {
+	const chunkSize = 4096
+	for pos := offset; pos < parser._eecde; pos += chunkSize - int64(len(keyword)) {
+		n := parser._eecde - pos
+		if n > chunkSize {
+			n = chunkSize
+		}
+		chunk, err := parser.readBytesAt(pos, n)
+		if err != nil {
+			return 0, err
+		}
+		if i := bytes.Index(chunk, keyword); i >= 0 {
+			return pos + int64(i), nil
+		}
+		if n < chunkSize {
+			break
+		}
+	}
+	return 0, errors.New("keyword not found")
+}
+
+// GetRevisionObjects returns the numbers of the objects defined and freed by the
+// cross-reference section of revision `rev`, i.e. the objects added, modified or deleted by
+// the revision.
+func (parser *PdfParser) GetRevisionObjects(rev Revision) (defined, freed []int, err error) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+
+	offset := parser.GetFileOffset()
+	defer parser.SetFileOffset(offset)
+
+	n := parser._eecde - rev.XrefOffset
+	if n > 20 {
+		n = 20
+	}
+	if n <= 0 {
+		return nil, nil, errors.New("xref offset outside of file")
+	}
+	head, err := parser.readBytesAt(rev.XrefOffset, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.HasPrefix(bytes.TrimLeft(head, "\x00\t\n\f\r "), []byte("xref")) {
+		return parser.xrefStreamObjects(rev.XrefOffset)
+	}
+	if defined, freed, err = parser.xrefTableObjects(rev.XrefOffset); err != nil {
+		return nil, nil, err
+	}
+
+	// The trailer of hybrid-reference files points to a cross-reference stream containing the
+	// entries of the objects stored in object streams (see section 7.5.8.4 "Compatibility
+	// with Applications That Do Not Support Compressed Reference Streams" of PDF32000_2008).
+	trailer, err := parser.xrefSectionDict(rev.XrefOffset)
+	if err != nil {
+		return nil, nil, err
+	}
+	xrefStm, ok := GetIntVal(trailer.Get("XRefStm"))
+	if !ok {
+		return defined, freed, nil
+	}
+	streamDefined, streamFreed, err := parser.xrefStreamObjects(int64(xrefStm))
+	if err != nil {
+		return nil, nil, err
+	}
+	defined, freed = mergeRevisionObjects(defined, freed, streamDefined, streamFreed)
+	return defined, freed, nil
+}
+
+// mergeRevisionObjects merges the objects defined and freed by a cross-reference table with the
+// objects defined and freed by the cross-reference stream of its hybrid-reference trailer. The
+// objects defined by either section are defined by the revision.
+func mergeRevisionObjects(defined, freed, streamDefined, streamFreed []int) ([]int, []int) {
+	isDefined := map[int]bool{}
+	for _, num := range defined {
+		isDefined[num] = true
+	}
+	for _, num := range streamDefined {
+		if !isDefined[num] {
+			isDefined[num] = true
+			defined = append(defined, num)
+		}
+	}
+
+	var merged []int
+	isFreed := map[int]bool{}
+	for _, list := range [][]int{freed, streamFreed} {
+		for _, num := range list {
+			if !isDefined[num] && !isFreed[num] {
+				isFreed[num] = true
+				merged = append(merged, num)
+			}
+		}
+	}
+	return defined, merged
+}
+
+// xrefTableObjects returns the numbers of the objects defined and freed by the
+// cross-reference table at `offset`.
+func (parser *PdfParser) xrefTableObjects(offset int64) (defined, freed []int, err error) {
+	end, err := parser.findKeyword(offset, []byte("trailer"))
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := parser.readBytesAt(offset, end-offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The section consists of the xref keyword followed by subsections, each of which starts
+	// with its first object number and entry count followed by the entries, made of three
+	// fields: offset, generation number and type (n or f).
+	fields := bytes.Fields(data)
+	for i := 1; i+1 < len(fields); {
+		first, err1 := strconv.Atoi(string(fields[i]))
+		count, err2 := strconv.Atoi(string(fields[i+1]))
+		if err1 != nil || err2 != nil || count < 0 {
+			return nil, nil, errors.New("invalid xref subsection header")
+		}
+		i += 2
+		for j := 0; j < count; j++ {
+			if i+2 >= len(fields) {
+				return nil, nil, errors.New("truncated xref subsection")
+			}
+			objNum := first + j
+			switch string(fields[i+2]) {
+			case "n":
+				defined = append(defined, objNum)
+			case "f":
+				if objNum != 0 {
+					freed = append(freed, objNum)
+				}
+			default:
+				return nil, nil, errors.New("invalid xref entry type")
+			}
+			i += 3
+		}
+	}
+	return defined, freed, nil
+}
+
+// xrefStreamObjects returns the numbers of the objects defined and freed by the
+// cross-reference stream at `offset`.
+func (parser *PdfParser) xrefStreamObjects(offset int64) (defined, freed []int, err error) {
+	parser.SetFileOffset(offset)
+	obj, err := parser.ParseIndirectObject()
+	if err != nil {
+		return nil, nil, err
+	}
+	stream, ok := obj.(*PdfObjectStream)
+	if !ok {
+		return nil, nil, errors.New("xref stream is not a stream object")
+	}
+	widths, ok := GetArray(stream.Get("W"))
+	if !ok || widths.Len() != 3 {
+		return nil, nil, errors.New("invalid xref stream W entry")
+	}
+	var w [3]int
+	for i := range w {
+		v, ok := GetIntVal(widths.Get(i))
+		if !ok || v < 0 || v > 8 {
+			return nil, nil, errors.New("invalid xref stream W entry")
+		}
+		w[i] = v
+	}
+	size, _ := GetIntVal(stream.Get("Size"))
+	index := []int{0, size}
+	if arr, ok := GetArray(stream.Get("Index")); ok {
+		index, err = arr.ToIntegerArray()
+		if err != nil || len(index)%2 != 0 {
+			return nil, nil, errors.New("invalid xref stream Index entry")
+		}
+	}
+	data, err := DecodeStream(stream)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entrySize := w[0] + w[1] + w[2]
+	if entrySize == 0 {
+		return nil, nil, errors.New("invalid xref stream W entry")
+	}
+	pos := 0
+	for i := 0; i < len(index); i += 2 {
+		for j := 0; j < index[i+1]; j++ {
+			if pos+entrySize > len(data) {
+				return nil, nil, errors.New("truncated xref stream")
+			}
+			// The type field defaults to 1 when absent.
+			entryType := 1
+			if w[0] > 0 {
+				entryType = 0
+				for _, b := range data[pos : pos+w[0]] {
+					entryType = entryType<<8 | int(b)
+				}
+			}
+			pos += entrySize
+			objNum := index[i] + j
+			switch entryType {
+			case 0:
+				if objNum != 0 {
+					freed = append(freed, objNum)
+				}
+			case 1, 2:
+				defined = append(defined, objNum)
+			}
+		}
+	}
+	return defined, freed, nil
+}
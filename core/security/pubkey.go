@@ -0,0 +1,154 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package security
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+)
+
+// PubKeyRecipient is a recipient of a document encrypted with the public-key security handler:
+// the certificate of the recipient and the permissions granted to its owner.
+type PubKeyRecipient struct {
+	Certificate *x509.Certificate
+	Permissions Permissions
+}
+
+// PubKeyEncryptDict is a set of additional fields used by the public-key security handler.
+type PubKeyEncryptDict struct {
+	// Recipients are the DER encoded PKCS#7 enveloped data objects of the Recipients array. Each
+	// object holds the seed of the file encryption key and the permissions of its recipients.
+	Recipients [][]byte
+
+	// EncryptMetadata is false when the metadata streams are not encrypted.
+	EncryptMetadata bool
+}
+
+// PubKeyHandler is an interface for public-key security handlers (Adobe.PubSec).
+type PubKeyHandler interface {
+	// GenerateParams generates the Recipients of `d` for `recipients` and returns the file
+	// encryption key.
+	GenerateParams(d *PubKeyEncryptDict, recipients []PubKeyRecipient) ([]byte, error)
+
+	// Authenticate uses the certificate `cert` and its private key `key` to get the file
+	// encryption key and the permissions of the recipient. A nil key is returned if `cert` is
+	// not a recipient of the document.
+	Authenticate(d *PubKeyEncryptDict, cert *x509.Certificate, key crypto.PrivateKey) ([]byte, Permissions, error)
+}
+
+var _ PubKeyHandler = pubKeyHandler{}
+
+// pubKeyHandler is the public-key security handler of the SubFilters adbe.pkcs7.s3,
+// adbe.pkcs7.s4 and adbe.pkcs7.s5.
+type pubKeyHandler struct {
+	Length int
+}
+
+// pubKeySeedLength is the length of the seed of the file encryption key, stored with the
+// permissions in the enveloped data of the recipients.
+const pubKeySeedLength = 20
+
+// NewPubKeyHandler creates a new public-key security handler for a file encryption key of
+// `length` bits. The key is derived with SHA-256 for 256 bit keys (AESV3) and with SHA-1
+// otherwise.
+func NewPubKeyHandler(length int) PubKeyHandler { return pubKeyHandler{Length: length} }
+
+// GenerateParams implements PubKeyHandler interface. The recipients with the same permissions
+// share an enveloped data object.
+func (h pubKeyHandler) GenerateParams(d *PubKeyEncryptDict, recipients []PubKeyRecipient) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("no recipients")
+	}
+	var (
+		perms  []Permissions
+		groups = map[Permissions][]*x509.Certificate{}
+	)
+	for _, r := range recipients {
+		if r.Certificate == nil {
+			return nil, errors.New("recipient without certificate")
+		}
+		if _, ok := groups[r.Permissions]; !ok {
+			perms = append(perms, r.Permissions)
+		}
+		groups[r.Permissions] = append(groups[r.Permissions], r.Certificate)
+	}
+
+	seed := make([]byte, pubKeySeedLength)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return nil, err
+	}
+	d.Recipients = nil
+	for _, p := range perms {
+		content := make([]byte, pubKeySeedLength+4)
+		copy(content, seed)
+		binary.BigEndian.PutUint32(content[pubKeySeedLength:], uint32(p))
+		envelope, err := envelope(content, groups[p])
+		if err != nil {
+			return nil, err
+		}
+		d.Recipients = append(d.Recipients, envelope)
+	}
+	return h.fileKey(d, seed), nil
+}
+
+// Authenticate implements PubKeyHandler interface.
+func (h pubKeyHandler) Authenticate(d *PubKeyEncryptDict, cert *x509.Certificate, key crypto.PrivateKey) ([]byte, Permissions, error) {
+	if cert == nil {
+		return nil, 0, errors.New("no certificate")
+	}
+	decrypter, ok := key.(crypto.Decrypter)
+	if !ok {
+		return nil, 0, errors.New("unsupported private key")
+	}
+	for _, r := range d.Recipients {
+		content, err := openEnvelope(r, cert, decrypter)
+		if err != nil {
+			return nil, 0, err
+		}
+		if content == nil {
+			continue
+		}
+		if len(content) < pubKeySeedLength+4 {
+			return nil, 0, errInvalidField{Func: "Authenticate", Field: "Recipients", Exp: pubKeySeedLength + 4, Got: len(content)}
+		}
+		perms := Permissions(binary.BigEndian.Uint32(content[pubKeySeedLength:]))
+		return h.fileKey(d, content[:pubKeySeedLength]), perms, nil
+	}
+	return nil, 0, nil
+}
+
+// fileKey computes the file encryption key from the seed and the recipients of `d`.
+func (h pubKeyHandler) fileKey(d *PubKeyEncryptDict, seed []byte) []byte {
+	var md hash.Hash
+	if h.Length == 256 {
+		md = sha256.New()
+	} else {
+		md = sha1.New()
+	}
+	md.Write(seed)
+	for _, r := range d.Recipients {
+		md.Write(r)
+	}
+	if !d.EncryptMetadata {
+		md.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	}
+	key := md.Sum(nil)
+	if n := h.Length / 8; n > 0 && n < len(key) {
+		key = key[:n]
+	}
+	return key
+}
@@ -0,0 +1,319 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"errors"
+	"math"
+)
+
+// Columns is a container component which lays out its contents in multiple
+// columns. The contents flow from the top to the bottom of each column, then
+// to the next column and, after the last column, to the next page.
+// Spanning components (e.g. a full width image or heading) are drawn across
+// all the columns.
+// Implements the Drawable interface and can be used with the Creator or
+// added to other containers.
+type Columns struct {
+	count    int
+	gutter   float64
+	balanced bool
+	items    []flowItem
+
+	// Creator used to reserve the space of the footnotes placed on the pages.
+	creator *Creator
+
+	// Margins to be applied around the columns block when drawing on Page.
+	margins margins
+}
+
+// newColumns creates a new columns component with the specified number of columns.
+func newColumns(count int) *Columns {
+	if count < 1 {
+		count = 1
+	}
+	return &Columns{
+		count:    count,
+		gutter:   10,
+		balanced: true,
+	}
+}
+
+// NewColumns creates a new container which lays out its contents in
+// `count` columns. The columns are separated by a 10 point gutter and are
+// balanced by default.
+func (c *Creator) NewColumns(count int) *Columns {
+	col := newColumns(count)
+	col.creator = c
+	return col
+}
+
+// Count returns the number of columns.
+func (col *Columns) Count() int {
+	return col.count
+}
+
+// SetGutter sets the horizontal space between the columns.
+func (col *Columns) SetGutter(gutter float64) {
+	col.gutter = math.Max(gutter, 0)
+}
+
+// Gutter returns the horizontal space between the columns.
+func (col *Columns) Gutter() float64 {
+	return col.gutter
+}
+
+// SetBalanced sets whether the columns are balanced. When enabled, the
+// height of the columns is reduced so that the contents are distributed
+// evenly among the columns on the last page and before spanning components.
+func (col *Columns) SetBalanced(balanced bool) {
+	col.balanced = balanced
+}
+
+// Add adds a component which flows through the columns.
+func (col *Columns) Add(d VectorDrawable) error {
+	if d == nil {
+		return errors.New("invalid columns component")
+	}
+	col.items = append(col.items, flowItem{drawable: d})
+	return nil
+}
+
+// AddSpanning adds a component which spans across all the columns. The
+// contents added before it are placed above it, while the contents added
+// after it continue in the columns below it.
+func (col *Columns) AddSpanning(d VectorDrawable) error {
+	if d == nil {
+		return errors.New("invalid columns component")
+	}
+	col.items = append(col.items, flowItem{drawable: d, spanning: true})
+	return nil
+}
+
+// SetMargins sets the margins of the columns component.
+func (col *Columns) SetMargins(left, right, top, bottom float64) {
+	col.margins._eagb = left
+	col.margins._ggbd = right
+	col.margins._egdb = top
+	col.margins._daeg = bottom
+}
+
+// GetMargins returns the margins of the columns component: left, right, top, bottom.
+func (col *Columns) GetMargins() (float64, float64, float64, float64) {
+	return col.margins._eagb, col.margins._ggbd, col.margins._egdb, col.margins._daeg
+}
+
+// Width returns the width of the component. The columns use all the
+// available width of the draw context.
+func (col *Columns) Width() float64 {
+	return 0
+}
+
+// Height returns the estimated height of the component, assuming the
+// contents are evenly distributed among the columns.
+func (col *Columns) Height() float64 {
+	height := col.margins._egdb + col.margins._daeg
+
+	var flowHeight float64
+	for _, item := range col.items {
+		if item.spanning {
+			height += flowHeight/float64(col.count) + item.drawable.Height()
+			flowHeight = 0
+			continue
+		}
+		flowHeight += item.drawable.Height()
+	}
+	return height + flowHeight/float64(col.count)
+}
+
+// GeneratePageBlocks generates the page blocks for the columns component.
+// Multiple blocks are generated if the contents wrap over multiple pages.
+func (col *Columns) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	origCtx := ctx
+	ctx.X += col.margins._eagb
+	ctx.Width -= col.margins._eagb + col.margins._ggbd
+
+	blk := NewBlock(ctx.PageWidth, ctx.PageHeight)
+	blocks := []*Block{blk}
+
+	var (
+		pos     flowPosition
+		page    int
+		y       = ctx.Y + col.margins._egdb
+		pageTop = ctx.Margins._egdb
+	)
+	for pos.item < len(col.items) {
+		start := pos
+
+		// The footnotes referenced by the contents drawn so far reduce the
+		// available height of the page.
+		bottom := col.pageBottom(ctx, page)
+
+		var (
+			filled float64
+			done   bool
+			err    error
+		)
+		if col.items[pos.item].spanning {
+			pos, filled, err = col.fillSpanning(blk, pos, page, y, bottom-y, ctx)
+			done = pos.item > start.item
+		} else {
+			pos, filled, err = col.fillSection(blk, pos, page, y, bottom-y, ctx)
+			done = col.sectionEnd(pos)
+		}
+		if err != nil {
+			return nil, origCtx, err
+		}
+
+		if done {
+			y += filled
+			continue
+		}
+		if pos.equals(start) && y <= pageTop {
+			return nil, origCtx, errors.New("columns content does not fit the page")
+		}
+
+		// Continue on the next page.
+		blk = NewBlock(ctx.PageWidth, ctx.PageHeight)
+		blocks = append(blocks, blk)
+		page++
+		y = pageTop
+	}
+
+	bottom := col.pageBottom(ctx, page)
+	ctx = origCtx
+	ctx.Page += page
+	ctx.Y = y + col.margins._daeg
+	ctx.Height = bottom - ctx.Y
+	return blocks, ctx, nil
+}
+
+// pageBottom returns the bottom of the area available for the contents on
+// the page with index `page`, relative to the page on which the columns start.
+// The space of the footnotes already placed on the page is excluded.
+func (col *Columns) pageBottom(ctx DrawContext, page int) float64 {
+	bottom := ctx.PageHeight - ctx.Margins._daeg
+	if col.creator != nil && ctx.footnoteSpace {
+		bottom -= col.creator.footnoteHeight(ctx.Page + page)
+	}
+	return bottom
+}
+
+// sectionEnd returns true if the flow position is at the end of a section
+// of contents, i.e. at the end of the contents or before a spanning component.
+func (col *Columns) sectionEnd(pos flowPosition) bool {
+	if len(pos.lines) > 0 || len(pos.blocks) > 0 {
+		return false
+	}
+	return pos.item >= len(col.items) || col.items[pos.item].spanning
+}
+
+// fillSpanning draws the spanning component at the specified position
+// across the full width of the columns. Returns the new position along with
+// the height of the filled area.
+func (col *Columns) fillSpanning(blk *Block, pos flowPosition, page int, y, height float64, ctx DrawContext) (flowPosition, float64, error) {
+	slot := flowSlot{page: page, x: ctx.X, y: y, width: ctx.Width, height: height}
+	items := []flowItem{{drawable: col.items[pos.item].drawable}}
+
+	item := pos.item
+	pos.item = 0
+	pos, filled, err := flowFill(blk, items, pos.cloneLines(), slot, ctx, col.creator)
+	if err != nil {
+		return pos, 0, err
+	}
+
+	done := pos.item > 0
+	pos.item = item
+	if done {
+		pos.item++
+	}
+	return pos, filled, nil
+}
+
+// fillSection draws the contents of the section at the specified position
+// in the columns. When balancing is enabled and the section ends in the
+// available height, the height of the columns is reduced to the minimum
+// height which fits the rest of the section. Returns the new position along
+// with the height of the filled area.
+func (col *Columns) fillSection(blk *Block, pos flowPosition, page int, y, height float64, ctx DrawContext) (flowPosition, float64, error) {
+	// The remaining blocks of split components cannot be placed multiple
+	// times, so they are not balanced.
+	if col.balanced && col.count > 1 && len(pos.blocks) == 0 {
+		end, err := col.trialFill(pos, page, y, height, ctx)
+		if err != nil {
+			return pos, 0, err
+		}
+
+		if col.sectionEnd(end) {
+			// Find the minimum column height which fits the section.
+			low, high := 0.0, height
+			for high-low > 0.5 {
+				mid := (low + high) / 2
+
+				trial, err := col.trialFill(pos, page, y, mid, ctx)
+				if err != nil {
+					return pos, 0, err
+				}
+				if trial.equals(end) {
+					high = mid
+				} else {
+					low = mid
+				}
+			}
+			height = high
+		}
+	}
+
+	return col.fillColumns(blk, pos.cloneLines(), page, y, height, ctx)
+}
+
+// trialFill places the contents starting at the specified position in the
+// columns of the specified height, without drawing them. The footnotes placed
+// by the contents are removed afterwards. Returns the position reached.
+func (col *Columns) trialFill(pos flowPosition, page int, y, height float64, ctx DrawContext) (flowPosition, error) {
+	if col.creator != nil {
+		state := col.creator.saveFootnotes()
+		defer col.creator.restoreFootnotes(state)
+	}
+	end, _, err := col.fillColumns(NewBlock(ctx.PageWidth, ctx.PageHeight), pos.cloneLines(), page, y, height, ctx)
+	return end, err
+}
+
+// fillColumns places the contents starting at the specified position in the
+// columns of the specified height and draws them onto block `blk`.
+// Returns the new position along with the height of the tallest column.
+func (col *Columns) fillColumns(blk *Block, pos flowPosition, page int, y, height float64, ctx DrawContext) (flowPosition, float64, error) {
+	width := (ctx.Width - col.gutter*float64(col.count-1)) / float64(col.count)
+	if width <= 0 {
+		return pos, 0, errors.New("columns width is too small")
+	}
+
+	var filled float64
+	for i := 0; i < col.count && !col.sectionEnd(pos); i++ {
+		slot := flowSlot{
+			page:   page,
+			x:      ctx.X + float64(i)*(width+col.gutter),
+			y:      y,
+			width:  width,
+			height: height,
+		}
+
+		var (
+			slotFilled float64
+			err        error
+		)
+		pos, slotFilled, err = flowFill(blk, col.items, pos, slot, ctx, col.creator)
+		if err != nil {
+			return pos, 0, err
+		}
+		filled = math.Max(filled, slotFilled)
+	}
+	return pos, filled, nil
+}
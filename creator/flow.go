@@ -0,0 +1,229 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"math"
+
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// flowItem represents a component of a content flow.
+type flowItem struct {
+	drawable VectorDrawable
+	spanning bool
+}
+
+// flowSlot represents a rectangular area of a page in which flow content is
+// placed (e.g. a column or a linked frame). The coordinates are relative to
+// the top left corner of the page. The page is relative to the page on which
+// the flow starts.
+type flowSlot struct {
+	page          int
+	x, y          float64
+	width, height float64
+}
+
+// flowPosition represents the position reached while placing the items of a
+// content flow.
+type flowPosition struct {
+	// Index of the item being placed.
+	item int
+
+	// Remaining lines of a partially placed styled paragraph.
+	lines [][]*TextChunk
+
+	// Remaining blocks of a partially placed item which is taller than the
+	// slots. The blocks are laid out for the geometry of `blocksSlot`, with the
+	// content of the last block ending at offset `endY` from the slot top.
+	blocks     []*Block
+	blocksSlot flowSlot
+	endY       float64
+}
+
+// equals returns true if both positions are at the same point of the flow.
+func (pos flowPosition) equals(other flowPosition) bool {
+	return pos.item == other.item && len(pos.lines) == len(other.lines) &&
+		len(pos.blocks) == len(other.blocks)
+}
+
+// cloneLines returns the position with a copy of its remaining lines, so
+// that placing them multiple times (e.g. while balancing columns) does not
+// affect the annotations of the original chunks.
+func (pos flowPosition) cloneLines() flowPosition {
+	if pos.lines != nil {
+		pos.lines = cloneChunkLines(pos.lines)
+	}
+	return pos
+}
+
+// cloneChunkLines returns a copy of the specified lines of text chunks.
+// Link annotations are copied as well.
+func cloneChunkLines(lines [][]*TextChunk) [][]*TextChunk {
+	clone := make([][]*TextChunk, len(lines))
+	for i, line := range lines {
+		clone[i] = make([]*TextChunk, len(line))
+		for j, chunk := range line {
+			clone[i][j] = &TextChunk{
+				Text:         chunk.Text,
+				Style:        chunk.Style,
+				_abdd:        copyChunkAnnotation(chunk._abdd),
+				indexEntries: chunk.indexEntries,
+			}
+		}
+	}
+	return clone
+}
+
+// flowFill places the flow items, starting at the specified position, in the
+// slot and draws them onto block `blk`. Stops when the slot is full, when a
+// spanning item is reached or when all items have been placed. `ctx` is the
+// draw context of the page on which the flow starts. If `c` is not nil, the
+// items are placed above the footnote area of the page, which grows as the
+// items referencing footnotes are placed. Returns the new position along with
+// the height of the slot area filled with content.
+func flowFill(blk *Block, items []flowItem, pos flowPosition, slot flowSlot, ctx DrawContext, c *Creator) (flowPosition, float64, error) {
+	y := slot.y
+	pageCtx := ctx
+	pageCtx.Page = ctx.Page + slot.page
+	pageCtx.Inline = false
+
+	for pos.item < len(items) {
+		item := items[pos.item]
+		if item.spanning {
+			break
+		}
+
+		bottom := slot.y + slot.height
+		if c != nil && ctx.footnoteSpace {
+			bottom = math.Min(bottom, ctx.PageHeight-ctx.Margins._daeg-c.footnoteHeight(pageCtx.Page))
+		}
+
+		// Place the remaining blocks of an item taller than the slots.
+		if len(pos.blocks) > 0 {
+			next := pos.blocks[0].duplicate()
+			translateBlock(next, slot.x-pos.blocksSlot.x, slot.y-pos.blocksSlot.y)
+			if err := blk.mergeBlocks(next); err != nil {
+				return pos, 0, err
+			}
+
+			if pos.blocks = pos.blocks[1:]; len(pos.blocks) > 0 {
+				return pos, slot.height, nil
+			}
+			y = slot.y + pos.endY
+			pos.item++
+			continue
+		}
+
+		if p, ok := item.drawable.(*StyledParagraph); ok && p._bddfc.isRelative() {
+			started := pos.lines != nil
+			pctx := pageCtx
+			pctx.X = slot.x + p._bbcf._eagb
+			pctx.Y = y
+			if !started {
+				pctx.Y += p._bbcf._egdb
+			}
+			pctx.Width = slot.width - p._bbcf._eagb - p._bbcf._ggbd
+			pctx.Height = bottom - pctx.Y - p._bbcf._daeg
+
+			if !started {
+				if p._gefc != nil {
+					p._gefc(p, pctx)
+				}
+				p.SetWidth(pctx.Width)
+				if err := p.wrapText(); err != nil {
+					return pos, 0, err
+				}
+				pos.lines = cloneChunkLines(p._gcded)
+			}
+
+			lines, rest := p.splitPageLines(pos.lines, pctx, !started)
+			newCtx, remaining, err := _cggf(blk, p, lines, pctx)
+			if err != nil {
+				return pos, 0, err
+			}
+			blk.addIndexMarks(p, lines[:len(lines)-len(remaining)], pctx)
+			if remaining = append(remaining, rest...); len(remaining) > 0 {
+				pos.lines = remaining
+				return pos, slot.height, nil
+			}
+
+			y = newCtx.Y + p._bbcf._daeg
+			pos.lines = nil
+			pos.item++
+			continue
+		}
+
+		ictx := pageCtx
+		ictx.X = slot.x
+		ictx.Y = y
+		ictx.Width = slot.width
+		ictx.Height = bottom - y
+		ictx.Margins = margins{slot.x, ctx.PageWidth - slot.x - slot.width, slot.y, ctx.PageHeight - bottom}
+
+		blocks, newCtx, err := item.drawable.GeneratePageBlocks(ictx)
+		if err != nil {
+			return pos, 0, err
+		}
+		if len(blocks) == 0 {
+			pos.item++
+			continue
+		}
+		if len(blocks) == 1 {
+			if err := blk.mergeBlocks(blocks[0]); err != nil {
+				return pos, 0, err
+			}
+			y = newCtx.Y
+			pos.item++
+			continue
+		}
+
+		// The item does not fit in the remaining space of the slot. Items which
+		// would fit in an empty slot are moved to the next one. Taller items
+		// are split, continuing in the next slots.
+		if y > slot.y && item.drawable.Height() <= slot.height {
+			return pos, y - slot.y, nil
+		}
+		if err := blk.mergeBlocks(blocks[0]); err != nil {
+			return pos, 0, err
+		}
+		pos.blocks = blocks[1:]
+		pos.blocksSlot = slot
+		pos.endY = newCtx.Y - slot.y
+		return pos, slot.height, nil
+	}
+
+	return pos, y - slot.y, nil
+}
+
+// translateBlock moves the contents, the annotations and the index marks of
+// the block by `dx` and `dy`, where positive `dy` values move the contents
+// down.
+func translateBlock(blk *Block, dx, dy float64) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	blk.translate(dx, dy)
+	blk.translateIndexMarks(dx, dy)
+
+	for _, annotation := range blk._fg {
+		rect, ok := core.GetArray(annotation.Rect)
+		if !ok || rect.Len() != 4 {
+			continue
+		}
+		coords, err := rect.ToFloat64Array()
+		if err != nil {
+			continue
+		}
+		annotation.Rect = core.MakeArrayFromFloats([]float64{
+			coords[0] + dx, coords[1] - dy, coords[2] + dx, coords[3] - dy,
+		})
+	}
+}
@@ -0,0 +1,167 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"unicode"
+
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// fontHasRune returns true if `font` has a glyph for rune `r`.
+func fontHasRune(font *model.PdfFont, r rune) bool {
+	if font == nil {
+		return false
+	}
+	encoder := font.Encoder()
+	if encoder == nil {
+		return false
+	}
+	_, ok := encoder.RuneToCharcode(r)
+	return ok
+}
+
+// isFallbackFont returns true if `font` is one of the fallback fonts of the style.
+func (ts *TextStyle) isFallbackFont(font *model.PdfFont) bool {
+	if font == nil {
+		return false
+	}
+	for _, fallback := range ts.FallbackFonts {
+		if fallback == font {
+			return true
+		}
+	}
+	return false
+}
+
+// fontForRune returns the first font of the style font stack (the style font
+// followed by its fallback fonts) which covers rune `r`. The style font is
+// returned if none of the fonts cover the rune.
+func (ts *TextStyle) fontForRune(r rune) *model.PdfFont {
+	if fontHasRune(ts.Font, r) {
+		return ts.Font
+	}
+	for _, font := range ts.FallbackFonts {
+		if fontHasRune(font, r) {
+			return font
+		}
+	}
+	return ts.Font
+}
+
+// splitChunksByFont splits the specified chunks into runs of text which can be
+// rendered using a single font of the chunk style font stack. The font of each
+// resulting chunk is set to the font which covers all of its runes.
+// Whitespace is kept in the current run when its font covers it, in order to
+// avoid fragmenting the text into unnecessary runs.
+func splitChunksByFont(chunks []*TextChunk) []*TextChunk {
+	var split []*TextChunk
+	for i, chunk := range chunks {
+		if len(chunk.Style.FallbackFonts) == 0 {
+			if split != nil {
+				split = append(split, chunk)
+			}
+			continue
+		}
+
+		runs := chunk.splitByFont()
+		if split == nil {
+			if len(runs) == 1 && runs[0] == chunk {
+				continue
+			}
+			split = append(split, chunks[:i]...)
+		}
+		split = append(split, runs...)
+	}
+
+	if split == nil {
+		return chunks
+	}
+	return split
+}
+
+// splitByFont splits the text chunk into runs of text which are rendered
+// using the same font of the chunk style font stack. The chunk itself is
+// returned if all its runes are covered by the style font.
+func (tc *TextChunk) splitByFont() []*TextChunk {
+	style := &tc.Style
+
+	var (
+		runs    []*TextChunk
+		text    []rune
+		runFont = style.Font
+	)
+	flush := func() {
+		if len(text) == 0 {
+			return
+		}
+
+		runStyle := tc.Style
+		runStyle.Font = runFont
+		runs = append(runs, &TextChunk{
+			Text:         string(text),
+			Style:        runStyle,
+			_abdd:        tc._abdd,
+			_aggb:        tc._aggb,
+			indexEntries: tc.indexEntries,
+		})
+		text = nil
+	}
+
+	for _, r := range tc.Text {
+		font := runFont
+		if !(unicode.IsSpace(r) && fontHasRune(runFont, r)) {
+			font = style.fontForRune(r)
+		}
+		if font != runFont {
+			flush()
+			runFont = font
+		}
+		text = append(text, r)
+	}
+	flush()
+
+	if len(runs) == 1 && runs[0].Style.Font == style.Font {
+		return []*TextChunk{tc}
+	}
+	return runs
+}
+
+// addFallbackFonts records the fallback fonts used for rendering the
+// contents of the block.
+func (blk *Block) addFallbackFonts(fonts ...*model.PdfFont) {
+	for _, font := range fonts {
+		if font == nil || containsFont(blk.fallbackFonts, font) {
+			continue
+		}
+		blk.fallbackFonts = append(blk.fallbackFonts, font)
+	}
+}
+
+// enableFallbackSubsetting enables font subsetting for the fallback fonts used
+// by the contents of the specified block. Fonts which are already enabled for
+// subsetting are skipped.
+func (c *Creator) enableFallbackSubsetting(blk *Block) {
+	for _, font := range blk.fallbackFonts {
+		if !containsFont(c._efd, font) {
+			c.EnableFontSubsetting(font)
+		}
+	}
+}
+
+// containsFont returns true if `font` is present in the `fonts` slice.
+func containsFont(fonts []*model.PdfFont, font *model.PdfFont) bool {
+	for _, f := range fonts {
+		if f == font {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,1041 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unipdf/v3/annotator"
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/contentstream"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// formFieldComponent is implemented by the form field components.
+type formFieldComponent interface {
+	VectorDrawable
+	fieldBase() *formField
+}
+
+// formWidget associates a widget annotation drawn by a form field component
+// with its form field.
+type formWidget struct {
+	field  *model.PdfField
+	widget *model.PdfAnnotationWidget
+
+	// Specifies whether the appearance of the widget is generated when the
+	// creator is finalized.
+	appearance bool
+}
+
+// formField contains the properties shared by the form field components.
+type formField struct {
+	name    string
+	field   *model.PdfField
+	creator *Creator
+
+	// Size of the field. A zero width makes the field use the available width.
+	width  float64
+	height float64
+
+	borderColor Color
+	borderWidth float64
+	fillColor   Color
+
+	// Positioning: relative / absolute.
+	positioning positioning
+	xPos, yPos  float64
+
+	// Margins to be applied around the field when drawing on Page.
+	margins margins
+}
+
+// newFormField initializes the common properties of a form field component.
+func newFormField(c *Creator, name string, width, height float64) formField {
+	return formField{
+		name:        name,
+		creator:     c,
+		width:       width,
+		height:      height,
+		borderColor: ColorBlack,
+		borderWidth: 1,
+		fillColor:   ColorWhite,
+	}
+}
+
+// fieldBase returns the common properties of the form field component.
+func (f *formField) fieldBase() *formField {
+	return f
+}
+
+// Name returns the partial name of the form field.
+func (f *formField) Name() string {
+	return f.name
+}
+
+// SetWidth sets the width of the field. If the width is 0, the field uses
+// the available width of the draw context.
+func (f *formField) SetWidth(width float64) {
+	f.width = math.Max(width, 0)
+}
+
+// SetHeight sets the height of the field.
+func (f *formField) SetHeight(height float64) {
+	f.height = math.Max(height, 0)
+}
+
+// Width returns the width of the field.
+func (f *formField) Width() float64 {
+	return f.width
+}
+
+// Height returns the height of the field.
+func (f *formField) Height() float64 {
+	return f.height
+}
+
+// SetBorder sets the border color and width of the field. The border is not
+// drawn if the color is nil or the width is 0.
+func (f *formField) SetBorder(color Color, width float64) {
+	f.borderColor = color
+	f.borderWidth = math.Max(width, 0)
+}
+
+// SetBackgroundColor sets the background color of the field. The background
+// is transparent if the color is nil.
+func (f *formField) SetBackgroundColor(color Color) {
+	f.fillColor = color
+}
+
+// SetReadOnly sets whether the value of the field can be changed by the user.
+func (f *formField) SetReadOnly(readOnly bool) {
+	f.setFlag(model.FieldFlagReadOnly, readOnly)
+}
+
+// SetRequired sets whether the field must have a value when the form is
+// submitted.
+func (f *formField) SetRequired(required bool) {
+	f.setFlag(model.FieldFlagRequired, required)
+}
+
+// SetTooltip sets the alternate description of the field, displayed by
+// viewers as a tooltip.
+func (f *formField) SetTooltip(tooltip string) {
+	f.field.TU = core.MakeString(tooltip)
+}
+
+// Field returns the underlying form field.
+func (f *formField) Field() *model.PdfField {
+	return f.field
+}
+
+// SetPos sets the absolute position of the field. Changes the positioning
+// mode to absolute.
+func (f *formField) SetPos(x, y float64) {
+	f.positioning = _bgaf
+	f.xPos = x
+	f.yPos = y
+}
+
+// SetMargins sets the margins of the field.
+func (f *formField) SetMargins(left, right, top, bottom float64) {
+	f.margins._eagb = left
+	f.margins._ggbd = right
+	f.margins._egdb = top
+	f.margins._daeg = bottom
+}
+
+// GetMargins returns the margins of the field: left, right, top, bottom.
+func (f *formField) GetMargins() (float64, float64, float64, float64) {
+	return f.margins._eagb, f.margins._ggbd, f.margins._egdb, f.margins._daeg
+}
+
+// setFlag sets or clears the specified field flag.
+func (f *formField) setFlag(flag model.FieldFlag, set bool) {
+	var flags model.FieldFlag
+	if f.field.Ff != nil {
+		flags = model.FieldFlag(*f.field.Ff)
+	}
+	if set {
+		flags = flags.Set(flag)
+	} else {
+		flags = flags.Clear(flag)
+	}
+	f.field.Ff = core.MakeInteger(int64(flags))
+}
+
+// generate lays out a component of the specified size in the draw context
+// and calls `draw` in order to draw it at the computed position, relative to
+// the top left corner of the page. A zero width makes the component use the
+// available width.
+func (f *formField) generate(ctx DrawContext, width, height float64, draw func(blk *Block, x, y, width float64, ctx DrawContext) error) ([]*Block, DrawContext, error) {
+	var blocks []*Block
+	origCtx := ctx
+	blk := NewBlock(ctx.PageWidth, ctx.PageHeight)
+
+	if f.positioning.isRelative() {
+		if height+f.margins._egdb+f.margins._daeg > ctx.Height {
+			// Move the field to the next page.
+			blocks = append(blocks, blk)
+			blk = NewBlock(ctx.PageWidth, ctx.PageHeight)
+			ctx.Page++
+			ctx.Y = ctx.Margins._egdb
+			ctx.X = ctx.Margins._eagb
+			ctx.Height = ctx.PageHeight - ctx.Margins._egdb - ctx.Margins._daeg
+			ctx.Width = ctx.PageWidth - ctx.Margins._eagb - ctx.Margins._ggbd
+		}
+		ctx.X += f.margins._eagb
+		ctx.Y += f.margins._egdb
+		ctx.Width -= f.margins._eagb + f.margins._ggbd
+		ctx.Height -= f.margins._egdb
+	} else {
+		ctx.X = f.xPos
+		ctx.Y = f.yPos
+	}
+
+	if width <= 0 {
+		width = ctx.Width
+	}
+	if width <= 0 {
+		return nil, origCtx, errors.New("form field width is too small")
+	}
+
+	if err := draw(blk, ctx.X, ctx.Y, width, ctx); err != nil {
+		return nil, origCtx, err
+	}
+	blocks = append(blocks, blk)
+
+	if f.positioning.isAbsolute() {
+		return blocks, origCtx, nil
+	}
+
+	ctx.X -= f.margins._eagb
+	ctx.Width += f.margins._eagb + f.margins._ggbd
+	ctx.Y += height + f.margins._daeg
+	ctx.Height -= height + f.margins._daeg
+	if ctx.Inline {
+		ctx.X += f.margins._eagb + width + f.margins._ggbd
+	}
+	return blocks, ctx, nil
+}
+
+// newWidget creates a new widget annotation for the field at the specified
+// position, relative to the top left corner of the page, and adds it to
+// the block.
+func (f *formField) newWidget(blk *Block, x, y, width, height float64, ctx DrawContext) *model.PdfAnnotationWidget {
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats([]float64{x, ctx.PageHeight - y - height, x + width, ctx.PageHeight - y})
+	widget.F = core.MakeInteger(4)
+
+	mk := core.MakeDict()
+	if f.borderColor != nil && f.borderWidth > 0 {
+		mk.Set("BC", colorArray(f.borderColor))
+	}
+	if f.fillColor != nil {
+		mk.Set("BG", colorArray(f.fillColor))
+	}
+	widget.MK = mk
+
+	bs := model.NewBorderStyle()
+	bs.SetBorderWidth(f.borderWidth)
+	widget.BS = bs.ToPdfObject()
+
+	blk.AddAnnotation(widget.PdfAnnotation)
+	return widget
+}
+
+// register registers the widget with the creator, so that the form field is
+// added to the form of the document when the creator is finalized.
+func (f *formField) register(widget *model.PdfAnnotationWidget, field *model.PdfField, appearance bool) {
+	c := f.creator
+	if c.formWidgets == nil {
+		c.formWidgets = map[*model.PdfAnnotation]*formWidget{}
+	}
+	c.formWidgets[widget.PdfAnnotation] = &formWidget{
+		field:      field,
+		widget:     widget,
+		appearance: appearance,
+	}
+}
+
+// drawBox draws the background and the border of the field appearance.
+func (f *formField) drawBox(cc *contentstream.ContentCreator, width, height float64) {
+	if f.fillColor != nil {
+		r, g, b := f.fillColor.ToRGB()
+		cc.Add_rg(r, g, b).Add_re(0, 0, width, height).Add_f()
+	}
+	if f.borderColor != nil && f.borderWidth > 0 {
+		bw := f.borderWidth
+		r, g, b := f.borderColor.ToRGB()
+		cc.Add_RG(r, g, b).Add_w(bw).Add_re(bw/2, bw/2, width-bw, height-bw).Add_S()
+	}
+}
+
+// setBoxAppearance sets the appearance of the widget to the background and
+// the border of the field. The appearance is replaced when the appearance of
+// the field value is generated.
+func (f *formField) setBoxAppearance(widget *model.PdfAnnotationWidget, width, height float64) error {
+	cc := contentstream.NewContentCreator()
+	cc.Add_q()
+	f.drawBox(cc, width, height)
+	cc.Add_Q()
+
+	form, err := newAppearance(cc, width, height, nil)
+	if err != nil {
+		return err
+	}
+	ap := core.MakeDict()
+	ap.Set("N", form.ToPdfObject())
+	widget.AP = ap
+	return nil
+}
+
+// colorArray returns the PDF representation of the color, as an array of
+// RGB components.
+func colorArray(color Color) *core.PdfObjectArray {
+	r, g, b := color.ToRGB()
+	return core.MakeArrayFromFloats([]float64{r, g, b})
+}
+
+// newAppearance creates a new appearance stream with the specified content.
+func newAppearance(cc *contentstream.ContentCreator, width, height float64, resources *model.PdfPageResources) (*model.XObjectForm, error) {
+	form := model.NewXObjectForm()
+	if resources == nil {
+		resources = model.NewPdfPageResources()
+	}
+	form.Resources = resources
+	form.BBox = core.MakeArrayFromFloats([]float64{0, 0, width, height})
+	if err := form.SetContentStream(cc.Bytes(), core.NewFlateEncoder()); err != nil {
+		return nil, err
+	}
+	return form, nil
+}
+
+// newZapfDingbatsResources returns resources containing the ZapfDingbats
+// font, registered as ZaDb.
+func newZapfDingbatsResources() (*model.PdfPageResources, error) {
+	font, err := model.NewStandard14Font(model.ZapfDingbatsName)
+	if err != nil {
+		return nil, err
+	}
+	resources := model.NewPdfPageResources()
+	if err := resources.SetFontByName("ZaDb", font.ToPdfObject()); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// addCircle appends a circle path to the content creator, approximated
+// using Bezier curves.
+func addCircle(cc *contentstream.ContentCreator, cx, cy, r float64) {
+	k := 0.5523 * r
+	cc.Add_m(cx+r, cy)
+	cc.Add_c(cx+r, cy+k, cx+k, cy+r, cx, cy+r)
+	cc.Add_c(cx-k, cy+r, cx-r, cy+k, cx-r, cy)
+	cc.Add_c(cx-r, cy-k, cx-k, cy-r, cx, cy-r)
+	cc.Add_c(cx+k, cy-r, cx+r, cy-k, cx+r, cy)
+	cc.Add_h()
+}
+
+// TextField is a form field component which allows the user to enter text.
+// Implements the Drawable interface and can be used with the Creator or
+// added to containers (e.g. Division, Table cells).
+type TextField struct {
+	formField
+	text     *model.PdfFieldText
+	fontSize float64
+}
+
+// NewTextField creates a new single line text field with the specified
+// partial name. The field uses the available width by default.
+func (c *Creator) NewTextField(name string) *TextField {
+	text := &model.PdfFieldText{}
+	field := model.NewPdfField()
+	field.SetContext(text)
+	text.PdfField = field
+	text.T = core.MakeString(name)
+
+	tf := &TextField{
+		formField: newFormField(c, name, 0, 20),
+		text:      text,
+		fontSize:  10,
+	}
+	tf.field = field
+	return tf
+}
+
+// NewMultilineTextField creates a new multiline text field with the
+// specified partial name.
+func (c *Creator) NewMultilineTextField(name string) *TextField {
+	tf := c.NewTextField(name)
+	tf.height = 60
+	tf.SetMultiline(true)
+	return tf
+}
+
+// SetValue sets the value of the text field.
+func (tf *TextField) SetValue(value string) {
+	tf.text.V = core.MakeString(value)
+}
+
+// SetMultiline sets whether the text field can contain multiple lines of text.
+func (tf *TextField) SetMultiline(multiline bool) {
+	tf.setFlag(model.FieldFlagMultiline, multiline)
+}
+
+// SetPassword sets whether the text of the field is masked.
+func (tf *TextField) SetPassword(password bool) {
+	tf.setFlag(model.FieldFlagPassword, password)
+}
+
+// SetMaxLength sets the maximum length of the text field value.
+// A value of 0 removes the limit.
+func (tf *TextField) SetMaxLength(maxLen int) {
+	tf.text.MaxLen = nil
+	if maxLen > 0 {
+		tf.text.MaxLen = core.MakeInteger(int64(maxLen))
+	}
+}
+
+// SetFontSize sets the font size of the field text. A font size of 0 makes
+// viewers adjust the font size to the size of the field.
+func (tf *TextField) SetFontSize(fontSize float64) {
+	tf.fontSize = math.Max(fontSize, 0)
+}
+
+// GeneratePageBlocks draws the text field on a block, implementing the
+// Drawable interface.
+func (tf *TextField) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	return tf.generate(ctx, tf.width, tf.height, func(blk *Block, x, y, width float64, ctx DrawContext) error {
+		tf.text.DA = core.MakeString(fmt.Sprintf("/Helv %.2f Tf 0 g", tf.fontSize))
+
+		widget := tf.newWidget(blk, x, y, width, tf.height, ctx)
+		if err := tf.setBoxAppearance(widget, width, tf.height); err != nil {
+			return err
+		}
+		tf.register(widget, tf.field, true)
+		return nil
+	})
+}
+
+// CheckboxField is a form field component representing a checkbox.
+// Implements the Drawable interface and can be used with the Creator or
+// added to containers (e.g. Division, Table cells).
+type CheckboxField struct {
+	formField
+	button  *model.PdfFieldButton
+	checked bool
+}
+
+// NewCheckboxField creates a new checkbox field with the specified partial name.
+func (c *Creator) NewCheckboxField(name string) *CheckboxField {
+	button := &model.PdfFieldButton{}
+	field := model.NewPdfField()
+	field.SetContext(button)
+	button.PdfField = field
+	button.T = core.MakeString(name)
+	button.SetType(model.ButtonTypeCheckbox)
+	button.V = core.MakeName("Off")
+
+	cb := &CheckboxField{
+		formField: newFormField(c, name, 12, 12),
+		button:    button,
+	}
+	cb.field = field
+	return cb
+}
+
+// SetChecked sets the state of the checkbox.
+func (cb *CheckboxField) SetChecked(checked bool) {
+	cb.checked = checked
+	cb.button.V = core.MakeName("Off")
+	if checked {
+		cb.button.V = core.MakeName("Yes")
+	}
+}
+
+// GeneratePageBlocks draws the checkbox field on a block, implementing the
+// Drawable interface.
+func (cb *CheckboxField) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	return cb.generate(ctx, cb.width, cb.height, func(blk *Block, x, y, width float64, ctx DrawContext) error {
+		widget := cb.newWidget(blk, x, y, width, cb.height, ctx)
+		if mk, ok := core.GetDict(widget.MK); ok {
+			mk.Set("CA", core.MakeString("4"))
+		}
+
+		ap, err := cb.stateAppearances(width, cb.height, "Yes", func(cc *contentstream.ContentCreator, w, h float64) {
+			size := 0.8 * math.Min(w, h)
+			cc.Add_BT().Add_rg(0, 0, 0).Add_Tf("ZaDb", size)
+			cc.Add_Td((w-0.846*size)/2, (h-0.7*size)/2)
+			cc.Add_Tj(*core.MakeString("4")).Add_ET()
+		})
+		if err != nil {
+			return err
+		}
+		widget.AP = ap
+
+		widget.AS = core.MakeName("Off")
+		if cb.checked {
+			widget.AS = core.MakeName("Yes")
+		}
+		cb.register(widget, cb.field, false)
+		return nil
+	})
+}
+
+// stateAppearances creates the appearance dictionary of a button widget
+// having an "Off" state and an "on" state named `onState`. `drawOn` draws
+// the mark of the on state.
+func (f *formField) stateAppearances(width, height float64, onState string, drawOn func(cc *contentstream.ContentCreator, w, h float64)) (*core.PdfObjectDictionary, error) {
+	resources, err := newZapfDingbatsResources()
+	if err != nil {
+		return nil, err
+	}
+
+	off := contentstream.NewContentCreator()
+	off.Add_q()
+	f.drawBox(off, width, height)
+	off.Add_Q()
+	offForm, err := newAppearance(off, width, height, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	on := contentstream.NewContentCreator()
+	on.Add_q()
+	f.drawBox(on, width, height)
+	drawOn(on, width, height)
+	on.Add_Q()
+	onForm, err := newAppearance(on, width, height, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	states := core.MakeDict()
+	states.Set("Off", offForm.ToPdfObject())
+	states.Set(core.PdfObjectName(onState), onForm.ToPdfObject())
+
+	ap := core.MakeDict()
+	ap.Set("N", states)
+	return ap, nil
+}
+
+// RadioGroupField is a form field component representing a group of radio
+// buttons, out of which a single one can be selected. Each option is drawn
+// as a radio button followed by its label. The size of the radio buttons is
+// set using SetHeight.
+// Implements the Drawable interface and can be used with the Creator or
+// added to containers (e.g. Division, Table cells).
+type RadioGroupField struct {
+	formField
+	button     *model.PdfFieldButton
+	options    []string
+	selected   string
+	labelStyle TextStyle
+	horizontal bool
+	spacing    float64
+}
+
+// NewRadioGroupField creates a new radio group field with the specified
+// partial name and options. The options are used as export values and as
+// labels of the radio buttons.
+func (c *Creator) NewRadioGroupField(name string, options []string) *RadioGroupField {
+	button := &model.PdfFieldButton{}
+	field := model.NewPdfField()
+	field.SetContext(button)
+	button.PdfField = field
+	button.T = core.MakeString(name)
+	button.SetType(model.ButtonTypeRadio)
+	button.V = core.MakeName("Off")
+
+	rg := &RadioGroupField{
+		formField:  newFormField(c, name, 12, 12),
+		button:     button,
+		options:    options,
+		labelStyle: c.NewTextStyle(),
+		spacing:    4,
+	}
+	rg.field = field
+	rg.setFlag(model.FieldFlagNoToggleToOff, true)
+	return rg
+}
+
+// SetSelected sets the selected option of the radio group.
+func (rg *RadioGroupField) SetSelected(option string) {
+	rg.selected = option
+	rg.button.V = core.MakeName("Off")
+	if option != "" {
+		rg.button.V = core.MakeName(option)
+	}
+}
+
+// SetHorizontal sets whether the options are laid out horizontally instead
+// of vertically.
+func (rg *RadioGroupField) SetHorizontal(horizontal bool) {
+	rg.horizontal = horizontal
+}
+
+// SetLabelStyle sets the style of the option labels.
+func (rg *RadioGroupField) SetLabelStyle(style TextStyle) {
+	rg.labelStyle = style
+}
+
+// optionSize returns the width and height of the specified option.
+func (rg *RadioGroupField) optionSize(option string) (float64, float64) {
+	style := rg.labelStyle
+	_, labelWidth := measureTextWidth([]*TextChunk{{Text: option, Style: style}})
+	return rg.height + rg.spacing + labelWidth, math.Max(rg.height, style.FontSize)
+}
+
+// Width returns the width of the radio group.
+func (rg *RadioGroupField) Width() float64 {
+	var width float64
+	for i, option := range rg.options {
+		w, _ := rg.optionSize(option)
+		if !rg.horizontal {
+			width = math.Max(width, w)
+			continue
+		}
+		if i > 0 {
+			width += 2 * rg.spacing
+		}
+		width += w
+	}
+	return width
+}
+
+// Height returns the height of the radio group.
+func (rg *RadioGroupField) Height() float64 {
+	var height float64
+	for i, option := range rg.options {
+		_, h := rg.optionSize(option)
+		if rg.horizontal {
+			height = math.Max(height, h)
+			continue
+		}
+		if i > 0 {
+			height += rg.spacing
+		}
+		height += h
+	}
+	return height
+}
+
+// GeneratePageBlocks draws the radio buttons and their labels on a block,
+// implementing the Drawable interface.
+func (rg *RadioGroupField) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	if len(rg.options) == 0 {
+		return nil, ctx, errors.New("radio group has no options")
+	}
+
+	size := rg.height
+	return rg.generate(ctx, rg.Width(), rg.Height(), func(blk *Block, x, y, width float64, ctx DrawContext) error {
+		for _, option := range rg.options {
+			optionWidth, optionHeight := rg.optionSize(option)
+
+			widget := rg.newWidget(blk, x, y+(optionHeight-size)/2, size, size, ctx)
+			if mk, ok := core.GetDict(widget.MK); ok {
+				mk.Set("CA", core.MakeString("l"))
+			}
+
+			ap, err := rg.radioAppearances(size, option)
+			if err != nil {
+				return err
+			}
+			widget.AP = ap
+
+			widget.AS = core.MakeName("Off")
+			if option == rg.selected {
+				widget.AS = core.MakeName(option)
+			}
+			rg.register(widget, rg.field, false)
+
+			label := _ffdgb(option, rg.labelStyle)
+			label.SetPos(x+size+rg.spacing, y+(optionHeight-rg.labelStyle.FontSize)/2)
+			if err := blk.Draw(label); err != nil {
+				return err
+			}
+
+			if rg.horizontal {
+				x += optionWidth + 2*rg.spacing
+			} else {
+				y += optionHeight + rg.spacing
+			}
+		}
+		return nil
+	})
+}
+
+// radioAppearances creates the appearance dictionary of a radio button.
+func (rg *RadioGroupField) radioAppearances(size float64, option string) (*core.PdfObjectDictionary, error) {
+	drawButton := func(cc *contentstream.ContentCreator, selected bool) {
+		r := size / 2
+		if rg.fillColor != nil {
+			cr, cg, cb := rg.fillColor.ToRGB()
+			cc.Add_rg(cr, cg, cb)
+			addCircle(cc, r, r, r)
+			cc.Add_f()
+		}
+		if rg.borderColor != nil && rg.borderWidth > 0 {
+			cr, cg, cb := rg.borderColor.ToRGB()
+			cc.Add_RG(cr, cg, cb).Add_w(rg.borderWidth)
+			addCircle(cc, r, r, r-rg.borderWidth/2)
+			cc.Add_S()
+		}
+		if selected {
+			cc.Add_rg(0, 0, 0)
+			addCircle(cc, r, r, r/2.5)
+			cc.Add_f()
+		}
+	}
+
+	states := core.MakeDict()
+	for _, selected := range []bool{false, true} {
+		cc := contentstream.NewContentCreator()
+		cc.Add_q()
+		drawButton(cc, selected)
+		cc.Add_Q()
+
+		form, err := newAppearance(cc, size, size, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		state := "Off"
+		if selected {
+			state = option
+		}
+		states.Set(core.PdfObjectName(state), form.ToPdfObject())
+	}
+
+	ap := core.MakeDict()
+	ap.Set("N", states)
+	return ap, nil
+}
+
+// ChoiceField is a form field component which allows the user to select
+// a value out of a list of options. The options are displayed either in a
+// dropdown (combo box) or in a scrollable list (list box).
+// Implements the Drawable interface and can be used with the Creator or
+// added to containers (e.g. Division, Table cells).
+type ChoiceField struct {
+	formField
+	choice   *model.PdfFieldChoice
+	fontSize float64
+}
+
+// newChoiceField creates a new choice field with the specified partial name
+// and options.
+func newChoiceField(c *Creator, name string, options []string, height float64) *ChoiceField {
+	choice := &model.PdfFieldChoice{}
+	field := model.NewPdfField()
+	field.SetContext(choice)
+	choice.PdfField = field
+	choice.T = core.MakeString(name)
+	choice.Opt = core.MakeArray()
+	for _, option := range options {
+		choice.Opt.Append(core.MakeString(option))
+	}
+
+	cf := &ChoiceField{
+		formField: newFormField(c, name, 0, height),
+		choice:    choice,
+		fontSize:  10,
+	}
+	cf.field = field
+	return cf
+}
+
+// NewComboboxField creates a new dropdown field with the specified partial
+// name and options.
+func (c *Creator) NewComboboxField(name string, options []string) *ChoiceField {
+	cf := newChoiceField(c, name, options, 20)
+	cf.setFlag(model.FieldFlagCombo, true)
+	return cf
+}
+
+// NewListBoxField creates a new list box field with the specified partial
+// name and options.
+func (c *Creator) NewListBoxField(name string, options []string) *ChoiceField {
+	return newChoiceField(c, name, options, 60)
+}
+
+// SetValue sets the selected option of the field.
+func (cf *ChoiceField) SetValue(value string) {
+	cf.choice.V = core.MakeString(value)
+}
+
+// SetEditable sets whether the user can enter a custom value, in addition
+// to selecting one of the options. Applies to combo boxes only.
+func (cf *ChoiceField) SetEditable(editable bool) {
+	cf.setFlag(model.FieldFlagEdit, editable)
+}
+
+// SetMultiSelect sets whether multiple options can be selected. Applies to
+// list boxes only.
+func (cf *ChoiceField) SetMultiSelect(multiSelect bool) {
+	cf.setFlag(model.FieldFlagMultiSelect, multiSelect)
+}
+
+// SetFontSize sets the font size of the field text.
+func (cf *ChoiceField) SetFontSize(fontSize float64) {
+	cf.fontSize = math.Max(fontSize, 0)
+}
+
+// GeneratePageBlocks draws the choice field on a block, implementing the
+// Drawable interface.
+func (cf *ChoiceField) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	return cf.generate(ctx, cf.width, cf.height, func(blk *Block, x, y, width float64, ctx DrawContext) error {
+		widget := cf.newWidget(blk, x, y, width, cf.height, ctx)
+		if err := cf.setBoxAppearance(widget, width, cf.height); err != nil {
+			return err
+		}
+		cf.register(widget, cf.field, cf.choice.Flags().Has(model.FieldFlagCombo))
+		return nil
+	})
+}
+
+// PushButtonField is a form field component representing a push button,
+// which performs an action when clicked (e.g. submitting the form).
+// Implements the Drawable interface and can be used with the Creator or
+// added to containers (e.g. Division, Table cells).
+type PushButtonField struct {
+	formField
+	button       *model.PdfFieldButton
+	caption      string
+	captionStyle TextStyle
+	action       *model.PdfAction
+}
+
+// NewPushButtonField creates a new push button field with the specified
+// partial name and caption.
+func (c *Creator) NewPushButtonField(name, caption string) *PushButtonField {
+	button := &model.PdfFieldButton{}
+	field := model.NewPdfField()
+	field.SetContext(button)
+	button.PdfField = field
+	button.T = core.MakeString(name)
+	button.SetType(model.ButtonTypePush)
+
+	pb := &PushButtonField{
+		formField:    newFormField(c, name, 100, 22),
+		button:       button,
+		caption:      caption,
+		captionStyle: c.NewTextStyle(),
+	}
+	pb.fillColor = ColorRGBFrom8bit(220, 220, 220)
+	pb.field = field
+	return pb
+}
+
+// SetCaptionStyle sets the style of the button caption.
+func (pb *PushButtonField) SetCaptionStyle(style TextStyle) {
+	pb.captionStyle = style
+}
+
+// SetAction sets the action performed when the button is clicked.
+func (pb *PushButtonField) SetAction(action *model.PdfAction) {
+	pb.action = action
+}
+
+// GeneratePageBlocks draws the push button on a block, implementing the
+// Drawable interface.
+func (pb *PushButtonField) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	return pb.generate(ctx, pb.width, pb.height, func(blk *Block, x, y, width float64, ctx DrawContext) error {
+		widget := pb.newWidget(blk, x, y, width, pb.height, ctx)
+		if mk, ok := core.GetDict(widget.MK); ok {
+			mk.Set("CA", core.MakeString(pb.caption))
+		}
+		if pb.action != nil {
+			widget.A = pb.action.ToPdfObject()
+		}
+
+		ap, err := pb.appearance(width, pb.height)
+		if err != nil {
+			return err
+		}
+		widget.AP = ap
+		pb.register(widget, pb.field, false)
+		return nil
+	})
+}
+
+// appearance creates the appearance dictionary of the push button.
+func (pb *PushButtonField) appearance(width, height float64) (*core.PdfObjectDictionary, error) {
+	style := pb.captionStyle
+	_, captionWidth := measureTextWidth([]*TextChunk{{Text: pb.caption, Style: style}})
+	encoded, _ := style.Font.StringToCharcodeBytes(pb.caption)
+
+	resources := model.NewPdfPageResources()
+	if err := resources.SetFontByName("F1", style.Font.ToPdfObject()); err != nil {
+		return nil, err
+	}
+
+	r, g, b := style.Color.ToRGB()
+	cc := contentstream.NewContentCreator()
+	cc.Add_q()
+	pb.drawBox(cc, width, height)
+	cc.Add_BT().Add_rg(r, g, b).Add_Tf("F1", style.FontSize)
+	cc.Add_Td((width-captionWidth)/2, (height-0.7*style.FontSize)/2)
+	cc.Add_Tj(*core.MakeStringFromBytes(encoded)).Add_ET()
+	cc.Add_Q()
+
+	form, err := newAppearance(cc, width, height, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	ap := core.MakeDict()
+	ap.Set("N", form.ToPdfObject())
+	return ap, nil
+}
+
+// SignatureField is a form field component representing an empty signature
+// field, which can be signed afterwards.
+// Implements the Drawable interface and can be used with the Creator or
+// added to containers (e.g. Division, Table cells).
+type SignatureField struct {
+	formField
+}
+
+// NewSignatureField creates a new empty signature field with the specified
+// partial name.
+func (c *Creator) NewSignatureField(name string) *SignatureField {
+	sf := &SignatureField{
+		formField: newFormField(c, name, 200, 50),
+	}
+	sf.field = model.NewPdfField()
+	return sf
+}
+
+// GeneratePageBlocks draws the signature field on a block, implementing the
+// Drawable interface.
+func (sf *SignatureField) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	return sf.generate(ctx, sf.width, sf.height, func(blk *Block, x, y, width float64, ctx DrawContext) error {
+		// The signature field and its widget annotation share the same
+		// dictionary, so a new field is created each time it is drawn.
+		sig := model.NewPdfFieldSignature(nil)
+		sig.T = core.MakeString(sf.name)
+		sig.TU = sf.field.TU
+		sig.Ff = sf.field.Ff
+
+		placeholder := sf.newWidget(NewBlock(ctx.PageWidth, ctx.PageHeight), x, y, width, sf.height, ctx)
+		sig.Rect = placeholder.Rect
+		sig.MK = placeholder.MK
+		sig.BS = placeholder.BS
+
+		if err := sf.setBoxAppearance(sig.PdfAnnotationWidget, width, sf.height); err != nil {
+			return err
+		}
+
+		blk.AddAnnotation(sig.PdfAnnotationWidget.PdfAnnotation)
+		sf.register(sig.PdfAnnotationWidget, sig.PdfField, false)
+		return nil
+	})
+}
+
+// addFormFields adds the form fields drawn on the pages of the creator to
+// the form of the document. The form is created if it was not set using
+// SetForms.
+func (c *Creator) addFormFields() error {
+	return c.addPageFormFields(c._ecfa)
+}
+
+// addPageFormFields adds the form fields drawn on the specified pages to the
+// form of the document.
+func (c *Creator) addPageFormFields(pages []*model.PdfPage) error {
+	if len(c.formWidgets) == 0 {
+		return nil
+	}
+
+	added := map[*model.PdfField]bool{}
+	var appearances []*formWidget
+	var needAppearances bool
+	for _, page := range pages {
+		blk, ok := c._acg[page]
+		if !ok {
+			continue
+		}
+
+		for _, annotation := range blk._fg {
+			fw, ok := c.formWidgets[annotation]
+			if !ok {
+				continue
+			}
+			fw.widget.P = page.ToPdfObject()
+
+			if _, isSignature := fw.field.GetContext().(*model.PdfFieldSignature); !isSignature {
+				fw.widget.Parent = fw.field.ToPdfObject()
+				fw.field.Annotations = append(fw.field.Annotations, fw.widget)
+			}
+
+			if c._daca == nil {
+				c._daca = model.NewPdfAcroForm()
+			}
+			form := c._daca
+			if !added[fw.field] {
+				added[fw.field] = true
+				if form.Fields == nil {
+					form.Fields = &[]*model.PdfField{}
+				}
+				*form.Fields = append(*form.Fields, fw.field)
+			}
+			if fw.appearance {
+				appearances = append(appearances, fw)
+			}
+
+			// The appearance of list boxes is not generated, so viewers
+			// are requested to generate it.
+			if choice, ok := fw.field.GetContext().(*model.PdfFieldChoice); ok && !choice.Flags().Has(model.FieldFlagCombo) {
+				needAppearances = true
+			}
+		}
+	}
+	if c._daca == nil {
+		return nil
+	}
+	if needAppearances {
+		c._daca.NeedAppearances = core.MakeBool(true)
+	}
+
+	// Generate the appearance of the text and combo box fields.
+	form := c._daca
+	if form.DR == nil {
+		form.DR = model.NewPdfPageResources()
+	}
+	if !form.DR.HasFontByName("Helv") {
+		font, err := model.NewStandard14Font(model.HelveticaName)
+		if err != nil {
+			return err
+		}
+		if err := form.DR.SetFontByName("Helv", font.ToPdfObject()); err != nil {
+			return err
+		}
+	}
+	if form.DA == nil {
+		form.DA = core.MakeString("/Helv 0 Tf 0 g")
+	}
+
+	var appGen annotator.FieldAppearance
+	for _, fw := range appearances {
+		ap, err := appGen.GenerateAppearanceDict(form, fw.field, fw.widget)
+		if err != nil {
+			common.Log.Debug("ERROR: failed to generate field appearance: %v", err)
+			continue
+		}
+		if ap != nil {
+			fw.widget.AP = ap
+		}
+	}
+	return nil
+}
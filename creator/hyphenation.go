@@ -0,0 +1,256 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Hyphenator finds the hyphenation points of words using Liang's algorithm,
+// based on TeX hyphenation patterns. Hyphenation patterns for most languages
+// are available from the hyph-utf8 project (https://www.hyphenation.org).
+type Hyphenator struct {
+	language   string
+	patterns   map[string][]int
+	exceptions map[string][]int
+	maxLen     int
+	leftMin    int
+	rightMin   int
+}
+
+// NewHyphenator creates a new hyphenator for the specified language, using
+// the hyphenation patterns read from `patterns`. The patterns can either be
+// in TeX format (\patterns{...} and optionally \hyphenation{...} groups) or
+// be plain whitespace separated lists of patterns, such as the hyph-*.pat.txt
+// files of the hyph-utf8 project.
+// By default, at least 2 characters are kept before and 3 characters after
+// each hyphenation point.
+func NewHyphenator(language string, patterns io.Reader) (*Hyphenator, error) {
+	data, err := ioutil.ReadAll(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Hyphenator{
+		language:   language,
+		patterns:   map[string][]int{},
+		exceptions: map[string][]int{},
+		leftMin:    2,
+		rightMin:   3,
+	}
+
+	content := stripTeXComments(string(data))
+	patternGroups := texGroups(content, `\patterns`)
+	if len(patternGroups) == 0 && !strings.Contains(content, `\`) {
+		patternGroups = []string{content}
+	}
+	for _, group := range patternGroups {
+		for _, pattern := range strings.Fields(group) {
+			h.addPattern(pattern)
+		}
+	}
+	if len(h.patterns) == 0 {
+		return nil, errors.New("no hyphenation patterns found")
+	}
+
+	for _, group := range texGroups(content, `\hyphenation`) {
+		h.AddExceptions(strings.Fields(group)...)
+	}
+	return h, nil
+}
+
+// NewHyphenatorFromFile creates a new hyphenator for the specified language,
+// using the hyphenation patterns loaded from the file at `path`.
+func NewHyphenatorFromFile(language, path string) (*Hyphenator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return NewHyphenator(language, file)
+}
+
+// Language returns the language of the hyphenator.
+func (h *Hyphenator) Language() string {
+	return h.language
+}
+
+// SetMinWordFragments sets the minimum number of characters which must be
+// kept before (left) and after (right) each hyphenation point of a word.
+func (h *Hyphenator) SetMinWordFragments(left, right int) {
+	if left < 1 {
+		left = 1
+	}
+	if right < 1 {
+		right = 1
+	}
+	h.leftMin = left
+	h.rightMin = right
+}
+
+// AddExceptions adds words with explicitly specified hyphenation points,
+// which take precedence over the hyphenation patterns. The hyphenation
+// points are marked using hyphens (e.g. "ta-ble").
+func (h *Hyphenator) AddExceptions(words ...string) {
+	for _, word := range words {
+		var (
+			letters []rune
+			points  []int
+		)
+		for _, r := range strings.ToLower(word) {
+			if r == '-' {
+				points = append(points, len(letters))
+				continue
+			}
+			letters = append(letters, r)
+		}
+		if len(letters) > 0 {
+			h.exceptions[string(letters)] = points
+		}
+	}
+}
+
+// Hyphenate splits the specified word into the fragments delimited by its
+// hyphenation points.
+func (h *Hyphenator) Hyphenate(word string) []string {
+	runes := []rune(word)
+
+	var (
+		fragments []string
+		start     int
+	)
+	for _, point := range h.hyphenationPoints(runes) {
+		fragments = append(fragments, string(runes[start:point]))
+		start = point
+	}
+	return append(fragments, string(runes[start:]))
+}
+
+// hyphenationPoints returns the positions of the word runes before which
+// the word can be hyphenated, in ascending order.
+func (h *Hyphenator) hyphenationPoints(word []rune) []int {
+	if len(word) < h.leftMin+h.rightMin {
+		return nil
+	}
+
+	lower := make([]rune, len(word))
+	for i, r := range word {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var points []int
+	if exception, ok := h.exceptions[string(lower)]; ok {
+		for _, point := range exception {
+			if point >= h.leftMin && point <= len(word)-h.rightMin {
+				points = append(points, point)
+			}
+		}
+		return points
+	}
+
+	// Apply all the patterns matching substrings of the word, delimited by
+	// dots. The value between two letters is the maximum value specified by
+	// the matching patterns. Odd values mark hyphenation points.
+	text := make([]rune, 0, len(lower)+2)
+	text = append(text, '.')
+	text = append(text, lower...)
+	text = append(text, '.')
+
+	values := make([]int, len(text)+1)
+	for i := range text {
+		for j := i + 1; j <= len(text) && j-i <= h.maxLen; j++ {
+			pattern, ok := h.patterns[string(text[i:j])]
+			if !ok {
+				continue
+			}
+			for k, value := range pattern {
+				if value > values[i+k] {
+					values[i+k] = value
+				}
+			}
+		}
+	}
+
+	// values[i+1] corresponds to the position before word rune i.
+	for i := h.leftMin; i <= len(word)-h.rightMin; i++ {
+		if values[i+1]%2 == 1 {
+			points = append(points, i)
+		}
+	}
+	return points
+}
+
+// addPattern parses a TeX hyphenation pattern (e.g. ".ach4" or "a1b") and
+// adds it to the hyphenator patterns.
+func (h *Hyphenator) addPattern(pattern string) {
+	var (
+		letters []rune
+		values  = []int{0}
+	)
+	for _, r := range pattern {
+		if r >= '0' && r <= '9' {
+			values[len(values)-1] = int(r - '0')
+			continue
+		}
+		letters = append(letters, unicode.ToLower(r))
+		values = append(values, 0)
+	}
+	if len(letters) == 0 {
+		return
+	}
+
+	h.patterns[string(letters)] = values
+	if len(letters) > h.maxLen {
+		h.maxLen = len(letters)
+	}
+}
+
+// stripTeXComments removes the comments (text following % characters up to
+// the end of the line) from the specified TeX content.
+func stripTeXComments(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if idx := strings.IndexRune(line, '%'); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// texGroups returns the contents of the brace delimited groups following
+// the occurrences of the specified TeX command.
+func texGroups(content, command string) []string {
+	var groups []string
+	for {
+		idx := strings.Index(content, command)
+		if idx < 0 {
+			break
+		}
+		content = content[idx+len(command):]
+
+		start := strings.IndexRune(content, '{')
+		if start < 0 || strings.TrimSpace(content[:start]) != "" {
+			continue
+		}
+		end := strings.IndexRune(content[start:], '}')
+		if end < 0 {
+			break
+		}
+		groups = append(groups, content[start+1:start+end])
+		content = content[start+end+1:]
+	}
+	return groups
+}
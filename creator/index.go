@@ -0,0 +1,316 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// indexEntry represents an entry of the alphabetical index, along with the
+// locations of the text chunks tagged with it.
+type indexEntry struct {
+	term      string
+	children  map[string]*indexEntry
+	locations []anchorTarget
+}
+
+// child returns the sub-entry of the entry with the specified term, creating
+// it if it does not exist.
+func (e *indexEntry) child(term string) *indexEntry {
+	if e.children == nil {
+		e.children = map[string]*indexEntry{}
+	}
+	entry, ok := e.children[term]
+	if !ok {
+		entry = &indexEntry{term: term}
+		e.children[term] = entry
+	}
+	return entry
+}
+
+// addLocation records a location of the entry. Only the first location of
+// each page is kept.
+func (e *indexEntry) addLocation(target anchorTarget) {
+	for _, loc := range e.locations {
+		if loc.page == target.page {
+			return
+		}
+	}
+	e.locations = append(e.locations, target)
+}
+
+// sortedChildren returns the sub-entries of the entry in alphabetical order.
+func (e *indexEntry) sortedChildren() []*indexEntry {
+	entries := make([]*indexEntry, 0, len(e.children))
+	for _, entry := range e.children {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ti, tj := strings.ToLower(entries[i].term), strings.ToLower(entries[j].term)
+		if ti != tj {
+			return ti < tj
+		}
+		return entries[i].term < entries[j].term
+	})
+	return entries
+}
+
+// Index represents an alphabetical (back-of-book) index. The index entries
+// are created by tagging the text chunks of styled paragraphs with terms.
+// The index is generated at the end of the document when the creator is
+// finalized, if enabled by setting the AddIndex field of the Creator.
+type Index struct {
+	heading     *StyledParagraph
+	letterStyle TextStyle
+	termStyle   TextStyle
+	pageStyle   TextStyle
+	indent      float64
+	columns     int
+	root        indexEntry
+}
+
+// newIndex creates a new alphabetical index with the specified title.
+func newIndex(title string, style, boldStyle TextStyle) *Index {
+	headingStyle := boldStyle
+	headingStyle.FontSize = 14
+
+	heading := _ebge(headingStyle)
+	heading.SetEnableWrap(true)
+	heading.SetTextAlignment(TextAlignmentLeft)
+	heading.SetMargins(0, 0, 0, 5)
+	heading.Append(title).Style = headingStyle
+
+	letterStyle := boldStyle
+	letterStyle.FontSize = 12
+
+	return &Index{
+		heading:     heading,
+		letterStyle: letterStyle,
+		termStyle:   style,
+		pageStyle:   style,
+		indent:      10,
+		columns:     2,
+	}
+}
+
+// Index returns the alphabetical index of the creator, generated at the end
+// of the document if the AddIndex field is set.
+func (c *Creator) Index() *Index {
+	if c.index == nil {
+		boldStyle := c.NewTextStyle()
+		boldStyle.Font = c._eee
+		c.index = newIndex("Index", c.NewTextStyle(), boldStyle)
+	}
+	return c.index
+}
+
+// Heading returns the heading paragraph of the index.
+func (idx *Index) Heading() *StyledParagraph {
+	return idx.heading
+}
+
+// SetLetterStyle sets the style of the letters which group the index entries.
+func (idx *Index) SetLetterStyle(style TextStyle) {
+	idx.letterStyle = style
+}
+
+// SetTermStyle sets the style of the index terms.
+func (idx *Index) SetTermStyle(style TextStyle) {
+	idx.termStyle = style
+}
+
+// SetPageStyle sets the style of the page numbers of the index entries.
+func (idx *Index) SetPageStyle(style TextStyle) {
+	idx.pageStyle = style
+}
+
+// SetIndent sets the left indentation of the sub-entries, relative to their
+// parent entry.
+func (idx *Index) SetIndent(indent float64) {
+	idx.indent = math.Max(indent, 0)
+}
+
+// SetColumns sets the number of columns of the index.
+func (idx *Index) SetColumns(count int) {
+	if count < 1 {
+		count = 1
+	}
+	idx.columns = count
+}
+
+// Mark tags the text chunk with the specified index term. The optional
+// subterms specify the path of a sub-entry of the term (e.g. "fonts",
+// "embedding"). The index entry refers to the page on which the chunk is drawn.
+func (idx *Index) Mark(chunk *TextChunk, term string, subterms ...string) {
+	if chunk == nil || term == "" {
+		return
+	}
+
+	entry := idx.root.child(term)
+	for _, subterm := range subterms {
+		entry = entry.child(subterm)
+	}
+	chunk.indexEntries = append(chunk.indexEntries, entry)
+}
+
+// indexMark represents the position of a text chunk tagged with an index
+// entry, relative to the top left corner of the block on which the chunk is
+// drawn. The location of the entry is recorded when the block is drawn on a
+// page, so that the layouts which are discarded (e.g. while balancing
+// columns) do not add locations to the index.
+type indexMark struct {
+	entry *indexEntry
+	x, y  float64
+}
+
+// addIndexMarks adds the index marks of the text chunks contained by the
+// specified lines of the paragraph, drawn onto the block using the provided
+// context.
+func (blk *Block) addIndexMarks(p *StyledParagraph, lines [][]*TextChunk, ctx DrawContext) {
+	y := ctx.Y
+	for _, line := range lines {
+		var lineHeight float64
+		for _, chunk := range line {
+			lineHeight = math.Max(lineHeight, chunk.Style.FontSize)
+			for _, entry := range chunk.indexEntries {
+				blk.indexMarks = append(blk.indexMarks, indexMark{entry: entry, x: ctx.X, y: y})
+			}
+		}
+		y += lineHeight * p._eadg
+	}
+}
+
+// translateIndexMarks moves the index marks of the block by `dx` and `dy`,
+// where positive `dy` values move the marks down.
+func (blk *Block) translateIndexMarks(dx, dy float64) {
+	for i := range blk.indexMarks {
+		blk.indexMarks[i].x += dx
+		blk.indexMarks[i].y += dy
+	}
+}
+
+// recordIndexMarks records the locations of the index entries marked on the
+// block, drawn on the specified page.
+func (blk *Block) recordIndexMarks(page int) {
+	for _, mark := range blk.indexMarks {
+		mark.entry.addLocation(anchorTarget{page: page, x: mark.x, y: mark.y})
+	}
+}
+
+// indexLetter returns the letter grouping the specified index term. Terms
+// not starting with a letter are grouped under "#".
+func indexLetter(term string) string {
+	for _, r := range term {
+		if unicode.IsLetter(r) {
+			return string(unicode.ToUpper(r))
+		}
+		break
+	}
+	return "#"
+}
+
+// drawIndex draws the index at the end of the content pages. `pageOffset`
+// is the number of pages inserted before the content pages (front page and
+// table of contents). Returns the number of added pages.
+func (c *Creator) drawIndex(pageOffset int) (int, error) {
+	idx := c.index
+	if !c.AddIndex || idx == nil || len(idx.root.children) == 0 {
+		return 0, nil
+	}
+
+	numPages := len(c._ecfa)
+	c.NewPage()
+	c._bbed.Page = len(c._ecfa)
+
+	if c.AddOutlines && c._fae != nil {
+		var title string
+		for _, chunk := range idx.heading._dcfef {
+			title += chunk.Text
+		}
+		c._fae.Add(model.NewOutlineItem(title, model.NewOutlineDest(int64(c._bbed.Page-1), c._bbed.X, c._bbed.Y)))
+	}
+	if err := c.Draw(idx.heading); err != nil {
+		return 0, err
+	}
+
+	cols := c.NewColumns(idx.columns)
+	var letter string
+	for _, entry := range idx.root.sortedChildren() {
+		if l := indexLetter(entry.term); l != letter {
+			letter = l
+
+			p := _ebge(idx.letterStyle)
+			p.SetMargins(0, 0, 6, 2)
+			p.Append(letter).Style = idx.letterStyle
+			if err := cols.Add(p); err != nil {
+				return 0, err
+			}
+		}
+		if err := idx.addEntry(c, cols, entry, 0, pageOffset); err != nil {
+			return 0, err
+		}
+	}
+	if err := c.Draw(cols); err != nil {
+		return 0, err
+	}
+	return len(c._ecfa) - numPages, nil
+}
+
+// addEntry adds the paragraphs of the index entry and of its sub-entries to
+// the columns component.
+func (idx *Index) addEntry(c *Creator, cols *Columns, entry *indexEntry, level int, pageOffset int) error {
+	p := _ebge(idx.termStyle)
+	p.SetMargins(idx.indent*float64(level), 0, 0, 1)
+	p.Append(entry.term).Style = idx.termStyle
+
+	locations := make([]anchorTarget, len(entry.locations))
+	copy(locations, entry.locations)
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].page < locations[j].page
+	})
+
+	// Merge the consecutive pages into page ranges.
+	for i := 0; i < len(locations); {
+		j := i
+		for j+1 < len(locations) && locations[j+1].page == locations[j].page+1 {
+			j++
+		}
+
+		text := strconv.Itoa(locations[i].page + pageOffset)
+		if j > i {
+			text += "\u2013" + strconv.Itoa(locations[j].page+pageOffset)
+		}
+		p.Append(", ").Style = idx.termStyle
+
+		target := locations[i]
+		chunk := NewTextChunk(text, idx.pageStyle)
+		chunk._abdd = c.newDeferredLink(func() (anchorTarget, bool) {
+			return target, true
+		})
+		p.appendChunk(chunk)
+		i = j + 1
+	}
+
+	if err := cols.Add(p); err != nil {
+		return err
+	}
+	for _, child := range entry.sortedChildren() {
+		if err := idx.addEntry(c, cols, child, level+1, pageOffset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import "unicode"
+
+// lineBreakClass represents a Unicode line breaking class, as defined by the
+// Unicode line breaking algorithm (UAX #14). Only the classes which are
+// relevant for the supported rules are represented.
+type lineBreakClass int
+
+const (
+	lbAL lineBreakClass = iota // Ordinary alphabetic and symbol characters.
+	lbBK                       // Mandatory break.
+	lbSP                       // Space.
+	lbZW                       // Zero width space.
+	lbGL                       // Non-breaking ("glue") characters, including word joiners.
+	lbCM                       // Combining marks.
+	lbBA                       // Break after.
+	lbBB                       // Break before.
+	lbB2                       // Break opportunity before and after.
+	lbHY                       // Hyphen-minus.
+	lbCL                       // Close punctuation.
+	lbCP                       // Close parenthesis.
+	lbOP                       // Open punctuation.
+	lbQU                       // Ambiguous quotation marks.
+	lbEX                       // Exclamation and interrogation marks.
+	lbIS                       // Infix numeric separators.
+	lbSY                       // Symbols allowing breaks after.
+	lbNS                       // Non-starters.
+	lbIN                       // Inseparable characters.
+	lbNU                       // Numeric characters.
+	lbPR                       // Prefix numeric characters.
+	lbPO                       // Postfix numeric characters.
+	lbID                       // Ideographic characters.
+)
+
+// lineBreakClasses maps the characters which do not belong to the classes
+// deduced from the general character properties.
+var lineBreakClasses = map[rune]lineBreakClass{
+	'\u000A': lbBK, '\u000B': lbBK, '\u000C': lbBK, '\u000D': lbBK, '\u0085': lbBK,
+	'\u2028': lbBK, '\u2029': lbBK,
+	'\u0020': lbSP,
+	'\u200B': lbZW,
+	'\u00A0': lbGL, '\u034F': lbGL, '\u2007': lbGL, '\u2011': lbGL, '\u202F': lbGL,
+	'\u2060': lbGL, '\uFEFF': lbGL,
+	'\u0009': lbBA, '\u00AD': lbBA, '|': lbBA, '\u1680': lbBA, '\u2000': lbBA,
+	'\u2001': lbBA, '\u2002': lbBA, '\u2003': lbBA, '\u2004': lbBA, '\u2005': lbBA,
+	'\u2006': lbBA, '\u2008': lbBA, '\u2009': lbBA, '\u200A': lbBA, '\u2010': lbBA,
+	'\u2012': lbBA, '\u2013': lbBA, '\u2027': lbBA, '\u205F': lbBA,
+	'\u00B4': lbBB, '\u02C8': lbBB, '\u02CC': lbBB, '\u02DF': lbBB,
+	'\u2014': lbB2,
+	'-':      lbHY,
+	'}':      lbCL, '\u3001': lbCL, '\u3002': lbCL, '\u3009': lbCL, '\u300B': lbCL,
+	'\u300D': lbCL, '\u300F': lbCL, '\u3011': lbCL, '\u3015': lbCL, '\u3017': lbCL,
+	'\u3019': lbCL, '\u301B': lbCL, '\uFF0C': lbCL, '\uFF0E': lbCL, '\uFF5D': lbCL,
+	')': lbCP, ']': lbCP, '\uFF09': lbCP, '\uFF3D': lbCP,
+	'(': lbOP, '[': lbOP, '{': lbOP, '\u00A1': lbOP, '\u00BF': lbOP, '\u3008': lbOP,
+	'\u300A': lbOP, '\u300C': lbOP, '\u300E': lbOP, '\u3010': lbOP, '\u3014': lbOP,
+	'\u3016': lbOP, '\u3018': lbOP, '\u301A': lbOP, '\uFF08': lbOP, '\uFF3B': lbOP,
+	'\uFF5B': lbOP,
+	'"':      lbQU, '\'': lbQU, '\u00AB': lbQU, '\u00BB': lbQU, '\u2018': lbQU,
+	'\u2019': lbQU, '\u201B': lbQU, '\u201C': lbQU, '\u201D': lbQU, '\u201F': lbQU,
+	'\u2039': lbQU, '\u203A': lbQU,
+	'!': lbEX, '?': lbEX, '\uFF01': lbEX, '\uFF1F': lbEX,
+	',': lbIS, '.': lbIS, ':': lbIS, ';': lbIS, '\u037E': lbIS, '\u0589': lbIS,
+	'\u060C': lbIS, '\u060D': lbIS, '\u2044': lbIS, '\uFE10': lbIS, '\uFE13': lbIS,
+	'\uFE14': lbIS,
+	'/':      lbSY,
+	'\u3005': lbNS, '\u301C': lbNS, '\u303B': lbNS, '\u309B': lbNS, '\u309C': lbNS,
+	'\u309D': lbNS, '\u309E': lbNS, '\u30A0': lbNS, '\u30FB': lbNS, '\u30FC': lbNS,
+	'\u30FD': lbNS, '\u30FE': lbNS, '\u203C': lbNS, '\u2047': lbNS, '\u2048': lbNS,
+	'\u2049': lbNS, '\uFF1A': lbNS, '\uFF1B': lbNS,
+	'\u2024': lbIN, '\u2025': lbIN, '\u2026': lbIN,
+	'$': lbPR, '+': lbPR, '\\': lbPR, '\u00A3': lbPR, '\u00A5': lbPR, '\u00B1': lbPR,
+	'\u2116': lbPR, '\u2212': lbPR,
+	'%': lbPO, '\u00A2': lbPO, '\u00B0': lbPO, '\u2030': lbPO, '\u2031': lbPO,
+	'\u2032': lbPO, '\u2033': lbPO, '\u2034': lbPO, '\u2035': lbPO, '\u2036': lbPO,
+	'\u2037': lbPO, '\u2103': lbPO, '\u2109': lbPO,
+}
+
+// smallKana contains the small hiragana and katakana characters, which are
+// treated as non-starters.
+var smallKana = map[rune]bool{
+	'\u3041': true, '\u3043': true, '\u3045': true, '\u3047': true, '\u3049': true,
+	'\u3063': true, '\u3083': true, '\u3085': true, '\u3087': true, '\u308E': true,
+	'\u3095': true, '\u3096': true, '\u30A1': true, '\u30A3': true, '\u30A5': true,
+	'\u30A7': true, '\u30A9': true, '\u30C3': true, '\u30E3': true, '\u30E5': true,
+	'\u30E7': true, '\u30EE': true, '\u30F5': true, '\u30F6': true,
+}
+
+// getLineBreakClass returns the line breaking class of rune `r`.
+func getLineBreakClass(r rune) lineBreakClass {
+	if class, ok := lineBreakClasses[r]; ok {
+		return class
+	}
+	if smallKana[r] {
+		return lbNS
+	}
+
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return lbCM
+	case unicode.IsDigit(r):
+		return lbNU
+	case unicode.Is(unicode.Sc, r):
+		return lbPR
+	case isIdeographic(r):
+		return lbID
+	}
+	return lbAL
+}
+
+// isIdeographic returns true if `r` is a CJK ideograph, a kana or hangul
+// character or a pictographic symbol, between which line breaks are allowed.
+func isIdeographic(r rune) bool {
+	switch {
+	case r >= 0x2E80 && r <= 0x2FFF, // CJK radicals, Kangxi radicals.
+		r >= 0x3040 && r <= 0x30FF,   // Hiragana, Katakana.
+		r >= 0x3130 && r <= 0x318F,   // Hangul compatibility jamo.
+		r >= 0x31F0 && r <= 0x31FF,   // Katakana phonetic extensions.
+		r >= 0x3400 && r <= 0x4DBF,   // CJK unified ideographs extension A.
+		r >= 0x4E00 && r <= 0x9FFF,   // CJK unified ideographs.
+		r >= 0xA000 && r <= 0xA4CF,   // Yi.
+		r >= 0xAC00 && r <= 0xD7A3,   // Hangul syllables.
+		r >= 0xF900 && r <= 0xFAFF,   // CJK compatibility ideographs.
+		r >= 0xFF01 && r <= 0xFF60,   // Fullwidth forms.
+		r >= 0x1F300 && r <= 0x1F64F, // Pictographs, emoticons.
+		r >= 0x1F900 && r <= 0x1F9FF, // Supplemental pictographs.
+		r >= 0x20000 && r <= 0x3FFFD: // CJK unified ideographs extensions.
+		return true
+	}
+	return false
+}
+
+// lineBreakOpportunity represents the type of line break allowed before
+// a character.
+type lineBreakOpportunity int
+
+const (
+	lineBreakProhibited lineBreakOpportunity = iota
+	lineBreakAllowed
+	lineBreakMandatory
+)
+
+// lineBreakOpportunities returns the line break opportunities of the
+// specified text, based on the rules of the Unicode line breaking algorithm
+// (UAX #14). The opportunity at index i applies to the position before
+// rune i. No break is allowed before the first rune.
+// The rules for complex context dependent scripts (e.g. Thai) and for
+// emoji modifier sequences are not supported.
+func lineBreakOpportunities(text []rune) []lineBreakOpportunity {
+	opportunities := make([]lineBreakOpportunity, len(text))
+	if len(text) == 0 {
+		return opportunities
+	}
+
+	// Resolve the classes of the runes. Combining marks take the class of
+	// the base character they are applied to (LB9, LB10).
+	classes := make([]lineBreakClass, len(text))
+	for i, r := range text {
+		class := getLineBreakClass(r)
+		if class == lbCM {
+			class = lbAL
+			if i > 0 {
+				switch prev := classes[i-1]; prev {
+				case lbBK, lbSP, lbZW:
+				default:
+					class = prev
+				}
+			}
+		}
+		classes[i] = class
+	}
+
+	// The class of the last non-space character preceding each position.
+	beforeSpaces := lbBK
+	for i := 1; i < len(text); i++ {
+		a, b := classes[i-1], classes[i]
+		if a != lbSP {
+			beforeSpaces = a
+		}
+		opportunities[i] = pairLineBreak(text[i-1], a, b, beforeSpaces, getLineBreakClass(text[i]) == lbCM)
+	}
+	return opportunities
+}
+
+// pairLineBreak returns the line break opportunity between two characters
+// of classes `a` and `b`. `beforeSpaces` is the class of the last non-space
+// character before the position, and `combining` specifies whether the
+// second character is a combining mark.
+func pairLineBreak(prev rune, a, b, beforeSpaces lineBreakClass, combining bool) lineBreakOpportunity {
+	isOneOf := func(class lineBreakClass, classes ...lineBreakClass) bool {
+		for _, c := range classes {
+			if class == c {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case a == lbBK: // LB4, LB5.
+		if prev == '\r' && b == lbBK {
+			return lineBreakProhibited
+		}
+		return lineBreakMandatory
+	case isOneOf(b, lbBK, lbSP, lbZW): // LB6, LB7.
+		return lineBreakProhibited
+	case a == lbZW || a == lbSP && beforeSpaces == lbZW: // LB8.
+		return lineBreakAllowed
+	case combining: // LB9.
+		return lineBreakProhibited
+	case a == lbGL: // LB11, LB12.
+		return lineBreakProhibited
+	case b == lbGL && !isOneOf(a, lbSP, lbBA, lbHY): // LB12a.
+		return lineBreakProhibited
+	case isOneOf(b, lbCL, lbCP, lbEX, lbIS, lbSY): // LB13.
+		return lineBreakProhibited
+	case beforeSpaces == lbOP: // LB14.
+		return lineBreakProhibited
+	case beforeSpaces == lbQU && b == lbOP: // LB15.
+		return lineBreakProhibited
+	case isOneOf(beforeSpaces, lbCL, lbCP) && b == lbNS: // LB16.
+		return lineBreakProhibited
+	case beforeSpaces == lbB2 && b == lbB2: // LB17.
+		return lineBreakProhibited
+	case a == lbSP: // LB18.
+		return lineBreakAllowed
+	case a == lbQU || b == lbQU: // LB19.
+		return lineBreakProhibited
+	case isOneOf(b, lbBA, lbHY, lbNS) || a == lbBB: // LB21.
+		return lineBreakProhibited
+	case b == lbIN: // LB22.
+		return lineBreakProhibited
+	case a == lbAL && b == lbNU || a == lbNU && b == lbAL: // LB23.
+		return lineBreakProhibited
+	case a == lbPR && b == lbID || a == lbID && b == lbPO: // LB23a.
+		return lineBreakProhibited
+	case isOneOf(a, lbPR, lbPO) && b == lbAL || a == lbAL && isOneOf(b, lbPR, lbPO): // LB24.
+		return lineBreakProhibited
+	case isOneOf(a, lbCL, lbCP, lbNU) && isOneOf(b, lbPO, lbPR), // LB25.
+		isOneOf(a, lbPO, lbPR) && isOneOf(b, lbOP, lbNU),
+		isOneOf(a, lbHY, lbIS, lbNU, lbSY) && b == lbNU:
+		return lineBreakProhibited
+	case a == lbAL && b == lbAL: // LB28.
+		return lineBreakProhibited
+	case a == lbIS && b == lbAL: // LB29.
+		return lineBreakProhibited
+	case isOneOf(a, lbAL, lbNU) && b == lbOP || a == lbCP && isOneOf(b, lbAL, lbNU): // LB30.
+		return lineBreakProhibited
+	}
+	return lineBreakAllowed // LB31.
+}
@@ -0,0 +1,784 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// LineBreakMode defines the algorithm used for breaking paragraph text
+// into lines.
+type LineBreakMode int
+
+const (
+	// LineBreakModeGreedy fills each line with as many words as possible,
+	// breaking lines only at spaces. This is the default mode.
+	LineBreakModeGreedy LineBreakMode = iota
+
+	// LineBreakModeUnicode fills each line with as many characters as
+	// possible, breaking lines at the opportunities defined by the Unicode
+	// line breaking algorithm (UAX #14). Lines can break after hyphens and
+	// slashes (e.g. in URLs) and between ideographic characters.
+	LineBreakModeUnicode
+
+	// LineBreakModeOptimal breaks lines at the opportunities defined by the
+	// Unicode line breaking algorithm, choosing the breaks which minimize
+	// the variation of spacing for the whole paragraph, as described by
+	// Knuth and Plass. Recommended for justified text.
+	LineBreakModeOptimal
+)
+
+// Penalties and demerits used for choosing the line breaks of a paragraph,
+// using the values recommended by Knuth and Plass.
+const (
+	lineBreakHyphenPenalty      = 50.0
+	lineBreakEmergencyPenalty   = 5000.0
+	lineBreakLinePenalty        = 10.0
+	lineBreakHyphenDemerits     = 3000.0
+	lineBreakMaxBadness         = 10000.0
+	lineBreakOverfullDemerits   = 1e9
+	lineBreakSpaceStretchFactor = 0.5
+	lineBreakSpaceShrinkFactor  = 1.0 / 3
+	lineBreakRaggedStretchRatio = 1.0 / 3
+)
+
+// lineBreaking contains the line breaking options of a paragraph.
+type lineBreaking struct {
+	mode       LineBreakMode
+	hyphenator *Hyphenator
+}
+
+// enabled returns true if the options require the line breaking engine,
+// instead of the default greedy wrapping on spaces.
+func (lb lineBreaking) enabled() bool {
+	return lb.mode != LineBreakModeGreedy || lb.hyphenator != nil
+}
+
+// breakPointKind represents the type of a line break opportunity.
+type breakPointKind int
+
+const (
+	breakPointNone breakPointKind = iota
+	breakPointStart
+	breakPointPlain
+	breakPointHyphen
+	breakPointEmergency
+	breakPointMandatory
+	breakPointEnd
+)
+
+// breakPoint represents a position of the text where a line can be broken.
+type breakPoint struct {
+	pos  int
+	kind breakPointKind
+}
+
+// penalty returns the penalty associated with breaking a line at the
+// break point.
+func (bp breakPoint) penalty() float64 {
+	switch bp.kind {
+	case breakPointHyphen:
+		return lineBreakHyphenPenalty
+	case breakPointEmergency:
+		return lineBreakEmergencyPenalty
+	}
+	return 0
+}
+
+// breakText contains the runes of a paragraph, along with the measurements
+// required for breaking it into lines.
+type breakText struct {
+	chunks     []*TextChunk
+	runes      []rune
+	chunkIdx   []int
+	widths     []float64
+	spaceWidth []float64
+	hyphens    []float64
+	points     []breakPoint
+	maxWidth   float64
+	justify    bool
+}
+
+// newBreakText measures the runes of the specified chunks and finds the
+// positions where lines can be broken. Widths are expressed in thousandths
+// of a point, the same unit used by font metrics scaled by the font size.
+func newBreakText(chunks []*TextChunk, width float64, lb lineBreaking, justify bool) (*breakText, error) {
+	bt := &breakText{
+		chunks:   chunks,
+		maxWidth: width * 1000.0,
+		justify:  justify,
+	}
+
+	hyphenWidths := make([]float64, len(chunks))
+	for i, chunk := range chunks {
+		style := &chunk.Style
+		if metrics, ok := style.Font.GetRuneMetrics('-'); ok {
+			hyphenWidths[i] = style.FontSize*metrics.Wx + style.CharSpacing*1000.0
+		}
+
+		for _, r := range chunk.Text {
+			var w float64
+			switch {
+			case r == '\u00AD' || getLineBreakClass(r) == lbBK:
+			default:
+				metrics, ok := style.Font.GetRuneMetrics(r)
+				if !ok {
+					common.Log.Debug("Rune char metrics not found! %v\n", r)
+					return nil, errors.New("glyph char metrics missing")
+				}
+				w = style.FontSize * metrics.Wx
+				if r != ' ' {
+					w += style.CharSpacing * 1000.0
+				}
+			}
+
+			bt.runes = append(bt.runes, r)
+			bt.chunkIdx = append(bt.chunkIdx, i)
+			bt.widths = append(bt.widths, w)
+			bt.hyphens = append(bt.hyphens, hyphenWidths[i])
+			if r == ' ' {
+				bt.spaceWidth = append(bt.spaceWidth, w)
+			} else {
+				bt.spaceWidth = append(bt.spaceWidth, 0)
+			}
+		}
+	}
+
+	bt.findBreakPoints(lb)
+	return bt, nil
+}
+
+// findBreakPoints finds the positions where the text can be broken.
+func (bt *breakText) findBreakPoints(lb lineBreaking) {
+	n := len(bt.runes)
+	kinds := make([]breakPointKind, n+1)
+
+	// Find the break opportunities. In greedy mode, lines are only broken
+	// at spaces, consistent with the default wrapping behavior.
+	if lb.mode == LineBreakModeGreedy {
+		for i := 1; i < n; i++ {
+			switch {
+			case getLineBreakClass(bt.runes[i-1]) == lbBK:
+				kinds[i] = breakPointMandatory
+			case bt.runes[i-1] == ' ' && bt.runes[i] != ' ' && getLineBreakClass(bt.runes[i]) != lbBK:
+				kinds[i] = breakPointPlain
+			}
+		}
+	} else {
+		for i, opportunity := range lineBreakOpportunities(bt.runes) {
+			switch opportunity {
+			case lineBreakMandatory:
+				kinds[i] = breakPointMandatory
+			case lineBreakAllowed:
+				kinds[i] = breakPointPlain
+			}
+		}
+	}
+
+	// Soft hyphens are rendered only when lines are broken after them.
+	for i := 1; i < n; i++ {
+		if bt.runes[i-1] == '\u00AD' && kinds[i] != breakPointMandatory {
+			kinds[i] = breakPointHyphen
+		}
+	}
+
+	// Hyphenate words.
+	if lb.hyphenator != nil {
+		for start := 0; start < n; {
+			if !unicode.IsLetter(bt.runes[start]) {
+				start++
+				continue
+			}
+			end := start
+			for end < n && (unicode.IsLetter(bt.runes[end]) || unicode.Is(unicode.Mn, bt.runes[end])) {
+				end++
+			}
+			for _, point := range lb.hyphenator.hyphenationPoints(bt.runes[start:end]) {
+				if kinds[start+point] == breakPointNone {
+					kinds[start+point] = breakPointHyphen
+				}
+			}
+			start = end
+		}
+	}
+
+	// Allow breaking words which do not fit on a line at any character.
+	last := 0
+	for i := 1; i <= n; i++ {
+		if i < n && kinds[i] == breakPointNone {
+			continue
+		}
+		if bt.contentWidth(last, i, false) > bt.maxWidth {
+			for j := last + 1; j < i; j++ {
+				if kinds[j] == breakPointNone && !unicode.Is(unicode.Mn, bt.runes[j]) {
+					kinds[j] = breakPointEmergency
+				}
+			}
+		}
+		last = i
+	}
+
+	bt.points = append(bt.points[:0], breakPoint{pos: 0, kind: breakPointStart})
+	for i := 1; i < n; i++ {
+		if kinds[i] != breakPointNone {
+			bt.points = append(bt.points, breakPoint{pos: i, kind: kinds[i]})
+		}
+	}
+	bt.points = append(bt.points, breakPoint{pos: n, kind: breakPointEnd})
+}
+
+// lineBounds returns the range of the runes displayed on the line starting
+// at break point `from` and ending at break point `to`. Spaces and
+// mandatory break characters are trimmed from the end of the line. Spaces
+// are trimmed from the start of lines which do not follow a mandatory break.
+func (bt *breakText) lineBounds(from, to breakPoint) (int, int) {
+	start, end := from.pos, to.pos
+	if from.kind != breakPointStart && from.kind != breakPointMandatory {
+		for start < end && bt.runes[start] == ' ' {
+			start++
+		}
+	}
+	for end > start && (bt.runes[end-1] == ' ' || getLineBreakClass(bt.runes[end-1]) == lbBK) {
+		end--
+	}
+	return start, end
+}
+
+// contentWidth returns the width of the runes in the [start, end) range,
+// including the width of a trailing hyphen if `hyphen` is true.
+func (bt *breakText) contentWidth(start, end int, hyphen bool) float64 {
+	var w float64
+	for i := start; i < end; i++ {
+		w += bt.widths[i]
+	}
+	if hyphen && end > 0 {
+		w += bt.hyphens[end-1]
+	}
+	return w
+}
+
+// lineMeasure returns the natural width of the line between the specified
+// break points, as well as the width of its spaces.
+func (bt *breakText) lineMeasure(from, to breakPoint) (float64, float64) {
+	start, end := bt.lineBounds(from, to)
+	var spaces float64
+	for i := start; i < end; i++ {
+		spaces += bt.spaceWidth[i]
+	}
+	return bt.contentWidth(start, end, to.kind == breakPointHyphen), spaces
+}
+
+// greedyBreaks returns the indices of the break points chosen by filling
+// each line with as much content as possible.
+func (bt *breakText) greedyBreaks() []int {
+	var (
+		breaks    []int
+		lineStart = 0
+		lastFit   = -1
+		emergency = -1
+	)
+	for k := 1; k < len(bt.points); k++ {
+		point := bt.points[k]
+		w, _ := bt.lineMeasure(bt.points[lineStart], point)
+		if w <= bt.maxWidth {
+			if point.kind == breakPointEmergency {
+				emergency = k
+			} else {
+				lastFit = k
+			}
+			if point.kind == breakPointMandatory {
+				breaks = append(breaks, k)
+				lineStart, lastFit, emergency = k, -1, -1
+			}
+			continue
+		}
+
+		// The line overflows. Break at the last fitting position, preferring
+		// regular break opportunities over emergency ones.
+		brk := lastFit
+		if brk < 0 {
+			brk = emergency
+		}
+		if brk < 0 {
+			// Nothing fits on the line. Break after the overflowing content.
+			brk = k
+		}
+		breaks = append(breaks, brk)
+		lineStart, lastFit, emergency = brk, -1, -1
+		k = brk
+	}
+
+	if len(breaks) == 0 || breaks[len(breaks)-1] != len(bt.points)-1 {
+		breaks = append(breaks, len(bt.points)-1)
+	}
+	return breaks
+}
+
+// optimalBreaks returns the indices of the break points which minimize the
+// total demerits of the paragraph lines, using the Knuth-Plass algorithm.
+func (bt *breakText) optimalBreaks() []int {
+	n := len(bt.points)
+	best := make([]float64, n)
+	prev := make([]int, n)
+	for i := 1; i < n; i++ {
+		best[i] = math.Inf(1)
+		prev[i] = i - 1
+	}
+
+	lastMandatory := 0
+	for i := 1; i < n; i++ {
+		to := bt.points[i]
+		for j := i - 1; j >= lastMandatory; j-- {
+			if math.IsInf(best[j], 1) {
+				continue
+			}
+			from := bt.points[j]
+
+			demerits, feasible := bt.lineDemerits(from, to)
+			if !feasible {
+				if j == i-1 {
+					// The line cannot be broken any further.
+					demerits = lineBreakOverfullDemerits
+				} else {
+					// Lines starting at earlier break points are even longer.
+					break
+				}
+			}
+			if from.kind == breakPointHyphen && to.kind == breakPointHyphen {
+				demerits += lineBreakHyphenDemerits
+			}
+
+			if total := best[j] + demerits; total < best[i] {
+				best[i] = total
+				prev[i] = j
+			}
+		}
+		if to.kind == breakPointMandatory {
+			lastMandatory = i
+		}
+	}
+
+	var breaks []int
+	for i := n - 1; i > 0; i = prev[i] {
+		breaks = append(breaks, i)
+	}
+	for i, j := 0, len(breaks)-1; i < j; i, j = i+1, j-1 {
+		breaks[i], breaks[j] = breaks[j], breaks[i]
+	}
+	return breaks
+}
+
+// lineDemerits returns the demerits of the line between the specified break
+// points. Returns false if the content of the line does not fit.
+func (bt *breakText) lineDemerits(from, to breakPoint) (float64, bool) {
+	width, spaces := bt.lineMeasure(from, to)
+	diff := bt.maxWidth - width
+
+	// Only justified text can be shrunk, by reducing the spacing of words.
+	var stretch, shrink float64
+	if bt.justify {
+		stretch = spaces * lineBreakSpaceStretchFactor
+		shrink = spaces * lineBreakSpaceShrinkFactor
+	} else {
+		stretch = bt.maxWidth * lineBreakRaggedStretchRatio
+	}
+	if diff < -shrink {
+		return 0, false
+	}
+
+	var badness float64
+	switch {
+	case to.kind == breakPointEnd || to.kind == breakPointMandatory:
+		// The last line of a paragraph is filled with blank space.
+		if diff < 0 {
+			badness = 100 * math.Pow(-diff/shrink, 3)
+		}
+	case diff < 0:
+		badness = 100 * math.Pow(-diff/shrink, 3)
+	case diff > 0 && stretch == 0:
+		badness = lineBreakMaxBadness
+	case diff > 0:
+		badness = 100 * math.Pow(diff/stretch, 3)
+	}
+	badness = math.Min(badness, lineBreakMaxBadness)
+
+	demerits := math.Pow(lineBreakLinePenalty+badness, 2)
+	if penalty := to.penalty(); penalty > 0 {
+		demerits += penalty * penalty
+	}
+	return demerits, true
+}
+
+// lines returns the lines of text chunks delimited by the specified break
+// points. Chunks which span multiple lines are split.
+func (bt *breakText) lines(breaks []int) [][]*TextChunk {
+	var lines [][]*TextChunk
+	from := bt.points[0]
+	for _, k := range breaks {
+		to := bt.points[k]
+		start, end := bt.lineBounds(from, to)
+
+		var line []*TextChunk
+		var (
+			text  []rune
+			chunk = -1
+		)
+		flush := func() {
+			if chunk < 0 {
+				return
+			}
+			src := bt.chunks[chunk]
+			line = append(line, &TextChunk{
+				Text:         string(text),
+				Style:        src.Style,
+				_abdd:        copyChunkAnnotation(src._abdd),
+				indexEntries: src.indexEntries,
+			})
+			text = nil
+		}
+		for i := start; i < end; i++ {
+			r := bt.runes[i]
+			if r == '\u00AD' || getLineBreakClass(r) == lbBK {
+				continue
+			}
+			if bt.chunkIdx[i] != chunk {
+				flush()
+				chunk = bt.chunkIdx[i]
+			}
+			text = append(text, r)
+		}
+		if to.kind == breakPointHyphen && end > 0 {
+			if bt.chunkIdx[end-1] != chunk {
+				flush()
+				chunk = bt.chunkIdx[end-1]
+			}
+			text = append(text, '-')
+		}
+		flush()
+
+		// Keep empty lines, using the style of the chunk they belong to.
+		if len(line) == 0 && len(bt.chunks) > 0 {
+			idx := len(bt.chunks) - 1
+			if from.pos < len(bt.chunkIdx) {
+				idx = bt.chunkIdx[from.pos]
+			}
+			line = append(line, &TextChunk{Style: bt.chunks[idx].Style})
+		}
+
+		lines = append(lines, line)
+		from = to
+	}
+	return lines
+}
+
+// breakChunkLines breaks the specified text chunks into lines which fit
+// the specified width, using the provided line breaking options.
+func breakChunkLines(chunks []*TextChunk, width float64, lb lineBreaking, justify bool) ([][]*TextChunk, error) {
+	bt, err := newBreakText(chunks, width, lb, justify)
+	if err != nil {
+		return nil, err
+	}
+	if len(bt.runes) == 0 {
+		return nil, nil
+	}
+
+	var breaks []int
+	if lb.mode == LineBreakModeOptimal {
+		breaks = bt.optimalBreaks()
+	} else {
+		breaks = bt.greedyBreaks()
+	}
+	return bt.lines(breaks), nil
+}
+
+// copyChunkAnnotation returns a copy of the specified text chunk
+// annotation, to be used by chunks resulting from splitting the text into
+// lines. Only link annotations are copied.
+func copyChunkAnnotation(annotation *model.PdfAnnotation) *model.PdfAnnotation {
+	if annotation == nil {
+		return nil
+	}
+	if link, ok := annotation.GetContext().(*model.PdfAnnotationLink); ok {
+		if linkCopy := _aaab(link); linkCopy != nil {
+			return linkCopy.PdfAnnotation
+		}
+	}
+	return nil
+}
+
+// breakLines wraps the text of the paragraph using the line breaking engine.
+func (p *StyledParagraph) breakLines(chunks []*TextChunk) error {
+	lines, err := breakChunkLines(chunks, p._faa, p.lineBreaking, p._fec == TextAlignmentJustify)
+	if err != nil {
+		return err
+	}
+	p._gcded = lines
+	return nil
+}
+
+// SetLineBreakMode sets the algorithm used for breaking the text of the
+// paragraph into lines.
+func (p *StyledParagraph) SetLineBreakMode(mode LineBreakMode) {
+	p.lineBreaking.mode = mode
+	p.wrapText()
+}
+
+// SetHyphenator sets the hyphenator used for hyphenating the words of the
+// paragraph when breaking its text into lines. Hyphenation is disabled if
+// `hyphenator` is nil. Soft hyphens (U+00AD) are always honored.
+func (p *StyledParagraph) SetHyphenator(hyphenator *Hyphenator) {
+	p.lineBreaking.hyphenator = hyphenator
+	p.wrapText()
+}
+
+// SetOrphanControl sets the minimum number of lines of the paragraph which
+// must be displayed at the bottom of a page, before the paragraph continues
+// on the next page. If fewer lines fit, the paragraph starts on the next
+// page. Orphan control is disabled for values lower than 2.
+func (p *StyledParagraph) SetOrphanControl(lines int) {
+	p.orphans = lines
+}
+
+// SetWidowControl sets the minimum number of lines of the paragraph which
+// must be displayed at the top of a page, when the paragraph continues from
+// the previous page. Widow control is disabled for values lower than 2.
+func (p *StyledParagraph) SetWidowControl(lines int) {
+	p.widows = lines
+}
+
+// splitPageLines splits the specified lines into the lines which are drawn
+// in the available height of the current page and the lines which continue
+// on the next pages, taking the widow and orphan controls into account.
+// The space required by the footnotes referenced in the lines is reserved at
+// the bottom of the page.
+// `first` specifies whether the lines are the first lines of the paragraph.
+func (p *StyledParagraph) splitPageLines(lines [][]*TextChunk, ctx DrawContext, first bool) ([][]*TextChunk, [][]*TextChunk) {
+	var c *Creator
+	if ctx.footnoteSpace && len(p.footnotes) > 0 {
+		c = p.footnotes[0].creator
+	}
+	if !p._bddfc.isRelative() || (c == nil && p.widows < 2 && p.orphans < 2) {
+		return lines, nil
+	}
+
+	// Available height, taking the footnotes already placed on the page into account.
+	available := ctx.Height
+	if c != nil {
+		available = math.Min(available, ctx.PageHeight-ctx.Margins._daeg-c.footnoteHeight(ctx.Page)-ctx.Y)
+	}
+
+	var (
+		fit, plainFit int
+		height        float64
+		notesHeight   float64
+		notes         []*Note
+	)
+	for _, line := range lines {
+		lineHeight := p.lineHeight(line)
+		if height+lineHeight > ctx.Height {
+			break
+		}
+		if plainFit == fit {
+			var lineNotes []*Note
+			var increase float64
+			if c != nil {
+				lineNotes = p.lineFootnotes(line)
+				increase = c.footnotesHeightIncrease(ctx.Page, append(notes, lineNotes...)) - notesHeight
+			}
+			if height+lineHeight+notesHeight+increase <= available {
+				notes = append(notes, lineNotes...)
+				notesHeight += increase
+				fit++
+			}
+		}
+		height += lineHeight
+		plainFit++
+	}
+
+	fit = widowOrphanFit(fit, len(lines), first, p.orphans, p.widows)
+	if fit == 0 && (plainFit == 0 || !first) {
+		// The lines must start on the current page, as moving them to the
+		// next page would not leave more room for them. Only the lines which
+		// fit above the footnote area are drawn, with at least one line so
+		// that the paragraph progresses.
+		if plainFit == 0 {
+			return lines, nil
+		}
+		for height, fit = p.lineHeight(lines[0]), 1; fit < plainFit; fit++ {
+			if height += p.lineHeight(lines[fit]); height > available {
+				break
+			}
+		}
+	}
+
+	p.placeLineFootnotes(c, ctx.Page, lines[:fit])
+	if fit >= len(lines) {
+		return lines, nil
+	}
+	return lines[:fit], lines[fit:]
+}
+
+// lineHeight returns the height of the specified line of the paragraph.
+func (p *StyledParagraph) lineHeight(line []*TextChunk) float64 {
+	var height float64
+	for _, chunk := range line {
+		height = math.Max(height, chunk.Style.FontSize)
+	}
+	return height * p._eadg
+}
+
+// widowOrphanFit returns the number of lines drawn on the current page, out
+// of the `fit` lines which fit in the available height, when breaking the
+// `total` remaining lines of a paragraph across pages with orphan and widow
+// control. `first` specifies whether the lines are the first lines of the
+// paragraph. Zero is returned if the lines must start on the next page.
+func widowOrphanFit(fit, total int, first bool, orphans, widows int) int {
+	if fit >= total {
+		return fit
+	}
+	if total-fit < widows {
+		fit = total - widows
+	}
+	if first && fit < orphans {
+		fit = 0
+	}
+	if fit < 0 {
+		fit = 0
+	}
+	return fit
+}
+
+// placeLineFootnotes places the footnotes referenced in the specified lines
+// on the page.
+func (p *StyledParagraph) placeLineFootnotes(c *Creator, page int, lines [][]*TextChunk) {
+	if c == nil {
+		return
+	}
+	for _, line := range lines {
+		c.placeFootnotes(page, p.lineFootnotes(line))
+	}
+}
+
+// breakLines breaks the text of the paragraph into lines using the line
+// breaking engine.
+func (p *Paragraph) breakLines(chunk *TextChunk) ([]string, error) {
+	lines, err := breakChunkLines([]*TextChunk{chunk}, p._faeg, p.lineBreaking, p._eceb == TextAlignmentJustify)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		var sb strings.Builder
+		for _, lineChunk := range line {
+			sb.WriteString(lineChunk.Text)
+		}
+		texts = append(texts, sb.String())
+	}
+	return texts, nil
+}
+
+// SetLineBreakMode sets the algorithm used for breaking the text of the
+// paragraph into lines.
+func (p *Paragraph) SetLineBreakMode(mode LineBreakMode) {
+	p.lineBreaking.mode = mode
+	p.wrapText()
+}
+
+// SetHyphenator sets the hyphenator used for hyphenating the words of the
+// paragraph when breaking its text into lines. Hyphenation is disabled if
+// `hyphenator` is nil. Soft hyphens (U+00AD) are always honored.
+func (p *Paragraph) SetHyphenator(hyphenator *Hyphenator) {
+	p.lineBreaking.hyphenator = hyphenator
+	p.wrapText()
+}
+
+// SetOrphanControl sets the minimum number of lines of the paragraph which
+// must be displayed at the bottom of a page, before the paragraph continues
+// on the next page. If fewer lines fit, the paragraph starts on the next
+// page. Orphan control is disabled for values lower than 2.
+// By default, the paragraph is not broken across pages: it starts on the next
+// page if it does not fit in the available height. Enabling orphan or widow
+// control lets the paragraph break across pages.
+func (p *Paragraph) SetOrphanControl(lines int) {
+	p.orphans = lines
+}
+
+// SetWidowControl sets the minimum number of lines of the paragraph which
+// must be displayed at the top of a page, when the paragraph continues from
+// the previous page. Widow control is disabled for values lower than 2.
+// Enabling widow control lets the paragraph break across pages.
+func (p *Paragraph) SetWidowControl(lines int) {
+	p.widows = lines
+}
+
+// breaksAcrossPages returns true if the paragraph is broken across pages,
+// which is enabled by the widow and orphan controls.
+func (p *Paragraph) breaksAcrossPages() bool {
+	return p.widows >= 2 || p.orphans >= 2
+}
+
+// generateSplitBlocks generates the page blocks of the relatively positioned
+// paragraph, breaking its lines across pages with widow and orphan control.
+// `ctx` is the draw context with the margins of the paragraph applied and
+// `origCtx` is the context passed to GeneratePageBlocks.
+func (p *Paragraph) generateSplitBlocks(origCtx, ctx DrawContext) ([]*Block, DrawContext, error) {
+	if err := p.wrapText(); err != nil {
+		return nil, origCtx, err
+	}
+	lineHeight := p._eded * p._bgebc
+
+	var blocks []*Block
+	for start := 0; ; {
+		total := len(p._dced) - start
+		fit := total
+		if lineHeight > 0 {
+			fit = int(math.Min(float64(total), math.Floor(ctx.Height/lineHeight+1e-9)))
+		}
+		first := start == 0
+		fit = widowOrphanFit(fit, total, first, p.orphans, p.widows)
+		if fit == 0 && !first {
+			// The lines continued on an empty page cannot be moved further.
+			fit = 1
+		}
+
+		blk := NewBlock(ctx.PageWidth, ctx.PageHeight)
+		if fit > 0 {
+			newCtx, err := _bcff(blk, p, ctx, start, start+fit)
+			if err != nil {
+				return nil, origCtx, err
+			}
+			ctx = newCtx
+		}
+		blocks = append(blocks, blk)
+		if start += fit; start >= len(p._dced) {
+			break
+		}
+
+		// Continue on the next page.
+		next := ctx
+		next.Page++
+		next.X = ctx.Margins._eagb + p._ecccb._eagb
+		next.Y = ctx.Margins._egdb
+		next.Width = ctx.PageWidth - ctx.Margins._eagb - ctx.Margins._ggbd - p._ecccb._eagb - p._ecccb._ggbd
+		next.Height = ctx.PageHeight - ctx.Margins._egdb - ctx.Margins._daeg - p._ecccb._daeg
+		ctx = next
+	}
+
+	ctx.X -= p._ecccb._eagb
+	ctx.Width = origCtx.Width
+	return blocks, ctx, nil
+}
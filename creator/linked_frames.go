@@ -0,0 +1,106 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"errors"
+)
+
+// LinkedFrames is a component which flows its contents through a chain of
+// frames, placed at fixed positions on one or more pages. The contents
+// which do not fit in a frame continue in the next frame of the chain.
+// Implements the Drawable interface and can be used with the Creator.
+type LinkedFrames struct {
+	frames   []flowSlot
+	items    []flowItem
+	overflow bool
+}
+
+// newLinkedFrames creates a new linked frames component.
+func newLinkedFrames() *LinkedFrames {
+	return &LinkedFrames{}
+}
+
+// NewLinkedFrames creates a new component which flows its contents through
+// a chain of frames.
+func (c *Creator) NewLinkedFrames() *LinkedFrames {
+	return newLinkedFrames()
+}
+
+// AddFrame appends a frame to the chain. The page of the frame is relative
+// to the page on which the component is drawn (0 for the current page, 1 for
+// the next one, etc). The x and y coordinates specify the position of the
+// top left corner of the frame, relative to the top left corner of the page.
+func (lf *LinkedFrames) AddFrame(page int, x, y, width, height float64) error {
+	if page < 0 {
+		return errors.New("invalid frame page")
+	}
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid frame size")
+	}
+
+	lf.frames = append(lf.frames, flowSlot{
+		page:   page,
+		x:      x,
+		y:      y,
+		width:  width,
+		height: height,
+	})
+	return nil
+}
+
+// Add adds a component which flows through the frames.
+func (lf *LinkedFrames) Add(d VectorDrawable) error {
+	if d == nil {
+		return errors.New("invalid linked frames component")
+	}
+	lf.items = append(lf.items, flowItem{drawable: d})
+	return nil
+}
+
+// HasOverflow returns true if the contents did not fit in the frames the
+// last time the component was drawn.
+func (lf *LinkedFrames) HasOverflow() bool {
+	return lf.overflow
+}
+
+// GeneratePageBlocks generates the page blocks for the linked frames
+// component. A block is generated for each page, up to the page of the last
+// frame. The frames are positioned absolutely, so the draw context is not
+// modified.
+func (lf *LinkedFrames) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	var pages int
+	for _, frame := range lf.frames {
+		if frame.page+1 > pages {
+			pages = frame.page + 1
+		}
+	}
+
+	blocks := make([]*Block, pages)
+	for i := range blocks {
+		blocks[i] = NewBlock(ctx.PageWidth, ctx.PageHeight)
+	}
+
+	var pos flowPosition
+	for _, frame := range lf.frames {
+		if pos.item >= len(lf.items) {
+			break
+		}
+
+		var err error
+		pos, _, err = flowFill(blocks[frame.page], lf.items, pos.cloneLines(), frame, ctx, nil)
+		if err != nil {
+			return nil, ctx, err
+		}
+	}
+
+	lf.overflow = pos.item < len(lf.items)
+	return blocks, ctx, nil
+}
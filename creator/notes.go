@@ -0,0 +1,338 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+const (
+	// Font size of the footnote and endnote texts.
+	noteFontSize = 8
+
+	// Height of the area containing the separator line drawn above the
+	// footnotes of a page.
+	footnoteSeparatorHeight = 10
+
+	// Vertical space between consecutive notes.
+	noteSpacing = 2
+)
+
+// Note represents a footnote or an endnote. The note is referenced in the
+// text by its number, appended to a paragraph using AppendNote.
+// Footnotes are drawn at the bottom of the page where they are referenced,
+// reducing the usable height of the page. Endnotes are drawn using the
+// Endnotes component.
+type Note struct {
+	number  int
+	endnote bool
+	content *StyledParagraph
+	creator *Creator
+
+	// Identifies the links pointing to the note.
+	marker *core.PdfObjectInteger
+
+	// Specifies whether the footnote has been placed on a page.
+	placed bool
+
+	// Position of the note text.
+	target   anchorTarget
+	resolved bool
+}
+
+// newNote creates a new note with the specified text and number.
+func newNote(c *Creator, text string, number int, endnote bool) *Note {
+	style := c.NewTextStyle()
+	style.FontSize = noteFontSize
+
+	content := c.NewStyledParagraph()
+	content.Append(strconv.Itoa(number) + " ").Style = style
+	content.Append(text).Style = style
+
+	return &Note{
+		number:  number,
+		endnote: endnote,
+		content: content,
+		creator: c,
+	}
+}
+
+// NewFootnote creates a new footnote with the specified text. The footnotes
+// are numbered automatically, in the order they are created.
+func (c *Creator) NewFootnote(text string) *Note {
+	c.footnoteCount++
+	return newNote(c, text, c.footnoteCount, false)
+}
+
+// NewEndnote creates a new endnote with the specified text. The endnotes are
+// numbered automatically, in the order they are created. The endnotes are
+// drawn using the component returned by NewEndnotes.
+func (c *Creator) NewEndnote(text string) *Note {
+	c.endnoteCount++
+	note := newNote(c, text, c.endnoteCount, true)
+	c.endnotes = append(c.endnotes, note)
+	return note
+}
+
+// Number returns the number of the note.
+func (n *Note) Number() int {
+	return n.number
+}
+
+// Content returns the paragraph containing the number and the text of the
+// note. Additional text can be appended to it.
+func (n *Note) Content() *StyledParagraph {
+	return n.content
+}
+
+// AppendNote appends the number of the note to the paragraph. The number is
+// rendered using a smaller font size and links to the text of the note.
+func (p *StyledParagraph) AppendNote(note *Note) *TextChunk {
+	style := p._cged
+	style.FontSize = math.Max(style.FontSize*0.6, 1)
+
+	chunk := NewTextChunk(strconv.Itoa(note.number), style)
+	chunk._abdd = note.creator.newDeferredLink(func() (anchorTarget, bool) {
+		return note.target, note.resolved
+	})
+
+	if dest, ok := core.GetArray(chunk._abdd.GetContext().(*model.PdfAnnotationLink).Dest); ok {
+		note.marker, _ = dest.Get(0).(*core.PdfObjectInteger)
+	}
+	if !note.endnote {
+		p.footnotes = append(p.footnotes, note)
+	}
+	return p.appendChunk(chunk)
+}
+
+// lineFootnotes returns the footnotes referenced in the specified line.
+func (p *StyledParagraph) lineFootnotes(line []*TextChunk) []*Note {
+	var notes []*Note
+	for _, chunk := range line {
+		if chunk._abdd == nil {
+			continue
+		}
+		link, ok := chunk._abdd.GetContext().(*model.PdfAnnotationLink)
+		if !ok {
+			continue
+		}
+		dest, ok := core.GetArray(link.Dest)
+		if !ok || dest.Len() == 0 {
+			continue
+		}
+		for _, note := range p.footnotes {
+			if note.marker == dest.Get(0) {
+				notes = append(notes, note)
+				break
+			}
+		}
+	}
+	return notes
+}
+
+// noteHeight returns the height of the specified note, when drawn using the
+// full width of the page.
+func (c *Creator) noteHeight(note *Note) float64 {
+	note.content.SetWidth(c._gacc - c._fgbg._eagb - c._fgbg._ggbd)
+	return note.content.Height() + noteSpacing
+}
+
+// footnoteHeight returns the height of the footnote area of the specified page.
+func (c *Creator) footnoteHeight(page int) float64 {
+	notes := c.footnotes[page]
+	if len(notes) == 0 {
+		return 0
+	}
+
+	height := float64(footnoteSeparatorHeight)
+	for _, note := range notes {
+		height += c.noteHeight(note)
+	}
+	return height
+}
+
+// placeFootnotes places the specified footnotes on the page.
+func (c *Creator) placeFootnotes(page int, notes []*Note) {
+	for _, note := range notes {
+		if note.placed {
+			continue
+		}
+		if c.footnotes == nil {
+			c.footnotes = map[int][]*Note{}
+		}
+		c.footnotes[page] = append(c.footnotes[page], note)
+		note.placed = true
+	}
+}
+
+// footnoteState records the number of footnotes placed on each page.
+type footnoteState map[int]int
+
+// saveFootnotes returns the state of the footnotes placed on the pages, so
+// that the footnotes placed while laying out contents which are discarded
+// afterwards (e.g. while balancing columns) can be removed.
+func (c *Creator) saveFootnotes() footnoteState {
+	state := footnoteState{}
+	for page, notes := range c.footnotes {
+		state[page] = len(notes)
+	}
+	return state
+}
+
+// restoreFootnotes removes the footnotes placed on the pages after `state`
+// was saved.
+func (c *Creator) restoreFootnotes(state footnoteState) {
+	for page, notes := range c.footnotes {
+		n := state[page]
+		for _, note := range notes[n:] {
+			note.placed = false
+		}
+		if n == 0 {
+			delete(c.footnotes, page)
+		} else {
+			c.footnotes[page] = notes[:n]
+		}
+	}
+}
+
+// footnotesHeightIncrease returns the increase of the height of the footnote
+// area of the page, if the specified footnotes are added to it.
+func (c *Creator) footnotesHeightIncrease(page int, notes []*Note) float64 {
+	var height float64
+	for _, note := range notes {
+		if !note.placed {
+			height += c.noteHeight(note)
+		}
+	}
+	if height > 0 && len(c.footnotes[page]) == 0 {
+		height += footnoteSeparatorHeight
+	}
+	return height
+}
+
+// drawFootnotes draws the footnote areas at the bottom of the content pages.
+func (c *Creator) drawFootnotes() error {
+	for page, notes := range c.footnotes {
+		if err := c.drawPageFootnotes(page, notes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawPageFootnotes draws the footnote area at the bottom of the specified
+// content page.
+func (c *Creator) drawPageFootnotes(page int, notes []*Note) error {
+	pageObj := c.contentPage(page)
+	if pageObj == nil {
+		return nil
+	}
+
+	left := c._fgbg._eagb
+	width := c._gacc - c._fgbg._eagb - c._fgbg._ggbd
+
+	blk := NewBlock(c._gacc, c._afdg)
+	y := c._afdg - c._fgbg._daeg - c.footnoteHeight(page)
+
+	sepY := y + footnoteSeparatorHeight/2
+	separator := c.NewLine(left, sepY, left+width/3, sepY)
+	separator.SetLineWidth(0.5)
+	if err := blk.Draw(separator); err != nil {
+		return err
+	}
+
+	ctx := DrawContext{
+		Page:       page,
+		X:          left,
+		Y:          y + footnoteSeparatorHeight,
+		Width:      width,
+		Height:     c._afdg - y,
+		Margins:    c._fgbg,
+		PageWidth:  c._gacc,
+		PageHeight: c._afdg,
+	}
+	for _, note := range notes {
+		note.target = anchorTarget{page: page, x: ctx.X, y: ctx.Y}
+		note.resolved = true
+
+		note.content.SetWidth(width)
+		blocks, newCtx, err := note.content.GeneratePageBlocks(ctx)
+		if err != nil {
+			return err
+		}
+		for _, noteBlk := range blocks {
+			if err := blk.mergeBlocks(noteBlk); err != nil {
+				return err
+			}
+		}
+		ctx.Y = newCtx.Y + noteSpacing
+	}
+	blk.recordIndexMarks(page)
+
+	if pageBlk, ok := c._acg[pageObj]; ok {
+		if err := pageBlk.mergeBlocks(blk); err != nil {
+			return err
+		}
+		if err := _afc(blk._fd, pageBlk._fd); err != nil {
+			return err
+		}
+	} else {
+		c._acg[pageObj] = blk
+	}
+	return nil
+}
+
+// Endnotes is a component which draws the endnotes of the document.
+// Implements the Drawable interface and can be used with the Creator.
+type Endnotes struct {
+	creator *Creator
+}
+
+// NewEndnotes creates a new component which draws the endnotes created
+// before the component is drawn.
+func (c *Creator) NewEndnotes() *Endnotes {
+	return &Endnotes{creator: c}
+}
+
+// GeneratePageBlocks draws the endnotes, one after the other. Multiple blocks
+// are generated if the endnotes wrap over multiple pages.
+func (e *Endnotes) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	blocks := []*Block{NewBlock(ctx.PageWidth, ctx.PageHeight)}
+	for _, note := range e.creator.endnotes {
+		note.target = anchorTarget{page: ctx.Page, x: ctx.X, y: ctx.Y}
+		note.resolved = true
+
+		noteBlocks, newCtx, err := note.content.GeneratePageBlocks(ctx)
+		if err != nil {
+			return nil, ctx, err
+		}
+		if len(noteBlocks) == 0 {
+			continue
+		}
+		if len(noteBlocks) > 1 && len(*noteBlocks[0]._ae) == 0 {
+			note.target.page++
+			note.target.y = ctx.Margins._egdb
+		}
+
+		if err := blocks[len(blocks)-1].mergeBlocks(noteBlocks[0]); err != nil {
+			return nil, ctx, err
+		}
+		blocks = append(blocks, noteBlocks[1:]...)
+
+		ctx = newCtx
+		ctx.Y += noteSpacing
+		ctx.Height -= noteSpacing
+	}
+	return blocks, ctx, nil
+}
@@ -0,0 +1,385 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// maxLayoutPasses is the maximum number of layout passes performed by
+// GenerateWithReferences in order to resolve the references of the document.
+const maxLayoutPasses = 3
+
+// anchorTarget represents the position of a named anchor in the document.
+type anchorTarget struct {
+	// Page number (starting at 1). Before the creator is finalized, the page
+	// number is relative to the first content page.
+	page int
+
+	// Position of the anchor, relative to the top left corner of the page.
+	x, y float64
+
+	// Label and title of the anchor (e.g. the number and title of a chapter).
+	label string
+	title string
+}
+
+// Anchor is a named location in the document, which can be referenced by
+// cross-references, links and footnotes. The anchor is placed at the current
+// position of the draw context, or at the position of the wrapped component.
+// Implements the Drawable interface and can be used with the Creator.
+type Anchor struct {
+	name     string
+	label    string
+	title    string
+	drawable Drawable
+	creator  *Creator
+}
+
+// NewAnchor creates a new named anchor for the specified component. The
+// component is drawn at the position of the anchor. If `d` is nil, the anchor
+// marks the current position of the draw context. If the component is a
+// Chapter, the label of the anchor is the chapter number (e.g. "3.2") and its
+// title is the chapter title.
+func (c *Creator) NewAnchor(name string, d Drawable) *Anchor {
+	return &Anchor{
+		name:     name,
+		drawable: d,
+		creator:  c,
+	}
+}
+
+// Name returns the name of the anchor.
+func (a *Anchor) Name() string {
+	return a.name
+}
+
+// SetLabel sets the label of the anchor, displayed by the references of type
+// ReferenceLabel (e.g. "Table 4").
+func (a *Anchor) SetLabel(label string) {
+	a.label = label
+}
+
+// SetTitle sets the title of the anchor, displayed by the references of type
+// ReferenceTitle.
+func (a *Anchor) SetTitle(title string) {
+	a.title = title
+}
+
+// GeneratePageBlocks records the position of the anchor and draws the
+// wrapped component, if any.
+func (a *Anchor) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	target := anchorTarget{
+		page:  ctx.Page,
+		x:     ctx.X,
+		y:     ctx.Y,
+		label: a.label,
+		title: a.title,
+	}
+	if ch, ok := a.drawable.(*Chapter); ok {
+		if target.label == "" {
+			target.label = strings.TrimSuffix(ch.headingNumber(), ".")
+		}
+		if target.title == "" {
+			target.title = ch._eag
+		}
+	}
+
+	if a.drawable == nil {
+		a.creator.setAnchor(a.name, target)
+		return []*Block{NewBlock(ctx.PageWidth, ctx.PageHeight)}, ctx, nil
+	}
+
+	blocks, newCtx, err := a.drawable.GeneratePageBlocks(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+
+	// Components which do not fit in the available space of the current page
+	// start on the next page.
+	if len(blocks) > 1 && len(*blocks[0]._ae) == 0 {
+		target.page++
+		target.y = ctx.Margins._egdb
+	}
+	a.creator.setAnchor(a.name, target)
+	return blocks, newCtx, nil
+}
+
+// setAnchor records the position of the named anchor.
+func (c *Creator) setAnchor(name string, target anchorTarget) {
+	if c.anchors == nil {
+		c.anchors = map[string]anchorTarget{}
+	}
+	if _, ok := c.anchors[name]; ok {
+		common.Log.Debug("WARN: anchor %s is already defined. Overwriting.", name)
+	}
+	c.anchors[name] = target
+}
+
+// lookupAnchor returns the position of the named anchor, using the final page
+// numbers. The anchors drawn so far are looked up first, followed by the
+// anchors of the previous layout pass.
+func (c *Creator) lookupAnchor(name string) (anchorTarget, bool) {
+	if target, ok := c.anchors[name]; ok {
+		target.page += c.expectedPageOffset()
+		return target, true
+	}
+	if target, ok := c.prevAnchors[name]; ok {
+		return target, true
+	}
+	return anchorTarget{}, false
+}
+
+// expectedPageOffset returns the expected number of pages inserted before
+// the content pages at Finalize (front page and table of contents).
+func (c *Creator) expectedPageOffset() int {
+	if c._eccab || c.prevPass {
+		return c.pageOffset
+	}
+	if c._effc != nil {
+		return 1
+	}
+	return 0
+}
+
+// finalAnchors returns the positions of the anchors, using the final page
+// numbers.
+func (c *Creator) finalAnchors() map[string]anchorTarget {
+	anchors := make(map[string]anchorTarget, len(c.anchors))
+	for name, target := range c.anchors {
+		target.page += c.pageOffset
+		anchors[name] = target
+	}
+	return anchors
+}
+
+// ReferenceType represents the type of the text displayed by a reference.
+type ReferenceType int
+
+// Reference types.
+const (
+	// ReferencePage displays the number of the page containing the anchor.
+	ReferencePage ReferenceType = iota
+
+	// ReferenceLabel displays the label of the anchor (e.g. "3.2" for chapters).
+	ReferenceLabel
+
+	// ReferenceTitle displays the title of the anchor.
+	ReferenceTitle
+)
+
+// Reference represents a cross-reference to a named anchor (e.g. "see page 4"
+// or "see section 3.2"). The text of the reference is resolved when the
+// paragraph containing it is laid out. References to anchors which are drawn
+// after the reference are resolved using a second layout pass (see GenerateWithReferences).
+type Reference struct {
+	anchor      string
+	typ         ReferenceType
+	placeholder string
+	text        string
+	creator     *Creator
+}
+
+// NewReference creates a new reference of the specified type to the named
+// anchor. Unresolved references are displayed as "??".
+func (c *Creator) NewReference(anchor string, typ ReferenceType) *Reference {
+	ref := &Reference{
+		anchor:      anchor,
+		typ:         typ,
+		placeholder: "??",
+		creator:     c,
+	}
+	c.references = append(c.references, ref)
+	return ref
+}
+
+// SetPlaceholder sets the text displayed while the reference is unresolved.
+func (r *Reference) SetPlaceholder(text string) {
+	r.placeholder = text
+}
+
+// Text returns the current text of the reference.
+func (r *Reference) Text() string {
+	return r.resolve()
+}
+
+// resolve returns the text of the reference, based on the position of the
+// anchor. The text is recorded in order to detect the references which
+// change after the final layout.
+func (r *Reference) resolve() string {
+	r.text = r.placeholder
+	if target, ok := r.creator.lookupAnchor(r.anchor); ok {
+		r.text = r.targetText(target)
+	}
+	return r.text
+}
+
+// targetText returns the text displayed by the reference for the specified
+// anchor position.
+func (r *Reference) targetText(target anchorTarget) string {
+	switch r.typ {
+	case ReferenceLabel:
+		return target.label
+	case ReferenceTitle:
+		return target.title
+	}
+	return strconv.Itoa(target.page)
+}
+
+// AppendReference appends a cross-reference to the paragraph. The reference
+// is rendered using the link style of the paragraph and links to the anchor.
+func (p *StyledParagraph) AppendReference(ref *Reference) *TextChunk {
+	chunk := NewTextChunk(ref.resolve(), p._bgbf)
+	chunk._abdd = ref.creator.newDeferredLink(func() (anchorTarget, bool) {
+		target, ok := ref.creator.anchors[ref.anchor]
+		return target, ok
+	})
+	chunk.reference = ref
+	return p.appendChunk(chunk)
+}
+
+// resolveChunkReferences updates the text of the chunks containing references.
+func resolveChunkReferences(chunks []*TextChunk) {
+	for _, chunk := range chunks {
+		if chunk.reference != nil {
+			chunk.Text = chunk.reference.resolve()
+		}
+	}
+}
+
+// referencesResolved returns true if the text of all the references matches
+// the final positions of their anchors.
+func (c *Creator) referencesResolved() bool {
+	anchors := c.finalAnchors()
+	for _, ref := range c.references {
+		target, ok := anchors[ref.anchor]
+		if !ok || ref.text != ref.targetText(target) {
+			return false
+		}
+	}
+	return true
+}
+
+// newDeferredLink creates a new internal link annotation, whose destination
+// is resolved at Finalize. The `resolve` function returns the target of the
+// link, with the page numbers relative to the first content page.
+func (c *Creator) newDeferredLink(resolve func() (anchorTarget, bool)) *model.PdfAnnotation {
+	link := model.NewPdfAnnotationLink()
+	bs := model.NewBorderStyle()
+	bs.SetBorderWidth(0)
+	link.BS = bs.ToPdfObject()
+
+	// The first element of the destination identifies the link. It is shared
+	// by the copies of the annotation made when the text is laid out.
+	marker := core.MakeInteger(0)
+	link.Dest = core.MakeArray(marker, core.MakeName("XYZ"), core.MakeFloat(0), core.MakeFloat(0), core.MakeFloat(0))
+
+	if c.deferredLinks == nil {
+		c.deferredLinks = map[*core.PdfObjectInteger]func() (anchorTarget, bool){}
+	}
+	c.deferredLinks[marker] = resolve
+	return link.PdfAnnotation
+}
+
+// resolveLinks sets the destinations of the deferred links drawn on the pages
+// of the creator. `pageOffset` is the number of pages inserted before the
+// content pages (front page and table of contents).
+func (c *Creator) resolveLinks(pageOffset int) {
+	c.pageOffset = pageOffset
+	if len(c.deferredLinks) == 0 {
+		return
+	}
+
+	for _, blk := range c._acg {
+		c.resolveBlockLinks(blk, pageOffset)
+	}
+}
+
+// resolveBlockLinks sets the destinations of the deferred links drawn on the
+// specified page block.
+func (c *Creator) resolveBlockLinks(blk *Block, pageOffset int) {
+	for _, annotation := range blk._fg {
+		link, ok := annotation.GetContext().(*model.PdfAnnotationLink)
+		if !ok {
+			continue
+		}
+		dest, ok := core.GetArray(link.Dest)
+		if !ok || dest.Len() == 0 {
+			continue
+		}
+		marker, ok := dest.Get(0).(*core.PdfObjectInteger)
+		if !ok {
+			continue
+		}
+		resolve, ok := c.deferredLinks[marker]
+		if !ok {
+			continue
+		}
+
+		target, ok := resolve()
+		var pageObj *core.PdfIndirectObject
+		if ok {
+			pageObj = c.pageIndirect(target.page + pageOffset - 1)
+		}
+		if pageObj == nil {
+			common.Log.Debug("WARN: could not resolve link destination")
+			link.Dest = nil
+			continue
+		}
+		link.Dest = core.MakeArray(
+			pageObj,
+			core.MakeName("XYZ"),
+			core.MakeFloat(target.x),
+			core.MakeFloat(c._afdg-target.y),
+			core.MakeFloat(0),
+		)
+	}
+}
+
+// GenerateWithReferences creates a new document by calling the `build`
+// function, which adds the contents to the provided creator, and finalizes it.
+// If the text of the references depends on the final layout (e.g. references
+// to anchors drawn later in the document), the document is built again using
+// the anchor positions of the previous pass, until the references are stable.
+//
+// Note that `build` may be called several times, up to 3, with a new creator
+// on each pass. It must create all the document components on each call and
+// should not have other side effects, such as writing output or consuming
+// input which cannot be read again.
+func GenerateWithReferences(build func(c *Creator) error) (*Creator, error) {
+	var prev *Creator
+	for pass := 0; pass < maxLayoutPasses; pass++ {
+		c := New()
+		if prev != nil {
+			c.prevAnchors = prev.finalAnchors()
+			c.pageOffset = prev.pageOffset
+			c.prevPass = true
+		}
+
+		if err := build(c); err != nil {
+			return nil, err
+		}
+		if err := c.Finalize(); err != nil {
+			return nil, err
+		}
+		if c.referencesResolved() {
+			return c, nil
+		}
+		prev = c
+	}
+
+	common.Log.Debug("WARN: references not resolved after %d layout passes", maxLayoutPasses)
+	return prev, nil
+}
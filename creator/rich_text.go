@@ -0,0 +1,180 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/annotator"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// richTextFontKey identifies a font variant of a font family.
+type richTextFontKey struct {
+	family       string
+	bold, italic bool
+}
+
+// RichTextRenderer lays out rich text using styled paragraphs.
+// Implements the annotator.RichTextRenderer interface, and can be set as
+// the rich text renderer of annotator.FieldAppearance, or used with
+// annotator.GenerateFreeTextRichAppearance.
+//
+// The font families of the rich text are mapped to the standard 14 fonts
+// (Helvetica, Times and Courier), unless fonts are registered for them
+// using SetFont.
+type RichTextRenderer struct {
+	fonts map[richTextFontKey]*model.PdfFont
+}
+
+// NewRichTextRenderer returns a new rich text renderer.
+func NewRichTextRenderer() *RichTextRenderer {
+	return &RichTextRenderer{fonts: map[richTextFontKey]*model.PdfFont{}}
+}
+
+// SetFont sets the font used for the specified font family variant.
+func (r *RichTextRenderer) SetFont(family string, bold, italic bool, font *model.PdfFont) {
+	r.fonts[richTextFontKey{family: strings.ToLower(family), bold: bold, italic: italic}] = font
+}
+
+// standardFontFamily returns the standard 14 font names of the regular,
+// bold, italic and bold italic variants of the font family closest to the
+// specified one.
+func standardFontFamily(family string) [4]model.StdFontName {
+	family = strings.ToLower(family)
+	switch {
+	case strings.Contains(family, "times"), strings.Contains(family, "serif") && !strings.Contains(family, "sans"),
+		strings.Contains(family, "georgia"), strings.Contains(family, "minion"):
+		return [4]model.StdFontName{model.TimesRomanName, model.TimesBoldName, model.TimesItalicName, model.TimesBoldItalicName}
+	case strings.Contains(family, "courier"), strings.Contains(family, "mono"), strings.Contains(family, "consolas"):
+		return [4]model.StdFontName{model.CourierName, model.CourierBoldName, model.CourierObliqueName, model.CourierBoldObliqueName}
+	}
+	return [4]model.StdFontName{model.HelveticaName, model.HelveticaBoldName, model.HelveticaObliqueName, model.HelveticaBoldObliqueName}
+}
+
+// font returns the font of the rich text style.
+func (r *RichTextRenderer) font(style annotator.RichTextStyle) (*model.PdfFont, error) {
+	key := richTextFontKey{family: strings.ToLower(style.FontFamily), bold: style.Bold, italic: style.Italic}
+	if font, ok := r.fonts[key]; ok {
+		return font, nil
+	}
+
+	idx := 0
+	if style.Bold {
+		idx++
+	}
+	if style.Italic {
+		idx += 2
+	}
+	font, err := model.NewStandard14Font(standardFontFamily(style.FontFamily)[idx])
+	if err != nil {
+		return nil, err
+	}
+	r.fonts[key] = font
+	return font, nil
+}
+
+// textStyle returns the text style matching the rich text style.
+func (r *RichTextRenderer) textStyle(style annotator.RichTextStyle) (TextStyle, error) {
+	font, err := r.font(style)
+	if err != nil {
+		return TextStyle{}, err
+	}
+
+	ts := TextStyle{
+		Color:    ColorBlack,
+		Font:     font,
+		FontSize: style.FontSize,
+	}
+	if ts.FontSize <= 0 {
+		ts.FontSize = 12
+	}
+	if style.Color != nil {
+		ts.Color = ColorRGBFromArithmetic(style.Color.R(), style.Color.G(), style.Color.B())
+	}
+	return ts, nil
+}
+
+// RenderRichText lays out the paragraphs of the rich text within an area of
+// the specified size, and returns the content stream drawing them along
+// with the resources it uses. The text which does not fit in the area is
+// omitted.
+func (r *RichTextRenderer) RenderRichText(rt *annotator.RichText, width, height float64) ([]byte, *model.PdfPageResources, error) {
+	blk := NewBlock(width, height)
+	ctx := DrawContext{
+		Width:      width,
+		Height:     height,
+		PageWidth:  width,
+		PageHeight: height,
+	}
+
+	for _, para := range rt.Paragraphs {
+		p, err := r.paragraph(para)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		blocks, next, err := p.GeneratePageBlocks(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(blocks) > 0 {
+			if err := blk.mergeBlocks(blocks[0]); err != nil {
+				return nil, nil, err
+			}
+		}
+		if len(blocks) > 1 {
+			break
+		}
+		ctx = next
+	}
+	return blk._ae.Bytes(), blk._fd, nil
+}
+
+// paragraph returns the styled paragraph representing the rich text
+// paragraph.
+func (r *RichTextRenderer) paragraph(para *annotator.RichTextParagraph) (*StyledParagraph, error) {
+	base, err := r.textStyle(annotator.DefaultRichTextStyle())
+	if err != nil {
+		return nil, err
+	}
+	if len(para.Spans) > 0 {
+		if base, err = r.textStyle(para.Spans[0].Style); err != nil {
+			return nil, err
+		}
+	}
+
+	p := _ebge(base)
+	p.SetEnableWrap(true)
+	switch para.Alignment {
+	case annotator.RichTextAlignCenter:
+		p.SetTextAlignment(TextAlignmentCenter)
+	case annotator.RichTextAlignRight:
+		p.SetTextAlignment(TextAlignmentRight)
+	case annotator.RichTextAlignJustify:
+		p.SetTextAlignment(TextAlignmentJustify)
+	default:
+		p.SetTextAlignment(TextAlignmentLeft)
+	}
+
+	if len(para.Spans) == 0 {
+		// Blank line.
+		p.Append(" ").Style = base
+		return p, nil
+	}
+	for _, span := range para.Spans {
+		style, err := r.textStyle(span.Style)
+		if err != nil {
+			return nil, err
+		}
+		p.Append(span.Text).Style = style
+	}
+	return p, nil
+}
@@ -0,0 +1,235 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"errors"
+	"io"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// StartStreaming enables the streaming write mode, in which the pages are
+// written to `out` progressively, as FlushPages is called, instead of being
+// kept in memory until Write. Write completes the document on `out` (the
+// writer passed to Write is not used).
+//
+// The optimizer and the PdfWriter access function must be set before calling
+// StartStreaming. The front page, the table of contents and the index, which
+// need the complete document, are not supported in streaming mode. The
+// TotalPages field of the header and footer function arguments is 0, as the
+// number of pages is not known when the pages are flushed. Links to anchors
+// drawn on pages not flushed yet are removed.
+func (c *Creator) StartStreaming(out io.Writer) error {
+	if c.stream != nil {
+		return errors.New("streaming already started")
+	}
+	if c._effc != nil || c.AddTOC || c.AddIndex {
+		return errors.New("front page, table of contents and index are not supported in streaming mode")
+	}
+
+	w := model.NewPdfWriter()
+	w.SetOptimizer(c._ccce)
+	if c._gdea != nil {
+		if err := c._gdea(&w); err != nil {
+			common.Log.Debug("Failure: %v", err)
+			return err
+		}
+	}
+	if err := w.StartStreaming(out); err != nil {
+		return err
+	}
+	c.stream = &w
+	return nil
+}
+
+// FlushPages finalizes the pages drawn so far, except the current page which
+// can still receive content, and writes them to the output in streaming mode
+// (see StartStreaming). The flushed pages are released and can no longer be
+// modified.
+func (c *Creator) FlushPages() error {
+	if c.stream == nil {
+		return errors.New("streaming not started")
+	}
+	return c.flushStreamPages(len(c._ecfa) - 1)
+}
+
+// flushStreamPages finalizes the first `n` pages of the creator and writes
+// them to the output.
+func (c *Creator) flushStreamPages(n int) error {
+	if n <= 0 {
+		return nil
+	}
+	pages := c._ecfa[:n]
+	first := len(c.streamPages) + 1
+
+	// Draw the footnotes and resolve the links and form fields of the pages
+	// before the page blocks are drawn.
+	for i := range pages {
+		page := first + i
+		if notes, ok := c.footnotes[page]; ok {
+			if err := c.drawPageFootnotes(page, notes); err != nil {
+				return err
+			}
+			delete(c.footnotes, page)
+		}
+	}
+	if len(c.deferredLinks) > 0 {
+		for _, page := range pages {
+			if blk, ok := c._acg[page]; ok {
+				c.resolveBlockLinks(blk, 0)
+			}
+		}
+	}
+	if err := c.addPageFormFields(pages); err != nil {
+		return err
+	}
+
+	// Drawing the headers and footers changes the drawing context, which is
+	// restored for the current page.
+	active, ctx := c._dae, c._bbed
+	for i, page := range pages {
+		c.setActivePage(page)
+		if err := c.drawPageDecorations(page, first+i); err != nil {
+			return err
+		}
+		if err := c.stream.AddPage(page); err != nil {
+			common.Log.Debug("ERROR: failed to add page %d: %v", first+i, err)
+			return err
+		}
+		c.streamPages = append(c.streamPages, page.GetPageAsIndirectObject())
+		delete(c._acg, page)
+		delete(c._cgd, page)
+		if active == page {
+			active = nil
+		}
+	}
+	// The flushed pages are not kept in the backing array of the page slice.
+	c._ecfa = append([]*model.PdfPage(nil), c._ecfa[n:]...)
+	c._dae, c._bbed = active, ctx
+
+	return c.stream.FlushPages()
+}
+
+// drawPageDecorations draws the header, the footer and the contents of the
+// page with the specified number (1-based) in streaming mode.
+func (c *Creator) drawPageDecorations(page *model.PdfPage, num int) error {
+	if c._cbbb != nil {
+		blk := NewBlock(c._gacc, c._fgbg._egdb)
+		c._cbbb(blk, HeaderFunctionArgs{PageNum: num})
+		blk.SetPos(0, 0)
+		if err := c.Draw(blk); err != nil {
+			common.Log.Debug("ERROR: drawing header: %v", err)
+			return err
+		}
+	}
+	if c._dbg != nil {
+		blk := NewBlock(c._gacc, c._fgbg._daeg)
+		c._dbg(blk, FooterFunctionArgs{PageNum: num})
+		blk.SetPos(0, c._afdg-blk._gfc)
+		if err := c.Draw(blk); err != nil {
+			common.Log.Debug("ERROR: drawing footer: %v", err)
+			return err
+		}
+	}
+
+	blk, ok := c._acg[page]
+	if !ok {
+		return nil
+	}
+	if m, ok := c._cgd[page]; ok {
+		blk.transform(m)
+	}
+	if err := blk.drawToPage(page); err != nil {
+		common.Log.Debug("ERROR: drawing page %d blocks: %v", num, err)
+		return err
+	}
+	return nil
+}
+
+// finishStreaming writes the remaining pages and completes the document
+// written in streaming mode. It is called by Write.
+func (c *Creator) finishStreaming() error {
+	if err := c.flushStreamPages(len(c._ecfa)); err != nil {
+		return err
+	}
+	w := c.stream
+
+	if c._daca != nil {
+		if err := w.SetForms(c._daca); err != nil {
+			common.Log.Debug("Failure: %v", err)
+			return err
+		}
+	}
+	if c._bfaf != nil {
+		w.AddOutlineTree(c._bfaf)
+	} else if c._fae != nil && c.AddOutlines {
+		var resolve func(item *model.OutlineItem)
+		resolve = func(item *model.OutlineItem) {
+			if idx := int(item.Dest.Page); idx >= 0 && idx < len(c.streamPages) {
+				item.Dest.PageObj = c.streamPages[idx]
+			} else {
+				common.Log.Debug("WARN: could not get page container for page %d", idx)
+			}
+			item.Dest.Y = c._afdg - item.Dest.Y
+			for _, child := range item.Items() {
+				resolve(child)
+			}
+		}
+		for _, item := range c._fae.Items() {
+			resolve(item)
+		}
+		w.AddOutlineTree(&c._fae.ToPdfOutline().PdfOutlineTreeNode)
+	}
+	if c._abac != nil {
+		if err := w.SetPageLabels(c._abac); err != nil {
+			common.Log.Debug("ERROR: Could not set page labels: %v", err)
+			return err
+		}
+	}
+
+	// The fonts are written last, so that they can be subset.
+	for _, font := range c._efd {
+		if err := font.SubsetRegistered(); err != nil {
+			common.Log.Debug("ERROR: Could not subset font: %v", err)
+			return err
+		}
+	}
+	c._eccab = true
+	return w.Write(nil)
+}
+
+// pageIndirect returns the indirect object of the page with the specified
+// index, including the pages already written in streaming mode.
+func (c *Creator) pageIndirect(idx int) *core.PdfIndirectObject {
+	if idx < 0 {
+		return nil
+	}
+	if idx < len(c.streamPages) {
+		return c.streamPages[idx]
+	}
+	idx -= len(c.streamPages)
+	if idx >= len(c._ecfa) {
+		return nil
+	}
+	return c._ecfa[idx].GetPageAsIndirectObject()
+}
+
+// contentPage returns the page with the specified number (1-based), or nil if
+// the page does not exist or was already written in streaming mode.
+func (c *Creator) contentPage(page int) *model.PdfPage {
+	idx := page - 1 - len(c.streamPages)
+	if idx < 0 || idx >= len(c._ecfa) {
+		return nil
+	}
+	return c._ecfa[idx]
+}
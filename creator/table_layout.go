@@ -0,0 +1,760 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// tableGroupCell represents a cell drawn as part of a group of table rows.
+type tableGroupCell struct {
+	cell *TableCell
+
+	// Content drawn in the cell. It can be a fragment of the cell content,
+	// if the content is split across pages.
+	content VectorDrawable
+
+	// Offset of the cell row from the first row of the group.
+	row int
+}
+
+// tableRowGroup represents consecutive table rows which must be drawn on the
+// same page, as they are spanned by the same cells.
+type tableRowGroup struct {
+	// First table row of the group (starting at 1).
+	startRow int
+
+	heights []float64
+	cells   []tableGroupCell
+}
+
+// height returns the height of the group.
+func (g *tableRowGroup) height() float64 {
+	return g.rowsHeight(0, len(g.heights))
+}
+
+// rowsHeight returns the height of `count` rows of the group, starting at
+// offset `from`.
+func (g *tableRowGroup) rowsHeight(from, count int) float64 {
+	var height float64
+	for i := from; i < from+count && i < len(g.heights); i++ {
+		height += g.heights[i]
+	}
+	return height
+}
+
+// tableLayout holds the state of the table while it is drawn on page blocks.
+type tableLayout struct {
+	table *Table
+	ctx   DrawContext
+
+	blk    *Block
+	blocks []*Block
+
+	// Horizontal position and width of the table.
+	x, width float64
+
+	// Vertical position of the next row, along with the top and bottom
+	// limits of the table on the current page.
+	y, pageTop, bottom float64
+
+	// Number of row groups drawn on the current page, excluding the repeated
+	// header rows.
+	drawn int
+
+	header       []*tableRowGroup
+	footer       []*tableRowGroup
+	footerHeight float64
+
+	// Indexes of the table body rows, used for row striping.
+	bodyRows map[int]int
+}
+
+// GeneratePageBlocks generate the page blocks.  Multiple blocks are generated if the contents wrap
+// over multiple pages.
+// Implements the Drawable interface.
+func (table *Table) GeneratePageBlocks(ctx DrawContext) ([]*Block, DrawContext, error) {
+	origCtx := ctx
+	if table._bafe.isAbsolute() {
+		ctx.X = table._dagc
+		ctx.Y = table._degba
+	} else {
+		ctx.X += table._dbcbe._eagb
+		ctx.Y += table._dbcbe._egdb
+		ctx.Width -= table._dbcbe._eagb + table._dbcbe._ggbd
+		ctx.Height -= table._dbcbe._daeg + table._dbcbe._egdb
+	}
+
+	if table.autoFit {
+		table.fitColumnWidths(ctx.Width)
+	}
+	table.updateRowHeights(ctx.Width)
+
+	l := &tableLayout{
+		table:    table,
+		ctx:      ctx,
+		blk:      NewBlock(ctx.PageWidth, ctx.PageHeight),
+		x:        ctx.X,
+		width:    ctx.Width,
+		y:        ctx.Y,
+		pageTop:  ctx.Margins._egdb,
+		bottom:   ctx.PageHeight - ctx.Margins._daeg,
+		bodyRows: map[int]int{},
+	}
+	for row := 1; row <= table._dgfbg; row++ {
+		if !table.isHeaderRow(row) && !table.isFooterRow(row) {
+			l.bodyRows[row] = len(l.bodyRows)
+		}
+	}
+	if table._fbda {
+		l.header = table.rowGroups(table._bdfbd, table._cfcc)
+	}
+	if table.footer {
+		l.footer = table.rowGroups(table.footerStart, table.footerEnd)
+		for _, g := range l.footer {
+			l.footerHeight += g.height()
+		}
+	}
+
+	for _, g := range table.rowGroups(1, table._dgfbg) {
+		if table.isFooterRow(g.startRow) {
+			continue
+		}
+		if err := l.place(g); err != nil {
+			return nil, origCtx, err
+		}
+	}
+	for _, g := range l.footer {
+		l.drawGroup(g)
+	}
+	l.blocks = append(l.blocks, l.blk)
+
+	if table._bafe.isAbsolute() {
+		return l.blocks, origCtx, nil
+	}
+	ctx = l.ctx
+	ctx.X = origCtx.X
+	ctx.Width = origCtx.Width
+	ctx.Y = l.y + table._dbcbe._daeg
+	ctx.Height = l.bottom - ctx.Y
+	return l.blocks, ctx, nil
+}
+
+// place draws the row group, moving it or splitting it across pages if it
+// does not fit in the available space of the current page.
+func (l *tableLayout) place(g *tableRowGroup) error {
+	for g != nil {
+		avail := l.bottom - l.footerHeight - l.y
+		if g.height() <= avail {
+			l.drawGroup(g)
+			l.drawn++
+			return nil
+		}
+
+		// Split the content of the row, if row wrapping is enabled.
+		if l.table.rowWrap && len(g.heights) == 1 && !l.table.isHeaderRow(g.startRow) {
+			if first, rest, ok := l.splitGroup(g, avail); ok {
+				l.drawGroup(first)
+				l.drawn++
+				if g = rest; g != nil {
+					l.newPage(g)
+				}
+				continue
+			}
+		}
+
+		// Draw the group on the current page if it cannot fit anywhere.
+		canBreak := l.drawn > 0 || (len(l.blocks) == 0 && l.y > l.pageTop)
+		if !canBreak {
+			l.drawGroup(g)
+			l.drawn++
+			return nil
+		}
+		l.newPage(g)
+	}
+	return nil
+}
+
+// newPage continues the table on the next page. The footer rows are drawn
+// at the end of the current page and the header rows are repeated on the
+// new page, unless the next row group `next` is part of the header.
+func (l *tableLayout) newPage(next *tableRowGroup) {
+	for _, g := range l.footer {
+		l.drawGroup(g)
+	}
+
+	l.blocks = append(l.blocks, l.blk)
+	l.blk = NewBlock(l.ctx.PageWidth, l.ctx.PageHeight)
+	l.ctx.Page++
+	l.y = l.pageTop
+	l.drawn = 0
+
+	if l.table.isHeaderRow(next.startRow) {
+		return
+	}
+	for _, g := range l.header {
+		l.drawGroup(g)
+	}
+}
+
+// drawGroup draws the cells of the row group at the current position and
+// advances the position past the group.
+func (l *tableLayout) drawGroup(g *tableRowGroup) {
+	for _, gc := range g.cells {
+		cell := gc.cell
+
+		var x float64
+		for i := 0; i < cell._efcbc-1; i++ {
+			x += l.table._gbea[i] * l.width
+		}
+		y := l.y + g.rowsHeight(0, gc.row)
+		w := l.table.cellWidth(cell, l.width)
+		h := g.rowsHeight(gc.row, cell._gbbbd)
+
+		background := cell._dcdfb
+		if background == nil && len(l.table.stripeColors) > 0 {
+			if idx, ok := l.bodyRows[cell._aefe]; ok {
+				background = l.table.stripeColors[idx%len(l.table.stripeColors)]
+			}
+		}
+		l.drawCell(cell, gc.content, background, l.x+x, y, w, h)
+	}
+	l.y += g.height()
+}
+
+// drawCell draws the border, background and content of a table cell.
+func (l *tableLayout) drawCell(cell *TableCell, content VectorDrawable, background *model.PdfColorDeviceRGB, x, y, w, h float64) {
+	border := _adga(x, y, w, h)
+	if background != nil {
+		border.SetFillColor(ColorRGBFromArithmetic(background.R(), background.G(), background.B()))
+	}
+	border.LineStyle = cell._agaa
+	border._dded = cell._caged
+	border._aafe = cell._dgecf
+	border._aeb = cell._ecaa
+	border._gee = cell._gaeb
+	if cell._gdbg != nil {
+		border.SetColorLeft(ColorRGBFromArithmetic(cell._gdbg.R(), cell._gdbg.G(), cell._gdbg.B()))
+	}
+	if cell._bgag != nil {
+		border.SetColorBottom(ColorRGBFromArithmetic(cell._bgag.R(), cell._bgag.G(), cell._bgag.B()))
+	}
+	if cell._fegcg != nil {
+		border.SetColorRight(ColorRGBFromArithmetic(cell._fegcg.R(), cell._fegcg.G(), cell._fegcg.B()))
+	}
+	if cell._abaceg != nil {
+		border.SetColorTop(ColorRGBFromArithmetic(cell._abaceg.R(), cell._abaceg.G(), cell._abaceg.B()))
+	}
+	border.SetWidthBottom(cell._baggc)
+	border.SetWidthLeft(cell._bfce)
+	border.SetWidthRight(cell._efba)
+	border.SetWidthTop(cell._aaed)
+	if err := l.blk.Draw(border); err != nil {
+		common.Log.Debug("ERROR: %v", err)
+	}
+
+	if content == nil {
+		return
+	}
+
+	ctx := l.ctx
+	ctx.X = x
+	ctx.Y = y
+	ctx.Width = w
+	ctx.Height = l.bottom - y
+	ctx.footnoteSpace = false
+
+	contentWidth := content.Width()
+	contentHeight := content.Height()
+	offsetY := 0.0
+	switch t := content.(type) {
+	case *Paragraph:
+		if t._dgf {
+			contentWidth = t.getMaxLineWidth() / 1000.0
+		}
+	case *StyledParagraph:
+		if t._eab {
+			contentWidth = t.getMaxLineWidth() / 1000.0
+		}
+		lineHeight, maxLineHeight := t.getLineHeight(0)
+		if len(t._gcded) == 1 {
+			contentHeight = lineHeight
+		} else {
+			contentHeight = contentHeight - maxLineHeight + lineHeight
+		}
+		offsetY = lineHeight - maxLineHeight
+		switch cell._faad {
+		case CellVerticalAlignmentTop:
+			offsetY += lineHeight * 0.5
+		case CellVerticalAlignmentBottom:
+			offsetY -= lineHeight * 0.5
+		}
+	case *Table:
+		contentWidth = w
+	case *List:
+		contentWidth = w
+	case formFieldComponent:
+		if t.fieldBase().width <= 0 {
+			contentWidth = w
+		}
+	}
+
+	switch cell._fdab {
+	case CellHorizontalAlignmentLeft:
+		ctx.X += cell._fbbd
+		ctx.Width -= cell._fbbd
+	case CellHorizontalAlignmentCenter:
+		if diff := w - contentWidth; diff > 0 {
+			ctx.X += diff / 2
+			ctx.Width -= diff / 2
+		}
+	case CellHorizontalAlignmentRight:
+		if w > contentWidth {
+			ctx.X = ctx.X + w - contentWidth - cell._fbbd
+			ctx.Width -= cell._fbbd
+		}
+	}
+
+	ctx.Y += offsetY
+	switch cell._faad {
+	case CellVerticalAlignmentMiddle:
+		if diff := h - contentHeight; diff > 0 {
+			ctx.Y += diff / 2
+			ctx.Height -= diff / 2
+		}
+	case CellVerticalAlignmentBottom:
+		if h > contentHeight {
+			ctx.Y = ctx.Y + h - contentHeight
+			ctx.Height = h
+		}
+	}
+
+	if err := l.blk.DrawWithContext(content, ctx); err != nil {
+		common.Log.Debug("ERROR: %v", err)
+	}
+}
+
+// splitGroup splits the content of a single row group at the specified
+// height. Returns the group fitting in the height and the group containing
+// the rest of the content, which is nil if all the content fits.
+func (l *tableLayout) splitGroup(g *tableRowGroup, height float64) (*tableRowGroup, *tableRowGroup, bool) {
+	first := &tableRowGroup{startRow: g.startRow, heights: []float64{height}}
+	rest := &tableRowGroup{startRow: g.startRow}
+
+	var (
+		restHeight float64
+		progress   bool
+	)
+	for _, gc := range g.cells {
+		if gc.content == nil {
+			first.cells = append(first.cells, gc)
+			rest.cells = append(rest.cells, gc)
+			continue
+		}
+
+		width := l.table.cellWidth(gc.cell, l.width)
+		head, tail, ok := l.table.splitCellContent(gc.cell, gc.content, width, height)
+		if !ok {
+			return nil, nil, false
+		}
+		if head != nil {
+			progress = true
+		}
+		if tail != nil {
+			restHeight = math.Max(restHeight, l.table.cellContentHeight(gc.cell, tail, width))
+		}
+
+		first.cells = append(first.cells, tableGroupCell{cell: gc.cell, content: head})
+		rest.cells = append(rest.cells, tableGroupCell{cell: gc.cell, content: tail})
+	}
+	if !progress {
+		return nil, nil, false
+	}
+
+	if restHeight <= 0 {
+		return first, nil, true
+	}
+	rest.heights = []float64{restHeight}
+	return first, rest, true
+}
+
+// rowGroups returns the groups of rows between the specified rows
+// (inclusive). Rows spanned by the same cells are part of the same group.
+func (table *Table) rowGroups(startRow, endRow int) []*tableRowGroup {
+	if endRow > table._dgfbg {
+		endRow = table._dgfbg
+	}
+
+	cellsByRow := map[int][]*TableCell{}
+	for _, cell := range table._gggbg {
+		cellsByRow[cell._aefe] = append(cellsByRow[cell._aefe], cell)
+	}
+
+	var groups []*tableRowGroup
+	for row := startRow; row <= endRow; {
+		end := row
+		for r := row; r <= end; r++ {
+			for _, cell := range cellsByRow[r] {
+				if last := cell._aefe + cell._gbbbd - 1; last > end {
+					end = last
+				}
+			}
+		}
+		if end > endRow {
+			end = endRow
+		}
+
+		g := &tableRowGroup{startRow: row}
+		for r := row; r <= end; r++ {
+			g.heights = append(g.heights, table._gbcf[r-1])
+			for _, cell := range cellsByRow[r] {
+				g.cells = append(g.cells, tableGroupCell{cell: cell, content: cell._bcdac, row: r - row})
+			}
+		}
+		groups = append(groups, g)
+		row = end + 1
+	}
+	return groups
+}
+
+// isHeaderRow returns true if the specified row is a header row.
+func (table *Table) isHeaderRow(row int) bool {
+	return table._fbda && row >= table._bdfbd && row <= table._cfcc
+}
+
+// isFooterRow returns true if the specified row is a footer row.
+func (table *Table) isFooterRow(row int) bool {
+	return table.footer && row >= table.footerStart && row <= table.footerEnd
+}
+
+// cellWidth returns the width of the cell for the specified table width.
+func (table *Table) cellWidth(cell *TableCell, width float64) float64 {
+	var fraction float64
+	for i := 0; i < cell._dgdef; i++ {
+		fraction += table._gbea[cell._efcbc+i-1]
+	}
+	return fraction * width
+}
+
+// updateRowHeights increases the heights of the table rows so that the
+// content of the cells fits in them.
+func (table *Table) updateRowHeights(width float64) {
+	for _, cell := range table._gggbg {
+		if cell._bcdac == nil {
+			continue
+		}
+
+		lastRow := cell._aefe + cell._gbbbd - 1
+		if lastRow > table._dgfbg {
+			lastRow = table._dgfbg
+		}
+		var height float64
+		for row := cell._aefe; row <= lastRow; row++ {
+			height += table._gbcf[row-1]
+		}
+
+		needed := table.cellContentHeight(cell, cell._bcdac, table.cellWidth(cell, width))
+		if needed > height {
+			table._gbcf[lastRow-1] += needed - height
+		}
+	}
+}
+
+// cellContentHeight returns the height needed by the specified content
+// in a cell of the specified width.
+func (table *Table) cellContentHeight(cell *TableCell, content VectorDrawable, width float64) float64 {
+	switch t := content.(type) {
+	case *Paragraph:
+		if t._dgf {
+			t.SetWidth(width - cell._fbbd)
+		}
+		return t.Height() + t._ecccb._egdb + t._ecccb._daeg + 0.5*t._bgebc*t._eded
+	case *StyledParagraph:
+		if t._eab {
+			t.SetWidth(width - cell._fbbd)
+		}
+		return t.Height() + t._bbcf._egdb + t._bbcf._daeg + 0.5*t.getTextHeight()
+	case *Image:
+		return t.Height() + t._eaga._egdb + t._eaga._daeg
+	case *Table:
+		return t.Height() + t._dbcbe._egdb + t._dbcbe._daeg
+	case *List:
+		return t.tableHeight(width-cell._fbbd) + t._ecab._egdb + t._ecab._daeg
+	case *Division:
+		return t.Height() + t._edda._egdb + t._edda._daeg
+	case formFieldComponent:
+		return t.Height() + t.fieldBase().margins._egdb + t.fieldBase().margins._daeg
+	}
+	return 0
+}
+
+// splitCellContent splits the content of the cell so that the first part
+// fits in the specified height. Only the lines of wrapped paragraphs can be
+// split. Other types of content are either kept entirely in the first part
+// or, if they do not fit, the content cannot be split. The returned parts
+// are nil if they have no content.
+func (table *Table) splitCellContent(cell *TableCell, content VectorDrawable, width, height float64) (VectorDrawable, VectorDrawable, bool) {
+	fits := table.cellContentHeight(cell, content, width) <= height
+
+	switch t := content.(type) {
+	case *StyledParagraph:
+		if fits || !t._eab || !t._bddfc.isRelative() {
+			break
+		}
+
+		available := height - t._bbcf._egdb - 0.5*t.getTextHeight()
+		var (
+			fit         int
+			linesHeight float64
+		)
+		for _, line := range t._gcded {
+			var lineHeight float64
+			for _, chunk := range line {
+				lineHeight = math.Max(lineHeight, chunk.Style.FontSize*t._eadg)
+			}
+			if linesHeight+lineHeight > available {
+				break
+			}
+			linesHeight += lineHeight
+			fit++
+		}
+		if fit == 0 {
+			return nil, content, true
+		}
+
+		head := t.lineFragment(t._gcded[:fit])
+		head._bbcf._daeg = 0
+		tail := t.lineFragment(t._gcded[fit:])
+		tail._bbcf._egdb = 0
+		return head, tail, true
+	case *Paragraph:
+		if fits || !t._dgf || !t._efcfe.isRelative() {
+			break
+		}
+
+		lineHeight := t._bgebc * t._eded
+		fit := int((height - t._ecccb._egdb - 0.5*lineHeight) / lineHeight)
+		if fit <= 0 {
+			return nil, content, true
+		}
+		if fit >= len(t._dced) {
+			return content, nil, true
+		}
+
+		head := *t
+		head._fccag = strings.Join(t._dced[:fit], "\n")
+		head._dced = nil
+		head._ecccb._daeg = 0
+		tail := *t
+		tail._fccag = strings.Join(t._dced[fit:], "\n")
+		tail._dced = nil
+		tail._ecccb._egdb = 0
+		return &head, &tail, true
+	}
+
+	if !fits {
+		return nil, nil, false
+	}
+	return content, nil, true
+}
+
+// lineFragment returns a copy of the paragraph containing only the
+// specified wrapped lines.
+func (p *StyledParagraph) lineFragment(lines [][]*TextChunk) *StyledParagraph {
+	frag := *p
+	frag._dcfef = nil
+	frag._gcded = nil
+
+	for i, line := range lines {
+		style := p._cged
+		for _, chunk := range line {
+			frag._dcfef = append(frag._dcfef, &TextChunk{
+				Text:         chunk.Text,
+				Style:        chunk.Style,
+				_abdd:        copyChunkAnnotation(chunk._abdd),
+				indexEntries: chunk.indexEntries,
+			})
+			style = chunk.Style
+		}
+		if i < len(lines)-1 {
+			frag._dcfef = append(frag._dcfef, &TextChunk{Text: "\n", Style: style})
+		}
+	}
+	return &frag
+}
+
+// fitColumnWidths computes the widths of the table columns based on the
+// measured widths of the cell contents. The columns receive at least the
+// width of their longest word and, if there is enough space, the width
+// of their longest unwrapped line. The remaining space is distributed
+// among the columns.
+func (table *Table) fitColumnWidths(width float64) {
+	if width <= 0 {
+		return
+	}
+
+	cols := table._gccf
+	mins := make([]float64, cols)
+	maxs := make([]float64, cols)
+	measured := make([]bool, cols)
+
+	// Measure the cells spanning a single column first, then widen the
+	// columns spanned by the other cells, if needed.
+	for _, multi := range []bool{false, true} {
+		for _, cell := range table._gggbg {
+			if cell._bcdac == nil || (cell._dgdef > 1) != multi {
+				continue
+			}
+			minWidth, maxWidth, ok := measureContentWidth(cell._bcdac)
+			if !ok {
+				continue
+			}
+			minWidth += cell._fbbd + 0.01
+			maxWidth += cell._fbbd + 0.01
+
+			start, end := cell._efcbc-1, cell._efcbc-1+cell._dgdef
+			var spannedMin, spannedMax float64
+			for i := start; i < end; i++ {
+				spannedMin += mins[i]
+				spannedMax += maxs[i]
+				measured[i] = true
+			}
+			for i := start; i < end; i++ {
+				if minWidth > spannedMin {
+					mins[i] += (minWidth - spannedMin) / float64(cell._dgdef)
+				}
+				if maxWidth > spannedMax {
+					maxs[i] += (maxWidth - spannedMax) / float64(cell._dgdef)
+				}
+			}
+		}
+	}
+
+	var sumMin, sumMax float64
+	var flexible int
+	for i := 0; i < cols; i++ {
+		sumMin += mins[i]
+		sumMax += maxs[i]
+		if !measured[i] {
+			flexible++
+		}
+	}
+
+	widths := make([]float64, cols)
+	switch {
+	case sumMax <= width:
+		// The remaining space goes to the columns which could not be measured
+		// or, if there are none, is distributed proportionally.
+		extra := width - sumMax
+		for i := 0; i < cols; i++ {
+			widths[i] = maxs[i]
+			switch {
+			case flexible > 0:
+				if !measured[i] {
+					widths[i] += extra / float64(flexible)
+				}
+			case sumMax > 0:
+				widths[i] += extra * maxs[i] / sumMax
+			default:
+				widths[i] += extra / float64(cols)
+			}
+		}
+	case sumMin <= width:
+		ratio := (width - sumMin) / (sumMax - sumMin)
+		for i := 0; i < cols; i++ {
+			widths[i] = mins[i] + (maxs[i]-mins[i])*ratio
+		}
+	default:
+		for i := 0; i < cols; i++ {
+			widths[i] = mins[i] * width / sumMin
+		}
+	}
+
+	table._gbea = make([]float64, cols)
+	for i, w := range widths {
+		table._gbea[i] = w / width
+	}
+}
+
+// measureContentWidth returns the minimum width (the width of the longest
+// word) and the preferred width (the width of the longest line) of the
+// content. Returns false if the content width cannot be measured.
+func measureContentWidth(content VectorDrawable) (float64, float64, bool) {
+	var (
+		minWidth, maxWidth float64
+		horizontal         float64
+	)
+	switch t := content.(type) {
+	case *Paragraph:
+		chunk := &TextChunk{Text: t._fccag, Style: TextStyle{Font: t._abcd, FontSize: t._bgebc}}
+		minWidth, maxWidth = measureTextWidth([]*TextChunk{chunk})
+		horizontal = t._ecccb._eagb + t._ecccb._ggbd
+	case *StyledParagraph:
+		minWidth, maxWidth = measureTextWidth(splitChunksByFont(t._dcfef))
+		horizontal = t._bbcf._eagb + t._bbcf._ggbd
+	case *Image:
+		minWidth = t.Width()
+		maxWidth = minWidth
+		horizontal = t._eaga._eagb + t._eaga._ggbd
+	case formFieldComponent:
+		if t.Width() <= 0 {
+			return 0, 0, false
+		}
+		minWidth = t.Width()
+		maxWidth = minWidth
+		horizontal = t.fieldBase().margins._eagb + t.fieldBase().margins._ggbd
+	default:
+		return 0, 0, false
+	}
+	return minWidth + horizontal, maxWidth + horizontal, true
+}
+
+// measureTextWidth returns the width of the longest word and the width of
+// the longest line of the specified text chunks.
+func measureTextWidth(chunks []*TextChunk) (float64, float64) {
+	var minWidth, maxWidth, lineWidth, wordWidth float64
+	for _, chunk := range chunks {
+		style := &chunk.Style
+		if style.Font == nil {
+			continue
+		}
+
+		for _, r := range chunk.Text {
+			if r == '\n' {
+				maxWidth = math.Max(maxWidth, lineWidth)
+				lineWidth = 0
+				wordWidth = 0
+				continue
+			}
+
+			metrics, ok := style.Font.GetRuneMetrics(r)
+			if !ok {
+				continue
+			}
+			w := style.FontSize * metrics.Wx / 1000.0
+			if unicode.IsSpace(r) {
+				lineWidth += w
+				wordWidth = 0
+				continue
+			}
+
+			w += style.CharSpacing
+			lineWidth += w
+			wordWidth += w
+			minWidth = math.Max(minWidth, wordWidth)
+		}
+	}
+	return minWidth, math.Max(maxWidth, lineWidth)
+}
@@ -0,0 +1,161 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package creator
+
+import (
+	"errors"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// MultiCell creates a new cell spanning the specified number of rows and
+// columns. The table positions covered by the cell are skipped when adding
+// the cells of the next rows.
+func (table *Table) MultiCell(rowspan, colspan int) *TableCell {
+	cell := table.newCell(colspan)
+	if rowspan < 1 {
+		common.Log.Debug("Table: cell rowspan less than 1 (%d). Setting cell rowspan to 1.", rowspan)
+		rowspan = 1
+	}
+	cell._gbbbd = rowspan
+
+	// Add the rows spanned by the cell.
+	for cell._aefe+rowspan-1 > table._dgfbg {
+		table._dgfbg++
+		table._gbcf = append(table._gbcf, table._abbg)
+	}
+
+	// Mark the positions covered by the cell in the next rows.
+	if rowspan > 1 {
+		if table.covered == nil {
+			table.covered = map[int]bool{}
+		}
+		for row := cell._aefe; row < cell._aefe+rowspan-1; row++ {
+			for col := cell._efcbc - 1; col < cell._efcbc-1+cell._dgdef; col++ {
+				table.covered[row*table._gccf+col] = true
+			}
+		}
+	}
+	return cell
+}
+
+// MultiRowCell creates a new cell spanning the specified number of rows.
+func (table *Table) MultiRowCell(rowspan int) *TableCell {
+	return table.MultiCell(rowspan, 1)
+}
+
+// SetFooterRows turns the selected table rows into footer rows.
+// The footer rows are drawn at the end of the table and, if the table
+// wraps over multiple pages, at the bottom of the table on each page.
+func (table *Table) SetFooterRows(startRow, endRow int) error {
+	if startRow <= 0 {
+		return errors.New("footer start row must be greater than 0")
+	}
+	if endRow <= 0 {
+		return errors.New("footer end row must be greater than 0")
+	}
+	if startRow > endRow {
+		return errors.New("footer start row must be less than or equal to the end row")
+	}
+
+	table.footer = true
+	table.footerStart = startRow
+	table.footerEnd = endRow
+	return nil
+}
+
+// EnableRowWrap sets whether the content of the table rows can be split
+// across pages. When enabled, the paragraphs of a row which does not fit in
+// the available page space are split and continue on the next page.
+// Otherwise, the row is moved to the next page.
+func (table *Table) EnableRowWrap(enable bool) {
+	table.rowWrap = enable
+}
+
+// EnableAutoFitColumns sets whether the widths of the table columns are
+// computed based on the measured width of the cell contents, when the table
+// is drawn. The column widths set using SetColumnWidths are ignored when
+// enabled.
+func (table *Table) EnableAutoFitColumns(enable bool) {
+	table.autoFit = enable
+}
+
+// SetRowStripeColors sets the background colors alternated between the
+// consecutive rows of the table body (zebra striping). The header and footer
+// rows, along with cells having a background color, are not affected.
+// Calling the method without any colors disables striping.
+func (table *Table) SetRowStripeColors(colors ...Color) {
+	table.stripeColors = nil
+	for _, col := range colors {
+		table.stripeColors = append(table.stripeColors, model.NewPdfColorDeviceRGB(col.ToRGB()))
+	}
+}
+
+// SetColumnHorizontalAlignment sets the default horizontal alignment of the
+// cells of the specified column (starting at 1). The alignment is applied to
+// the existing cells of the column and to the cells created afterwards.
+func (table *Table) SetColumnHorizontalAlignment(col int, halign CellHorizontalAlignment) error {
+	if col < 1 || col > table._gccf {
+		return errors.New("range check error")
+	}
+	if table.columnHAlign == nil {
+		table.columnHAlign = map[int]CellHorizontalAlignment{}
+	}
+	table.columnHAlign[col] = halign
+
+	for _, cell := range table._gggbg {
+		if cell._efcbc == col {
+			cell._fdab = halign
+		}
+	}
+	return nil
+}
+
+// SetColumnVerticalAlignment sets the default vertical alignment of the
+// cells of the specified column (starting at 1). The alignment is applied to
+// the existing cells of the column and to the cells created afterwards.
+func (table *Table) SetColumnVerticalAlignment(col int, valign CellVerticalAlignment) error {
+	if col < 1 || col > table._gccf {
+		return errors.New("range check error")
+	}
+	if table.columnVAlign == nil {
+		table.columnVAlign = map[int]CellVerticalAlignment{}
+	}
+	table.columnVAlign[col] = valign
+
+	for _, cell := range table._gggbg {
+		if cell._efcbc == col {
+			cell._faad = valign
+		}
+	}
+	return nil
+}
+
+// applyColumnAlignment applies the default alignment of the cell column to
+// the specified cell.
+func (table *Table) applyColumnAlignment(cell *TableCell) {
+	if halign, ok := table.columnHAlign[cell._efcbc]; ok {
+		cell._fdab = halign
+	}
+	if valign, ok := table.columnVAlign[cell._efcbc]; ok {
+		cell._faad = valign
+	}
+}
+
+// Rowspan returns the number of rows spanned by the cell.
+func (cell *TableCell) Rowspan() int {
+	return cell._gbbbd
+}
+
+// Colspan returns the number of columns spanned by the cell.
+func (cell *TableCell) Colspan() int {
+	return cell._dgdef
+}
@@ -0,0 +1,96 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package extractor
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// ProcessPages creates an Extractor for each page of `reader` and calls `fn` with it, processing
+// the pages concurrently in `workers` goroutines (runtime.NumCPU() if `workers` is not
+// positive). `fn` is called with the 1-based page number and is typically used to extract the
+// text of the page, e.g.
+//
+//	texts := make([]string, numPages)
+//	err := extractor.ProcessPages(reader, 0, func(pageNum int, e *extractor.Extractor) error {
+//		text, err := e.ExtractText()
+//		texts[pageNum-1] = text
+//		return err
+//	})
+//
+// The objects of the document are loaded by the parser of the reader, which is safe for
+// concurrent use, so that `reader` can be created with model.NewPdfReaderLazy and a limited
+// object cache (see model.ReaderOpts) to process huge documents. An Extractor must not be shared
+// between goroutines, and the pages and the other objects of `reader` must not be modified
+// while the pages are processed.
+//
+// The pages are processed in order of the page numbers, but `fn` is called concurrently. When
+// an error occurs, the remaining pages are not processed and the first error is returned.
+func ProcessPages(reader *model.PdfReader, workers int, fn func(pageNum int, e *Extractor) error) error {
+	numPages, err := reader.GetNumPages()
+	if err != nil {
+		return err
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > numPages {
+		workers = numPages
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	pageNums := make(chan int)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pageNum := range pageNums {
+				if err := processPage(reader, pageNum, fn); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for pageNum := 1; pageNum <= numPages && !failed(); pageNum++ {
+		pageNums <- pageNum
+	}
+	close(pageNums)
+	wg.Wait()
+	return firstErr
+}
+
+// processPage calls `fn` with the extractor of the page `pageNum` of `reader`.
+func processPage(reader *model.PdfReader, pageNum int, fn func(pageNum int, e *Extractor) error) error {
+	page, err := reader.GetPage(pageNum)
+	if err != nil {
+		return err
+	}
+	e, err := New(page)
+	if err != nil {
+		return err
+	}
+	return fn(pageNum, e)
+}
@@ -0,0 +1,349 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package fdf
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/annotator"
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/contentstream/draw"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/internal/annotutil"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// generateAppearance generates the appearance of the imported annotation
+// using the annotation generators of the annotator package, and sets its
+// rectangle to the bounding box of the appearance. Annotations which cannot
+// be generated are left without appearance.
+func generateAppearance(annot *model.PdfAnnotation) {
+	gen, err := appearanceAnnotation(annot)
+	if err != nil {
+		common.Log.Debug("Unable to generate annotation appearance: %v", err)
+		return
+	}
+	if gen == nil {
+		return
+	}
+	annot.AP = gen.AP
+	annot.Rect = gen.Rect
+}
+
+// appearanceAnnotation returns an annotation generated by the annotator
+// package with the properties of `annot`. Returns nil if the annotation
+// type is not supported or lacks the properties required for generating
+// its appearance.
+func appearanceAnnotation(annot *model.PdfAnnotation) (*model.PdfAnnotation, error) {
+	rect, ok := annotationRect(annot)
+	if !ok {
+		return nil, nil
+	}
+	markup := annotutil.Markup(annot)
+	opacity := 1.0
+	if ca, err := core.GetNumberAsFloat(markup.CA); err == nil {
+		opacity = ca
+	}
+	color := rgbColor(annot.C)
+	x, y, width, height := rect.Llx, rect.Lly, rect.Width(), rect.Height()
+
+	switch t := annot.GetContext().(type) {
+	case *model.PdfAnnotationText:
+		name, _ := core.GetName(t.Name)
+		open, _ := core.GetBoolVal(t.Open)
+		return annotator.CreateTextAnnotation(annotator.TextAnnotationDef{
+			X: x, Y: y, Width: width, Height: height,
+			Icon:    annotator.TextIcon(nameString(name)),
+			Color:   color,
+			Opacity: opacity,
+			Open:    open,
+		})
+	case *model.PdfAnnotationHighlight:
+		return textMarkupAppearance(t.QuadPoints, color, opacity, annotator.CreateHighlightAnnotation)
+	case *model.PdfAnnotationUnderline:
+		return textMarkupAppearance(t.QuadPoints, color, opacity, annotator.CreateUnderlineAnnotation)
+	case *model.PdfAnnotationStrikeOut:
+		return textMarkupAppearance(t.QuadPoints, color, opacity, annotator.CreateStrikeOutAnnotation)
+	case *model.PdfAnnotationSquiggly:
+		return textMarkupAppearance(t.QuadPoints, color, opacity, annotator.CreateSquigglyAnnotation)
+	case *model.PdfAnnotationInk:
+		var paths [][]draw.Point
+		if inkList, ok := core.GetArray(t.InkList); ok {
+			for _, path := range inkList.Elements() {
+				if points := arrayPoints(path); len(points) > 0 {
+					paths = append(paths, points)
+				}
+			}
+		}
+		if len(paths) == 0 {
+			return nil, nil
+		}
+		return annotator.CreateInkAnnotation(annotator.InkAnnotationDef{
+			Paths:     paths,
+			LineColor: color,
+			LineWidth: borderWidth(t.BS),
+			Opacity:   opacity,
+		})
+	case *model.PdfAnnotationPolygon:
+		vertices := arrayPoints(t.Vertices)
+		if len(vertices) < 3 {
+			return nil, nil
+		}
+		fill := rgbColor(t.IC)
+		return annotator.CreatePolygonAnnotation(annotator.PolygonAnnotationDef{
+			Vertices:      vertices,
+			FillEnabled:   fill != nil,
+			FillColor:     fill,
+			BorderEnabled: color != nil,
+			BorderWidth:   borderWidth(t.BS),
+			BorderColor:   color,
+			Opacity:       opacity,
+		})
+	case *model.PdfAnnotationPolyLine:
+		points := arrayPoints(t.Vertices)
+		if len(points) < 2 {
+			return nil, nil
+		}
+		head, tail := lineEndingStyles(t.LE)
+		return annotator.CreatePolyLineAnnotation(annotator.PolyLineAnnotationDef{
+			Points:           points,
+			LineColor:        color,
+			LineWidth:        borderWidth(t.BS),
+			Opacity:          opacity,
+			LineEndingStyle1: head,
+			LineEndingStyle2: tail,
+		})
+	case *model.PdfAnnotationLine:
+		points := arrayPoints(t.L)
+		if len(points) != 2 {
+			return nil, nil
+		}
+		if color == nil {
+			color = model.NewPdfColorDeviceRGB(0, 0, 0)
+		}
+		head, tail := lineEndingStyles(t.LE)
+		return annotator.CreateLineAnnotation(annotator.LineAnnotationDef{
+			X1: points[0].X, Y1: points[0].Y, X2: points[1].X, Y2: points[1].Y,
+			LineColor:        color,
+			Opacity:          opacity,
+			LineWidth:        borderWidth(t.BS),
+			LineEndingStyle1: head,
+			LineEndingStyle2: tail,
+		})
+	case *model.PdfAnnotationSquare:
+		fill := rgbColor(t.IC)
+		return annotator.CreateRectangleAnnotation(annotator.RectangleAnnotationDef{
+			X: x, Y: y, Width: width, Height: height,
+			FillEnabled:   fill != nil,
+			FillColor:     fill,
+			BorderEnabled: color != nil,
+			BorderWidth:   borderWidth(t.BS),
+			BorderColor:   color,
+			Opacity:       opacity,
+		})
+	case *model.PdfAnnotationCircle:
+		fill := rgbColor(t.IC)
+		return annotator.CreateCircleAnnotation(annotator.CircleAnnotationDef{
+			X: x, Y: y, Width: width, Height: height,
+			FillEnabled:   fill != nil,
+			FillColor:     fill,
+			BorderEnabled: color != nil,
+			BorderWidth:   borderWidth(t.BS),
+			BorderColor:   color,
+			Opacity:       opacity,
+		})
+	case *model.PdfAnnotationFreeText:
+		def := annotator.FreeTextAnnotationDef{
+			X: x, Y: y, Width: width, Height: height,
+			FillEnabled: color != nil,
+			FillColor:   color,
+			Opacity:     opacity,
+		}
+		if contents, ok := core.GetString(annot.Contents); ok {
+			def.Text = contents.Decoded()
+		}
+		if da, ok := core.GetString(t.DA); ok {
+			def.FontSize, def.TextColor = parseDefaultAppearance(da.Decoded())
+		}
+		switch q, _ := core.GetIntVal(t.Q); q {
+		case 1:
+			def.Alignment = annotator.RichTextAlignCenter
+		case 2:
+			def.Alignment = annotator.RichTextAlignRight
+		}
+		if w := borderWidth(t.BS); w > 0 {
+			def.BorderEnabled = true
+			def.BorderWidth = w
+			def.BorderColor = model.NewPdfColorDeviceRGB(0, 0, 0)
+		}
+		return annotator.CreateFreeTextAnnotation(def)
+	case *model.PdfAnnotationStamp:
+		name, _ := core.GetName(t.Name)
+		return annotator.CreateStampAnnotation(annotator.StampAnnotationDef{
+			X: x, Y: y, Width: width, Height: height,
+			Name:    annotator.StampName(nameString(name)),
+			Color:   color,
+			Opacity: opacity,
+		})
+	case *model.PdfAnnotationCaret:
+		return annotator.CreateCaretAnnotation(annotator.CaretAnnotationDef{
+			X: x, Y: y, Width: width, Height: height,
+			Color:   color,
+			Opacity: opacity,
+		})
+	}
+	return nil, nil
+}
+
+// textMarkupAppearance returns the text markup annotation generated by
+// `create`, covering the bounding boxes of the quadrilaterals.
+func textMarkupAppearance(quadPoints core.PdfObject, color *model.PdfColorDeviceRGB, opacity float64,
+	create func(annotator.TextMarkupAnnotationDef) (*model.PdfAnnotation, error)) (*model.PdfAnnotation, error) {
+	points := arrayPoints(quadPoints)
+	var rects []model.PdfRectangle
+	for i := 0; i+4 <= len(points); i += 4 {
+		r := model.PdfRectangle{Llx: points[i].X, Lly: points[i].Y, Urx: points[i].X, Ury: points[i].Y}
+		for _, p := range points[i+1 : i+4] {
+			if p.X < r.Llx {
+				r.Llx = p.X
+			}
+			if p.X > r.Urx {
+				r.Urx = p.X
+			}
+			if p.Y < r.Lly {
+				r.Lly = p.Y
+			}
+			if p.Y > r.Ury {
+				r.Ury = p.Y
+			}
+		}
+		rects = append(rects, r)
+	}
+	if len(rects) == 0 {
+		return nil, nil
+	}
+	return create(annotator.TextMarkupAnnotationDef{Rects: rects, Color: color, Opacity: opacity})
+}
+
+// annotationRect returns the rectangle of the annotation.
+func annotationRect(annot *model.PdfAnnotation) (*model.PdfRectangle, bool) {
+	arr, ok := core.GetArray(annot.Rect)
+	if !ok {
+		return nil, false
+	}
+	rect, err := model.NewPdfRectangle(*arr)
+	if err != nil {
+		return nil, false
+	}
+	return rect, true
+}
+
+// rgbColor returns the RGB color of the color array, or nil if the array is
+// empty or invalid.
+func rgbColor(obj core.PdfObject) *model.PdfColorDeviceRGB {
+	arr, ok := core.GetArray(obj)
+	if !ok {
+		return nil
+	}
+	vals, err := arr.ToFloat64Array()
+	if err != nil {
+		return nil
+	}
+	switch len(vals) {
+	case 1:
+		return model.NewPdfColorDeviceRGB(vals[0], vals[0], vals[0])
+	case 3:
+		return model.NewPdfColorDeviceRGB(vals[0], vals[1], vals[2])
+	case 4:
+		k := vals[3]
+		return model.NewPdfColorDeviceRGB((1-vals[0])*(1-k), (1-vals[1])*(1-k), (1-vals[2])*(1-k))
+	}
+	return nil
+}
+
+// arrayPoints returns the points of the flat array of coordinates.
+func arrayPoints(obj core.PdfObject) []draw.Point {
+	arr, ok := core.GetArray(obj)
+	if !ok {
+		return nil
+	}
+	vals, err := arr.ToFloat64Array()
+	if err != nil {
+		return nil
+	}
+	var points []draw.Point
+	for i := 0; i+1 < len(vals); i += 2 {
+		points = append(points, draw.Point{X: vals[i], Y: vals[i+1]})
+	}
+	return points
+}
+
+// borderWidth returns the width of the border style dictionary (BS), which
+// defaults to 1.
+func borderWidth(bs core.PdfObject) float64 {
+	if dict, ok := core.GetDict(bs); ok {
+		if w, err := core.GetNumberAsFloat(dict.Get("W")); err == nil {
+			return w
+		}
+	}
+	return 1
+}
+
+// lineEndingStyles returns the drawing styles closest to the line ending
+// styles array (LE).
+func lineEndingStyles(le core.PdfObject) (draw.LineEndingStyle, draw.LineEndingStyle) {
+	style := func(obj core.PdfObject) draw.LineEndingStyle {
+		name, _ := core.GetName(obj)
+		switch s := nameString(name); {
+		case strings.HasSuffix(s, "Arrow"):
+			return draw.LineEndingStyleArrow
+		case s == "Butt":
+			return draw.LineEndingStyleButt
+		}
+		return draw.LineEndingStyleNone
+	}
+	arr, ok := core.GetArray(le)
+	if !ok || arr.Len() != 2 {
+		return draw.LineEndingStyleNone, draw.LineEndingStyleNone
+	}
+	return style(arr.Get(0)), style(arr.Get(1))
+}
+
+// nameString returns the value of the name, or an empty string if nil.
+func nameString(name *core.PdfObjectName) string {
+	if name == nil {
+		return ""
+	}
+	return name.String()
+}
+
+var (
+	daFontSizeRegexp = regexp.MustCompile(`([0-9.]+)\s+Tf`)
+	daColorRegexp    = regexp.MustCompile(`([0-9.]+)\s+([0-9.]+)\s+([0-9.]+)\s+rg`)
+)
+
+// parseDefaultAppearance returns the font size and the RGB text color set
+// by the default appearance string (DA), or zero values if not set.
+func parseDefaultAppearance(da string) (float64, *model.PdfColorDeviceRGB) {
+	var size float64
+	if m := daFontSizeRegexp.FindStringSubmatch(da); m != nil {
+		size, _ = strconv.ParseFloat(m[1], 64)
+	}
+	var color *model.PdfColorDeviceRGB
+	if m := daColorRegexp.FindStringSubmatch(da); m != nil {
+		r, _ := strconv.ParseFloat(m[1], 64)
+		g, _ := strconv.ParseFloat(m[2], 64)
+		b, _ := strconv.ParseFloat(m[3], 64)
+		color = model.NewPdfColorDeviceRGB(r, g, b)
+	}
+	return size, color
+}
@@ -0,0 +1,492 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package fdf
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/internal/annotutil"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// AnnotationExportOptions contains options for exporting annotations.
+type AnnotationExportOptions struct {
+	// File is the path or URL of the PDF document, written in the XFDF f
+	// element. Omitted if empty.
+	File string
+}
+
+// xfdfAnnots represents the annots element of an XFDF document.
+type xfdfAnnots struct {
+	Annots []*xfdfAnnot `xml:",any"`
+}
+
+// xfdfAnnot represents an annotation element of an XFDF document. The name
+// of the element is the lower case subtype of the annotation (e.g.
+// highlight).
+type xfdfAnnot struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Contents *string       `xml:"contents"`
+	RichText *xfdfRichText `xml:"contents-richtext"`
+	DA       string        `xml:"defaultappearance,omitempty"`
+	DS       string        `xml:"defaultstyle,omitempty"`
+	Popup    *xfdfPopup    `xml:"popup"`
+	InkList  *xfdfInkList  `xml:"inklist"`
+	Vertices string        `xml:"vertices,omitempty"`
+}
+
+// xfdfInkList represents the inklist element of an ink annotation, containing
+// the points of each path.
+type xfdfInkList struct {
+	Gestures []string `xml:"gesture"`
+}
+
+// xfdfRichText represents the contents-richtext element of an annotation,
+// which contains the XHTML rich text of the annotation as is.
+type xfdfRichText struct {
+	Content string `xml:",innerxml"`
+}
+
+// xfdfPopup represents the popup element of an annotation.
+type xfdfPopup struct {
+	Attrs []xml.Attr `xml:",any,attr"`
+}
+
+// xfdfAnnotationTypes maps the XFDF element names to the annotation subtypes
+// supported for export and import.
+var xfdfAnnotationTypes = map[string]string{
+	"text":      "Text",
+	"highlight": "Highlight",
+	"underline": "Underline",
+	"strikeout": "StrikeOut",
+	"squiggly":  "Squiggly",
+	"ink":       "Ink",
+	"polygon":   "Polygon",
+	"polyline":  "PolyLine",
+	"line":      "Line",
+	"square":    "Square",
+	"circle":    "Circle",
+	"freetext":  "FreeText",
+	"stamp":     "Stamp",
+	"caret":     "Caret",
+}
+
+// annotationFlagNames are the XFDF names of the annotation flags, by bit
+// position.
+var annotationFlagNames = []string{
+	"invisible", "hidden", "print", "nozoom", "norotate", "noview",
+	"readonly", "locked", "togglenoview", "lockedcontents",
+}
+
+// WriteAnnotationsXFDF writes the markup annotations of the pages to `w` as
+// an XFDF document. The page numbers of the XFDF document are the indices
+// of the pages in `pages`, starting from 0. Popup annotations are written
+// along with the annotations they belong to, and replies reference the
+// annotations they reply to by name. Annotations without a name (NM) are
+// assigned one. Link, widget and other non-markup annotations are skipped.
+func WriteAnnotationsXFDF(w io.Writer, pages []*model.PdfPage, opts *AnnotationExportOptions) error {
+	if opts == nil {
+		opts = &AnnotationExportOptions{}
+	}
+
+	// Name the annotations first, as replies can precede the annotations
+	// they reply to.
+	type pageAnnot struct {
+		page  int
+		annot *model.PdfAnnotation
+	}
+	var annots []pageAnnot
+	names := map[interface{}]string{}
+	for i, page := range pages {
+		pageAnnots, err := page.GetAnnotations()
+		if err != nil {
+			return err
+		}
+		for j, annot := range pageAnnots {
+			if _, ok := xfdfAnnotationElement(annot); !ok {
+				continue
+			}
+			name, ok := core.GetStringVal(annot.NM)
+			if !ok || name == "" {
+				name = fmt.Sprintf("annot-%d-%d", i, j)
+			}
+			names[annotutil.ObjectKey(annot.GetContainingPdfObject())] = name
+			annots = append(annots, pageAnnot{page: i, annot: annot})
+		}
+	}
+
+	doc := &xfdfDocument{
+		Xmlns:  xfdfNamespace,
+		Space:  "preserve",
+		Annots: &xfdfAnnots{},
+	}
+	for _, pa := range annots {
+		elem := exportAnnotation(pa.annot, pa.page, names)
+		doc.Annots.Annots = append(doc.Annots.Annots, elem)
+	}
+	if opts.File != "" {
+		doc.File = &xfdfFile{Href: opts.File}
+	}
+	return writeXFDFDocument(w, doc)
+}
+
+// xfdfAnnotationElement returns the XFDF element name of the annotation.
+// Returns false if the annotation is not exported.
+func xfdfAnnotationElement(annot *model.PdfAnnotation) (string, bool) {
+	switch annot.GetContext().(type) {
+	case *model.PdfAnnotationText:
+		return "text", true
+	case *model.PdfAnnotationHighlight:
+		return "highlight", true
+	case *model.PdfAnnotationUnderline:
+		return "underline", true
+	case *model.PdfAnnotationStrikeOut:
+		return "strikeout", true
+	case *model.PdfAnnotationSquiggly:
+		return "squiggly", true
+	case *model.PdfAnnotationInk:
+		return "ink", true
+	case *model.PdfAnnotationPolygon:
+		return "polygon", true
+	case *model.PdfAnnotationPolyLine:
+		return "polyline", true
+	case *model.PdfAnnotationLine:
+		return "line", true
+	case *model.PdfAnnotationSquare:
+		return "square", true
+	case *model.PdfAnnotationCircle:
+		return "circle", true
+	case *model.PdfAnnotationFreeText:
+		return "freetext", true
+	case *model.PdfAnnotationStamp:
+		return "stamp", true
+	case *model.PdfAnnotationCaret:
+		return "caret", true
+	}
+	return "", false
+}
+
+// xfdfAttrs is a list of XFDF attributes.
+type xfdfAttrs []xml.Attr
+
+// set appends the attribute, unless the value is empty.
+func (attrs *xfdfAttrs) set(name, value string) {
+	if value != "" {
+		*attrs = append(*attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+}
+
+// setString appends the attribute with the value of the string object.
+func (attrs *xfdfAttrs) setString(name string, obj core.PdfObject) {
+	if s, ok := core.GetString(obj); ok {
+		attrs.set(name, s.Decoded())
+	}
+}
+
+// setName appends the attribute with the value of the name object.
+func (attrs *xfdfAttrs) setName(name string, obj core.PdfObject) {
+	if n, ok := core.GetName(obj); ok {
+		attrs.set(name, n.String())
+	}
+}
+
+// setNumbers appends the attribute with the comma separated numbers of the
+// array object.
+func (attrs *xfdfAttrs) setNumbers(name string, obj core.PdfObject) {
+	if arr, ok := core.GetArray(obj); ok {
+		if vals, err := arr.ToFloat64Array(); err == nil {
+			attrs.set(name, formatNumbers(vals, ","))
+		}
+	}
+}
+
+// getAttr returns the value of the attribute with the specified local name.
+func getAttr(attrs []xml.Attr, name string) string {
+	for _, attr := range attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// exportAnnotation returns the XFDF element of the annotation on the page
+// with index `page`. The names of the annotations are keyed by
+// annotutil.ObjectKey.
+func exportAnnotation(annot *model.PdfAnnotation, page int, names map[interface{}]string) *xfdfAnnot {
+	elemName, _ := xfdfAnnotationElement(annot)
+	elem := &xfdfAnnot{XMLName: xml.Name{Local: elemName}}
+
+	attrs := xfdfAttrs{}
+	attrs.set("page", strconv.Itoa(page))
+	attrs.setNumbers("rect", annot.Rect)
+	attrs.set("color", formatColor(annot.C))
+	attrs.set("name", names[annotutil.ObjectKey(annot.GetContainingPdfObject())])
+	attrs.setString("date", annot.M)
+	attrs.set("flags", formatFlags(annot.F))
+	if contents, ok := core.GetString(annot.Contents); ok {
+		text := contents.Decoded()
+		elem.Contents = &text
+	}
+
+	markup := annotutil.Markup(annot)
+	attrs.setString("title", markup.T)
+	attrs.setString("subject", markup.Subj)
+	attrs.setString("creationdate", markup.CreationDate)
+	if ca, err := core.GetNumberAsFloat(markup.CA); err == nil {
+		attrs.set("opacity", formatNumber(ca))
+	}
+	if markup.IRT != nil {
+		attrs.set("inreplyto", names[annotutil.ObjectKey(markup.IRT)])
+		if rt, ok := core.GetName(markup.RT); ok && rt.String() == "Group" {
+			attrs.set("replyType", "group")
+		} else {
+			attrs.set("replyType", "reply")
+		}
+	}
+	if rc, ok := richText(markup.RC); ok {
+		elem.RichText = &xfdfRichText{Content: rc}
+	}
+	if popup := markup.Popup; popup != nil {
+		pattrs := xfdfAttrs{}
+		pattrs.set("page", strconv.Itoa(page))
+		pattrs.setNumbers("rect", popup.Rect)
+		pattrs.set("flags", formatFlags(popup.F))
+		if open, ok := core.GetBoolVal(popup.Open); ok {
+			pattrs.set("open", formatYesNo(open))
+		}
+		elem.Popup = &xfdfPopup{Attrs: pattrs}
+	}
+
+	switch t := annot.GetContext().(type) {
+	case *model.PdfAnnotationText:
+		attrs.setName("icon", t.Name)
+		if open, ok := core.GetBoolVal(t.Open); ok {
+			attrs.set("open", formatYesNo(open))
+		}
+		attrs.setString("state", t.State)
+		attrs.setString("statemodel", t.StateModel)
+	case *model.PdfAnnotationHighlight:
+		attrs.setNumbers("coords", t.QuadPoints)
+	case *model.PdfAnnotationUnderline:
+		attrs.setNumbers("coords", t.QuadPoints)
+	case *model.PdfAnnotationStrikeOut:
+		attrs.setNumbers("coords", t.QuadPoints)
+	case *model.PdfAnnotationSquiggly:
+		attrs.setNumbers("coords", t.QuadPoints)
+	case *model.PdfAnnotationInk:
+		attrs.set("width", formatBorderWidth(t.BS))
+		if inkList, ok := core.GetArray(t.InkList); ok {
+			elem.InkList = &xfdfInkList{}
+			for _, path := range inkList.Elements() {
+				if arr, ok := core.GetArray(path); ok {
+					if vals, err := arr.ToFloat64Array(); err == nil {
+						elem.InkList.Gestures = append(elem.InkList.Gestures, formatPoints(vals))
+					}
+				}
+			}
+		}
+	case *model.PdfAnnotationPolygon:
+		attrs.set("width", formatBorderWidth(t.BS))
+		attrs.set("interior-color", formatColor(t.IC))
+		elem.Vertices = formatPointsArray(t.Vertices)
+	case *model.PdfAnnotationPolyLine:
+		attrs.set("width", formatBorderWidth(t.BS))
+		attrs.set("interior-color", formatColor(t.IC))
+		setLineEndings(&attrs, t.LE)
+		elem.Vertices = formatPointsArray(t.Vertices)
+	case *model.PdfAnnotationLine:
+		attrs.set("width", formatBorderWidth(t.BS))
+		attrs.set("interior-color", formatColor(t.IC))
+		setLineEndings(&attrs, t.LE)
+		if arr, ok := core.GetArray(t.L); ok {
+			if vals, err := arr.ToFloat64Array(); err == nil && len(vals) == 4 {
+				attrs.set("start", formatNumbers(vals[:2], ","))
+				attrs.set("end", formatNumbers(vals[2:], ","))
+			}
+		}
+	case *model.PdfAnnotationSquare:
+		attrs.set("width", formatBorderWidth(t.BS))
+		attrs.set("interior-color", formatColor(t.IC))
+	case *model.PdfAnnotationCircle:
+		attrs.set("width", formatBorderWidth(t.BS))
+		attrs.set("interior-color", formatColor(t.IC))
+	case *model.PdfAnnotationFreeText:
+		attrs.set("width", formatBorderWidth(t.BS))
+		switch q, _ := core.GetIntVal(t.Q); q {
+		case 1:
+			attrs.set("justification", "centered")
+		case 2:
+			attrs.set("justification", "right")
+		}
+		if da, ok := core.GetString(t.DA); ok {
+			elem.DA = da.Decoded()
+		}
+		if ds, ok := core.GetString(t.DS); ok {
+			elem.DS = ds.Decoded()
+		}
+		if rc, ok := richText(t.RC); ok {
+			elem.RichText = &xfdfRichText{Content: rc}
+		}
+	case *model.PdfAnnotationStamp:
+		attrs.setName("icon", t.Name)
+	case *model.PdfAnnotationCaret:
+		if sy, ok := core.GetName(t.Sy); ok && sy.String() == "P" {
+			attrs.set("symbol", "paragraph")
+		}
+		attrs.setNumbers("fringe", t.RD)
+	}
+
+	elem.Attrs = attrs
+	return elem
+}
+
+// setLineEndings appends the head and tail attributes of the line ending
+// styles array (LE).
+func setLineEndings(attrs *xfdfAttrs, le core.PdfObject) {
+	arr, ok := core.GetArray(le)
+	if !ok || arr.Len() != 2 {
+		return
+	}
+	attrs.setName("head", arr.Get(0))
+	attrs.setName("tail", arr.Get(1))
+}
+
+// xmlDeclRegexp matches the XML declaration of a document.
+var xmlDeclRegexp = regexp.MustCompile(`^\s*<\?xml[^>]*\?>\s*`)
+
+// richText returns the XHTML rich text of the rich text object (string or
+// stream), without its XML declaration.
+func richText(obj core.PdfObject) (string, bool) {
+	var content string
+	switch t := core.TraceToDirectObject(obj).(type) {
+	case *core.PdfObjectString:
+		content = t.Decoded()
+	case *core.PdfObjectStream:
+		data, err := core.DecodeStream(t)
+		if err != nil {
+			return "", false
+		}
+		content = string(data)
+	default:
+		return "", false
+	}
+	content = strings.TrimSpace(xmlDeclRegexp.ReplaceAllString(content, ""))
+	return content, content != ""
+}
+
+// formatNumber returns the shortest representation of the number.
+func formatNumber(val float64) string {
+	return strconv.FormatFloat(val, 'f', -1, 64)
+}
+
+// formatNumbers returns the numbers separated by `sep`.
+func formatNumbers(vals []float64, sep string) string {
+	strs := make([]string, len(vals))
+	for i, val := range vals {
+		strs[i] = formatNumber(val)
+	}
+	return strings.Join(strs, sep)
+}
+
+// formatPoints returns the XFDF representation of the flat coordinates of
+// points, e.g. "x1,y1;x2,y2".
+func formatPoints(coords []float64) string {
+	var points []string
+	for i := 0; i+1 < len(coords); i += 2 {
+		points = append(points, formatNumbers(coords[i:i+2], ","))
+	}
+	return strings.Join(points, ";")
+}
+
+// formatPointsArray returns the XFDF representation of the points of the
+// array object.
+func formatPointsArray(obj core.PdfObject) string {
+	arr, ok := core.GetArray(obj)
+	if !ok {
+		return ""
+	}
+	vals, err := arr.ToFloat64Array()
+	if err != nil {
+		return ""
+	}
+	return formatPoints(vals)
+}
+
+// formatColor returns the #RRGGBB representation of the gray, RGB or CMYK
+// color array. Returns an empty string for empty (transparent) or invalid
+// colors.
+func formatColor(obj core.PdfObject) string {
+	arr, ok := core.GetArray(obj)
+	if !ok {
+		return ""
+	}
+	vals, err := arr.ToFloat64Array()
+	if err != nil {
+		return ""
+	}
+
+	var r, g, b float64
+	switch len(vals) {
+	case 1:
+		r, g, b = vals[0], vals[0], vals[0]
+	case 3:
+		r, g, b = vals[0], vals[1], vals[2]
+	case 4:
+		k := vals[3]
+		r, g, b = (1-vals[0])*(1-k), (1-vals[1])*(1-k), (1-vals[2])*(1-k)
+	default:
+		return ""
+	}
+	component := func(v float64) int {
+		return int(annotutil.ClampUnit(v)*255 + 0.5)
+	}
+	return fmt.Sprintf("#%02X%02X%02X", component(r), component(g), component(b))
+}
+
+// formatFlags returns the comma separated names of the annotation flags.
+func formatFlags(obj core.PdfObject) string {
+	flags, ok := core.GetIntVal(obj)
+	if !ok {
+		return ""
+	}
+	var names []string
+	for i, name := range annotationFlagNames {
+		if flags&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// formatBorderWidth returns the width of the border style dictionary (BS).
+func formatBorderWidth(bs core.PdfObject) string {
+	if dict, ok := core.GetDict(bs); ok {
+		if w, err := core.GetNumberAsFloat(dict.Get("W")); err == nil {
+			return formatNumber(w)
+		}
+	}
+	return ""
+}
+
+// formatYesNo returns the XFDF representation of the boolean.
+func formatYesNo(val bool) string {
+	if val {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,437 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package fdf
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/internal/annotutil"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// AnnotationImportOptions contains options for importing annotations.
+type AnnotationImportOptions struct {
+	// PageMap maps the page numbers of the XFDF document (page indices,
+	// starting from 0) to the indices of the pages the annotations are
+	// imported onto. The annotations of the pages missing from the map are
+	// skipped. When nil, the annotations are imported onto the pages with the
+	// same indices.
+	PageMap map[int]int
+}
+
+// AnnotationCount returns the number of annotations of the XFDF document
+// which can be imported.
+func (data *XFDFData) AnnotationCount() int {
+	count := 0
+	for _, elem := range data.annots {
+		if _, ok := xfdfAnnotationTypes[elem.XMLName.Local]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// ImportAnnotations adds the annotations of the XFDF document to the pages,
+// along with their popups. Replies are linked to the annotations they reply
+// to, either imported or already present on the pages. An annotation with
+// the same name (NM) as an annotation of the target page replaces it, so
+// that importing an updated export of the comments does not duplicate them.
+// The appearance of the imported annotations is generated.
+func (data *XFDFData) ImportAnnotations(pages []*model.PdfPage, opts *AnnotationImportOptions) error {
+	if opts == nil {
+		opts = &AnnotationImportOptions{}
+	}
+
+	pageAnnots := make([][]*model.PdfAnnotation, len(pages))
+	byName := map[string]*model.PdfAnnotation{}
+	for i, page := range pages {
+		annots, err := page.GetAnnotations()
+		if err != nil {
+			return err
+		}
+		pageAnnots[i] = annots
+		for _, annot := range annots {
+			if name, ok := core.GetStringVal(annot.NM); ok && name != "" {
+				byName[name] = annot
+			}
+		}
+	}
+
+	type reply struct {
+		markup *model.PdfAnnotationMarkup
+		irt    string
+	}
+	var replies []reply
+	modified := make([]bool, len(pages))
+	for _, elem := range data.annots {
+		subtype, ok := xfdfAnnotationTypes[elem.XMLName.Local]
+		if !ok {
+			common.Log.Debug("Skipping unsupported XFDF annotation: %s", elem.XMLName.Local)
+			continue
+		}
+		srcPage, err := strconv.Atoi(getAttr(elem.Attrs, "page"))
+		if err != nil {
+			return fmt.Errorf("invalid page of XFDF annotation %s", elem.XMLName.Local)
+		}
+		target := srcPage
+		if opts.PageMap != nil {
+			if target, ok = opts.PageMap[srcPage]; !ok {
+				continue
+			}
+		}
+		if target < 0 || target >= len(pages) {
+			common.Log.Debug("Skipping XFDF annotation of page %d: page out of range", srcPage)
+			continue
+		}
+
+		annot, err := importAnnotation(elem, subtype)
+		if err != nil {
+			return err
+		}
+		generateAppearance(annot)
+
+		annots := pageAnnots[target]
+		name := getAttr(elem.Attrs, "name")
+		if old, ok := byName[name]; ok && name != "" {
+			annots = removeAnnotation(annots, old)
+		}
+		annots = append(annots, annot)
+		markup := annotutil.Markup(annot)
+		if markup.Popup != nil {
+			annots = append(annots, markup.Popup.PdfAnnotation)
+		}
+		pageAnnots[target] = annots
+		modified[target] = true
+
+		if name != "" {
+			byName[name] = annot
+		}
+		if irt := getAttr(elem.Attrs, "inreplyto"); irt != "" {
+			replies = append(replies, reply{markup: markup, irt: irt})
+		}
+	}
+
+	for _, r := range replies {
+		annot, ok := byName[r.irt]
+		if !ok {
+			common.Log.Debug("Annotation replied to not found: %s", r.irt)
+			continue
+		}
+		r.markup.IRT = annot.GetContainingPdfObject()
+	}
+	for i, page := range pages {
+		if modified[i] {
+			page.SetAnnotations(pageAnnots[i])
+		}
+	}
+	return nil
+}
+
+// removeAnnotation removes the annotation and its popup from the list.
+func removeAnnotation(annots []*model.PdfAnnotation, annot *model.PdfAnnotation) []*model.PdfAnnotation {
+	var popup *model.PdfAnnotation
+	if markup := annotutil.Markup(annot); markup != nil && markup.Popup != nil {
+		popup = markup.Popup.PdfAnnotation
+	}
+
+	var kept []*model.PdfAnnotation
+	for _, a := range annots {
+		if a == annot || popup != nil && a == popup {
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
+
+// importAnnotation returns the annotation represented by the XFDF element.
+func importAnnotation(elem *xfdfAnnot, subtype string) (*model.PdfAnnotation, error) {
+	attr := func(name string) string {
+		return getAttr(elem.Attrs, name)
+	}
+
+	var annot *model.PdfAnnotation
+	var markup *model.PdfAnnotationMarkup
+	var err error
+	switch subtype {
+	case "Text":
+		a := model.NewPdfAnnotationText()
+		a.Name = makeName(attr("icon"))
+		if open := attr("open"); open != "" {
+			a.Open = core.MakeBool(open == "yes")
+		}
+		a.State = makeString(attr("state"))
+		a.StateModel = makeString(attr("statemodel"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Highlight":
+		a := model.NewPdfAnnotationHighlight()
+		a.QuadPoints, err = parseNumbers(attr("coords"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Underline":
+		a := model.NewPdfAnnotationUnderline()
+		a.QuadPoints, err = parseNumbers(attr("coords"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "StrikeOut":
+		a := model.NewPdfAnnotationStrikeOut()
+		a.QuadPoints, err = parseNumbers(attr("coords"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Squiggly":
+		a := model.NewPdfAnnotationSquiggly()
+		a.QuadPoints, err = parseNumbers(attr("coords"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Ink":
+		a := model.NewPdfAnnotationInk()
+		inkList := core.MakeArray()
+		var gestures []string
+		if elem.InkList != nil {
+			gestures = elem.InkList.Gestures
+		}
+		for _, gesture := range gestures {
+			path, perr := parseNumbers(gesture)
+			if perr != nil {
+				err = perr
+				break
+			}
+			if path != nil {
+				inkList.Append(path)
+			}
+		}
+		a.InkList = inkList
+		a.BS = makeBorderStyle(attr("width"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Polygon":
+		a := model.NewPdfAnnotationPolygon()
+		a.Vertices, err = parseNumbers(elem.Vertices)
+		a.BS = makeBorderStyle(attr("width"))
+		a.IC = makeColor(attr("interior-color"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "PolyLine":
+		a := model.NewPdfAnnotationPolyLine()
+		a.Vertices, err = parseNumbers(elem.Vertices)
+		a.BS = makeBorderStyle(attr("width"))
+		a.IC = makeColor(attr("interior-color"))
+		a.LE = makeLineEndings(attr("head"), attr("tail"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Line":
+		a := model.NewPdfAnnotationLine()
+		var start, end core.PdfObject
+		if start, err = parseNumbers(attr("start")); err == nil {
+			end, err = parseNumbers(attr("end"))
+		}
+		if err == nil {
+			startArr, ok1 := core.GetArray(start)
+			endArr, ok2 := core.GetArray(end)
+			if !ok1 || !ok2 || startArr.Len() != 2 || endArr.Len() != 2 {
+				err = errors.New("invalid XFDF line start or end")
+			} else {
+				a.L = core.MakeArray(append(startArr.Elements(), endArr.Elements()...)...)
+			}
+		}
+		a.BS = makeBorderStyle(attr("width"))
+		a.IC = makeColor(attr("interior-color"))
+		a.LE = makeLineEndings(attr("head"), attr("tail"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Square":
+		a := model.NewPdfAnnotationSquare()
+		a.BS = makeBorderStyle(attr("width"))
+		a.IC = makeColor(attr("interior-color"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Circle":
+		a := model.NewPdfAnnotationCircle()
+		a.BS = makeBorderStyle(attr("width"))
+		a.IC = makeColor(attr("interior-color"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "FreeText":
+		a := model.NewPdfAnnotationFreeText()
+		a.BS = makeBorderStyle(attr("width"))
+		switch attr("justification") {
+		case "centered":
+			a.Q = core.MakeInteger(1)
+		case "right":
+			a.Q = core.MakeInteger(2)
+		}
+		a.DA = makeString(elem.DA)
+		a.DS = makeString(elem.DS)
+		if elem.RichText != nil {
+			a.RC = makeString(strings.TrimSpace(elem.RichText.Content))
+		}
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Stamp":
+		a := model.NewPdfAnnotationStamp()
+		a.Name = makeName(attr("icon"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	case "Caret":
+		a := model.NewPdfAnnotationCaret()
+		a.Sy = core.MakeName("None")
+		if attr("symbol") == "paragraph" {
+			a.Sy = core.MakeName("P")
+		}
+		a.RD, err = parseNumbers(attr("fringe"))
+		annot, markup = a.PdfAnnotation, a.PdfAnnotationMarkup
+	default:
+		return nil, fmt.Errorf("unsupported annotation type: %s", subtype)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rect, err := parseNumbers(attr("rect"))
+	if err != nil {
+		return nil, err
+	}
+	if arr, ok := core.GetArray(rect); !ok || arr.Len() != 4 {
+		return nil, fmt.Errorf("invalid rect of XFDF annotation %s", elem.XMLName.Local)
+	}
+	annot.Rect = rect
+	annot.C = makeColor(attr("color"))
+	annot.NM = makeString(attr("name"))
+	annot.M = makeString(attr("date"))
+	annot.F = makeFlags(attr("flags"))
+	if elem.Contents != nil {
+		annot.Contents = core.MakeString(*elem.Contents)
+	}
+
+	markup.T = makeString(attr("title"))
+	markup.Subj = makeString(attr("subject"))
+	markup.CreationDate = makeString(attr("creationdate"))
+	if opacity := attr("opacity"); opacity != "" {
+		ca, err := strconv.ParseFloat(opacity, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid opacity of XFDF annotation %s", elem.XMLName.Local)
+		}
+		markup.CA = core.MakeFloat(ca)
+	}
+	if attr("replyType") == "group" {
+		markup.RT = core.MakeName("Group")
+	}
+	if elem.RichText != nil && subtype != "FreeText" {
+		markup.RC = makeString(strings.TrimSpace(elem.RichText.Content))
+	}
+
+	if elem.Popup != nil {
+		popup := model.NewPdfAnnotationPopup()
+		if popup.Rect, err = parseNumbers(getAttr(elem.Popup.Attrs, "rect")); err != nil {
+			return nil, err
+		}
+		popup.F = makeFlags(getAttr(elem.Popup.Attrs, "flags"))
+		if open := getAttr(elem.Popup.Attrs, "open"); open != "" {
+			popup.Open = core.MakeBool(open == "yes")
+		}
+		popup.Parent = annot.GetContainingPdfObject()
+		markup.Popup = popup
+	}
+	return annot, nil
+}
+
+// makeString returns a string object, or nil if the string is empty.
+func makeString(s string) core.PdfObject {
+	if s == "" {
+		return nil
+	}
+	return core.MakeString(s)
+}
+
+// makeName returns a name object, or nil if the name is empty.
+func makeName(s string) core.PdfObject {
+	if s == "" {
+		return nil
+	}
+	return core.MakeName(s)
+}
+
+// parseNumbers returns the array of the numbers separated by commas,
+// semicolons or spaces, or nil if the string is empty.
+func parseNumbers(s string) (core.PdfObject, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	vals := make([]float64, len(fields))
+	for i, field := range fields {
+		val, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid XFDF number: %s", field)
+		}
+		vals[i] = val
+	}
+	return core.MakeArrayFromFloats(vals), nil
+}
+
+// makeColor returns the RGB color array of the #RRGGBB color, or nil if the
+// color is empty or invalid.
+func makeColor(s string) core.PdfObject {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return nil
+	}
+	val, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil
+	}
+	return core.MakeArrayFromFloats([]float64{
+		float64(val>>16&0xff) / 255,
+		float64(val>>8&0xff) / 255,
+		float64(val&0xff) / 255,
+	})
+}
+
+// makeFlags returns the annotation flags of the comma separated flag names,
+// or nil if no flags are set.
+func makeFlags(s string) core.PdfObject {
+	flags := 0
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for i, flagName := range annotationFlagNames {
+			if name == flagName {
+				flags |= 1 << uint(i)
+			}
+		}
+	}
+	if flags == 0 {
+		return nil
+	}
+	return core.MakeInteger(int64(flags))
+}
+
+// makeBorderStyle returns the border style dictionary (BS) of the border
+// width, or nil if the width is not set.
+func makeBorderStyle(width string) core.PdfObject {
+	w, err := strconv.ParseFloat(width, 64)
+	if err != nil {
+		return nil
+	}
+	bs := model.NewBorderStyle()
+	bs.SetBorderWidth(w)
+	return bs.ToPdfObject()
+}
+
+// makeLineEndings returns the line ending styles array (LE) of the head and
+// tail styles, or nil if neither is set.
+func makeLineEndings(head, tail string) core.PdfObject {
+	if head == "" && tail == "" {
+		return nil
+	}
+	if head == "" {
+		head = "None"
+	}
+	if tail == "" {
+		tail = "None"
+	}
+	return core.MakeArray(core.MakeName(head), core.MakeName(tail))
+}
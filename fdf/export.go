@@ -0,0 +1,331 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package fdf
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// ExportOptions specifies the options for exporting form field data.
+type ExportOptions struct {
+	// File is the path or URL of the PDF document the form data belongs to.
+	// Written as the F entry of the FDF dictionary, or as the href of the
+	// XFDF f element. Omitted if empty.
+	File string
+
+	// Fields restricts the export to the fields with the specified full
+	// names, and to their descendants. All fields are exported if empty.
+	Fields []string
+
+	// IncludeEmpty specifies whether the fields without a value are exported.
+	IncludeEmpty bool
+}
+
+// exportField represents the value of a terminal field being exported.
+type exportField struct {
+	field *model.PdfField
+	path  []string
+	value core.PdfObject
+}
+
+// fullName returns the full name of the exported field.
+func (f exportField) fullName() string {
+	return strings.Join(f.path, ".")
+}
+
+// fieldValue returns the value of the field, inherited from its ancestors
+// if not set.
+func fieldValue(field *model.PdfField) core.PdfObject {
+	for f := field; f != nil; f = f.Parent {
+		if f.V != nil {
+			return core.TraceToDirectObject(f.V)
+		}
+	}
+	return nil
+}
+
+// fieldPath returns the partial names of the field and of its ancestors,
+// starting with the root field.
+func fieldPath(field *model.PdfField) []string {
+	var path []string
+	for f := field; f != nil; f = f.Parent {
+		if f.T == nil {
+			continue
+		}
+		path = append([]string{f.PartialName()}, path...)
+	}
+	return path
+}
+
+// isEmptyValue returns true if the field value object does not hold a value.
+func isEmptyValue(value core.PdfObject) bool {
+	switch t := value.(type) {
+	case nil, *core.PdfObjectNull:
+		return true
+	case *core.PdfObjectString:
+		return len(t.Bytes()) == 0
+	case *core.PdfObjectName:
+		return len(*t) == 0
+	case *core.PdfObjectArray:
+		return t.Len() == 0
+	}
+	return false
+}
+
+// valueStrings returns the string representation of the field value object.
+// Arrays (e.g. multiple selections of list boxes) result in multiple strings.
+func valueStrings(value core.PdfObject) []string {
+	switch t := value.(type) {
+	case *core.PdfObjectString:
+		return []string{t.Decoded()}
+	case *core.PdfObjectName:
+		return []string{string(*t)}
+	case *core.PdfObjectArray:
+		var values []string
+		for _, obj := range t.Elements() {
+			values = append(values, valueStrings(core.TraceToDirectObject(obj))...)
+		}
+		return values
+	}
+	return nil
+}
+
+// collectFields returns the values of the terminal fields of the form
+// accepted by `filter`. Push buttons are never exported, as they do not
+// hold a value.
+func collectFields(form *model.PdfAcroForm, filter func(field *model.PdfField, fullName string) bool, includeEmpty bool) []exportField {
+	if form == nil {
+		return nil
+	}
+
+	var fields []exportField
+	for _, field := range form.AllFields() {
+		if !field.IsTerminal() {
+			continue
+		}
+		if button, ok := field.GetContext().(*model.PdfFieldButton); ok && button.IsPush() {
+			continue
+		}
+		if _, ok := field.GetContext().(*model.PdfFieldSignature); ok {
+			continue
+		}
+
+		path := fieldPath(field)
+		if len(path) == 0 {
+			continue
+		}
+		ef := exportField{field: field, path: path, value: fieldValue(field)}
+		if filter != nil && !filter(field, ef.fullName()) {
+			continue
+		}
+		if !includeEmpty && isEmptyValue(ef.value) {
+			continue
+		}
+		fields = append(fields, ef)
+	}
+	return fields
+}
+
+// matchesFieldName returns true if `fullName` is the full name of the field
+// `name`, or of one of its descendants.
+func matchesFieldName(fullName, name string) bool {
+	return fullName == name || strings.HasPrefix(fullName, name+".")
+}
+
+// exportFields returns the fields of the form selected by the export options.
+func exportFields(form *model.PdfAcroForm, opts *ExportOptions) []exportField {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	var filter func(*model.PdfField, string) bool
+	if len(opts.Fields) > 0 {
+		filter = func(_ *model.PdfField, fullName string) bool {
+			for _, name := range opts.Fields {
+				if matchesFieldName(fullName, name) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return collectFields(form, filter, opts.IncludeEmpty)
+}
+
+// fdfFieldNode represents a field of the FDF field hierarchy.
+type fdfFieldNode struct {
+	name  string
+	value core.PdfObject
+	kids  []*fdfFieldNode
+}
+
+// child returns the kid of the node with the specified partial name,
+// creating it if it does not exist.
+func (n *fdfFieldNode) child(name string) *fdfFieldNode {
+	for _, kid := range n.kids {
+		if kid.name == name {
+			return kid
+		}
+	}
+	kid := &fdfFieldNode{name: name}
+	n.kids = append(n.kids, kid)
+	return kid
+}
+
+// buildFieldTree arranges the exported fields in a hierarchy of nodes
+// matching the hierarchy of the form fields.
+func buildFieldTree(fields []exportField) *fdfFieldNode {
+	root := &fdfFieldNode{}
+	for _, f := range fields {
+		node := root
+		for _, name := range f.path {
+			node = node.child(name)
+		}
+		node.value = f.value
+	}
+	return root
+}
+
+// makeTextString returns a PDF text string containing `s`, UTF-16BE encoded
+// if it contains non-ASCII characters.
+func makeTextString(s string) *core.PdfObjectString {
+	for _, r := range s {
+		if r >= utf8.RuneSelf {
+			return core.MakeEncodedString(s, true)
+		}
+	}
+	return core.MakeString(s)
+}
+
+// toPdfObject returns the FDF field dictionaries of the kids of the node.
+func (n *fdfFieldNode) toPdfObject() *core.PdfObjectArray {
+	arr := core.MakeArray()
+	for _, kid := range n.kids {
+		dict := core.MakeDict()
+		dict.Set("T", makeTextString(kid.name))
+		if kid.value != nil {
+			dict.Set("V", kid.value)
+		}
+		if len(kid.kids) > 0 {
+			dict.Set("Kids", kid.toPdfObject())
+		}
+		arr.Append(dict)
+	}
+	return arr
+}
+
+// WriteFDF writes the values of the fields of `form` to `w` as an FDF file.
+// The field hierarchy of the form is preserved through the Kids entries of
+// the FDF field dictionaries.
+func WriteFDF(w io.Writer, form *model.PdfAcroForm, opts *ExportOptions) error {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	return writeFDFFields(w, buildFieldTree(exportFields(form, opts)), opts.File)
+}
+
+// writeFDFFields writes an FDF file containing the fields of the tree.
+func writeFDFFields(w io.Writer, root *fdfFieldNode, file string) error {
+	fdfDict := core.MakeDict()
+	if file != "" {
+		fdfDict.Set("F", core.MakeString(file))
+	}
+	fdfDict.Set("Fields", root.toPdfObject())
+
+	catalog := core.MakeDict()
+	catalog.Set("FDF", fdfDict)
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("%FDF-1.2\n%\xe2\xe3\xcf\xd3\n")
+	bw.WriteString("1 0 obj\n")
+	bw.WriteString(catalog.WriteString())
+	bw.WriteString("\nendobj\n")
+	bw.WriteString("trailer\n<</Root 1 0 R>>\n%%EOF\n")
+	return bw.Flush()
+}
+
+// xfdfNamespace is the namespace of XFDF documents.
+const xfdfNamespace = "http://ns.adobe.com/xfdf/"
+
+// xfdfDocument represents the root element of an XFDF document.
+type xfdfDocument struct {
+	XMLName xml.Name     `xml:"xfdf"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Space   string       `xml:"http://www.w3.org/XML/1998/namespace space,attr,omitempty"`
+	File    *xfdfFile    `xml:"f"`
+	Fields  []*xfdfField `xml:"fields>field"`
+	Annots  *xfdfAnnots  `xml:"annots"`
+}
+
+// xfdfFile represents the f element of an XFDF document, which specifies the
+// PDF document the form data belongs to.
+type xfdfFile struct {
+	Href string `xml:"href,attr"`
+}
+
+// xfdfField represents a field element of an XFDF document.
+type xfdfField struct {
+	Name   string       `xml:"name,attr"`
+	Values []string     `xml:"value"`
+	Fields []*xfdfField `xml:"field"`
+}
+
+// toXFDF returns the XFDF field elements of the kids of the node.
+func (n *fdfFieldNode) toXFDF() []*xfdfField {
+	var fields []*xfdfField
+	for _, kid := range n.kids {
+		fields = append(fields, &xfdfField{
+			Name:   kid.name,
+			Values: valueStrings(kid.value),
+			Fields: kid.toXFDF(),
+		})
+	}
+	return fields
+}
+
+// WriteXFDF writes the values of the fields of `form` to `w` as an XFDF
+// document. The field hierarchy of the form is preserved through nested
+// field elements.
+func WriteXFDF(w io.Writer, form *model.PdfAcroForm, opts *ExportOptions) error {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	doc := &xfdfDocument{
+		Xmlns:  xfdfNamespace,
+		Space:  "preserve",
+		Fields: buildFieldTree(exportFields(form, opts)).toXFDF(),
+	}
+	if opts.File != "" {
+		doc.File = &xfdfFile{Href: opts.File}
+	}
+	return writeXFDFDocument(w, doc)
+}
+
+// writeXFDFDocument writes the XFDF document to `w`.
+func writeXFDFDocument(w io.Writer, doc *xfdfDocument) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,225 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package fdf
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// SubmitFormFlag represents the flags of a submit-form action
+// (Table 237 - Flags for submit-form actions).
+type SubmitFormFlag uint32
+
+// Submit-form action flags.
+const (
+	SubmitFormFlagExclude              SubmitFormFlag = 1
+	SubmitFormFlagIncludeNoValueFields SubmitFormFlag = 1 << 1
+	SubmitFormFlagExportFormat         SubmitFormFlag = 1 << 2
+	SubmitFormFlagGetMethod            SubmitFormFlag = 1 << 3
+	SubmitFormFlagSubmitCoordinates    SubmitFormFlag = 1 << 4
+	SubmitFormFlagXFDF                 SubmitFormFlag = 1 << 5
+	SubmitFormFlagIncludeAppendSaves   SubmitFormFlag = 1 << 6
+	SubmitFormFlagIncludeAnnotations   SubmitFormFlag = 1 << 7
+	SubmitFormFlagSubmitPDF            SubmitFormFlag = 1 << 8
+	SubmitFormFlagCanonicalFormat      SubmitFormFlag = 1 << 9
+	SubmitFormFlagExclNonUserAnnots    SubmitFormFlag = 1 << 10
+	SubmitFormFlagExclFKey             SubmitFormFlag = 1 << 11
+	SubmitFormFlagEmbedForm            SubmitFormFlag = 1 << 13
+)
+
+// Has returns true if the flag `flag` is set.
+func (f SubmitFormFlag) Has(flag SubmitFormFlag) bool {
+	return f&flag != 0
+}
+
+// Content types of the submit-form payloads.
+const (
+	ContentTypeURLEncoded = "application/x-www-form-urlencoded"
+	ContentTypeFDF        = "application/vnd.fdf"
+	ContentTypeXFDF       = "application/vnd.adobe.xfdf"
+)
+
+// ErrSubmitPDFNotSupported is returned when building the payload of a
+// submit-form action which submits the entire PDF document.
+var ErrSubmitPDFNotSupported = errors.New("submitting the PDF document is not supported")
+
+// SubmitPayload represents the HTTP request a viewer sends when performing a
+// submit-form action.
+type SubmitPayload struct {
+	// URL is the URL the form data is submitted to. When the GET method is
+	// used, the URL includes the URL-encoded form data as query string.
+	URL string
+
+	// Method is the HTTP method of the request: POST or GET.
+	Method string
+
+	// ContentType is the MIME type of the body.
+	ContentType string
+
+	// Body is the form data, formatted as specified by the action flags:
+	// URL-encoded (HTML form format), FDF or XFDF. Empty for GET requests.
+	Body []byte
+}
+
+// submitURL returns the URL of the submit-form action.
+func submitURL(action *model.PdfActionSubmitForm) string {
+	if action.F == nil {
+		return ""
+	}
+	for _, obj := range []core.PdfObject{action.F.UF, action.F.F} {
+		if s, ok := core.GetString(obj); ok {
+			return s.Decoded()
+		}
+	}
+	return ""
+}
+
+// submitFieldFilter returns the filter selecting the fields submitted by the
+// action, based on its Fields entry and its Include/Exclude flag. Fields
+// flagged NoExport are never submitted.
+func submitFieldFilter(action *model.PdfActionSubmitForm, flags SubmitFormFlag) func(*model.PdfField, string) bool {
+	var names []string
+	var objs []core.PdfObject
+	if arr, ok := core.GetArray(action.Fields); ok {
+		for _, obj := range arr.Elements() {
+			if s, ok := core.GetString(obj); ok {
+				names = append(names, s.Decoded())
+				continue
+			}
+			objs = append(objs, core.TraceToDirectObject(obj))
+		}
+	}
+	listed := len(names) > 0 || len(objs) > 0
+
+	isListed := func(field *model.PdfField, fullName string) bool {
+		for _, name := range names {
+			if matchesFieldName(fullName, name) {
+				return true
+			}
+		}
+		for f := field; f != nil; f = f.Parent {
+			for _, obj := range objs {
+				if core.TraceToDirectObject(f.GetContainingPdfObject()) == obj {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	return func(field *model.PdfField, fullName string) bool {
+		for f := field; f != nil; f = f.Parent {
+			if f.Flags().Has(model.FieldFlagNoExport) {
+				return false
+			}
+		}
+		if !listed {
+			return true
+		}
+		return isListed(field, fullName) != flags.Has(SubmitFormFlagExclude)
+	}
+}
+
+// encodeFields returns the values of the fields in the HTML form format.
+// Unchecked check boxes and radio buttons are omitted, as in HTML forms,
+// unless empty fields are included.
+func encodeFields(fields []exportField, includeEmpty bool) string {
+	var pairs []string
+	for _, f := range fields {
+		name := url.QueryEscape(f.fullName())
+		value := f.value
+		if n, ok := value.(*core.PdfObjectName); ok && *n == "Off" {
+			value = nil
+		}
+
+		values := valueStrings(value)
+		if len(values) == 0 {
+			if !includeEmpty {
+				continue
+			}
+			values = []string{""}
+		}
+		for _, v := range values {
+			pairs = append(pairs, name+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// NewSubmitPayload builds the request a viewer sends when performing the
+// submit-form `action` on `form`. The format of the form data is selected
+// by the action flags: URL-encoded (ExportFormat), XFDF (XFDF) or FDF
+// (default). Returns ErrSubmitPDFNotSupported if the action submits the
+// entire document (SubmitPDF).
+func NewSubmitPayload(form *model.PdfAcroForm, action *model.PdfActionSubmitForm) (*SubmitPayload, error) {
+	if action == nil {
+		return nil, errors.New("submit-form action not specified")
+	}
+
+	var flags SubmitFormFlag
+	if action.Flags != nil {
+		val, ok := core.GetIntVal(action.Flags)
+		if !ok {
+			return nil, core.ErrTypeError
+		}
+		flags = SubmitFormFlag(val)
+	}
+	if flags.Has(SubmitFormFlagSubmitPDF) {
+		return nil, ErrSubmitPDFNotSupported
+	}
+
+	includeEmpty := flags.Has(SubmitFormFlagIncludeNoValueFields)
+	fields := collectFields(form, submitFieldFilter(action, flags), includeEmpty)
+
+	payload := &SubmitPayload{
+		URL:    submitURL(action),
+		Method: "POST",
+	}
+	switch {
+	case flags.Has(SubmitFormFlagExportFormat):
+		query := encodeFields(fields, includeEmpty)
+		if flags.Has(SubmitFormFlagGetMethod) {
+			payload.Method = "GET"
+			if query != "" {
+				sep := "?"
+				if strings.Contains(payload.URL, "?") {
+					sep = "&"
+				}
+				payload.URL += sep + query
+			}
+			return payload, nil
+		}
+		payload.ContentType = ContentTypeURLEncoded
+		payload.Body = []byte(query)
+	case flags.Has(SubmitFormFlagXFDF):
+		var buf bytes.Buffer
+		root := buildFieldTree(fields)
+		doc := &xfdfDocument{Xmlns: xfdfNamespace, Space: "preserve", Fields: root.toXFDF()}
+		if err := writeXFDFDocument(&buf, doc); err != nil {
+			return nil, err
+		}
+		payload.ContentType = ContentTypeXFDF
+		payload.Body = buf.Bytes()
+	default:
+		var buf bytes.Buffer
+		if err := writeFDFFields(&buf, buildFieldTree(fields), ""); err != nil {
+			return nil, err
+		}
+		payload.ContentType = ContentTypeFDF
+		payload.Body = buf.Bytes()
+	}
+	return payload, nil
+}
@@ -0,0 +1,108 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package fdf
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// XFDFData represents form field data and annotations loaded from an XFDF
+// document.
+type XFDFData struct {
+	file   string
+	values map[string][]string
+	annots []*xfdfAnnot
+}
+
+// LoadXFDF loads XFDF form data and annotations from `r`.
+func LoadXFDF(r io.Reader) (*XFDFData, error) {
+	var doc xfdfDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	data := &XFDFData{values: map[string][]string{}}
+	if doc.File != nil {
+		data.file = doc.File.Href
+	}
+	data.addFields("", doc.Fields)
+	if doc.Annots != nil {
+		data.annots = doc.Annots.Annots
+	}
+	return data, nil
+}
+
+// LoadXFDFFromPath loads XFDF form data and annotations from file path
+// `xfdfPath`.
+func LoadXFDFFromPath(xfdfPath string) (*XFDFData, error) {
+	f, err := os.Open(xfdfPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadXFDF(f)
+}
+
+// addFields records the values of the XFDF field elements, and of their
+// nested field elements, by full field name.
+func (data *XFDFData) addFields(prefix string, fields []*xfdfField) {
+	for _, field := range fields {
+		name := field.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if len(field.Values) > 0 {
+			data.values[name] = field.Values
+		}
+		data.addFields(name, field.Fields)
+	}
+}
+
+// File returns the path or URL of the PDF document the form data belongs
+// to, as specified by the f element of the XFDF document.
+func (data *XFDFData) File() string {
+	return data.file
+}
+
+// FieldNames returns the sorted full names of the fields having a value.
+func (data *XFDFData) FieldNames() []string {
+	names := make([]string, 0, len(data.values))
+	for name := range data.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldValues implements model.FieldValueProvider interface. Fields with
+// multiple values (e.g. list boxes with multiple selections) map to an
+// array of strings.
+func (data *XFDFData) FieldValues() (map[string]core.PdfObject, error) {
+	values := make(map[string]core.PdfObject, len(data.values))
+	for name, vals := range data.values {
+		if len(vals) == 1 {
+			values[name] = core.MakeString(vals[0])
+			continue
+		}
+
+		arr := core.MakeArray()
+		for _, val := range vals {
+			arr.Append(core.MakeString(val))
+		}
+		values[name] = arr
+	}
+	return values, nil
+}
@@ -13,13 +13,13 @@
 package fjson ;import (_c "encoding/json";_b "github.com/unidoc/unipdf/v3/core";_f "github.com/unidoc/unipdf/v3/model";_a "io";_cd "os";);
 
 // FieldValues implements model.FieldValueProvider interface.
-func (_fa *FieldData )FieldValues ()(map[string ]_b .PdfObject ,error ){_ffg :=make (map[string ]_b .PdfObject );for _ ,_ffd :=range _fa ._af {if len (_ffd .Value )> 0{_ffg [_ffd .Name ]=_b .MakeString (_ffd .Value );};};return _ffg ,nil ;};
+func (_fa *FieldData )FieldValues ()(map[string ]_b .PdfObject ,error ){_ffg :=make (map[string ]_b .PdfObject );for _ ,_ffd :=range _fa ._af {if len (_ffd .Value )> 0{_ffg [_ffd .Name ]=_b .MakeString (_ffd .Value );}else if len (_ffd .RichValue )> 0{_ffg [_ffd .Name ]=_b .MakeString (richValueText (_ffd .RichValue ));};};return _ffg ,nil ;};
 
 // LoadFromJSON loads JSON form data from `r`.
 func LoadFromJSON (r _a .Reader )(*FieldData ,error ){var _d FieldData ;_cb :=_c .NewDecoder (r ).Decode (&_d ._af );if _cb !=nil {return nil ,_cb ;};return &_d ,nil ;};
 
 // LoadFromPDF loads form field data from a PDF.
-func LoadFromPDF (rs _a .ReadSeeker )(*FieldData ,error ){_dc ,_da :=_f .NewPdfReader (rs );if _da !=nil {return nil ,_da ;};if _dc .AcroForm ==nil {return nil ,nil ;};var _dcg []fieldValue ;_e :=_dc .AcroForm .AllFields ();for _ ,_ag :=range _e {var _ab []string ;_fe :=make (map[string ]struct{});_ae ,_be :=_ag .FullName ();if _be !=nil {return nil ,_be ;};if _de ,_ea :=_ag .V .(*_b .PdfObjectString );_ea {_dcg =append (_dcg ,fieldValue {Name :_ae ,Value :_de .Decoded ()});continue ;};var _fd string ;for _ ,_aed :=range _ag .Annotations {_gb ,_bg :=_b .GetName (_aed .AS );if _bg {_fd =_gb .String ();};_bf ,_feb :=_b .GetDict (_aed .AP );if !_feb {continue ;};_bfb ,_ :=_b .GetDict (_bf .Get ("\u004e"));for _ ,_dg :=range _bfb .Keys (){_gfg :=_dg .String ();if _ ,_bd :=_fe [_gfg ];!_bd {_ab =append (_ab ,_gfg );_fe [_gfg ]=struct{}{};};};_fee ,_ :=_b .GetDict (_bf .Get ("\u0044"));for _ ,_cc :=range _fee .Keys (){_acb :=_cc .String ();if _ ,_ba :=_fe [_acb ];!_ba {_ab =append (_ab ,_acb );_fe [_acb ]=struct{}{};};};};_cbg :=fieldValue {Name :_ae ,Value :_fd ,Options :_ab };_dcg =append (_dcg ,_cbg );};_afb :=FieldData {_af :_dcg };return &_afb ,nil ;};
+func LoadFromPDF (rs _a .ReadSeeker )(*FieldData ,error ){_dc ,_da :=_f .NewPdfReader (rs );if _da !=nil {return nil ,_da ;};if _dc .AcroForm ==nil {return nil ,nil ;};var _dcg []fieldValue ;_e :=_dc .AcroForm .AllFields ();for _ ,_ag :=range _e {var _ab []string ;_fe :=make (map[string ]struct{});_ae ,_be :=_ag .FullName ();if _be !=nil {return nil ,_be ;};if _de ,_ea :=_ag .V .(*_b .PdfObjectString );_ea {_dcg =append (_dcg ,fieldValue {Name :_ae ,Value :_de .Decoded (),RichValue :fieldRichValue (_ag )});continue ;};var _fd string ;for _ ,_aed :=range _ag .Annotations {_gb ,_bg :=_b .GetName (_aed .AS );if _bg &&(_fd ==""||_gb .String ()!="\u004f\u0066\u0066"){_fd =_gb .String ();};_bf ,_feb :=_b .GetDict (_aed .AP );if !_feb {continue ;};_bfb ,_ :=_b .GetDict (_bf .Get ("\u004e"));for _ ,_dg :=range _bfb .Keys (){_gfg :=_dg .String ();if _ ,_bd :=_fe [_gfg ];!_bd {_ab =append (_ab ,_gfg );_fe [_gfg ]=struct{}{};};};_fee ,_ :=_b .GetDict (_bf .Get ("\u0044"));for _ ,_cc :=range _fee .Keys (){_acb :=_cc .String ();if _ ,_ba :=_fe [_acb ];!_ba {_ab =append (_ab ,_acb );_fe [_acb ]=struct{}{};};};};_cbg :=fieldValue {Name :_ae ,Value :_fd ,Options :_ab };_dcg =append (_dcg ,_cbg );};_afb :=FieldData {_af :_dcg };return &_afb ,nil ;};
 
 // JSON returns the field data as a string in JSON format.
 func (_caa FieldData )JSON ()(string ,error ){_ff ,_bdg :=_c .MarshalIndent (_caa ._af ,"","\u0020\u0020\u0020\u0020");return string (_ff ),_bdg ;};
@@ -34,4 +34,7 @@ func LoadFromPDFFile (filePath string )(*FieldData ,error ){_dea ,_ge :=_cd .Ope
 type FieldData struct{_af []fieldValue };type fieldValue struct{Name string `json:"name"`;Value string `json:"value"`;
 
 // Options lists allowed values if present.
-Options []string `json:"options,omitempty"`;};
\ No newline at end of file
+Options []string `json:"options,omitempty"`;
+
+// RichValue is the rich text value (XHTML) of text fields with the RichText flag, if present.
+RichValue string `json:"richValue,omitempty"`;};
\ No newline at end of file
@@ -0,0 +1,46 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package fjson
+
+import (
+	"github.com/unidoc/unipdf/v3/annotator"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// fieldRichValue returns the rich text value (RV) of the field, if it is a
+// text field with the RichText flag.
+func fieldRichValue(field *model.PdfField) string {
+	text, ok := field.GetContext().(*model.PdfFieldText)
+	if !ok || text.RV == nil || !field.Flags().Has(model.FieldFlagRichText) {
+		return ""
+	}
+
+	switch t := core.TraceToDirectObject(text.RV).(type) {
+	case *core.PdfObjectString:
+		return t.Decoded()
+	case *core.PdfObjectStream:
+		data, err := core.DecodeStream(t)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+	return ""
+}
+
+// richValueText returns the plain text of the rich text value.
+func richValueText(rv string) string {
+	rt, err := annotator.ParseRichText(rv, "")
+	if err != nil {
+		return ""
+	}
+	return rt.Text()
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+// Package annotutil contains helpers shared by the packages which read and
+// write annotations (annotator, fdf and pdfutil).
+package annotutil
+
+import (
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// Markup returns the markup entries of the annotation, or nil if it is not a
+// markup annotation.
+func Markup(annot *model.PdfAnnotation) *model.PdfAnnotationMarkup {
+	switch t := annot.GetContext().(type) {
+	case *model.PdfAnnotationText:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationFreeText:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationLine:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationSquare:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationCircle:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationPolygon:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationPolyLine:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationHighlight:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationUnderline:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationSquiggly:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationStrikeOut:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationCaret:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationStamp:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationInk:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationFileAttachment:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationSound:
+		return t.PdfAnnotationMarkup
+	case *model.PdfAnnotationRedact:
+		return t.PdfAnnotationMarkup
+	}
+	return nil
+}
+
+// ObjectKey returns the key identifying the object: the object number of
+// indirect objects and references when known, or the object itself
+// otherwise.
+func ObjectKey(obj core.PdfObject) interface{} {
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject:
+		if t.ObjectNumber > 0 {
+			return t.ObjectNumber
+		}
+		return t
+	case *core.PdfObjectReference:
+		return t.ObjectNumber
+	}
+	return obj
+}
+
+// ClampUnit clamps the value to the [0, 1] range.
+func ClampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,294 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// jp2Signature is the content of the signature box starting JP2 files.
+var jp2Signature = []byte{0x0D, 0x0A, 0x87, 0x0A}
+
+// Enumerated color spaces of the color specification boxes (T.800 Table
+// I.10 and T.801 Table M.25).
+const (
+	enumYCbCr1  = 1
+	enumYCbCr2  = 3
+	enumYCbCr3  = 4
+	enumCMYK    = 12
+	enumSRGB    = 16
+	enumGray    = 17
+	enumSYCC    = 18
+	enumESRGB   = 20
+	enumROMMRGB = 21
+	enumESYCC   = 24
+)
+
+// Channel types of the channel definition box.
+const (
+	channelColor         = 0
+	channelOpacity       = 1
+	channelPremultiplied = 2
+)
+
+// jp2Header contains the information of the JP2 header box relevant to the
+// decoding of the codestream.
+type jp2Header struct {
+	// colorSpace is the enumerated color space, or 0 if the color space is
+	// specified by an ICC profile or not specified.
+	colorSpace int
+	icc        []byte
+
+	palette  *palette
+	mappings []componentMapping
+	channels []channelDefinition
+}
+
+// palette represents the palette box, which maps the values of a component
+// to the values of several channels.
+type palette struct {
+	entries int
+
+	// bits and signed are the bit depth and signedness of each column, and
+	// values the values of the entries of each column.
+	bits   []int
+	signed []bool
+	values [][]int32
+}
+
+// componentMapping represents an entry of the component mapping box,
+// mapping a component of the codestream to a channel, directly or through a
+// column of the palette.
+type componentMapping struct {
+	component  int
+	usePalette bool
+	column     int
+}
+
+// channelDefinition represents an entry of the channel definition box,
+// specifying the type of a channel and the color it is associated with
+// (1-based, or 0 for the whole image).
+type channelDefinition struct {
+	channel int
+	typ     int
+	assoc   int
+}
+
+// box represents a box of a JP2 file.
+type box struct {
+	typ  string
+	data []byte
+}
+
+// readBoxes returns the boxes contained in `data`.
+func readBoxes(data []byte) ([]box, error) {
+	var boxes []box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, FormatError("truncated box header")
+		}
+		length := uint64(binary.BigEndian.Uint32(data))
+		typ := string(data[4:8])
+		header := uint64(8)
+		switch length {
+		case 0:
+			length = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, FormatError("truncated box header")
+			}
+			length = binary.BigEndian.Uint64(data[8:])
+			header = 16
+		}
+		if length < header {
+			return nil, FormatError("invalid box length")
+		}
+		if length > uint64(len(data)) {
+			// Truncated box, which is tolerated for the last box (usually the
+			// codestream).
+			length = uint64(len(data))
+		}
+		boxes = append(boxes, box{typ: typ, data: data[header:length]})
+		data = data[length:]
+	}
+	return boxes, nil
+}
+
+// isCodestream returns true if `data` starts with the SOC and SIZ markers of
+// a codestream.
+func isCodestream(data []byte) bool {
+	return len(data) >= 4 && data[0] == 0xFF && data[1] == markerSOC && data[2] == 0xFF && data[3] == markerSIZ
+}
+
+// readFile returns the codestream of the JP2 file or raw codestream `data`,
+// and the JP2 header, which is nil for a raw codestream.
+func readFile(data []byte) ([]byte, *jp2Header, error) {
+	if isCodestream(data) {
+		return data, nil, nil
+	}
+	boxes, err := readBoxes(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(boxes) == 0 || boxes[0].typ != "jP  " || !bytes.Equal(boxes[0].data, jp2Signature) {
+		return nil, nil, FormatError("missing JP2 signature")
+	}
+	header := &jp2Header{}
+	var codestream []byte
+	var readHeader func(boxes []box) error
+	readHeader = func(boxes []box) error {
+		for _, b := range boxes {
+			var err error
+			switch b.typ {
+			case "jp2h", "jpch", "jplh", "cgrp":
+				var children []box
+				if children, err = readBoxes(b.data); err == nil {
+					err = readHeader(children)
+				}
+			case "colr":
+				header.readColorSpecification(b.data)
+			case "pclr":
+				if header.palette == nil {
+					header.palette, err = readPalette(b.data)
+				}
+			case "cmap":
+				if header.mappings == nil {
+					header.mappings, err = readComponentMappings(b.data)
+				}
+			case "cdef":
+				if header.channels == nil {
+					header.channels, err = readChannelDefinitions(b.data)
+				}
+			case "jp2c":
+				if codestream == nil {
+					codestream = b.data
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := readHeader(boxes[1:]); err != nil {
+		return nil, nil, err
+	}
+	if codestream == nil {
+		return nil, nil, FormatError("missing codestream")
+	}
+	return codestream, header, nil
+}
+
+// readColorSpecification reads the color specification box. Only the first
+// box specifying the color space in a supported way is taken into account.
+func (h *jp2Header) readColorSpecification(data []byte) {
+	if h.colorSpace != 0 || h.icc != nil || len(data) < 3 {
+		return
+	}
+	switch method := data[0]; method {
+	case 1:
+		if len(data) >= 7 {
+			h.colorSpace = int(binary.BigEndian.Uint32(data[3:]))
+		}
+	case 2, 3:
+		if len(data) > 3 {
+			h.icc = data[3:]
+		}
+	}
+}
+
+func readPalette(data []byte) (*palette, error) {
+	if len(data) < 3 {
+		return nil, FormatError("truncated palette box")
+	}
+	p := &palette{entries: int(binary.BigEndian.Uint16(data))}
+	columns := int(data[2])
+	if p.entries < 1 || p.entries > 1024 || columns < 1 || len(data) < 3+columns {
+		return nil, FormatError("invalid palette box")
+	}
+	size := 0
+	for i := 0; i < columns; i++ {
+		b := data[3+i]
+		p.bits = append(p.bits, int(b&0x7F)+1)
+		p.signed = append(p.signed, b&0x80 != 0)
+		if b&0x7F >= 16 {
+			return nil, UnsupportedError("palette bit depth greater than 16")
+		}
+		size += (int(b&0x7F) + 8) / 8
+	}
+	data = data[3+columns:]
+	if len(data) < size*p.entries {
+		return nil, FormatError("truncated palette box")
+	}
+	p.values = make([][]int32, columns)
+	for i := range p.values {
+		p.values[i] = make([]int32, p.entries)
+	}
+	for e := 0; e < p.entries; e++ {
+		for i := 0; i < columns; i++ {
+			var v uint64
+			for n := (p.bits[i] + 7) / 8; n > 0; n-- {
+				v = v<<8 | uint64(data[0])
+				data = data[1:]
+			}
+			value := int64(v)
+			if p.signed[i] && v&(1<<uint(p.bits[i]-1)) != 0 {
+				value -= 1 << uint(p.bits[i])
+			}
+			p.values[i][e] = int32(value)
+		}
+	}
+	return p, nil
+}
+
+func readComponentMappings(data []byte) ([]componentMapping, error) {
+	if len(data)%4 != 0 {
+		return nil, FormatError("invalid component mapping box")
+	}
+	mappings := make([]componentMapping, 0, len(data)/4)
+	for ; len(data) >= 4; data = data[4:] {
+		mappings = append(mappings, componentMapping{
+			component:  int(binary.BigEndian.Uint16(data)),
+			usePalette: data[2] == 1,
+			column:     int(data[3]),
+		})
+	}
+	return mappings, nil
+}
+
+func readChannelDefinitions(data []byte) ([]channelDefinition, error) {
+	if len(data) < 2 {
+		return nil, FormatError("truncated channel definition box")
+	}
+	n := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	if len(data) < 6*n {
+		return nil, FormatError("truncated channel definition box")
+	}
+	channels := make([]channelDefinition, n)
+	for i := range channels {
+		channels[i] = channelDefinition{
+			channel: int(binary.BigEndian.Uint16(data)),
+			typ:     int(binary.BigEndian.Uint16(data[2:])),
+			assoc:   int(binary.BigEndian.Uint16(data[4:])),
+		}
+		data = data[6:]
+	}
+	return channels, nil
+}
+
+// writeBox appends the box with the specified type and content to `out`.
+func writeBox(out []byte, typ string, data []byte) []byte {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:], uint32(8+len(data)))
+	copy(header[4:], typ)
+	return append(append(out, header[:]...), data...)
+}
@@ -0,0 +1,663 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// Codestream markers (the byte following 0xFF).
+const (
+	markerSOC = 0x4F
+	markerSIZ = 0x51
+	markerCOD = 0x52
+	markerCOC = 0x53
+	markerQCD = 0x5C
+	markerQCC = 0x5D
+	markerRGN = 0x5E
+	markerPOC = 0x5F
+	markerPPM = 0x60
+	markerPPT = 0x61
+	markerSOT = 0x90
+	markerSOP = 0x91
+	markerEPH = 0x92
+	markerSOD = 0x93
+	markerEOC = 0xD9
+)
+
+// Progression orders.
+const (
+	progressionLRCP = iota
+	progressionRLCP
+	progressionRPCL
+	progressionPCRL
+	progressionCPRL
+)
+
+// Quantization styles.
+const (
+	quantizationNone     = 0
+	quantizationDerived  = 1
+	quantizationExpanded = 2
+)
+
+// maxLevels is the maximum number of decomposition levels (T.800 Table
+// A.15).
+const maxLevels = 32
+
+// component contains the properties of a component of the image, from the
+// SIZ marker segment.
+type component struct {
+	precision int
+	signed    bool
+	dx, dy    int
+}
+
+// imageSize contains the properties of the image and of its tiling, from the
+// SIZ marker segment. All the coordinates are on the reference grid.
+type imageSize struct {
+	x1, y1         int
+	x0, y0         int
+	tileW, tileH   int
+	tileX0, tileY0 int
+	components     []component
+}
+
+// tilesAcross and tilesDown return the numbers of tiles in each direction.
+func (s *imageSize) tilesAcross() int { return ceilDiv(s.x1-s.tileX0, s.tileW) }
+func (s *imageSize) tilesDown() int   { return ceilDiv(s.y1-s.tileY0, s.tileH) }
+
+// codingStyle contains the coding parameters of a component, from the COD
+// and COC marker segments.
+type codingStyle struct {
+	levels int
+
+	// xcb and ycb are the base 2 logarithms of the code-block dimensions.
+	xcb, ycb int
+
+	// style contains the code-block coding style flags.
+	style      int
+	reversible bool
+
+	// precincts contains the base 2 logarithms of the precinct dimensions
+	// of each resolution level, PPx in the 4 least significant bits and PPy
+	// in the 4 most significant bits.
+	precincts []uint8
+}
+
+// precinctSize returns the base 2 logarithms of the precinct dimensions of
+// the resolution level `r`.
+func (s *codingStyle) precinctSize(r int) (int, int) {
+	if s.precincts == nil {
+		return 15, 15
+	}
+	return int(s.precincts[r] & 0xF), int(s.precincts[r] >> 4)
+}
+
+// codingDefaults contains the parameters of the COD marker segments which
+// apply to all the components.
+type codingDefaults struct {
+	sop, eph    bool
+	progression int
+	layers      int
+	mct         bool
+	style       codingStyle
+}
+
+// quantization contains the quantization parameters of a component, from
+// the QCD and QCC marker segments.
+type quantization struct {
+	style     int
+	guardBits int
+	steps     []quantizationStep
+}
+
+// quantizationStep is the quantization step size of a subband, defined by
+// its exponent and its mantissa.
+type quantizationStep struct {
+	exponent, mantissa int
+}
+
+// step returns the step size of the subband of the specified kind at
+// resolution level `r` of a component with `levels` decomposition levels.
+func (q *quantization) step(r, kind int) quantizationStep {
+	if q.style == quantizationDerived {
+		s := q.steps[0]
+		if r > 0 {
+			s.exponent -= r - 1
+		}
+		return s
+	}
+	i := 0
+	if r > 0 {
+		i = 1 + 3*(r-1) + kind - 1
+	}
+	if i >= len(q.steps) {
+		i = len(q.steps) - 1
+	}
+	return q.steps[i]
+}
+
+// progressionChange represents an entry of a POC marker segment: the
+// progression order of the packets of the layers before `layerEnd`, of the
+// resolution levels in [resStart, resEnd) of the components in [compStart,
+// compEnd).
+type progressionChange struct {
+	resStart, compStart int
+	layerEnd            int
+	resEnd, compEnd     int
+	order               int
+}
+
+// headerParams contains the parameters specified by the marker segments of
+// the main header or of a tile header. The parameters which are not
+// specified are nil.
+type headerParams struct {
+	cod  *codingDefaults
+	coc  map[int]*codingStyle
+	qcd  *quantization
+	qcc  map[int]*quantization
+	rgn  map[int]int
+	pocs []progressionChange
+}
+
+// tileData contains the data of the tile-parts of a tile.
+type tileData struct {
+	params headerParams
+
+	// data contains the concatenated packet data of the tile-parts, and
+	// headers the packet headers when they are packed in PPM or PPT marker
+	// segments, otherwise nil.
+	data    []byte
+	headers []byte
+}
+
+// codestream represents a parsed codestream.
+type codestream struct {
+	imageSize
+	main  headerParams
+	tiles []*tileData
+}
+
+// codestreamReader reads the marker segments of a codestream.
+type codestreamReader struct {
+	data []byte
+	pos  int
+	cs   *codestream
+
+	// ppm contains the packet headers of each tile-part, from the PPM
+	// marker segments of the main header, if usePPM is true.
+	ppm    [][]byte
+	usePPM bool
+}
+
+// parseCodestream parses the codestream `data`. Only the main header is
+// parsed if `headerOnly` is true.
+func parseCodestream(data []byte, headerOnly bool) (*codestream, error) {
+	r := &codestreamReader{data: data, cs: &codestream{}}
+	if !isCodestream(data) {
+		return nil, FormatError("missing SOC marker")
+	}
+	r.pos = 2
+	if err := r.readMainHeader(); err != nil {
+		return nil, err
+	}
+	if headerOnly {
+		return r.cs, nil
+	}
+	if err := r.readTileParts(); err != nil {
+		return nil, err
+	}
+	return r.cs, nil
+}
+
+// segment reads the marker at the current position and the content of its
+// segment.
+func (r *codestreamReader) segment() (byte, []byte, error) {
+	if r.pos+2 > len(r.data) || r.data[r.pos] != 0xFF {
+		return 0, nil, FormatError("missing marker")
+	}
+	marker := r.data[r.pos+1]
+	r.pos += 2
+	if marker == markerSOD || marker == markerEOC {
+		return marker, nil, nil
+	}
+	if r.pos+2 > len(r.data) {
+		return 0, nil, FormatError("truncated marker segment")
+	}
+	length := int(binary.BigEndian.Uint16(r.data[r.pos:]))
+	if length < 2 || r.pos+length > len(r.data) {
+		return 0, nil, FormatError("truncated marker segment")
+	}
+	content := r.data[r.pos+2 : r.pos+length]
+	r.pos += length
+	return marker, content, nil
+}
+
+func (r *codestreamReader) readMainHeader() error {
+	cs := r.cs
+	var ppm [][]byte
+	var ppmIndex []int
+	first := true
+loop:
+	for {
+		if r.pos+2 <= len(r.data) && r.data[r.pos] == 0xFF && r.data[r.pos+1] == markerSOT {
+			break
+		}
+		marker, content, err := r.segment()
+		if err != nil {
+			return err
+		}
+		if first != (marker == markerSIZ) {
+			return FormatError("missing SIZ marker")
+		}
+		first = false
+		switch marker {
+		case markerSIZ:
+			err = r.readSIZ(content)
+		case markerPPM:
+			if len(content) < 1 {
+				return FormatError("invalid PPM marker segment")
+			}
+			ppmIndex = append(ppmIndex, int(content[0]))
+			ppm = append(ppm, content[1:])
+		case markerEOC:
+			break loop
+		default:
+			err = r.readParam(&cs.main, marker, content)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if cs.main.cod == nil {
+		return FormatError("missing COD marker")
+	}
+	if cs.main.qcd == nil {
+		return FormatError("missing QCD marker")
+	}
+	if len(ppm) > 0 {
+		// The packet headers of all the tile-parts are concatenated, each
+		// preceded by their length.
+		order := make([]int, len(ppm))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool { return ppmIndex[order[i]] < ppmIndex[order[j]] })
+		var all []byte
+		for _, i := range order {
+			all = append(all, ppm[i]...)
+		}
+		r.ppm = nil
+		for len(all) >= 4 {
+			n := int(binary.BigEndian.Uint32(all))
+			all = all[4:]
+			if n > len(all) {
+				n = len(all)
+			}
+			r.ppm = append(r.ppm, all[:n])
+			all = all[n:]
+		}
+		r.usePPM = true
+	}
+	return nil
+}
+
+func (r *codestreamReader) readSIZ(data []byte) error {
+	if len(data) < 36 {
+		return FormatError("truncated SIZ marker segment")
+	}
+	s := &r.cs.imageSize
+	u32 := func(i int) int { return int(binary.BigEndian.Uint32(data[i:])) }
+	s.x1, s.y1 = u32(2), u32(6)
+	s.x0, s.y0 = u32(10), u32(14)
+	s.tileW, s.tileH = u32(18), u32(22)
+	s.tileX0, s.tileY0 = u32(26), u32(30)
+	n := int(binary.BigEndian.Uint16(data[34:]))
+	if s.x1 <= s.x0 || s.y1 <= s.y0 || s.x1 < 0 || s.y1 < 0 || s.x0 < 0 || s.y0 < 0 ||
+		s.tileW <= 0 || s.tileH <= 0 || s.tileX0 < 0 || s.tileY0 < 0 ||
+		s.tileX0 > s.x0 || s.tileY0 > s.y0 || s.tileX0+s.tileW <= s.x0 || s.tileY0+s.tileH <= s.y0 {
+		return FormatError("invalid image size")
+	}
+	if n < 1 || n > 16384 || len(data) < 36+3*n {
+		return FormatError("invalid number of components")
+	}
+	if s.tilesAcross()*s.tilesDown() > 65535 {
+		return FormatError("too many tiles")
+	}
+	for i := 0; i < n; i++ {
+		c := data[36+3*i:]
+		comp := component{
+			precision: int(c[0]&0x7F) + 1,
+			signed:    c[0]&0x80 != 0,
+			dx:        int(c[1]),
+			dy:        int(c[2]),
+		}
+		if comp.precision > 16 {
+			return UnsupportedError("bit depth greater than 16")
+		}
+		if comp.dx == 0 || comp.dy == 0 {
+			return FormatError("invalid component subsampling")
+		}
+		s.components = append(s.components, comp)
+	}
+	return nil
+}
+
+// componentIndex reads the index of a component in a marker segment, on 1
+// or 2 bytes depending on the number of components.
+func (r *codestreamReader) componentIndex(data []byte) (int, []byte, error) {
+	if len(r.cs.components) < 257 {
+		if len(data) < 1 {
+			return 0, nil, FormatError("truncated marker segment")
+		}
+		return int(data[0]), data[1:], nil
+	}
+	if len(data) < 2 {
+		return 0, nil, FormatError("truncated marker segment")
+	}
+	return int(binary.BigEndian.Uint16(data)), data[2:], nil
+}
+
+// readParam reads a marker segment specifying coding parameters into `p`.
+// The unknown marker segments are ignored.
+func (r *codestreamReader) readParam(p *headerParams, marker byte, data []byte) error {
+	switch marker {
+	case markerCOD:
+		if len(data) < 5 {
+			return FormatError("truncated COD marker segment")
+		}
+		cod := &codingDefaults{
+			sop:         data[0]&2 != 0,
+			eph:         data[0]&4 != 0,
+			progression: int(data[1]),
+			layers:      int(binary.BigEndian.Uint16(data[2:])),
+			mct:         data[4] != 0,
+		}
+		if cod.layers < 1 || cod.progression > progressionCPRL {
+			return FormatError("invalid COD marker segment")
+		}
+		if err := readCodingStyle(&cod.style, data[5:], data[0]&1 != 0); err != nil {
+			return err
+		}
+		p.cod = cod
+	case markerCOC:
+		c, data, err := r.componentIndex(data)
+		if err != nil {
+			return err
+		}
+		if len(data) < 1 || c >= len(r.cs.components) {
+			return FormatError("invalid COC marker segment")
+		}
+		style := &codingStyle{}
+		if err := readCodingStyle(style, data[1:], data[0]&1 != 0); err != nil {
+			return err
+		}
+		if p.coc == nil {
+			p.coc = map[int]*codingStyle{}
+		}
+		p.coc[c] = style
+	case markerQCD:
+		q, err := readQuantization(data)
+		if err != nil {
+			return err
+		}
+		p.qcd = q
+	case markerQCC:
+		c, data, err := r.componentIndex(data)
+		if err != nil {
+			return err
+		}
+		if c >= len(r.cs.components) {
+			return FormatError("invalid QCC marker segment")
+		}
+		q, err := readQuantization(data)
+		if err != nil {
+			return err
+		}
+		if p.qcc == nil {
+			p.qcc = map[int]*quantization{}
+		}
+		p.qcc[c] = q
+	case markerRGN:
+		c, data, err := r.componentIndex(data)
+		if err != nil {
+			return err
+		}
+		if len(data) < 2 || c >= len(r.cs.components) {
+			return FormatError("invalid RGN marker segment")
+		}
+		if data[0] != 0 {
+			return UnsupportedError("region of interest style")
+		}
+		if p.rgn == nil {
+			p.rgn = map[int]int{}
+		}
+		p.rgn[c] = int(data[1])
+	case markerPOC:
+		size := 7
+		if len(r.cs.components) >= 257 {
+			size = 9
+		}
+		for ; len(data) >= size; data = data[size:] {
+			var change progressionChange
+			e := data
+			change.resStart = int(e[0])
+			change.compStart, e, _ = r.componentIndex(e[1:])
+			change.layerEnd = int(binary.BigEndian.Uint16(e))
+			change.resEnd = int(e[2])
+			change.compEnd, e, _ = r.componentIndex(e[3:])
+			if change.compEnd == 0 {
+				change.compEnd = 256
+			}
+			change.order = int(e[0])
+			if change.order > progressionCPRL {
+				return FormatError("invalid POC marker segment")
+			}
+			p.pocs = append(p.pocs, change)
+		}
+	}
+	return nil
+}
+
+// readCodingStyle reads the SPcod or SPcoc parameters of a COD or COC marker
+// segment.
+func readCodingStyle(s *codingStyle, data []byte, precincts bool) error {
+	if len(data) < 5 {
+		return FormatError("truncated coding style")
+	}
+	s.levels = int(data[0])
+	s.xcb = int(data[1]&0xF) + 2
+	s.ycb = int(data[2]&0xF) + 2
+	s.style = int(data[3])
+	s.reversible = data[4] == 1
+	if s.levels > maxLevels || s.xcb+s.ycb > 12 {
+		return FormatError("invalid coding style")
+	}
+	if data[4] > 1 {
+		return UnsupportedError("wavelet transformation")
+	}
+	if precincts {
+		if len(data) < 5+s.levels+1 {
+			return FormatError("truncated precinct sizes")
+		}
+		s.precincts = append([]uint8(nil), data[5:5+s.levels+1]...)
+		for r, p := range s.precincts {
+			if r > 0 && (p&0xF == 0 || p>>4 == 0) {
+				return FormatError("invalid precinct size")
+			}
+		}
+	}
+	return nil
+}
+
+// readQuantization reads the Sqcd and SPqcd parameters of a QCD or QCC
+// marker segment.
+func readQuantization(data []byte) (*quantization, error) {
+	if len(data) < 1 {
+		return nil, FormatError("truncated quantization")
+	}
+	q := &quantization{style: int(data[0] & 0x1F), guardBits: int(data[0] >> 5)}
+	data = data[1:]
+	switch q.style {
+	case quantizationNone:
+		for _, b := range data {
+			q.steps = append(q.steps, quantizationStep{exponent: int(b >> 3)})
+		}
+	case quantizationDerived, quantizationExpanded:
+		for ; len(data) >= 2; data = data[2:] {
+			v := int(binary.BigEndian.Uint16(data))
+			q.steps = append(q.steps, quantizationStep{exponent: v >> 11, mantissa: v & 0x7FF})
+		}
+	default:
+		return nil, FormatError("invalid quantization style")
+	}
+	if len(q.steps) == 0 {
+		return nil, FormatError("missing quantization step sizes")
+	}
+	return q, nil
+}
+
+// readTileParts reads the tile-parts of the codestream. A truncated
+// codestream is tolerated, its missing data being treated as missing
+// packets.
+func (r *codestreamReader) readTileParts() error {
+	cs := r.cs
+	cs.tiles = make([]*tileData, cs.tilesAcross()*cs.tilesDown())
+	tilePart := 0
+	for r.pos+2 <= len(r.data) && r.data[r.pos] == 0xFF && r.data[r.pos+1] == markerSOT {
+		start := r.pos
+		_, content, err := r.segment()
+		if err != nil || len(content) < 8 {
+			break
+		}
+		index := int(binary.BigEndian.Uint16(content))
+		length := int(binary.BigEndian.Uint32(content[2:]))
+		if index >= len(cs.tiles) {
+			return FormatError("invalid tile index")
+		}
+		end := len(r.data)
+		if length != 0 && start+length < end {
+			end = start + length
+		}
+		t := cs.tiles[index]
+		if t == nil {
+			t = &tileData{}
+			cs.tiles[index] = t
+		}
+		for {
+			marker, content, err := r.segment()
+			if err != nil {
+				return nil
+			}
+			if marker == markerSOD {
+				break
+			}
+			switch marker {
+			case markerPPT:
+				if len(content) > 0 {
+					t.headers = append(t.headers, content[1:]...)
+				}
+			case markerEOC:
+				return nil
+			default:
+				if err := r.readParam(&t.params, marker, content); err != nil {
+					return err
+				}
+			}
+		}
+		if r.pos < end {
+			t.data = append(t.data, r.data[r.pos:end]...)
+		}
+		if r.usePPM {
+			if tilePart < len(r.ppm) {
+				t.headers = append(t.headers, r.ppm[tilePart]...)
+			}
+			if t.headers == nil {
+				t.headers = []byte{}
+			}
+		}
+		tilePart++
+		r.pos = end
+	}
+	return nil
+}
+
+// codingDefaults returns the coding parameters of the tile which apply to
+// all the components.
+func (cs *codestream) codingDefaults(t *tileData) *codingDefaults {
+	if t.params.cod != nil {
+		return t.params.cod
+	}
+	return cs.main.cod
+}
+
+// codingStyle returns the coding style of the component `c` of the tile.
+func (cs *codestream) codingStyle(t *tileData, c int) *codingStyle {
+	if s, ok := t.params.coc[c]; ok {
+		return s
+	}
+	if t.params.cod != nil {
+		return &t.params.cod.style
+	}
+	if s, ok := cs.main.coc[c]; ok {
+		return s
+	}
+	return &cs.main.cod.style
+}
+
+// quantization returns the quantization parameters of the component `c` of
+// the tile.
+func (cs *codestream) quantization(t *tileData, c int) *quantization {
+	if q, ok := t.params.qcc[c]; ok {
+		return q
+	}
+	if t.params.qcd != nil {
+		return t.params.qcd
+	}
+	if q, ok := cs.main.qcc[c]; ok {
+		return q
+	}
+	return cs.main.qcd
+}
+
+// roiShift returns the region of interest shift of the component `c` of the
+// tile.
+func (cs *codestream) roiShift(t *tileData, c int) int {
+	if s, ok := t.params.rgn[c]; ok {
+		return s
+	}
+	return cs.main.rgn[c]
+}
+
+// progressionChanges returns the progression order changes of the tile.
+func (cs *codestream) progressionChanges(t *tileData) []progressionChange {
+	if t.params.pocs != nil {
+		return t.params.pocs
+	}
+	return cs.main.pocs
+}
+
+// ceilDiv returns a/b rounded up, for b > 0.
+func ceilDiv(a, b int) int {
+	if a <= 0 {
+		return -(-a / b)
+	}
+	return (a + b - 1) / b
+}
+
+// floorDiv returns a/b rounded down, for b > 0.
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return -ceilDiv(-a, b)
+	}
+	return a / b
+}
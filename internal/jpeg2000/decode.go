@@ -0,0 +1,621 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+import (
+	"bytes"
+	"math"
+)
+
+// channel is a channel of the image: a component of the codestream, mapped
+// through a column of the palette if `column` is not negative.
+type channel struct {
+	comp      int
+	column    int
+	precision int
+	signed    bool
+}
+
+// layout describes how the channels of the image are built from the
+// components of the codestream.
+type layout struct {
+	Config
+
+	colors []channel
+	alpha  *channel
+
+	// ycc indicates that the color channels are YCbCr components, to be
+	// converted to RGB.
+	ycc     bool
+	palette *palette
+}
+
+// DecodeConfig returns the properties of the JPEG 2000 image `data` without
+// decoding it.
+func DecodeConfig(data []byte) (Config, error) {
+	codestream, header, err := readFile(data)
+	if err != nil {
+		return Config{}, err
+	}
+	cs, err := parseCodestream(codestream, true)
+	if err != nil {
+		return Config{}, err
+	}
+	l, err := newLayout(cs, header)
+	if err != nil {
+		return Config{}, err
+	}
+	return l.Config, nil
+}
+
+// Decode decodes the JPEG 2000 image `data`, in the JP2 or JPX file format
+// or as a raw codestream.
+func Decode(data []byte) (*Image, error) {
+	codestream, header, err := readFile(data)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := parseCodestream(codestream, false)
+	if err != nil {
+		return nil, err
+	}
+	l, err := newLayout(cs, header)
+	if err != nil {
+		return nil, err
+	}
+	planes, err := decodeComponents(cs)
+	if err != nil {
+		return nil, err
+	}
+	return l.image(cs, planes), nil
+}
+
+// newLayout determines the channels of the image from the components of the
+// codestream and the JP2 header, if any.
+func newLayout(cs *codestream, header *jp2Header) (*layout, error) {
+	l := &layout{}
+	l.Width, l.Height = cs.x1-cs.x0, cs.y1-cs.y0
+
+	var channels []channel
+	if header != nil && header.palette != nil && header.mappings != nil {
+		l.palette = header.palette
+		for _, m := range header.mappings {
+			if m.component >= len(cs.components) {
+				return nil, FormatError("invalid component mapping")
+			}
+			comp := cs.components[m.component]
+			ch := channel{comp: m.component, column: -1, precision: comp.precision, signed: comp.signed}
+			if m.usePalette {
+				if m.column >= len(l.palette.values) {
+					return nil, FormatError("invalid palette column")
+				}
+				ch.column = m.column
+				ch.precision, ch.signed = l.palette.bits[m.column], l.palette.signed[m.column]
+			}
+			channels = append(channels, ch)
+		}
+	} else {
+		for c, comp := range cs.components {
+			channels = append(channels, channel{comp: c, column: -1, precision: comp.precision, signed: comp.signed})
+		}
+	}
+
+	numColors := 0
+	if header != nil {
+		switch header.colorSpace {
+		case enumSRGB, enumESRGB, enumROMMRGB:
+			l.ColorSpace, numColors = ColorSpaceRGB, 3
+		case enumSYCC, enumESYCC, enumYCbCr1, enumYCbCr2, enumYCbCr3:
+			l.ColorSpace, numColors = ColorSpaceRGB, 3
+			l.ycc = true
+		case enumGray:
+			l.ColorSpace, numColors = ColorSpaceGray, 1
+		case enumCMYK:
+			l.ColorSpace, numColors = ColorSpaceCMYK, 4
+		}
+		if header.icc != nil {
+			l.ICCProfile = header.icc
+			numColors = iccComponents(header.icc)
+		}
+	}
+	if numColors == 0 || numColors > len(channels) {
+		l.ColorSpace, l.ycc = ColorSpaceUnknown, false
+		numColors = len(channels)
+		if numColors == 2 {
+			numColors = 1
+		}
+	}
+
+	colors := make([]int, numColors)
+	alpha := -1
+	if header != nil && header.channels != nil {
+		for i := range colors {
+			colors[i] = -1
+		}
+		for _, d := range header.channels {
+			if d.channel >= len(channels) {
+				continue
+			}
+			switch d.typ {
+			case channelColor:
+				if d.assoc >= 1 && d.assoc <= numColors {
+					colors[d.assoc-1] = d.channel
+				}
+			case channelOpacity, channelPremultiplied:
+				if d.assoc == 0 && alpha < 0 {
+					alpha = d.channel
+					l.Premultiplied = d.typ == channelPremultiplied
+				}
+			}
+		}
+		for i := range colors {
+			if colors[i] < 0 {
+				colors[i] = i
+			}
+		}
+	} else {
+		for i := range colors {
+			colors[i] = i
+		}
+		if len(channels) > numColors {
+			alpha = numColors
+		}
+	}
+
+	used := make([]channel, 0, numColors+1)
+	for _, i := range colors {
+		l.colors = append(l.colors, channels[i])
+		used = append(used, channels[i])
+	}
+	if alpha >= 0 {
+		l.alpha = &channels[alpha]
+		l.HasAlpha = true
+		used = append(used, channels[alpha])
+	}
+	l.ColorComponents = len(l.colors)
+
+	// The samples are output with their precision if possible, otherwise
+	// scaled to 8 or 16 bits.
+	precision, uniform := used[0].precision, true
+	for _, ch := range used {
+		if ch.precision != precision {
+			uniform = false
+		}
+		precision = maxInt(precision, ch.precision)
+	}
+	switch {
+	case uniform && (precision == 1 || precision == 2 || precision == 4 || precision == 8 || precision == 16):
+		l.BitsPerComponent = precision
+	case precision <= 8:
+		l.BitsPerComponent = 8
+	default:
+		l.BitsPerComponent = 16
+	}
+	return l, nil
+}
+
+// iccComponents returns the number of components of the color space of the
+// ICC profile, or 0 if unknown.
+func iccComponents(profile []byte) int {
+	if len(profile) < 20 {
+		return 0
+	}
+	switch string(bytes.TrimSpace(profile[16:20])) {
+	case "GRAY":
+		return 1
+	case "RGB", "Lab", "XYZ", "YCbr":
+		return 3
+	case "CMYK":
+		return 4
+	}
+	return 0
+}
+
+// plane contains the decoded samples of a component of the image.
+type plane struct {
+	x0, y0        int
+	width, height int
+	samples       []int32
+}
+
+// decodeComponents decodes the tiles of the codestream and returns the
+// samples of each component.
+func decodeComponents(cs *codestream) ([]*plane, error) {
+	planes := make([]*plane, len(cs.components))
+	for c, comp := range cs.components {
+		p := &plane{x0: ceilDiv(cs.x0, comp.dx), y0: ceilDiv(cs.y0, comp.dy)}
+		p.width = ceilDiv(cs.x1, comp.dx) - p.x0
+		p.height = ceilDiv(cs.y1, comp.dy) - p.y0
+		p.samples = make([]int32, p.width*p.height)
+		if !comp.signed {
+			// Missing tiles are mid-gray.
+			mid := int32(1) << uint(comp.precision-1)
+			for i := range p.samples {
+				p.samples[i] = mid
+			}
+		}
+		planes[c] = p
+	}
+	d := &tileDecoder{}
+	for index, td := range cs.tiles {
+		if td == nil {
+			continue
+		}
+		t, err := newTile(cs, index, td)
+		if err != nil {
+			return nil, err
+		}
+		samples := d.decodeTile(cs, t, td)
+		for c, tc := range t.components {
+			p := planes[c]
+			w := tc.x1 - tc.x0
+			for y := tc.y0; y < tc.y1; y++ {
+				copy(p.samples[(y-p.y0)*p.width+tc.x0-p.x0:], samples[c][(y-tc.y0)*w:(y-tc.y0+1)*w])
+			}
+		}
+	}
+	return planes, nil
+}
+
+// tileDecoder decodes the tiles of a codestream.
+type tileDecoder struct {
+	blocks blockDecoder
+	w53    wavelet53
+	w97    wavelet97
+}
+
+// decodeTile decodes the tile and returns the samples of its components.
+func (d *tileDecoder) decodeTile(cs *codestream, t *tile, td *tileData) [][]int32 {
+	pr := &packetReader{data: td.data, headers: td.headers}
+	for _, pk := range t.packets(cs.progressionChanges(td)) {
+		if err := pr.decodePacket(t, pk); err != nil {
+			// Truncated tile: the data of the remaining packets is missing.
+			break
+		}
+	}
+
+	ints := make([][]int32, len(t.components))
+	floats := make([][]float32, len(t.components))
+	for c, tc := range t.components {
+		if tc.style.reversible {
+			ints[c] = d.reconstructReversible(tc)
+		} else {
+			floats[c] = d.reconstructIrreversible(tc)
+		}
+	}
+
+	if t.defaults.mct && len(t.components) >= 3 {
+		n := len(ints[0]) + len(floats[0])
+		if n == len(ints[1])+len(floats[1]) && n == len(ints[2])+len(floats[2]) {
+			if ints[0] != nil && ints[1] != nil && ints[2] != nil {
+				inverseRCT(ints[0], ints[1], ints[2])
+			} else {
+				for c := 0; c < 3; c++ {
+					if ints[c] != nil {
+						floats[c] = make([]float32, n)
+						for i, v := range ints[c] {
+							floats[c][i] = float32(v)
+						}
+						ints[c] = nil
+					}
+				}
+				inverseICT(floats[0], floats[1], floats[2])
+			}
+		}
+	}
+
+	for c, comp := range cs.components {
+		if floats[c] != nil {
+			ints[c] = make([]int32, len(floats[c]))
+			for i, v := range floats[c] {
+				ints[c][i] = int32(math.Floor(float64(v) + 0.5))
+			}
+		}
+		min, max := int32(0), int32(1)<<uint(comp.precision)-1
+		shift := int32(1) << uint(comp.precision-1)
+		if comp.signed {
+			min, max, shift = -shift, shift-1, 0
+		}
+		samples := ints[c]
+		for i, v := range samples {
+			v += shift
+			if v < min {
+				v = min
+			} else if v > max {
+				v = max
+			}
+			samples[i] = v
+		}
+	}
+	return ints
+}
+
+// decodeBand decodes the code-blocks of the subband and calls `set` for each
+// non-zero coefficient, with its index in the subband, its magnitude and
+// the number of decoded bit-planes.
+func (d *tileDecoder) decodeBand(tc *tileComponent, b *subband, set func(i int, magnitude int32, bits int, negative bool)) {
+	width := b.x1 - b.x0
+	for _, prec := range b.precincts {
+		for _, cb := range prec.blocks {
+			if cb.passes == 0 {
+				continue
+			}
+			d.blocks.decodeBlock(cb, b.kind, tc.style.style)
+			w := cb.x1 - cb.x0
+			for y := 0; y < cb.y1-cb.y0; y++ {
+				for x := 0; x < w; x++ {
+					i := y*w + x
+					if m := d.blocks.magnitude[i]; m != 0 {
+						negative := d.blocks.flags[(y+1)*d.blocks.stride+x+1]&flagNegative != 0
+						set((cb.y0-b.y0+y)*width+cb.x0-b.x0+x, m, int(d.blocks.bits[i]), negative)
+					}
+				}
+			}
+		}
+	}
+}
+
+// interleave returns the position in the resolution level of the
+// coefficient at index `i` of the subband `b`, or -1 if it is outside the
+// resolution level.
+func interleave(res *resolution, b *subband, i int) int {
+	bw := b.x1 - b.x0
+	x := 2*(b.x0+i%bw) + b.kind&1 - res.x0
+	y := 2*(b.y0+i/bw) + b.kind>>1 - res.y0
+	w, h := res.x1-res.x0, res.y1-res.y0
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return -1
+	}
+	return y*w + x
+}
+
+// reconstructReversible returns the samples of the tile component coded
+// with the reversible transformation.
+func (d *tileDecoder) reconstructReversible(tc *tileComponent) []int32 {
+	var out []int32
+	var prev *resolution
+	for r, res := range tc.resolutions {
+		w, h := res.x1-res.x0, res.y1-res.y0
+		data := make([]int32, w*h)
+		if r > 0 {
+			for i, v := range out {
+				pw := prev.x1 - prev.x0
+				x := 2*(prev.x0+i%pw) - res.x0
+				y := 2*(prev.y0+i/pw) - res.y0
+				if x >= 0 && y >= 0 && x < w && y < h {
+					data[y*w+x] = v
+				}
+			}
+		}
+		for _, b := range res.bands {
+			mb := b.magnitudeBits
+			set := func(i int, m int32, bits int, negative bool) {
+				if r > 0 {
+					if i = interleave(res, b, i); i < 0 {
+						return
+					}
+				}
+				if bits < mb {
+					m <<= uint(mb - bits)
+				} else if bits > mb {
+					m >>= uint(bits - mb)
+				}
+				if tc.roiShift > 0 && m >= 1<<uint(tc.roiShift) {
+					m >>= uint(tc.roiShift)
+				}
+				if negative {
+					m = -m
+				}
+				data[i] = m
+			}
+			d.decodeBand(tc, b, set)
+		}
+		if r > 0 && w > 0 && h > 0 {
+			d.w53.inverse2D(data, w, h, res.x0, res.y0)
+		}
+		out, prev = data, res
+	}
+	return out
+}
+
+// reconstructIrreversible returns the samples of the tile component coded
+// with the irreversible transformation.
+func (d *tileDecoder) reconstructIrreversible(tc *tileComponent) []float32 {
+	var out []float32
+	var prev *resolution
+	for r, res := range tc.resolutions {
+		w, h := res.x1-res.x0, res.y1-res.y0
+		data := make([]float32, w*h)
+		if r > 0 {
+			for i, v := range out {
+				pw := prev.x1 - prev.x0
+				x := 2*(prev.x0+i%pw) - res.x0
+				y := 2*(prev.y0+i/pw) - res.y0
+				if x >= 0 && y >= 0 && x < w && y < h {
+					data[y*w+x] = v
+				}
+			}
+		}
+		for _, b := range res.bands {
+			mb := b.magnitudeBits
+			step := b.step
+			set := func(i int, m int32, bits int, negative bool) {
+				if r > 0 {
+					if i = interleave(res, b, i); i < 0 {
+						return
+					}
+				}
+				v := math.Ldexp(float64(m)+0.5, mb-bits)
+				if tc.roiShift > 0 && v >= math.Ldexp(1, tc.roiShift) {
+					v = math.Ldexp(v, -tc.roiShift)
+				}
+				v *= step
+				if negative {
+					v = -v
+				}
+				data[i] = float32(v)
+			}
+			d.decodeBand(tc, b, set)
+		}
+		if r > 0 && w > 0 && h > 0 {
+			d.w97.inverse2D(data, w, h, res.x0, res.y0)
+		}
+		out, prev = data, res
+	}
+	return out
+}
+
+// inverseRCT applies the inverse reversible component transformation.
+func inverseRCT(y0, y1, y2 []int32) {
+	for i := range y0 {
+		g := y0[i] - (y1[i]+y2[i])>>2
+		y0[i], y1[i], y2[i] = y2[i]+g, g, y1[i]+g
+	}
+}
+
+// inverseICT applies the inverse irreversible component transformation.
+func inverseICT(y0, y1, y2 []float32) {
+	for i := range y0 {
+		y, cb, cr := y0[i], y1[i], y2[i]
+		y0[i] = y + 1.402*cr
+		y1[i] = y - 0.344136*cb - 0.714136*cr
+		y2[i] = y + 1.772*cb
+	}
+}
+
+// image returns the image made of the decoded components.
+func (l *layout) image(cs *codestream, planes []*plane) *Image {
+	img := &Image{Config: l.Config}
+	n := l.Width * l.Height
+	bpc := l.BitsPerComponent
+
+	// Index of the sample of each component for each column and row of the
+	// image, the components being upsampled if subsampled.
+	xs := make([][]int, len(planes))
+	ys := make([][]int, len(planes))
+	for c, p := range planes {
+		comp := cs.components[c]
+		xs[c] = make([]int, l.Width)
+		for x := range xs[c] {
+			xs[c][x] = clampInt(floorDiv(cs.x0+x, comp.dx)-p.x0, 0, p.width-1)
+		}
+		ys[c] = make([]int, l.Height)
+		for y := range ys[c] {
+			ys[c][y] = clampInt(floorDiv(cs.y0+y, comp.dy)-p.y0, 0, p.height-1)
+		}
+	}
+	values := func(ch channel) []int32 {
+		p := planes[ch.comp]
+		out := make([]int32, n)
+		for y := 0; y < l.Height; y++ {
+			row := p.samples[ys[ch.comp][y]*p.width:]
+			for x := 0; x < l.Width; x++ {
+				v := row[xs[ch.comp][x]]
+				if ch.column >= 0 {
+					v = l.palette.values[ch.column][clampInt(int(v), 0, l.palette.entries-1)]
+				}
+				if ch.signed {
+					v += 1 << uint(ch.precision-1)
+				}
+				out[y*l.Width+x] = v
+			}
+		}
+		return out
+	}
+
+	colors := make([][]int32, len(l.colors))
+	for i, ch := range l.colors {
+		colors[i] = values(ch)
+		scaleSamples(colors[i], ch.precision, bpc)
+	}
+	if l.ycc && len(colors) >= 3 {
+		yccToRGB(colors[0], colors[1], colors[2], bpc)
+	}
+	img.Data = packSamples(colors, l.Width, l.Height, bpc)
+	if l.alpha != nil {
+		alpha := values(*l.alpha)
+		scaleSamples(alpha, l.alpha.precision, bpc)
+		img.Alpha = packSamples([][]int32{alpha}, l.Width, l.Height, bpc)
+	}
+	return img
+}
+
+// scaleSamples scales the samples from `precision` to `bpc` bits.
+func scaleSamples(samples []int32, precision, bpc int) {
+	if precision == bpc {
+		return
+	}
+	max := int64(1)<<uint(bpc) - 1
+	from := int64(1)<<uint(precision) - 1
+	for i, v := range samples {
+		samples[i] = int32((int64(v)*max + from/2) / from)
+	}
+}
+
+// yccToRGB converts YCbCr samples of `bpc` bits to RGB.
+func yccToRGB(y0, y1, y2 []int32, bpc int) {
+	max := float64(int(1)<<uint(bpc) - 1)
+	mid := float64(int(1) << uint(bpc-1))
+	for i := range y0 {
+		y, cb, cr := float64(y0[i]), float64(y1[i])-mid, float64(y2[i])-mid
+		y0[i] = int32(clampFloat(y+1.402*cr, max))
+		y1[i] = int32(clampFloat(y-0.344136*cb-0.714136*cr, max))
+		y2[i] = int32(clampFloat(y+1.772*cb, max))
+	}
+}
+
+// packSamples returns the samples of the channels, interleaved and packed
+// with `bpc` bits per sample.
+func packSamples(channels [][]int32, width, height, bpc int) []byte {
+	stride := bytesPerLine(width, len(channels), bpc)
+	out := make([]byte, stride*height)
+	for y := 0; y < height; y++ {
+		row := out[y*stride:]
+		bit := 0
+		for x := 0; x < width; x++ {
+			for _, ch := range channels {
+				v := uint32(ch[y*width+x])
+				switch bpc {
+				case 8:
+					row[bit>>3] = byte(v)
+				case 16:
+					row[bit>>3] = byte(v >> 8)
+					row[bit>>3+1] = byte(v)
+				default:
+					row[bit>>3] |= byte(v<<uint(8-bpc-bit&7)) & byte(0xFF>>uint(bit&7))
+				}
+				bit += bpc
+			}
+		}
+	}
+	return out
+}
+
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// clampFloat rounds `v` to the nearest integer in [0, max].
+func clampFloat(v, max float64) float64 {
+	v = math.Floor(v + 0.5)
+	if v < 0 {
+		return 0
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,732 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+)
+
+// DefaultQuality is the default quality of the lossy compression.
+const DefaultQuality = 75
+
+// EncodeOptions contains the options for encoding images.
+type EncodeOptions struct {
+	// Quality is the quality of the lossy compression, from 1 (smallest
+	// output) to 100 (best quality), with a scale similar to the one of
+	// JPEG encoders. Defaults to DefaultQuality.
+	Quality int
+
+	// Lossless selects the reversible transformations, which preserve the
+	// samples of the image exactly. The quality is not used in this case.
+	Lossless bool
+}
+
+// Parameters of the encoder.
+const (
+	// encodeMaxLevels is the maximum number of decomposition levels.
+	encodeMaxLevels = 5
+
+	// encodeBlockSize is the base 2 logarithm of the code-block dimensions.
+	encodeBlockSize = 6
+
+	// encodeTileSize is the size of the tiles of the images whose width or
+	// height exceeds encodeMaxUntiled. Smaller images are not tiled.
+	encodeTileSize   = 2048
+	encodeMaxUntiled = 4096
+
+	// encodeGuardBits is the number of guard bits of the reversible
+	// transformation.
+	encodeGuardBits = 2
+)
+
+// Encode encodes the image in the JP2 file format, or the JPX file format
+// for CMYK images. The images must have 1, 3 or 4 color components, and
+// optionally an alpha channel.
+func Encode(img *Image, opts *EncodeOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &EncodeOptions{}
+	}
+	quality := opts.Quality
+	if quality <= 0 {
+		quality = DefaultQuality
+	}
+	if quality > 100 {
+		quality = 100
+	}
+	w, h, bpc := img.Width, img.Height, img.BitsPerComponent
+	if w <= 0 || h <= 0 {
+		return nil, errors.New("jpeg2000: invalid image dimensions")
+	}
+	colors := img.ColorComponents
+	if colors != 1 && colors != 3 && colors != 4 {
+		return nil, errors.New("jpeg2000: unsupported number of color components")
+	}
+	if bpc != 1 && bpc != 2 && bpc != 4 && bpc != 8 && bpc != 16 {
+		return nil, errors.New("jpeg2000: unsupported number of bits per component")
+	}
+	if len(img.Data) < bytesPerLine(w, colors, bpc)*h {
+		return nil, errors.New("jpeg2000: image data too short")
+	}
+	planes := unpackSamples(img.Data, w, h, colors, bpc)
+	if img.HasAlpha && img.Alpha != nil {
+		if len(img.Alpha) < bytesPerLine(w, 1, bpc)*h {
+			return nil, errors.New("jpeg2000: alpha data too short")
+		}
+		planes = append(planes, unpackSamples(img.Alpha, w, h, 1, bpc)...)
+	}
+
+	e := &encoder{
+		lossless: opts.Lossless,
+		mct:      colors == 3,
+		quality:  quality,
+	}
+	codestream, err := e.encodeCodestream(planes, w, h, bpc)
+	if err != nil {
+		return nil, err
+	}
+
+	colorSpace := img.ColorSpace
+	if colorSpace == ColorSpaceUnknown {
+		colorSpace = [5]ColorSpace{0, ColorSpaceGray, 0, ColorSpaceRGB, ColorSpaceCMYK}[colors]
+	}
+	return writeFile(codestream, img, len(planes), colorSpace), nil
+}
+
+// unpackSamples returns the samples of each of the `n` components of the
+// packed image data.
+func unpackSamples(data []byte, w, h, n, bpc int) [][]int32 {
+	planes := make([][]int32, n)
+	for c := range planes {
+		planes[c] = make([]int32, w*h)
+	}
+	stride := bytesPerLine(w, n, bpc)
+	mask := uint32(1)<<uint(bpc) - 1
+	for y := 0; y < h; y++ {
+		row := data[y*stride:]
+		bit := 0
+		for x := 0; x < w; x++ {
+			for c := 0; c < n; c++ {
+				var v uint32
+				switch bpc {
+				case 8:
+					v = uint32(row[bit>>3])
+				case 16:
+					v = uint32(row[bit>>3])<<8 | uint32(row[bit>>3+1])
+				default:
+					v = uint32(row[bit>>3]>>uint(8-bpc-bit&7)) & mask
+				}
+				planes[c][y*w+x] = int32(v)
+				bit += bpc
+			}
+		}
+	}
+	return planes
+}
+
+// writeFile wraps the codestream in a JP2 file, or a JPX file for the CMYK
+// color space, which is not supported by JP2.
+func writeFile(codestream []byte, img *Image, components int, colorSpace ColorSpace) []byte {
+	var out []byte
+	out = writeBox(out, "jP  ", jp2Signature)
+	var ftyp []byte
+	if colorSpace == ColorSpaceCMYK && img.ICCProfile == nil {
+		ftyp = []byte("jpx \x00\x00\x00\x00jpx jp2 jpxb")
+	} else {
+		ftyp = []byte("jp2 \x00\x00\x00\x00jp2 ")
+	}
+	out = writeBox(out, "ftyp", ftyp)
+
+	var header []byte
+	ihdr := make([]byte, 14)
+	binary.BigEndian.PutUint32(ihdr, uint32(img.Height))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(img.Width))
+	binary.BigEndian.PutUint16(ihdr[8:], uint16(components))
+	ihdr[10] = byte(img.BitsPerComponent - 1)
+	ihdr[11] = 7
+	header = writeBox(header, "ihdr", ihdr)
+	if img.ICCProfile != nil {
+		header = writeBox(header, "colr", append([]byte{2, 0, 0}, img.ICCProfile...))
+	} else {
+		colr := []byte{1, 0, 0, 0, 0, 0, 0}
+		enum := map[ColorSpace]uint32{ColorSpaceGray: enumGray, ColorSpaceRGB: enumSRGB, ColorSpaceCMYK: enumCMYK}
+		binary.BigEndian.PutUint32(colr[3:], enum[colorSpace])
+		header = writeBox(header, "colr", colr)
+	}
+	if components > img.ColorComponents {
+		cdef := make([]byte, 2+6*components)
+		binary.BigEndian.PutUint16(cdef, uint16(components))
+		for i := 0; i < components; i++ {
+			d := cdef[2+6*i:]
+			binary.BigEndian.PutUint16(d, uint16(i))
+			if i < img.ColorComponents {
+				binary.BigEndian.PutUint16(d[4:], uint16(i+1))
+				continue
+			}
+			typ := channelOpacity
+			if img.Premultiplied {
+				typ = channelPremultiplied
+			}
+			binary.BigEndian.PutUint16(d[2:], uint16(typ))
+		}
+		header = writeBox(header, "cdef", cdef)
+	}
+	out = writeBox(out, "jp2h", header)
+	return writeBox(out, "jp2c", codestream)
+}
+
+// encoder encodes the codestream of an image.
+type encoder struct {
+	lossless bool
+	mct      bool
+	quality  int
+
+	blocks blockEncoder
+	w53    wavelet53
+	w97    wavelet97
+}
+
+// encodeCodestream returns the codestream of the image made of the
+// components `planes`, of `bpc` bits per sample.
+func (e *encoder) encodeCodestream(planes [][]int32, w, h, bpc int) ([]byte, error) {
+	tileW, tileH := w, h
+	if w > encodeMaxUntiled || h > encodeMaxUntiled {
+		tileW, tileH = encodeTileSize, encodeTileSize
+	}
+	levels := 0
+	for levels < encodeMaxLevels && 2<<uint(levels) <= minInt(tileW, tileH) {
+		levels++
+	}
+
+	cs := &codestream{imageSize: imageSize{x1: w, y1: h, tileW: tileW, tileH: tileH}}
+	for range planes {
+		cs.components = append(cs.components, component{precision: bpc, dx: 1, dy: 1})
+	}
+	cod := &codingDefaults{
+		progression: progressionLRCP,
+		layers:      1,
+		mct:         e.mct,
+		style: codingStyle{
+			levels:     levels,
+			xcb:        encodeBlockSize,
+			ycb:        encodeBlockSize,
+			reversible: e.lossless,
+		},
+	}
+	cs.main.cod = cod
+	cs.main.qcd = e.nominalQuantization(bpc, levels)
+
+	out := []byte{0xFF, markerSOC}
+	out = append(out, e.sizSegment(cs)...)
+	out = append(out, codSegment(cod)...)
+	out = append(out, qcdSegment(markerQCD, -1, cs.main.qcd)...)
+	for index := 0; index < cs.tilesAcross()*cs.tilesDown(); index++ {
+		tilePart, err := e.encodeTile(cs, index, planes)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tilePart...)
+	}
+	return append(out, 0xFF, markerEOC), nil
+}
+
+func (e *encoder) sizSegment(cs *codestream) []byte {
+	n := len(cs.components)
+	s := make([]byte, 40+3*n)
+	s[0], s[1] = 0xFF, markerSIZ
+	binary.BigEndian.PutUint16(s[2:], uint16(38+3*n))
+	binary.BigEndian.PutUint32(s[6:], uint32(cs.x1))
+	binary.BigEndian.PutUint32(s[10:], uint32(cs.y1))
+	binary.BigEndian.PutUint32(s[22:], uint32(cs.tileW))
+	binary.BigEndian.PutUint32(s[26:], uint32(cs.tileH))
+	binary.BigEndian.PutUint16(s[38:], uint16(n))
+	for i, comp := range cs.components {
+		s[40+3*i] = byte(comp.precision - 1)
+		s[41+3*i] = 1
+		s[42+3*i] = 1
+	}
+	return s
+}
+
+func codSegment(cod *codingDefaults) []byte {
+	mct := byte(0)
+	if cod.mct {
+		mct = 1
+	}
+	transform := byte(0)
+	if cod.style.reversible {
+		transform = 1
+	}
+	layers := uint16(cod.layers)
+	return []byte{
+		0xFF, markerCOD, 0, 12,
+		0, byte(cod.progression), byte(layers >> 8), byte(layers), mct,
+		byte(cod.style.levels), byte(cod.style.xcb - 2), byte(cod.style.ycb - 2), byte(cod.style.style), transform,
+	}
+}
+
+// qcdSegment returns the QCD marker segment, or the QCC marker segment of the
+// component `c` if `marker` is markerQCC.
+func qcdSegment(marker byte, c int, q *quantization) []byte {
+	var content []byte
+	if marker == markerQCC {
+		content = append(content, byte(c))
+	}
+	content = append(content, byte(q.style)|byte(q.guardBits<<5))
+	for _, s := range q.steps {
+		if q.style == quantizationNone {
+			content = append(content, byte(s.exponent<<3))
+		} else {
+			v := uint16(s.exponent<<11 | s.mantissa)
+			content = append(content, byte(v>>8), byte(v))
+		}
+	}
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(content)+2))
+	return append(seg, content...)
+}
+
+// nominalQuantization returns the quantization of the main header. The
+// actual quantization of each component is specified in the tile headers.
+func (e *encoder) nominalQuantization(bpc, levels int) *quantization {
+	q := &quantization{style: quantizationNone, guardBits: encodeGuardBits}
+	if !e.lossless {
+		q.style = quantizationExpanded
+	}
+	for i := 0; i < 1+3*levels; i++ {
+		gain := 0
+		if i > 0 {
+			gain = [3]int{1, 1, 2}[(i-1)%3]
+		}
+		q.steps = append(q.steps, quantizationStep{exponent: bpc + gain})
+	}
+	return q
+}
+
+// bandData contains the quantized coefficients of a subband.
+type bandData struct {
+	r    int
+	band *subband
+	data []int32
+}
+
+// encodeTile returns the tile-part of the tile with the specified index.
+func (e *encoder) encodeTile(cs *codestream, index int, planes [][]int32) ([]byte, error) {
+	td := &tileData{params: headerParams{qcc: map[int]*quantization{}}}
+	t, err := newTile(cs, index, td)
+	if err != nil {
+		return nil, err
+	}
+
+	// DC level shift and component transformation.
+	n := len(t.components)
+	ints := make([][]int32, n)
+	floats := make([][]float32, n)
+	for c, tc := range t.components {
+		w := tc.x1 - tc.x0
+		shift := int32(1) << uint(cs.components[c].precision-1)
+		samples := make([]int32, w*(tc.y1-tc.y0))
+		for y := tc.y0; y < tc.y1; y++ {
+			for x := tc.x0; x < tc.x1; x++ {
+				samples[(y-tc.y0)*w+x-tc.x0] = planes[c][y*cs.x1+x] - shift
+			}
+		}
+		ints[c] = samples
+	}
+	if e.lossless {
+		if e.mct {
+			forwardRCT(ints[0], ints[1], ints[2])
+		}
+	} else {
+		for c := range ints {
+			floats[c] = make([]float32, len(ints[c]))
+			for i, v := range ints[c] {
+				floats[c][i] = float32(v)
+			}
+			ints[c] = nil
+		}
+		if e.mct {
+			forwardICT(floats[0], floats[1], floats[2])
+		}
+	}
+
+	// Wavelet transformation and quantization.
+	bands := make([][]bandData, n)
+	for c, tc := range t.components {
+		precision := cs.components[c].precision
+		if e.lossless {
+			bands[c] = e.transformReversible(tc, ints[c])
+			td.params.qcc[c] = reversibleQuantization(bands[c], precision)
+		} else {
+			var q *quantization
+			bands[c], q = e.transformIrreversible(tc, floats[c], precision)
+			td.params.qcc[c] = q
+		}
+	}
+	// The partition is computed again for the quantization of the tile.
+	if t, err = newTile(cs, index, td); err != nil {
+		return nil, err
+	}
+
+	var header []byte
+	for c := range t.components {
+		header = append(header, qcdSegment(markerQCC, c, td.params.qcc[c])...)
+	}
+	body := e.encodePackets(t, bands)
+
+	sot := make([]byte, 12)
+	sot[0], sot[1] = 0xFF, markerSOT
+	binary.BigEndian.PutUint16(sot[2:], 10)
+	binary.BigEndian.PutUint16(sot[4:], uint16(index))
+	binary.BigEndian.PutUint32(sot[6:], uint32(len(sot)+len(header)+2+len(body)))
+	sot[11] = 1
+	out := append(sot, header...)
+	out = append(out, 0xFF, markerSOD)
+	return append(out, body...), nil
+}
+
+// subbandIndex returns the kind of the subband of the coefficient at
+// coordinates (x, y) of the resolution level `r` of the tile component, once
+// transformed, and its index in the subband or, for the LL subband, in the
+// lower resolution level.
+func subbandIndex(tc *tileComponent, r, x, y int) (int, int) {
+	kind := x&1 | (y&1)<<1
+	if kind == bandLL {
+		prev := tc.resolutions[r-1]
+		return bandLL, (y/2-prev.y0)*(prev.x1-prev.x0) + x/2 - prev.x0
+	}
+	b := tc.resolutions[r].bands[kind-1]
+	return kind, (y/2-b.y0)*(b.x1-b.x0) + x/2 - b.x0
+}
+
+// transformReversible applies the reversible wavelet transformation to the
+// samples of the tile component and returns the coefficients of its
+// subbands.
+func (e *encoder) transformReversible(tc *tileComponent, samples []int32) []bandData {
+	var bands []bandData
+	cur := samples
+	for r := len(tc.resolutions) - 1; r >= 1; r-- {
+		res, prev := tc.resolutions[r], tc.resolutions[r-1]
+		w, h := res.x1-res.x0, res.y1-res.y0
+		if w > 0 && h > 0 {
+			e.w53.forward2D(cur, w, h, res.x0, res.y0)
+		}
+		ll := make([]int32, (prev.x1-prev.x0)*(prev.y1-prev.y0))
+		var data [3][]int32
+		for i, b := range res.bands {
+			data[i] = make([]int32, (b.x1-b.x0)*(b.y1-b.y0))
+		}
+		for y := res.y0; y < res.y1; y++ {
+			for x := res.x0; x < res.x1; x++ {
+				v := cur[(y-res.y0)*w+x-res.x0]
+				if kind, i := subbandIndex(tc, r, x, y); kind == bandLL {
+					ll[i] = v
+				} else {
+					data[kind-1][i] = v
+				}
+			}
+		}
+		for i, b := range res.bands {
+			bands = append(bands, bandData{r: r, band: b, data: data[i]})
+		}
+		cur = ll
+	}
+	return append(bands, bandData{r: 0, band: tc.resolutions[0].bands[0], data: cur})
+}
+
+// reversibleQuantization returns the quantization parameters of the
+// reversible transformation, with exponents large enough for the magnitudes
+// of the coefficients of the subbands.
+func reversibleQuantization(bands []bandData, precision int) *quantization {
+	levels := 0
+	for _, b := range bands {
+		levels = maxInt(levels, b.r)
+	}
+	q := &quantization{style: quantizationNone, guardBits: encodeGuardBits}
+	q.steps = make([]quantizationStep, 1+3*levels)
+	for _, b := range bands {
+		var max int32
+		for _, v := range b.data {
+			if v < 0 {
+				v = -v
+			}
+			if v > max {
+				max = v
+			}
+		}
+		bits := 0
+		for ; max > 0; max >>= 1 {
+			bits++
+		}
+		gain := [4]int{0, 1, 1, 2}[b.band.kind]
+		exponent := maxInt(precision+gain, bits-encodeGuardBits+1)
+		i := 0
+		if b.r > 0 {
+			i = 1 + 3*(b.r-1) + b.band.kind - 1
+		}
+		q.steps[i] = quantizationStep{exponent: minInt(exponent, 31)}
+	}
+	return q
+}
+
+// transformIrreversible applies the irreversible wavelet transformation to
+// the samples of the tile component and returns the quantized coefficients
+// of its subbands and the quantization parameters.
+func (e *encoder) transformIrreversible(tc *tileComponent, samples []float32, precision int) ([]bandData, *quantization) {
+	levels := len(tc.resolutions) - 1
+	type realBand struct {
+		r    int
+		band *subband
+		data []float32
+	}
+	var coeffs []realBand
+	cur := samples
+	for r := levels; r >= 1; r-- {
+		res, prev := tc.resolutions[r], tc.resolutions[r-1]
+		w, h := res.x1-res.x0, res.y1-res.y0
+		if w > 0 && h > 0 {
+			e.w97.forward2D(cur, w, h, res.x0, res.y0)
+		}
+		ll := make([]float32, (prev.x1-prev.x0)*(prev.y1-prev.y0))
+		var data [3][]float32
+		for i, b := range res.bands {
+			data[i] = make([]float32, (b.x1-b.x0)*(b.y1-b.y0))
+		}
+		for y := res.y0; y < res.y1; y++ {
+			for x := res.x0; x < res.x1; x++ {
+				v := cur[(y-res.y0)*w+x-res.x0]
+				if kind, i := subbandIndex(tc, r, x, y); kind == bandLL {
+					ll[i] = v
+				} else {
+					data[kind-1][i] = v
+				}
+			}
+		}
+		for i, b := range res.bands {
+			coeffs = append(coeffs, realBand{r: r, band: b, data: data[i]})
+		}
+		cur = ll
+	}
+	coeffs = append(coeffs, realBand{r: 0, band: tc.resolutions[0].bands[0], data: cur})
+
+	// The step size of each subband is inversely proportional to the norm of
+	// its synthesis basis functions, so that the quantization errors of all
+	// the subbands contribute equally to the error of the samples.
+	scale := 200 - 2*float64(e.quality)
+	if e.quality < 50 {
+		scale = 5000 / float64(e.quality)
+	}
+	base := math.Max(0.5, 16*scale/100) * math.Ldexp(1, precision-8)
+
+	q := &quantization{style: quantizationExpanded}
+	q.steps = make([]quantizationStep, 1+3*levels)
+	var bands []bandData
+	guardBits := 1
+	for _, rb := range coeffs {
+		n := levels
+		if rb.r > 0 {
+			n = levels - rb.r + 1
+		}
+		kind := rb.band.kind
+		norm := waveletNorm(n, kind&1 != 0) * waveletNorm(n, kind>>1 != 0)
+		gain := [4]int{0, 1, 1, 2}[kind]
+
+		// Step size relative to the dynamic range of the subband, coded as an
+		// exponent and a mantissa.
+		relative := base / norm / math.Ldexp(1, precision+gain)
+		exponent := int(math.Ceil(-math.Log2(relative)))
+		exponent = minInt(maxInt(exponent, 0), 31)
+		mantissa := int(math.Floor((relative*math.Ldexp(1, exponent)-1)*2048 + 0.5))
+		mantissa = minInt(maxInt(mantissa, 0), 2047)
+		step := math.Ldexp(1+float64(mantissa)/2048, precision+gain-exponent)
+
+		data := make([]int32, len(rb.data))
+		var max int32
+		for i, v := range rb.data {
+			m := int32(math.Abs(float64(v)) / step)
+			if m > max {
+				max = m
+			}
+			if v < 0 {
+				m = -m
+			}
+			data[i] = m
+		}
+		bits := 0
+		for ; max > 0; max >>= 1 {
+			bits++
+		}
+		guardBits = maxInt(guardBits, bits-exponent+1)
+
+		i := 0
+		if rb.r > 0 {
+			i = 1 + 3*(rb.r-1) + kind - 1
+		}
+		q.steps[i] = quantizationStep{exponent: exponent, mantissa: mantissa}
+		bands = append(bands, bandData{r: rb.r, band: rb.band, data: data})
+	}
+	q.guardBits = minInt(guardBits, 7)
+	return bands, q
+}
+
+var (
+	waveletNormsMu sync.Mutex
+	waveletNorms   = map[[2]int]float64{}
+)
+
+// waveletNorm returns the norm of the 1D synthesis basis functions of the
+// irreversible transformation for the low-pass or high-pass coefficients at
+// decomposition level `n`.
+func waveletNorm(n int, high bool) float64 {
+	key := [2]int{n, 0}
+	if high {
+		key[1] = 1
+	}
+	waveletNormsMu.Lock()
+	defer waveletNormsMu.Unlock()
+	if norm, ok := waveletNorms[key]; ok {
+		return norm
+	}
+	if n == 0 {
+		return 1
+	}
+	var w wavelet97
+	length := 1 << uint(n+5)
+	signal := make([]float32, length>>uint(n-1))
+	pos := len(signal) / 2
+	if high {
+		pos++
+	}
+	signal[pos] = 1
+	w.inverse1D(signal, 0)
+	for k := n - 1; k >= 1; k-- {
+		next := make([]float32, length>>uint(k-1))
+		for i, v := range signal {
+			next[2*i] = v
+		}
+		w.inverse1D(next, 0)
+		signal = next
+	}
+	var sum float64
+	for _, v := range signal {
+		sum += float64(v) * float64(v)
+	}
+	norm := math.Sqrt(sum)
+	waveletNorms[key] = norm
+	return norm
+}
+
+// encodePackets encodes the code-blocks of the tile and returns its packets,
+// in the LRCP progression order with a single layer and a single precinct
+// for each resolution level.
+func (e *encoder) encodePackets(t *tile, bands [][]bandData) []byte {
+	type codedBlock struct {
+		data   []byte
+		passes int
+		planes int
+	}
+	var out []byte
+	levels := 0
+	for _, tc := range t.components {
+		levels = maxInt(levels, len(tc.resolutions)-1)
+	}
+	for r := 0; r <= levels; r++ {
+		for c, tc := range t.components {
+			if r >= len(tc.resolutions) {
+				continue
+			}
+			res := tc.resolutions[r]
+			if res.precinctsW*res.precinctsH == 0 {
+				continue
+			}
+			// Partition bands match the transformed bands by kind and level.
+			var resBands []bandData
+			for _, bd := range bands[c] {
+				if bd.r == r {
+					resBands = append(resBands, bd)
+				}
+			}
+
+			w := &bitWriter{}
+			var bodies []byte
+			coded := make([][]codedBlock, len(res.bands))
+			included := false
+			for i, b := range res.bands {
+				bd := resBands[i]
+				prec := b.precincts[0]
+				coded[i] = make([]codedBlock, len(prec.blocks))
+				bw := b.x1 - b.x0
+				for j, cb := range prec.blocks {
+					data, passes, planes := e.blocks.encodeBlock(bd.data, (cb.y0-b.y0)*bw+cb.x0-b.x0, bw,
+						cb.x1-cb.x0, cb.y1-cb.y0, b.kind)
+					coded[i][j] = codedBlock{data: data, passes: passes, planes: planes}
+					if passes > 0 {
+						included = true
+						prec.inclusion.setValue(j%prec.blocksW, j/prec.blocksW, 0)
+					}
+					prec.zeroPlanes.setValue(j%prec.blocksW, j/prec.blocksW, b.magnitudeBits-planes)
+				}
+			}
+			if !included {
+				w.writeBit(0)
+				out = append(out, w.flush()...)
+				continue
+			}
+			w.writeBit(1)
+			for i, b := range res.bands {
+				prec := b.precincts[0]
+				for j, cb := range prec.blocks {
+					x, y := j%prec.blocksW, j/prec.blocksW
+					block := coded[i][j]
+					prec.inclusion.encode(w, x, y, 1)
+					if block.passes == 0 {
+						continue
+					}
+					prec.zeroPlanes.encode(w, x, y, b.magnitudeBits-block.planes+1)
+					writePassCount(w, block.passes)
+					bits := 0
+					for n := len(block.data); n > 0; n >>= 1 {
+						bits++
+					}
+					for cb.lblock+floorLog2(block.passes) < bits {
+						w.writeBit(1)
+						cb.lblock++
+					}
+					w.writeBit(0)
+					w.writeBits(len(block.data), cb.lblock+floorLog2(block.passes))
+					bodies = append(bodies, block.data...)
+				}
+			}
+			out = append(out, w.flush()...)
+			out = append(out, bodies...)
+		}
+	}
+	return out
+}
+
+// forwardRCT applies the forward reversible component transformation.
+func forwardRCT(r, g, b []int32) {
+	for i := range r {
+		y0 := (r[i] + 2*g[i] + b[i]) >> 2
+		r[i], g[i], b[i] = y0, b[i]-g[i], r[i]-g[i]
+	}
+}
+
+// forwardICT applies the forward irreversible component transformation.
+func forwardICT(r, g, b []float32) {
+	for i := range r {
+		red, green, blue := r[i], g[i], b[i]
+		r[i] = 0.299*red + 0.587*green + 0.114*blue
+		g[i] = -0.168736*red - 0.331264*green + 0.5*blue
+		b[i] = 0.5*red - 0.418688*green - 0.081312*blue
+	}
+}
@@ -0,0 +1,96 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+// Package jpeg2000 implements a decoder and an encoder for JPEG 2000 images (ITU-T T.800), in the
+// JP2/JPX file formats or as raw codestreams, as used by the JPXDecode filter of PDF documents.
+//
+// The decoded samples use the layout of PDF image data: the components of each pixel are
+// interleaved, the samples are packed most significant bit first and each row starts on a byte
+// boundary.
+package jpeg2000
+
+// ColorSpace represents the color space of the image.
+type ColorSpace int
+
+// Color spaces.
+const (
+	// ColorSpaceUnknown is the color space of the images whose color space
+	// is not specified or not supported, e.g. the codestreams which are not
+	// contained in a JP2 file. The color space is implied by the number of
+	// color components in this case.
+	ColorSpaceUnknown ColorSpace = iota
+	ColorSpaceGray
+	ColorSpaceRGB
+	ColorSpaceCMYK
+)
+
+// String returns the name of the color space.
+func (cs ColorSpace) String() string {
+	switch cs {
+	case ColorSpaceGray:
+		return "Gray"
+	case ColorSpaceRGB:
+		return "RGB"
+	case ColorSpaceCMYK:
+		return "CMYK"
+	}
+	return "Unknown"
+}
+
+// Config contains the properties of an image.
+type Config struct {
+	Width, Height int
+
+	// ColorComponents is the number of color components of the image data,
+	// not including the alpha channel.
+	ColorComponents int
+
+	// BitsPerComponent is the number of bits per sample of the image data
+	// and of the alpha channel: 1, 2, 4, 8 or 16.
+	BitsPerComponent int
+
+	// HasAlpha indicates that the image has an alpha channel (opacity).
+	// Premultiplied indicates that the color components are premultiplied by
+	// the alpha channel.
+	HasAlpha      bool
+	Premultiplied bool
+
+	// ColorSpace is the color space of the image. ICCProfile contains the
+	// ICC profile of the image, if any, in which case the color space is the
+	// one implied by the number of components.
+	ColorSpace ColorSpace
+	ICCProfile []byte
+}
+
+// Image represents a decoded image.
+type Image struct {
+	Config
+
+	// Data contains the samples of the color components of the image, and
+	// Alpha the samples of its alpha channel, if any.
+	Data  []byte
+	Alpha []byte
+}
+
+// FormatError reports that the input is not a valid JPEG 2000 image.
+type FormatError string
+
+func (e FormatError) Error() string { return "jpeg2000: invalid format: " + string(e) }
+
+// UnsupportedError reports that the input uses a valid but unimplemented
+// JPEG 2000 feature.
+type UnsupportedError string
+
+func (e UnsupportedError) Error() string { return "jpeg2000: unsupported feature: " + string(e) }
+
+// bytesPerLine returns the number of bytes of a row of `width` pixels of
+// `components` samples of `bpc` bits.
+func bytesPerLine(width, components, bpc int) int {
+	return (width*components*bpc + 7) / 8
+}
@@ -0,0 +1,277 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+// mqState is an entry of the probability estimation table of the MQ coder
+// (T.800 Table C.2).
+type mqState struct {
+	qe        uint32
+	nmps      uint8
+	nlps      uint8
+	switchMPS bool
+}
+
+var mqStates = [47]mqState{
+	{0x5601, 1, 1, true}, {0x3401, 2, 6, false}, {0x1801, 3, 9, false},
+	{0x0AC1, 4, 12, false}, {0x0521, 5, 29, false}, {0x0221, 38, 33, false},
+	{0x5601, 7, 6, true}, {0x5401, 8, 14, false}, {0x4801, 9, 14, false},
+	{0x3801, 10, 14, false}, {0x3001, 11, 17, false}, {0x2401, 12, 18, false},
+	{0x1C01, 13, 20, false}, {0x1601, 29, 21, false}, {0x5601, 15, 14, true},
+	{0x5401, 16, 14, false}, {0x5101, 17, 15, false}, {0x4801, 18, 16, false},
+	{0x3801, 19, 17, false}, {0x3401, 20, 18, false}, {0x3001, 21, 19, false},
+	{0x2801, 22, 19, false}, {0x2401, 23, 20, false}, {0x2201, 24, 21, false},
+	{0x1C01, 25, 22, false}, {0x1801, 26, 23, false}, {0x1601, 27, 24, false},
+	{0x1401, 28, 25, false}, {0x1201, 29, 26, false}, {0x1101, 30, 27, false},
+	{0x0AC1, 31, 28, false}, {0x09C1, 32, 29, false}, {0x08A1, 33, 30, false},
+	{0x0521, 34, 31, false}, {0x0441, 35, 32, false}, {0x02A1, 36, 33, false},
+	{0x0221, 37, 34, false}, {0x0141, 38, 35, false}, {0x0111, 39, 36, false},
+	{0x0085, 40, 37, false}, {0x0049, 41, 38, false}, {0x0025, 42, 39, false},
+	{0x0015, 43, 40, false}, {0x0009, 44, 41, false}, {0x0005, 45, 42, false},
+	{0x0001, 45, 43, false}, {0x5601, 46, 46, false},
+}
+
+// mqContext is the state of a context of the MQ coder: the index in the
+// probability estimation table and the most probable symbol.
+type mqContext struct {
+	index uint8
+	mps   uint8
+}
+
+// mqDecoder implements the MQ arithmetic decoder (T.800 Annex C.3).
+type mqDecoder struct {
+	data []byte
+	pos  int
+	c    uint32
+	a    uint32
+	ct   int
+}
+
+// byteAt returns the byte of the data at `i`, or 0xFF past the end of the
+// data, as if the data were followed by a marker.
+func (d *mqDecoder) byteAt(i int) uint32 {
+	if i < len(d.data) {
+		return uint32(d.data[i])
+	}
+	return 0xFF
+}
+
+// init initializes the decoder to decode the codeword segment `data`.
+func (d *mqDecoder) init(data []byte) {
+	d.data = data
+	d.pos = 0
+	d.c = d.byteAt(0) << 16
+	d.byteIn()
+	d.c <<= 7
+	d.ct -= 7
+	d.a = 0x8000
+}
+
+func (d *mqDecoder) byteIn() {
+	if d.byteAt(d.pos) == 0xFF {
+		if b := d.byteAt(d.pos + 1); b > 0x8F {
+			d.c += 0xFF00
+			d.ct = 8
+		} else {
+			d.pos++
+			d.c += b << 9
+			d.ct = 7
+		}
+	} else {
+		d.pos++
+		d.c += d.byteAt(d.pos) << 8
+		d.ct = 8
+	}
+}
+
+// decode decodes a binary decision in context `cx`.
+func (d *mqDecoder) decode(cx *mqContext) int {
+	s := &mqStates[cx.index]
+	qe := s.qe
+	d.a -= qe
+	var bit int
+	if d.c>>16 < qe {
+		// LPS exchange.
+		if d.a < qe {
+			d.a = qe
+			bit = int(cx.mps)
+			cx.index = s.nmps
+		} else {
+			d.a = qe
+			bit = 1 - int(cx.mps)
+			if s.switchMPS {
+				cx.mps = 1 - cx.mps
+			}
+			cx.index = s.nlps
+		}
+	} else {
+		d.c -= qe << 16
+		if d.a&0x8000 != 0 {
+			return int(cx.mps)
+		}
+		// MPS exchange.
+		if d.a < qe {
+			bit = 1 - int(cx.mps)
+			if s.switchMPS {
+				cx.mps = 1 - cx.mps
+			}
+			cx.index = s.nlps
+		} else {
+			bit = int(cx.mps)
+			cx.index = s.nmps
+		}
+	}
+	for {
+		if d.ct == 0 {
+			d.byteIn()
+		}
+		d.a <<= 1
+		d.c <<= 1
+		d.ct--
+		if d.a&0x8000 != 0 {
+			break
+		}
+	}
+	return bit
+}
+
+// rawDecoder reads the raw (bypassed) coding passes of a code-block, in
+// which the bits are not arithmetically coded (T.800 D.6).
+type rawDecoder struct {
+	data []byte
+	pos  int
+	c    uint32
+	ct   int
+}
+
+func (d *rawDecoder) init(data []byte) {
+	d.data = data
+	d.pos = 0
+	d.c = 0
+	d.ct = 0
+}
+
+func (d *rawDecoder) decode() int {
+	if d.ct == 0 {
+		prev := d.c
+		d.c = 0xFF
+		if d.pos < len(d.data) {
+			d.c = uint32(d.data[d.pos])
+			d.pos++
+		}
+		d.ct = 8
+		if prev == 0xFF {
+			// A bit is stuffed after each 0xFF byte.
+			d.ct = 7
+		}
+	}
+	d.ct--
+	return int(d.c>>uint(d.ct)) & 1
+}
+
+// mqEncoder implements the MQ arithmetic encoder (T.800 Annex C.2).
+type mqEncoder struct {
+	// out holds the encoded bytes, preceded by a byte which is not part of
+	// the output.
+	out []byte
+	c   uint32
+	a   uint32
+	ct  int
+}
+
+func (e *mqEncoder) init() {
+	e.out = append(e.out[:0], 0)
+	e.a = 0x8000
+	e.c = 0
+	e.ct = 12
+}
+
+// encode encodes the binary decision `bit` in context `cx`.
+func (e *mqEncoder) encode(cx *mqContext, bit int) {
+	s := &mqStates[cx.index]
+	qe := s.qe
+	e.a -= qe
+	if uint8(bit) == cx.mps {
+		if e.a&0x8000 != 0 {
+			e.c += qe
+			return
+		}
+		if e.a < qe {
+			e.a = qe
+		} else {
+			e.c += qe
+		}
+		cx.index = s.nmps
+	} else {
+		if e.a < qe {
+			e.c += qe
+		} else {
+			e.a = qe
+		}
+		if s.switchMPS {
+			cx.mps = 1 - cx.mps
+		}
+		cx.index = s.nlps
+	}
+	for {
+		e.a <<= 1
+		e.c <<= 1
+		e.ct--
+		if e.ct == 0 {
+			e.byteOut()
+		}
+		if e.a&0x8000 != 0 {
+			break
+		}
+	}
+}
+
+func (e *mqEncoder) byteOut() {
+	last := len(e.out) - 1
+	if e.out[last] == 0xFF {
+		e.out = append(e.out, byte(e.c>>20))
+		e.c &= 0xFFFFF
+		e.ct = 7
+		return
+	}
+	if e.c < 0x8000000 {
+		e.out = append(e.out, byte(e.c>>19))
+		e.c &= 0x7FFFF
+		e.ct = 8
+		return
+	}
+	e.out[last]++
+	if e.out[last] == 0xFF {
+		e.c &= 0x7FFFFFF
+		e.out = append(e.out, byte(e.c>>20))
+		e.c &= 0xFFFFF
+		e.ct = 7
+		return
+	}
+	e.out = append(e.out, byte(e.c>>19))
+	e.c &= 0x7FFFF
+	e.ct = 8
+}
+
+// flush terminates the codeword and returns the encoded bytes.
+func (e *mqEncoder) flush() []byte {
+	temp := e.c + e.a
+	e.c |= 0xFFFF
+	if e.c >= temp {
+		e.c -= 0x8000
+	}
+	e.c <<= uint(e.ct)
+	e.byteOut()
+	e.c <<= uint(e.ct)
+	e.byteOut()
+	if e.out[len(e.out)-1] == 0xFF {
+		e.out = e.out[:len(e.out)-1]
+	}
+	return e.out[1:]
+}
@@ -0,0 +1,352 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+import "errors"
+
+// errTruncated is returned when the data of a packet is missing.
+var errTruncated = errors.New("truncated packet data")
+
+// bitReader reads the bits of packet headers. A zero bit is stuffed after
+// each 0xFF byte, which is skipped.
+type bitReader struct {
+	data []byte
+	pos  int
+	buf  byte
+	ct   int
+	eof  bool
+}
+
+func (r *bitReader) readBit() int {
+	if r.ct == 0 {
+		if r.pos >= len(r.data) {
+			r.eof = true
+			return 0
+		}
+		r.ct = 8
+		if r.buf == 0xFF {
+			r.ct = 7
+		}
+		r.buf = r.data[r.pos]
+		r.pos++
+	}
+	r.ct--
+	return int(r.buf>>uint(r.ct)) & 1
+}
+
+func (r *bitReader) readBits(n int) int {
+	v := 0
+	for ; n > 0; n-- {
+		v = v<<1 | r.readBit()
+	}
+	return v
+}
+
+// align skips the remaining bits of the current byte, and the following
+// byte if the current byte is 0xFF, since it contains a stuffed bit.
+func (r *bitReader) align() {
+	if r.buf == 0xFF && r.pos < len(r.data) {
+		r.pos++
+	}
+	r.buf = 0
+	r.ct = 0
+}
+
+// bitWriter writes the bits of packet headers, stuffing a zero bit after each
+// 0xFF byte.
+type bitWriter struct {
+	out []byte
+	buf byte
+	ct  int
+}
+
+func (w *bitWriter) writeBit(bit int) {
+	if w.ct == 0 {
+		w.ct = 8
+		if len(w.out) > 0 && w.out[len(w.out)-1] == 0xFF {
+			w.ct = 7
+		}
+		w.out = append(w.out, 0)
+	}
+	w.ct--
+	w.out[len(w.out)-1] |= byte(bit&1) << uint(w.ct)
+}
+
+func (w *bitWriter) writeBits(v, n int) {
+	for n--; n >= 0; n-- {
+		w.writeBit(v >> uint(n))
+	}
+}
+
+// flush terminates the packet header. A zero byte is appended if the last
+// byte is 0xFF, so that the packet body does not follow a 0xFF byte.
+func (w *bitWriter) flush() []byte {
+	if len(w.out) > 0 && w.out[len(w.out)-1] == 0xFF {
+		w.out = append(w.out, 0)
+	}
+	w.ct = 0
+	return w.out
+}
+
+// tagTree implements the tag trees of the packet headers (T.800 B.10.2),
+// which code two-dimensional arrays of non-negative integers.
+type tagTree struct {
+	// levels contains the nodes of each level, starting from the leaves.
+	levels [][]tagNode
+	widths []int
+}
+
+type tagNode struct {
+	value int
+	low   int
+	known bool
+}
+
+// tagUnknown is the value of the nodes which have not been decoded yet.
+const tagUnknown = 1 << 30
+
+func newTagTree(w, h int) *tagTree {
+	t := &tagTree{}
+	for {
+		nodes := make([]tagNode, w*h)
+		for i := range nodes {
+			nodes[i].value = tagUnknown
+		}
+		t.levels = append(t.levels, nodes)
+		t.widths = append(t.widths, w)
+		if w == 1 && h == 1 {
+			break
+		}
+		w, h = (w+1)/2, (h+1)/2
+	}
+	return t
+}
+
+// path returns the nodes from the root of the tree to the leaf (x, y).
+func (t *tagTree) path(x, y int) []*tagNode {
+	nodes := make([]*tagNode, len(t.levels))
+	for i := range t.levels {
+		nodes[len(nodes)-1-i] = &t.levels[i][y*t.widths[i]+x]
+		x, y = x/2, y/2
+	}
+	return nodes
+}
+
+// decode decodes the value of the leaf (x, y), until it is known to be
+// greater than or equal to `threshold`. Returns true if the value is less
+// than `threshold`.
+func (t *tagTree) decode(r *bitReader, x, y, threshold int) bool {
+	nodes := t.path(x, y)
+	low := 0
+	for _, n := range nodes {
+		if low > n.low {
+			n.low = low
+		} else {
+			low = n.low
+		}
+		for low < threshold && low < n.value {
+			if r.readBit() == 1 {
+				n.value = low
+			} else {
+				low++
+			}
+		}
+		n.low = low
+	}
+	return nodes[len(nodes)-1].value < threshold
+}
+
+// setValue sets the value of the leaf (x, y) and updates the values of its
+// ancestors, which are the minimum values of their children. The values of
+// the nodes must be initialized to tagUnknown (or more) beforehand.
+func (t *tagTree) setValue(x, y, value int) {
+	for i := range t.levels {
+		n := &t.levels[i][y*t.widths[i]+x]
+		if value < n.value {
+			n.value = value
+		}
+		x, y = x/2, y/2
+	}
+}
+
+// encode encodes the information needed to determine whether the value of
+// the leaf (x, y) is less than `threshold`.
+func (t *tagTree) encode(w *bitWriter, x, y, threshold int) {
+	low := 0
+	for _, n := range t.path(x, y) {
+		if low > n.low {
+			n.low = low
+		} else {
+			low = n.low
+		}
+		for low < threshold {
+			if low >= n.value {
+				if !n.known {
+					w.writeBit(1)
+					n.known = true
+				}
+				break
+			}
+			w.writeBit(0)
+			low++
+		}
+		n.low = low
+	}
+}
+
+// packetReader reads the packets of a tile.
+type packetReader struct {
+	data []byte
+	pos  int
+
+	// headers contains the packet headers if they are packed in PPM or PPT
+	// marker segments, otherwise nil.
+	headers []byte
+	hpos    int
+}
+
+// pendingData is a part of the body of a packet: the data of passes
+// contributed by a code-block to one of its codeword segments.
+type pendingData struct {
+	cb     *codeBlock
+	seg    int
+	length int
+}
+
+// decodePacket reads the packet `pk` of the tile and adds the data it
+// contains to the code-blocks.
+func (pr *packetReader) decodePacket(t *tile, pk packet) error {
+	tc := t.components[pk.comp]
+	res := tc.resolutions[pk.res]
+	style := tc.style.style
+
+	if t.defaults.sop && pr.pos+6 <= len(pr.data) && pr.data[pr.pos] == 0xFF && pr.data[pr.pos+1] == markerSOP {
+		pr.pos += 6
+	}
+	br := &bitReader{data: pr.data, pos: pr.pos}
+	if pr.headers != nil {
+		br = &bitReader{data: pr.headers, pos: pr.hpos}
+	}
+
+	var pending []pendingData
+	if br.readBit() == 1 {
+		for _, b := range res.bands {
+			prec := b.precincts[pk.precinct]
+			for i, cb := range prec.blocks {
+				x, y := i%prec.blocksW, i/prec.blocksW
+				if !cb.included {
+					if !prec.inclusion.decode(br, x, y, pk.layer+1) {
+						continue
+					}
+					n := 0
+					for !prec.zeroPlanes.decode(br, x, y, n+1) {
+						if n++; n > b.magnitudeBits || br.eof {
+							return errTruncated
+						}
+					}
+					cb.zeroPlanes = n
+					cb.included = true
+				} else if br.readBit() == 0 {
+					continue
+				}
+
+				passes := readPassCount(br)
+				for br.readBit() == 1 {
+					if cb.lblock++; br.eof || cb.lblock > 32 {
+						return errTruncated
+					}
+				}
+				for passes > 0 {
+					last := len(cb.segments) - 1
+					if last < 0 || cb.segments[last].passes >= segmentMaxPasses(style, cb.passes-cb.segments[last].passes) {
+						cb.segments = append(cb.segments, segment{})
+						last++
+					}
+					seg := &cb.segments[last]
+					n := minInt(passes, segmentMaxPasses(style, cb.passes-seg.passes)-seg.passes)
+					length := br.readBits(cb.lblock + floorLog2(n))
+					pending = append(pending, pendingData{cb: cb, seg: last, length: length})
+					seg.passes += n
+					cb.passes += n
+					passes -= n
+				}
+			}
+		}
+	}
+	if br.eof {
+		return errTruncated
+	}
+	br.align()
+
+	if t.defaults.eph && br.pos+2 <= len(br.data) && br.data[br.pos] == 0xFF && br.data[br.pos+1] == markerEPH {
+		br.pos += 2
+	}
+	if pr.headers != nil {
+		pr.hpos = br.pos
+	} else {
+		pr.pos = br.pos
+	}
+
+	for _, p := range pending {
+		end := pr.pos + p.length
+		if end > len(pr.data) {
+			end = len(pr.data)
+		}
+		seg := &p.cb.segments[p.seg]
+		seg.data = append(seg.data, pr.data[pr.pos:end]...)
+		pr.pos = end
+	}
+	return nil
+}
+
+// readPassCount reads the number of coding passes contributed by a
+// code-block to a packet (T.800 Table B.4).
+func readPassCount(br *bitReader) int {
+	if br.readBit() == 0 {
+		return 1
+	}
+	if br.readBit() == 0 {
+		return 2
+	}
+	if n := br.readBits(2); n < 3 {
+		return 3 + n
+	}
+	if n := br.readBits(5); n < 31 {
+		return 6 + n
+	}
+	return 37 + br.readBits(7)
+}
+
+// writePassCount writes the number of coding passes contributed by a
+// code-block to a packet (1 to 164).
+func writePassCount(w *bitWriter, n int) {
+	switch {
+	case n == 1:
+		w.writeBit(0)
+	case n == 2:
+		w.writeBits(2, 2)
+	case n <= 5:
+		w.writeBits(0xC|(n-3), 4)
+	case n <= 36:
+		w.writeBits(0x1E0|(n-6), 9)
+	default:
+		w.writeBits(0xFF80|(n-37), 16)
+	}
+}
+
+// floorLog2 returns the base 2 logarithm of n > 0, rounded down.
+func floorLog2(n int) int {
+	l := 0
+	for n > 1 {
+		n >>= 1
+		l++
+	}
+	return l
+}
@@ -0,0 +1,627 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+// Subband orientations.
+const (
+	bandLL = iota
+	bandHL
+	bandLH
+	bandHH
+)
+
+// Code-block coding style flags (T.800 Table A.19).
+const (
+	styleBypass       = 0x01
+	styleReset        = 0x02
+	styleTermAll      = 0x04
+	styleCausal       = 0x08
+	stylePredictable  = 0x10
+	styleSegmentation = 0x20
+)
+
+// Coding pass types, in the order in which they are applied to each
+// bit-plane. The first bit-plane is coded by a cleanup pass only.
+const (
+	passSignificance = iota
+	passRefinement
+	passCleanup
+)
+
+// Contexts of the coefficient bit modeling (T.800 Annex D): 9 zero coding
+// contexts, 5 sign coding contexts, 3 magnitude refinement contexts, the run
+// length context and the uniform context.
+const (
+	ctxZeroCoding = 0
+	ctxSignCoding = 9
+	ctxMagnitude  = 14
+	ctxRunLength  = 17
+	ctxUniform    = 18
+	numContexts   = 19
+)
+
+// Flags of the coefficients of a code-block.
+const (
+	flagSignificant = 1 << iota
+	flagVisited
+	flagRefined
+	flagNegative
+)
+
+// zeroCodingContexts maps the subband orientation and the numbers of
+// significant horizontal (0-2), vertical (0-2) and diagonal (0-4) neighbours
+// of a coefficient to its zero coding context (T.800 Table D.1).
+var zeroCodingContexts [4][3][3][5]uint8
+
+func init() {
+	for band := 0; band < 4; band++ {
+		for h := 0; h < 3; h++ {
+			for v := 0; v < 3; v++ {
+				for d := 0; d < 5; d++ {
+					zeroCodingContexts[band][h][v][d] = zeroCodingContext(band, h, v, d)
+				}
+			}
+		}
+	}
+}
+
+func zeroCodingContext(band, h, v, d int) uint8 {
+	switch band {
+	case bandHL:
+		h, v = v, h
+	case bandHH:
+		hv := h + v
+		switch {
+		case d >= 3:
+			return 8
+		case d == 2:
+			if hv >= 1 {
+				return 7
+			}
+			return 6
+		case d == 1:
+			if hv >= 2 {
+				return 5
+			}
+			if hv == 1 {
+				return 4
+			}
+			return 3
+		}
+		if hv >= 2 {
+			return 2
+		}
+		return uint8(hv)
+	}
+	switch {
+	case h == 2:
+		return 8
+	case h == 1:
+		if v >= 1 {
+			return 7
+		}
+		if d >= 1 {
+			return 6
+		}
+		return 5
+	case v == 2:
+		return 4
+	case v == 1:
+		return 3
+	case d >= 2:
+		return 2
+	}
+	return uint8(d)
+}
+
+// signContexts maps the horizontal and vertical sign contributions (-1, 0
+// or 1, offset by 1) to the sign coding context and the bit XORed with the
+// sign (T.800 Table D.3).
+var signContexts = [3][3][2]uint8{
+	{{13, 1}, {12, 1}, {11, 1}},
+	{{10, 1}, {9, 0}, {10, 0}},
+	{{11, 0}, {12, 0}, {13, 0}},
+}
+
+// blockCoder contains the state of the coefficient bit modeling of a
+// code-block, shared by the decoder and the encoder.
+type blockCoder struct {
+	width, height int
+	band          int
+	style         int
+
+	// flags holds the flags of the coefficients, with a border of one
+	// coefficient on each side so that the neighbours of the coefficients at
+	// the edges of the block can be accessed.
+	flags    []uint8
+	stride   int
+	contexts [numContexts]mqContext
+}
+
+// reset prepares the coder for a code-block.
+func (c *blockCoder) reset(width, height, band, style int) {
+	c.width, c.height = width, height
+	c.band = band
+	c.style = style
+	c.stride = width + 2
+	n := (width + 2) * (height + 2)
+	if cap(c.flags) < n {
+		c.flags = make([]uint8, n)
+	} else {
+		c.flags = c.flags[:n]
+		for i := range c.flags {
+			c.flags[i] = 0
+		}
+	}
+	c.resetContexts()
+}
+
+// resetContexts sets the contexts to their initial states (T.800 Table D.7).
+func (c *blockCoder) resetContexts() {
+	for i := range c.contexts {
+		c.contexts[i] = mqContext{}
+	}
+	c.contexts[ctxZeroCoding] = mqContext{index: 4}
+	c.contexts[ctxRunLength] = mqContext{index: 3}
+	c.contexts[ctxUniform] = mqContext{index: 46}
+}
+
+// below returns true if the neighbours below the coefficient in row `y` are
+// taken into account, which is not the case for the last row of a stripe in
+// the vertically causal mode.
+func (c *blockCoder) below(y int) bool {
+	return c.style&styleCausal == 0 || y&3 != 3
+}
+
+// neighbours returns the numbers of significant horizontal, vertical and
+// diagonal neighbours of the coefficient at index `p` of the flags, in row
+// `y`.
+func (c *blockCoder) neighbours(p, y int) (h, v, d int) {
+	f := c.flags
+	s := c.stride
+	h = int(f[p-1]&flagSignificant) + int(f[p+1]&flagSignificant)
+	v = int(f[p-s] & flagSignificant)
+	d = int(f[p-s-1]&flagSignificant) + int(f[p-s+1]&flagSignificant)
+	if c.below(y) {
+		v += int(f[p+s] & flagSignificant)
+		d += int(f[p+s-1]&flagSignificant) + int(f[p+s+1]&flagSignificant)
+	}
+	return h, v, d
+}
+
+// zeroCodingContext returns the zero coding context of the coefficient.
+// Returns 0 if the coefficient has no significant neighbours.
+func (c *blockCoder) zeroCodingContext(p, y int) int {
+	h, v, d := c.neighbours(p, y)
+	return int(zeroCodingContexts[c.band][h][v][d])
+}
+
+// signContext returns the sign coding context of the coefficient and the
+// bit which is XORed with its sign.
+func (c *blockCoder) signContext(p, y int) (int, int) {
+	contribution := func(q int) int {
+		switch f := c.flags[q]; {
+		case f&flagSignificant == 0:
+			return 0
+		case f&flagNegative != 0:
+			return -1
+		}
+		return 1
+	}
+	clamp := func(v int) int {
+		if v < -1 {
+			return -1
+		}
+		if v > 1 {
+			return 1
+		}
+		return v
+	}
+	h := clamp(contribution(p-1) + contribution(p+1))
+	v := contribution(p - c.stride)
+	if c.below(y) {
+		v += contribution(p + c.stride)
+	}
+	v = clamp(v)
+	e := signContexts[h+1][v+1]
+	return int(e[0]), int(e[1])
+}
+
+// magnitudeContext returns the magnitude refinement context of the
+// coefficient.
+func (c *blockCoder) magnitudeContext(p, y int) int {
+	if c.flags[p]&flagRefined != 0 {
+		return ctxMagnitude + 2
+	}
+	if h, v, d := c.neighbours(p, y); h+v+d > 0 {
+		return ctxMagnitude + 1
+	}
+	return ctxMagnitude
+}
+
+// runLengthColumn returns true if the column of four coefficients starting
+// at row `y0` of the stripe can be coded in run length mode by the cleanup
+// pass: the coefficients are insignificant, have not been coded in the
+// current bit-plane and have no significant neighbours.
+func (c *blockCoder) runLengthColumn(x, y0 int) bool {
+	if y0+4 > c.height {
+		return false
+	}
+	for y := y0; y < y0+4; y++ {
+		p := (y+1)*c.stride + x + 1
+		if c.flags[p]&(flagSignificant|flagVisited) != 0 || c.zeroCodingContext(p, y) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// clearVisited clears the flags of the coefficients coded in the
+// significance propagation pass, at the end of a bit-plane.
+func (c *blockCoder) clearVisited() {
+	for i := range c.flags {
+		c.flags[i] &^= flagVisited
+	}
+}
+
+// passBypassed returns true if the coding pass with the specified index and
+// type is raw coded (not arithmetically coded), which is the case for the
+// significance propagation and magnitude refinement passes after the tenth
+// pass in the selective arithmetic coding bypass mode.
+func passBypassed(style, index, passType int) bool {
+	return style&styleBypass != 0 && index >= 10 && passType != passCleanup
+}
+
+// segmentMaxPasses returns the maximum number of coding passes of the
+// codeword segment of a code-block starting with the pass with the specified
+// index (T.800 Table D.9).
+func segmentMaxPasses(style, index int) int {
+	switch {
+	case style&styleTermAll != 0:
+		return 1
+	case style&styleBypass != 0:
+		if index < 10 {
+			return 10 - index
+		}
+		if (index-10)%3 == 0 {
+			return 2
+		}
+		return 1
+	}
+	return 1 << 30
+}
+
+// blockDecoder decodes the coding passes of code-blocks (tier-1 decoding).
+type blockDecoder struct {
+	blockCoder
+	mq  mqDecoder
+	raw rawDecoder
+
+	// magnitude holds the decoded magnitude bits of the coefficients, and
+	// bits the number of bit-planes decoded for each coefficient, including
+	// the missing most significant bit-planes.
+	magnitude []int32
+	bits      []uint8
+}
+
+// decodeBlock decodes the coding passes of the code-block, whose most
+// significant `zeroPlanes` bit-planes are zero.
+func (d *blockDecoder) decodeBlock(cb *codeBlock, band, style int) {
+	w, h := cb.x1-cb.x0, cb.y1-cb.y0
+	d.reset(w, h, band, style)
+	n := w * h
+	if cap(d.magnitude) < n {
+		d.magnitude = make([]int32, n)
+		d.bits = make([]uint8, n)
+	} else {
+		d.magnitude = d.magnitude[:n]
+		d.bits = d.bits[:n]
+		for i := range d.magnitude {
+			d.magnitude[i] = 0
+		}
+	}
+	for i := range d.bits {
+		d.bits[i] = uint8(cb.zeroPlanes)
+	}
+
+	passType := passCleanup
+	seg, left := -1, 0
+	for index := 0; index < cb.passes; index++ {
+		raw := passBypassed(style, index, passType)
+		if left == 0 {
+			for left == 0 {
+				seg++
+				if seg >= len(cb.segments) {
+					return
+				}
+				left = cb.segments[seg].passes
+			}
+			if raw {
+				d.raw.init(cb.segments[seg].data)
+			} else {
+				d.mq.init(cb.segments[seg].data)
+			}
+		}
+		switch passType {
+		case passSignificance:
+			d.significancePass(raw)
+		case passRefinement:
+			d.refinementPass(raw)
+		case passCleanup:
+			d.cleanupPass()
+			if style&styleSegmentation != 0 {
+				for i := 0; i < 4; i++ {
+					d.mq.decode(&d.contexts[ctxUniform])
+				}
+			}
+		}
+		if style&styleReset != 0 {
+			d.resetContexts()
+		}
+		passType = (passType + 1) % 3
+		left--
+	}
+}
+
+// decodeSign decodes the sign of the coefficient which becomes significant.
+func (d *blockDecoder) decodeSign(p, y, i int, raw bool) {
+	var negative int
+	if raw {
+		negative = d.raw.decode()
+	} else {
+		ctx, xor := d.signContext(p, y)
+		negative = d.mq.decode(&d.contexts[ctx]) ^ xor
+	}
+	d.flags[p] |= flagSignificant
+	if negative != 0 {
+		d.flags[p] |= flagNegative
+	}
+	d.magnitude[i] = 1
+}
+
+func (d *blockDecoder) significancePass(raw bool) {
+	for y0 := 0; y0 < d.height; y0 += 4 {
+		for x := 0; x < d.width; x++ {
+			for y := y0; y < y0+4 && y < d.height; y++ {
+				p := (y+1)*d.stride + x + 1
+				if d.flags[p]&flagSignificant != 0 {
+					continue
+				}
+				ctx := d.zeroCodingContext(p, y)
+				if ctx == 0 {
+					continue
+				}
+				var bit int
+				if raw {
+					bit = d.raw.decode()
+				} else {
+					bit = d.mq.decode(&d.contexts[ctx])
+				}
+				i := y*d.width + x
+				d.bits[i]++
+				if bit != 0 {
+					d.decodeSign(p, y, i, raw)
+				}
+				d.flags[p] |= flagVisited
+			}
+		}
+	}
+}
+
+func (d *blockDecoder) refinementPass(raw bool) {
+	for y0 := 0; y0 < d.height; y0 += 4 {
+		for x := 0; x < d.width; x++ {
+			for y := y0; y < y0+4 && y < d.height; y++ {
+				p := (y+1)*d.stride + x + 1
+				if d.flags[p]&(flagSignificant|flagVisited) != flagSignificant {
+					continue
+				}
+				var bit int
+				if raw {
+					bit = d.raw.decode()
+				} else {
+					bit = d.mq.decode(&d.contexts[d.magnitudeContext(p, y)])
+				}
+				i := y*d.width + x
+				d.magnitude[i] = d.magnitude[i]<<1 | int32(bit)
+				d.bits[i]++
+				d.flags[p] |= flagRefined
+			}
+		}
+	}
+}
+
+func (d *blockDecoder) cleanupPass() {
+	for y0 := 0; y0 < d.height; y0 += 4 {
+		for x := 0; x < d.width; x++ {
+			y := y0
+			if d.runLengthColumn(x, y0) {
+				if d.mq.decode(&d.contexts[ctxRunLength]) == 0 {
+					for k := 0; k < 4; k++ {
+						d.bits[(y0+k)*d.width+x]++
+					}
+					continue
+				}
+				r := d.mq.decode(&d.contexts[ctxUniform]) << 1
+				r |= d.mq.decode(&d.contexts[ctxUniform])
+				for k := 0; k <= r; k++ {
+					d.bits[(y0+k)*d.width+x]++
+				}
+				y = y0 + r
+				d.decodeSign((y+1)*d.stride+x+1, y, y*d.width+x, false)
+				y++
+			}
+			for ; y < y0+4 && y < d.height; y++ {
+				p := (y+1)*d.stride + x + 1
+				if d.flags[p]&(flagSignificant|flagVisited) != 0 {
+					continue
+				}
+				i := y*d.width + x
+				d.bits[i]++
+				if d.mq.decode(&d.contexts[d.zeroCodingContext(p, y)]) != 0 {
+					d.decodeSign(p, y, i, false)
+				}
+			}
+		}
+	}
+	d.clearVisited()
+}
+
+// blockEncoder encodes the coefficients of code-blocks (tier-1 encoding).
+// All the coding passes of a code-block are coded in a single codeword
+// segment, using the default coding style.
+type blockEncoder struct {
+	blockCoder
+	mq mqEncoder
+
+	// magnitude holds the magnitudes of the coefficients of the block.
+	magnitude []int32
+	plane     uint
+}
+
+// encodeBlock encodes the `width` x `height` coefficients starting at index
+// `offset` of `coeffs`, whose rows are `stride` coefficients apart. Returns
+// the encoded data, the number of coding passes and the number of
+// significant bit-planes.
+func (e *blockEncoder) encodeBlock(coeffs []int32, offset, stride, width, height, band int) ([]byte, int, int) {
+	e.reset(width, height, band, 0)
+	n := width * height
+	if cap(e.magnitude) < n {
+		e.magnitude = make([]int32, n)
+	}
+	e.magnitude = e.magnitude[:n]
+	var max int32
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := coeffs[offset+y*stride+x]
+			p := (y+1)*e.stride + x + 1
+			if v < 0 {
+				v = -v
+				e.flags[p] |= flagNegative
+			}
+			e.magnitude[y*width+x] = v
+			if v > max {
+				max = v
+			}
+		}
+	}
+	planes := 0
+	for max > 0 {
+		planes++
+		max >>= 1
+	}
+	if planes == 0 {
+		return nil, 0, 0
+	}
+
+	e.mq.init()
+	passes := 0
+	for plane := planes - 1; plane >= 0; plane-- {
+		e.plane = uint(plane)
+		if plane != planes-1 {
+			e.significancePass()
+			e.refinementPass()
+			passes += 2
+		}
+		e.cleanupPass()
+		passes++
+	}
+	data := e.mq.flush()
+	return append([]byte(nil), data...), passes, planes
+}
+
+func (e *blockEncoder) bit(i int) int {
+	return int(e.magnitude[i]>>e.plane) & 1
+}
+
+// encodeSign encodes the sign of the coefficient which becomes significant.
+func (e *blockEncoder) encodeSign(p, y int) {
+	ctx, xor := e.signContext(p, y)
+	negative := 0
+	if e.flags[p]&flagNegative != 0 {
+		negative = 1
+	}
+	e.mq.encode(&e.contexts[ctx], negative^xor)
+	e.flags[p] |= flagSignificant
+}
+
+func (e *blockEncoder) significancePass() {
+	for y0 := 0; y0 < e.height; y0 += 4 {
+		for x := 0; x < e.width; x++ {
+			for y := y0; y < y0+4 && y < e.height; y++ {
+				p := (y+1)*e.stride + x + 1
+				if e.flags[p]&flagSignificant != 0 {
+					continue
+				}
+				ctx := e.zeroCodingContext(p, y)
+				if ctx == 0 {
+					continue
+				}
+				bit := e.bit(y*e.width + x)
+				e.mq.encode(&e.contexts[ctx], bit)
+				if bit != 0 {
+					e.encodeSign(p, y)
+				}
+				e.flags[p] |= flagVisited
+			}
+		}
+	}
+}
+
+func (e *blockEncoder) refinementPass() {
+	for y0 := 0; y0 < e.height; y0 += 4 {
+		for x := 0; x < e.width; x++ {
+			for y := y0; y < y0+4 && y < e.height; y++ {
+				p := (y+1)*e.stride + x + 1
+				if e.flags[p]&(flagSignificant|flagVisited) != flagSignificant {
+					continue
+				}
+				e.mq.encode(&e.contexts[e.magnitudeContext(p, y)], e.bit(y*e.width+x))
+				e.flags[p] |= flagRefined
+			}
+		}
+	}
+}
+
+func (e *blockEncoder) cleanupPass() {
+	for y0 := 0; y0 < e.height; y0 += 4 {
+		for x := 0; x < e.width; x++ {
+			y := y0
+			if e.runLengthColumn(x, y0) {
+				r := 0
+				for r < 4 && e.bit((y0+r)*e.width+x) == 0 {
+					r++
+				}
+				if r == 4 {
+					e.mq.encode(&e.contexts[ctxRunLength], 0)
+					continue
+				}
+				e.mq.encode(&e.contexts[ctxRunLength], 1)
+				e.mq.encode(&e.contexts[ctxUniform], r>>1)
+				e.mq.encode(&e.contexts[ctxUniform], r&1)
+				y = y0 + r
+				e.encodeSign((y+1)*e.stride+x+1, y)
+				y++
+			}
+			for ; y < y0+4 && y < e.height; y++ {
+				p := (y+1)*e.stride + x + 1
+				if e.flags[p]&(flagSignificant|flagVisited) != 0 {
+					continue
+				}
+				bit := e.bit(y*e.width + x)
+				e.mq.encode(&e.contexts[e.zeroCodingContext(p, y)], bit)
+				if bit != 0 {
+					e.encodeSign(p, y)
+				}
+			}
+		}
+	}
+	e.clearVisited()
+}
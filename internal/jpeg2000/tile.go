@@ -0,0 +1,353 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+import (
+	"math"
+	"sort"
+)
+
+// tile represents a tile of the image and the partition of its components
+// into resolution levels, subbands, precincts and code-blocks (T.800 Annex
+// B). The coordinates are on the reference grid for the tiles, and on the
+// grid of the component, resolution level or subband for the others.
+type tile struct {
+	x0, y0, x1, y1 int
+	defaults       *codingDefaults
+	components     []*tileComponent
+}
+
+// tileComponent represents a component of a tile.
+type tileComponent struct {
+	x0, y0, x1, y1 int
+	dx, dy         int
+	style          *codingStyle
+	quant          *quantization
+	roiShift       int
+	resolutions    []*resolution
+}
+
+// resolution represents a resolution level of a tile component.
+type resolution struct {
+	x0, y0, x1, y1 int
+
+	// ppx and ppy are the base 2 logarithms of the precinct dimensions, and
+	// precinctsW and precinctsH the numbers of precincts in each direction.
+	ppx, ppy               int
+	precinctsW, precinctsH int
+
+	// bands contains the LL subband at the lowest resolution level, the HL,
+	// LH and HH subbands at the others.
+	bands []*subband
+}
+
+// subband represents a subband of a resolution level.
+type subband struct {
+	kind           int
+	x0, y0, x1, y1 int
+
+	// magnitudeBits is the maximum number of magnitude bit-planes of the
+	// coefficients (Mb), including the region of interest shift, and step
+	// the quantization step size of the irreversible transformation.
+	magnitudeBits int
+	step          float64
+
+	// precincts contains the code-blocks of each precinct of the resolution
+	// level in the subband.
+	precincts []*precinct
+}
+
+// precinct represents the part of a precinct in a subband.
+type precinct struct {
+	blocksW, blocksH int
+	blocks           []*codeBlock
+
+	// inclusion and zeroPlanes are the tag trees coding the first layer in
+	// which each code-block is included and its number of missing most
+	// significant bit-planes.
+	inclusion  *tagTree
+	zeroPlanes *tagTree
+}
+
+// codeBlock represents a code-block and its decoded codeword segments.
+type codeBlock struct {
+	x0, y0, x1, y1 int
+
+	included   bool
+	lblock     int
+	zeroPlanes int
+	passes     int
+	segments   []segment
+}
+
+// segment is a codeword segment of a code-block: a sequence of coding
+// passes terminated together.
+type segment struct {
+	data   []byte
+	passes int
+}
+
+// newTile returns the tile with the specified index and computes its
+// partition.
+func newTile(cs *codestream, index int, td *tileData) (*tile, error) {
+	across := cs.tilesAcross()
+	p, q := index%across, index/across
+	t := &tile{
+		x0:       maxInt(cs.tileX0+p*cs.tileW, cs.x0),
+		y0:       maxInt(cs.tileY0+q*cs.tileH, cs.y0),
+		x1:       minInt(cs.tileX0+(p+1)*cs.tileW, cs.x1),
+		y1:       minInt(cs.tileY0+(q+1)*cs.tileH, cs.y1),
+		defaults: cs.codingDefaults(td),
+	}
+	for c, comp := range cs.components {
+		tc := &tileComponent{
+			x0:       ceilDiv(t.x0, comp.dx),
+			y0:       ceilDiv(t.y0, comp.dy),
+			x1:       ceilDiv(t.x1, comp.dx),
+			y1:       ceilDiv(t.y1, comp.dy),
+			dx:       comp.dx,
+			dy:       comp.dy,
+			style:    cs.codingStyle(td, c),
+			quant:    cs.quantization(td, c),
+			roiShift: cs.roiShift(td, c),
+		}
+		if err := tc.partition(comp.precision); err != nil {
+			return nil, err
+		}
+		t.components = append(t.components, tc)
+	}
+	return t, nil
+}
+
+// partition computes the resolution levels, subbands, precincts and
+// code-blocks of the tile component.
+func (tc *tileComponent) partition(precision int) error {
+	levels := tc.style.levels
+	for r := 0; r <= levels; r++ {
+		scale := 1 << uint(levels-r)
+		res := &resolution{
+			x0: ceilDiv(tc.x0, scale),
+			y0: ceilDiv(tc.y0, scale),
+			x1: ceilDiv(tc.x1, scale),
+			y1: ceilDiv(tc.y1, scale),
+		}
+		res.ppx, res.ppy = tc.style.precinctSize(r)
+		if res.x1 > res.x0 && res.y1 > res.y0 {
+			res.precinctsW = ceilDiv(res.x1, 1<<uint(res.ppx)) - res.x0>>uint(res.ppx)
+			res.precinctsH = ceilDiv(res.y1, 1<<uint(res.ppy)) - res.y0>>uint(res.ppy)
+		}
+		if res.precinctsW*res.precinctsH > 1<<20 {
+			return FormatError("too many precincts")
+		}
+
+		kinds := []int{bandHL, bandLH, bandHH}
+		if r == 0 {
+			kinds = []int{bandLL}
+		}
+		for _, kind := range kinds {
+			b := &subband{kind: kind}
+			// Precinct and code-block dimensions in the subband.
+			pbx, pby := res.ppx, res.ppy
+			if r == 0 {
+				b.x0, b.y0, b.x1, b.y1 = res.x0, res.y0, res.x1, res.y1
+			} else {
+				pbx, pby = pbx-1, pby-1
+				n := uint(levels - r + 1)
+				xo, yo := kind&1, kind>>1
+				b.x0 = ceilDiv(tc.x0-xo<<(n-1), 1<<n)
+				b.y0 = ceilDiv(tc.y0-yo<<(n-1), 1<<n)
+				b.x1 = ceilDiv(tc.x1-xo<<(n-1), 1<<n)
+				b.y1 = ceilDiv(tc.y1-yo<<(n-1), 1<<n)
+			}
+			xcb, ycb := minInt(tc.style.xcb, pbx), minInt(tc.style.ycb, pby)
+
+			gain := [4]int{0, 1, 1, 2}[kind]
+			step := tc.quant.step(r, kind)
+			b.magnitudeBits = tc.quant.guardBits + step.exponent - 1 + tc.roiShift
+			b.step = math.Ldexp(1+float64(step.mantissa)/2048, precision+gain-step.exponent)
+			if b.magnitudeBits > 31 || b.magnitudeBits < 0 {
+				return UnsupportedError("number of bit-planes")
+			}
+
+			for py := 0; py < res.precinctsH; py++ {
+				for px := 0; px < res.precinctsW; px++ {
+					// Precinct bounds in the subband.
+					x0 := (res.x0>>uint(res.ppx) + px) << uint(pbx)
+					y0 := (res.y0>>uint(res.ppy) + py) << uint(pby)
+					x1 := minInt(x0+1<<uint(pbx), b.x1)
+					y1 := minInt(y0+1<<uint(pby), b.y1)
+					x0, y0 = maxInt(x0, b.x0), maxInt(y0, b.y0)
+					prec := &precinct{}
+					if x1 > x0 && y1 > y0 {
+						bx0, by0 := x0>>uint(xcb), y0>>uint(ycb)
+						prec.blocksW = ceilDiv(x1, 1<<uint(xcb)) - bx0
+						prec.blocksH = ceilDiv(y1, 1<<uint(ycb)) - by0
+						for j := 0; j < prec.blocksH; j++ {
+							for i := 0; i < prec.blocksW; i++ {
+								cb := &codeBlock{
+									x0:     maxInt((bx0+i)<<uint(xcb), x0),
+									y0:     maxInt((by0+j)<<uint(ycb), y0),
+									x1:     minInt((bx0+i+1)<<uint(xcb), x1),
+									y1:     minInt((by0+j+1)<<uint(ycb), y1),
+									lblock: 3,
+								}
+								prec.blocks = append(prec.blocks, cb)
+							}
+						}
+						prec.inclusion = newTagTree(prec.blocksW, prec.blocksH)
+						prec.zeroPlanes = newTagTree(prec.blocksW, prec.blocksH)
+					}
+					b.precincts = append(b.precincts, prec)
+				}
+			}
+			res.bands = append(res.bands, b)
+		}
+		tc.resolutions = append(tc.resolutions, res)
+	}
+	return nil
+}
+
+// packet identifies a packet of a tile: the data of a layer of a precinct
+// of a resolution level of a component.
+type packet struct {
+	layer, comp, res, precinct int
+}
+
+// packets returns the packets of the tile in the order of the progression
+// of the tile, with the specified progression order changes.
+func (t *tile) packets(changes []progressionChange) []packet {
+	layers := t.defaults.layers
+	maxRes := 0
+	for _, tc := range t.components {
+		maxRes = maxInt(maxRes, len(tc.resolutions))
+	}
+	if len(changes) == 0 {
+		changes = []progressionChange{{
+			layerEnd: layers,
+			resEnd:   maxRes,
+			compEnd:  len(t.components),
+			order:    t.defaults.progression,
+		}}
+	}
+
+	// next contains the index of the next layer of each precinct.
+	next := make([][][]int, len(t.components))
+	for c, tc := range t.components {
+		next[c] = make([][]int, len(tc.resolutions))
+		for r, res := range tc.resolutions {
+			next[c][r] = make([]int, res.precinctsW*res.precinctsH)
+		}
+	}
+
+	var packets []packet
+	emit := func(l, c, r, p int) {
+		if next[c][r][p] == l {
+			packets = append(packets, packet{l, c, r, p})
+			next[c][r][p]++
+		}
+	}
+	for _, change := range changes {
+		layerEnd := minInt(change.layerEnd, layers)
+		compEnd := minInt(change.compEnd, len(t.components))
+		resEnd := minInt(change.resEnd, maxRes)
+		switch change.order {
+		case progressionLRCP:
+			for l := 0; l < layerEnd; l++ {
+				for r := change.resStart; r < resEnd; r++ {
+					for c := change.compStart; c < compEnd; c++ {
+						if r < len(t.components[c].resolutions) {
+							res := t.components[c].resolutions[r]
+							for p := 0; p < res.precinctsW*res.precinctsH; p++ {
+								emit(l, c, r, p)
+							}
+						}
+					}
+				}
+			}
+		case progressionRLCP:
+			for r := change.resStart; r < resEnd; r++ {
+				for l := 0; l < layerEnd; l++ {
+					for c := change.compStart; c < compEnd; c++ {
+						if r < len(t.components[c].resolutions) {
+							res := t.components[c].resolutions[r]
+							for p := 0; p < res.precinctsW*res.precinctsH; p++ {
+								emit(l, c, r, p)
+							}
+						}
+					}
+				}
+			}
+		default:
+			// Position driven progressions: the precincts are sorted by
+			// position on the reference grid.
+			type position struct {
+				c, r, p int
+				x, y    int
+			}
+			var positions []position
+			for c := change.compStart; c < compEnd; c++ {
+				tc := t.components[c]
+				for r := change.resStart; r < resEnd && r < len(tc.resolutions); r++ {
+					res := tc.resolutions[r]
+					scale := uint(len(tc.resolutions) - 1 - r)
+					for p := 0; p < res.precinctsW*res.precinctsH; p++ {
+						px := (res.x0>>uint(res.ppx) + p%res.precinctsW) << uint(res.ppx)
+						py := (res.y0>>uint(res.ppy) + p/res.precinctsW) << uint(res.ppy)
+						positions = append(positions, position{
+							c: c, r: r, p: p,
+							x: maxInt(t.x0, px<<scale*tc.dx),
+							y: maxInt(t.y0, py<<scale*tc.dy),
+						})
+					}
+				}
+			}
+			order := change.order
+			sort.SliceStable(positions, func(i, j int) bool {
+				a, b := positions[i], positions[j]
+				switch {
+				case order == progressionRPCL && a.r != b.r:
+					return a.r < b.r
+				case order == progressionCPRL && a.c != b.c:
+					return a.c < b.c
+				case a.y != b.y:
+					return a.y < b.y
+				case a.x != b.x:
+					return a.x < b.x
+				case order == progressionPCRL && a.c != b.c:
+					return a.c < b.c
+				}
+				if order == progressionRPCL {
+					return a.c < b.c
+				}
+				return a.r < b.r
+			})
+			for _, pos := range positions {
+				for l := 0; l < layerEnd; l++ {
+					emit(l, pos.c, pos.r, pos.p)
+				}
+			}
+		}
+	}
+	return packets
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
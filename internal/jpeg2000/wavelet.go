@@ -0,0 +1,275 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package jpeg2000
+
+// Lifting parameters of the irreversible 9-7 wavelet transformation (T.800
+// Table F.4).
+const (
+	alpha97 = -1.586134342059924
+	beta97  = -0.052980118572961
+	gamma97 = 0.882911075530934
+	delta97 = 0.443506852043971
+	k97     = 1.230174104914001
+)
+
+// waveletPadding is the number of samples by which the signals are extended
+// on each side for the lifting steps.
+const waveletPadding = 4
+
+// reflect returns the index of the sample at index `i` of the periodic
+// symmetric extension of a signal of `n` samples (T.800 F.3.7).
+func reflect(i, n int) int {
+	if n == 1 {
+		return 0
+	}
+	period := 2 * (n - 1)
+	i %= period
+	if i < 0 {
+		i += period
+	}
+	if i >= n {
+		i = period - i
+	}
+	return i
+}
+
+// wavelet53 implements the reversible 5-3 wavelet transformation on integer
+// samples. The transformations are applied in place, to interleaved low-pass
+// and high-pass samples.
+type wavelet53 struct {
+	buf []int32
+	col []int32
+}
+
+// extend copies the signal `x` to the buffer, with its symmetric extension.
+func (w *wavelet53) extend(x []int32) []int32 {
+	n := len(x)
+	if cap(w.buf) < n+2*waveletPadding {
+		w.buf = make([]int32, n+2*waveletPadding)
+	}
+	buf := w.buf[:n+2*waveletPadding]
+	for i := range buf {
+		buf[i] = x[reflect(i-waveletPadding, n)]
+	}
+	return buf
+}
+
+// inverse1D applies the 1D inverse transformation to the signal `x` starting
+// at coordinate `i0`.
+func (w *wavelet53) inverse1D(x []int32, i0 int) {
+	if len(x) == 1 {
+		if i0&1 != 0 {
+			x[0] /= 2
+		}
+		return
+	}
+	buf := w.extend(x)
+	// Index of the first low-pass (even) and high-pass (odd) samples, past
+	// the first sample of the buffer.
+	low, high := 1+(i0&1^1), 1+i0&1
+	for j := low; j < len(buf)-1; j += 2 {
+		buf[j] -= (buf[j-1] + buf[j+1] + 2) >> 2
+	}
+	for j := high; j < len(buf)-1; j += 2 {
+		buf[j] += (buf[j-1] + buf[j+1]) >> 1
+	}
+	copy(x, buf[waveletPadding:])
+}
+
+// forward1D applies the 1D forward transformation to the signal `x` starting
+// at coordinate `i0`.
+func (w *wavelet53) forward1D(x []int32, i0 int) {
+	if len(x) == 1 {
+		if i0&1 != 0 {
+			x[0] *= 2
+		}
+		return
+	}
+	buf := w.extend(x)
+	// Index of the first low-pass (even) and high-pass (odd) samples, past
+	// the first sample of the buffer.
+	low, high := 1+(i0&1^1), 1+i0&1
+	for j := high; j < len(buf)-1; j += 2 {
+		buf[j] -= (buf[j-1] + buf[j+1]) >> 1
+	}
+	for j := low; j < len(buf)-1; j += 2 {
+		buf[j] += (buf[j-1] + buf[j+1] + 2) >> 2
+	}
+	copy(x, buf[waveletPadding:])
+}
+
+// inverse2D applies the 2D inverse transformation to the interleaved
+// coefficients of the `width` x `height` resolution level starting at
+// coordinates (u0, v0): horizontally, then vertically.
+func (w *wavelet53) inverse2D(data []int32, width, height, u0, v0 int) {
+	for y := 0; y < height; y++ {
+		w.inverse1D(data[y*width:(y+1)*width], u0)
+	}
+	if cap(w.col) < height {
+		w.col = make([]int32, height)
+	}
+	col := w.col[:height]
+	for x := 0; x < width; x++ {
+		for y := range col {
+			col[y] = data[y*width+x]
+		}
+		w.inverse1D(col, v0)
+		for y, v := range col {
+			data[y*width+x] = v
+		}
+	}
+}
+
+// forward2D applies the 2D forward transformation to the samples of the
+// `width` x `height` resolution level starting at coordinates (u0, v0):
+// vertically, then horizontally. The resulting coefficients are
+// interleaved.
+func (w *wavelet53) forward2D(data []int32, width, height, u0, v0 int) {
+	if cap(w.col) < height {
+		w.col = make([]int32, height)
+	}
+	col := w.col[:height]
+	for x := 0; x < width; x++ {
+		for y := range col {
+			col[y] = data[y*width+x]
+		}
+		w.forward1D(col, v0)
+		for y, v := range col {
+			data[y*width+x] = v
+		}
+	}
+	for y := 0; y < height; y++ {
+		w.forward1D(data[y*width:(y+1)*width], u0)
+	}
+}
+
+// wavelet97 implements the irreversible 9-7 wavelet transformation on real
+// samples. The transformations are applied in place, to interleaved low-pass
+// and high-pass samples.
+type wavelet97 struct {
+	buf []float32
+	col []float32
+}
+
+// extend copies the signal `x` to the buffer, with its symmetric extension.
+func (w *wavelet97) extend(x []float32) []float32 {
+	n := len(x)
+	if cap(w.buf) < n+2*waveletPadding {
+		w.buf = make([]float32, n+2*waveletPadding)
+	}
+	buf := w.buf[:n+2*waveletPadding]
+	for i := range buf {
+		buf[i] = x[reflect(i-waveletPadding, n)]
+	}
+	return buf
+}
+
+// lift applies a lifting step to the samples starting at index `first` of
+// the buffer, every other sample.
+func lift(buf []float32, first int, factor float32) {
+	for j := first; j < len(buf)-1; j += 2 {
+		buf[j] += factor * (buf[j-1] + buf[j+1])
+	}
+}
+
+// inverse1D applies the 1D inverse transformation to the signal `x` starting
+// at coordinate `i0`.
+func (w *wavelet97) inverse1D(x []float32, i0 int) {
+	if len(x) == 1 {
+		if i0&1 != 0 {
+			x[0] /= 2
+		}
+		return
+	}
+	buf := w.extend(x)
+	// Index of the first low-pass (even) and high-pass (odd) samples, past
+	// the first sample of the buffer.
+	low, high := 1+(i0&1^1), 1+i0&1
+	for j := low - 1; j < len(buf); j += 2 {
+		buf[j] *= k97
+	}
+	for j := high - 1; j < len(buf); j += 2 {
+		buf[j] *= 1 / k97
+	}
+	lift(buf, low, -delta97)
+	lift(buf, high, -gamma97)
+	lift(buf, low, -beta97)
+	lift(buf, high, -alpha97)
+	copy(x, buf[waveletPadding:])
+}
+
+// forward1D applies the 1D forward transformation to the signal `x` starting
+// at coordinate `i0`.
+func (w *wavelet97) forward1D(x []float32, i0 int) {
+	if len(x) == 1 {
+		if i0&1 != 0 {
+			x[0] *= 2
+		}
+		return
+	}
+	buf := w.extend(x)
+	low, high := 1+(i0&1^1), 1+i0&1
+	lift(buf, high, alpha97)
+	lift(buf, low, beta97)
+	lift(buf, high, gamma97)
+	lift(buf, low, delta97)
+	for j := low - 1; j < len(buf); j += 2 {
+		buf[j] *= 1 / k97
+	}
+	for j := high - 1; j < len(buf); j += 2 {
+		buf[j] *= k97
+	}
+	copy(x, buf[waveletPadding:])
+}
+
+// inverse2D applies the 2D inverse transformation to the interleaved
+// coefficients of the `width` x `height` resolution level starting at
+// coordinates (u0, v0): horizontally, then vertically.
+func (w *wavelet97) inverse2D(data []float32, width, height, u0, v0 int) {
+	for y := 0; y < height; y++ {
+		w.inverse1D(data[y*width:(y+1)*width], u0)
+	}
+	if cap(w.col) < height {
+		w.col = make([]float32, height)
+	}
+	col := w.col[:height]
+	for x := 0; x < width; x++ {
+		for y := range col {
+			col[y] = data[y*width+x]
+		}
+		w.inverse1D(col, v0)
+		for y, v := range col {
+			data[y*width+x] = v
+		}
+	}
+}
+
+// forward2D applies the 2D forward transformation to the samples of the
+// `width` x `height` resolution level starting at coordinates (u0, v0):
+// vertically, then horizontally. The resulting coefficients are
+// interleaved.
+func (w *wavelet97) forward2D(data []float32, width, height, u0, v0 int) {
+	if cap(w.col) < height {
+		w.col = make([]float32, height)
+	}
+	col := w.col[:height]
+	for x := 0; x < width; x++ {
+		for y := range col {
+			col[y] = data[y*width+x]
+		}
+		w.forward1D(col, v0)
+		for y, v := range col {
+			data[y*width+x] = v
+		}
+	}
+	for y := 0; y < height; y++ {
+		w.forward1D(data[y*width:(y+1)*width], u0)
+	}
+}
@@ -0,0 +1,418 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// XrefFormat defines the format of the cross-reference section written by the PdfAppender.
+type XrefFormat int
+
+const (
+	// XrefFormatPreserve uses the format of the last cross-reference section of the document.
+	XrefFormatPreserve XrefFormat = iota
+
+	// XrefFormatTable writes a cross-reference table.
+	XrefFormatTable
+
+	// XrefFormatStream writes a cross-reference stream (PDF 1.5).
+	XrefFormatStream
+)
+
+// AppenderOpts defines the options of the PdfAppender.
+type AppenderOpts struct {
+	// XrefFormat is the format of the cross-reference section of the incremental update.
+	XrefFormat XrefFormat
+}
+
+// NewPdfAppenderWithOpts creates a new PdfAppender for the document loaded by `reader` with
+// the options `opts`. A nil `opts` is equivalent to NewPdfAppender.
+func NewPdfAppenderWithOpts(reader *PdfReader, opts *AppenderOpts) (*PdfAppender, error) {
+	appender, err := NewPdfAppender(reader)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil {
+		appender.xrefFormat = opts.XrefFormat
+	}
+	return appender, nil
+}
+
+// SetDocInfo sets the document information dictionary of the incremental update.
+func (a *PdfAppender) SetDocInfo(info *PdfInfo) {
+	dict, ok := core.GetDict(info.ToPdfObject())
+	if !ok {
+		return
+	}
+	if ind := a.readerInfo(); ind != nil {
+		ind.PdfObject = dict
+		return
+	}
+	a.Reader._gdbbd.GetTrailer().Set("Info", core.MakeIndirectObject(dict))
+}
+
+// Revisions returns the revisions of the document, ordered from the original document to the
+// latest incremental update.
+func (a *PdfAppender) Revisions() ([]core.Revision, error) {
+	return a.Reader._gdbbd.GetRevisions()
+}
+
+// RollbackTo discards the revisions of the document following revision `number`, as returned
+// by Revisions, and the changes made through the appender. Reader is replaced by a reader of
+// the document as of the revision, to which the following changes apply.
+func (a *PdfAppender) RollbackTo(number int) error {
+	encrypted, err := a.Reader.IsEncrypted()
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		return errors.New("rollback of encrypted documents not supported")
+	}
+	reader, err := a.Reader.GetRevision(number)
+	if err != nil {
+		return err
+	}
+	appender, err := NewPdfAppenderWithOpts(reader, &AppenderOpts{XrefFormat: a.xrefFormat})
+	if err != nil {
+		return err
+	}
+	*a = *appender
+	return nil
+}
+
+// readerInfo returns the document information dictionary of Reader, if it is an indirect
+// object.
+func (a *PdfAppender) readerInfo() *core.PdfIndirectObject {
+	info := a.Reader._gdbbd.GetTrailer().Get("Info")
+	if ref, ok := info.(*core.PdfObjectReference); ok {
+		obj, err := a.Reader.GetIndirectObjectByNumber(int(ref.ObjectNumber))
+		if err != nil {
+			return nil
+		}
+		info = obj
+	}
+	ind, _ := info.(*core.PdfIndirectObject)
+	return ind
+}
+
+// setInfo sets the document information dictionary written by `w`. The dictionary of the
+// document is kept, and only written if it was modified.
+func (a *PdfAppender) setInfo(w *PdfWriter) {
+	ind := a.readerInfo()
+	if ind == nil {
+		a.addNewObject(w._caefd)
+		return
+	}
+	w._caefd = ind
+	a.trackObject(ind, map[core.PdfObject]struct{}{})
+}
+
+// setXrefFormat sets the format of the cross-reference section written by `w`.
+func (a *PdfAppender) setXrefFormat(w *PdfWriter) {
+	var useStream bool
+	switch a.xrefFormat {
+	case XrefFormatTable:
+	case XrefFormatStream:
+		useStream = true
+	default:
+		return
+	}
+	w._ecfeg = &useStream
+}
+
+// trackChanges adds the objects of the document modified through Reader to the objects
+// written by the appender, i.e. the objects of the pages and their annotations, the
+// interactive form, the outlines and the other entries of the catalog. The models of Reader
+// are converted to PDF objects and compared to the same models of the original document, so
+// that only the actual changes are written.
+func (a *PdfAppender) trackChanges() {
+	orig := a._fdd
+	// All the models are converted before tracking, as the objects they update can be reached
+	// from each other, e.g. the pages through the page tree.
+	var pages []core.PdfObject
+	for i, page := range a.Reader.PageList {
+		if i < len(orig.PageList) {
+			if page._bcgfd != nil {
+				orig.PageList[i].GetAnnotations()
+			}
+			orig.PageList[i].ToPdfObject()
+		}
+		pages = append(pages, page.ToPdfObject())
+	}
+
+	var form core.PdfObject
+	if orig.AcroForm != nil {
+		orig.AcroForm.ToPdfObject()
+	}
+	if a.Reader.AcroForm != nil && a._faga == orig.AcroForm {
+		if orig.AcroForm == nil {
+			a._faga = a.Reader.AcroForm
+		} else {
+			form = a.Reader.AcroForm.ToPdfObject()
+		}
+	}
+
+	// The outline items are not bound to the objects of the document, the outline tree is
+	// written anew if it was modified.
+	var outlines core.PdfObject
+	if a.Reader._gaad != nil && a.Reader._acae != nil && !sameOutlines(orig._gaad, a.Reader._gaad) {
+		outlines = a.Reader._gaad.GetContext().ToPdfObject()
+		a.Reader._acae.Set("Outlines", outlines)
+	}
+
+	visited := map[core.PdfObject]struct{}{}
+	// The page tree and the catalog are written by the appender.
+	if a.Reader._cbfdd != nil {
+		visited[a.Reader._cbfdd] = struct{}{}
+	}
+	if root, ok := core.GetIndirect(a.Reader._gdbbd.GetTrailer().Get("Root")); ok {
+		visited[root] = struct{}{}
+	}
+	for _, page := range pages {
+		a.trackObject(page, visited)
+	}
+	a.trackObject(form, visited)
+	a.trackObject(outlines, visited)
+	if catalog := a.Reader._acae; catalog != nil {
+		for _, key := range catalog.Keys() {
+			if key == "Pages" || key == "AcroForm" {
+				continue
+			}
+			a.trackObject(catalog.Get(key), visited)
+		}
+	}
+}
+
+// sameOutlines returns true if the outline trees starting at `node1` and `node2` have the same
+// structure and items.
+func sameOutlines(node1, node2 *PdfOutlineTreeNode) bool {
+	for node1 != nil || node2 != nil {
+		if node1 == nil || node2 == nil {
+			return false
+		}
+		switch t1 := node1.GetContext().(type) {
+		case *PdfOutline:
+			t2, ok := node2.GetContext().(*PdfOutline)
+			return ok && sameCount(t1.Count, t2.Count) && sameOutlines(t1.First, t2.First)
+		case *PdfOutlineItem:
+			t2, ok := node2.GetContext().(*PdfOutlineItem)
+			if !ok || !sameOutlineItem(t1, t2) || !sameOutlines(t1.First, t2.First) {
+				return false
+			}
+			node1, node2 = t1.Next, t2.Next
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// sameOutlineItem returns true if the outline items `item1` and `item2` have the same entries,
+// regardless of their position in the outline tree.
+func sameOutlineItem(item1, item2 *PdfOutlineItem) bool {
+	if (item1.Title == nil) != (item2.Title == nil) ||
+		item1.Title != nil && item1.Title.Str() != item2.Title.Str() {
+		return false
+	}
+	return sameCount(item1.Count, item2.Count) &&
+		sameObjectContents(item1.Dest, item2.Dest) &&
+		sameObjectContents(item1.A, item2.A) &&
+		sameObjectContents(item1.C, item2.C) &&
+		sameObjectContents(item1.F, item2.F)
+}
+
+// sameCount returns true if the optional counts `count1` and `count2` are equal.
+func sameCount(count1, count2 *int64) bool {
+	if count1 == nil || count2 == nil {
+		return count1 == count2
+	}
+	return *count1 == *count2
+}
+
+// trackObject adds `obj` and the objects it contains to the objects written by the appender
+// if they are new or were modified. Only the objects loaded by Reader are compared to the
+// original document, the others cannot have been modified.
+func (a *PdfAppender) trackObject(obj core.PdfObject, visited map[core.PdfObject]struct{}) {
+	if obj == nil {
+		return
+	}
+	if _, ok := visited[obj]; ok {
+		return
+	}
+	visited[obj] = struct{}{}
+
+	parser := a.Reader._gdbbd
+	switch t := obj.(type) {
+	case *core.PdfObjectReference:
+		if t.GetParser() != parser {
+			return
+		}
+		if cached, ok := parser.ObjCache[int(t.ObjectNumber)]; ok {
+			a.trackObject(cached, visited)
+		}
+	case *core.PdfIndirectObject:
+		if !a.trackModified(t, t.ObjectNumber, t.GetParser()) {
+			return
+		}
+		a.trackObject(t.PdfObject, visited)
+	case *core.PdfObjectStream:
+		if !a.trackModified(t, t.ObjectNumber, t.GetParser()) {
+			return
+		}
+		a.trackObject(t.PdfObjectDictionary, visited)
+	case *core.PdfObjectDictionary:
+		for _, key := range t.Keys() {
+			a.trackObject(t.Get(key), visited)
+		}
+	case *core.PdfObjectArray:
+		for _, elem := range t.Elements() {
+			a.trackObject(elem, visited)
+		}
+	}
+}
+
+// trackModified adds the indirect object `obj` with number `objNum` loaded by `parser` to the
+// objects written by the appender if it is new or was modified. Returns false if `obj` belongs
+// to the original document, whose objects are never written.
+func (a *PdfAppender) trackModified(obj core.PdfObject, objNum int64, parser *core.PdfParser) bool {
+	// The objects of object streams are not bound to the parser which loaded them.
+	loadedBy := func(p *core.PdfParser) bool {
+		return parser == p || objNum > 0 && p.ObjCache[int(objNum)] == obj
+	}
+	switch {
+	case loadedBy(a._fdd._gdbbd):
+		return false
+	case loadedBy(a.Reader._gdbbd):
+		if !a.isUnchanged(objNum, obj) {
+			a.addNewObject(obj)
+			a._ddff[obj] = objNum
+		}
+	default:
+		a.addNewObject(obj)
+	}
+	return true
+}
+
+// isUnchanged returns true if `obj` has the same contents as the object `objNum` of the
+// original document.
+func (a *PdfAppender) isUnchanged(objNum int64, obj core.PdfObject) bool {
+	orig, err := a._fdd.GetIndirectObjectByNumber(int(objNum))
+	if err != nil {
+		return false
+	}
+	return sameIndirectObjects(obj, orig)
+}
+
+// sameIndirectObjects returns true if the indirect objects or streams `obj1` and `obj2` have
+// the same contents, see sameObjectContents.
+func sameIndirectObjects(obj1, obj2 core.PdfObject) bool {
+	switch t1 := obj1.(type) {
+	case *core.PdfIndirectObject:
+		t2, ok := obj2.(*core.PdfIndirectObject)
+		return ok && sameObjectContents(t1.PdfObject, t2.PdfObject)
+	case *core.PdfObjectStream:
+		t2, ok := obj2.(*core.PdfObjectStream)
+		return ok && bytes.Equal(t1.Stream, t2.Stream) &&
+			sameObjectContents(t1.PdfObjectDictionary, t2.PdfObjectDictionary)
+	}
+	return false
+}
+
+// sameObjectContents returns true if `obj1` and `obj2` have the same value. The indirect
+// objects they contain are compared by object number, the order of dictionary entries and the
+// representation of numbers and strings are ignored.
+func sameObjectContents(obj1, obj2 core.PdfObject) bool {
+	if num1, ok := indirectObjectNumber(obj1); ok {
+		num2, ok := indirectObjectNumber(obj2)
+		return ok && num1 == num2 && num1 != 0
+	}
+	if _, ok := indirectObjectNumber(obj2); ok {
+		return false
+	}
+	if isNullObject(obj1) || isNullObject(obj2) {
+		return isNullObject(obj1) && isNullObject(obj2)
+	}
+	switch t1 := obj1.(type) {
+	case *core.PdfObjectInteger, *core.PdfObjectFloat:
+		v1, err1 := core.GetNumberAsFloat(obj1)
+		v2, err2 := core.GetNumberAsFloat(obj2)
+		return err1 == nil && err2 == nil && v1 == v2
+	case *core.PdfObjectName:
+		t2, ok := obj2.(*core.PdfObjectName)
+		return ok && *t1 == *t2
+	case *core.PdfObjectString:
+		t2, ok := obj2.(*core.PdfObjectString)
+		return ok && t1.Str() == t2.Str()
+	case *core.PdfObjectBool:
+		t2, ok := obj2.(*core.PdfObjectBool)
+		return ok && *t1 == *t2
+	case *core.PdfObjectArray:
+		t2, ok := obj2.(*core.PdfObjectArray)
+		if !ok || t1.Len() != t2.Len() {
+			return false
+		}
+		for i, elem := range t1.Elements() {
+			if !sameObjectContents(elem, t2.Get(i)) {
+				return false
+			}
+		}
+		return true
+	case *core.PdfObjectDictionary:
+		t2, ok := obj2.(*core.PdfObjectDictionary)
+		if !ok {
+			return false
+		}
+		count := 0
+		for _, key := range t1.Keys() {
+			val := t1.Get(key)
+			if isNullObject(val) {
+				continue
+			}
+			count++
+			if !sameObjectContents(val, t2.Get(key)) {
+				return false
+			}
+		}
+		for _, key := range t2.Keys() {
+			if !isNullObject(t2.Get(key)) {
+				count--
+			}
+		}
+		return count == 0
+	}
+	return false
+}
+
+// indirectObjectNumber returns the object number of `obj` if it is an indirect object, a
+// stream or a reference.
+func indirectObjectNumber(obj core.PdfObject) (int64, bool) {
+	switch t := obj.(type) {
+	case *core.PdfObjectReference:
+		return t.ObjectNumber, true
+	case *core.PdfIndirectObject:
+		return t.ObjectNumber, true
+	case *core.PdfObjectStream:
+		return t.ObjectNumber, true
+	}
+	return 0, false
+}
+
+// isNullObject returns true if `obj` is nil or the null object.
+func isNullObject(obj core.PdfObject) bool {
+	if obj == nil {
+		return true
+	}
+	_, ok := obj.(*core.PdfObjectNull)
+	return ok
+}
@@ -0,0 +1,871 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// errUnsupportedScript is returned when a field action script contains
+// anything other than calls of the supported AF functions.
+var errUnsupportedScript = errors.New("unsupported form script")
+
+// afCall represents a call of one of the Acrobat form functions (AF*), used
+// by the field actions for formatting, validating and calculating values.
+// The arguments are of type float64, string, bool or []string.
+type afCall struct {
+	name string
+	args []interface{}
+}
+
+// number returns the i-th argument of the call as a number.
+func (c afCall) number(i int, def float64) float64 {
+	if i >= len(c.args) {
+		return def
+	}
+	switch t := c.args[i].(type) {
+	case float64:
+		return t
+	case bool:
+		if t {
+			return 1
+		}
+		return 0
+	case string:
+		if v, err := strconv.ParseFloat(strings.TrimSpace(t), 64); err == nil {
+			return v
+		}
+	}
+	return def
+}
+
+// integer returns the i-th argument of the call as an integer.
+func (c afCall) integer(i int, def int) int {
+	return int(c.number(i, float64(def)))
+}
+
+// str returns the i-th argument of the call as a string.
+func (c afCall) str(i int) string {
+	if i >= len(c.args) {
+		return ""
+	}
+	switch t := c.args[i].(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return ""
+}
+
+// boolean returns the i-th argument of the call as a boolean.
+func (c afCall) boolean(i int) bool {
+	if i >= len(c.args) {
+		return false
+	}
+	switch t := c.args[i].(type) {
+	case bool:
+		return t
+	case float64:
+		return t != 0
+	case string:
+		return t == "true"
+	}
+	return false
+}
+
+// strings returns the i-th argument of the call as a list of strings. A
+// single string argument is split by commas.
+func (c afCall) strings(i int) []string {
+	if i >= len(c.args) {
+		return nil
+	}
+	switch t := c.args[i].(type) {
+	case []string:
+		return t
+	case string:
+		var list []string
+		for _, s := range strings.Split(t, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// afParser parses the scripts of the field actions. Only sequences of
+// function calls with literal arguments are supported, e.g.
+// `AFNumber_Format(2, 0, 0, 0, "$", true);`.
+type afParser struct {
+	src string
+	pos int
+}
+
+// parseAFCalls parses the function calls of the specified script.
+func parseAFCalls(js string) ([]afCall, error) {
+	p := &afParser{src: js}
+	var calls []afCall
+	for {
+		p.skipSpace()
+		for p.pos < len(p.src) && p.src[p.pos] == ';' {
+			p.pos++
+			p.skipSpace()
+		}
+		if p.pos >= len(p.src) {
+			return calls, nil
+		}
+
+		name := p.identifier()
+		if name == "" || !p.consume('(') {
+			return nil, errUnsupportedScript
+		}
+		args, err := p.arguments(')')
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, afCall{name: name, args: args})
+	}
+}
+
+// skipSpace skips the whitespace and the comments.
+func (p *afParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch {
+		case unicode.IsSpace(rune(p.src[p.pos])):
+			p.pos++
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			end := strings.IndexAny(p.src[p.pos:], "\r\n")
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += end
+		case strings.HasPrefix(p.src[p.pos:], "/*"):
+			end := strings.Index(p.src[p.pos+2:], "*/")
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+// consume skips the whitespace and consumes the specified character, if
+// it is the next character of the script.
+func (p *afParser) consume(ch byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == ch {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// identifier parses an identifier, which may contain dots (e.g. "event.value").
+func (p *afParser) identifier() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) {
+		ch := rune(p.src[p.pos])
+		if !(unicode.IsLetter(ch) || ch == '_' || ch == '$' || ch == '.' || (p.pos > start && unicode.IsDigit(ch))) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// arguments parses a comma separated list of arguments, ending with the
+// specified closing character.
+func (p *afParser) arguments(closing byte) ([]interface{}, error) {
+	var args []interface{}
+	if p.consume(closing) {
+		return args, nil
+	}
+	for {
+		arg, err := p.argument()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if p.consume(closing) {
+			return args, nil
+		}
+		if !p.consume(',') {
+			return nil, errUnsupportedScript
+		}
+	}
+}
+
+// argument parses a literal argument: a number, a string, a boolean or an
+// array of strings.
+func (p *afParser) argument() (interface{}, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, errUnsupportedScript
+	}
+
+	switch ch := p.src[p.pos]; {
+	case ch == '"' || ch == '\'':
+		return p.stringLiteral()
+	case ch == '[':
+		p.pos++
+		return p.stringArray(']')
+	case ch == '-' || ch == '+' || ch == '.' || (ch >= '0' && ch <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && strings.IndexByte("0123456789.eE", p.src[p.pos]) >= 0 {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, errUnsupportedScript
+		}
+		return v, nil
+	}
+
+	switch name := p.identifier(); name {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "new":
+		if p.identifier() != "Array" || !p.consume('(') {
+			return nil, errUnsupportedScript
+		}
+		return p.stringArray(')')
+	}
+	return nil, errUnsupportedScript
+}
+
+// stringArray parses the elements of an array, ending with the specified
+// closing character. The elements are converted to strings.
+func (p *afParser) stringArray(closing byte) ([]string, error) {
+	args, err := p.arguments(closing)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]string, len(args))
+	for i, arg := range args {
+		list[i] = afCall{args: args}.str(i)
+		if _, ok := arg.([]string); ok {
+			return nil, errUnsupportedScript
+		}
+	}
+	return list, nil
+}
+
+// stringLiteral parses a quoted string literal.
+func (p *afParser) stringLiteral() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		ch := p.src[p.pos]
+		p.pos++
+		switch ch {
+		case quote:
+			return sb.String(), nil
+		case '\\':
+			if p.pos >= len(p.src) {
+				return "", errUnsupportedScript
+			}
+			esc := p.src[p.pos]
+			p.pos++
+			switch esc {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 'u':
+				if p.pos+4 > len(p.src) {
+					return "", errUnsupportedScript
+				}
+				r, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return "", errUnsupportedScript
+				}
+				sb.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				sb.WriteByte(esc)
+			}
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", errUnsupportedScript
+}
+
+// afNumberSeparators returns the digit group separator and the decimal
+// separator of the specified separator style.
+func afNumberSeparators(sepStyle int) (string, string) {
+	switch sepStyle {
+	case 1:
+		return "", "."
+	case 2:
+		return ".", ","
+	case 3:
+		return "", ","
+	case 4:
+		return "'", "."
+	}
+	return ",", "."
+}
+
+// afMakeNumber converts the field value to a number. Decimal commas are
+// accepted. Returns false if the value is not a number.
+func afMakeNumber(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if v, err := strconv.ParseFloat(value, 64); err == nil {
+		return v, true
+	}
+	if !strings.Contains(value, ".") {
+		if v, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64); err == nil {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// afFormatNumber formats the absolute value of `v` using `nDec` decimals and
+// the separators of the specified separator style.
+func afFormatNumber(v float64, nDec, sepStyle int) string {
+	if nDec < 0 {
+		nDec = 0
+	}
+	group, decimal := afNumberSeparators(sepStyle)
+
+	str := strconv.FormatFloat(math.Abs(v), 'f', nDec, 64)
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+
+	var sb strings.Builder
+	for i, d := range intPart {
+		if i > 0 && group != "" && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(group)
+		}
+		sb.WriteRune(d)
+	}
+	if fracPart != "" {
+		sb.WriteString(decimal)
+		sb.WriteString(fracPart)
+	}
+	return sb.String()
+}
+
+// afNumberFormat implements AFNumber_Format(nDec, sepStyle, negStyle,
+// currStyle, strCurrency, bCurrencyPrepend). Returns the formatted value
+// and whether it is displayed in red.
+func afNumberFormat(c afCall, value string) (string, bool) {
+	v, ok := afMakeNumber(value)
+	if !ok {
+		return "", false
+	}
+	nDec, sepStyle, negStyle := c.integer(0, 2), c.integer(1, 0), c.integer(2, 0)
+	currency, prepend := c.str(4), c.boolean(5)
+
+	str := afFormatNumber(v, nDec, sepStyle)
+	if prepend {
+		str = currency + str
+	} else {
+		str += currency
+	}
+
+	// Values rounding to zero are not negative.
+	negative := v < 0 && strings.ContainsAny(str, "123456789")
+	if !negative {
+		return str, false
+	}
+	switch negStyle {
+	case 1:
+		return str, true
+	case 2:
+		return "(" + str + ")", false
+	case 3:
+		return "(" + str + ")", true
+	}
+	return "-" + str, false
+}
+
+// afPercentFormat implements AFPercent_Format(nDec, sepStyle, bPercentPrepend).
+func afPercentFormat(c afCall, value string) string {
+	v, ok := afMakeNumber(value)
+	if !ok {
+		return ""
+	}
+	str := afFormatNumber(v*100, c.integer(0, 2), c.integer(1, 0))
+	if c.boolean(2) {
+		str = "%" + str
+	} else {
+		str += "%"
+	}
+	if v < 0 && strings.ContainsAny(str, "123456789") {
+		str = "-" + str
+	}
+	return str
+}
+
+// afNumberKeystroke validates the committed value of a number field,
+// implementing AFNumber_Keystroke and AFPercent_Keystroke. The value may
+// contain the currency symbol and the decimal separator of the format.
+func afNumberKeystroke(c afCall, value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return true
+	}
+	if c.name == "AFNumber_Keystroke" {
+		if currency := c.str(4); currency != "" {
+			value = strings.TrimSpace(strings.Replace(value, currency, "", 1))
+		}
+	} else {
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(value, "%"), "%"))
+	}
+
+	_, decimal := afNumberSeparators(c.integer(1, 0))
+	value = strings.Replace(value, decimal, ".", 1)
+	return afNumberPattern.MatchString(value)
+}
+
+var afNumberPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)$`)
+
+// afDateFormats are the formats of AFDate_Format, by index.
+var afDateFormats = []string{
+	"m/d", "m/d/yy", "mm/dd/yy", "mm/yy", "d-mmm", "d-mmm-yy", "dd-mmm-yy",
+	"yy-mm-dd", "mmm-yy", "mmmm-yy", "mmm d, yyyy", "mmmm d, yyyy",
+	"m/d/yy h:MM tt", "m/d/yy HH:MM",
+}
+
+// afTimeFormats are the formats of AFTime_Format, by index.
+var afTimeFormats = []string{"HH:MM", "h:MM tt", "HH:MM:ss", "h:MM:ss tt"}
+
+// afDateFormat returns the date format of the AFDate_* and AFTime_* calls.
+func afDateFormat(c afCall) string {
+	switch c.name {
+	case "AFDate_Format", "AFDate_Keystroke":
+		if i := c.integer(0, 0); i >= 0 && i < len(afDateFormats) {
+			return afDateFormats[i]
+		}
+		return afDateFormats[0]
+	case "AFTime_Format", "AFTime_Keystroke":
+		if i := c.integer(0, 0); i >= 0 && i < len(afTimeFormats) {
+			return afTimeFormats[i]
+		}
+		return afTimeFormats[0]
+	}
+	return c.str(0)
+}
+
+// afDateToken represents a component of a date format (e.g. "mmm"), or a
+// literal string if `field` is 0.
+type afDateToken struct {
+	field byte
+	width int
+	text  string
+}
+
+// tokenizeAFDateFormat splits the date format into its components.
+func tokenizeAFDateFormat(format string) []afDateToken {
+	var tokens []afDateToken
+	for i := 0; i < len(format); {
+		ch := format[i]
+		if strings.IndexByte("dmyHhMst", ch) < 0 {
+			if ch == '\\' && i+1 < len(format) {
+				i++
+				ch = format[i]
+			}
+			tokens = append(tokens, afDateToken{text: string(ch)})
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(format) && format[j] == ch {
+			j++
+		}
+		tokens = append(tokens, afDateToken{field: ch, width: j - i})
+		i = j
+	}
+	return tokens
+}
+
+// formatAFDate formats the time using the specified Acrobat date format.
+func formatAFDate(t time.Time, format string) string {
+	var sb strings.Builder
+	for _, tok := range tokenizeAFDateFormat(format) {
+		switch tok.field {
+		case 0:
+			sb.WriteString(tok.text)
+		case 'd':
+			switch {
+			case tok.width >= 4:
+				sb.WriteString(t.Weekday().String())
+			case tok.width == 3:
+				sb.WriteString(t.Weekday().String()[:3])
+			case tok.width == 2:
+				fmt.Fprintf(&sb, "%02d", t.Day())
+			default:
+				sb.WriteString(strconv.Itoa(t.Day()))
+			}
+		case 'm':
+			switch {
+			case tok.width >= 4:
+				sb.WriteString(t.Month().String())
+			case tok.width == 3:
+				sb.WriteString(t.Month().String()[:3])
+			case tok.width == 2:
+				fmt.Fprintf(&sb, "%02d", int(t.Month()))
+			default:
+				sb.WriteString(strconv.Itoa(int(t.Month())))
+			}
+		case 'y':
+			if tok.width >= 4 {
+				fmt.Fprintf(&sb, "%04d", t.Year())
+			} else {
+				fmt.Fprintf(&sb, "%02d", t.Year()%100)
+			}
+		case 'H':
+			writeAFDateNumber(&sb, t.Hour(), tok.width)
+		case 'h':
+			hour := t.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			writeAFDateNumber(&sb, hour, tok.width)
+		case 'M':
+			writeAFDateNumber(&sb, t.Minute(), tok.width)
+		case 's':
+			writeAFDateNumber(&sb, t.Second(), tok.width)
+		case 't':
+			ampm := "am"
+			if t.Hour() >= 12 {
+				ampm = "pm"
+			}
+			if tok.width == 1 {
+				ampm = ampm[:1]
+			}
+			sb.WriteString(ampm)
+		}
+	}
+	return sb.String()
+}
+
+// writeAFDateNumber writes the number, padded with zeros if `width` is 2.
+func writeAFDateNumber(sb *strings.Builder, v, width int) {
+	if width >= 2 {
+		fmt.Fprintf(sb, "%02d", v)
+		return
+	}
+	sb.WriteString(strconv.Itoa(v))
+}
+
+// parseAFDate parses the date, which is expected to match the specified date
+// format. Like Acrobat, the parsing is lenient: the numbers and the month
+// names of the value are assigned to the fields of the format, in order.
+// Missing years default to the current year and missing days to 1.
+func parseAFDate(value, format string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	// Split the value into numbers, words and AM/PM markers.
+	var (
+		numbers []int
+		widths  []int
+		month   int
+		pm, am  bool
+	)
+	for _, part := range afDatePartPattern.FindAllString(value, -1) {
+		if part[0] >= '0' && part[0] <= '9' {
+			n, _ := strconv.Atoi(part)
+			numbers = append(numbers, n)
+			widths = append(widths, len(part))
+			continue
+		}
+
+		lower := strings.ToLower(part)
+		switch {
+		case lower == "am" || lower == "a":
+			am = true
+		case lower == "pm" || lower == "p":
+			pm = true
+		case len(lower) >= 3:
+			for m := time.January; m <= time.December; m++ {
+				if strings.HasPrefix(strings.ToLower(m.String()), lower) {
+					month = int(m)
+					break
+				}
+			}
+		}
+	}
+
+	// Assign the numbers to the fields of the format, in order.
+	var fields []byte
+	for _, tok := range tokenizeAFDateFormat(format) {
+		switch tok.field {
+		case 'm':
+			if tok.width <= 2 || month == 0 {
+				fields = append(fields, 'm')
+			}
+		case 'd':
+			if tok.width <= 2 {
+				fields = append(fields, 'd')
+			}
+		case 'y', 'H', 'h', 'M', 's':
+			fields = append(fields, tok.field)
+		}
+	}
+
+	now := time.Now()
+	year, day := now.Year(), 1
+	var hour, minute, second int
+	hasDate := month != 0
+	for i, n := range numbers {
+		if i >= len(fields) {
+			return time.Time{}, false
+		}
+		switch fields[i] {
+		case 'm':
+			month = n
+			hasDate = true
+		case 'd':
+			day = n
+			hasDate = true
+		case 'y':
+			year = n
+			if widths[i] <= 2 {
+				// Two digit years are in the range [1950, 2049].
+				year += 2000
+				if n >= 50 {
+					year -= 100
+				}
+			}
+			hasDate = true
+		case 'H', 'h':
+			hour = n
+		case 'M':
+			minute = n
+		case 's':
+			second = n
+		}
+	}
+	if len(numbers) == 0 && month == 0 {
+		return time.Time{}, false
+	}
+	if !hasDate {
+		month, day = int(now.Month()), now.Day()
+	}
+	if pm && hour < 12 {
+		hour += 12
+	} else if am && hour == 12 {
+		hour = 0
+	}
+
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, time.Local)
+	if t.Day() != day {
+		// Invalid day of the month (e.g. February 30).
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+var afDatePartPattern = regexp.MustCompile(`\d+|[A-Za-z]+`)
+
+// afSpecialMasks are the masks of the AFSpecial_Format formats, by index:
+// zip code, zip + 4, phone number and social security number.
+var afSpecialMasks = []string{"99999", "99999-9999", "(999) 999-9999", "999-99-9999"}
+
+// afSpecialMask returns the mask of the AFSpecial_* call for the value.
+func afSpecialMask(c afCall, value string) string {
+	if c.name == "AFSpecial_Format" || c.name == "AFSpecial_Keystroke" {
+		psf := c.integer(0, 0)
+		if psf < 0 || psf >= len(afSpecialMasks) {
+			return ""
+		}
+		// Phone numbers without the area code.
+		if psf == 2 && len(afDigits(value)) == 7 {
+			return "999-9999"
+		}
+		return afSpecialMasks[psf]
+	}
+	return c.str(0)
+}
+
+// afDigits returns the letters and digits of the value.
+func afDigits(value string) string {
+	var sb strings.Builder
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// applyAFMask applies the mask to the letters and digits of the value. In
+// the mask, "9" stands for a digit, "A" for a letter, "O" for a letter or
+// a digit and "X" for any character. Other characters are copied as is.
+// Returns false if the value does not match the mask.
+func applyAFMask(mask, value string) (string, bool) {
+	chars := []rune(afDigits(value))
+	var sb strings.Builder
+	i := 0
+	for _, m := range mask {
+		switch m {
+		case '9', 'A', 'O', 'X':
+			if i >= len(chars) {
+				return "", false
+			}
+			ch := chars[i]
+			i++
+			if (m == '9' && !unicode.IsDigit(ch)) || (m == 'A' && !unicode.IsLetter(ch)) {
+				return "", false
+			}
+			sb.WriteRune(ch)
+		default:
+			sb.WriteRune(m)
+		}
+	}
+	if i != len(chars) {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+// afRangeValidate implements AFRange_Validate(bGreaterThan, nGreaterThan,
+// bLessThan, nLessThan).
+func afRangeValidate(c afCall, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		return true
+	}
+	v, ok := afMakeNumber(value)
+	if !ok {
+		return false
+	}
+	if c.boolean(0) && v < c.number(1, 0) {
+		return false
+	}
+	if c.boolean(2) && v > c.number(3, 0) {
+		return false
+	}
+	return true
+}
+
+// afFormat formats the value using the format action call. Returns the
+// formatted value, whether the value is displayed in red and false if the
+// function is not supported.
+func afFormat(c afCall, value string) (string, bool, bool) {
+	switch c.name {
+	case "AFNumber_Format":
+		str, red := afNumberFormat(c, value)
+		return str, red, true
+	case "AFPercent_Format":
+		return afPercentFormat(c, value), false, true
+	case "AFDate_Format", "AFDate_FormatEx", "AFTime_Format", "AFTime_FormatEx":
+		format := afDateFormat(c)
+		t, ok := parseAFDate(value, format)
+		if !ok {
+			return "", false, true
+		}
+		return formatAFDate(t, format), false, true
+	case "AFSpecial_Format", "AFSpecial_FormatEx":
+		str, ok := applyAFMask(afSpecialMask(c, value), value)
+		if !ok {
+			return value, false, true
+		}
+		return str, false, true
+	}
+	return "", false, false
+}
+
+// afValidate checks the committed value using the keystroke or validation
+// action call. Returns false if the value is rejected.
+func afValidate(c afCall, value string) bool {
+	switch c.name {
+	case "AFNumber_Keystroke", "AFPercent_Keystroke":
+		return afNumberKeystroke(c, value)
+	case "AFDate_Keystroke", "AFDate_KeystrokeEx", "AFTime_Keystroke", "AFTime_KeystrokeEx":
+		if strings.TrimSpace(value) == "" {
+			return true
+		}
+		_, ok := parseAFDate(value, afDateFormat(c))
+		return ok
+	case "AFSpecial_Keystroke", "AFSpecial_KeystrokeEx":
+		if strings.TrimSpace(value) == "" {
+			return true
+		}
+		_, ok := applyAFMask(afSpecialMask(c, value), value)
+		return ok
+	case "AFRange_Validate":
+		return afRangeValidate(c, value)
+	}
+	return true
+}
+
+// afSimpleCalculate implements AFSimple_Calculate(cFunction, cFields). The
+// `values` function returns the numeric values of the named field.
+func afSimpleCalculate(c afCall, values func(name string) []float64) (float64, error) {
+	function := strings.ToUpper(c.str(0))
+
+	var (
+		result float64
+		count  int
+	)
+	for _, name := range c.strings(1) {
+		for _, v := range values(name) {
+			switch {
+			case count == 0:
+				result = v
+			case function == "SUM" || function == "AVG":
+				result += v
+			case function == "PRD":
+				result *= v
+			case function == "MIN":
+				result = math.Min(result, v)
+			case function == "MAX":
+				result = math.Max(result, v)
+			default:
+				return 0, fmt.Errorf("unsupported calculation function: %s", function)
+			}
+			count++
+		}
+	}
+	if function == "AVG" && count > 0 {
+		result /= float64(count)
+	}
+	return result, nil
+}
@@ -0,0 +1,285 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// fieldActionCalls returns the AF function calls of the JavaScript action
+// triggered by the specified field event: K (keystroke), F (format),
+// V (validate) or C (calculate). Returns nil if the field does not have
+// such an action, or if the action script is not supported.
+func fieldActionCalls(field *PdfField, event core.PdfObjectName) []afCall {
+	aa, ok := core.GetDict(field.AA)
+	if !ok {
+		return nil
+	}
+	action, ok := core.GetDict(aa.Get(event))
+	if !ok {
+		return nil
+	}
+	if s, ok := core.GetName(action.Get("S")); !ok || *s != "JavaScript" {
+		return nil
+	}
+
+	var js string
+	switch t := core.TraceToDirectObject(action.Get("JS")).(type) {
+	case *core.PdfObjectString:
+		js = t.Decoded()
+	case *core.PdfObjectStream:
+		data, err := core.DecodeStream(t)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to decode field action script: %v", err)
+			return nil
+		}
+		js = string(data)
+	}
+
+	calls, err := parseAFCalls(js)
+	if err != nil {
+		common.Log.Debug("WARN: field %s: %s action script not supported: %q", field.PartialName(), event, js)
+		return nil
+	}
+	return calls
+}
+
+// fieldValueString returns the value of the field as a string.
+func fieldValueString(field *PdfField) string {
+	switch t := core.TraceToDirectObject(field.V).(type) {
+	case *core.PdfObjectString:
+		return t.Decoded()
+	case *core.PdfObjectName:
+		if *t == "Off" {
+			return ""
+		}
+		return t.String()
+	case *core.PdfObjectInteger:
+		return strconv.FormatInt(int64(*t), 10)
+	case *core.PdfObjectFloat:
+		return strconv.FormatFloat(float64(*t), 'f', -1, 64)
+	}
+	return ""
+}
+
+// ValidateFieldValue checks the value of the field using the AF functions of
+// its keystroke (K) and validate (V) actions, as a viewer would when the
+// value is committed. Returns true if the field has no such actions.
+func (form *PdfAcroForm) ValidateFieldValue(field *PdfField) bool {
+	value := fieldValueString(field)
+	for _, event := range []core.PdfObjectName{"K", "V"} {
+		for _, call := range fieldActionCalls(field, event) {
+			if !afValidate(call, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FormattedValue returns the value of the field, formatted using the AF
+// functions of its format (F) action, as displayed by a viewer. The second
+// return value specifies whether the value is displayed in red (negative
+// numbers of some formats). Returns false if the field does not have a
+// supported format action.
+func (form *PdfAcroForm) FormattedValue(field *PdfField) (string, bool, bool) {
+	calls := fieldActionCalls(field, "F")
+	value := fieldValueString(field)
+	var red, formatted bool
+	for _, call := range calls {
+		str, isRed, ok := afFormat(call, value)
+		if !ok {
+			continue
+		}
+		value, red, formatted = str, isRed, true
+	}
+	return value, red, formatted
+}
+
+// calculationOrder returns the fields having a calculate (C) action, in the
+// order in which they are calculated. The fields listed in the calculation
+// order (CO) of the form are calculated first.
+func (form *PdfAcroForm) calculationOrder() []*PdfField {
+	var fields []*PdfField
+	added := map[*PdfField]bool{}
+	all := form.AllFields()
+
+	if form.CO != nil {
+		for _, obj := range form.CO.Elements() {
+			for _, field := range all {
+				if added[field] || field.GetContainingPdfObject() != obj {
+					continue
+				}
+				added[field] = true
+				fields = append(fields, field)
+				break
+			}
+		}
+	}
+	for _, field := range all {
+		if added[field] {
+			continue
+		}
+		if aa, ok := core.GetDict(field.AA); ok && aa.Get("C") != nil {
+			added[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// fieldNumbers returns the numeric values of the terminal fields matching the
+// specified name. The name matches the full name of a field, or of one of
+// its ancestors. Empty values count as 0.
+func (form *PdfAcroForm) fieldNumbers(name string) []float64 {
+	var values []float64
+	for _, field := range form.AllFields() {
+		if len(field.Kids) > 0 {
+			continue
+		}
+		fullName, err := field.FullName()
+		if err != nil {
+			continue
+		}
+		if fullName != name && !strings.HasPrefix(fullName, name+".") && field.PartialName() != name {
+			continue
+		}
+
+		v, _ := afMakeNumber(fieldValueString(field))
+		values = append(values, v)
+	}
+	return values
+}
+
+// Calculate recomputes the values of the calculated fields of the form,
+// using the AF functions of their calculate (C) actions. The calculations
+// are performed in the calculation order (CO) of the form. Returns the
+// fields whose value changed.
+func (form *PdfAcroForm) Calculate() ([]*PdfField, error) {
+	var changed []*PdfField
+	for _, field := range form.calculationOrder() {
+		for _, call := range fieldActionCalls(field, "C") {
+			if call.name != "AFSimple_Calculate" {
+				common.Log.Debug("WARN: field %s: unsupported calculation function: %s", field.PartialName(), call.name)
+				continue
+			}
+
+			result, err := afSimpleCalculate(call, form.fieldNumbers)
+			if err != nil {
+				return nil, err
+			}
+			value := strconv.FormatFloat(result, 'f', -1, 64)
+			if fieldValueString(field) != value {
+				field.V = core.MakeString(value)
+				changed = append(changed, field)
+			}
+		}
+	}
+	return changed, nil
+}
+
+// fillWithScripts fills the form with the values provided by `provider`,
+// taking into account the AF functions of the field actions: the values
+// rejected by the keystroke and validate actions are not applied, the
+// calculated fields are updated and the appearances of the fields are
+// generated using their formatted values.
+func (form *PdfAcroForm) fillWithScripts(provider FieldValueProvider, appGen FieldAppearanceGenerator) error {
+	if form == nil {
+		return nil
+	}
+
+	// Record the current values, in order to restore the rejected values.
+	prev := map[*PdfField]core.PdfObject{}
+	for _, field := range form.AllFields() {
+		prev[field] = field.V
+	}
+	if err := form.fill(provider, nil); err != nil {
+		return err
+	}
+
+	var updated []*PdfField
+	for _, field := range form.AllFields() {
+		if field.V == prev[field] {
+			continue
+		}
+		if !form.ValidateFieldValue(field) {
+			common.Log.Debug("WARN: value of field %s rejected by its actions: %v", field.PartialName(), field.V)
+			field.V = prev[field]
+			if _, ok := field.GetContext().(*PdfFieldButton); ok && field.V != nil {
+				_gbgga(field, field.V)
+			}
+			continue
+		}
+		updated = append(updated, field)
+	}
+
+	calculated, err := form.Calculate()
+	if err != nil {
+		return err
+	}
+	if appGen == nil {
+		return nil
+	}
+
+	generated := map[*PdfField]bool{}
+	for _, field := range append(updated, calculated...) {
+		if generated[field] {
+			continue
+		}
+		generated[field] = true
+		if err := form.generateFieldAppearance(field, appGen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateFieldAppearance generates the appearances of the widgets of the
+// field, displaying the formatted value of the field.
+func (form *PdfAcroForm) generateFieldAppearance(field *PdfField, appGen FieldAppearanceGenerator) error {
+	value, red, formatted := form.FormattedValue(field)
+	if formatted {
+		// The appearance generators display the field value, which is
+		// temporarily replaced with the formatted value.
+		origV := field.V
+		field.V = core.MakeString(value)
+		defer func() {
+			field.V = origV
+		}()
+
+		if text, ok := field.GetContext().(*PdfFieldText); ok && red {
+			origDA := text.DA
+			da := "/Helv 0 Tf"
+			if text.DA != nil {
+				da = text.DA.Decoded()
+			} else if form.DA != nil {
+				da = form.DA.Decoded()
+			}
+			text.DA = core.MakeString(da + " 1 0 0 rg")
+			defer func() {
+				text.DA = origDA
+			}()
+		}
+	}
+
+	for _, widget := range field.Annotations {
+		ap, err := appGen.GenerateAppearanceDict(form, field, widget)
+		if err != nil {
+			return err
+		}
+		widget.AP = ap
+		widget.ToPdfObject()
+	}
+	return nil
+}
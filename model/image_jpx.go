@@ -0,0 +1,120 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// setJPXDefaults sets the properties of JPX encoded images which are specified by the JPEG 2000
+// data: the BitsPerComponent entry is ignored for such images and the ColorSpace entry is
+// optional (see section 8.9.5 "Image Dictionaries" of PDF32000_2008).
+func (ximg *XObjectImage) setJPXDefaults(dict core.PdfObjectDictionary) {
+	if _, ok := ximg.Filter.(*core.JPXEncoder); !ok {
+		return
+	}
+	info, err := core.DecodeJPXInfo(ximg._dcafe.Stream)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to read JPX image header: %v", err)
+		return
+	}
+	bpc := int64(info.BitsPerComponent)
+	ximg.BitsPerComponent = &bpc
+	if dict.Get("ColorSpace") != nil {
+		return
+	}
+	var device PdfColorspace
+	switch {
+	case info.ColorSpace == "DeviceCMYK" || info.ColorSpace == "" && info.ColorComponents == 4:
+		device = NewPdfColorspaceDeviceCMYK()
+	case info.ColorSpace == "DeviceRGB" || info.ColorSpace == "" && info.ColorComponents == 3:
+		device = NewPdfColorspaceDeviceRGB()
+	default:
+		device = NewPdfColorspaceDeviceGray()
+	}
+	ximg.ColorSpace = device
+	if info.ICCProfile != nil {
+		cs, err := NewPdfColorspaceICCBased(info.ColorComponents)
+		if err != nil {
+			common.Log.Debug("ERROR: unsupported JPX ICC profile: %v", err)
+			return
+		}
+		cs.Alternate = device
+		cs.Data = info.ICCProfile
+		ximg.ColorSpace = cs
+	}
+}
+
+// decodeData decodes the data of the image stream into `img`. The alpha channel of JPX encoded
+// images with SMaskInData is decoded as well, unless the image has an SMask entry which takes
+// precedence.
+func (ximg *XObjectImage) decodeData(img *Image) error {
+	if _, ok := ximg.Filter.(*core.JPXEncoder); !ok {
+		data, err := core.DecodeStream(ximg._dcafe)
+		if err != nil {
+			return err
+		}
+		img.Data = data
+		return nil
+	}
+	jpx, err := core.DecodeJPXImage(ximg._dcafe.Stream)
+	if err != nil {
+		return err
+	}
+	img.Data = jpx.Data
+	smaskInData, _ := core.GetNumberAsInt64(ximg.SMaskInData)
+	if !jpx.HasAlpha || smaskInData <= 0 || ximg.SMask != nil {
+		return nil
+	}
+	img._afge = jpx.Alpha
+	if jpx.Premultiplied || smaskInData == 2 {
+		unpremultiply(img.Data, jpx.Alpha, jpx.ColorComponents, jpx.BitsPerComponent)
+	}
+	return nil
+}
+
+// unpremultiply divides the color samples of `data` by the samples of the alpha channel
+// `alpha`. Only 8 and 16 bits per component are supported, other images are left unchanged.
+func unpremultiply(data, alpha []byte, components, bpc int) {
+	sample := func(b []byte, i int) uint32 {
+		if bpc == 16 {
+			return uint32(b[2*i])<<8 | uint32(b[2*i+1])
+		}
+		return uint32(b[i])
+	}
+	var max uint32
+	switch bpc {
+	case 8:
+		max = 0xFF
+	case 16:
+		max = 0xFFFF
+	default:
+		return
+	}
+	size := bpc / 8
+	for p := 0; (p+1)*size <= len(alpha) && (p+1)*components*size <= len(data); p++ {
+		a := sample(alpha, p)
+		if a == 0 || a == max {
+			continue
+		}
+		for c := p * components; c < (p+1)*components; c++ {
+			v := (sample(data, c)*max + a/2) / a
+			if v > max {
+				v = max
+			}
+			if bpc == 16 {
+				data[2*c], data[2*c+1] = byte(v>>8), byte(v)
+			} else {
+				data[c] = byte(v)
+			}
+		}
+	}
+}
@@ -9,4 +9,4 @@
 // Use of this source code is governed by the UniDoc End User License Agreement
 // terms that can be accessed at https://unidoc.io/eula/
 
-package fonts ;import (_e "bytes";_g "encoding/binary";_fg "errors";_d "fmt";_gf "github.com/unidoc/unipdf/v3/common";_fb "github.com/unidoc/unipdf/v3/core";_gg "github.com/unidoc/unipdf/v3/internal/cmap";_ge "github.com/unidoc/unipdf/v3/internal/textencoding";_de "golang.org/x/xerrors";_f "io";_ac "os";_b "regexp";_dg "sort";_df "strings";_ga "sync";);type Descriptor struct{Name StdFontName ;Family string ;Weight FontWeight ;Flags uint ;BBox [4]float64 ;ItalicAngle float64 ;Ascent float64 ;Descent float64 ;CapHeight float64 ;XHeight float64 ;StemV float64 ;StemH float64 ;};func (_bdg *ttfParser )parseCmapSubtable31 (_bcdb int64 )error {_afed :=make ([]rune ,0,8);_cab :=make ([]rune ,0,8);_bcb :=make ([]int16 ,0,8);_ece :=make ([]uint16 ,0,8);_bdg ._age .Chars =make (map[rune ]GID );_bdg ._gbb .Seek (int64 (_bdg ._cba ["\u0063\u006d\u0061\u0070"])+_bcdb ,_f .SeekStart );_cbg :=_bdg .ReadUShort ();if _cbg !=4{return _de .Errorf ("u\u006e\u0065\u0078\u0070\u0065\u0063t\u0065\u0064\u0020\u0073\u0075\u0062t\u0061\u0062\u006c\u0065\u0020\u0066\u006fr\u006d\u0061\u0074\u003a\u0020\u0025\u0064\u0020\u0028\u0025w\u0029",_cbg ,_fb .ErrNotSupported );};_bdg .Skip (2*2);_efe :=int (_bdg .ReadUShort ()/2);_bdg .Skip (3*2);for _bdge :=0;_bdge < _efe ;_bdge ++{_cab =append (_cab ,rune (_bdg .ReadUShort ()));};_bdg .Skip (2);for _ffd :=0;_ffd < _efe ;_ffd ++{_afed =append (_afed ,rune (_bdg .ReadUShort ()));};for _fad :=0;_fad < _efe ;_fad ++{_bcb =append (_bcb ,_bdg .ReadShort ());};_acdd ,_ :=_bdg ._gbb .Seek (int64 (0),_f .SeekCurrent );for _ecef :=0;_ecef < _efe ;_ecef ++{_ece =append (_ece ,_bdg .ReadUShort ());};for _bdd :=0;_bdd < _efe ;_bdd ++{_ecd :=_afed [_bdd ];_aea :=_cab [_bdd ];_fagg :=_bcb [_bdd ];_afc :=_ece [_bdd ];if _afc > 0{_bdg ._gbb .Seek (_acdd +2*int64 (_bdd )+int64 (_afc ),_f .SeekStart );};for _gcaa :=_ecd ;_gcaa <=_aea ;_gcaa ++{if _gcaa ==0xFFFF{break ;};var _agb int32 ;if _afc > 0{_agb =int32 (_bdg .ReadUShort ());if _agb > 0{_agb +=int32 (_fagg );};}else {_agb =_gcaa +int32 (_fagg );};if _agb >=65536{_agb -=65536;};if _agb > 0{_bdg ._age .Chars [_gcaa ]=GID (_agb );};};};return nil ;};func (_bd StdFont )GetMetricsTable ()map[rune ]CharMetrics {return _bd ._fa };func (_fac *ttfParser )Read32Fixed ()float64 {_dba :=float64 (_fac .ReadShort ());_gcc :=float64 (_fac .ReadUShort ())/65536.0;return _dba +_gcc ;};var _gdf =[]int16 {722,889,722,722,722,722,722,722,722,722,722,667,667,667,667,667,722,722,722,612,611,611,611,611,611,611,611,611,611,722,500,556,722,722,722,722,333,333,333,333,333,333,333,333,389,722,722,611,611,611,611,611,889,722,722,722,722,722,722,889,722,722,722,722,722,722,722,722,556,722,667,667,667,667,556,556,556,556,556,611,611,611,556,722,722,722,722,722,722,722,722,722,722,944,722,722,722,722,611,611,611,611,444,444,444,444,333,444,667,444,444,778,444,444,469,541,500,921,444,500,278,200,480,480,333,333,333,200,350,444,444,333,444,444,333,500,333,278,250,250,760,500,500,500,500,588,500,400,333,564,500,333,278,444,444,444,444,444,444,444,500,1000,444,1000,500,444,564,500,333,333,333,556,500,556,500,500,167,500,500,500,500,333,564,549,500,500,333,333,500,333,333,278,278,278,278,278,278,278,278,500,500,278,278,344,278,564,549,564,471,278,778,333,564,500,564,500,500,500,500,500,549,500,500,500,500,500,500,722,333,500,500,500,500,750,750,300,276,310,500,500,500,453,333,333,476,833,250,250,1000,564,564,500,444,444,408,444,444,444,333,333,333,180,333,333,453,333,333,760,333,389,389,389,389,389,500,278,500,500,278,250,500,600,278,326,278,500,500,750,300,333,980,500,300,500,500,500,500,500,500,500,500,500,500,500,722,500,500,500,500,500,444,444,444,444,500};var _ebfa map[rune ]CharMetrics ;func _ed ()StdFont {_fbf .Do (_deb );_fea :=Descriptor {Name :CourierName ,Family :string (CourierName ),Weight :FontWeightMedium ,Flags :0x0021,BBox :[4]float64 {-23,-250,715,805},ItalicAngle :0,Ascent :629,Descent :-157,CapHeight :562,XHeight :426,StemV :51,StemH :51};return NewStdFont (_fea ,_fag );};func (_edeaa *ttfParser )parseCmapSubtable10 (_gdbg int64 )error {if _edeaa ._age .Chars ==nil {_edeaa ._age .Chars =make (map[rune ]GID );};_edeaa ._gbb .Seek (int64 (_edeaa ._cba ["\u0063\u006d\u0061\u0070"])+_gdbg ,_f .SeekStart );var _bad ,_gge uint32 ;_fgge :=_edeaa .ReadUShort ();if _fgge < 8{_bad =uint32 (_edeaa .ReadUShort ());_gge =uint32 (_edeaa .ReadUShort ());}else {_edeaa .ReadUShort ();_bad =_edeaa .ReadULong ();_gge =_edeaa .ReadULong ();};_gf .Log .Trace ("\u0070\u0061r\u0073\u0065\u0043\u006d\u0061p\u0053\u0075\u0062\u0074\u0061b\u006c\u0065\u0031\u0030\u003a\u0020\u0066\u006f\u0072\u006d\u0061\u0074\u003d\u0025\u0064\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u003d\u0025\u0064\u0020\u006c\u0061\u006e\u0067\u0075\u0061\u0067\u0065\u003d\u0025\u0064",_fgge ,_bad ,_gge );if _fgge !=0{return _fg .New ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0063\u006d\u0061p\u0020s\u0075\u0062\u0074\u0061\u0062\u006c\u0065\u0020\u0066\u006f\u0072\u006d\u0061\u0074");};_efgd ,_dfeb :=_edeaa .ReadStr (256);if _dfeb !=nil {return _dfeb ;};_dbg :=[]byte (_efgd );for _egb ,_afg :=range _dbg {_edeaa ._age .Chars [rune (_egb )]=GID (_afg );if _afg !=0{_d .Printf ("\u0009\u0030\u0078\u002502\u0078\u0020\u279e\u0020\u0030\u0078\u0025\u0030\u0032\u0078\u003d\u0025\u0063\u000a",_egb ,_afg ,rune (_afg ));};};return nil ;};func (_eb StdFont )GetRuneMetrics (r rune )(CharMetrics ,bool ){_ecc ,_da :=_eb ._fa [r ];return _ecc ,_da ;};func _cf ()StdFont {_fbf .Do (_deb );_gfa :=Descriptor {Name :CourierObliqueName ,Family :string (CourierName ),Weight :FontWeightMedium ,Flags :0x0061,BBox :[4]float64 {-27,-250,849,805},ItalicAngle :-12,Ascent :629,Descent :-157,CapHeight :562,XHeight :426,StemV :51,StemH :51};return NewStdFont (_gfa ,_cd );};func (_deab *ttfParser )ReadShort ()(_ebde int16 ){_g .Read (_deab ._gbb ,_g .BigEndian ,&_ebde );return _ebde ;};func _gb ()StdFont {_gce :=_ge .NewZapfDingbatsEncoder ();_cbf :=Descriptor {Name :ZapfDingbatsName ,Family :string (ZapfDingbatsName ),Weight :FontWeightMedium ,Flags :0x0004,BBox :[4]float64 {-1,-143,981,820},ItalicAngle :0,Ascent :0,Descent :0,CapHeight :0,XHeight :0,StemV :90,StemH :28};return NewStdFontWithEncoding (_cbf ,_dfb ,_gce );};const (SymbolName =StdFontName ("\u0053\u0079\u006d\u0062\u006f\u006c");ZapfDingbatsName =StdFontName ("\u005a\u0061\u0070f\u0044\u0069\u006e\u0067\u0062\u0061\u0074\u0073"););var _fag map[rune ]CharMetrics ;type StdFont struct{_acd Descriptor ;_fa map[rune ]CharMetrics ;_gfe _ge .TextEncoder ;};func NewFontFile2FromPdfObject (obj _fb .PdfObject )(TtfType ,error ){obj =_fb .TraceToDirectObject (obj );_ecf ,_bb :=obj .(*_fb .PdfObjectStream );if !_bb {_gf .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0046\u006f\u006e\u0074\u0046\u0069\u006c\u0065\u0032\u0020\u006d\u0075\u0073\u0074\u0020\u0062\u0065 \u0061\u0020\u0073\u0074\u0072e\u0061\u006d \u0028\u0025\u0054\u0029",obj );return TtfType {},_fb .ErrTypeError ;};_effe ,_fga :=_fb .DecodeStream (_ecf );if _fga !=nil {return TtfType {},_fga ;};_eda :=ttfParser {_gbb :_e .NewReader (_effe )};return _eda .Parse ();};func TtfParse (r _f .ReadSeeker )(TtfType ,error ){_eag :=&ttfParser {_gbb :r };return _eag .Parse ()};var _gca _ga .Once ;func _bac ()StdFont {_fbf .Do (_deb );_ag :=Descriptor {Name :CourierBoldName ,Family :string (CourierName ),Weight :FontWeightBold ,Flags :0x0021,BBox :[4]float64 {-113,-250,749,801},ItalicAngle :0,Ascent :629,Descent :-157,CapHeight :562,XHeight :439,StemV :106,StemH :84};return NewStdFont (_ag ,_bc );};var _bdf _ga .Once ;var _bcd =[]int16 {667,1000,667,667,667,667,667,667,667,667,667,667,722,722,722,722,722,722,722,612,667,667,667,667,667,667,667,667,667,722,556,611,778,778,778,722,278,278,278,278,278,278,278,278,500,667,667,556,556,556,556,556,833,722,722,722,722,722,778,1000,778,778,778,778,778,778,778,778,667,778,722,722,722,722,667,667,667,667,667,611,611,611,667,722,722,722,722,722,722,722,722,722,667,944,667,667,667,667,611,611,611,611,556,556,556,556,333,556,889,556,556,667,556,556,469,584,389,1015,556,556,278,260,334,334,278,278,333,260,350,500,500,333,500,500,333,556,333,278,278,250,737,556,556,556,556,643,556,400,333,584,556,333,278,556,556,556,556,556,556,556,556,1000,556,1000,556,556,584,556,278,333,278,500,556,500,556,556,167,556,556,556,611,333,584,549,556,556,333,333,556,333,333,222,278,278,278,278,278,222,222,500,500,222,222,299,222,584,549,584,471,222,833,333,584,556,584,556,556,556,556,556,549,556,556,556,556,556,556,944,333,556,556,556,556,834,834,333,370,365,611,556,556,537,333,333,476,889,278,278,1000,584,584,556,556,611,355,333,333,333,222,222,222,191,333,333,453,333,333,737,333,500,500,500,500,500,556,278,556,556,278,278,556,600,278,317,278,556,556,834,333,333,1000,556,333,556,556,556,556,556,556,556,556,556,556,500,722,500,500,500,500,556,500,500,500,500,556};func _ffa ()StdFont {_bdf .Do (_ea );_dga :=Descriptor {Name :HelveticaBoldObliqueName ,Family :string (HelveticaName ),Weight :FontWeightBold ,Flags :0x0060,BBox :[4]float64 {-174,-228,1114,962},ItalicAngle :-12,Ascent :718,Descent :-207,CapHeight :718,XHeight :532,StemV :140,StemH :118};return NewStdFont (_dga ,_ebfa );};func init (){RegisterStdFont (CourierName ,_ed ,"\u0043\u006f\u0075\u0072\u0069\u0065\u0072\u0043\u006f\u0075\u0072\u0069e\u0072\u004e\u0065\u0077","\u0043\u006f\u0075\u0072\u0069\u0065\u0072\u004e\u0065\u0077");RegisterStdFont (CourierBoldName ,_bac ,"\u0043o\u0075r\u0069\u0065\u0072\u004e\u0065\u0077\u002c\u0042\u006f\u006c\u0064");RegisterStdFont (CourierObliqueName ,_cf ,"\u0043\u006f\u0075\u0072\u0069\u0065\u0072\u004e\u0065\u0077\u002c\u0049t\u0061\u006c\u0069\u0063");RegisterStdFont (CourierBoldObliqueName ,_geb ,"C\u006f\u0075\u0072\u0069er\u004ee\u0077\u002c\u0042\u006f\u006cd\u0049\u0074\u0061\u006c\u0069\u0063");};var _beg =[]int16 {722,1000,722,722,722,722,722,722,722,722,722,667,722,722,722,722,722,722,722,612,667,667,667,667,667,667,667,667,667,722,500,611,778,778,778,778,389,389,389,389,389,389,389,389,500,778,778,667,667,667,667,667,944,722,722,722,722,722,778,1000,778,778,778,778,778,778,778,778,611,778,722,722,722,722,556,556,556,556,556,667,667,667,611,722,722,722,722,722,722,722,722,722,722,1000,722,722,722,722,667,667,667,667,500,500,500,500,333,500,722,500,500,833,500,500,581,520,500,930,500,556,278,220,394,394,333,333,333,220,350,444,444,333,444,444,333,500,333,333,250,250,747,500,556,500,500,672,556,400,333,570,500,333,278,444,444,444,444,444,444,444,500,1000,444,1000,500,444,570,500,333,333,333,556,500,556,500,500,167,500,500,500,556,333,570,549,500,500,333,333,556,333,333,278,278,278,278,278,278,278,333,556,556,278,278,394,278,570,549,570,494,278,833,333,570,556,570,556,556,556,556,500,549,556,500,500,500,500,500,722,333,500,500,500,500,750,750,300,300,330,500,500,556,540,333,333,494,1000,250,250,1000,570,570,556,500,500,555,500,500,500,333,333,333,278,444,444,549,444,444,747,333,389,389,389,389,389,500,333,500,500,278,250,500,600,333,416,333,556,500,750,300,333,1000,500,300,556,556,556,556,556,556,556,500,556,556,500,722,500,500,500,500,500,444,444,444,444,500};var _eff map[rune ]CharMetrics ;func TtfParseFile (fileStr string )(TtfType ,error ){_dgeg ,_eddf :=_ac .Open (fileStr );if _eddf !=nil {return TtfType {},_eddf ;};defer _dgeg .Close ();return TtfParse (_dgeg );};var _ddc =[]int16 {667,944,667,667,667,667,667,667,667,667,667,667,667,667,667,667,722,722,722,612,667,667,667,667,667,667,667,667,667,722,500,667,722,722,722,778,389,389,389,389,389,389,389,389,500,667,667,611,611,611,611,611,889,722,722,722,722,722,722,944,722,722,722,722,722,722,722,722,611,722,667,667,667,667,556,556,556,556,556,611,611,611,611,722,722,722,722,722,722,722,722,722,667,889,667,611,611,611,611,611,611,611,500,500,500,500,333,500,722,500,500,778,500,500,570,570,500,832,500,500,278,220,348,348,333,333,333,220,350,444,444,333,444,444,333,500,333,333,250,250,747,500,500,500,500,608,500,400,333,570,500,333,278,444,444,444,444,444,444,444,500,1000,444,1000,500,444,570,500,389,389,333,556,500,556,500,500,167,500,500,500,500,333,570,549,500,500,333,333,556,333,333,278,278,278,278,278,278,278,278,500,500,278,278,382,278,570,549,606,494,278,778,333,606,576,570,556,556,556,556,500,549,556,500,500,500,500,500,722,333,500,500,500,500,750,750,300,266,300,500,500,500,500,333,333,494,833,250,250,1000,570,570,500,500,500,555,500,500,500,333,333,333,278,389,389,549,389,389,747,333,389,389,389,389,389,500,333,500,500,278,250,500,600,278,366,278,500,500,750,300,333,1000,500,300,556,556,556,556,556,556,556,500,556,556,444,667,500,444,444,444,500,389,389,389,389,500};type StdFontName string ;func _gcd ()StdFont {_gca .Do (_cg );_efa :=Descriptor {Name :TimesRomanName ,Family :_ccf ,Weight :FontWeightRoman ,Flags :0x0020,BBox :[4]float64 {-168,-218,1000,898},ItalicAngle :0,Ascent :683,Descent :-217,CapHeight :662,XHeight :450,StemV :84,StemH :28};return NewStdFont (_efa ,_cgd );};func _bga ()StdFont {_bdf .Do (_ea );_gee :=Descriptor {Name :HelveticaName ,Family :string (HelveticaName ),Weight :FontWeightMedium ,Flags :0x0020,BBox :[4]float64 {-166,-225,1000,931},ItalicAngle :0,Ascent :718,Descent :-207,CapHeight :718,XHeight :523,StemV :88,StemH :76};return NewStdFont (_gee ,_af );};const (HelveticaName =StdFontName ("\u0048e\u006c\u0076\u0065\u0074\u0069\u0063a");HelveticaBoldName =StdFontName ("\u0048\u0065\u006c\u0076\u0065\u0074\u0069\u0063\u0061-\u0042\u006f\u006c\u0064");HelveticaObliqueName =StdFontName ("\u0048\u0065\u006c\u0076\u0065\u0074\u0069\u0063\u0061\u002d\u004f\u0062l\u0069\u0071\u0075\u0065");HelveticaBoldObliqueName =StdFontName ("H\u0065\u006c\u0076\u0065ti\u0063a\u002d\u0042\u006f\u006c\u0064O\u0062\u006c\u0069\u0071\u0075\u0065"););type GlyphName =_ge .GlyphName ;func _dgc ()StdFont {_gca .Do (_cg );_dd :=Descriptor {Name :TimesBoldItalicName ,Family :_ccf ,Weight :FontWeightBold ,Flags :0x0060,BBox :[4]float64 {-200,-218,996,921},ItalicAngle :-15,Ascent :683,Descent :-217,CapHeight :669,XHeight :462,StemV :121,StemH :42};return NewStdFont (_dd ,_fcf );};func _dgd ()StdFont {_bdf .Do (_ea );_gc :=Descriptor {Name :HelveticaBoldName ,Family :string (HelveticaName ),Weight :FontWeightBold ,Flags :0x0020,BBox :[4]float64 {-170,-228,1003,962},ItalicAngle :0,Ascent :718,Descent :-207,CapHeight :718,XHeight :532,StemV :140,StemH :118};return NewStdFont (_gc ,_ce );};func init (){RegisterStdFont (HelveticaName ,_bga ,"\u0041\u0072\u0069a\u006c");RegisterStdFont (HelveticaBoldName ,_dgd ,"\u0041\u0072\u0069\u0061\u006c\u002c\u0042\u006f\u006c\u0064");RegisterStdFont (HelveticaObliqueName ,_abc ,"\u0041\u0072\u0069a\u006c\u002c\u0049\u0074\u0061\u006c\u0069\u0063");RegisterStdFont (HelveticaBoldObliqueName ,_ffa ,"\u0041\u0072i\u0061\u006c\u002cB\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063");};func (_gfag *ttfParser )parseCmapVersion (_dgcd int64 )error {_gf .Log .Trace ("p\u0061\u0072\u0073\u0065\u0043\u006da\u0070\u0056\u0065\u0072\u0073\u0069\u006f\u006e\u003a \u006f\u0066\u0066s\u0065t\u003d\u0025\u0064",_dgcd );if _gfag ._age .Chars ==nil {_gfag ._age .Chars =make (map[rune ]GID );};_gfag ._gbb .Seek (int64 (_gfag ._cba ["\u0063\u006d\u0061\u0070"])+_dgcd ,_f .SeekStart );var _afcg ,_bef uint32 ;_ccdb :=_gfag .ReadUShort ();if _ccdb < 8{_afcg =uint32 (_gfag .ReadUShort ());_bef =uint32 (_gfag .ReadUShort ());}else {_gfag .ReadUShort ();_afcg =_gfag .ReadULong ();_bef =_gfag .ReadULong ();};_gf .Log .Debug ("\u0070\u0061\u0072\u0073\u0065\u0043m\u0061\u0070\u0056\u0065\u0072\u0073\u0069\u006f\u006e\u003a\u0020\u0066\u006f\u0072\u006d\u0061\u0074\u003d\u0025\u0064 \u006c\u0065\u006e\u0067\u0074\u0068\u003d\u0025\u0064\u0020\u006c\u0061\u006e\u0067u\u0061g\u0065\u003d\u0025\u0064",_ccdb ,_afcg ,_bef );switch _ccdb {case 0:return _gfag .parseCmapFormat0 ();case 6:return _gfag .parseCmapFormat6 ();case 12:return _gfag .parseCmapFormat12 ();default:_gf .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0063m\u0061\u0070\u0020\u0066\u006f\u0072\u006da\u0074\u003d\u0025\u0064",_ccdb );return nil ;};};func _deb (){const _gag =600;_fag =make (map[rune ]CharMetrics ,len (_fc ));for _ ,_cfe :=range _fc {_fag [_cfe ]=CharMetrics {Wx :_gag };};_bc =_fag ;_bec =_fag ;_cd =_fag ;};var _fbf _ga .Once ;func (_bea *ttfParser )ParseOS2 ()error {if _edba :=_bea .Seek ("\u004f\u0053\u002f\u0032");_edba !=nil {return _edba ;};_bbg :=_bea .ReadUShort ();_bea .Skip (4*2);_bea .Skip (11*2+10+4*4+4);_deac :=_bea .ReadUShort ();_bea ._age .Bold =(_deac &32)!=0;_bea .Skip (2*2);_bea ._age .TypoAscender =_bea .ReadShort ();_bea ._age .TypoDescender =_bea .ReadShort ();if _bbg >=2{_bea .Skip (3*2+2*4+2);_bea ._age .CapHeight =_bea .ReadShort ();}else {_bea ._age .CapHeight =0;};return nil ;};func (_dc StdFont )Encoder ()_ge .TextEncoder {return _dc ._gfe };func (_adg *ttfParser )ParseHmtx ()error {if _efbb :=_adg .Seek ("\u0068\u006d\u0074\u0078");_efbb !=nil {return _efbb ;};_adg ._age .Widths =make ([]uint16 ,0,8);for _gdfb :=uint16 (0);_gdfb < _adg ._cfd ;_gdfb ++{_adg ._age .Widths =append (_adg ._age .Widths ,_adg .ReadUShort ());_adg .Skip (2);};if _adg ._cfd < _adg ._dfe &&_adg ._cfd > 0{_dbbb :=_adg ._age .Widths [_adg ._cfd -1];for _fce :=_adg ._cfd ;_fce < _adg ._dfe ;_fce ++{_adg ._age .Widths =append (_adg ._age .Widths ,_dbbb );};};return nil ;};func (_gea *ttfParser )parseCmapFormat6 ()error {_dcc :=int (_gea .ReadUShort ());_dgca :=int (_gea .ReadUShort ());_gf .Log .Trace ("p\u0061\u0072\u0073\u0065\u0043\u006d\u0061\u0070\u0046o\u0072\u006d\u0061\u0074\u0036\u003a\u0020%s\u0020\u0066\u0069\u0072s\u0074\u0043\u006f\u0064\u0065\u003d\u0025\u0064\u0020en\u0074\u0072y\u0043\u006f\u0075\u006e\u0074\u003d\u0025\u0064",_gea ._age .String (),_dcc ,_dgca );for _bcgd :=0;_bcgd < _dgca ;_bcgd ++{_ceb :=GID (_gea .ReadUShort ());_gea ._age .Chars [rune (_bcgd +_dcc )]=_ceb ;};return nil ;};func (_ae StdFont )Descriptor ()Descriptor {return _ae ._acd };func _bgb ()StdFont {_gca .Do (_cg );_gd :=Descriptor {Name :TimesBoldName ,Family :_ccf ,Weight :FontWeightBold ,Flags :0x0020,BBox :[4]float64 {-168,-218,1000,935},ItalicAngle :0,Ascent :683,Descent :-217,CapHeight :676,XHeight :461,StemV :139,StemH :44};return NewStdFont (_gd ,_abf );};func _gbee (_cge map[string ]uint32 )string {var _efb []string ;for _fae :=range _cge {_efb =append (_efb ,_fae );};_dg .Slice (_efb ,func (_ede ,_agg int )bool {return _cge [_efb [_ede ]]< _cge [_efb [_agg ]]});_ebe :=[]string {_d .Sprintf ("\u0054\u0072\u0075\u0065Ty\u0070\u0065\u0020\u0074\u0061\u0062\u006c\u0065\u0073\u003a\u0020\u0025\u0064",len (_cge ))};for _ ,_ccb :=range _efb {_ebe =append (_ebe ,_d .Sprintf ("\u0009%\u0071\u0020\u0025\u0035\u0064",_ccb ,_cge [_ccb ]));};return _df .Join (_ebe ,"\u000a");};var _cd map[rune ]CharMetrics ;func _cg (){_cgd =make (map[rune ]CharMetrics ,len (_fc ));_abf =make (map[rune ]CharMetrics ,len (_fc ));_fcf =make (map[rune ]CharMetrics ,len (_fc ));_gebb =make (map[rune ]CharMetrics ,len (_fc ));for _dbf ,_feb :=range _fc {_cgd [_feb ]=CharMetrics {Wx :float64 (_gdf [_dbf ])};_abf [_feb ]=CharMetrics {Wx :float64 (_beg [_dbf ])};_fcf [_feb ]=CharMetrics {Wx :float64 (_ddc [_dbf ])};_gebb [_feb ]=CharMetrics {Wx :float64 (_ebb [_dbf ])};};};var _cdf =[]int16 {722,1000,722,722,722,722,722,722,722,722,722,722,722,722,722,722,722,722,722,612,667,667,667,667,667,667,667,667,667,722,556,611,778,778,778,722,278,278,278,278,278,278,278,278,556,722,722,611,611,611,611,611,833,722,722,722,722,722,778,1000,778,778,778,778,778,778,778,778,667,778,722,722,722,722,667,667,667,667,667,611,611,611,667,722,722,722,722,722,722,722,722,722,667,944,667,667,667,667,611,611,611,611,556,556,556,556,333,556,889,556,556,722,556,556,584,584,389,975,556,611,278,280,389,389,333,333,333,280,350,556,556,333,556,556,333,556,333,333,278,250,737,556,611,556,556,743,611,400,333,584,556,333,278,556,556,556,556,556,556,556,556,1000,556,1000,556,556,584,611,333,333,333,611,556,611,556,556,167,611,611,611,611,333,584,549,556,556,333,333,611,333,333,278,278,278,278,278,278,278,278,556,556,278,278,400,278,584,549,584,494,278,889,333,584,611,584,611,611,611,611,556,549,611,556,611,611,611,611,944,333,611,611,611,556,834,834,333,370,365,611,611,611,556,333,333,494,889,278,278,1000,584,584,611,611,611,474,500,500,500,278,278,278,238,389,389,549,389,389,737,333,556,556,556,556,556,556,333,556,556,278,278,556,600,333,389,333,611,556,834,333,333,1000,556,333,611,611,611,611,611,611,611,556,611,611,556,778,556,556,556,556,556,500,500,500,500,556};func init (){RegisterStdFont (SymbolName ,_edb ,"\u0053\u0079\u006d\u0062\u006f\u006c\u002c\u0049\u0074\u0061\u006c\u0069\u0063","S\u0079\u006d\u0062\u006f\u006c\u002c\u0042\u006f\u006c\u0064","\u0053\u0079\u006d\u0062\u006f\u006c\u002c\u0042\u006f\u006c\u0064\u0049t\u0061\u006c\u0069\u0063");RegisterStdFont (ZapfDingbatsName ,_gb );};func _abc ()StdFont {_bdf .Do (_ea );_dge :=Descriptor {Name :HelveticaObliqueName ,Family :string (HelveticaName ),Weight :FontWeightMedium ,Flags :0x0060,BBox :[4]float64 {-170,-225,1116,931},ItalicAngle :-12,Ascent :718,Descent :-207,CapHeight :718,XHeight :523,StemV :88,StemH :76};return NewStdFont (_dge ,_eff );};var _dfb =map[rune ]CharMetrics {' ':{Wx :278},'→':{Wx :838},'↔':{Wx :1016},'↕':{Wx :458},'①':{Wx :788},'②':{Wx :788},'③':{Wx :788},'④':{Wx :788},'⑤':{Wx :788},'⑥':{Wx :788},'⑦':{Wx :788},'⑧':{Wx :788},'⑨':{Wx :788},'⑩':{Wx :788},'■':{Wx :761},'▲':{Wx :892},'▼':{Wx :892},'◆':{Wx :788},'●':{Wx :791},'◗':{Wx :438},'★':{Wx :816},'☎':{Wx :719},'☛':{Wx :960},'☞':{Wx :939},'♠':{Wx :626},'♣':{Wx :776},'♥':{Wx :694},'♦':{Wx :595},'✁':{Wx :974},'✂':{Wx :961},'✃':{Wx :974},'✄':{Wx :980},'✆':{Wx :789},'✇':{Wx :790},'✈':{Wx :791},'✉':{Wx :690},'✌':{Wx :549},'✍':{Wx :855},'✎':{Wx :911},'✏':{Wx :933},'✐':{Wx :911},'✑':{Wx :945},'✒':{Wx :974},'✓':{Wx :755},'✔':{Wx :846},'✕':{Wx :762},'✖':{Wx :761},'✗':{Wx :571},'✘':{Wx :677},'✙':{Wx :763},'✚':{Wx :760},'✛':{Wx :759},'✜':{Wx :754},'✝':{Wx :494},'✞':{Wx :552},'✟':{Wx :537},'✠':{Wx :577},'✡':{Wx :692},'✢':{Wx :786},'✣':{Wx :788},'✤':{Wx :788},'✥':{Wx :790},'✦':{Wx :793},'✧':{Wx :794},'✩':{Wx :823},'✪':{Wx :789},'✫':{Wx :841},'✬':{Wx :823},'✭':{Wx :833},'✮':{Wx :816},'✯':{Wx :831},'✰':{Wx :923},'✱':{Wx :744},'✲':{Wx :723},'✳':{Wx :749},'✴':{Wx :790},'✵':{Wx :792},'✶':{Wx :695},'✷':{Wx :776},'✸':{Wx :768},'✹':{Wx :792},'✺':{Wx :759},'✻':{Wx :707},'✼':{Wx :708},'✽':{Wx :682},'✾':{Wx :701},'✿':{Wx :826},'❀':{Wx :815},'❁':{Wx :789},'❂':{Wx :789},'❃':{Wx :707},'❄':{Wx :687},'❅':{Wx :696},'❆':{Wx :689},'❇':{Wx :786},'❈':{Wx :787},'❉':{Wx :713},'❊':{Wx :791},'❋':{Wx :785},'❍':{Wx :873},'❏':{Wx :762},'❐':{Wx :762},'❑':{Wx :759},'❒':{Wx :759},'❖':{Wx :784},'❘':{Wx :138},'❙':{Wx :277},'❚':{Wx :415},'❛':{Wx :392},'❜':{Wx :392},'❝':{Wx :668},'❞':{Wx :668},'❡':{Wx :732},'❢':{Wx :544},'❣':{Wx :544},'❤':{Wx :910},'❥':{Wx :667},'❦':{Wx :760},'❧':{Wx :760},'❶':{Wx :788},'❷':{Wx :788},'❸':{Wx :788},'❹':{Wx :788},'❺':{Wx :788},'❻':{Wx :788},'❼':{Wx :788},'❽':{Wx :788},'❾':{Wx :788},'❿':{Wx :788},'➀':{Wx :788},'➁':{Wx :788},'➂':{Wx :788},'➃':{Wx :788},'➄':{Wx :788},'➅':{Wx :788},'➆':{Wx :788},'➇':{Wx :788},'➈':{Wx :788},'➉':{Wx :788},'➊':{Wx :788},'➋':{Wx :788},'➌':{Wx :788},'➍':{Wx :788},'➎':{Wx :788},'➏':{Wx :788},'➐':{Wx :788},'➑':{Wx :788},'➒':{Wx :788},'➓':{Wx :788},'➔':{Wx :894},'➘':{Wx :748},'➙':{Wx :924},'➚':{Wx :748},'➛':{Wx :918},'➜':{Wx :927},'➝':{Wx :928},'➞':{Wx :928},'➟':{Wx :834},'➠':{Wx :873},'➡':{Wx :828},'➢':{Wx :924},'➣':{Wx :924},'➤':{Wx :917},'➥':{Wx :930},'➦':{Wx :931},'➧':{Wx :463},'➨':{Wx :883},'➩':{Wx :836},'➪':{Wx :836},'➫':{Wx :867},'➬':{Wx :867},'➭':{Wx :696},'➮':{Wx :696},'➯':{Wx :874},'➱':{Wx :874},'➲':{Wx :760},'➳':{Wx :946},'➴':{Wx :771},'➵':{Wx :865},'➶':{Wx :771},'➷':{Wx :888},'➸':{Wx :967},'➹':{Wx :888},'➺':{Wx :831},'➻':{Wx :873},'➼':{Wx :927},'➽':{Wx :970},'➾':{Wx :918},'\uf8d7':{Wx :390},'\uf8d8':{Wx :390},'\uf8d9':{Wx :317},'\uf8da':{Wx :317},'\uf8db':{Wx :276},'\uf8dc':{Wx :276},'\uf8dd':{Wx :509},'\uf8de':{Wx :509},'\uf8df':{Wx :410},'\uf8e0':{Wx :410},'\uf8e1':{Wx :234},'\uf8e2':{Wx :234},'\uf8e3':{Wx :334},'\uf8e4':{Wx :334}};func NewStdFontByName (name StdFontName )(StdFont ,bool ){_ggc ,_ba :=_ec [name ];if !_ba {return StdFont {},false ;};return _ggc (),true ;};const (_ccf ="\u0054\u0069\u006de\u0073";TimesRomanName =StdFontName ("T\u0069\u006d\u0065\u0073\u002d\u0052\u006f\u006d\u0061\u006e");TimesBoldName =StdFontName ("\u0054\u0069\u006d\u0065\u0073\u002d\u0042\u006f\u006c\u0064");TimesItalicName =StdFontName ("\u0054\u0069\u006de\u0073\u002d\u0049\u0074\u0061\u006c\u0069\u0063");TimesBoldItalicName =StdFontName ("\u0054\u0069m\u0065\u0073\u002dB\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063"););func _geb ()StdFont {_fbf .Do (_deb );_gafd :=Descriptor {Name :CourierBoldObliqueName ,Family :string (CourierName ),Weight :FontWeightBold ,Flags :0x0061,BBox :[4]float64 {-57,-250,869,801},ItalicAngle :-12,Ascent :629,Descent :-157,CapHeight :562,XHeight :439,StemV :106,StemH :84};return NewStdFont (_gafd ,_bec );};func init (){RegisterStdFont (TimesRomanName ,_gcd ,"\u0054\u0069\u006d\u0065\u0073\u004e\u0065\u0077\u0052\u006f\u006d\u0061\u006e","\u0054\u0069\u006de\u0073");RegisterStdFont (TimesBoldName ,_bgb ,"\u0054i\u006de\u0073\u004e\u0065\u0077\u0052o\u006d\u0061n\u002c\u0042\u006f\u006c\u0064","\u0054\u0069\u006d\u0065\u0073\u002c\u0042\u006f\u006c\u0064");RegisterStdFont (TimesItalicName ,_gdc ,"T\u0069m\u0065\u0073\u004e\u0065\u0077\u0052\u006f\u006da\u006e\u002c\u0049\u0074al\u0069\u0063","\u0054\u0069\u006de\u0073\u002c\u0049\u0074\u0061\u006c\u0069\u0063");RegisterStdFont (TimesBoldItalicName ,_dgc ,"\u0054i\u006d\u0065\u0073\u004e\u0065\u0077\u0052\u006f\u006d\u0061\u006e,\u0042\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063","\u0054\u0069m\u0065\u0073\u002cB\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063");};func (_abg *TtfType )NewEncoder ()_ge .TextEncoder {return _ge .NewTrueTypeFontEncoder (_abg .Chars )};var _bc map[rune ]CharMetrics ;const (FontWeightMedium FontWeight =iota ;FontWeightBold ;FontWeightRoman ;);var _af map[rune ]CharMetrics ;func (_ab CharMetrics )String ()string {return _d .Sprintf ("<\u0025\u002e\u0031\u0066\u002c\u0025\u002e\u0031\u0066\u003e",_ab .Wx ,_ab .Wy );};const (CourierName =StdFontName ("\u0043o\u0075\u0072\u0069\u0065\u0072");CourierBoldName =StdFontName ("\u0043\u006f\u0075r\u0069\u0065\u0072\u002d\u0042\u006f\u006c\u0064");CourierObliqueName =StdFontName ("\u0043o\u0075r\u0069\u0065\u0072\u002d\u004f\u0062\u006c\u0069\u0071\u0075\u0065");CourierBoldObliqueName =StdFontName ("\u0043\u006f\u0075\u0072ie\u0072\u002d\u0042\u006f\u006c\u0064\u004f\u0062\u006c\u0069\u0071\u0075\u0065"););type CharMetrics struct{Wx float64 ;Wy float64 ;};type TtfType struct{UnitsPerEm uint16 ;PostScriptName string ;Bold bool ;ItalicAngle float64 ;IsFixedPitch bool ;TypoAscender int16 ;TypoDescender int16 ;UnderlinePosition int16 ;UnderlineThickness int16 ;Xmin ,Ymin ,Xmax ,Ymax int16 ;CapHeight int16 ;Widths []uint16 ;Chars map[rune ]GID ;GlyphNames []GlyphName ;};func (_cgda *TtfType )MakeEncoder ()(_ge .SimpleEncoder ,error ){_dgae :=make (map[_ge .CharCode ]GlyphName );for _fbg :=_ge .CharCode (0);_fbg <=256;_fbg ++{_afe :=rune (_fbg );_ded ,_gdb :=_cgda .Chars [_afe ];if !_gdb {continue ;};var _bace GlyphName ;if int (_ded )>=0&&int (_ded )< len (_cgda .GlyphNames ){_bace =_cgda .GlyphNames [_ded ];}else {_ceag :=rune (_ded );if _bcg ,_ccd :=_ge .RuneToGlyph (_ceag );_ccd {_bace =_bcg ;};};if _bace !=""{_dgae [_fbg ]=_bace ;};};if len (_dgae )==0{_gf .Log .Debug ("WA\u0052\u004eI\u004e\u0047\u003a\u0020\u005a\u0065\u0072\u006f\u0020l\u0065\u006e\u0067\u0074\u0068\u0020\u0054\u0072\u0075\u0065\u0054\u0079\u0070\u0065\u0020\u0065\u006e\u0063\u006f\u0064\u0069\u006e\u0067\u002e\u0020\u0074\u0074\u0066=\u0025s\u0020\u0043\u0068\u0061\u0072\u0073\u003d\u005b%\u00200\u0032\u0078]",_cgda ,_cgda .Chars );};return _ge .NewCustomSimpleTextEncoder (_dgae ,nil );};var _fcf map[rune ]CharMetrics ;var _gebb map[rune ]CharMetrics ;func (_bfb *ttfParser )ReadStr (length int )(string ,error ){_dedd :=make ([]byte ,length );_gcfd ,_agba :=_bfb ._gbb .Read (_dedd );if _agba !=nil {return "",_agba ;}else if _gcfd !=length {return "",_d .Errorf ("\u0075\u006e\u0061bl\u0065\u0020\u0074\u006f\u0020\u0072\u0065\u0061\u0064\u0020\u0025\u0064\u0020\u0062\u0079\u0074\u0065\u0073",length );};return string (_dedd ),nil ;};func _gdc ()StdFont {_gca .Do (_cg );_ffc :=Descriptor {Name :TimesItalicName ,Family :_ccf ,Weight :FontWeightMedium ,Flags :0x0060,BBox :[4]float64 {-169,-217,1010,883},ItalicAngle :-15.5,Ascent :683,Descent :-217,CapHeight :653,XHeight :441,StemV :76,StemH :32};return NewStdFont (_ffc ,_gebb );};func (_gad *ttfParser )ParseName ()error {if _geeb :=_gad .Seek ("\u006e\u0061\u006d\u0065");_geeb !=nil {return _geeb ;};_ecbg ,_ :=_gad ._gbb .Seek (0,_f .SeekCurrent );_gad ._age .PostScriptName ="";_gad .Skip (2);_eddfa :=_gad .ReadUShort ();_cee :=_gad .ReadUShort ();for _aefd :=uint16 (0);_aefd < _eddfa &&_gad ._age .PostScriptName =="";_aefd ++{_gad .Skip (3*2);_bag :=_gad .ReadUShort ();_cebb :=_gad .ReadUShort ();_ada :=_gad .ReadUShort ();if _bag ==6{_gad ._gbb .Seek (_ecbg +int64 (_cee )+int64 (_ada ),_f .SeekStart );_fgfb ,_cff :=_gad .ReadStr (int (_cebb ));if _cff !=nil {return _cff ;};_fgfb =_df .Replace (_fgfb ,"\u0000","",-1);_befd ,_cff :=_b .Compile ("\u005b\u0028\u0029\u007b\u007d\u003c\u003e\u0020\u002f%\u005b\u005c\u005d\u005d");if _cff !=nil {return _cff ;};_gad ._age .PostScriptName =_befd .ReplaceAllString (_fgfb ,"");};};if _gad ._age .PostScriptName ==""{_gf .Log .Debug ("\u0050a\u0072\u0073e\u004e\u0061\u006de\u003a\u0020\u0054\u0068\u0065\u0020\u006ea\u006d\u0065\u0020\u0050\u006f\u0073t\u0053\u0063\u0072\u0069\u0070\u0074\u0020\u0077\u0061\u0073\u0020n\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u002e");};return nil ;};func (_cef *ttfParser )ParseComponents ()error {if _edea :=_cef .ParseHead ();_edea !=nil {return _edea ;};if _gfc :=_cef .ParseHhea ();_gfc !=nil {return _gfc ;};if _efg :=_cef .ParseMaxp ();_efg !=nil {return _efg ;};if _ddf :=_cef .ParseHmtx ();_ddf !=nil {return _ddf ;};if _ ,_gfb :=_cef ._cba ["\u006e\u0061\u006d\u0065"];_gfb {if _gcf :=_cef .ParseName ();_gcf !=nil {return _gcf ;};};if _ ,_bca :=_cef ._cba ["\u004f\u0053\u002f\u0032"];_bca {if _fgg :=_cef .ParseOS2 ();_fgg !=nil {return _fgg ;};};if _ ,_dgf :=_cef ._cba ["\u0070\u006f\u0073\u0074"];_dgf {if _fde :=_cef .ParsePost ();_fde !=nil {return _fde ;};};if _ ,_gff :=_cef ._cba ["\u0063\u006d\u0061\u0070"];_gff {if _fcd :=_cef .ParseCmap ();_fcd !=nil {return _fcd ;};};return nil ;};func (_ceg *ttfParser )ParseMaxp ()error {if _gagc :=_ceg .Seek ("\u006d\u0061\u0078\u0070");_gagc !=nil {return _gagc ;};_ceg .Skip (4);_ceg ._dfe =_ceg .ReadUShort ();return nil ;};func (_dad *ttfParser )ReadSByte ()(_aba int8 ){_g .Read (_dad ._gbb ,_g .BigEndian ,&_aba );return _aba };func (_fcgb *ttfParser )parseCmapFormat12 ()error {_bbde :=_fcgb .ReadULong ();_gf .Log .Trace ("\u0070\u0061\u0072se\u0043\u006d\u0061\u0070\u0046\u006f\u0072\u006d\u0061t\u00312\u003a \u0025s\u0020\u006e\u0075\u006d\u0047\u0072\u006f\u0075\u0070\u0073\u003d\u0025\u0064",_fcgb ._age .String (),_bbde );for _bcab :=uint32 (0);_bcab < _bbde ;_bcab ++{_dbc :=_fcgb .ReadULong ();_gfg :=_fcgb .ReadULong ();_dae :=_fcgb .ReadULong ();if _dbc > 0x0010FFFF||(0xD800<=_dbc &&_dbc <=0xDFFF){return _fg .New ("\u0069n\u0076\u0061\u006c\u0069\u0064\u0020\u0063\u0068\u0061\u0072\u0061c\u0074\u0065\u0072\u0073\u0020\u0063\u006f\u0064\u0065\u0073");};if _gfg < _dbc ||_gfg > 0x0010FFFF||(0xD800<=_gfg &&_gfg <=0xDFFF){return _fg .New ("\u0069n\u0076\u0061\u006c\u0069\u0064\u0020\u0063\u0068\u0061\u0072\u0061c\u0074\u0065\u0072\u0073\u0020\u0063\u006f\u0064\u0065\u0073");};for _dac :=_dbc ;_dac <=_gfg ;_dac ++{if _dac > 0x10FFFF{_gf .Log .Debug ("\u0046\u006fr\u006d\u0061\u0074\u0020\u0031\u0032\u0020\u0063\u006d\u0061\u0070\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0073\u0020\u0063\u0068\u0061\u0072\u0061\u0063\u0074\u0065\u0072\u0020\u0062\u0065\u0079\u006f\u006e\u0064\u0020\u0055\u0043\u0053\u002d\u0034");};_fcgb ._age .Chars [rune (_dac )]=GID (_dae );_dae ++;};};return nil ;};var _fd =map[rune ]CharMetrics {' ':{Wx :250},'!':{Wx :333},'#':{Wx :500},'%':{Wx :833},'&':{Wx :778},'(':{Wx :333},')':{Wx :333},'+':{Wx :549},',':{Wx :250},'.':{Wx :250},'/':{Wx :278},'0':{Wx :500},'1':{Wx :500},'2':{Wx :500},'3':{Wx :500},'4':{Wx :500},'5':{Wx :500},'6':{Wx :500},'7':{Wx :500},'8':{Wx :500},'9':{Wx :500},':':{Wx :278},';':{Wx :278},'<':{Wx :549},'=':{Wx :549},'>':{Wx :549},'?':{Wx :444},'[':{Wx :333},']':{Wx :333},'_':{Wx :500},'{':{Wx :480},'|':{Wx :200},'}':{Wx :480},'¬':{Wx :713},'°':{Wx :400},'±':{Wx :549},'µ':{Wx :576},'×':{Wx :549},'÷':{Wx :549},'ƒ':{Wx :500},'Α':{Wx :722},'Β':{Wx :667},'Γ':{Wx :603},'Ε':{Wx :611},'Ζ':{Wx :611},'Η':{Wx :722},'Θ':{Wx :741},'Ι':{Wx :333},'Κ':{Wx :722},'Λ':{Wx :686},'Μ':{Wx :889},'Ν':{Wx :722},'Ξ':{Wx :645},'Ο':{Wx :722},'Π':{Wx :768},'Ρ':{Wx :556},'Σ':{Wx :592},'Τ':{Wx :611},'Υ':{Wx :690},'Φ':{Wx :763},'Χ':{Wx :722},'Ψ':{Wx :795},'α':{Wx :631},'β':{Wx :549},'γ':{Wx :411},'δ':{Wx :494},'ε':{Wx :439},'ζ':{Wx :494},'η':{Wx :603},'θ':{Wx :521},'ι':{Wx :329},'κ':{Wx :549},'λ':{Wx :549},'ν':{Wx :521},'ξ':{Wx :493},'ο':{Wx :549},'π':{Wx :549},'ρ':{Wx :549},'ς':{Wx :439},'σ':{Wx :603},'τ':{Wx :439},'υ':{Wx :576},'φ':{Wx :521},'χ':{Wx :549},'ψ':{Wx :686},'ω':{Wx :686},'ϑ':{Wx :631},'ϒ':{Wx :620},'ϕ':{Wx :603},'ϖ':{Wx :713},'•':{Wx :460},'…':{Wx :1000},'′':{Wx :247},'″':{Wx :411},'⁄':{Wx :167},'€':{Wx :750},'ℑ':{Wx :686},'℘':{Wx :987},'ℜ':{Wx :795},'Ω':{Wx :768},'ℵ':{Wx :823},'←':{Wx :987},'↑':{Wx :603},'→':{Wx :987},'↓':{Wx :603},'↔':{Wx :1042},'↵':{Wx :658},'⇐':{Wx :987},'⇑':{Wx :603},'⇒':{Wx :987},'⇓':{Wx :603},'⇔':{Wx :1042},'∀':{Wx :713},'∂':{Wx :494},'∃':{Wx :549},'∅':{Wx :823},'∆':{Wx :612},'∇':{Wx :713},'∈':{Wx :713},'∉':{Wx :713},'∋':{Wx :439},'∏':{Wx :823},'∑':{Wx :713},'−':{Wx :549},'∗':{Wx :500},'√':{Wx :549},'∝':{Wx :713},'∞':{Wx :713},'∠':{Wx :768},'∧':{Wx :603},'∨':{Wx :603},'∩':{Wx :768},'∪':{Wx :768},'∫':{Wx :274},'∴':{Wx :863},'∼':{Wx :549},'≅':{Wx :549},'≈':{Wx :549},'≠':{Wx :549},'≡':{Wx :549},'≤':{Wx :549},'≥':{Wx :549},'⊂':{Wx :713},'⊃':{Wx :713},'⊄':{Wx :713},'⊆':{Wx :713},'⊇':{Wx :713},'⊕':{Wx :768},'⊗':{Wx :768},'⊥':{Wx :658},'⋅':{Wx :250},'⌠':{Wx :686},'⌡':{Wx :686},'〈':{Wx :329},'〉':{Wx :329},'◊':{Wx :494},'♠':{Wx :753},'♣':{Wx :753},'♥':{Wx :753},'♦':{Wx :753},'\uf6d9':{Wx :790},'\uf6da':{Wx :790},'\uf6db':{Wx :890},'\uf8e5':{Wx :500},'\uf8e6':{Wx :603},'\uf8e7':{Wx :1000},'\uf8e8':{Wx :790},'\uf8e9':{Wx :790},'\uf8ea':{Wx :786},'\uf8eb':{Wx :384},'\uf8ec':{Wx :384},'\uf8ed':{Wx :384},'\uf8ee':{Wx :384},'\uf8ef':{Wx :384},'\uf8f0':{Wx :384},'\uf8f1':{Wx :494},'\uf8f2':{Wx :494},'\uf8f3':{Wx :494},'\uf8f4':{Wx :494},'\uf8f5':{Wx :686},'\uf8f6':{Wx :384},'\uf8f7':{Wx :384},'\uf8f8':{Wx :384},'\uf8f9':{Wx :384},'\uf8fa':{Wx :384},'\uf8fb':{Wx :384},'\uf8fc':{Wx :494},'\uf8fd':{Wx :494},'\uf8fe':{Wx :494},'\uf8ff':{Wx :790}};func (_fdcf *ttfParser )parseCmapFormat0 ()error {_gcb ,_aef :=_fdcf .ReadStr (256);if _aef !=nil {return _aef ;};_bbf :=[]byte (_gcb );_gf .Log .Trace ("\u0070a\u0072\u0073e\u0043\u006d\u0061p\u0046\u006f\u0072\u006d\u0061\u0074\u0030:\u0020\u0025\u0073\u000a\u0064\u0061t\u0061\u0053\u0074\u0072\u003d\u0025\u002b\u0071\u000a\u0064\u0061t\u0061\u003d\u005b\u0025\u0020\u0030\u0032\u0078\u005d",_fdcf ._age .String (),_gcb ,_bbf );for _dea ,_bbd :=range _bbf {_fdcf ._age .Chars [rune (_dea )]=GID (_bbd );};return nil ;};func _ea (){_af =make (map[rune ]CharMetrics ,len (_fc ));_ce =make (map[rune ]CharMetrics ,len (_fc ));for _ef ,_cc :=range _fc {_af [_cc ]=CharMetrics {Wx :float64 (_bcd [_ef ])};_ce [_cc ]=CharMetrics {Wx :float64 (_cdf [_ef ])};};_eff =_af ;_ebfa =_ce ;};func (_ecb *ttfParser )Parse ()(TtfType ,error ){_acg ,_dedg :=_ecb .ReadStr (4);if _dedg !=nil {return TtfType {},_dedg ;};if _acg =="\u004f\u0054\u0054\u004f"{return TtfType {},_de .Errorf ("\u0066\u006f\u006e\u0074s\u0020\u0062\u0061\u0073\u0065\u0064\u0020\u006f\u006e \u0050\u006f\u0073\u0074\u0053\u0063\u0072\u0069\u0070\u0074\u0020\u006f\u0075\u0074\u006c\u0069\u006e\u0065s\u0020\u0061\u0072\u0065\u0020n\u006f\u0074\u0020\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0028\u0025\u0077\u0029",_fb .ErrNotSupported );};if _acg !="\u0000\u0001\u0000\u0000"&&_acg !="\u0074\u0072\u0075\u0065"{_gf .Log .Debug ("\u0055n\u0072\u0065c\u006f\u0067\u006ei\u007a\u0065\u0064\u0020\u0054\u0072\u0075e\u0054\u0079\u0070\u0065\u0020\u0066i\u006c\u0065\u0020\u0066\u006f\u0072\u006d\u0061\u0074\u002e\u0020v\u0065\u0072\u0073\u0069\u006f\u006e\u003d\u0025\u0071",_acg );};_ace :=int (_ecb .ReadUShort ());_ecb .Skip (3*2);_ecb ._cba =make (map[string ]uint32 );var _gde string ;for _cfed :=0;_cfed < _ace ;_cfed ++{_gde ,_dedg =_ecb .ReadStr (4);if _dedg !=nil {return TtfType {},_dedg ;};_ecb .Skip (4);_cde :=_ecb .ReadULong ();_ecb .Skip (4);_ecb ._cba [_gde ]=_cde ;};_gf .Log .Trace (_gbee (_ecb ._cba ));if _dedg =_ecb .ParseComponents ();_dedg !=nil {return TtfType {},_dedg ;};return _ecb ._age ,nil ;};var _bec map[rune ]CharMetrics ;func _edb ()StdFont {_cea :=_ge .NewSymbolEncoder ();_ebd :=Descriptor {Name :SymbolName ,Family :string (SymbolName ),Weight :FontWeightMedium ,Flags :0x0004,BBox :[4]float64 {-180,-293,1090,1010},ItalicAngle :0,Ascent :0,Descent :0,CapHeight :0,XHeight :0,StemV :85,StemH :92};return NewStdFontWithEncoding (_ebd ,_fd ,_cea );};func (_gaa *ttfParser )ParseHhea ()error {if _baa :=_gaa .Seek ("\u0068\u0068\u0065\u0061");_baa !=nil {return _baa ;};_gaa .Skip (4+15*2);_gaa ._cfd =_gaa .ReadUShort ();return nil ;};func (_dbe *ttfParser )ParseHead ()error {if _dbb :=_dbe .Seek ("\u0068\u0065\u0061\u0064");_dbb !=nil {return _dbb ;};_dbe .Skip (3*4);_dbd :=_dbe .ReadULong ();if _dbd !=0x5F0F3CF5{_gf .Log .Debug ("\u0045\u0052\u0052\u004f\u0052:\u0020\u0049\u006e\u0063\u006fr\u0072e\u0063\u0074\u0020\u006d\u0061\u0067\u0069\u0063\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u002e\u0020\u0046\u006fn\u0074\u0020\u006d\u0061\u0079\u0020\u006e\u006f\u0074\u0020\u0064\u0069\u0073\u0070\u006c\u0061\u0079\u0020\u0063\u006f\u0072\u0072\u0065\u0063t\u006c\u0079\u002e\u0020\u0025\u0073",_dbe );};_dbe .Skip (2);_dbe ._age .UnitsPerEm =_dbe .ReadUShort ();_dbe .Skip (2*8);_dbe ._age .Xmin =_dbe .ReadShort ();_dbe ._age .Ymin =_dbe .ReadShort ();_dbe ._age .Xmax =_dbe .ReadShort ();_dbe ._age .Ymax =_dbe .ReadShort ();return nil ;};var _ebb =[]int16 {611,889,611,611,611,611,611,611,611,611,611,611,667,667,667,667,722,722,722,612,611,611,611,611,611,611,611,611,611,722,500,611,722,722,722,722,333,333,333,333,333,333,333,333,444,667,667,556,556,611,556,556,833,667,667,667,667,667,722,944,722,722,722,722,722,722,722,722,611,722,611,611,611,611,500,500,500,500,500,556,556,556,611,722,722,722,722,722,722,722,722,722,611,833,611,556,556,556,556,556,556,556,500,500,500,500,333,500,667,500,500,778,500,500,422,541,500,920,500,500,278,275,400,400,389,389,333,275,350,444,444,333,444,444,333,500,333,333,250,250,760,500,500,500,500,544,500,400,333,675,500,333,278,444,444,444,444,444,444,444,500,889,444,889,500,444,675,500,333,389,278,500,500,500,500,500,167,500,500,500,500,333,675,549,500,500,333,333,500,333,333,278,278,278,278,278,278,278,278,444,444,278,278,300,278,675,549,675,471,278,722,333,675,500,675,500,500,500,500,500,549,500,500,500,500,500,500,667,333,500,500,500,500,750,750,300,276,310,500,500,500,523,333,333,476,833,250,250,1000,675,675,500,500,500,420,556,556,556,333,333,333,214,389,389,453,389,389,760,333,389,389,389,389,389,500,333,500,500,278,250,500,600,278,300,278,500,500,750,300,333,980,500,300,500,500,500,500,500,500,500,500,500,500,444,667,444,444,444,444,500,389,389,389,389,500};func NewStdFont (desc Descriptor ,metrics map[rune ]CharMetrics )StdFont {return NewStdFontWithEncoding (desc ,metrics ,_ge .NewStandardEncoder ());};func (_cbc *TtfType )MakeToUnicode ()*_gg .CMap {_aeb :=make (map[_gg .CharCode ]rune );if len (_cbc .GlyphNames )==0{return _gg .NewToUnicodeCMap (_aeb );};for _gbe ,_cdff :=range _cbc .Chars {_eba :=_gg .CharCode (_gbe );_edd :=_cbc .GlyphNames [_cdff ];_ffb ,_fcg :=_ge .GlyphToRune (_edd );if !_fcg {_gf .Log .Debug ("\u004e\u006f \u0072\u0075\u006e\u0065\u002e\u0020\u0063\u006f\u0064\u0065\u003d\u0030\u0078\u0025\u0030\u0034\u0078\u0020\u0067\u006c\u0079\u0070h=\u0025\u0071",_gbe ,_edd );_ffb =_ge .MissingCodeRune ;};_aeb [_eba ]=_ffb ;};return _gg .NewToUnicodeCMap (_aeb );};var _cgd map[rune ]CharMetrics ;func NewStdFontWithEncoding (desc Descriptor ,metrics map[rune ]CharMetrics ,encoder _ge .TextEncoder )StdFont {var _acb rune =0xA0;if _ ,_cb :=metrics [_acb ];!_cb {metrics [_acb ]=metrics [0x20];};return StdFont {_acd :desc ,_fa :metrics ,_gfe :encoder };};func (_eef *ttfParser )ReadUShort ()(_bdfc uint16 ){_g .Read (_eef ._gbb ,_g .BigEndian ,&_bdfc );return _bdfc ;};func (_agga *ttfParser )ReadULong ()(_feg uint32 ){_g .Read (_agga ._gbb ,_g .BigEndian ,&_feg );return _feg ;};type ttfParser struct{_age TtfType ;_gbb _f .ReadSeeker ;_cba map[string ]uint32 ;_cfd uint16 ;_dfe uint16 ;};func (_eaa *ttfParser )Skip (n int ){_eaa ._gbb .Seek (int64 (n ),_f .SeekCurrent )};func (_efd *ttfParser )ParsePost ()error {if _fcfd :=_efd .Seek ("\u0070\u006f\u0073\u0074");_fcfd !=nil {return _fcfd ;};_gcda :=_efd .Read32Fixed ();_efd ._age .ItalicAngle =_efd .Read32Fixed ();_efd ._age .UnderlinePosition =_efd .ReadShort ();_efd ._age .UnderlineThickness =_efd .ReadShort ();_efd ._age .IsFixedPitch =_efd .ReadULong ()!=0;_efd .ReadULong ();_efd .ReadULong ();_efd .ReadULong ();_efd .ReadULong ();_gf .Log .Trace ("\u0050a\u0072\u0073\u0065\u0050\u006f\u0073\u0074\u003a\u0020\u0066\u006fr\u006d\u0061\u0074\u0054\u0079\u0070\u0065\u003d\u0025\u0066",_gcda );switch _gcda {case 1.0:_efd ._age .GlyphNames =_gded ;case 2.0:_fbd :=int (_efd .ReadUShort ());_dff :=make ([]int ,_fbd );_efd ._age .GlyphNames =make ([]GlyphName ,_fbd );_ccdc :=-1;for _cegg :=0;_cegg < _fbd ;_cegg ++{_fdee :=int (_efd .ReadUShort ());_dff [_cegg ]=_fdee ;if _fdee <=0x7fff&&_fdee > _ccdc {_ccdc =_fdee ;};};var _fgd []GlyphName ;if _ccdc >=len (_gded ){_fgd =make ([]GlyphName ,_ccdc -len (_gded )+1);for _cbfb :=0;_cbfb < _ccdc -len (_gded )+1;_cbfb ++{_gef :=int (_efd .readByte ());_dfec ,_ddg :=_efd .ReadStr (_gef );if _ddg !=nil {return _ddg ;};_fgd [_cbfb ]=GlyphName (_dfec );};};for _efdf :=0;_efdf < _fbd ;_efdf ++{_ee :=_dff [_efdf ];if _ee < len (_gded ){_efd ._age .GlyphNames [_efdf ]=_gded [_ee ];}else if _ee >=len (_gded )&&_ee <=32767{_efd ._age .GlyphNames [_efdf ]=_fgd [_ee -len (_gded )];}else {_efd ._age .GlyphNames [_efdf ]="\u002e\u0075\u006e\u0064\u0065\u0066\u0069\u006e\u0065\u0064";};};case 2.5:_dbcc :=make ([]int ,_efd ._dfe );for _adac :=0;_adac < len (_dbcc );_adac ++{_fgdb :=int (_efd .ReadSByte ());_dbcc [_adac ]=_adac +1+_fgdb ;};_efd ._age .GlyphNames =make ([]GlyphName ,len (_dbcc ));for _ege :=0;_ege < len (_efd ._age .GlyphNames );_ege ++{_bgg :=_gded [_dbcc [_ege ]];_efd ._age .GlyphNames [_ege ]=_bgg ;};case 3.0:_gf .Log .Debug ("\u004e\u006f\u0020\u0050\u006f\u0073t\u0053\u0063\u0072i\u0070\u0074\u0020n\u0061\u006d\u0065\u0020\u0069\u006e\u0066\u006f\u0072\u006da\u0074\u0069\u006f\u006e\u0020is\u0020\u0070\u0072\u006f\u0076\u0069\u0064\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0074\u0068\u0065\u0020\u0066\u006f\u006e\u0074\u002e");default:_gf .Log .Debug ("\u0045\u0052\u0052\u004fR\u003a\u0020\u0055\u006e\u006b\u006e\u006f\u0077\u006e\u0020f\u006fr\u006d\u0061\u0074\u0054\u0079\u0070\u0065=\u0025\u0066",_gcda );};return nil ;};func RegisterStdFont (name StdFontName ,fnc func ()StdFont ,aliases ...StdFontName ){if _ ,_fgf :=_ec [name ];_fgf {panic ("\u0066o\u006e\u0074\u0020\u0061l\u0072\u0065\u0061\u0064\u0079 \u0072e\u0067i\u0073\u0074\u0065\u0072\u0065\u0064\u003a "+string (name ));};_ec [name ]=fnc ;for _ ,_c :=range aliases {RegisterStdFont (_c ,fnc );};};func (_fgcc *TtfType )String ()string {return _d .Sprintf ("\u0046\u004fN\u0054\u005f\u0046\u0049\u004cE\u0032\u007b\u0025\u0023\u0071 \u0055\u006e\u0069\u0074\u0073\u0050\u0065\u0072\u0045\u006d\u003d\u0025\u0064\u0020\u0042\u006f\u006c\u0064\u003d\u0025\u0074\u0020\u0049\u0074\u0061\u006c\u0069\u0063\u0041\u006e\u0067\u006c\u0065\u003d\u0025\u0066\u0020"+"\u0043\u0061pH\u0065\u0069\u0067h\u0074\u003d\u0025\u0064 Ch\u0061rs\u003d\u0025\u0064\u0020\u0047\u006c\u0079ph\u004e\u0061\u006d\u0065\u0073\u003d\u0025d\u007d",_fgcc .PostScriptName ,_fgcc .UnitsPerEm ,_fgcc .Bold ,_fgcc .ItalicAngle ,_fgcc .CapHeight ,len (_fgcc .Chars ),len (_fgcc .GlyphNames ));};var _ Font =StdFont {};var _ec =make (map[StdFontName ]func ()StdFont );var _fc =[]rune {'A','Æ','Á','Ă','Â','Ä','À','Ā','Ą','Å','Ã','B','C','Ć','Č','Ç','D','Ď','Đ','∆','E','É','Ě','Ê','Ë','Ė','È','Ē','Ę','Ð','€','F','G','Ğ','Ģ','H','I','Í','Î','Ï','İ','Ì','Ī','Į','J','K','Ķ','L','Ĺ','Ľ','Ļ','Ł','M','N','Ń','Ň','Ņ','Ñ','O','Œ','Ó','Ô','Ö','Ò','Ő','Ō','Ø','Õ','P','Q','R','Ŕ','Ř','Ŗ','S','Ś','Š','Ş','Ș','T','Ť','Ţ','Þ','U','Ú','Û','Ü','Ù','Ű','Ū','Ų','Ů','V','W','X','Y','Ý','Ÿ','Z','Ź','Ž','Ż','a','á','ă','â','´','ä','æ','à','ā','&','ą','å','^','~','*','@','ã','b','\\','|','{','}','[',']','˘','¦','•','c','ć','ˇ','č','ç','¸','¢','ˆ',':',',','\uf6c3','©','¤','d','†','‡','ď','đ','°','¨','÷','$','˙','ı','e','é','ě','ê','ë','ė','è','8','…','ē','—','–','ę','=','ð','!','¡','f','ﬁ','5','ﬂ','ƒ','4','⁄','g','ğ','ģ','ß','`','>','≥','«','»','‹','›','h','˝','-','i','í','î','ï','ì','ī','į','j','k','ķ','l','ĺ','ľ','ļ','<','≤','¬','◊','ł','m','¯','−','µ','×','n','ń','ň','ņ','9','≠','ñ','#','o','ó','ô','ö','œ','˛','ò','ő','ō','1','½','¼','¹','ª','º','ø','õ','p','¶','(',')','∂','%','.','·','‰','+','±','q','?','¿','"','„','“','”','‘','’','‚','\'','r','ŕ','√','ř','ŗ','®','˚','s','ś','š','ş','ș','§',';','7','6','/',' ','£','∑','t','ť','ţ','þ','3','¾','³','˜','™','2','²','u','ú','û','ü','ù','ű','ū','_','ų','ů','v','w','x','y','ý','ÿ','¥','z','ź','ž','ż','0'};func (_fe StdFont )Name ()string {return string (_fe ._acd .Name )};var _abf map[rune ]CharMetrics ;type GID =_ge .GID ;func (_daf *ttfParser )ParseCmap ()error {var _bae int64 ;if _eaf :=_daf .Seek ("\u0063\u006d\u0061\u0070");_eaf !=nil {return _eaf ;};_gf .Log .Trace ("\u0050a\u0072\u0073\u0065\u0043\u006d\u0061p");_daf .ReadUShort ();_ead :=int (_daf .ReadUShort ());_adf :=int64 (0);_dda :=int64 (0);_fgce :=int64 (0);for _fdc :=0;_fdc < _ead ;_fdc ++{_gcg :=_daf .ReadUShort ();_gae :=_daf .ReadUShort ();_bae =int64 (_daf .ReadULong ());if _gcg ==3&&_gae ==1{_dda =_bae ;}else if _gcg ==3&&_gae ==10{_fgce =_bae ;}else if _gcg ==1&&_gae ==0{_adf =_bae ;};};if _adf !=0{if _cbgd :=_daf .parseCmapVersion (_adf );_cbgd !=nil {return _cbgd ;};};if _dda !=0{if _egd :=_daf .parseCmapSubtable31 (_dda );_egd !=nil {return _egd ;};};if _fgce !=0{if _fagf :=_daf .parseCmapVersion (_fgce );_fagf !=nil {return _fagf ;};};if _dda ==0&&_adf ==0&&_fgce ==0{_gf .Log .Debug ("\u0074\u0074\u0066P\u0061\u0072\u0073\u0065\u0072\u002e\u0050\u0061\u0072\u0073\u0065\u0043\u006d\u0061\u0070\u002e\u0020\u004e\u006f\u0020\u0033\u0031\u002c\u0020\u0031\u0030\u002c\u0020\u00331\u0030\u0020\u0074\u0061\u0062\u006c\u0065\u002e");};return nil ;};func (_db StdFont )ToPdfObject ()_fb .PdfObject {_faf :=_fb .MakeDict ();_faf .Set ("\u0054\u0079\u0070\u0065",_fb .MakeName ("\u0046\u006f\u006e\u0074"));_faf .Set ("\u0053u\u0062\u0074\u0079\u0070\u0065",_fb .MakeName ("\u0054\u0079\u0070e\u0031"));_faf .Set ("\u0042\u0061\u0073\u0065\u0046\u006f\u006e\u0074",_fb .MakeName (_db .Name ()));_faf .Set ("\u0045\u006e\u0063\u006f\u0064\u0069\u006e\u0067",_db ._gfe .ToPdfObject ());return _fb .MakeIndirectObject (_faf );};type FontWeight int ;func (_fdb *ttfParser )readByte ()(_bcdc uint8 ){_g .Read (_fdb ._gbb ,_g .BigEndian ,&_bcdc );return _bcdc ;};func IsStdFont (name StdFontName )bool {_ ,_deg :=_ec [name ];return _deg };var _gded =[]GlyphName {"\u002en\u006f\u0074\u0064\u0065\u0066","\u002e\u006e\u0075l\u006c","\u006e\u006fn\u006d\u0061\u0072k\u0069\u006e\u0067\u0072\u0065\u0074\u0075\u0072\u006e","\u0073\u0070\u0061c\u0065","\u0065\u0078\u0063\u006c\u0061\u006d","\u0071\u0075\u006f\u0074\u0065\u0064\u0062\u006c","\u006e\u0075\u006d\u0062\u0065\u0072\u0073\u0069\u0067\u006e","\u0064\u006f\u006c\u006c\u0061\u0072","\u0070e\u0072\u0063\u0065\u006e\u0074","\u0061m\u0070\u0065\u0072\u0073\u0061\u006ed","q\u0075\u006f\u0074\u0065\u0073\u0069\u006e\u0067\u006c\u0065","\u0070a\u0072\u0065\u006e\u006c\u0065\u0066t","\u0070\u0061\u0072\u0065\u006e\u0072\u0069\u0067\u0068\u0074","\u0061\u0073\u0074\u0065\u0072\u0069\u0073\u006b","\u0070\u006c\u0075\u0073","\u0063\u006f\u006dm\u0061","\u0068\u0079\u0070\u0068\u0065\u006e","\u0070\u0065\u0072\u0069\u006f\u0064","\u0073\u006c\u0061s\u0068","\u007a\u0065\u0072\u006f","\u006f\u006e\u0065","\u0074\u0077\u006f","\u0074\u0068\u0072e\u0065","\u0066\u006f\u0075\u0072","\u0066\u0069\u0076\u0065","\u0073\u0069\u0078","\u0073\u0065\u0076e\u006e","\u0065\u0069\u0067h\u0074","\u006e\u0069\u006e\u0065","\u0063\u006f\u006co\u006e","\u0073e\u006d\u0069\u0063\u006f\u006c\u006fn","\u006c\u0065\u0073\u0073","\u0065\u0071\u0075a\u006c","\u0067r\u0065\u0061\u0074\u0065\u0072","\u0071\u0075\u0065\u0073\u0074\u0069\u006f\u006e","\u0061\u0074","\u0041","\u0042","\u0043","\u0044","\u0045","\u0046","\u0047","\u0048","\u0049","\u004a","\u004b","\u004c","\u004d","\u004e","\u004f","\u0050","\u0051","\u0052","\u0053","\u0054","\u0055","\u0056","\u0057","\u0058","\u0059","\u005a","b\u0072\u0061\u0063\u006b\u0065\u0074\u006c\u0065\u0066\u0074","\u0062a\u0063\u006b\u0073\u006c\u0061\u0073h","\u0062\u0072\u0061c\u006b\u0065\u0074\u0072\u0069\u0067\u0068\u0074","a\u0073\u0063\u0069\u0069\u0063\u0069\u0072\u0063\u0075\u006d","\u0075\u006e\u0064\u0065\u0072\u0073\u0063\u006f\u0072\u0065","\u0067\u0072\u0061v\u0065","\u0061","\u0062","\u0063","\u0064","\u0065","\u0066","\u0067","\u0068","\u0069","\u006a","\u006b","\u006c","\u006d","\u006e","\u006f","\u0070","\u0071","\u0072","\u0073","\u0074","\u0075","\u0076","\u0077","\u0078","\u0079","\u007a","\u0062r\u0061\u0063\u0065\u006c\u0065\u0066t","\u0062\u0061\u0072","\u0062\u0072\u0061\u0063\u0065\u0072\u0069\u0067\u0068\u0074","\u0061\u0073\u0063\u0069\u0069\u0074\u0069\u006c\u0064\u0065","\u0041d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0041\u0072\u0069n\u0067","\u0043\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0045\u0061\u0063\u0075\u0074\u0065","\u004e\u0074\u0069\u006c\u0064\u0065","\u004fd\u0069\u0065\u0072\u0065\u0073\u0069s","\u0055d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0061\u0061\u0063\u0075\u0074\u0065","\u0061\u0067\u0072\u0061\u0076\u0065","a\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0061d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0061\u0074\u0069\u006c\u0064\u0065","\u0061\u0072\u0069n\u0067","\u0063\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0065\u0061\u0063\u0075\u0074\u0065","\u0065\u0067\u0072\u0061\u0076\u0065","e\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0065d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0069\u0061\u0063\u0075\u0074\u0065","\u0069\u0067\u0072\u0061\u0076\u0065","i\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0069d\u0069\u0065\u0072\u0065\u0073\u0069s","\u006e\u0074\u0069\u006c\u0064\u0065","\u006f\u0061\u0063\u0075\u0074\u0065","\u006f\u0067\u0072\u0061\u0076\u0065","o\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u006fd\u0069\u0065\u0072\u0065\u0073\u0069s","\u006f\u0074\u0069\u006c\u0064\u0065","\u0075\u0061\u0063\u0075\u0074\u0065","\u0075\u0067\u0072\u0061\u0076\u0065","u\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0075d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0064\u0061\u0067\u0067\u0065\u0072","\u0064\u0065\u0067\u0072\u0065\u0065","\u0063\u0065\u006e\u0074","\u0073\u0074\u0065\u0072\u006c\u0069\u006e\u0067","\u0073e\u0063\u0074\u0069\u006f\u006e","\u0062\u0075\u006c\u006c\u0065\u0074","\u0070a\u0072\u0061\u0067\u0072\u0061\u0070h","\u0067\u0065\u0072\u006d\u0061\u006e\u0064\u0062\u006c\u0073","\u0072\u0065\u0067\u0069\u0073\u0074\u0065\u0072\u0065\u0064","\u0063o\u0070\u0079\u0072\u0069\u0067\u0068t","\u0074r\u0061\u0064\u0065\u006d\u0061\u0072k","\u0061\u0063\u0075t\u0065","\u0064\u0069\u0065\u0072\u0065\u0073\u0069\u0073","\u006e\u006f\u0074\u0065\u0071\u0075\u0061\u006c","\u0041\u0045","\u004f\u0073\u006c\u0061\u0073\u0068","\u0069\u006e\u0066\u0069\u006e\u0069\u0074\u0079","\u0070l\u0075\u0073\u006d\u0069\u006e\u0075s","\u006ce\u0073\u0073\u0065\u0071\u0075\u0061l","\u0067\u0072\u0065a\u0074\u0065\u0072\u0065\u0071\u0075\u0061\u006c","\u0079\u0065\u006e","\u006d\u0075","p\u0061\u0072\u0074\u0069\u0061\u006c\u0064\u0069\u0066\u0066","\u0073u\u006d\u006d\u0061\u0074\u0069\u006fn","\u0070r\u006f\u0064\u0075\u0063\u0074","\u0070\u0069","\u0069\u006e\u0074\u0065\u0067\u0072\u0061\u006c","o\u0072\u0064\u0066\u0065\u006d\u0069\u006e\u0069\u006e\u0065","\u006f\u0072\u0064m\u0061\u0073\u0063\u0075\u006c\u0069\u006e\u0065","\u004f\u006d\u0065g\u0061","\u0061\u0065","\u006f\u0073\u006c\u0061\u0073\u0068","\u0071\u0075\u0065s\u0074\u0069\u006f\u006e\u0064\u006f\u0077\u006e","\u0065\u0078\u0063\u006c\u0061\u006d\u0064\u006f\u0077\u006e","\u006c\u006f\u0067\u0069\u0063\u0061\u006c\u006e\u006f\u0074","\u0072a\u0064\u0069\u0063\u0061\u006c","\u0066\u006c\u006f\u0072\u0069\u006e","a\u0070\u0070\u0072\u006f\u0078\u0065\u0071\u0075\u0061\u006c","\u0044\u0065\u006ct\u0061","\u0067\u0075\u0069\u006c\u006c\u0065\u006d\u006f\u0074\u006c\u0065\u0066\u0074","\u0067\u0075\u0069\u006c\u006c\u0065\u006d\u006f\u0074r\u0069\u0067\u0068\u0074","\u0065\u006c\u006c\u0069\u0070\u0073\u0069\u0073","\u006e\u006fn\u0062\u0072\u0065a\u006b\u0069\u006e\u0067\u0073\u0070\u0061\u0063\u0065","\u0041\u0067\u0072\u0061\u0076\u0065","\u0041\u0074\u0069\u006c\u0064\u0065","\u004f\u0074\u0069\u006c\u0064\u0065","\u004f\u0045","\u006f\u0065","\u0065\u006e\u0064\u0061\u0073\u0068","\u0065\u006d\u0064\u0061\u0073\u0068","\u0071\u0075\u006ft\u0065\u0064\u0062\u006c\u006c\u0065\u0066\u0074","\u0071\u0075\u006f\u0074\u0065\u0064\u0062\u006c\u0072\u0069\u0067\u0068\u0074","\u0071u\u006f\u0074\u0065\u006c\u0065\u0066t","\u0071\u0075\u006f\u0074\u0065\u0072\u0069\u0067\u0068\u0074","\u0064\u0069\u0076\u0069\u0064\u0065","\u006co\u007a\u0065\u006e\u0067\u0065","\u0079d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0059d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0066\u0072\u0061\u0063\u0074\u0069\u006f\u006e","\u0063\u0075\u0072\u0072\u0065\u006e\u0063\u0079","\u0067\u0075\u0069\u006c\u0073\u0069\u006e\u0067\u006c\u006c\u0065\u0066\u0074","\u0067\u0075\u0069\u006c\u0073\u0069\u006e\u0067\u006cr\u0069\u0067\u0068\u0074","\u0066\u0069","\u0066\u006c","\u0064a\u0067\u0067\u0065\u0072\u0064\u0062l","\u0070\u0065\u0072\u0069\u006f\u0064\u0063\u0065\u006et\u0065\u0072\u0065\u0064","\u0071\u0075\u006f\u0074\u0065\u0073\u0069\u006e\u0067l\u0062\u0061\u0073\u0065","\u0071\u0075\u006ft\u0065\u0064\u0062\u006c\u0062\u0061\u0073\u0065","p\u0065\u0072\u0074\u0068\u006f\u0075\u0073\u0061\u006e\u0064","A\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","E\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0041\u0061\u0063\u0075\u0074\u0065","\u0045d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0045\u0067\u0072\u0061\u0076\u0065","\u0049\u0061\u0063\u0075\u0074\u0065","I\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0049d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0049\u0067\u0072\u0061\u0076\u0065","\u004f\u0061\u0063\u0075\u0074\u0065","O\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0061\u0070\u0070l\u0065","\u004f\u0067\u0072\u0061\u0076\u0065","\u0055\u0061\u0063\u0075\u0074\u0065","U\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0055\u0067\u0072\u0061\u0076\u0065","\u0064\u006f\u0074\u006c\u0065\u0073\u0073\u0069","\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0074\u0069\u006cd\u0065","\u006d\u0061\u0063\u0072\u006f\u006e","\u0062\u0072\u0065v\u0065","\u0064o\u0074\u0061\u0063\u0063\u0065\u006et","\u0072\u0069\u006e\u0067","\u0063e\u0064\u0069\u006c\u006c\u0061","\u0068\u0075\u006eg\u0061\u0072\u0075\u006d\u006c\u0061\u0075\u0074","\u006f\u0067\u006f\u006e\u0065\u006b","\u0063\u0061\u0072o\u006e","\u004c\u0073\u006c\u0061\u0073\u0068","\u006c\u0073\u006c\u0061\u0073\u0068","\u0053\u0063\u0061\u0072\u006f\u006e","\u0073\u0063\u0061\u0072\u006f\u006e","\u005a\u0063\u0061\u0072\u006f\u006e","\u007a\u0063\u0061\u0072\u006f\u006e","\u0062r\u006f\u006b\u0065\u006e\u0062\u0061r","\u0045\u0074\u0068","\u0065\u0074\u0068","\u0059\u0061\u0063\u0075\u0074\u0065","\u0079\u0061\u0063\u0075\u0074\u0065","\u0054\u0068\u006fr\u006e","\u0074\u0068\u006fr\u006e","\u006d\u0069\u006eu\u0073","\u006d\u0075\u006c\u0074\u0069\u0070\u006c\u0079","o\u006e\u0065\u0073\u0075\u0070\u0065\u0072\u0069\u006f\u0072","t\u0077\u006f\u0073\u0075\u0070\u0065\u0072\u0069\u006f\u0072","\u0074\u0068\u0072\u0065\u0065\u0073\u0075\u0070\u0065\u0072\u0069\u006f\u0072","\u006fn\u0065\u0068\u0061\u006c\u0066","\u006f\u006e\u0065\u0071\u0075\u0061\u0072\u0074\u0065\u0072","\u0074\u0068\u0072\u0065\u0065\u0071\u0075\u0061\u0072\u0074\u0065\u0072\u0073","\u0066\u0072\u0061n\u0063","\u0047\u0062\u0072\u0065\u0076\u0065","\u0067\u0062\u0072\u0065\u0076\u0065","\u0049\u0064\u006f\u0074\u0061\u0063\u0063\u0065\u006e\u0074","\u0053\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0073\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0043\u0061\u0063\u0075\u0074\u0065","\u0063\u0061\u0063\u0075\u0074\u0065","\u0043\u0063\u0061\u0072\u006f\u006e","\u0063\u0063\u0061\u0072\u006f\u006e","\u0064\u0063\u0072\u006f\u0061\u0074"};var _ce map[rune ]CharMetrics ;func (_bdgd *ttfParser )Seek (tag string )error {_dca ,_dfd :=_bdgd ._cba [tag ];if !_dfd {return _d .Errorf ("\u0074\u0061\u0062\u006ce \u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u003a\u0020\u0025\u0073",tag );};_bdgd ._gbb .Seek (int64 (_dca ),_f .SeekStart );return nil ;};type Font interface{Encoder ()_ge .TextEncoder ;GetRuneMetrics (_be rune )(CharMetrics ,bool );};
\ No newline at end of file
+package fonts ;import (_e "bytes";_g "encoding/binary";_fg "errors";_d "fmt";_gf "github.com/unidoc/unipdf/v3/common";_fb "github.com/unidoc/unipdf/v3/core";_gg "github.com/unidoc/unipdf/v3/internal/cmap";_ge "github.com/unidoc/unipdf/v3/internal/textencoding";_de "golang.org/x/xerrors";_f "io";_ac "os";_b "regexp";_dg "sort";_df "strings";_ga "sync";);type Descriptor struct{Name StdFontName ;Family string ;Weight FontWeight ;Flags uint ;BBox [4]float64 ;ItalicAngle float64 ;Ascent float64 ;Descent float64 ;CapHeight float64 ;XHeight float64 ;StemV float64 ;StemH float64 ;};func (_bdg *ttfParser )parseCmapSubtable31 (_bcdb int64 )error {_afed :=make ([]rune ,0,8);_cab :=make ([]rune ,0,8);_bcb :=make ([]int16 ,0,8);_ece :=make ([]uint16 ,0,8);_bdg ._age .Chars =make (map[rune ]GID );_bdg ._gbb .Seek (int64 (_bdg ._cba ["\u0063\u006d\u0061\u0070"])+_bcdb ,_f .SeekStart );_cbg :=_bdg .ReadUShort ();if _cbg !=4{return _de .Errorf ("u\u006e\u0065\u0078\u0070\u0065\u0063t\u0065\u0064\u0020\u0073\u0075\u0062t\u0061\u0062\u006c\u0065\u0020\u0066\u006fr\u006d\u0061\u0074\u003a\u0020\u0025\u0064\u0020\u0028\u0025w\u0029",_cbg ,_fb .ErrNotSupported );};_bdg .Skip (2*2);_efe :=int (_bdg .ReadUShort ()/2);_bdg .Skip (3*2);for _bdge :=0;_bdge < _efe ;_bdge ++{_cab =append (_cab ,rune (_bdg .ReadUShort ()));};_bdg .Skip (2);for _ffd :=0;_ffd < _efe ;_ffd ++{_afed =append (_afed ,rune (_bdg .ReadUShort ()));};for _fad :=0;_fad < _efe ;_fad ++{_bcb =append (_bcb ,_bdg .ReadShort ());};_acdd ,_ :=_bdg ._gbb .Seek (int64 (0),_f .SeekCurrent );for _ecef :=0;_ecef < _efe ;_ecef ++{_ece =append (_ece ,_bdg .ReadUShort ());};for _bdd :=0;_bdd < _efe ;_bdd ++{_ecd :=_afed [_bdd ];_aea :=_cab [_bdd ];_fagg :=_bcb [_bdd ];_afc :=_ece [_bdd ];if _afc > 0{_bdg ._gbb .Seek (_acdd +2*int64 (_bdd )+int64 (_afc ),_f .SeekStart );};for _gcaa :=_ecd ;_gcaa <=_aea ;_gcaa ++{if _gcaa ==0xFFFF{break ;};var _agb int32 ;if _afc > 0{_agb =int32 (_bdg .ReadUShort ());if _agb > 0{_agb +=int32 (_fagg );};}else {_agb =_gcaa +int32 (_fagg );};if _agb >=65536{_agb -=65536;};if _agb > 0{_bdg ._age .Chars [_gcaa ]=GID (_agb );};};};return nil ;};func (_bd StdFont )GetMetricsTable ()map[rune ]CharMetrics {return _bd ._fa };func (_fac *ttfParser )Read32Fixed ()float64 {_dba :=float64 (_fac .ReadShort ());_gcc :=float64 (_fac .ReadUShort ())/65536.0;return _dba +_gcc ;};var _gdf =[]int16 {722,889,722,722,722,722,722,722,722,722,722,667,667,667,667,667,722,722,722,612,611,611,611,611,611,611,611,611,611,722,500,556,722,722,722,722,333,333,333,333,333,333,333,333,389,722,722,611,611,611,611,611,889,722,722,722,722,722,722,889,722,722,722,722,722,722,722,722,556,722,667,667,667,667,556,556,556,556,556,611,611,611,556,722,722,722,722,722,722,722,722,722,722,944,722,722,722,722,611,611,611,611,444,444,444,444,333,444,667,444,444,778,444,444,469,541,500,921,444,500,278,200,480,480,333,333,333,200,350,444,444,333,444,444,333,500,333,278,250,250,760,500,500,500,500,588,500,400,333,564,500,333,278,444,444,444,444,444,444,444,500,1000,444,1000,500,444,564,500,333,333,333,556,500,556,500,500,167,500,500,500,500,333,564,549,500,500,333,333,500,333,333,278,278,278,278,278,278,278,278,500,500,278,278,344,278,564,549,564,471,278,778,333,564,500,564,500,500,500,500,500,549,500,500,500,500,500,500,722,333,500,500,500,500,750,750,300,276,310,500,500,500,453,333,333,476,833,250,250,1000,564,564,500,444,444,408,444,444,444,333,333,333,180,333,333,453,333,333,760,333,389,389,389,389,389,500,278,500,500,278,250,500,600,278,326,278,500,500,750,300,333,980,500,300,500,500,500,500,500,500,500,500,500,500,500,722,500,500,500,500,500,444,444,444,444,500};var _ebfa map[rune ]CharMetrics ;func _ed ()StdFont {_fbf .Do (_deb );_fea :=Descriptor {Name :CourierName ,Family :string (CourierName ),Weight :FontWeightMedium ,Flags :0x0021,BBox :[4]float64 {-23,-250,715,805},ItalicAngle :0,Ascent :629,Descent :-157,CapHeight :562,XHeight :426,StemV :51,StemH :51};return NewStdFont (_fea ,_fag );};func (_edeaa *ttfParser )parseCmapSubtable10 (_gdbg int64 )error {if _edeaa ._age .Chars ==nil {_edeaa ._age .Chars =make (map[rune ]GID );};_edeaa ._gbb .Seek (int64 (_edeaa ._cba ["\u0063\u006d\u0061\u0070"])+_gdbg ,_f .SeekStart );var _bad ,_gge uint32 ;_fgge :=_edeaa .ReadUShort ();if _fgge < 8{_bad =uint32 (_edeaa .ReadUShort ());_gge =uint32 (_edeaa .ReadUShort ());}else {_edeaa .ReadUShort ();_bad =_edeaa .ReadULong ();_gge =_edeaa .ReadULong ();};_gf .Log .Trace ("\u0070\u0061r\u0073\u0065\u0043\u006d\u0061p\u0053\u0075\u0062\u0074\u0061b\u006c\u0065\u0031\u0030\u003a\u0020\u0066\u006f\u0072\u006d\u0061\u0074\u003d\u0025\u0064\u0020\u006c\u0065\u006e\u0067\u0074\u0068\u003d\u0025\u0064\u0020\u006c\u0061\u006e\u0067\u0075\u0061\u0067\u0065\u003d\u0025\u0064",_fgge ,_bad ,_gge );if _fgge !=0{return _fg .New ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0063\u006d\u0061p\u0020s\u0075\u0062\u0074\u0061\u0062\u006c\u0065\u0020\u0066\u006f\u0072\u006d\u0061\u0074");};_efgd ,_dfeb :=_edeaa .ReadStr (256);if _dfeb !=nil {return _dfeb ;};_dbg :=[]byte (_efgd );for _egb ,_afg :=range _dbg {_edeaa ._age .Chars [rune (_egb )]=GID (_afg );if _afg !=0{_d .Printf ("\u0009\u0030\u0078\u002502\u0078\u0020\u279e\u0020\u0030\u0078\u0025\u0030\u0032\u0078\u003d\u0025\u0063\u000a",_egb ,_afg ,rune (_afg ));};};return nil ;};func (_eb StdFont )GetRuneMetrics (r rune )(CharMetrics ,bool ){_ecc ,_da :=_eb ._fa [r ];return _ecc ,_da ;};func _cf ()StdFont {_fbf .Do (_deb );_gfa :=Descriptor {Name :CourierObliqueName ,Family :string (CourierName ),Weight :FontWeightMedium ,Flags :0x0061,BBox :[4]float64 {-27,-250,849,805},ItalicAngle :-12,Ascent :629,Descent :-157,CapHeight :562,XHeight :426,StemV :51,StemH :51};return NewStdFont (_gfa ,_cd );};func (_deab *ttfParser )ReadShort ()(_ebde int16 ){_g .Read (_deab ._gbb ,_g .BigEndian ,&_ebde );return _ebde ;};func _gb ()StdFont {_gce :=_ge .NewZapfDingbatsEncoder ();_cbf :=Descriptor {Name :ZapfDingbatsName ,Family :string (ZapfDingbatsName ),Weight :FontWeightMedium ,Flags :0x0004,BBox :[4]float64 {-1,-143,981,820},ItalicAngle :0,Ascent :0,Descent :0,CapHeight :0,XHeight :0,StemV :90,StemH :28};return NewStdFontWithEncoding (_cbf ,_dfb ,_gce );};const (SymbolName =StdFontName ("\u0053\u0079\u006d\u0062\u006f\u006c");ZapfDingbatsName =StdFontName ("\u005a\u0061\u0070f\u0044\u0069\u006e\u0067\u0062\u0061\u0074\u0073"););var _fag map[rune ]CharMetrics ;type StdFont struct{_acd Descriptor ;_fa map[rune ]CharMetrics ;_gfe _ge .TextEncoder ;};func NewFontFile2FromPdfObject (obj _fb .PdfObject )(TtfType ,error ){obj =_fb .TraceToDirectObject (obj );_ecf ,_bb :=obj .(*_fb .PdfObjectStream );if !_bb {_gf .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0046\u006f\u006e\u0074\u0046\u0069\u006c\u0065\u0032\u0020\u006d\u0075\u0073\u0074\u0020\u0062\u0065 \u0061\u0020\u0073\u0074\u0072e\u0061\u006d \u0028\u0025\u0054\u0029",obj );return TtfType {},_fb .ErrTypeError ;};_effe ,_fga :=_fb .DecodeStream (_ecf );if _fga !=nil {return TtfType {},_fga ;};_eda :=ttfParser {_gbb :_e .NewReader (_effe )};return _eda .Parse ();};func TtfParse (r _f .ReadSeeker )(TtfType ,error ){_eag :=&ttfParser {_gbb :r };return _eag .Parse ()};var _gca _ga .Once ;func _bac ()StdFont {_fbf .Do (_deb );_ag :=Descriptor {Name :CourierBoldName ,Family :string (CourierName ),Weight :FontWeightBold ,Flags :0x0021,BBox :[4]float64 {-113,-250,749,801},ItalicAngle :0,Ascent :629,Descent :-157,CapHeight :562,XHeight :439,StemV :106,StemH :84};return NewStdFont (_ag ,_bc );};var _bdf _ga .Once ;var _bcd =[]int16 {667,1000,667,667,667,667,667,667,667,667,667,667,722,722,722,722,722,722,722,612,667,667,667,667,667,667,667,667,667,722,556,611,778,778,778,722,278,278,278,278,278,278,278,278,500,667,667,556,556,556,556,556,833,722,722,722,722,722,778,1000,778,778,778,778,778,778,778,778,667,778,722,722,722,722,667,667,667,667,667,611,611,611,667,722,722,722,722,722,722,722,722,722,667,944,667,667,667,667,611,611,611,611,556,556,556,556,333,556,889,556,556,667,556,556,469,584,389,1015,556,556,278,260,334,334,278,278,333,260,350,500,500,333,500,500,333,556,333,278,278,250,737,556,556,556,556,643,556,400,333,584,556,333,278,556,556,556,556,556,556,556,556,1000,556,1000,556,556,584,556,278,333,278,500,556,500,556,556,167,556,556,556,611,333,584,549,556,556,333,333,556,333,333,222,278,278,278,278,278,222,222,500,500,222,222,299,222,584,549,584,471,222,833,333,584,556,584,556,556,556,556,556,549,556,556,556,556,556,556,944,333,556,556,556,556,834,834,333,370,365,611,556,556,537,333,333,476,889,278,278,1000,584,584,556,556,611,355,333,333,333,222,222,222,191,333,333,453,333,333,737,333,500,500,500,500,500,556,278,556,556,278,278,556,600,278,317,278,556,556,834,333,333,1000,556,333,556,556,556,556,556,556,556,556,556,556,500,722,500,500,500,500,556,500,500,500,500,556};func _ffa ()StdFont {_bdf .Do (_ea );_dga :=Descriptor {Name :HelveticaBoldObliqueName ,Family :string (HelveticaName ),Weight :FontWeightBold ,Flags :0x0060,BBox :[4]float64 {-174,-228,1114,962},ItalicAngle :-12,Ascent :718,Descent :-207,CapHeight :718,XHeight :532,StemV :140,StemH :118};return NewStdFont (_dga ,_ebfa );};func init (){RegisterStdFont (CourierName ,_ed ,"\u0043\u006f\u0075\u0072\u0069\u0065\u0072\u0043\u006f\u0075\u0072\u0069e\u0072\u004e\u0065\u0077","\u0043\u006f\u0075\u0072\u0069\u0065\u0072\u004e\u0065\u0077");RegisterStdFont (CourierBoldName ,_bac ,"\u0043o\u0075r\u0069\u0065\u0072\u004e\u0065\u0077\u002c\u0042\u006f\u006c\u0064");RegisterStdFont (CourierObliqueName ,_cf ,"\u0043\u006f\u0075\u0072\u0069\u0065\u0072\u004e\u0065\u0077\u002c\u0049t\u0061\u006c\u0069\u0063");RegisterStdFont (CourierBoldObliqueName ,_geb ,"C\u006f\u0075\u0072\u0069er\u004ee\u0077\u002c\u0042\u006f\u006cd\u0049\u0074\u0061\u006c\u0069\u0063");};var _beg =[]int16 {722,1000,722,722,722,722,722,722,722,722,722,667,722,722,722,722,722,722,722,612,667,667,667,667,667,667,667,667,667,722,500,611,778,778,778,778,389,389,389,389,389,389,389,389,500,778,778,667,667,667,667,667,944,722,722,722,722,722,778,1000,778,778,778,778,778,778,778,778,611,778,722,722,722,722,556,556,556,556,556,667,667,667,611,722,722,722,722,722,722,722,722,722,722,1000,722,722,722,722,667,667,667,667,500,500,500,500,333,500,722,500,500,833,500,500,581,520,500,930,500,556,278,220,394,394,333,333,333,220,350,444,444,333,444,444,333,500,333,333,250,250,747,500,556,500,500,672,556,400,333,570,500,333,278,444,444,444,444,444,444,444,500,1000,444,1000,500,444,570,500,333,333,333,556,500,556,500,500,167,500,500,500,556,333,570,549,500,500,333,333,556,333,333,278,278,278,278,278,278,278,333,556,556,278,278,394,278,570,549,570,494,278,833,333,570,556,570,556,556,556,556,500,549,556,500,500,500,500,500,722,333,500,500,500,500,750,750,300,300,330,500,500,556,540,333,333,494,1000,250,250,1000,570,570,556,500,500,555,500,500,500,333,333,333,278,444,444,549,444,444,747,333,389,389,389,389,389,500,333,500,500,278,250,500,600,333,416,333,556,500,750,300,333,1000,500,300,556,556,556,556,556,556,556,500,556,556,500,722,500,500,500,500,500,444,444,444,444,500};var _eff map[rune ]CharMetrics ;func TtfParseFile (fileStr string )(TtfType ,error ){_dgeg ,_eddf :=_ac .Open (fileStr );if _eddf !=nil {return TtfType {},_eddf ;};defer _dgeg .Close ();return TtfParse (_dgeg );};var _ddc =[]int16 {667,944,667,667,667,667,667,667,667,667,667,667,667,667,667,667,722,722,722,612,667,667,667,667,667,667,667,667,667,722,500,667,722,722,722,778,389,389,389,389,389,389,389,389,500,667,667,611,611,611,611,611,889,722,722,722,722,722,722,944,722,722,722,722,722,722,722,722,611,722,667,667,667,667,556,556,556,556,556,611,611,611,611,722,722,722,722,722,722,722,722,722,667,889,667,611,611,611,611,611,611,611,500,500,500,500,333,500,722,500,500,778,500,500,570,570,500,832,500,500,278,220,348,348,333,333,333,220,350,444,444,333,444,444,333,500,333,333,250,250,747,500,500,500,500,608,500,400,333,570,500,333,278,444,444,444,444,444,444,444,500,1000,444,1000,500,444,570,500,389,389,333,556,500,556,500,500,167,500,500,500,500,333,570,549,500,500,333,333,556,333,333,278,278,278,278,278,278,278,278,500,500,278,278,382,278,570,549,606,494,278,778,333,606,576,570,556,556,556,556,500,549,556,500,500,500,500,500,722,333,500,500,500,500,750,750,300,266,300,500,500,500,500,333,333,494,833,250,250,1000,570,570,500,500,500,555,500,500,500,333,333,333,278,389,389,549,389,389,747,333,389,389,389,389,389,500,333,500,500,278,250,500,600,278,366,278,500,500,750,300,333,1000,500,300,556,556,556,556,556,556,556,500,556,556,444,667,500,444,444,444,500,389,389,389,389,500};type StdFontName string ;func _gcd ()StdFont {_gca .Do (_cg );_efa :=Descriptor {Name :TimesRomanName ,Family :_ccf ,Weight :FontWeightRoman ,Flags :0x0020,BBox :[4]float64 {-168,-218,1000,898},ItalicAngle :0,Ascent :683,Descent :-217,CapHeight :662,XHeight :450,StemV :84,StemH :28};return NewStdFont (_efa ,_cgd );};func _bga ()StdFont {_bdf .Do (_ea );_gee :=Descriptor {Name :HelveticaName ,Family :string (HelveticaName ),Weight :FontWeightMedium ,Flags :0x0020,BBox :[4]float64 {-166,-225,1000,931},ItalicAngle :0,Ascent :718,Descent :-207,CapHeight :718,XHeight :523,StemV :88,StemH :76};return NewStdFont (_gee ,_af );};const (HelveticaName =StdFontName ("\u0048e\u006c\u0076\u0065\u0074\u0069\u0063a");HelveticaBoldName =StdFontName ("\u0048\u0065\u006c\u0076\u0065\u0074\u0069\u0063\u0061-\u0042\u006f\u006c\u0064");HelveticaObliqueName =StdFontName ("\u0048\u0065\u006c\u0076\u0065\u0074\u0069\u0063\u0061\u002d\u004f\u0062l\u0069\u0071\u0075\u0065");HelveticaBoldObliqueName =StdFontName ("H\u0065\u006c\u0076\u0065ti\u0063a\u002d\u0042\u006f\u006c\u0064O\u0062\u006c\u0069\u0071\u0075\u0065"););type GlyphName =_ge .GlyphName ;func _dgc ()StdFont {_gca .Do (_cg );_dd :=Descriptor {Name :TimesBoldItalicName ,Family :_ccf ,Weight :FontWeightBold ,Flags :0x0060,BBox :[4]float64 {-200,-218,996,921},ItalicAngle :-15,Ascent :683,Descent :-217,CapHeight :669,XHeight :462,StemV :121,StemH :42};return NewStdFont (_dd ,_fcf );};func _dgd ()StdFont {_bdf .Do (_ea );_gc :=Descriptor {Name :HelveticaBoldName ,Family :string (HelveticaName ),Weight :FontWeightBold ,Flags :0x0020,BBox :[4]float64 {-170,-228,1003,962},ItalicAngle :0,Ascent :718,Descent :-207,CapHeight :718,XHeight :532,StemV :140,StemH :118};return NewStdFont (_gc ,_ce );};func init (){RegisterStdFont (HelveticaName ,_bga ,"\u0041\u0072\u0069a\u006c");RegisterStdFont (HelveticaBoldName ,_dgd ,"\u0041\u0072\u0069\u0061\u006c\u002c\u0042\u006f\u006c\u0064");RegisterStdFont (HelveticaObliqueName ,_abc ,"\u0041\u0072\u0069a\u006c\u002c\u0049\u0074\u0061\u006c\u0069\u0063");RegisterStdFont (HelveticaBoldObliqueName ,_ffa ,"\u0041\u0072i\u0061\u006c\u002cB\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063");};func (_gfag *ttfParser )parseCmapVersion (_dgcd int64 )error {_gf .Log .Trace ("p\u0061\u0072\u0073\u0065\u0043\u006da\u0070\u0056\u0065\u0072\u0073\u0069\u006f\u006e\u003a \u006f\u0066\u0066s\u0065t\u003d\u0025\u0064",_dgcd );if _gfag ._age .Chars ==nil {_gfag ._age .Chars =make (map[rune ]GID );};_gfag ._gbb .Seek (int64 (_gfag ._cba ["\u0063\u006d\u0061\u0070"])+_dgcd ,_f .SeekStart );var _afcg ,_bef uint32 ;_ccdb :=_gfag .ReadUShort ();if _ccdb < 8{_afcg =uint32 (_gfag .ReadUShort ());_bef =uint32 (_gfag .ReadUShort ());}else {_gfag .ReadUShort ();_afcg =_gfag .ReadULong ();_bef =_gfag .ReadULong ();};_gf .Log .Debug ("\u0070\u0061\u0072\u0073\u0065\u0043m\u0061\u0070\u0056\u0065\u0072\u0073\u0069\u006f\u006e\u003a\u0020\u0066\u006f\u0072\u006d\u0061\u0074\u003d\u0025\u0064 \u006c\u0065\u006e\u0067\u0074\u0068\u003d\u0025\u0064\u0020\u006c\u0061\u006e\u0067u\u0061g\u0065\u003d\u0025\u0064",_ccdb ,_afcg ,_bef );switch _ccdb {case 0:return _gfag .parseCmapFormat0 ();case 6:return _gfag .parseCmapFormat6 ();case 12:return _gfag .parseCmapFormat12 ();default:_gf .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0055\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0063m\u0061\u0070\u0020\u0066\u006f\u0072\u006da\u0074\u003d\u0025\u0064",_ccdb );return nil ;};};func _deb (){const _gag =600;_fag =make (map[rune ]CharMetrics ,len (_fc ));for _ ,_cfe :=range _fc {_fag [_cfe ]=CharMetrics {Wx :_gag };};_bc =_fag ;_bec =_fag ;_cd =_fag ;};var _fbf _ga .Once ;func (_bea *ttfParser )ParseOS2 ()error {if _edba :=_bea .Seek ("\u004f\u0053\u002f\u0032");_edba !=nil {return _edba ;};_bbg :=_bea .ReadUShort ();_bea .Skip (4*2);_bea .Skip (11*2+10+4*4+4);_deac :=_bea .ReadUShort ();_bea ._age .Bold =(_deac &32)!=0;_bea .Skip (2*2);_bea ._age .TypoAscender =_bea .ReadShort ();_bea ._age .TypoDescender =_bea .ReadShort ();if _bbg >=2{_bea .Skip (3*2+2*4+2);_bea ._age .CapHeight =_bea .ReadShort ();}else {_bea ._age .CapHeight =0;};return nil ;};func (_dc StdFont )Encoder ()_ge .TextEncoder {return _dc ._gfe };func (_adg *ttfParser )ParseHmtx ()error {if _efbb :=_adg .Seek ("\u0068\u006d\u0074\u0078");_efbb !=nil {return _efbb ;};_adg ._age .Widths =make ([]uint16 ,0,8);for _gdfb :=uint16 (0);_gdfb < _adg ._cfd ;_gdfb ++{_adg ._age .Widths =append (_adg ._age .Widths ,_adg .ReadUShort ());_adg .Skip (2);};if _adg ._cfd < _adg ._dfe &&_adg ._cfd > 0{_dbbb :=_adg ._age .Widths [_adg ._cfd -1];for _fce :=_adg ._cfd ;_fce < _adg ._dfe ;_fce ++{_adg ._age .Widths =append (_adg ._age .Widths ,_dbbb );};};return nil ;};func (_gea *ttfParser )parseCmapFormat6 ()error {_dcc :=int (_gea .ReadUShort ());_dgca :=int (_gea .ReadUShort ());_gf .Log .Trace ("p\u0061\u0072\u0073\u0065\u0043\u006d\u0061\u0070\u0046o\u0072\u006d\u0061\u0074\u0036\u003a\u0020%s\u0020\u0066\u0069\u0072s\u0074\u0043\u006f\u0064\u0065\u003d\u0025\u0064\u0020en\u0074\u0072y\u0043\u006f\u0075\u006e\u0074\u003d\u0025\u0064",_gea ._age .String (),_dcc ,_dgca );for _bcgd :=0;_bcgd < _dgca ;_bcgd ++{_ceb :=GID (_gea .ReadUShort ());_gea ._age .Chars [rune (_bcgd +_dcc )]=_ceb ;};return nil ;};func (_ae StdFont )Descriptor ()Descriptor {return _ae ._acd };func _bgb ()StdFont {_gca .Do (_cg );_gd :=Descriptor {Name :TimesBoldName ,Family :_ccf ,Weight :FontWeightBold ,Flags :0x0020,BBox :[4]float64 {-168,-218,1000,935},ItalicAngle :0,Ascent :683,Descent :-217,CapHeight :676,XHeight :461,StemV :139,StemH :44};return NewStdFont (_gd ,_abf );};func _gbee (_cge map[string ]uint32 )string {var _efb []string ;for _fae :=range _cge {_efb =append (_efb ,_fae );};_dg .Slice (_efb ,func (_ede ,_agg int )bool {return _cge [_efb [_ede ]]< _cge [_efb [_agg ]]});_ebe :=[]string {_d .Sprintf ("\u0054\u0072\u0075\u0065Ty\u0070\u0065\u0020\u0074\u0061\u0062\u006c\u0065\u0073\u003a\u0020\u0025\u0064",len (_cge ))};for _ ,_ccb :=range _efb {_ebe =append (_ebe ,_d .Sprintf ("\u0009%\u0071\u0020\u0025\u0035\u0064",_ccb ,_cge [_ccb ]));};return _df .Join (_ebe ,"\u000a");};var _cd map[rune ]CharMetrics ;func _cg (){_cgd =make (map[rune ]CharMetrics ,len (_fc ));_abf =make (map[rune ]CharMetrics ,len (_fc ));_fcf =make (map[rune ]CharMetrics ,len (_fc ));_gebb =make (map[rune ]CharMetrics ,len (_fc ));for _dbf ,_feb :=range _fc {_cgd [_feb ]=CharMetrics {Wx :float64 (_gdf [_dbf ])};_abf [_feb ]=CharMetrics {Wx :float64 (_beg [_dbf ])};_fcf [_feb ]=CharMetrics {Wx :float64 (_ddc [_dbf ])};_gebb [_feb ]=CharMetrics {Wx :float64 (_ebb [_dbf ])};};};var _cdf =[]int16 {722,1000,722,722,722,722,722,722,722,722,722,722,722,722,722,722,722,722,722,612,667,667,667,667,667,667,667,667,667,722,556,611,778,778,778,722,278,278,278,278,278,278,278,278,556,722,722,611,611,611,611,611,833,722,722,722,722,722,778,1000,778,778,778,778,778,778,778,778,667,778,722,722,722,722,667,667,667,667,667,611,611,611,667,722,722,722,722,722,722,722,722,722,667,944,667,667,667,667,611,611,611,611,556,556,556,556,333,556,889,556,556,722,556,556,584,584,389,975,556,611,278,280,389,389,333,333,333,280,350,556,556,333,556,556,333,556,333,333,278,250,737,556,611,556,556,743,611,400,333,584,556,333,278,556,556,556,556,556,556,556,556,1000,556,1000,556,556,584,611,333,333,333,611,556,611,556,556,167,611,611,611,611,333,584,549,556,556,333,333,611,333,333,278,278,278,278,278,278,278,278,556,556,278,278,400,278,584,549,584,494,278,889,333,584,611,584,611,611,611,611,556,549,611,556,611,611,611,611,944,333,611,611,611,556,834,834,333,370,365,611,611,611,556,333,333,494,889,278,278,1000,584,584,611,611,611,474,500,500,500,278,278,278,238,389,389,549,389,389,737,333,556,556,556,556,556,556,333,556,556,278,278,556,600,333,389,333,611,556,834,333,333,1000,556,333,611,611,611,611,611,611,611,556,611,611,556,778,556,556,556,556,556,500,500,500,500,556};func init (){RegisterStdFont (SymbolName ,_edb ,"\u0053\u0079\u006d\u0062\u006f\u006c\u002c\u0049\u0074\u0061\u006c\u0069\u0063","S\u0079\u006d\u0062\u006f\u006c\u002c\u0042\u006f\u006c\u0064","\u0053\u0079\u006d\u0062\u006f\u006c\u002c\u0042\u006f\u006c\u0064\u0049t\u0061\u006c\u0069\u0063");RegisterStdFont (ZapfDingbatsName ,_gb );};func _abc ()StdFont {_bdf .Do (_ea );_dge :=Descriptor {Name :HelveticaObliqueName ,Family :string (HelveticaName ),Weight :FontWeightMedium ,Flags :0x0060,BBox :[4]float64 {-170,-225,1116,931},ItalicAngle :-12,Ascent :718,Descent :-207,CapHeight :718,XHeight :523,StemV :88,StemH :76};return NewStdFont (_dge ,_eff );};var _dfb =map[rune ]CharMetrics {' ':{Wx :278},'→':{Wx :838},'↔':{Wx :1016},'↕':{Wx :458},'①':{Wx :788},'②':{Wx :788},'③':{Wx :788},'④':{Wx :788},'⑤':{Wx :788},'⑥':{Wx :788},'⑦':{Wx :788},'⑧':{Wx :788},'⑨':{Wx :788},'⑩':{Wx :788},'■':{Wx :761},'▲':{Wx :892},'▼':{Wx :892},'◆':{Wx :788},'●':{Wx :791},'◗':{Wx :438},'★':{Wx :816},'☎':{Wx :719},'☛':{Wx :960},'☞':{Wx :939},'♠':{Wx :626},'♣':{Wx :776},'♥':{Wx :694},'♦':{Wx :595},'✁':{Wx :974},'✂':{Wx :961},'✃':{Wx :974},'✄':{Wx :980},'✆':{Wx :789},'✇':{Wx :790},'✈':{Wx :791},'✉':{Wx :690},'✌':{Wx :549},'✍':{Wx :855},'✎':{Wx :911},'✏':{Wx :933},'✐':{Wx :911},'✑':{Wx :945},'✒':{Wx :974},'✓':{Wx :755},'✔':{Wx :846},'✕':{Wx :762},'✖':{Wx :761},'✗':{Wx :571},'✘':{Wx :677},'✙':{Wx :763},'✚':{Wx :760},'✛':{Wx :759},'✜':{Wx :754},'✝':{Wx :494},'✞':{Wx :552},'✟':{Wx :537},'✠':{Wx :577},'✡':{Wx :692},'✢':{Wx :786},'✣':{Wx :788},'✤':{Wx :788},'✥':{Wx :790},'✦':{Wx :793},'✧':{Wx :794},'✩':{Wx :823},'✪':{Wx :789},'✫':{Wx :841},'✬':{Wx :823},'✭':{Wx :833},'✮':{Wx :816},'✯':{Wx :831},'✰':{Wx :923},'✱':{Wx :744},'✲':{Wx :723},'✳':{Wx :749},'✴':{Wx :790},'✵':{Wx :792},'✶':{Wx :695},'✷':{Wx :776},'✸':{Wx :768},'✹':{Wx :792},'✺':{Wx :759},'✻':{Wx :707},'✼':{Wx :708},'✽':{Wx :682},'✾':{Wx :701},'✿':{Wx :826},'❀':{Wx :815},'❁':{Wx :789},'❂':{Wx :789},'❃':{Wx :707},'❄':{Wx :687},'❅':{Wx :696},'❆':{Wx :689},'❇':{Wx :786},'❈':{Wx :787},'❉':{Wx :713},'❊':{Wx :791},'❋':{Wx :785},'❍':{Wx :873},'❏':{Wx :762},'❐':{Wx :762},'❑':{Wx :759},'❒':{Wx :759},'❖':{Wx :784},'❘':{Wx :138},'❙':{Wx :277},'❚':{Wx :415},'❛':{Wx :392},'❜':{Wx :392},'❝':{Wx :668},'❞':{Wx :668},'❡':{Wx :732},'❢':{Wx :544},'❣':{Wx :544},'❤':{Wx :910},'❥':{Wx :667},'❦':{Wx :760},'❧':{Wx :760},'❶':{Wx :788},'❷':{Wx :788},'❸':{Wx :788},'❹':{Wx :788},'❺':{Wx :788},'❻':{Wx :788},'❼':{Wx :788},'❽':{Wx :788},'❾':{Wx :788},'❿':{Wx :788},'➀':{Wx :788},'➁':{Wx :788},'➂':{Wx :788},'➃':{Wx :788},'➄':{Wx :788},'➅':{Wx :788},'➆':{Wx :788},'➇':{Wx :788},'➈':{Wx :788},'➉':{Wx :788},'➊':{Wx :788},'➋':{Wx :788},'➌':{Wx :788},'➍':{Wx :788},'➎':{Wx :788},'➏':{Wx :788},'➐':{Wx :788},'➑':{Wx :788},'➒':{Wx :788},'➓':{Wx :788},'➔':{Wx :894},'➘':{Wx :748},'➙':{Wx :924},'➚':{Wx :748},'➛':{Wx :918},'➜':{Wx :927},'➝':{Wx :928},'➞':{Wx :928},'➟':{Wx :834},'➠':{Wx :873},'➡':{Wx :828},'➢':{Wx :924},'➣':{Wx :924},'➤':{Wx :917},'➥':{Wx :930},'➦':{Wx :931},'➧':{Wx :463},'➨':{Wx :883},'➩':{Wx :836},'➪':{Wx :836},'➫':{Wx :867},'➬':{Wx :867},'➭':{Wx :696},'➮':{Wx :696},'➯':{Wx :874},'➱':{Wx :874},'➲':{Wx :760},'➳':{Wx :946},'➴':{Wx :771},'➵':{Wx :865},'➶':{Wx :771},'➷':{Wx :888},'➸':{Wx :967},'➹':{Wx :888},'➺':{Wx :831},'➻':{Wx :873},'➼':{Wx :927},'➽':{Wx :970},'➾':{Wx :918},'\uf8d7':{Wx :390},'\uf8d8':{Wx :390},'\uf8d9':{Wx :317},'\uf8da':{Wx :317},'\uf8db':{Wx :276},'\uf8dc':{Wx :276},'\uf8dd':{Wx :509},'\uf8de':{Wx :509},'\uf8df':{Wx :410},'\uf8e0':{Wx :410},'\uf8e1':{Wx :234},'\uf8e2':{Wx :234},'\uf8e3':{Wx :334},'\uf8e4':{Wx :334}};func NewStdFontByName (name StdFontName )(StdFont ,bool ){_ggc ,_ba :=_ec [name ];if !_ba {return StdFont {},false ;};return _ggc (),true ;};const (_ccf ="\u0054\u0069\u006de\u0073";TimesRomanName =StdFontName ("T\u0069\u006d\u0065\u0073\u002d\u0052\u006f\u006d\u0061\u006e");TimesBoldName =StdFontName ("\u0054\u0069\u006d\u0065\u0073\u002d\u0042\u006f\u006c\u0064");TimesItalicName =StdFontName ("\u0054\u0069\u006de\u0073\u002d\u0049\u0074\u0061\u006c\u0069\u0063");TimesBoldItalicName =StdFontName ("\u0054\u0069m\u0065\u0073\u002dB\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063"););func _geb ()StdFont {_fbf .Do (_deb );_gafd :=Descriptor {Name :CourierBoldObliqueName ,Family :string (CourierName ),Weight :FontWeightBold ,Flags :0x0061,BBox :[4]float64 {-57,-250,869,801},ItalicAngle :-12,Ascent :629,Descent :-157,CapHeight :562,XHeight :439,StemV :106,StemH :84};return NewStdFont (_gafd ,_bec );};func init (){RegisterStdFont (TimesRomanName ,_gcd ,"\u0054\u0069\u006d\u0065\u0073\u004e\u0065\u0077\u0052\u006f\u006d\u0061\u006e","\u0054\u0069\u006de\u0073");RegisterStdFont (TimesBoldName ,_bgb ,"\u0054i\u006de\u0073\u004e\u0065\u0077\u0052o\u006d\u0061n\u002c\u0042\u006f\u006c\u0064","\u0054\u0069\u006d\u0065\u0073\u002c\u0042\u006f\u006c\u0064");RegisterStdFont (TimesItalicName ,_gdc ,"T\u0069m\u0065\u0073\u004e\u0065\u0077\u0052\u006f\u006da\u006e\u002c\u0049\u0074al\u0069\u0063","\u0054\u0069\u006de\u0073\u002c\u0049\u0074\u0061\u006c\u0069\u0063");RegisterStdFont (TimesBoldItalicName ,_dgc ,"\u0054i\u006d\u0065\u0073\u004e\u0065\u0077\u0052\u006f\u006d\u0061\u006e,\u0042\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063","\u0054\u0069m\u0065\u0073\u002cB\u006f\u006c\u0064\u0049\u0074\u0061\u006c\u0069\u0063");};func (_abg *TtfType )NewEncoder ()_ge .TextEncoder {return _ge .NewTrueTypeFontEncoder (_abg .Chars )};var _bc map[rune ]CharMetrics ;const (FontWeightMedium FontWeight =iota ;FontWeightBold ;FontWeightRoman ;);var _af map[rune ]CharMetrics ;func (_ab CharMetrics )String ()string {return _d .Sprintf ("<\u0025\u002e\u0031\u0066\u002c\u0025\u002e\u0031\u0066\u003e",_ab .Wx ,_ab .Wy );};const (CourierName =StdFontName ("\u0043o\u0075\u0072\u0069\u0065\u0072");CourierBoldName =StdFontName ("\u0043\u006f\u0075r\u0069\u0065\u0072\u002d\u0042\u006f\u006c\u0064");CourierObliqueName =StdFontName ("\u0043o\u0075r\u0069\u0065\u0072\u002d\u004f\u0062\u006c\u0069\u0071\u0075\u0065");CourierBoldObliqueName =StdFontName ("\u0043\u006f\u0075\u0072ie\u0072\u002d\u0042\u006f\u006c\u0064\u004f\u0062\u006c\u0069\u0071\u0075\u0065"););type CharMetrics struct{Wx float64 ;Wy float64 ;};type TtfType struct{UnitsPerEm uint16 ;PostScriptName string ;Bold bool ;ItalicAngle float64 ;IsFixedPitch bool ;TypoAscender int16 ;TypoDescender int16 ;UnderlinePosition int16 ;UnderlineThickness int16 ;Xmin ,Ymin ,Xmax ,Ymax int16 ;CapHeight int16 ;Widths []uint16 ;Chars map[rune ]GID ;GlyphNames []GlyphName ;};func (_cgda *TtfType )MakeEncoder ()(_ge .SimpleEncoder ,error ){_dgae :=make (map[_ge .CharCode ]GlyphName );for _fbg :=_ge .CharCode (0);_fbg <=256;_fbg ++{_afe :=rune (_fbg );_ded ,_gdb :=_cgda .Chars [_afe ];if !_gdb {continue ;};var _bace GlyphName ;if int (_ded )>=0&&int (_ded )< len (_cgda .GlyphNames ){_bace =_cgda .GlyphNames [_ded ];}else {_ceag :=rune (_ded );if _bcg ,_ccd :=_ge .RuneToGlyph (_ceag );_ccd {_bace =_bcg ;};};if _bace !=""{_dgae [_fbg ]=_bace ;};};if len (_dgae )==0{_gf .Log .Debug ("WA\u0052\u004eI\u004e\u0047\u003a\u0020\u005a\u0065\u0072\u006f\u0020l\u0065\u006e\u0067\u0074\u0068\u0020\u0054\u0072\u0075\u0065\u0054\u0079\u0070\u0065\u0020\u0065\u006e\u0063\u006f\u0064\u0069\u006e\u0067\u002e\u0020\u0074\u0074\u0066=\u0025s\u0020\u0043\u0068\u0061\u0072\u0073\u003d\u005b%\u00200\u0032\u0078]",_cgda ,_cgda .Chars );};return _ge .NewCustomSimpleTextEncoder (_dgae ,nil );};var _fcf map[rune ]CharMetrics ;var _gebb map[rune ]CharMetrics ;func (_bfb *ttfParser )ReadStr (length int )(string ,error ){_dedd :=make ([]byte ,length );_gcfd ,_agba :=_bfb ._gbb .Read (_dedd );if _agba !=nil {return "",_agba ;}else if _gcfd !=length {return "",_d .Errorf ("\u0075\u006e\u0061bl\u0065\u0020\u0074\u006f\u0020\u0072\u0065\u0061\u0064\u0020\u0025\u0064\u0020\u0062\u0079\u0074\u0065\u0073",length );};return string (_dedd ),nil ;};func _gdc ()StdFont {_gca .Do (_cg );_ffc :=Descriptor {Name :TimesItalicName ,Family :_ccf ,Weight :FontWeightMedium ,Flags :0x0060,BBox :[4]float64 {-169,-217,1010,883},ItalicAngle :-15.5,Ascent :683,Descent :-217,CapHeight :653,XHeight :441,StemV :76,StemH :32};return NewStdFont (_ffc ,_gebb );};func (_gad *ttfParser )ParseName ()error {if _geeb :=_gad .Seek ("\u006e\u0061\u006d\u0065");_geeb !=nil {return _geeb ;};_ecbg ,_ :=_gad ._gbb .Seek (0,_f .SeekCurrent );_gad ._age .PostScriptName ="";_gad .Skip (2);_eddfa :=_gad .ReadUShort ();_cee :=_gad .ReadUShort ();for _aefd :=uint16 (0);_aefd < _eddfa &&_gad ._age .PostScriptName =="";_aefd ++{_gad .Skip (3*2);_bag :=_gad .ReadUShort ();_cebb :=_gad .ReadUShort ();_ada :=_gad .ReadUShort ();if _bag ==6{_gad ._gbb .Seek (_ecbg +int64 (_cee )+int64 (_ada ),_f .SeekStart );_fgfb ,_cff :=_gad .ReadStr (int (_cebb ));if _cff !=nil {return _cff ;};_fgfb =_df .Replace (_fgfb ,"\u0000","",-1);_befd ,_cff :=_b .Compile ("\u005b\u0028\u0029\u007b\u007d\u003c\u003e\u0020\u002f%\u005b\u005c\u005d\u005d");if _cff !=nil {return _cff ;};_gad ._age .PostScriptName =_befd .ReplaceAllString (_fgfb ,"");};};if _gad ._age .PostScriptName ==""{_gf .Log .Debug ("\u0050a\u0072\u0073e\u004e\u0061\u006de\u003a\u0020\u0054\u0068\u0065\u0020\u006ea\u006d\u0065\u0020\u0050\u006f\u0073t\u0053\u0063\u0072\u0069\u0070\u0074\u0020\u0077\u0061\u0073\u0020n\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u002e");};return nil ;};func (_cef *ttfParser )ParseComponents ()error {if _edea :=_cef .ParseHead ();_edea !=nil {return _edea ;};if _gfc :=_cef .ParseHhea ();_gfc !=nil {return _gfc ;};if _efg :=_cef .ParseMaxp ();_efg !=nil {return _efg ;};if _ddf :=_cef .ParseHmtx ();_ddf !=nil {return _ddf ;};if _ ,_gfb :=_cef ._cba ["\u006e\u0061\u006d\u0065"];_gfb {if _gcf :=_cef .ParseName ();_gcf !=nil {return _gcf ;};};if _ ,_bca :=_cef ._cba ["\u004f\u0053\u002f\u0032"];_bca {if _fgg :=_cef .ParseOS2 ();_fgg !=nil {return _fgg ;};};if _ ,_dgf :=_cef ._cba ["\u0070\u006f\u0073\u0074"];_dgf {if _fde :=_cef .ParsePost ();_fde !=nil {return _fde ;};};if _ ,_gff :=_cef ._cba ["\u0063\u006d\u0061\u0070"];_gff {if _fcd :=_cef .ParseCmap ();_fcd !=nil {return _fcd ;};};return nil ;};func (_ceg *ttfParser )ParseMaxp ()error {if _gagc :=_ceg .Seek ("\u006d\u0061\u0078\u0070");_gagc !=nil {return _gagc ;};_ceg .Skip (4);_ceg ._dfe =_ceg .ReadUShort ();return nil ;};func (_dad *ttfParser )ReadSByte ()(_aba int8 ){_g .Read (_dad ._gbb ,_g .BigEndian ,&_aba );return _aba };func (_fcgb *ttfParser )parseCmapFormat12 ()error {_bbde :=_fcgb .ReadULong ();_gf .Log .Trace ("\u0070\u0061\u0072se\u0043\u006d\u0061\u0070\u0046\u006f\u0072\u006d\u0061t\u00312\u003a \u0025s\u0020\u006e\u0075\u006d\u0047\u0072\u006f\u0075\u0070\u0073\u003d\u0025\u0064",_fcgb ._age .String (),_bbde );for _bcab :=uint32 (0);_bcab < _bbde ;_bcab ++{_dbc :=_fcgb .ReadULong ();_gfg :=_fcgb .ReadULong ();_dae :=_fcgb .ReadULong ();if _dbc > 0x0010FFFF||(0xD800<=_dbc &&_dbc <=0xDFFF){return _fg .New ("\u0069n\u0076\u0061\u006c\u0069\u0064\u0020\u0063\u0068\u0061\u0072\u0061c\u0074\u0065\u0072\u0073\u0020\u0063\u006f\u0064\u0065\u0073");};if _gfg < _dbc ||_gfg > 0x0010FFFF||(0xD800<=_gfg &&_gfg <=0xDFFF){return _fg .New ("\u0069n\u0076\u0061\u006c\u0069\u0064\u0020\u0063\u0068\u0061\u0072\u0061c\u0074\u0065\u0072\u0073\u0020\u0063\u006f\u0064\u0065\u0073");};for _dac :=_dbc ;_dac <=_gfg ;_dac ++{if _dac > 0x10FFFF{_gf .Log .Debug ("\u0046\u006fr\u006d\u0061\u0074\u0020\u0031\u0032\u0020\u0063\u006d\u0061\u0070\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0073\u0020\u0063\u0068\u0061\u0072\u0061\u0063\u0074\u0065\u0072\u0020\u0062\u0065\u0079\u006f\u006e\u0064\u0020\u0055\u0043\u0053\u002d\u0034");};_fcgb ._age .Chars [rune (_dac )]=GID (_dae );_dae ++;};};return nil ;};var _fd =map[rune ]CharMetrics {' ':{Wx :250},'!':{Wx :333},'#':{Wx :500},'%':{Wx :833},'&':{Wx :778},'(':{Wx :333},')':{Wx :333},'+':{Wx :549},',':{Wx :250},'.':{Wx :250},'/':{Wx :278},'0':{Wx :500},'1':{Wx :500},'2':{Wx :500},'3':{Wx :500},'4':{Wx :500},'5':{Wx :500},'6':{Wx :500},'7':{Wx :500},'8':{Wx :500},'9':{Wx :500},':':{Wx :278},';':{Wx :278},'<':{Wx :549},'=':{Wx :549},'>':{Wx :549},'?':{Wx :444},'[':{Wx :333},']':{Wx :333},'_':{Wx :500},'{':{Wx :480},'|':{Wx :200},'}':{Wx :480},'¬':{Wx :713},'°':{Wx :400},'±':{Wx :549},'µ':{Wx :576},'×':{Wx :549},'÷':{Wx :549},'ƒ':{Wx :500},'Α':{Wx :722},'Β':{Wx :667},'Γ':{Wx :603},'Ε':{Wx :611},'Ζ':{Wx :611},'Η':{Wx :722},'Θ':{Wx :741},'Ι':{Wx :333},'Κ':{Wx :722},'Λ':{Wx :686},'Μ':{Wx :889},'Ν':{Wx :722},'Ξ':{Wx :645},'Ο':{Wx :722},'Π':{Wx :768},'Ρ':{Wx :556},'Σ':{Wx :592},'Τ':{Wx :611},'Υ':{Wx :690},'Φ':{Wx :763},'Χ':{Wx :722},'Ψ':{Wx :795},'α':{Wx :631},'β':{Wx :549},'γ':{Wx :411},'δ':{Wx :494},'ε':{Wx :439},'ζ':{Wx :494},'η':{Wx :603},'θ':{Wx :521},'ι':{Wx :329},'κ':{Wx :549},'λ':{Wx :549},'ν':{Wx :521},'ξ':{Wx :493},'ο':{Wx :549},'π':{Wx :549},'ρ':{Wx :549},'ς':{Wx :439},'σ':{Wx :603},'τ':{Wx :439},'υ':{Wx :576},'φ':{Wx :521},'χ':{Wx :549},'ψ':{Wx :686},'ω':{Wx :686},'ϑ':{Wx :631},'ϒ':{Wx :620},'ϕ':{Wx :603},'ϖ':{Wx :713},'•':{Wx :460},'…':{Wx :1000},'′':{Wx :247},'″':{Wx :411},'⁄':{Wx :167},'€':{Wx :750},'ℑ':{Wx :686},'℘':{Wx :987},'ℜ':{Wx :795},'Ω':{Wx :768},'ℵ':{Wx :823},'←':{Wx :987},'↑':{Wx :603},'→':{Wx :987},'↓':{Wx :603},'↔':{Wx :1042},'↵':{Wx :658},'⇐':{Wx :987},'⇑':{Wx :603},'⇒':{Wx :987},'⇓':{Wx :603},'⇔':{Wx :1042},'∀':{Wx :713},'∂':{Wx :494},'∃':{Wx :549},'∅':{Wx :823},'∆':{Wx :612},'∇':{Wx :713},'∈':{Wx :713},'∉':{Wx :713},'∋':{Wx :439},'∏':{Wx :823},'∑':{Wx :713},'−':{Wx :549},'∗':{Wx :500},'√':{Wx :549},'∝':{Wx :713},'∞':{Wx :713},'∠':{Wx :768},'∧':{Wx :603},'∨':{Wx :603},'∩':{Wx :768},'∪':{Wx :768},'∫':{Wx :274},'∴':{Wx :863},'∼':{Wx :549},'≅':{Wx :549},'≈':{Wx :549},'≠':{Wx :549},'≡':{Wx :549},'≤':{Wx :549},'≥':{Wx :549},'⊂':{Wx :713},'⊃':{Wx :713},'⊄':{Wx :713},'⊆':{Wx :713},'⊇':{Wx :713},'⊕':{Wx :768},'⊗':{Wx :768},'⊥':{Wx :658},'⋅':{Wx :250},'⌠':{Wx :686},'⌡':{Wx :686},'〈':{Wx :329},'〉':{Wx :329},'◊':{Wx :494},'♠':{Wx :753},'♣':{Wx :753},'♥':{Wx :753},'♦':{Wx :753},'\uf6d9':{Wx :790},'\uf6da':{Wx :790},'\uf6db':{Wx :890},'\uf8e5':{Wx :500},'\uf8e6':{Wx :603},'\uf8e7':{Wx :1000},'\uf8e8':{Wx :790},'\uf8e9':{Wx :790},'\uf8ea':{Wx :786},'\uf8eb':{Wx :384},'\uf8ec':{Wx :384},'\uf8ed':{Wx :384},'\uf8ee':{Wx :384},'\uf8ef':{Wx :384},'\uf8f0':{Wx :384},'\uf8f1':{Wx :494},'\uf8f2':{Wx :494},'\uf8f3':{Wx :494},'\uf8f4':{Wx :494},'\uf8f5':{Wx :686},'\uf8f6':{Wx :384},'\uf8f7':{Wx :384},'\uf8f8':{Wx :384},'\uf8f9':{Wx :384},'\uf8fa':{Wx :384},'\uf8fb':{Wx :384},'\uf8fc':{Wx :494},'\uf8fd':{Wx :494},'\uf8fe':{Wx :494},'\uf8ff':{Wx :790}};func (_fdcf *ttfParser )parseCmapFormat0 ()error {_gcb ,_aef :=_fdcf .ReadStr (256);if _aef !=nil {return _aef ;};_bbf :=[]byte (_gcb );_gf .Log .Trace ("\u0070a\u0072\u0073e\u0043\u006d\u0061p\u0046\u006f\u0072\u006d\u0061\u0074\u0030:\u0020\u0025\u0073\u000a\u0064\u0061t\u0061\u0053\u0074\u0072\u003d\u0025\u002b\u0071\u000a\u0064\u0061t\u0061\u003d\u005b\u0025\u0020\u0030\u0032\u0078\u005d",_fdcf ._age .String (),_gcb ,_bbf );for _dea ,_bbd :=range _bbf {_fdcf ._age .Chars [rune (_dea )]=GID (_bbd );};return nil ;};func _ea (){_af =make (map[rune ]CharMetrics ,len (_fc ));_ce =make (map[rune ]CharMetrics ,len (_fc ));for _ef ,_cc :=range _fc {_af [_cc ]=CharMetrics {Wx :float64 (_bcd [_ef ])};_ce [_cc ]=CharMetrics {Wx :float64 (_cdf [_ef ])};};_eff =_af ;_ebfa =_ce ;};func (_ecb *ttfParser )Parse ()(TtfType ,error ){_acg ,_dedg :=_ecb .ReadStr (4);if _dedg !=nil {return TtfType {},_dedg ;};if _acg =="\u004f\u0054\u0054\u004f"{return TtfType {},_de .Errorf ("\u0066\u006f\u006e\u0074s\u0020\u0062\u0061\u0073\u0065\u0064\u0020\u006f\u006e \u0050\u006f\u0073\u0074\u0053\u0063\u0072\u0069\u0070\u0074\u0020\u006f\u0075\u0074\u006c\u0069\u006e\u0065s\u0020\u0061\u0072\u0065\u0020n\u006f\u0074\u0020\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0028\u0025\u0077\u0029",_fb .ErrNotSupported );};if _acg !="\u0000\u0001\u0000\u0000"&&_acg !="\u0074\u0072\u0075\u0065"{_gf .Log .Debug ("\u0055n\u0072\u0065c\u006f\u0067\u006ei\u007a\u0065\u0064\u0020\u0054\u0072\u0075e\u0054\u0079\u0070\u0065\u0020\u0066i\u006c\u0065\u0020\u0066\u006f\u0072\u006d\u0061\u0074\u002e\u0020v\u0065\u0072\u0073\u0069\u006f\u006e\u003d\u0025\u0071",_acg );};_ace :=int (_ecb .ReadUShort ());_ecb .Skip (3*2);_ecb ._cba =make (map[string ]uint32 );var _gde string ;for _cfed :=0;_cfed < _ace ;_cfed ++{_gde ,_dedg =_ecb .ReadStr (4);if _dedg !=nil {return TtfType {},_dedg ;};_ecb .Skip (4);_cde :=_ecb .ReadULong ();_ecb .Skip (4);_ecb ._cba [_gde ]=_cde ;};_gf .Log .Trace (_gbee (_ecb ._cba ));if _dedg =_ecb .ParseComponents ();_dedg !=nil {return TtfType {},_dedg ;};return _ecb ._age ,nil ;};var _bec map[rune ]CharMetrics ;func _edb ()StdFont {_cea :=_ge .NewSymbolEncoder ();_ebd :=Descriptor {Name :SymbolName ,Family :string (SymbolName ),Weight :FontWeightMedium ,Flags :0x0004,BBox :[4]float64 {-180,-293,1090,1010},ItalicAngle :0,Ascent :0,Descent :0,CapHeight :0,XHeight :0,StemV :85,StemH :92};return NewStdFontWithEncoding (_ebd ,_fd ,_cea );};func (_gaa *ttfParser )ParseHhea ()error {if _baa :=_gaa .Seek ("\u0068\u0068\u0065\u0061");_baa !=nil {return _baa ;};_gaa .Skip (4+15*2);_gaa ._cfd =_gaa .ReadUShort ();return nil ;};func (_dbe *ttfParser )ParseHead ()error {if _dbb :=_dbe .Seek ("\u0068\u0065\u0061\u0064");_dbb !=nil {return _dbb ;};_dbe .Skip (3*4);_dbd :=_dbe .ReadULong ();if _dbd !=0x5F0F3CF5{_gf .Log .Debug ("\u0045\u0052\u0052\u004f\u0052:\u0020\u0049\u006e\u0063\u006fr\u0072e\u0063\u0074\u0020\u006d\u0061\u0067\u0069\u0063\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u002e\u0020\u0046\u006fn\u0074\u0020\u006d\u0061\u0079\u0020\u006e\u006f\u0074\u0020\u0064\u0069\u0073\u0070\u006c\u0061\u0079\u0020\u0063\u006f\u0072\u0072\u0065\u0063t\u006c\u0079\u002e\u0020\u0025\u0073",_dbe );};_dbe .Skip (2);_dbe ._age .UnitsPerEm =_dbe .ReadUShort ();_dbe .Skip (2*8);_dbe ._age .Xmin =_dbe .ReadShort ();_dbe ._age .Ymin =_dbe .ReadShort ();_dbe ._age .Xmax =_dbe .ReadShort ();_dbe ._age .Ymax =_dbe .ReadShort ();return nil ;};var _ebb =[]int16 {611,889,611,611,611,611,611,611,611,611,611,611,667,667,667,667,722,722,722,612,611,611,611,611,611,611,611,611,611,722,500,611,722,722,722,722,333,333,333,333,333,333,333,333,444,667,667,556,556,611,556,556,833,667,667,667,667,667,722,944,722,722,722,722,722,722,722,722,611,722,611,611,611,611,500,500,500,500,500,556,556,556,611,722,722,722,722,722,722,722,722,722,611,833,611,556,556,556,556,556,556,556,500,500,500,500,333,500,667,500,500,778,500,500,422,541,500,920,500,500,278,275,400,400,389,389,333,275,350,444,444,333,444,444,333,500,333,333,250,250,760,500,500,500,500,544,500,400,333,675,500,333,278,444,444,444,444,444,444,444,500,889,444,889,500,444,675,500,333,389,278,500,500,500,500,500,167,500,500,500,500,333,675,549,500,500,333,333,500,333,333,278,278,278,278,278,278,278,278,444,444,278,278,300,278,675,549,675,471,278,722,333,675,500,675,500,500,500,500,500,549,500,500,500,500,500,500,667,333,500,500,500,500,750,750,300,276,310,500,500,500,523,333,333,476,833,250,250,1000,675,675,500,500,500,420,556,556,556,333,333,333,214,389,389,453,389,389,760,333,389,389,389,389,389,500,333,500,500,278,250,500,600,278,300,278,500,500,750,300,333,980,500,300,500,500,500,500,500,500,500,500,500,500,444,667,444,444,444,444,500,389,389,389,389,500};func NewStdFont (desc Descriptor ,metrics map[rune ]CharMetrics )StdFont {return NewStdFontWithEncoding (desc ,metrics ,_ge .NewStandardEncoder ());};func (_cbc *TtfType )MakeToUnicode ()*_gg .CMap {_aeb :=make (map[_gg .CharCode ]rune );if len (_cbc .GlyphNames )==0{return _gg .NewToUnicodeCMap (_aeb );};for _gbe ,_cdff :=range _cbc .Chars {_eba :=_gg .CharCode (_gbe );_edd :=_cbc .GlyphNames [_cdff ];_ffb ,_fcg :=_ge .GlyphToRune (_edd );if !_fcg {_gf .Log .Debug ("\u004e\u006f \u0072\u0075\u006e\u0065\u002e\u0020\u0063\u006f\u0064\u0065\u003d\u0030\u0078\u0025\u0030\u0034\u0078\u0020\u0067\u006c\u0079\u0070h=\u0025\u0071",_gbe ,_edd );_ffb =_ge .MissingCodeRune ;};_aeb [_eba ]=_ffb ;};return _gg .NewToUnicodeCMap (_aeb );};var _cgd map[rune ]CharMetrics ;func NewStdFontWithEncoding (desc Descriptor ,metrics map[rune ]CharMetrics ,encoder _ge .TextEncoder )StdFont {var _acb rune =0xA0;if _ ,_cb :=metrics [_acb ];!_cb {_fcb :=make (map[rune ]CharMetrics ,len (metrics )+1);for _gbd ,_cdd :=range metrics {_fcb [_gbd ]=_cdd ;};_fcb [_acb ]=metrics [0x20];metrics =_fcb ;};return StdFont {_acd :desc ,_fa :metrics ,_gfe :encoder };};func (_eef *ttfParser )ReadUShort ()(_bdfc uint16 ){_g .Read (_eef ._gbb ,_g .BigEndian ,&_bdfc );return _bdfc ;};func (_agga *ttfParser )ReadULong ()(_feg uint32 ){_g .Read (_agga ._gbb ,_g .BigEndian ,&_feg );return _feg ;};type ttfParser struct{_age TtfType ;_gbb _f .ReadSeeker ;_cba map[string ]uint32 ;_cfd uint16 ;_dfe uint16 ;};func (_eaa *ttfParser )Skip (n int ){_eaa ._gbb .Seek (int64 (n ),_f .SeekCurrent )};func (_efd *ttfParser )ParsePost ()error {if _fcfd :=_efd .Seek ("\u0070\u006f\u0073\u0074");_fcfd !=nil {return _fcfd ;};_gcda :=_efd .Read32Fixed ();_efd ._age .ItalicAngle =_efd .Read32Fixed ();_efd ._age .UnderlinePosition =_efd .ReadShort ();_efd ._age .UnderlineThickness =_efd .ReadShort ();_efd ._age .IsFixedPitch =_efd .ReadULong ()!=0;_efd .ReadULong ();_efd .ReadULong ();_efd .ReadULong ();_efd .ReadULong ();_gf .Log .Trace ("\u0050a\u0072\u0073\u0065\u0050\u006f\u0073\u0074\u003a\u0020\u0066\u006fr\u006d\u0061\u0074\u0054\u0079\u0070\u0065\u003d\u0025\u0066",_gcda );switch _gcda {case 1.0:_efd ._age .GlyphNames =_gded ;case 2.0:_fbd :=int (_efd .ReadUShort ());_dff :=make ([]int ,_fbd );_efd ._age .GlyphNames =make ([]GlyphName ,_fbd );_ccdc :=-1;for _cegg :=0;_cegg < _fbd ;_cegg ++{_fdee :=int (_efd .ReadUShort ());_dff [_cegg ]=_fdee ;if _fdee <=0x7fff&&_fdee > _ccdc {_ccdc =_fdee ;};};var _fgd []GlyphName ;if _ccdc >=len (_gded ){_fgd =make ([]GlyphName ,_ccdc -len (_gded )+1);for _cbfb :=0;_cbfb < _ccdc -len (_gded )+1;_cbfb ++{_gef :=int (_efd .readByte ());_dfec ,_ddg :=_efd .ReadStr (_gef );if _ddg !=nil {return _ddg ;};_fgd [_cbfb ]=GlyphName (_dfec );};};for _efdf :=0;_efdf < _fbd ;_efdf ++{_ee :=_dff [_efdf ];if _ee < len (_gded ){_efd ._age .GlyphNames [_efdf ]=_gded [_ee ];}else if _ee >=len (_gded )&&_ee <=32767{_efd ._age .GlyphNames [_efdf ]=_fgd [_ee -len (_gded )];}else {_efd ._age .GlyphNames [_efdf ]="\u002e\u0075\u006e\u0064\u0065\u0066\u0069\u006e\u0065\u0064";};};case 2.5:_dbcc :=make ([]int ,_efd ._dfe );for _adac :=0;_adac < len (_dbcc );_adac ++{_fgdb :=int (_efd .ReadSByte ());_dbcc [_adac ]=_adac +1+_fgdb ;};_efd ._age .GlyphNames =make ([]GlyphName ,len (_dbcc ));for _ege :=0;_ege < len (_efd ._age .GlyphNames );_ege ++{_bgg :=_gded [_dbcc [_ege ]];_efd ._age .GlyphNames [_ege ]=_bgg ;};case 3.0:_gf .Log .Debug ("\u004e\u006f\u0020\u0050\u006f\u0073t\u0053\u0063\u0072i\u0070\u0074\u0020n\u0061\u006d\u0065\u0020\u0069\u006e\u0066\u006f\u0072\u006da\u0074\u0069\u006f\u006e\u0020is\u0020\u0070\u0072\u006f\u0076\u0069\u0064\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0074\u0068\u0065\u0020\u0066\u006f\u006e\u0074\u002e");default:_gf .Log .Debug ("\u0045\u0052\u0052\u004fR\u003a\u0020\u0055\u006e\u006b\u006e\u006f\u0077\u006e\u0020f\u006fr\u006d\u0061\u0074\u0054\u0079\u0070\u0065=\u0025\u0066",_gcda );};return nil ;};func RegisterStdFont (name StdFontName ,fnc func ()StdFont ,aliases ...StdFontName ){if _ ,_fgf :=_ec [name ];_fgf {panic ("\u0066o\u006e\u0074\u0020\u0061l\u0072\u0065\u0061\u0064\u0079 \u0072e\u0067i\u0073\u0074\u0065\u0072\u0065\u0064\u003a "+string (name ));};_ec [name ]=fnc ;for _ ,_c :=range aliases {RegisterStdFont (_c ,fnc );};};func (_fgcc *TtfType )String ()string {return _d .Sprintf ("\u0046\u004fN\u0054\u005f\u0046\u0049\u004cE\u0032\u007b\u0025\u0023\u0071 \u0055\u006e\u0069\u0074\u0073\u0050\u0065\u0072\u0045\u006d\u003d\u0025\u0064\u0020\u0042\u006f\u006c\u0064\u003d\u0025\u0074\u0020\u0049\u0074\u0061\u006c\u0069\u0063\u0041\u006e\u0067\u006c\u0065\u003d\u0025\u0066\u0020"+"\u0043\u0061pH\u0065\u0069\u0067h\u0074\u003d\u0025\u0064 Ch\u0061rs\u003d\u0025\u0064\u0020\u0047\u006c\u0079ph\u004e\u0061\u006d\u0065\u0073\u003d\u0025d\u007d",_fgcc .PostScriptName ,_fgcc .UnitsPerEm ,_fgcc .Bold ,_fgcc .ItalicAngle ,_fgcc .CapHeight ,len (_fgcc .Chars ),len (_fgcc .GlyphNames ));};var _ Font =StdFont {};var _ec =make (map[StdFontName ]func ()StdFont );var _fc =[]rune {'A','Æ','Á','Ă','Â','Ä','À','Ā','Ą','Å','Ã','B','C','Ć','Č','Ç','D','Ď','Đ','∆','E','É','Ě','Ê','Ë','Ė','È','Ē','Ę','Ð','€','F','G','Ğ','Ģ','H','I','Í','Î','Ï','İ','Ì','Ī','Į','J','K','Ķ','L','Ĺ','Ľ','Ļ','Ł','M','N','Ń','Ň','Ņ','Ñ','O','Œ','Ó','Ô','Ö','Ò','Ő','Ō','Ø','Õ','P','Q','R','Ŕ','Ř','Ŗ','S','Ś','Š','Ş','Ș','T','Ť','Ţ','Þ','U','Ú','Û','Ü','Ù','Ű','Ū','Ų','Ů','V','W','X','Y','Ý','Ÿ','Z','Ź','Ž','Ż','a','á','ă','â','´','ä','æ','à','ā','&','ą','å','^','~','*','@','ã','b','\\','|','{','}','[',']','˘','¦','•','c','ć','ˇ','č','ç','¸','¢','ˆ',':',',','\uf6c3','©','¤','d','†','‡','ď','đ','°','¨','÷','$','˙','ı','e','é','ě','ê','ë','ė','è','8','…','ē','—','–','ę','=','ð','!','¡','f','ﬁ','5','ﬂ','ƒ','4','⁄','g','ğ','ģ','ß','`','>','≥','«','»','‹','›','h','˝','-','i','í','î','ï','ì','ī','į','j','k','ķ','l','ĺ','ľ','ļ','<','≤','¬','◊','ł','m','¯','−','µ','×','n','ń','ň','ņ','9','≠','ñ','#','o','ó','ô','ö','œ','˛','ò','ő','ō','1','½','¼','¹','ª','º','ø','õ','p','¶','(',')','∂','%','.','·','‰','+','±','q','?','¿','"','„','“','”','‘','’','‚','\'','r','ŕ','√','ř','ŗ','®','˚','s','ś','š','ş','ș','§',';','7','6','/',' ','£','∑','t','ť','ţ','þ','3','¾','³','˜','™','2','²','u','ú','û','ü','ù','ű','ū','_','ų','ů','v','w','x','y','ý','ÿ','¥','z','ź','ž','ż','0'};func (_fe StdFont )Name ()string {return string (_fe ._acd .Name )};var _abf map[rune ]CharMetrics ;type GID =_ge .GID ;func (_daf *ttfParser )ParseCmap ()error {var _bae int64 ;if _eaf :=_daf .Seek ("\u0063\u006d\u0061\u0070");_eaf !=nil {return _eaf ;};_gf .Log .Trace ("\u0050a\u0072\u0073\u0065\u0043\u006d\u0061p");_daf .ReadUShort ();_ead :=int (_daf .ReadUShort ());_adf :=int64 (0);_dda :=int64 (0);_fgce :=int64 (0);for _fdc :=0;_fdc < _ead ;_fdc ++{_gcg :=_daf .ReadUShort ();_gae :=_daf .ReadUShort ();_bae =int64 (_daf .ReadULong ());if _gcg ==3&&_gae ==1{_dda =_bae ;}else if _gcg ==3&&_gae ==10{_fgce =_bae ;}else if _gcg ==1&&_gae ==0{_adf =_bae ;};};if _adf !=0{if _cbgd :=_daf .parseCmapVersion (_adf );_cbgd !=nil {return _cbgd ;};};if _dda !=0{if _egd :=_daf .parseCmapSubtable31 (_dda );_egd !=nil {return _egd ;};};if _fgce !=0{if _fagf :=_daf .parseCmapVersion (_fgce );_fagf !=nil {return _fagf ;};};if _dda ==0&&_adf ==0&&_fgce ==0{_gf .Log .Debug ("\u0074\u0074\u0066P\u0061\u0072\u0073\u0065\u0072\u002e\u0050\u0061\u0072\u0073\u0065\u0043\u006d\u0061\u0070\u002e\u0020\u004e\u006f\u0020\u0033\u0031\u002c\u0020\u0031\u0030\u002c\u0020\u00331\u0030\u0020\u0074\u0061\u0062\u006c\u0065\u002e");};return nil ;};func (_db StdFont )ToPdfObject ()_fb .PdfObject {_faf :=_fb .MakeDict ();_faf .Set ("\u0054\u0079\u0070\u0065",_fb .MakeName ("\u0046\u006f\u006e\u0074"));_faf .Set ("\u0053u\u0062\u0074\u0079\u0070\u0065",_fb .MakeName ("\u0054\u0079\u0070e\u0031"));_faf .Set ("\u0042\u0061\u0073\u0065\u0046\u006f\u006e\u0074",_fb .MakeName (_db .Name ()));_faf .Set ("\u0045\u006e\u0063\u006f\u0064\u0069\u006e\u0067",_db ._gfe .ToPdfObject ());return _fb .MakeIndirectObject (_faf );};type FontWeight int ;func (_fdb *ttfParser )readByte ()(_bcdc uint8 ){_g .Read (_fdb ._gbb ,_g .BigEndian ,&_bcdc );return _bcdc ;};func IsStdFont (name StdFontName )bool {_ ,_deg :=_ec [name ];return _deg };var _gded =[]GlyphName {"\u002en\u006f\u0074\u0064\u0065\u0066","\u002e\u006e\u0075l\u006c","\u006e\u006fn\u006d\u0061\u0072k\u0069\u006e\u0067\u0072\u0065\u0074\u0075\u0072\u006e","\u0073\u0070\u0061c\u0065","\u0065\u0078\u0063\u006c\u0061\u006d","\u0071\u0075\u006f\u0074\u0065\u0064\u0062\u006c","\u006e\u0075\u006d\u0062\u0065\u0072\u0073\u0069\u0067\u006e","\u0064\u006f\u006c\u006c\u0061\u0072","\u0070e\u0072\u0063\u0065\u006e\u0074","\u0061m\u0070\u0065\u0072\u0073\u0061\u006ed","q\u0075\u006f\u0074\u0065\u0073\u0069\u006e\u0067\u006c\u0065","\u0070a\u0072\u0065\u006e\u006c\u0065\u0066t","\u0070\u0061\u0072\u0065\u006e\u0072\u0069\u0067\u0068\u0074","\u0061\u0073\u0074\u0065\u0072\u0069\u0073\u006b","\u0070\u006c\u0075\u0073","\u0063\u006f\u006dm\u0061","\u0068\u0079\u0070\u0068\u0065\u006e","\u0070\u0065\u0072\u0069\u006f\u0064","\u0073\u006c\u0061s\u0068","\u007a\u0065\u0072\u006f","\u006f\u006e\u0065","\u0074\u0077\u006f","\u0074\u0068\u0072e\u0065","\u0066\u006f\u0075\u0072","\u0066\u0069\u0076\u0065","\u0073\u0069\u0078","\u0073\u0065\u0076e\u006e","\u0065\u0069\u0067h\u0074","\u006e\u0069\u006e\u0065","\u0063\u006f\u006co\u006e","\u0073e\u006d\u0069\u0063\u006f\u006c\u006fn","\u006c\u0065\u0073\u0073","\u0065\u0071\u0075a\u006c","\u0067r\u0065\u0061\u0074\u0065\u0072","\u0071\u0075\u0065\u0073\u0074\u0069\u006f\u006e","\u0061\u0074","\u0041","\u0042","\u0043","\u0044","\u0045","\u0046","\u0047","\u0048","\u0049","\u004a","\u004b","\u004c","\u004d","\u004e","\u004f","\u0050","\u0051","\u0052","\u0053","\u0054","\u0055","\u0056","\u0057","\u0058","\u0059","\u005a","b\u0072\u0061\u0063\u006b\u0065\u0074\u006c\u0065\u0066\u0074","\u0062a\u0063\u006b\u0073\u006c\u0061\u0073h","\u0062\u0072\u0061c\u006b\u0065\u0074\u0072\u0069\u0067\u0068\u0074","a\u0073\u0063\u0069\u0069\u0063\u0069\u0072\u0063\u0075\u006d","\u0075\u006e\u0064\u0065\u0072\u0073\u0063\u006f\u0072\u0065","\u0067\u0072\u0061v\u0065","\u0061","\u0062","\u0063","\u0064","\u0065","\u0066","\u0067","\u0068","\u0069","\u006a","\u006b","\u006c","\u006d","\u006e","\u006f","\u0070","\u0071","\u0072","\u0073","\u0074","\u0075","\u0076","\u0077","\u0078","\u0079","\u007a","\u0062r\u0061\u0063\u0065\u006c\u0065\u0066t","\u0062\u0061\u0072","\u0062\u0072\u0061\u0063\u0065\u0072\u0069\u0067\u0068\u0074","\u0061\u0073\u0063\u0069\u0069\u0074\u0069\u006c\u0064\u0065","\u0041d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0041\u0072\u0069n\u0067","\u0043\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0045\u0061\u0063\u0075\u0074\u0065","\u004e\u0074\u0069\u006c\u0064\u0065","\u004fd\u0069\u0065\u0072\u0065\u0073\u0069s","\u0055d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0061\u0061\u0063\u0075\u0074\u0065","\u0061\u0067\u0072\u0061\u0076\u0065","a\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0061d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0061\u0074\u0069\u006c\u0064\u0065","\u0061\u0072\u0069n\u0067","\u0063\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0065\u0061\u0063\u0075\u0074\u0065","\u0065\u0067\u0072\u0061\u0076\u0065","e\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0065d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0069\u0061\u0063\u0075\u0074\u0065","\u0069\u0067\u0072\u0061\u0076\u0065","i\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0069d\u0069\u0065\u0072\u0065\u0073\u0069s","\u006e\u0074\u0069\u006c\u0064\u0065","\u006f\u0061\u0063\u0075\u0074\u0065","\u006f\u0067\u0072\u0061\u0076\u0065","o\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u006fd\u0069\u0065\u0072\u0065\u0073\u0069s","\u006f\u0074\u0069\u006c\u0064\u0065","\u0075\u0061\u0063\u0075\u0074\u0065","\u0075\u0067\u0072\u0061\u0076\u0065","u\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0075d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0064\u0061\u0067\u0067\u0065\u0072","\u0064\u0065\u0067\u0072\u0065\u0065","\u0063\u0065\u006e\u0074","\u0073\u0074\u0065\u0072\u006c\u0069\u006e\u0067","\u0073e\u0063\u0074\u0069\u006f\u006e","\u0062\u0075\u006c\u006c\u0065\u0074","\u0070a\u0072\u0061\u0067\u0072\u0061\u0070h","\u0067\u0065\u0072\u006d\u0061\u006e\u0064\u0062\u006c\u0073","\u0072\u0065\u0067\u0069\u0073\u0074\u0065\u0072\u0065\u0064","\u0063o\u0070\u0079\u0072\u0069\u0067\u0068t","\u0074r\u0061\u0064\u0065\u006d\u0061\u0072k","\u0061\u0063\u0075t\u0065","\u0064\u0069\u0065\u0072\u0065\u0073\u0069\u0073","\u006e\u006f\u0074\u0065\u0071\u0075\u0061\u006c","\u0041\u0045","\u004f\u0073\u006c\u0061\u0073\u0068","\u0069\u006e\u0066\u0069\u006e\u0069\u0074\u0079","\u0070l\u0075\u0073\u006d\u0069\u006e\u0075s","\u006ce\u0073\u0073\u0065\u0071\u0075\u0061l","\u0067\u0072\u0065a\u0074\u0065\u0072\u0065\u0071\u0075\u0061\u006c","\u0079\u0065\u006e","\u006d\u0075","p\u0061\u0072\u0074\u0069\u0061\u006c\u0064\u0069\u0066\u0066","\u0073u\u006d\u006d\u0061\u0074\u0069\u006fn","\u0070r\u006f\u0064\u0075\u0063\u0074","\u0070\u0069","\u0069\u006e\u0074\u0065\u0067\u0072\u0061\u006c","o\u0072\u0064\u0066\u0065\u006d\u0069\u006e\u0069\u006e\u0065","\u006f\u0072\u0064m\u0061\u0073\u0063\u0075\u006c\u0069\u006e\u0065","\u004f\u006d\u0065g\u0061","\u0061\u0065","\u006f\u0073\u006c\u0061\u0073\u0068","\u0071\u0075\u0065s\u0074\u0069\u006f\u006e\u0064\u006f\u0077\u006e","\u0065\u0078\u0063\u006c\u0061\u006d\u0064\u006f\u0077\u006e","\u006c\u006f\u0067\u0069\u0063\u0061\u006c\u006e\u006f\u0074","\u0072a\u0064\u0069\u0063\u0061\u006c","\u0066\u006c\u006f\u0072\u0069\u006e","a\u0070\u0070\u0072\u006f\u0078\u0065\u0071\u0075\u0061\u006c","\u0044\u0065\u006ct\u0061","\u0067\u0075\u0069\u006c\u006c\u0065\u006d\u006f\u0074\u006c\u0065\u0066\u0074","\u0067\u0075\u0069\u006c\u006c\u0065\u006d\u006f\u0074r\u0069\u0067\u0068\u0074","\u0065\u006c\u006c\u0069\u0070\u0073\u0069\u0073","\u006e\u006fn\u0062\u0072\u0065a\u006b\u0069\u006e\u0067\u0073\u0070\u0061\u0063\u0065","\u0041\u0067\u0072\u0061\u0076\u0065","\u0041\u0074\u0069\u006c\u0064\u0065","\u004f\u0074\u0069\u006c\u0064\u0065","\u004f\u0045","\u006f\u0065","\u0065\u006e\u0064\u0061\u0073\u0068","\u0065\u006d\u0064\u0061\u0073\u0068","\u0071\u0075\u006ft\u0065\u0064\u0062\u006c\u006c\u0065\u0066\u0074","\u0071\u0075\u006f\u0074\u0065\u0064\u0062\u006c\u0072\u0069\u0067\u0068\u0074","\u0071u\u006f\u0074\u0065\u006c\u0065\u0066t","\u0071\u0075\u006f\u0074\u0065\u0072\u0069\u0067\u0068\u0074","\u0064\u0069\u0076\u0069\u0064\u0065","\u006co\u007a\u0065\u006e\u0067\u0065","\u0079d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0059d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0066\u0072\u0061\u0063\u0074\u0069\u006f\u006e","\u0063\u0075\u0072\u0072\u0065\u006e\u0063\u0079","\u0067\u0075\u0069\u006c\u0073\u0069\u006e\u0067\u006c\u006c\u0065\u0066\u0074","\u0067\u0075\u0069\u006c\u0073\u0069\u006e\u0067\u006cr\u0069\u0067\u0068\u0074","\u0066\u0069","\u0066\u006c","\u0064a\u0067\u0067\u0065\u0072\u0064\u0062l","\u0070\u0065\u0072\u0069\u006f\u0064\u0063\u0065\u006et\u0065\u0072\u0065\u0064","\u0071\u0075\u006f\u0074\u0065\u0073\u0069\u006e\u0067l\u0062\u0061\u0073\u0065","\u0071\u0075\u006ft\u0065\u0064\u0062\u006c\u0062\u0061\u0073\u0065","p\u0065\u0072\u0074\u0068\u006f\u0075\u0073\u0061\u006e\u0064","A\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","E\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0041\u0061\u0063\u0075\u0074\u0065","\u0045d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0045\u0067\u0072\u0061\u0076\u0065","\u0049\u0061\u0063\u0075\u0074\u0065","I\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0049d\u0069\u0065\u0072\u0065\u0073\u0069s","\u0049\u0067\u0072\u0061\u0076\u0065","\u004f\u0061\u0063\u0075\u0074\u0065","O\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0061\u0070\u0070l\u0065","\u004f\u0067\u0072\u0061\u0076\u0065","\u0055\u0061\u0063\u0075\u0074\u0065","U\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0055\u0067\u0072\u0061\u0076\u0065","\u0064\u006f\u0074\u006c\u0065\u0073\u0073\u0069","\u0063\u0069\u0072\u0063\u0075\u006d\u0066\u006c\u0065\u0078","\u0074\u0069\u006cd\u0065","\u006d\u0061\u0063\u0072\u006f\u006e","\u0062\u0072\u0065v\u0065","\u0064o\u0074\u0061\u0063\u0063\u0065\u006et","\u0072\u0069\u006e\u0067","\u0063e\u0064\u0069\u006c\u006c\u0061","\u0068\u0075\u006eg\u0061\u0072\u0075\u006d\u006c\u0061\u0075\u0074","\u006f\u0067\u006f\u006e\u0065\u006b","\u0063\u0061\u0072o\u006e","\u004c\u0073\u006c\u0061\u0073\u0068","\u006c\u0073\u006c\u0061\u0073\u0068","\u0053\u0063\u0061\u0072\u006f\u006e","\u0073\u0063\u0061\u0072\u006f\u006e","\u005a\u0063\u0061\u0072\u006f\u006e","\u007a\u0063\u0061\u0072\u006f\u006e","\u0062r\u006f\u006b\u0065\u006e\u0062\u0061r","\u0045\u0074\u0068","\u0065\u0074\u0068","\u0059\u0061\u0063\u0075\u0074\u0065","\u0079\u0061\u0063\u0075\u0074\u0065","\u0054\u0068\u006fr\u006e","\u0074\u0068\u006fr\u006e","\u006d\u0069\u006eu\u0073","\u006d\u0075\u006c\u0074\u0069\u0070\u006c\u0079","o\u006e\u0065\u0073\u0075\u0070\u0065\u0072\u0069\u006f\u0072","t\u0077\u006f\u0073\u0075\u0070\u0065\u0072\u0069\u006f\u0072","\u0074\u0068\u0072\u0065\u0065\u0073\u0075\u0070\u0065\u0072\u0069\u006f\u0072","\u006fn\u0065\u0068\u0061\u006c\u0066","\u006f\u006e\u0065\u0071\u0075\u0061\u0072\u0074\u0065\u0072","\u0074\u0068\u0072\u0065\u0065\u0071\u0075\u0061\u0072\u0074\u0065\u0072\u0073","\u0066\u0072\u0061n\u0063","\u0047\u0062\u0072\u0065\u0076\u0065","\u0067\u0062\u0072\u0065\u0076\u0065","\u0049\u0064\u006f\u0074\u0061\u0063\u0063\u0065\u006e\u0074","\u0053\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0073\u0063\u0065\u0064\u0069\u006c\u006c\u0061","\u0043\u0061\u0063\u0075\u0074\u0065","\u0063\u0061\u0063\u0075\u0074\u0065","\u0043\u0063\u0061\u0072\u006f\u006e","\u0063\u0063\u0061\u0072\u006f\u006e","\u0064\u0063\u0072\u006f\u0061\u0074"};var _ce map[rune ]CharMetrics ;func (_bdgd *ttfParser )Seek (tag string )error {_dca ,_dfd :=_bdgd ._cba [tag ];if !_dfd {return _d .Errorf ("\u0074\u0061\u0062\u006ce \u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u003a\u0020\u0025\u0073",tag );};_bdgd ._gbb .Seek (int64 (_dca ),_f .SeekStart );return nil ;};type Font interface{Encoder ()_ge .TextEncoder ;GetRuneMetrics (_be rune )(CharMetrics ,bool );};
\ No newline at end of file
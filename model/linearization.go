@@ -0,0 +1,960 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/internal/bitwise"
+)
+
+// SetLinearized sets whether the document is written as a linearized file, also known as
+// Fast Web View, which allows viewers to display the first page before the whole file is
+// received (see Annex F "Linearized PDF" of PDF32000_2008). Linearization can be combined
+// with object streams (optimize.ObjectStreams) and cross-reference streams but not with
+// incremental updates.
+func (w *PdfWriter) SetLinearized(linearized bool) {
+	w.linearized = linearized
+}
+
+// linearUnit is an object written at the top level of a linearized file: an indirect
+// object, a stream or an object stream containing `members`.
+type linearUnit struct {
+	object  core.PdfObject
+	members []core.PdfObject
+	data    []byte
+	offset  int64
+}
+
+// number returns the object number of the unit.
+func (u *linearUnit) number() int64 {
+	switch t := u.object.(type) {
+	case *core.PdfIndirectObject:
+		return t.ObjectNumber
+	case *core.PdfObjectStream:
+		return t.ObjectNumber
+	case *core.PdfObjectStreams:
+		return t.ObjectNumber
+	}
+	return 0
+}
+
+// firstNumber returns the lowest object number of the unit. Object stream members are
+// numbered before the object stream.
+func (u *linearUnit) firstNumber() int64 {
+	if len(u.members) > 0 {
+		return u.members[0].(*core.PdfIndirectObject).ObjectNumber
+	}
+	return u.number()
+}
+
+func (u *linearUnit) numObjects() int {
+	return len(u.members) + 1
+}
+
+// linearLayout holds the parts of a linearized file (see section F.3 "Linearized PDF
+// Document Structure" of PDF32000_2008).
+type linearLayout struct {
+	// document holds the catalog and the other document-level objects (part 4).
+	document []*linearUnit
+
+	// pages holds the objects of each page, starting with the page object. The first page
+	// section (part 6) includes all objects required to display the first page.
+	pages [][]*linearUnit
+
+	// shared holds the objects shared by pages other than the first (part 8) and other
+	// holds the remaining objects (part 9).
+	shared []*linearUnit
+	other  []*linearUnit
+
+	// contents and sharedRefs hold the content streams and the shared objects referenced
+	// by each page.
+	contents   []map[core.PdfObject]bool
+	sharedRefs [][]core.PdfObject
+
+	unitOf map[core.PdfObject]*linearUnit
+}
+
+// walkLinearRefs calls `fn` for the top level objects referenced by the direct object `obj`.
+func walkLinearRefs(obj core.PdfObject, objects map[core.PdfObject]bool, fn func(core.PdfObject)) {
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject, *core.PdfObjectStream:
+		if objects[t] {
+			fn(t)
+		}
+	case *core.PdfObjectDictionary:
+		for _, key := range t.Keys() {
+			walkLinearRefs(t.Get(key), objects, fn)
+		}
+	case *core.PdfObjectArray:
+		for _, elem := range t.Elements() {
+			walkLinearRefs(elem, objects, fn)
+		}
+	}
+}
+
+// linearClosure returns the objects reachable from `seeds` in depth-first order, without
+// going through the objects in `skip` other than the seeds.
+func linearClosure(seeds []core.PdfObject, objects map[core.PdfObject]bool,
+	skip map[core.PdfObject]bool) []core.PdfObject {
+	var closure []core.PdfObject
+	visited := map[core.PdfObject]bool{}
+	var visit func(obj core.PdfObject)
+	visit = func(obj core.PdfObject) {
+		if visited[obj] {
+			return
+		}
+		visited[obj] = true
+		closure = append(closure, obj)
+		var contents core.PdfObject
+		switch t := obj.(type) {
+		case *core.PdfIndirectObject:
+			contents = t.PdfObject
+		case *core.PdfObjectStream:
+			contents = t.PdfObjectDictionary
+		}
+		walkLinearRefs(contents, objects, func(ref core.PdfObject) {
+			if !skip[ref] {
+				visit(ref)
+			}
+		})
+	}
+	for _, seed := range seeds {
+		visit(seed)
+	}
+	return closure
+}
+
+// linearUnits groups `objects` into the units of a section. When `pack` is set, the
+// objects which can be compressed are stored in an object stream placed first.
+func (w *PdfWriter) linearUnits(objects []core.PdfObject, pack bool) []*linearUnit {
+	var packed, units []*linearUnit
+	stream := &linearUnit{}
+	for _, obj := range objects {
+		if ind, ok := obj.(*core.PdfIndirectObject); ok && pack && obj != w._eebbg {
+			if _, isSig := ind.PdfObject.(*pdfSignDictionary); !isSig {
+				stream.members = append(stream.members, obj)
+				continue
+			}
+		}
+		units = append(units, &linearUnit{object: obj})
+	}
+	switch len(stream.members) {
+	case 0:
+	case 1:
+		packed = append(packed, &linearUnit{object: stream.members[0]})
+	default:
+		stream.object = core.MakeObjectStreams(stream.members...)
+		packed = append(packed, stream)
+	}
+	return append(packed, units...)
+}
+
+// linearLayout classifies the objects to write into the parts of a linearized file.
+func (w *PdfWriter) linearLayout(objects []core.PdfObject, pack bool) (*linearLayout, error) {
+	inSet := make(map[core.PdfObject]bool, len(objects))
+	for _, obj := range objects {
+		inSet[obj] = true
+	}
+	// The objects were copied before writing, the catalog refers to the copies.
+	catalog, ok := core.GetDict(w._bdbdfg)
+	if !ok {
+		return nil, errors.New("invalid catalog object")
+	}
+	pagesRoot := catalog.Get("Pages")
+	pagesDict, ok := core.GetDict(pagesRoot)
+	if !ok {
+		return nil, errors.New("invalid Pages object")
+	}
+	kids, _ := core.GetArray(pagesDict.Get("Kids"))
+	var pages []core.PdfObject
+	skip := map[core.PdfObject]bool{}
+	if kids != nil {
+		for _, kid := range kids.Elements() {
+			if inSet[kid] && !skip[kid] {
+				pages = append(pages, kid)
+				skip[kid] = true
+			}
+		}
+	}
+	if len(pages) == 0 {
+		return nil, errors.New("linearization requires at least one page")
+	}
+
+	// Document-level objects: the catalog, the page tree, the encryption dictionary and the
+	// objects required when opening the document.
+	assigned := map[core.PdfObject]bool{}
+	var document []core.PdfObject
+	for _, obj := range []core.PdfObject{w._bdbdfg, pagesRoot, w._eebbg} {
+		if obj != nil && inSet[obj] && !assigned[obj] {
+			document = append(document, obj)
+			assigned[obj] = true
+			skip[obj] = true
+		}
+	}
+	openKeys := []core.PdfObjectName{"ViewerPreferences", "OpenAction", "AcroForm", "Threads"}
+	if mode, ok := core.GetName(catalog.Get("PageMode")); ok && *mode == "UseOutlines" {
+		openKeys = append(openKeys, "Outlines")
+	}
+	for _, key := range openKeys {
+		var seeds []core.PdfObject
+		walkLinearRefs(catalog.Get(key), inSet, func(ref core.PdfObject) {
+			if !skip[ref] {
+				seeds = append(seeds, ref)
+			}
+		})
+		for _, obj := range linearClosure(seeds, inSet, skip) {
+			document = append(document, obj)
+			assigned[obj] = true
+			skip[obj] = true
+		}
+	}
+
+	// Page objects. Objects required by the first page belong to the first page section,
+	// the objects used by a single other page belong to its section and the others are
+	// shared.
+	closures := make([][]core.PdfObject, len(pages))
+	for i, page := range pages {
+		closures[i] = linearClosure([]core.PdfObject{page}, inSet, skip)
+	}
+	inFirst := map[core.PdfObject]bool{}
+	for _, obj := range closures[0] {
+		inFirst[obj] = true
+	}
+	users := map[core.PdfObject]int{}
+	var shared []core.PdfObject
+	for _, closure := range closures[1:] {
+		for _, obj := range closure {
+			users[obj]++
+			if users[obj] == 2 && !inFirst[obj] {
+				shared = append(shared, obj)
+			}
+		}
+	}
+	isShared := func(obj core.PdfObject) bool {
+		return users[obj] > 1 || inFirst[obj] && users[obj] > 0
+	}
+
+	layout := &linearLayout{
+		pages:      make([][]*linearUnit, len(pages)),
+		contents:   make([]map[core.PdfObject]bool, len(pages)),
+		sharedRefs: make([][]core.PdfObject, len(pages)),
+		unitOf:     map[core.PdfObject]*linearUnit{},
+	}
+	for i, closure := range closures {
+		var section []core.PdfObject
+		for _, obj := range closure {
+			if isShared(obj) {
+				layout.sharedRefs[i] = append(layout.sharedRefs[i], obj)
+			}
+			if !assigned[obj] && (i == 0 || !inFirst[obj] && users[obj] == 1) {
+				section = append(section, obj)
+				assigned[obj] = true
+			}
+		}
+		layout.pages[i] = w.linearUnits(section, pack)
+		layout.contents[i] = map[core.PdfObject]bool{}
+		if dict, ok := core.GetDict(pages[i]); ok {
+			walkLinearRefs(dict.Get("Contents"), inSet, func(ref core.PdfObject) {
+				layout.contents[i][ref] = true
+			})
+		}
+	}
+	for _, obj := range shared {
+		assigned[obj] = true
+	}
+	var other []core.PdfObject
+	for _, obj := range objects {
+		if !assigned[obj] {
+			other = append(other, obj)
+		}
+	}
+	layout.document = w.linearUnits(document, pack)
+	layout.shared = w.linearUnits(shared, pack)
+	layout.other = w.linearUnits(other, pack)
+
+	for _, units := range layout.allUnits() {
+		for _, u := range units {
+			layout.unitOf[u.object] = u
+			for _, member := range u.members {
+				layout.unitOf[member] = u
+			}
+		}
+	}
+	return layout, nil
+}
+
+// allUnits returns the units of the layout in file order, excluding the hint stream.
+func (l *linearLayout) allUnits() [][]*linearUnit {
+	parts := [][]*linearUnit{l.document}
+	parts = append(parts, l.pages...)
+	return append(parts, l.shared, l.other)
+}
+
+// numberLinearUnits assigns object numbers to `units` starting at `next` and returns the
+// next free object number.
+func numberLinearUnits(units []*linearUnit, next int64) int64 {
+	for _, u := range units {
+		for _, member := range u.members {
+			ind := member.(*core.PdfIndirectObject)
+			ind.ObjectNumber = next
+			ind.GenerationNumber = 0
+			next++
+		}
+		switch t := u.object.(type) {
+		case *core.PdfIndirectObject:
+			t.ObjectNumber, t.GenerationNumber = next, 0
+		case *core.PdfObjectStream:
+			t.ObjectNumber, t.GenerationNumber = next, 0
+		case *core.PdfObjectStreams:
+			t.ObjectNumber, t.GenerationNumber = next, 0
+		}
+		next++
+	}
+	return next
+}
+
+// serializeObject returns the serialized form of object `num` as written by writeObject.
+func (w *PdfWriter) serializeObject(num int, obj core.PdfObject) ([]byte, error) {
+	var buf bytes.Buffer
+	out, offset := w._fcadd, w._dfdcd
+	w._fcadd, w._dfdcd = bufio.NewWriter(&buf), 0
+	w.writeObject(num, obj)
+	if w._geac == nil {
+		w._geac = w._fcadd.Flush()
+	}
+	w._fcadd, w._dfdcd = out, offset
+	return buf.Bytes(), w._geac
+}
+
+// writeLinearized writes the document as a linearized file. It is called by Write in place
+// of the regular output once the objects to write are collected and optimized.
+func (w *PdfWriter) writeLinearized(useXrefStream bool) error {
+	if w._bcdd {
+		return errors.New("linearization is not supported for incremental updates")
+	}
+
+	// Objects packed into object streams by the optimizer are repacked by section.
+	var objects []core.PdfObject
+	seen := map[core.PdfObject]bool{}
+	pack := false
+	for _, obj := range w._aage {
+		if streams, ok := obj.(*core.PdfObjectStreams); ok {
+			pack = true
+			for _, elem := range streams.Elements() {
+				if !seen[elem] {
+					objects = append(objects, elem)
+					seen[elem] = true
+				}
+			}
+			continue
+		}
+		if !seen[obj] {
+			objects = append(objects, obj)
+			seen[obj] = true
+		}
+	}
+	if pack {
+		useXrefStream = true
+	}
+	if useXrefStream && w._cfebb == 1 && w._gbcce < 5 {
+		w._gbcce = 5
+	}
+
+	layout, err := w.linearLayout(objects, pack)
+	if err != nil {
+		return err
+	}
+
+	// The objects of the first page section have the highest numbers, the other pages are
+	// numbered from 1 in page order.
+	next := int64(1)
+	for _, units := range layout.pages[1:] {
+		next = numberLinearUnits(units, next)
+	}
+	next = numberLinearUnits(layout.shared, next)
+	next = numberLinearUnits(layout.other, next)
+	mainXrefNum := next
+	if useXrefStream {
+		next++
+	}
+	linNum := next
+	next = numberLinearUnits(layout.document, next+1)
+	hintNum := next
+	next = numberLinearUnits(layout.pages[0], next+1)
+	if useXrefStream {
+		// The first page cross-reference stream.
+		next++
+	}
+	size := next
+
+	w._ffede = map[int]crossReference{0: {Type: 0, ObjectNumber: 0, Generation: 0xFFFF}}
+	for _, units := range layout.allUnits() {
+		for _, u := range units {
+			num := u.number()
+			if w._ecfag != nil && u.object != w._eebbg {
+				if err := w._ecfag.Encrypt(u.object, num, 0); err != nil {
+					common.Log.Debug("ERROR: Failed encrypting (%s)", err)
+					return err
+				}
+			}
+			if u.data, err = w.serializeObject(int(num), u.object); err != nil {
+				return err
+			}
+		}
+	}
+
+	header := fmt.Sprintf("%%PDF-%d.%d\n", w._cfebb, w._gbcce) + "%âãÏÓ\n"
+	linDict := func(length, hintOffset, hintLength, firstPageEnd, mainXref int64) string {
+		return fmt.Sprintf("%d 0 obj\n<</Linearized 1/L %10d/H [%10d %10d]/O %d/E %10d/N %d/T %10d>>\nendobj\n",
+			linNum, length, hintOffset, hintLength, layout.pages[0][0].firstNumber(), firstPageEnd,
+			len(layout.pages), mainXref)
+	}
+	linOffset := w._ccdaf + int64(len(header))
+	firstXrefOffset := linOffset + int64(len(linDict(0, 0, 0, 0, 0)))
+	firstXref := func(hintOffset, mainXref int64) ([]byte, error) {
+		w._ffede[int(linNum)] = crossReference{Type: 1, Offset: linOffset}
+		w._ffede[int(hintNum)] = crossReference{Type: 1, Offset: hintOffset}
+		for _, units := range [][]*linearUnit{layout.document, layout.pages[0]} {
+			for _, u := range units {
+				w._ffede[int(u.number())] = crossReference{Type: 1, Offset: u.offset}
+			}
+		}
+		return w.linearFirstXref(linNum, size, firstXrefOffset, mainXref, useXrefStream)
+	}
+	xref, err := firstXref(0, 0)
+	if err != nil {
+		return err
+	}
+
+	// The hint tables depend on the offsets of the objects following the hint stream. The
+	// hint stream data is padded to the size reserved for it until the layout is stable.
+	var hint []byte
+	var hintOffset, firstPageEnd, mainXref int64
+	for reserved := 0; ; {
+		placeholder, err := w.linearHintStream(hintNum, make([]byte, reserved), 0)
+		if err != nil {
+			return err
+		}
+		pos := firstXrefOffset + int64(len(xref))
+		for i, units := range layout.allUnits() {
+			if i == 1 {
+				hintOffset = pos
+				pos += int64(len(placeholder))
+			}
+			for _, u := range units {
+				u.offset = pos
+				pos += int64(len(u.data))
+			}
+			if i == 1 {
+				firstPageEnd = pos
+			}
+		}
+		mainXref = pos
+		data, sharedOffset := layout.hintTables(hintOffset, int64(len(placeholder)))
+		if len(data) > reserved {
+			reserved = len(data)
+			continue
+		}
+		data = append(data, make([]byte, reserved-len(data))...)
+		if hint, err = w.linearHintStream(hintNum, data, sharedOffset); err != nil {
+			return err
+		}
+		break
+	}
+
+	// Main cross-reference section.
+	for _, units := range layout.allUnits() {
+		for _, u := range units {
+			w._ffede[int(u.number())] = crossReference{Type: 1, Offset: u.offset}
+		}
+	}
+	var mainSection bytes.Buffer
+	tableOffset := mainXref
+	if useXrefStream {
+		w._ffede[int(mainXrefNum)] = crossReference{Type: 1, Offset: mainXref}
+		entries := w.linearXrefEntries(0, int(mainXrefNum)+1)
+		stream, err := core.MakeStream(entries, core.NewFlateEncoder())
+		if err != nil {
+			return err
+		}
+		stream.Set("Type", core.MakeName("XRef"))
+		stream.Set("W", core.MakeArray(core.MakeInteger(1), core.MakeInteger(4), core.MakeInteger(2)))
+		stream.Set("Index", core.MakeArray(core.MakeInteger(0), core.MakeInteger(mainXrefNum+1)))
+		stream.Set("Size", core.MakeInteger(mainXrefNum+1))
+		fmt.Fprintf(&mainSection, "%d 0 obj\n%s\nstream\n", mainXrefNum, stream.PdfObjectDictionary.WriteString())
+		mainSection.Write(stream.Stream)
+		mainSection.WriteString("\nendstream\nendobj\n")
+	} else {
+		fmt.Fprintf(&mainSection, "xref\n0 %d", linNum)
+		tableOffset = mainXref + int64(mainSection.Len())
+		mainSection.WriteString("\n")
+		for num := 0; num < int(linNum); num++ {
+			if ref, ok := w._ffede[num]; ok && ref.Type == 1 {
+				fmt.Fprintf(&mainSection, "%.10d %.5d n\r\n", ref.Offset, 0)
+			} else {
+				fmt.Fprintf(&mainSection, "%.10d %.5d f\r\n", 0, 65535)
+			}
+		}
+		fmt.Fprintf(&mainSection, "trailer\n<</Size %d>>\n", linNum)
+	}
+	fmt.Fprintf(&mainSection, "startxref\n%d\n%%%%EOF\n", firstXrefOffset)
+	length := mainXref + int64(mainSection.Len())
+
+	if xref, err = firstXref(hintOffset, mainXref); err != nil {
+		return err
+	}
+	w._dfdcd = w._ccdaf
+	w.writeString(header)
+	w.writeString(linDict(length, hintOffset, int64(len(hint)), firstPageEnd, tableOffset))
+	w.writeBytes(xref)
+	for i, units := range layout.allUnits() {
+		if i == 1 {
+			w.writeBytes(hint)
+		}
+		for _, u := range units {
+			w.writeBytes(u.data)
+		}
+	}
+	w.writeBytes(mainSection.Bytes())
+	if w._geac == nil && w._dfdcd != length {
+		w._geac = fmt.Errorf("linearized length mismatch: %d != %d", w._dfdcd, length)
+	}
+	if w._geac == nil {
+		w._geac = w._fcadd.Flush()
+	}
+	return w._geac
+}
+
+// linearXrefEntries returns the cross-reference stream entries of objects [first, end)
+// with field widths [1 4 2].
+func (w *PdfWriter) linearXrefEntries(first, end int) []byte {
+	var buf bytes.Buffer
+	for num := first; num < end; num++ {
+		var entry [7]byte
+		ref, ok := w._ffede[num]
+		switch {
+		case !ok || ref.Type == 0:
+			entry[5], entry[6] = 0xFF, 0xFF
+		case ref.Type == 1:
+			entry[0] = 1
+			putUint32(entry[1:5], uint32(ref.Offset))
+		case ref.Type == 2:
+			entry[0] = 2
+			putUint32(entry[1:5], uint32(ref.ObjectNumber))
+			entry[5], entry[6] = byte(ref.Index>>8), byte(ref.Index)
+		}
+		buf.Write(entry[:])
+	}
+	return buf.Bytes()
+}
+
+func putUint32(b []byte, v uint32) {
+	b[0], b[1], b[2], b[3] = byte(v>>24), byte(v>>16), byte(v>>8), byte(v)
+}
+
+// linearFirstXref returns the first page cross-reference section, located at `offset`,
+// for objects [first, size). Its size does not depend on the offsets of the objects.
+func (w *PdfWriter) linearFirstXref(first, size int64, offset, mainXref int64,
+	useXrefStream bool) ([]byte, error) {
+	trailer := core.MakeDict()
+	trailer.Set("Size", core.MakeInteger(size))
+	trailer.Set("Root", w._bdbdfg)
+	trailer.Set("Info", w._caefd)
+	if w._ecfag != nil {
+		trailer.Set("Encrypt", w._eebbg)
+		trailer.Set("ID", w._acddd)
+	}
+	withPrev := func(dict *core.PdfObjectDictionary) string {
+		s := dict.WriteString()
+		return s[:len(s)-2] + fmt.Sprintf("/Prev %10d>>", mainXref)
+	}
+
+	var buf bytes.Buffer
+	if useXrefStream {
+		w._ffede[int(size-1)] = crossReference{Type: 1, Offset: offset}
+		entries := w.linearXrefEntries(int(first), int(size))
+		trailer.Set("Type", core.MakeName("XRef"))
+		trailer.Set("W", core.MakeArray(core.MakeInteger(1), core.MakeInteger(4), core.MakeInteger(2)))
+		trailer.Set("Index", core.MakeArray(core.MakeInteger(first), core.MakeInteger(size-first)))
+		trailer.Set("Length", core.MakeInteger(int64(len(entries))))
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nstream\n", size-1, withPrev(trailer))
+		buf.Write(entries)
+		buf.WriteString("\nendstream\nendobj\n")
+	} else {
+		fmt.Fprintf(&buf, "xref\n%d %d\n", first, size-first)
+		for num := first; num < size; num++ {
+			fmt.Fprintf(&buf, "%.10d %.5d n\r\n", w._ffede[int(num)].Offset, 0)
+		}
+		fmt.Fprintf(&buf, "trailer\n%s\n", withPrev(trailer))
+	}
+	buf.WriteString("startxref\n0\n%%EOF\n")
+	return buf.Bytes(), nil
+}
+
+// linearHintStream returns the serialized primary hint stream with hint tables `data`.
+// The size of the result only depends on the length of `data`.
+func (w *PdfWriter) linearHintStream(num int64, data []byte, sharedOffset int) ([]byte, error) {
+	stream, err := core.MakeStream(data, nil)
+	if err != nil {
+		return nil, err
+	}
+	stream.ObjectNumber = num
+	if w._ecfag != nil {
+		if err := w._ecfag.Encrypt(stream, num, 0); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d 0 obj\n<</Length %10d/S %10d>>\nstream\n", num, len(stream.Stream), sharedOffset)
+	buf.Write(stream.Stream)
+	buf.WriteString("\nendstream\nendobj\n")
+	return buf.Bytes(), nil
+}
+
+// bitsFor returns the number of bits required to represent `v`.
+func bitsFor(v int64) int {
+	n := 0
+	for ; v > 0; v >>= 1 {
+		n++
+	}
+	return n
+}
+
+// hintTables returns the page offset hint table followed by the shared object hint table
+// (see section F.4 "Hint Tables" of PDF32000_2008) and the offset of the latter. The offsets
+// in the tables are computed as if the primary hint stream, located at `hintOffset` with
+// length `hintLength`, were not in the file (see section F.3).
+func (l *linearLayout) hintTables(hintOffset, hintLength int64) ([]byte, int) {
+	adjusted := func(offset int64) int64 {
+		if offset >= hintOffset {
+			return offset - hintLength
+		}
+		return offset
+	}
+	// Shared object groups: the units of the first page section followed by the units of
+	// the shared objects section.
+	groups := append(append([]*linearUnit{}, l.pages[0]...), l.shared...)
+	groupIndex := map[*linearUnit]int64{}
+	for i, u := range groups {
+		groupIndex[u] = int64(i)
+	}
+
+	n := len(l.pages)
+	nobjects := make([]int64, n)
+	lengths := make([]int64, n)
+	contentOffsets := make([]int64, n)
+	contentLengths := make([]int64, n)
+	refs := make([][]int64, n)
+	for i, units := range l.pages {
+		start := units[0].offset
+		contentStart := int64(-1)
+		for _, u := range units {
+			nobjects[i] += int64(u.numObjects())
+			lengths[i] += int64(len(u.data))
+			if l.contents[i][u.object] {
+				if contentStart < 0 {
+					contentStart = u.offset
+				}
+				contentLengths[i] += int64(len(u.data))
+			}
+		}
+		if contentStart >= 0 {
+			contentOffsets[i] = contentStart - start
+		}
+		seen := map[int64]bool{}
+		for _, obj := range l.sharedRefs[i] {
+			if index, ok := groupIndex[l.unitOf[obj]]; ok && !seen[index] {
+				refs[i] = append(refs[i], index)
+				seen[index] = true
+			}
+		}
+	}
+	minMax := func(values []int64) (int64, int64) {
+		lo, hi := values[0], values[0]
+		for _, v := range values {
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+		}
+		return lo, hi
+	}
+	minObjects, maxObjects := minMax(nobjects)
+	minLength, maxLength := minMax(lengths)
+	minContentOffset, maxContentOffset := minMax(contentOffsets)
+	minContentLength, maxContentLength := minMax(contentLengths)
+	var maxRefs, maxIndex int64
+	for _, pageRefs := range refs {
+		if int64(len(pageRefs)) > maxRefs {
+			maxRefs = int64(len(pageRefs))
+		}
+		for _, index := range pageRefs {
+			if index > maxIndex {
+				maxIndex = index
+			}
+		}
+	}
+
+	bw := bitwise.BufferedMSB()
+	put := func(v int64, bits int) {
+		bw.WriteBits(uint64(v), bits)
+	}
+	objectBits, lengthBits := bitsFor(maxObjects-minObjects), bitsFor(maxLength-minLength)
+	offsetBits, contentBits := bitsFor(maxContentOffset-minContentOffset), bitsFor(maxContentLength-minContentLength)
+	refsBits, indexBits := bitsFor(maxRefs), bitsFor(maxIndex)
+	put(minObjects, 32)
+	put(adjusted(l.pages[0][0].offset), 32)
+	put(int64(objectBits), 16)
+	put(minLength, 32)
+	put(int64(lengthBits), 16)
+	put(minContentOffset, 32)
+	put(int64(offsetBits), 16)
+	put(minContentLength, 32)
+	put(int64(contentBits), 16)
+	put(int64(refsBits), 16)
+	put(int64(indexBits), 16)
+	put(0, 16)
+	put(1, 16)
+	// Each item is written for all pages in sequence starting at a byte boundary.
+	for _, item := range []func(i int){
+		func(i int) { put(nobjects[i]-minObjects, objectBits) },
+		func(i int) { put(lengths[i]-minLength, lengthBits) },
+		func(i int) { put(int64(len(refs[i])), refsBits) },
+		func(i int) {
+			for _, index := range refs[i] {
+				put(index, indexBits)
+			}
+		},
+		func(i int) {},
+		func(i int) { put(contentOffsets[i]-minContentOffset, offsetBits) },
+		func(i int) { put(contentLengths[i]-minContentLength, contentBits) },
+	} {
+		for i := 0; i < n; i++ {
+			item(i)
+		}
+		bw.FinishByte()
+	}
+	sharedOffset := len(bw.Data())
+
+	groupObjects := make([]int64, len(groups))
+	groupLengths := make([]int64, len(groups))
+	for i, u := range groups {
+		groupObjects[i] = int64(u.numObjects() - 1)
+		groupLengths[i] = int64(len(u.data))
+	}
+	var firstShared, firstSharedOffset int64
+	if len(l.shared) > 0 {
+		firstShared, firstSharedOffset = l.shared[0].firstNumber(), adjusted(l.shared[0].offset)
+	}
+	_, maxGroupObjects := minMax(groupObjects)
+	minGroupLength, maxGroupLength := minMax(groupLengths)
+	groupObjectBits, groupLengthBits := bitsFor(maxGroupObjects), bitsFor(maxGroupLength-minGroupLength)
+	put(firstShared, 32)
+	put(firstSharedOffset, 32)
+	put(int64(len(l.pages[0])), 32)
+	put(int64(len(groups)), 32)
+	put(int64(groupObjectBits), 16)
+	put(minGroupLength, 32)
+	put(int64(groupLengthBits), 16)
+	for _, item := range []func(i int){
+		func(i int) { put(groupLengths[i]-minGroupLength, groupLengthBits) },
+		func(i int) { put(0, 1) },
+		func(i int) { put(groupObjects[i], groupObjectBits) },
+	} {
+		for i := range groups {
+			item(i)
+		}
+		bw.FinishByte()
+	}
+	return bw.Data(), sharedOffset
+}
+
+// linearPageHints holds the values of the page offset hint table needed to locate pages.
+type linearPageHints struct {
+	firstPageOffset int64
+	nobjects        []int64
+	lengths         []int64
+
+	firstShared       int64
+	firstSharedOffset int64
+	numShared         int64
+}
+
+// decodeLinearHints decodes the page offset hint table of `numPages` pages and the header
+// of the shared object hint table at `sharedOffset` from the primary hint stream `data`.
+func decodeLinearHints(data []byte, sharedOffset int, numPages int) (*linearPageHints, error) {
+	if sharedOffset < 0 || sharedOffset > len(data) {
+		return nil, errors.New("invalid shared object hint table offset")
+	}
+	br := bitwise.NewReader(data)
+	var err error
+	get := func(bits int) int64 {
+		if bits == 0 || err != nil {
+			return 0
+		}
+		var v uint64
+		v, err = br.ReadBits(byte(bits))
+		return int64(v)
+	}
+	hints := &linearPageHints{}
+	minObjects := get(32)
+	hints.firstPageOffset = get(32)
+	objectBits := int(get(16))
+	minLength := get(32)
+	lengthBits := int(get(16))
+	if err != nil {
+		return nil, err
+	}
+	if objectBits > 32 || lengthBits > 32 {
+		return nil, errors.New("invalid page offset hint table")
+	}
+	br.Seek(36, 0)
+	hints.nobjects = make([]int64, numPages)
+	for i := range hints.nobjects {
+		hints.nobjects[i] = minObjects + get(objectBits)
+	}
+	br.Align()
+	hints.lengths = make([]int64, numPages)
+	for i := range hints.lengths {
+		hints.lengths[i] = minLength + get(lengthBits)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid page offset hint table: %v", err)
+	}
+
+	br.Seek(int64(sharedOffset), 0)
+	hints.firstShared = get(32)
+	hints.firstSharedOffset = get(32)
+	numFirst := get(32)
+	hints.numShared = get(32) - numFirst
+	if err != nil {
+		return nil, fmt.Errorf("invalid shared object hint table: %v", err)
+	}
+	return hints, nil
+}
+
+// ValidateLinearization checks the linearization parameters of the document and its page
+// offset and shared object hint tables against the file. It returns nil parameters if the
+// document is not linearized and an error if they do not match the file, e.g. when the
+// document was incrementally updated after it was linearized.
+func (r *PdfReader) ValidateLinearization() (*core.Linearization, error) {
+	lin, err := r._gdbbd.ValidateLinearization()
+	if err != nil || lin == nil {
+		return lin, err
+	}
+	if err = r.loadPages(); err != nil {
+		return lin, err
+	}
+	if lin.NumPages != len(r.PageList) {
+		return lin, fmt.Errorf("linearized page count %d does not match page count %d",
+			lin.NumPages, len(r.PageList))
+	}
+	if lin.FirstPage < 0 || lin.FirstPage >= len(r.PageList) {
+		return lin, fmt.Errorf("invalid linearized first page %d", lin.FirstPage)
+	}
+	pageNumber := func(i int) int64 {
+		if page := r.PageList[i]._gfbbf; page != nil {
+			return page.ObjectNumber
+		}
+		return 0
+	}
+	if pageNumber(lin.FirstPage) != lin.FirstPageObject {
+		return lin, fmt.Errorf("first page object %d does not match linearized object %d",
+			pageNumber(lin.FirstPage), lin.FirstPageObject)
+	}
+
+	// Primary hint stream.
+	hintData, err := r._gdbbd.ReadBytesAt(lin.HintOffset, 32)
+	if err != nil {
+		return lin, err
+	}
+	var hintNum, hintGen int
+	if _, err := fmt.Sscanf(string(hintData), "%d %d obj", &hintNum, &hintGen); err != nil {
+		return lin, errors.New("invalid primary hint stream")
+	}
+	obj, err := r._gdbbd.LookupByNumber(hintNum)
+	if err != nil {
+		return lin, err
+	}
+	stream, ok := core.GetStream(obj)
+	if !ok {
+		return lin, errors.New("primary hint stream is not a stream object")
+	}
+	sharedOffset, ok := core.GetIntVal(stream.Get("S"))
+	if !ok {
+		return lin, errors.New("primary hint stream without shared object hint table")
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		return lin, err
+	}
+	hints, err := decodeLinearHints(data, sharedOffset, lin.NumPages)
+	if err != nil {
+		return lin, err
+	}
+	if lin.FirstPage != 0 {
+		// The page offset hint table lists the first page section first, the order of the
+		// other pages can not be checked.
+		return lin, nil
+	}
+
+	xref := r._gdbbd.GetXrefTable()
+	objectOffset := func(num int64) (int64, bool) {
+		entry, ok := xref.ObjectMap[int(num)]
+		if ok && entry.XType == core.XrefTypeObjectStream {
+			entry, ok = xref.ObjectMap[entry.OsObjNumber]
+		}
+		if !ok || entry.XType != core.XrefTypeTableEntry {
+			return 0, false
+		}
+		return entry.Offset, true
+	}
+	// The offsets of the hint tables are computed as if the primary hint stream were not in
+	// the file.
+	actual := func(offset int64) int64 {
+		if offset >= lin.HintOffset {
+			return offset + lin.HintLength
+		}
+		return offset
+	}
+	pageOffset := hints.firstPageOffset
+	if offset, ok := objectOffset(lin.FirstPageObject); !ok || offset != actual(pageOffset) {
+		return lin, errors.New("first page offset does not match the page offset hint table")
+	}
+	if actual(pageOffset)+hints.lengths[0] != lin.FirstPageEnd {
+		return lin, errors.New("first page length does not match the page offset hint table")
+	}
+	objNum := int64(1)
+	for i := 1; i < lin.NumPages; i++ {
+		pageOffset += hints.lengths[i-1]
+		if pageNumber(i) != objNum {
+			return lin, fmt.Errorf("page %d object %d does not match the page offset hint table",
+				i+1, pageNumber(i))
+		}
+		if offset, ok := objectOffset(objNum); !ok || offset != actual(pageOffset) {
+			return lin, fmt.Errorf("page %d offset does not match the page offset hint table", i+1)
+		}
+		objNum += hints.nobjects[i]
+	}
+	if hints.numShared > 0 {
+		if offset, ok := objectOffset(hints.firstShared); !ok || offset != actual(hints.firstSharedOffset) {
+			return lin, errors.New("shared objects offset does not match the shared object hint table")
+		}
+	}
+	return lin, nil
+}
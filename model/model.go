@@ -64,13 +64,13 @@ func (_ceece *PdfShading )SetContext (ctx PdfModel ){_ceece ._egaca =ctx };
 // generation is skipped.
 // e.g.: appGen := annotator.FieldAppearance{OnlyIfMissing: true, RegenerateTextFields: true}
 // NOTE: In next major version this functionality will be part of Fill. (v4)
-func (_fbdbc *PdfAcroForm )FillWithAppearance (provider FieldValueProvider ,appGen FieldAppearanceGenerator )error {return _fbdbc .fill (provider ,appGen );};func (_dfgg *PdfReader )newPdfAnnotationMovieFromDict (_caba *_aef .PdfObjectDictionary )(*PdfAnnotationMovie ,error ){_fage :=PdfAnnotationMovie {};_fage .T =_caba .Get ("\u0054");_fage .Movie =_caba .Get ("\u004d\u006f\u0076i\u0065");_fage .A =_caba .Get ("\u0041");return &_fage ,nil ;};
+func (_fbdbc *PdfAcroForm )FillWithAppearance (provider FieldValueProvider ,appGen FieldAppearanceGenerator )error {return _fbdbc .fillWithScripts (provider ,appGen );};func (_dfgg *PdfReader )newPdfAnnotationMovieFromDict (_caba *_aef .PdfObjectDictionary )(*PdfAnnotationMovie ,error ){_fage :=PdfAnnotationMovie {};_fage .T =_caba .Get ("\u0054");_fage .Movie =_caba .Get ("\u004d\u006f\u0076i\u0065");_fage .A =_caba .Get ("\u0041");return &_fage ,nil ;};
 
 // PdfShadingType5 is a Lattice-form Gouraud-shaded triangle mesh.
 type PdfShadingType5 struct{*PdfShading ;BitsPerCoordinate *_aef .PdfObjectInteger ;BitsPerComponent *_aef .PdfObjectInteger ;VerticesPerRow *_aef .PdfObjectInteger ;Decode *_aef .PdfObjectArray ;Function []PdfFunction ;};
 
 // NewPdfAppender creates a new Pdf appender from a Pdf reader.
-func NewPdfAppender (reader *PdfReader )(*PdfAppender ,error ){_ddbd :=&PdfAppender {_dfgf :reader ._cced ,Reader :reader ,_egag :reader ._gdbbd ,_gfce :reader ._cadgb };_gedfg ,_fagfb :=_ddbd ._dfgf .Seek (0,_gfc .SeekEnd );if _fagfb !=nil {return nil ,_fagfb ;};_ddbd ._gadc =_gedfg ;if _ ,_fagfb =_ddbd ._dfgf .Seek (0,_gfc .SeekStart );_fagfb !=nil {return nil ,_fagfb ;};_ddbd ._fdd ,_fagfb =NewPdfReader (_ddbd ._dfgf );if _fagfb !=nil {return nil ,_fagfb ;};for _ ,_eeaeg :=range _ddbd .Reader .GetObjectNums (){if _ddbd ._gdbd < _eeaeg {_ddbd ._gdbd =_eeaeg ;};};_ddbd ._cdda =_ddbd ._egag .GetXrefTable ();_ddbd ._fccb =_ddbd ._egag .GetXrefOffset ();_ddbd ._ebegf =append (_ddbd ._ebegf ,_ddbd ._fdd .PageList ...);_ddbd ._abge =make (map[_aef .PdfObject ]struct{});_ddbd ._ddff =make (map[_aef .PdfObject ]int64 );_ddbd ._cbf =make (map[_aef .PdfObject ]struct{});_ddbd ._faga =_ddbd ._fdd .AcroForm ;_ddbd ._gcde =_ddbd ._fdd .DSS ;return _ddbd ,nil ;};func _gdegc (_adgdf _aef .PdfObject )(*PdfFunctionType2 ,error ){_dcgcf :=&PdfFunctionType2 {};var _gfege *_aef .PdfObjectDictionary ;if _gdaad ,_fcdb :=_adgdf .(*_aef .PdfIndirectObject );_fcdb {_ddcac ,_efgg :=_gdaad .PdfObject .(*_aef .PdfObjectDictionary );if !_efgg {return nil ,_fa .New ("\u0074\u0079p\u0065\u0020\u0063h\u0065\u0063\u006b\u0020\u0065\u0072\u0072\u006f\u0072");};_dcgcf ._fecc =_gdaad ;_gfege =_ddcac ;}else if _dbbca ,_dgdga :=_adgdf .(*_aef .PdfObjectDictionary );_dgdga {_gfege =_dbbca ;}else {return nil ,_fa .New ("\u0074\u0079p\u0065\u0020\u0063h\u0065\u0063\u006b\u0020\u0065\u0072\u0072\u006f\u0072");};_abe .Log .Trace ("\u0046U\u004e\u0043\u0032\u003a\u0020\u0025s",_gfege .String ());_dged ,_ddab :=_aef .TraceToDirectObject (_gfege .Get ("\u0044\u006f\u006d\u0061\u0069\u006e")).(*_aef .PdfObjectArray );if !_ddab {_abe .Log .Error ("D\u006fm\u0061\u0069\u006e\u0020\u006e\u006f\u0074\u0020s\u0070\u0065\u0063\u0069fi\u0065\u0064");return nil ,_fa .New ("\u0072\u0065q\u0075\u0069\u0072\u0065d\u0020\u0061t\u0074\u0072\u0069\u0062\u0075\u0074\u0065\u0020m\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u006f\u0072\u0020\u0069\u006ev\u0061\u006c\u0069\u0064");};if _dged .Len ()< 0||_dged .Len ()%2!=0{_abe .Log .Error ("D\u006fm\u0061\u0069\u006e\u0020\u0072\u0061\u006e\u0067e\u0020\u0069\u006e\u0076al\u0069\u0064");return nil ,_fa .New ("i\u006ev\u0061\u006c\u0069\u0064\u0020\u0064\u006f\u006da\u0069\u006e\u0020\u0072an\u0067\u0065");};_bacbd ,_cage :=_dged .ToFloat64Array ();if _cage !=nil {return nil ,_cage ;};_dcgcf .Domain =_bacbd ;_dged ,_ddab =_aef .TraceToDirectObject (_gfege .Get ("\u0052\u0061\u006eg\u0065")).(*_aef .PdfObjectArray );if _ddab {if _dged .Len ()< 0||_dged .Len ()%2!=0{return nil ,_fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0072\u0061\u006e\u0067\u0065");};_ddbfa ,_cgad :=_dged .ToFloat64Array ();if _cgad !=nil {return nil ,_cgad ;};_dcgcf .Range =_ddbfa ;};_dged ,_ddab =_aef .TraceToDirectObject (_gfege .Get ("\u0043\u0030")).(*_aef .PdfObjectArray );if _ddab {_gegga ,_aeddd :=_dged .ToFloat64Array ();if _aeddd !=nil {return nil ,_aeddd ;};_dcgcf .C0 =_gegga ;};_dged ,_ddab =_aef .TraceToDirectObject (_gfege .Get ("\u0043\u0031")).(*_aef .PdfObjectArray );if _ddab {_bfgd ,_dcbdb :=_dged .ToFloat64Array ();if _dcbdb !=nil {return nil ,_dcbdb ;};_dcgcf .C1 =_bfgd ;};if len (_dcgcf .C0 )!=len (_dcgcf .C1 ){_abe .Log .Error ("\u0043\u0030\u0020\u0061nd\u0020\u0043\u0031\u0020\u006e\u006f\u0074\u0020\u006d\u0061\u0074\u0063\u0068\u0069n\u0067");return nil ,_aef .ErrRangeError ;};N ,_cage :=_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_gfege .Get ("\u004e")));if _cage !=nil {_abe .Log .Error ("\u004e\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020o\u0072\u0020\u0069\u006e\u0076\u0061\u006ci\u0064\u002c\u0020\u0064\u0069\u0063\u0074\u003a\u0020\u0025\u0073",_gfege .String ());return nil ,_cage ;};_dcgcf .N =N ;return _dcgcf ,nil ;};
+func NewPdfAppender (reader *PdfReader )(*PdfAppender ,error ){if _cfdeg :=reader .loadPages ();_cfdeg !=nil {return nil ,_cfdeg ;};_ddbd :=&PdfAppender {_dfgf :reader ._cced ,Reader :reader ,_egag :reader ._gdbbd ,_gfce :reader ._cadgb };_gedfg ,_fagfb :=_ddbd ._dfgf .Seek (0,_gfc .SeekEnd );if _fagfb !=nil {return nil ,_fagfb ;};_ddbd ._gadc =_gedfg ;if _ ,_fagfb =_ddbd ._dfgf .Seek (0,_gfc .SeekStart );_fagfb !=nil {return nil ,_fagfb ;};_ddbd ._fdd ,_fagfb =NewPdfReader (_ddbd ._dfgf );if _fagfb !=nil {return nil ,_fagfb ;};for _ ,_eeaeg :=range _ddbd .Reader .GetObjectNums (){if _ddbd ._gdbd < _eeaeg {_ddbd ._gdbd =_eeaeg ;};};_ddbd ._cdda =_ddbd ._egag .GetXrefTable ();_ddbd ._fccb =_ddbd ._egag .GetXrefOffset ();_ddbd ._ebegf =append (_ddbd ._ebegf ,_ddbd ._fdd .PageList ...);_ddbd ._abge =make (map[_aef .PdfObject ]struct{});_ddbd ._ddff =make (map[_aef .PdfObject ]int64 );_ddbd ._cbf =make (map[_aef .PdfObject ]struct{});_ddbd ._faga =_ddbd ._fdd .AcroForm ;_ddbd ._gcde =_ddbd ._fdd .DSS ;return _ddbd ,nil ;};func _gdegc (_adgdf _aef .PdfObject )(*PdfFunctionType2 ,error ){_dcgcf :=&PdfFunctionType2 {};var _gfege *_aef .PdfObjectDictionary ;if _gdaad ,_fcdb :=_adgdf .(*_aef .PdfIndirectObject );_fcdb {_ddcac ,_efgg :=_gdaad .PdfObject .(*_aef .PdfObjectDictionary );if !_efgg {return nil ,_fa .New ("\u0074\u0079p\u0065\u0020\u0063h\u0065\u0063\u006b\u0020\u0065\u0072\u0072\u006f\u0072");};_dcgcf ._fecc =_gdaad ;_gfege =_ddcac ;}else if _dbbca ,_dgdga :=_adgdf .(*_aef .PdfObjectDictionary );_dgdga {_gfege =_dbbca ;}else {return nil ,_fa .New ("\u0074\u0079p\u0065\u0020\u0063h\u0065\u0063\u006b\u0020\u0065\u0072\u0072\u006f\u0072");};_abe .Log .Trace ("\u0046U\u004e\u0043\u0032\u003a\u0020\u0025s",_gfege .String ());_dged ,_ddab :=_aef .TraceToDirectObject (_gfege .Get ("\u0044\u006f\u006d\u0061\u0069\u006e")).(*_aef .PdfObjectArray );if !_ddab {_abe .Log .Error ("D\u006fm\u0061\u0069\u006e\u0020\u006e\u006f\u0074\u0020s\u0070\u0065\u0063\u0069fi\u0065\u0064");return nil ,_fa .New ("\u0072\u0065q\u0075\u0069\u0072\u0065d\u0020\u0061t\u0074\u0072\u0069\u0062\u0075\u0074\u0065\u0020m\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u006f\u0072\u0020\u0069\u006ev\u0061\u006c\u0069\u0064");};if _dged .Len ()< 0||_dged .Len ()%2!=0{_abe .Log .Error ("D\u006fm\u0061\u0069\u006e\u0020\u0072\u0061\u006e\u0067e\u0020\u0069\u006e\u0076al\u0069\u0064");return nil ,_fa .New ("i\u006ev\u0061\u006c\u0069\u0064\u0020\u0064\u006f\u006da\u0069\u006e\u0020\u0072an\u0067\u0065");};_bacbd ,_cage :=_dged .ToFloat64Array ();if _cage !=nil {return nil ,_cage ;};_dcgcf .Domain =_bacbd ;_dged ,_ddab =_aef .TraceToDirectObject (_gfege .Get ("\u0052\u0061\u006eg\u0065")).(*_aef .PdfObjectArray );if _ddab {if _dged .Len ()< 0||_dged .Len ()%2!=0{return nil ,_fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0072\u0061\u006e\u0067\u0065");};_ddbfa ,_cgad :=_dged .ToFloat64Array ();if _cgad !=nil {return nil ,_cgad ;};_dcgcf .Range =_ddbfa ;};_dged ,_ddab =_aef .TraceToDirectObject (_gfege .Get ("\u0043\u0030")).(*_aef .PdfObjectArray );if _ddab {_gegga ,_aeddd :=_dged .ToFloat64Array ();if _aeddd !=nil {return nil ,_aeddd ;};_dcgcf .C0 =_gegga ;};_dged ,_ddab =_aef .TraceToDirectObject (_gfege .Get ("\u0043\u0031")).(*_aef .PdfObjectArray );if _ddab {_bfgd ,_dcbdb :=_dged .ToFloat64Array ();if _dcbdb !=nil {return nil ,_dcbdb ;};_dcgcf .C1 =_bfgd ;};if len (_dcgcf .C0 )!=len (_dcgcf .C1 ){_abe .Log .Error ("\u0043\u0030\u0020\u0061nd\u0020\u0043\u0031\u0020\u006e\u006f\u0074\u0020\u006d\u0061\u0074\u0063\u0068\u0069n\u0067");return nil ,_aef .ErrRangeError ;};N ,_cage :=_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_gfege .Get ("\u004e")));if _cage !=nil {_abe .Log .Error ("\u004e\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020o\u0072\u0020\u0069\u006e\u0076\u0061\u006ci\u0064\u002c\u0020\u0064\u0069\u0063\u0074\u003a\u0020\u0025\u0073",_gfege .String ());return nil ,_cage ;};_dcgcf .N =N ;return _dcgcf ,nil ;};
 
 // NewPdfOutlineTree returns an initialized PdfOutline tree.
 func NewPdfOutlineTree ()*PdfOutline {_geeb :=NewPdfOutline ();_geeb ._gdgaba =&_geeb ;return _geeb };
@@ -89,7 +89,7 @@ func NewPdfAnnotationPrinterMark ()*PdfAnnotationPrinterMark {_agfb :=NewPdfAnno
 
 // Write writes the Appender output to io.Writer.
 // It can only be called once and further invocations will result in an error.
-func (_dcdb *PdfAppender )Write (w _gfc .Writer )error {if _dcdb ._ebef {return _fa .New ("\u0061\u0070\u0070\u0065\u006e\u0064\u0065\u0072\u0020\u0077\u0072\u0069\u0074e\u0020\u0063\u0061\u006e\u0020\u006fn\u006c\u0079\u0020\u0062\u0065\u0020\u0069\u006e\u0076\u006f\u006b\u0065\u0064 \u006f\u006e\u0063\u0065");};_aeff :=NewPdfWriter ();_gebf ,_fddg :=_aef .GetDict (_aeff ._gacae );if !_fddg {return _fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0050\u0061g\u0065\u0073\u0020\u006f\u0062\u006a\u0020(\u006e\u006f\u0074\u0020\u0061\u0020\u0064\u0069\u0063\u0074\u0029");};_dfcf ,_fddg :=_gebf .Get ("\u004b\u0069\u0064\u0073").(*_aef .PdfObjectArray );if !_fddg {return _fa .New ("\u0069\u006ev\u0061\u006c\u0069\u0064 \u0050\u0061g\u0065\u0073\u0020\u004b\u0069\u0064\u0073\u0020o\u0062\u006a\u0020\u0028\u006e\u006f\u0074\u0020\u0061\u006e\u0020\u0061r\u0072\u0061\u0079\u0029");};_dcab ,_fddg :=_gebf .Get ("\u0043\u006f\u0075n\u0074").(*_aef .PdfObjectInteger );if !_fddg {return _fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064 \u0050\u0061\u0067e\u0073\u0020\u0043\u006fu\u006e\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0028\u006e\u006f\u0074\u0020\u0061\u006e\u0020\u0069\u006e\u0074\u0065\u0067\u0065\u0072\u0029");};_cgfa :=_dcdb ._fdd ._gdbbd ;_facb :=_cgfa .GetTrailer ();if _facb ==nil {return _fa .New ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0074\u0072\u0061\u0069\u006c\u0065\u0072");};_ccf ,_fddg :=_aef .GetIndirect (_facb .Get ("\u0052\u006f\u006f\u0074"));if !_fddg {return _fa .New ("c\u0061\u0074\u0061\u006c\u006f\u0067 \u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0065\u0072 \u006e\u006f\u0074 \u0066o\u0075\u006e\u0064");};_dfda ,_fddg :=_aef .GetDict (_ccf );if !_fddg {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u004d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0028\u0072\u006f\u006f\u0074\u0020\u0025\u0071\u0029\u0020\u0028\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u0020\u0025\u0073\u0029",_ccf ,*_facb );return _fa .New ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067");};for _ ,_edca :=range _dfda .Keys (){if _aeff ._abebc .Get (_edca )==nil {_dcbd :=_dfda .Get (_edca );_aeff ._abebc .Set (_edca ,_dcbd );};};if _dcdb ._faga !=nil {_aeff ._abebc .Set ("\u0041\u0063\u0072\u006f\u0046\u006f\u0072\u006d",_dcdb ._faga .ToPdfObject ());_dcdb .updateObjectsDeep (_dcdb ._faga .ToPdfObject (),nil );};if _dcdb ._gcde !=nil {_dcdb .updateObjectsDeep (_dcdb ._gcde .ToPdfObject (),nil );_aeff ._abebc .Set ("\u0044\u0053\u0053",_dcdb ._gcde .GetContainingPdfObject ());};if _aeff ._cfebb < 2{_aeff .AddExtension ("\u0045\u0053\u0049\u0043","\u0031\u002e\u0037",5);_aeff .AddExtension ("\u0041\u0044\u0042\u0045","\u0031\u002e\u0037",8);};_dcdb .addNewObject (_aeff ._caefd );_dcdb .addNewObject (_aeff ._bdbdfg );_ggega :=false ;if len (_dcdb ._fdd .PageList )!=len (_dcdb ._ebegf ){_ggega =true ;}else {for _edfb :=range _dcdb ._fdd .PageList {switch {case _dcdb ._ebegf [_edfb ]==_dcdb ._fdd .PageList [_edfb ]:case _dcdb ._ebegf [_edfb ]==_dcdb .Reader .PageList [_edfb ]:default:_ggega =true ;};if _ggega {break ;};};};if _ggega {_dcdb .updateObjectsDeep (_aeff ._gacae ,nil );}else {_dcdb ._cbf [_aeff ._gacae ]=struct{}{};};_aeff ._gacae .ObjectNumber =_dcdb .Reader ._cbfdd .ObjectNumber ;_dcdb ._ddff [_aeff ._gacae ]=_dcdb .Reader ._cbfdd .ObjectNumber ;_accd :=[]_aef .PdfObjectName {"\u0052e\u0073\u006f\u0075\u0072\u0063\u0065s","\u004d\u0065\u0064\u0069\u0061\u0042\u006f\u0078","\u0043r\u006f\u0070\u0042\u006f\u0078","\u0052\u006f\u0074\u0061\u0074\u0065"};for _ ,_ddac :=range _dcdb ._ebegf {_ffde :=_ddac .ToPdfObject ();*_dcab =*_dcab +1;if _abcg ,_ggc :=_ffde .(*_aef .PdfIndirectObject );_ggc &&_abcg .GetParser ()==_dcdb ._fdd ._gdbbd {_dfcf .Append (&_abcg .PdfObjectReference );continue ;};if _eagf ,_fdce :=_aef .GetDict (_ffde );_fdce {_gaggc ,_agbd :=_eagf .Get ("\u0050\u0061\u0072\u0065\u006e\u0074").(*_aef .PdfIndirectObject );for _agbd {_abe .Log .Trace ("\u0050a\u0067e\u0020\u0050\u0061\u0072\u0065\u006e\u0074\u003a\u0020\u0025\u0054",_gaggc );_eaede ,_dfae :=_gaggc .PdfObject .(*_aef .PdfObjectDictionary );if !_dfae {return _fa .New ("i\u006e\u0076\u0061\u006cid\u0020P\u0061\u0072\u0065\u006e\u0074 \u006f\u0062\u006a\u0065\u0063\u0074");};for _ ,_gdg :=range _accd {_abe .Log .Trace ("\u0046\u0069\u0065\u006c\u0064\u0020\u0025\u0073",_gdg );if _eagf .Get (_gdg )!=nil {_abe .Log .Trace ("\u002d \u0070a\u0067\u0065\u0020\u0068\u0061s\u0020\u0061l\u0072\u0065\u0061\u0064\u0079");continue ;};if _gggb :=_eaede .Get (_gdg );_gggb !=nil {_abe .Log .Trace ("\u0049\u006e\u0068\u0065ri\u0074\u0069\u006e\u0067\u0020\u0066\u0069\u0065\u006c\u0064\u0020\u0025\u0073",_gdg );_eagf .Set (_gdg ,_gggb );};};_gaggc ,_agbd =_eaede .Get ("\u0050\u0061\u0072\u0065\u006e\u0074").(*_aef .PdfIndirectObject );_abe .Log .Trace ("\u004ee\u0078t\u0020\u0070\u0061\u0072\u0065\u006e\u0074\u003a\u0020\u0025\u0054",_eaede .Get ("\u0050\u0061\u0072\u0065\u006e\u0074"));};_eagf .Set ("\u0050\u0061\u0072\u0065\u006e\u0074",_aeff ._gacae );};_dcdb .updateObjectsDeep (_ffde ,nil );_dfcf .Append (_ffde );};if _ ,_aaee :=_dcdb ._dfgf .Seek (0,_gfc .SeekStart );_aaee !=nil {return _aaee ;};_efff :=make (map[SignatureHandler ]_gfc .Writer );_cefeg :=_aef .MakeArray ();for _ ,_fgbea :=range _dcdb ._aeaaa {if _dacd ,_fdfd :=_aef .GetIndirect (_fgbea );_fdfd {if _agbb ,_ecfg :=_dacd .PdfObject .(*pdfSignDictionary );_ecfg {_eefb :=*_agbb ._gegee ;var _bdff error ;_efff [_eefb ],_bdff =_eefb .NewDigest (_agbb ._bebca );if _bdff !=nil {return _bdff ;};_cefeg .Append (_aef .MakeInteger (0xfffff),_aef .MakeInteger (0xfffff));};};};if _cefeg .Len ()> 0{_cefeg .Append (_aef .MakeInteger (0xfffff),_aef .MakeInteger (0xfffff));};for _ ,_egfe :=range _dcdb ._aeaaa {if _acaa ,_efeb :=_aef .GetIndirect (_egfe );_efeb {if _bfec ,_cafd :=_acaa .PdfObject .(*pdfSignDictionary );_cafd {_bfec .Set ("\u0042y\u0074\u0065\u0052\u0061\u006e\u0067e",_cefeg );};};};_fcbe :=len (_efff )> 0;var _eccb _gfc .Reader =_dcdb ._dfgf ;if _fcbe {_gaabf :=make ([]_gfc .Writer ,0,len (_efff ));for _ ,_cdff :=range _efff {_gaabf =append (_gaabf ,_cdff );};_eccb =_gfc .TeeReader (_dcdb ._dfgf ,_gfc .MultiWriter (_gaabf ...));};_dbbb ,_acga :=_gfc .Copy (w ,_eccb );if _acga !=nil {return _acga ;};if len (_dcdb ._aeaaa )==0{return nil ;};_aeff ._ccdaf =_dbbb ;_aeff .ObjNumOffset =_dcdb ._gdbd ;_aeff ._bcdd =true ;_aeff ._gdbde =_dcdb ._cdda ;_aeff ._bbbefb =_dcdb ._fccb ;_aeff ._bfagd =_dcdb ._gadc ;_aeff ._gbcce =_dcdb ._fdd .PdfVersion ().Minor ;_aeff ._bfeac =_dcdb ._ddff ;_bbac :=_dcdb ._egag .GetXrefType ();if _bbac !=nil {_fgfc :=*_bbac ==_aef .XrefTypeObjectStream ;_aeff ._ecfeg =&_fgfc ;};_aeff ._cafea =map[_aef .PdfObject ]struct{}{};_aeff ._aage =[]_aef .PdfObject {};for _ ,_dfaa :=range _dcdb ._aeaaa {if _ ,_bega :=_dcdb ._cbf [_dfaa ];_bega {continue ;};_aeff .addObject (_dfaa );};_edff :=w ;if _fcbe {_edff =_cg .NewBuffer (nil );};if _gdgf :=_aeff .Write (_edff );_gdgf !=nil {return _gdgf ;};if _fcbe {_bcbb :=_edff .(*_cg .Buffer ).Bytes ();_eadf :=_aef .MakeArray ();var _cbge []*pdfSignDictionary ;var _abgea int64 ;for _ ,_ffbc :=range _aeff ._aage {if _gccf ,_cbcc :=_aef .GetIndirect (_ffbc );_cbcc {if _bcef ,_ccg :=_gccf .PdfObject .(*pdfSignDictionary );_ccg {_cbge =append (_cbge ,_bcef );_cbgd :=_bcef ._ggbad +int64 (_bcef ._dfdbe );_eadf .Append (_aef .MakeInteger (_abgea ),_aef .MakeInteger (_cbgd -_abgea ));_abgea =_bcef ._ggbad +int64 (_bcef ._ffff );};};};_eadf .Append (_aef .MakeInteger (_abgea ),_aef .MakeInteger (_dbbb +int64 (len (_bcbb ))-_abgea ));_cbege :=[]byte (_eadf .WriteString ());for _ ,_cgcf :=range _cbge {_gegae :=int (_cgcf ._ggbad -_dbbb );for _babe :=_cgcf ._afebc ;_babe < _cgcf ._aceeb ;_babe ++{_bcbb [_gegae +_babe ]=' ';};_aggd :=_bcbb [_gegae +_cgcf ._afebc :_gegae +_cgcf ._aceeb ];copy (_aggd ,_cbege );};var _cceb int ;for _ ,_cbee :=range _cbge {_gaegf :=int (_cbee ._ggbad -_dbbb );_efgfg :=_bcbb [_cceb :_gaegf +_cbee ._dfdbe ];_gaege :=*_cbee ._gegee ;_efff [_gaege ].Write (_efgfg );_cceb =_gaegf +_cbee ._ffff ;};for _ ,_dbdg :=range _cbge {_dgge :=_bcbb [_cceb :];_bdea :=*_dbdg ._gegee ;_efff [_bdea ].Write (_dgge );};for _ ,_cacgg :=range _cbge {_fgcd :=int (_cacgg ._ggbad -_dbbb );_abfg :=*_cacgg ._gegee ;_faag :=_efff [_abfg ];if _eacg :=_abfg .Sign (_cacgg ._bebca ,_faag );_eacg !=nil {return _eacg ;};_cacgg ._bebca .ByteRange =_eadf ;_ffdd :=[]byte (_cacgg ._bebca .Contents .WriteString ());for _cfac :=_cacgg ._afebc ;_cfac < _cacgg ._aceeb ;_cfac ++{_bcbb [_fgcd +_cfac ]=' ';};for _adbfc :=_cacgg ._dfdbe ;_adbfc < _cacgg ._ffff ;_adbfc ++{_bcbb [_fgcd +_adbfc ]=' ';};_gcdfe :=_bcbb [_fgcd +_cacgg ._afebc :_fgcd +_cacgg ._aceeb ];copy (_gcdfe ,_cbege );_gcdfe =_bcbb [_fgcd +_cacgg ._dfdbe :_fgcd +_cacgg ._ffff ];copy (_gcdfe ,_ffdd );};_egba :=_cg .NewBuffer (_bcbb );_ ,_acga =_gfc .Copy (w ,_egba );if _acga !=nil {return _acga ;};};_dcdb ._ebef =true ;return nil ;};
+func (_dcdb *PdfAppender )Write (w _gfc .Writer )error {if _dcdb ._ebef {return _fa .New ("\u0061\u0070\u0070\u0065\u006e\u0064\u0065\u0072\u0020\u0077\u0072\u0069\u0074e\u0020\u0063\u0061\u006e\u0020\u006fn\u006c\u0079\u0020\u0062\u0065\u0020\u0069\u006e\u0076\u006f\u006b\u0065\u0064 \u006f\u006e\u0063\u0065");};_dcdb .trackChanges ();_aeff :=NewPdfWriter ();_gebf ,_fddg :=_aef .GetDict (_aeff ._gacae );if !_fddg {return _fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0050\u0061g\u0065\u0073\u0020\u006f\u0062\u006a\u0020(\u006e\u006f\u0074\u0020\u0061\u0020\u0064\u0069\u0063\u0074\u0029");};_dfcf ,_fddg :=_gebf .Get ("\u004b\u0069\u0064\u0073").(*_aef .PdfObjectArray );if !_fddg {return _fa .New ("\u0069\u006ev\u0061\u006c\u0069\u0064 \u0050\u0061g\u0065\u0073\u0020\u004b\u0069\u0064\u0073\u0020o\u0062\u006a\u0020\u0028\u006e\u006f\u0074\u0020\u0061\u006e\u0020\u0061r\u0072\u0061\u0079\u0029");};_dcab ,_fddg :=_gebf .Get ("\u0043\u006f\u0075n\u0074").(*_aef .PdfObjectInteger );if !_fddg {return _fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064 \u0050\u0061\u0067e\u0073\u0020\u0043\u006fu\u006e\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0028\u006e\u006f\u0074\u0020\u0061\u006e\u0020\u0069\u006e\u0074\u0065\u0067\u0065\u0072\u0029");};_cgfa :=_dcdb .Reader ._gdbbd ;_facb :=_cgfa .GetTrailer ();if _facb ==nil {return _fa .New ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0074\u0072\u0061\u0069\u006c\u0065\u0072");};_ccf ,_fddg :=_aef .GetIndirect (_facb .Get ("\u0052\u006f\u006f\u0074"));if !_fddg {return _fa .New ("c\u0061\u0074\u0061\u006c\u006f\u0067 \u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0065\u0072 \u006e\u006f\u0074 \u0066o\u0075\u006e\u0064");};_dfda ,_fddg :=_aef .GetDict (_ccf );if !_fddg {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u004d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0028\u0072\u006f\u006f\u0074\u0020\u0025\u0071\u0029\u0020\u0028\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u0020\u0025\u0073\u0029",_ccf ,*_facb );return _fa .New ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067");};for _ ,_edca :=range _dfda .Keys (){if _aeff ._abebc .Get (_edca )==nil {_dcbd :=_dfda .Get (_edca );_aeff ._abebc .Set (_edca ,_dcbd );};};if _dcdb ._faga !=nil {_aeff ._abebc .Set ("\u0041\u0063\u0072\u006f\u0046\u006f\u0072\u006d",_dcdb ._faga .ToPdfObject ());_dcdb .updateObjectsDeep (_dcdb ._faga .ToPdfObject (),nil );};if _dcdb ._gcde !=nil {_dcdb .updateObjectsDeep (_dcdb ._gcde .ToPdfObject (),nil );_aeff ._abebc .Set ("\u0044\u0053\u0053",_dcdb ._gcde .GetContainingPdfObject ());};if _aeff ._cfebb < 2{_aeff .AddExtension ("\u0045\u0053\u0049\u0043","\u0031\u002e\u0037",5);_aeff .AddExtension ("\u0041\u0044\u0042\u0045","\u0031\u002e\u0037",8);};_dcdb .setInfo (&_aeff );_dcdb .addNewObject (_aeff ._bdbdfg );_ggega :=false ;if len (_dcdb ._fdd .PageList )!=len (_dcdb ._ebegf ){_ggega =true ;}else {for _edfb :=range _dcdb ._fdd .PageList {switch {case _dcdb ._ebegf [_edfb ]==_dcdb ._fdd .PageList [_edfb ]:case _dcdb ._ebegf [_edfb ]==_dcdb .Reader .PageList [_edfb ]:default:_ggega =true ;};if _ggega {break ;};};};if _ggega {_dcdb .updateObjectsDeep (_aeff ._gacae ,nil );}else {_dcdb ._cbf [_aeff ._gacae ]=struct{}{};};_aeff ._gacae .ObjectNumber =_dcdb .Reader ._cbfdd .ObjectNumber ;_dcdb ._ddff [_aeff ._gacae ]=_dcdb .Reader ._cbfdd .ObjectNumber ;_accd :=[]_aef .PdfObjectName {"\u0052e\u0073\u006f\u0075\u0072\u0063\u0065s","\u004d\u0065\u0064\u0069\u0061\u0042\u006f\u0078","\u0043r\u006f\u0070\u0042\u006f\u0078","\u0052\u006f\u0074\u0061\u0074\u0065"};for _ ,_ddac :=range _dcdb ._ebegf {_ffde :=_ddac .ToPdfObject ();*_dcab =*_dcab +1;if _abcg ,_ggc :=_ffde .(*_aef .PdfIndirectObject );_ggc &&_abcg .GetParser ()==_dcdb ._fdd ._gdbbd {_dfcf .Append (&_abcg .PdfObjectReference );continue ;};if _eagf ,_fdce :=_aef .GetDict (_ffde );_fdce {_gaggc ,_agbd :=_eagf .Get ("\u0050\u0061\u0072\u0065\u006e\u0074").(*_aef .PdfIndirectObject );for _agbd {_abe .Log .Trace ("\u0050a\u0067e\u0020\u0050\u0061\u0072\u0065\u006e\u0074\u003a\u0020\u0025\u0054",_gaggc );_eaede ,_dfae :=_gaggc .PdfObject .(*_aef .PdfObjectDictionary );if !_dfae {return _fa .New ("i\u006e\u0076\u0061\u006cid\u0020P\u0061\u0072\u0065\u006e\u0074 \u006f\u0062\u006a\u0065\u0063\u0074");};for _ ,_gdg :=range _accd {_abe .Log .Trace ("\u0046\u0069\u0065\u006c\u0064\u0020\u0025\u0073",_gdg );if _eagf .Get (_gdg )!=nil {_abe .Log .Trace ("\u002d \u0070a\u0067\u0065\u0020\u0068\u0061s\u0020\u0061l\u0072\u0065\u0061\u0064\u0079");continue ;};if _gggb :=_eaede .Get (_gdg );_gggb !=nil {_abe .Log .Trace ("\u0049\u006e\u0068\u0065ri\u0074\u0069\u006e\u0067\u0020\u0066\u0069\u0065\u006c\u0064\u0020\u0025\u0073",_gdg );_eagf .Set (_gdg ,_gggb );};};_gaggc ,_agbd =_eaede .Get ("\u0050\u0061\u0072\u0065\u006e\u0074").(*_aef .PdfIndirectObject );_abe .Log .Trace ("\u004ee\u0078t\u0020\u0070\u0061\u0072\u0065\u006e\u0074\u003a\u0020\u0025\u0054",_eaede .Get ("\u0050\u0061\u0072\u0065\u006e\u0074"));};_eagf .Set ("\u0050\u0061\u0072\u0065\u006e\u0074",_aeff ._gacae );};_dcdb .updateObjectsDeep (_ffde ,nil );_dfcf .Append (_ffde );};if _ ,_aaee :=_dcdb ._dfgf .Seek (0,_gfc .SeekStart );_aaee !=nil {return _aaee ;};_efff :=make (map[SignatureHandler ]_gfc .Writer );_cefeg :=_aef .MakeArray ();for _ ,_fgbea :=range _dcdb ._aeaaa {if _dacd ,_fdfd :=_aef .GetIndirect (_fgbea );_fdfd {if _agbb ,_ecfg :=_dacd .PdfObject .(*pdfSignDictionary );_ecfg {_eefb :=*_agbb ._gegee ;var _bdff error ;_efff [_eefb ],_bdff =_eefb .NewDigest (_agbb ._bebca );if _bdff !=nil {return _bdff ;};_cefeg .Append (_aef .MakeInteger (0xfffff),_aef .MakeInteger (0xfffff));};};};if _cefeg .Len ()> 0{_cefeg .Append (_aef .MakeInteger (0xfffff),_aef .MakeInteger (0xfffff));};for _ ,_egfe :=range _dcdb ._aeaaa {if _acaa ,_efeb :=_aef .GetIndirect (_egfe );_efeb {if _bfec ,_cafd :=_acaa .PdfObject .(*pdfSignDictionary );_cafd {_bfec .Set ("\u0042y\u0074\u0065\u0052\u0061\u006e\u0067e",_cefeg );};};};_fcbe :=len (_efff )> 0;var _eccb _gfc .Reader =_dcdb ._dfgf ;if _fcbe {_gaabf :=make ([]_gfc .Writer ,0,len (_efff ));for _ ,_cdff :=range _efff {_gaabf =append (_gaabf ,_cdff );};_eccb =_gfc .TeeReader (_dcdb ._dfgf ,_gfc .MultiWriter (_gaabf ...));};_dbbb ,_acga :=_gfc .Copy (w ,_eccb );if _acga !=nil {return _acga ;};if len (_dcdb ._aeaaa )==0{return nil ;};_aeff ._ccdaf =_dbbb ;_aeff .ObjNumOffset =_dcdb ._gdbd ;_aeff ._bcdd =true ;_aeff ._gdbde =_dcdb ._cdda ;_aeff ._bbbefb =_dcdb ._fccb ;_aeff ._bfagd =_dcdb ._gadc ;_aeff ._gbcce =_dcdb ._fdd .PdfVersion ().Minor ;_aeff ._bfeac =_dcdb ._ddff ;_bbac :=_dcdb ._egag .GetXrefType ();if _bbac !=nil {_fgfc :=*_bbac ==_aef .XrefTypeObjectStream ;_aeff ._ecfeg =&_fgfc ;};_dcdb .setXrefFormat (&_aeff );_aeff ._cafea =map[_aef .PdfObject ]struct{}{};_aeff ._aage =[]_aef .PdfObject {};for _ ,_dfaa :=range _dcdb ._aeaaa {if _ ,_bega :=_dcdb ._cbf [_dfaa ];_bega {continue ;};_aeff .addObject (_dfaa );};_edff :=w ;if _fcbe {_edff =_cg .NewBuffer (nil );};if _gdgf :=_aeff .Write (_edff );_gdgf !=nil {return _gdgf ;};if _fcbe {_bcbb :=_edff .(*_cg .Buffer ).Bytes ();_eadf :=_aef .MakeArray ();var _cbge []*pdfSignDictionary ;var _abgea int64 ;for _ ,_ffbc :=range _aeff ._aage {if _gccf ,_cbcc :=_aef .GetIndirect (_ffbc );_cbcc {if _bcef ,_ccg :=_gccf .PdfObject .(*pdfSignDictionary );_ccg {_cbge =append (_cbge ,_bcef );_cbgd :=_bcef ._ggbad +int64 (_bcef ._dfdbe );_eadf .Append (_aef .MakeInteger (_abgea ),_aef .MakeInteger (_cbgd -_abgea ));_abgea =_bcef ._ggbad +int64 (_bcef ._ffff );};};};_eadf .Append (_aef .MakeInteger (_abgea ),_aef .MakeInteger (_dbbb +int64 (len (_bcbb ))-_abgea ));_cbege :=[]byte (_eadf .WriteString ());for _ ,_cgcf :=range _cbge {_gegae :=int (_cgcf ._ggbad -_dbbb );for _babe :=_cgcf ._afebc ;_babe < _cgcf ._aceeb ;_babe ++{_bcbb [_gegae +_babe ]=' ';};_aggd :=_bcbb [_gegae +_cgcf ._afebc :_gegae +_cgcf ._aceeb ];copy (_aggd ,_cbege );};var _cceb int ;for _ ,_cbee :=range _cbge {_gaegf :=int (_cbee ._ggbad -_dbbb );_efgfg :=_bcbb [_cceb :_gaegf +_cbee ._dfdbe ];_gaege :=*_cbee ._gegee ;_efff [_gaege ].Write (_efgfg );_cceb =_gaegf +_cbee ._ffff ;};for _ ,_dbdg :=range _cbge {_dgge :=_bcbb [_cceb :];_bdea :=*_dbdg ._gegee ;_efff [_bdea ].Write (_dgge );};for _ ,_cacgg :=range _cbge {_fgcd :=int (_cacgg ._ggbad -_dbbb );_abfg :=*_cacgg ._gegee ;_faag :=_efff [_abfg ];if _eacg :=_abfg .Sign (_cacgg ._bebca ,_faag );_eacg !=nil {return _eacg ;};_cacgg ._bebca .ByteRange =_eadf ;_ffdd :=[]byte (_cacgg ._bebca .Contents .WriteString ());for _cfac :=_cacgg ._afebc ;_cfac < _cacgg ._aceeb ;_cfac ++{_bcbb [_fgcd +_cfac ]=' ';};for _adbfc :=_cacgg ._dfdbe ;_adbfc < _cacgg ._ffff ;_adbfc ++{_bcbb [_fgcd +_adbfc ]=' ';};_gcdfe :=_bcbb [_fgcd +_cacgg ._afebc :_fgcd +_cacgg ._aceeb ];copy (_gcdfe ,_cbege );_gcdfe =_bcbb [_fgcd +_cacgg ._dfdbe :_fgcd +_cacgg ._ffff ];copy (_gcdfe ,_ffdd );};_egba :=_cg .NewBuffer (_bcbb );_ ,_acga =_gfc .Copy (w ,_egba );if _acga !=nil {return _acga ;};};_dcdb ._ebef =true ;return nil ;};
 
 // NewOutlineDest returns a new outline destination which can be used
 // with outline items.
@@ -607,7 +607,7 @@ func NewPdfPageResourcesColorspaces ()*PdfPageResourcesColorspaces {_eabcg :=&Pd
 func (_bbc *PdfActionMovie )ToPdfObject ()_aef .PdfObject {_bbc .PdfAction .ToPdfObject ();_dfg :=_bbc ._gc ;_fabf :=_dfg .PdfObject .(*_aef .PdfObjectDictionary );_fabf .SetIfNotNil ("\u0053",_aef .MakeName (string (ActionTypeMovie )));_fabf .SetIfNotNil ("\u0041\u006e\u006e\u006f\u0074\u0061\u0074\u0069\u006f\u006e",_bbc .Annotation );_fabf .SetIfNotNil ("\u0054",_bbc .T );_fabf .SetIfNotNil ("\u004fp\u0065\u0072\u0061\u0074\u0069\u006fn",_bbc .Operation );return _dfg ;};
 
 // Fill populates `form` with values provided by `provider`.
-func (_ceab *PdfAcroForm )Fill (provider FieldValueProvider )error {return _ceab .fill (provider ,nil )};
+func (_ceab *PdfAcroForm )Fill (provider FieldValueProvider )error {return _ceab .fillWithScripts (provider ,nil )};
 
 // Inspect inspects the object types, subtypes and content in the PDF file returning a map of
 // object type to number of instances of each.
@@ -645,7 +645,7 @@ func (_ddaee *pdfSignDictionary )GetSubFilter ()string {_ccab :=_ddaee .Get ("\u
 func (_bffec *PdfReader )FlattenFields (allannots bool ,appgen FieldAppearanceGenerator )error {return _bffec .flattenFieldsWithOpts (allannots ,appgen ,nil );};func (_cdadd *PdfShading )getShadingDict ()(*_aef .PdfObjectDictionary ,error ){_daddc :=_cdadd ._bbga ;if _defc ,_cafe :=_daddc .(*_aef .PdfIndirectObject );_cafe {_bebg ,_cdfg :=_defc .PdfObject .(*_aef .PdfObjectDictionary );if !_cdfg {return nil ,_aef .ErrTypeError ;};return _bebg ,nil ;}else if _bfdc ,_faed :=_daddc .(*_aef .PdfObjectStream );_faed {return _bfdc .PdfObjectDictionary ,nil ;}else if _abded ,_eeeda :=_daddc .(*_aef .PdfObjectDictionary );_eeeda {return _abded ,nil ;}else {_abe .Log .Debug ("U\u006e\u0061\u0062\u006c\u0065\u0020t\u006f\u0020\u0061\u0063\u0063\u0065s\u0073\u0020\u0073\u0068\u0061\u0064\u0069n\u0067\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061r\u0079");return nil ,_aef .ErrTypeError ;};};
 
 // PdfAppender appends new PDF content to an existing PDF document via incremental updates.
-type PdfAppender struct{_dfgf _gfc .ReadSeeker ;_egag *_aef .PdfParser ;_fdd *PdfReader ;Reader *PdfReader ;_ebegf []*PdfPage ;_faga *PdfAcroForm ;_gcde *DSS ;_cdda _aef .XrefTable ;_fccb int64 ;_gdbd int ;_aeaaa []_aef .PdfObject ;_abge map[_aef .PdfObject ]struct{};_ddff map[_aef .PdfObject ]int64 ;_cbf map[_aef .PdfObject ]struct{};_gfce map[_aef .PdfObject ]struct{};_gadc int64 ;_ebef bool ;};
+type PdfAppender struct{_dfgf _gfc .ReadSeeker ;_egag *_aef .PdfParser ;_fdd *PdfReader ;Reader *PdfReader ;_ebegf []*PdfPage ;_faga *PdfAcroForm ;_gcde *DSS ;_cdda _aef .XrefTable ;_fccb int64 ;_gdbd int ;_aeaaa []_aef .PdfObject ;_abge map[_aef .PdfObject ]struct{};_ddff map[_aef .PdfObject ]int64 ;_cbf map[_aef .PdfObject ]struct{};_gfce map[_aef .PdfObject ]struct{};_gadc int64 ;_ebef bool ;xrefFormat XrefFormat ;};
 
 // SetFilter sets compression filter. Decodes with current filter sets and
 // encodes the data with the new filter.
@@ -703,7 +703,7 @@ func (_fbaf *PdfAppender )ReplacePage (pageNum int ,page *PdfPage ){_aedb :=page
 func (_gbgd *PdfColorspaceSpecialIndexed )ColorToRGB (color PdfColor )(PdfColor ,error ){if _gbgd .Base ==nil {return nil ,_fa .New ("\u0069\u006e\u0064\u0065\u0078\u0065d\u0020\u0062\u0061\u0073\u0065\u0020\u0063\u006f\u006c\u006f\u0072\u0073\u0070a\u0063\u0065\u0020\u0075\u006e\u0064\u0065f\u0069\u006e\u0065\u0064");};return _gbgd .Base .ColorToRGB (color );};func (_bfbfea *DSS )addCRLs (_cedg [][]byte )([]*_aef .PdfObjectStream ,error ){return _bfbfea .add (&_bfbfea .CRLs ,_bfbfea ._gfbg ,_cedg );};type modelManager struct{_gfdb map[PdfModel ]_aef .PdfObject ;_bgbf map[_aef .PdfObject ]PdfModel ;};
 
 // GetNumPages returns the number of pages in the document.
-func (_ccbaa *PdfReader )GetNumPages ()(int ,error ){if _ccbaa ._gdbbd .GetCrypter ()!=nil &&!_ccbaa ._gdbbd .IsAuthenticated (){return 0,_b .Errorf ("\u0066\u0069\u006ce\u0020\u006e\u0065\u0065d\u0020\u0074\u006f\u0020\u0062\u0065\u0020d\u0065\u0063\u0072\u0079\u0070\u0074\u0065\u0064\u0020\u0066\u0069\u0072\u0073\u0074");};return len (_ccbaa ._dbdgb ),nil ;};func _cfeeg (_gcbg _aef .PdfObject )(*fontFile ,error ){_abe .Log .Trace ("\u006e\u0065\u0077\u0046\u006f\u006e\u0074\u0046\u0069\u006c\u0065\u0046\u0072\u006f\u006dP\u0064f\u004f\u0062\u006a\u0065\u0063\u0074\u003a\u0020\u006f\u0062\u006a\u003d\u0025\u0073",_gcbg );_dfgaec :=&fontFile {};_gcbg =_aef .TraceToDirectObject (_gcbg );_dfgad ,_fccfb :=_gcbg .(*_aef .PdfObjectStream );if !_fccfb {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020F\u006f\u006et\u0046\u0069\u006c\u0065\u0020\u006d\u0075\u0073t\u0020\u0062\u0065\u0020\u0061\u0020\u0073\u0074\u0072\u0065\u0061\u006d \u0028\u0025\u0054\u0029",_gcbg );return nil ,_aef .ErrTypeError ;};_eddf :=_dfgad .PdfObjectDictionary ;_bged ,_cdbfg :=_aef .DecodeStream (_dfgad );if _cdbfg !=nil {return nil ,_cdbfg ;};_adeg ,_fccfb :=_aef .GetNameVal (_eddf .Get ("\u0053u\u0062\u0074\u0079\u0070\u0065"));if !_fccfb {_dfgaec ._aacd =_adeg ;if _adeg =="\u0054\u0079\u0070\u0065\u0031\u0043"{_abe .Log .Debug ("T\u0079\u0070\u0065\u0031\u0043\u0020\u0066\u006f\u006e\u0074\u0073\u0020\u0061\u0072\u0065\u0020\u0063\u0075r\u0072\u0065\u006e\u0074\u006c\u0079\u0020\u006e\u006f\u0074 s\u0075\u0070\u0070o\u0072t\u0065\u0064");return nil ,ErrType1CFontNotSupported ;};};_aagab ,_ :=_aef .GetIntVal (_eddf .Get ("\u004ce\u006e\u0067\u0074\u0068\u0031"));_gcbgg ,_ :=_aef .GetIntVal (_eddf .Get ("\u004ce\u006e\u0067\u0074\u0068\u0032"));if _aagab > len (_bged ){_aagab =len (_bged );};if _aagab +_gcbgg > len (_bged ){_gcbgg =len (_bged )-_aagab ;};_gggf :=_bged [:_aagab ];var _ebad []byte ;if _gcbgg > 0{_ebad =_bged [_aagab :_aagab +_gcbgg ];};if _aagab > 0&&_gcbgg > 0{_cbbgg :=_dfgaec .loadFromSegments (_gggf ,_ebad );if _cbbgg !=nil {return nil ,_cbbgg ;};};return _dfgaec ,nil ;};
+func (_ccbaa *PdfReader )GetNumPages ()(int ,error ){if _ccbaa ._gdbbd .GetCrypter ()!=nil &&!_ccbaa ._gdbbd .IsAuthenticated (){return 0,_b .Errorf ("\u0066\u0069\u006ce\u0020\u006e\u0065\u0065d\u0020\u0074\u006f\u0020\u0062\u0065\u0020d\u0065\u0063\u0072\u0079\u0070\u0074\u0065\u0064\u0020\u0066\u0069\u0072\u0073\u0074");};if _ccbaa .lazyPages {return _ccbaa ._bbccc ,nil ;};return len (_ccbaa ._dbdgb ),nil ;};func _cfeeg (_gcbg _aef .PdfObject )(*fontFile ,error ){_abe .Log .Trace ("\u006e\u0065\u0077\u0046\u006f\u006e\u0074\u0046\u0069\u006c\u0065\u0046\u0072\u006f\u006dP\u0064f\u004f\u0062\u006a\u0065\u0063\u0074\u003a\u0020\u006f\u0062\u006a\u003d\u0025\u0073",_gcbg );_dfgaec :=&fontFile {};_gcbg =_aef .TraceToDirectObject (_gcbg );_dfgad ,_fccfb :=_gcbg .(*_aef .PdfObjectStream );if !_fccfb {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020F\u006f\u006et\u0046\u0069\u006c\u0065\u0020\u006d\u0075\u0073t\u0020\u0062\u0065\u0020\u0061\u0020\u0073\u0074\u0072\u0065\u0061\u006d \u0028\u0025\u0054\u0029",_gcbg );return nil ,_aef .ErrTypeError ;};_eddf :=_dfgad .PdfObjectDictionary ;_bged ,_cdbfg :=_aef .DecodeStream (_dfgad );if _cdbfg !=nil {return nil ,_cdbfg ;};_adeg ,_fccfb :=_aef .GetNameVal (_eddf .Get ("\u0053u\u0062\u0074\u0079\u0070\u0065"));if !_fccfb {_dfgaec ._aacd =_adeg ;if _adeg =="\u0054\u0079\u0070\u0065\u0031\u0043"{_abe .Log .Debug ("T\u0079\u0070\u0065\u0031\u0043\u0020\u0066\u006f\u006e\u0074\u0073\u0020\u0061\u0072\u0065\u0020\u0063\u0075r\u0072\u0065\u006e\u0074\u006c\u0079\u0020\u006e\u006f\u0074 s\u0075\u0070\u0070o\u0072t\u0065\u0064");return nil ,ErrType1CFontNotSupported ;};};_aagab ,_ :=_aef .GetIntVal (_eddf .Get ("\u004ce\u006e\u0067\u0074\u0068\u0031"));_gcbgg ,_ :=_aef .GetIntVal (_eddf .Get ("\u004ce\u006e\u0067\u0074\u0068\u0032"));if _aagab > len (_bged ){_aagab =len (_bged );};if _aagab +_gcbgg > len (_bged ){_gcbgg =len (_bged )-_aagab ;};_gggf :=_bged [:_aagab ];var _ebad []byte ;if _gcbgg > 0{_ebad =_bged [_aagab :_aagab +_gcbgg ];};if _aagab > 0&&_gcbgg > 0{_cbbgg :=_dfgaec .loadFromSegments (_gggf ,_ebad );if _cbbgg !=nil {return nil ,_cbbgg ;};};return _dfgaec ,nil ;};
 
 // PdfActionSubmitForm represents a submitForm action.
 type PdfActionSubmitForm struct{*PdfAction ;F *PdfFilespec ;Fields _aef .PdfObject ;Flags _aef .PdfObject ;};
@@ -912,14 +912,14 @@ func (_egad *PdfColorDeviceCMYK )GetNumComponents ()int {return 4};func _bedgc (
 
 // ToImage converts an object to an Image which can be transformed or saved out.
 // The image data is decoded and the Image returned.
-func (_ggebee *XObjectImage )ToImage ()(*Image ,error ){_bfagc :=&Image {};if _ggebee .Height ==nil {return nil ,_fa .New ("\u0068e\u0069\u0067\u0068\u0074\u0020\u0061\u0074\u0074\u0072\u0069\u0062u\u0074\u0065\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067");};_bfagc .Height =*_ggebee .Height ;if _ggebee .Width ==nil {return nil ,_fa .New ("\u0077\u0069\u0064th\u0020\u0061\u0074\u0074\u0072\u0069\u0062\u0075\u0074\u0065\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067");};_bfagc .Width =*_ggebee .Width ;if _ggebee .BitsPerComponent ==nil {return nil ,_fa .New ("\u0062\u0069\u0074\u0073\u0020\u0070\u0065\u0072\u0020\u0063\u006fm\u0070\u006f\u006e\u0065\u006e\u0074\u0020\u006d\u0069\u0073s\u0069\u006e\u0067");};_bfagc .BitsPerComponent =*_ggebee .BitsPerComponent ;_bfagc .ColorComponents =_ggebee .ColorSpace .GetNumComponents ();_ggebee ._dcafe .Set ("\u0043o\u006co\u0072\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074\u0073",_aef .MakeInteger (int64 (_bfagc .ColorComponents )));_gedef ,_dbbcf :=_aef .DecodeStream (_ggebee ._dcafe );if _dbbcf !=nil {return nil ,_dbbcf ;};_bfagc .Data =_gedef ;if _ggebee .Decode !=nil {_befbg ,_gffcc :=_ggebee .Decode .(*_aef .PdfObjectArray );if !_gffcc {_abe .Log .Debug ("I\u006e\u0076\u0061\u006cid\u0020D\u0065\u0063\u006f\u0064\u0065 \u006f\u0062\u006a\u0065\u0063\u0074");return nil ,_fa .New ("\u0069\u006e\u0076a\u006c\u0069\u0064\u0020\u0074\u0079\u0070\u0065");};_bfafg ,_babdf :=_befbg .ToFloat64Array ();if _babdf !=nil {return nil ,_babdf ;};_bfagc ._fgafa =_bfafg ;};return _bfagc ,nil ;};
+func (_ggebee *XObjectImage )ToImage ()(*Image ,error ){_bfagc :=&Image {};if _ggebee .Height ==nil {return nil ,_fa .New ("\u0068e\u0069\u0067\u0068\u0074\u0020\u0061\u0074\u0074\u0072\u0069\u0062u\u0074\u0065\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067");};_bfagc .Height =*_ggebee .Height ;if _ggebee .Width ==nil {return nil ,_fa .New ("\u0077\u0069\u0064th\u0020\u0061\u0074\u0074\u0072\u0069\u0062\u0075\u0074\u0065\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067");};_bfagc .Width =*_ggebee .Width ;if _ggebee .BitsPerComponent ==nil {return nil ,_fa .New ("\u0062\u0069\u0074\u0073\u0020\u0070\u0065\u0072\u0020\u0063\u006fm\u0070\u006f\u006e\u0065\u006e\u0074\u0020\u006d\u0069\u0073s\u0069\u006e\u0067");};_bfagc .BitsPerComponent =*_ggebee .BitsPerComponent ;_bfagc .ColorComponents =_ggebee .ColorSpace .GetNumComponents ();_ggebee ._dcafe .Set ("\u0043o\u006co\u0072\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074\u0073",_aef .MakeInteger (int64 (_bfagc .ColorComponents )));if _dbbcf :=_ggebee .decodeData (_bfagc );_dbbcf !=nil {return nil ,_dbbcf ;};if _ggebee .Decode !=nil {_befbg ,_gffcc :=_ggebee .Decode .(*_aef .PdfObjectArray );if !_gffcc {_abe .Log .Debug ("I\u006e\u0076\u0061\u006cid\u0020D\u0065\u0063\u006f\u0064\u0065 \u006f\u0062\u006a\u0065\u0063\u0074");return nil ,_fa .New ("\u0069\u006e\u0076a\u006c\u0069\u0064\u0020\u0074\u0079\u0070\u0065");};_bfafg ,_babdf :=_befbg .ToFloat64Array ();if _babdf !=nil {return nil ,_babdf ;};_bfagc ._fgafa =_bfafg ;};return _bfagc ,nil ;};
 
 // ColorFromFloats returns a new PdfColor based on input color components.
 func (_dfggee *PdfColorspaceDeviceN )ColorFromFloats (vals []float64 )(PdfColor ,error ){if len (vals )!=_dfggee .GetNumComponents (){return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_bfcf ,_abacc :=_dfggee .TintTransform .Evaluate (vals );if _abacc !=nil {return nil ,_abacc ;};_bcac ,_abacc :=_dfggee .AlternateSpace .ColorFromFloats (_bfcf );if _abacc !=nil {return nil ,_abacc ;};return _bcac ,nil ;};
 
 // PdfReader represents a PDF file reader. It is a frontend to the lower level parsing mechanism and provides
 // a higher level access to work with PDF structure and information, such as the page structure etc.
-type PdfReader struct{_gdbbd *_aef .PdfParser ;_eebff _aef .PdfObject ;_cbfdd *_aef .PdfIndirectObject ;_fgbfc *_aef .PdfObjectDictionary ;_dbdgb []*_aef .PdfIndirectObject ;PageList []*PdfPage ;_bbccc int ;_acae *_aef .PdfObjectDictionary ;_gaad *PdfOutlineTreeNode ;AcroForm *PdfAcroForm ;DSS *DSS ;_fggbd *modelManager ;_afae bool ;_cadgb map[_aef .PdfObject ]struct{};_cced _gfc .ReadSeeker ;};
+type PdfReader struct{_gdbbd *_aef .PdfParser ;_eebff _aef .PdfObject ;_cbfdd *_aef .PdfIndirectObject ;_fgbfc *_aef .PdfObjectDictionary ;_dbdgb []*_aef .PdfIndirectObject ;PageList []*PdfPage ;_bbccc int ;_acae *_aef .PdfObjectDictionary ;_gaad *PdfOutlineTreeNode ;AcroForm *PdfAcroForm ;DSS *DSS ;_fggbd *modelManager ;_afae bool ;_cadgb map[_aef .PdfObject ]struct{};_cced _gfc .ReadSeeker ;password []byte ;pubKey *pubKeyCredentials ;lazyPages bool ;pageCache map[int64 ]*PdfPage ;pageMu _d .Mutex ;};
 
 // PdfActionImportData represents a importData action.
 type PdfActionImportData struct{*PdfAction ;F *PdfFilespec ;};
@@ -1046,8 +1046,10 @@ type PdfActionType string ;
 // HasXObjectByName checks if an XObject with a specified keyName is defined.
 func (_bfgcf *PdfPageResources )HasXObjectByName (keyName _aef .PdfObjectName )bool {_dbgbe ,_ :=_bfgcf .GetXObjectByName (keyName );return _dbgbe !=nil ;};func _daed (_fccaf []byte )([]byte ,error ){_bbfac :=_ec .New ();if _ ,_fegdac :=_gfc .Copy (_bbfac ,_cg .NewReader (_fccaf ));_fegdac !=nil {return nil ,_fegdac ;};return _bbfac .Sum (nil ),nil ;};func _gfab (_agbgd *_aef .PdfObjectDictionary )(*PdfFieldButton ,error ){_cbef :=&PdfFieldButton {};_cbef .Opt ,_ =_aef .GetArray (_agbgd .Get ("\u004f\u0070\u0074"));return _cbef ,nil ;};
 
-// Encrypt encrypts the output file with a specified user/owner password.
-func (_eegbff *PdfWriter )Encrypt (userPass ,ownerPass []byte ,options *EncryptOptions )error {_egdba :=RC4_128bit ;if options !=nil {_egdba =options .Algorithm ;};_dbgea :=_db .PermOwner ;if options !=nil {_dbgea =options .Permissions ;};var _bbada _cca .Filter ;switch _egdba {case RC4_128bit :_bbada =_cca .NewFilterV2 (16);case AES_128bit :_bbada =_cca .NewFilterAESV2 ();case AES_256bit :_bbada =_cca .NewFilterAESV3 ();default:return _b .Errorf ("\u0075n\u0073\u0075\u0070\u0070o\u0072\u0074\u0065\u0064\u0020a\u006cg\u006fr\u0069\u0074\u0068\u006d\u003a\u0020\u0025v",options .Algorithm );};_daebe ,_abacb ,_baada :=_aef .PdfCryptNewEncrypt (_bbada ,userPass ,ownerPass ,_dbgea );if _baada !=nil {return _baada ;};_eegbff ._ecfag =_daebe ;if _abacb .Major !=0{_eegbff .SetVersion (_abacb .Major ,_abacb .Minor );};_eegbff ._ebeb =_abacb .Encrypt ;_eegbff ._acddd =_aef .MakeArray (_aef .MakeHexString (_abacb .ID0 ),_aef .MakeHexString (_abacb .ID1 ));_degeg :=_aef .MakeIndirectObject (_abacb .Encrypt );_eegbff ._eebbg =_degeg ;_eegbff .addObject (_degeg );return nil ;};type pdfFontSimple struct{fontCommon ;_bcabb *_aef .PdfIndirectObject ;_bacac map[_be .CharCode ]float64 ;_bcbge _be .TextEncoder ;_gbee _be .TextEncoder ;_fggf *PdfFontDescriptor ;
+// Encrypt encrypts the output file with a specified user/owner password. When the Recipients of
+// `options` are set, the file is encrypted for the certificates of the recipients with the
+// public-key security handler instead, see PdfReader.DecryptWithKey.
+func (_eegbff *PdfWriter )Encrypt (userPass ,ownerPass []byte ,options *EncryptOptions )error {_egdba :=RC4_128bit ;if options !=nil {_egdba =options .Algorithm ;};_dbgea :=_db .PermOwner ;if options !=nil {_dbgea =options .Permissions ;};var _bbada _cca .Filter ;switch _egdba {case RC4_128bit :_bbada =_cca .NewFilterV2 (16);case AES_128bit :_bbada =_cca .NewFilterAESV2 ();case AES_256bit :_bbada =_cca .NewFilterAESV3 ();default:return _b .Errorf ("\u0075n\u0073\u0075\u0070\u0070o\u0072\u0074\u0065\u0064\u0020a\u006cg\u006fr\u0069\u0074\u0068\u006d\u003a\u0020\u0025v",options .Algorithm );};var (_daebe *_aef .PdfCrypt ;_abacb *_aef .EncryptInfo ;_baada error ;);if options !=nil &&len (options .Recipients )> 0{_daebe ,_abacb ,_baada =_aef .PdfCryptNewEncryptPubKey (_bbada ,options .Recipients ,options .CryptFilters );}else if options !=nil {_daebe ,_abacb ,_baada =_aef .PdfCryptNewEncryptWithOpts (_bbada ,userPass ,ownerPass ,_dbgea ,options .CryptFilters );}else {_daebe ,_abacb ,_baada =_aef .PdfCryptNewEncrypt (_bbada ,userPass ,ownerPass ,_dbgea );};if _baada !=nil {return _baada ;};_eegbff ._ecfag =_daebe ;if _abacb .Major !=0{_eegbff .SetVersion (_abacb .Major ,_abacb .Minor );};_eegbff ._ebeb =_abacb .Encrypt ;_eegbff ._acddd =_aef .MakeArray (_aef .MakeHexString (_abacb .ID0 ),_aef .MakeHexString (_abacb .ID1 ));_degeg :=_aef .MakeIndirectObject (_abacb .Encrypt );_eegbff ._eebbg =_degeg ;_eegbff .addObject (_degeg );return nil ;};type pdfFontSimple struct{fontCommon ;_bcabb *_aef .PdfIndirectObject ;_bacac map[_be .CharCode ]float64 ;_bcbge _be .TextEncoder ;_gbee _be .TextEncoder ;_fggf *PdfFontDescriptor ;
 
 // Encoding is subject to limitations that are described in 9.6.6, "Character Encoding".
 // BaseFont is derived differently.
@@ -1155,6 +1157,8 @@ func (_fdbgd *PdfColorspaceSpecialPattern )ToPdfObject ()_aef .PdfObject {if _fd
 // rather than entire structure being loaded into memory on reader creation.
 // Note that it may make sense to use the lazy-load reader when processing only parts of files,
 // rather than loading entire file into memory. Example: splitting a few pages from a large PDF file.
+// The objects are loaded by the parser, which is safe for concurrent use, so that the pages can be
+// read concurrently, e.g. with extractor.ProcessPages.
 func NewPdfReaderLazy (rs _gfc .ReadSeeker )(*PdfReader ,error ){_edgdc :=&PdfReader {_cced :rs ,_cadgb :map[_aef .PdfObject ]struct{}{},_fggbd :_beed (),_afae :true };_cdge ,_dgaaf :=_aef .NewParser (rs );if _dgaaf !=nil {return nil ,_dgaaf ;};_edgdc ._gdbbd =_cdge ;_bdcda ,_dgaaf :=_edgdc .IsEncrypted ();if _dgaaf !=nil {return nil ,_dgaaf ;};if !_bdcda {_dgaaf =_edgdc .loadStructure ();if _dgaaf !=nil {return nil ,_dgaaf ;};};return _edgdc ,nil ;};func _acfaf (_eeec _aef .PdfObject ,_eeaec bool )(*PdfFont ,error ){_fbdeb ,_fgef ,_aacce :=_fbfg (_eeec );if _aacce !=nil {if _aacce ==ErrType3FontNotSupported ||_aacce ==ErrType1CFontNotSupported {_ddbe ,_dcda :=_ceaf (_fbdeb ,_fgef ,nil );if _dcda !=nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0057h\u0069\u006c\u0065 l\u006f\u0061\u0064\u0069\u006e\u0067 \u0073\u0069\u006d\u0070\u006c\u0065\u0020\u0066\u006f\u006e\u0074\u003a\u0020\u0066\u006fn\u0074\u003d\u0025\u0073\u0020\u0065\u0072\u0072=\u0025\u0076",_fgef ,_dcda );return nil ,_aacce ;};return &PdfFont {_dccga :_ddbe },_aacce ;};return nil ,_aacce ;};_edab :=&PdfFont {};switch _fgef ._ababd {case "\u0054\u0079\u0070e\u0030":if !_eeaec {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052:\u0020\u004c\u006f\u0061\u0064\u0069\u006e\u0067\u0020\u0074\u0079\u0070\u00650\u0020\u006e\u006f\u0074\u0020\u0061\u006c\u006c\u006f\u0077\u0065\u0064\u002e\u0020\u0066\u006f\u006e\u0074\u003d\u0025\u0073",_fgef );return nil ,_fa .New ("\u0063\u0079\u0063\u006cic\u0061\u006c\u0020\u0074\u0079\u0070\u0065\u0030\u0020\u006c\u006f\u0061\u0064\u0069n\u0067");};_ggdda ,_aecbf :=_bbeg (_fbdeb ,_fgef );if _aecbf !=nil {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020\u0057\u0068\u0069l\u0065\u0020\u006c\u006f\u0061\u0064\u0069ng\u0020\u0054\u0079\u0070e\u0030\u0020\u0066\u006f\u006e\u0074\u002e\u0020\u0066on\u0074\u003d%\u0073\u0020\u0065\u0072\u0072\u003d\u0025\u0076",_fgef ,_aecbf );return nil ,_aecbf ;};_edab ._dccga =_ggdda ;case "\u0054\u0079\u0070e\u0031","\u0054\u0079\u0070e\u0033","\u004dM\u0054\u0079\u0070\u0065\u0031","\u0054\u0072\u0075\u0065\u0054\u0079\u0070\u0065":var _facee *pdfFontSimple ;_dfag ,_ddfd :=_ecf .NewStdFontByName (_ecf .StdFontName (_fgef ._fegdg ));if _ddfd {_gbdc :=_edbed (_dfag );_edab ._dccga =&_gbdc ;_egabe :=_aef .TraceToDirectObject (_gbdc .ToPdfObject ());_cafaf ,_dgdbe ,_agce :=_fbfg (_egabe );if _agce !=nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0042\u0061\u0064\u0020\u0053\u0074a\u006e\u0064\u0061\u0072\u0064\u00314\u000a\u0009\u0066\u006f\u006e\u0074\u003d\u0025\u0073\u000a\u0009\u0073\u0074d\u003d\u0025\u002b\u0076",_fgef ,_gbdc );return nil ,_agce ;};for _ ,_ffaeg :=range _fbdeb .Keys (){_cafaf .Set (_ffaeg ,_fbdeb .Get (_ffaeg ));};_facee ,_agce =_ceaf (_cafaf ,_dgdbe ,_gbdc ._gbee );if _agce !=nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0042\u0061\u0064\u0020\u0053\u0074a\u006e\u0064\u0061\u0072\u0064\u00314\u000a\u0009\u0066\u006f\u006e\u0074\u003d\u0025\u0073\u000a\u0009\u0073\u0074d\u003d\u0025\u002b\u0076",_fgef ,_gbdc );return nil ,_agce ;};_facee ._bacac =_gbdc ._bacac ;_facee ._gcbd =_gbdc ._gcbd ;}else {_facee ,_aacce =_ceaf (_fbdeb ,_fgef ,nil );if _aacce !=nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0057h\u0069\u006c\u0065 l\u006f\u0061\u0064\u0069\u006e\u0067 \u0073\u0069\u006d\u0070\u006c\u0065\u0020\u0066\u006f\u006e\u0074\u003a\u0020\u0066\u006fn\u0074\u003d\u0025\u0073\u0020\u0065\u0072\u0072=\u0025\u0076",_fgef ,_aacce );return nil ,_aacce ;};};_aacce =_facee .addEncoding ();if _aacce !=nil {return nil ,_aacce ;};if _ddfd {_facee .updateStandard14Font ();};if _ddfd &&_facee ._bcbge ==nil &&_facee ._gbee ==nil {_abe .Log .Error ("\u0073\u0069\u006d\u0070\u006c\u0065\u0066\u006f\u006e\u0074\u003d\u0025\u0073",_facee );_abe .Log .Error ("\u0066n\u0074\u003d\u0025\u002b\u0076",_dfag );};if len (_facee ._bacac )==0{_abe .Log .Debug ("\u0045R\u0052\u004f\u0052\u003a \u004e\u006f\u0020\u0077\u0069d\u0074h\u0073.\u0020\u0066\u006f\u006e\u0074\u003d\u0025s",_facee );};_edab ._dccga =_facee ;case "\u0043\u0049\u0044F\u006f\u006e\u0074\u0054\u0079\u0070\u0065\u0030":_adadf ,_abcdb :=_gfabd (_fbdeb ,_fgef );if _abcdb !=nil {_abe .Log .Debug ("\u0045R\u0052\u004fR\u003a\u0020\u0057\u0068i\u006c\u0065\u0020l\u006f\u0061\u0064\u0069\u006e\u0067\u0020\u0063\u0069d \u0066\u006f\u006et\u0020\u0074y\u0070\u0065\u0030\u0020\u0066\u006fn\u0074\u003a \u0025\u0076",_abcdb );return nil ,_abcdb ;};_edab ._dccga =_adadf ;case "\u0043\u0049\u0044F\u006f\u006e\u0074\u0054\u0079\u0070\u0065\u0032":_bdabe ,_aega :=_gagac (_fbdeb ,_fgef );if _aega !=nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0057\u0068\u0069l\u0065\u0020\u006co\u0061\u0064\u0069\u006e\u0067\u0020\u0063\u0069\u0064\u0020f\u006f\u006e\u0074\u0020\u0074yp\u0065\u0032\u0020\u0066\u006f\u006e\u0074\u002e\u0020\u0066\u006f\u006e\u0074\u003d\u0025\u0073\u0020\u0065\u0072\u0072\u003d\u0025\u0076",_fgef ,_aega );return nil ,_aega ;};_edab ._dccga =_bdabe ;default:_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020U\u006e\u0073u\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020f\u006f\u006e\u0074\u0020\u0074\u0079\u0070\u0065\u003a\u0020\u0066\u006fn\u0074\u003d\u0025\u0073",_fgef );return nil ,_b .Errorf ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065d\u0020\u0066\u006f\u006e\u0074\u0020\u0074y\u0070\u0065\u003a\u0020\u0066\u006f\u006e\u0074\u003d\u0025\u0073",_fgef );};return _edab ,nil ;};
 
 // Encoder returns the font's text encoder.
@@ -1240,7 +1244,7 @@ func NewOutlineBookmark (title string ,page *_aef .PdfIndirectObject )*PdfOutlin
 // Decrypt decrypts the PDF file with a specified password.  Also tries to
 // decrypt with an empty password.  Returns true if successful,
 // false otherwise.
-func (_dgdfe *PdfReader )Decrypt (password []byte )(bool ,error ){_gegcd ,_bagfa :=_dgdfe ._gdbbd .Decrypt (password );if _bagfa !=nil {return false ,_bagfa ;};if !_gegcd {return false ,nil ;};_bagfa =_dgdfe .loadStructure ();if _bagfa !=nil {_abe .Log .Debug ("\u0045\u0052\u0052OR\u003a\u0020\u0046\u0061\u0069\u006c\u0020\u0074\u006f \u006co\u0061d\u0020s\u0074\u0072\u0075\u0063\u0074\u0075\u0072\u0065\u0020\u0028\u0025\u0073\u0029",_bagfa );return false ,_bagfa ;};return true ,nil ;};
+func (_dgdfe *PdfReader )Decrypt (password []byte )(bool ,error ){_gegcd ,_bagfa :=_dgdfe ._gdbbd .Decrypt (password );if _bagfa !=nil {return false ,_bagfa ;};if !_gegcd {return false ,nil ;};_dgdfe .password =password ;_bagfa =_dgdfe .loadStructure ();if _bagfa !=nil {_abe .Log .Debug ("\u0045\u0052\u0052OR\u003a\u0020\u0046\u0061\u0069\u006c\u0020\u0074\u006f \u006co\u0061d\u0020s\u0074\u0072\u0075\u0063\u0074\u0075\u0072\u0065\u0020\u0028\u0025\u0073\u0029",_bagfa );return false ,_bagfa ;};return true ,nil ;};
 
 // ToPdfObject implements interface PdfModel.
 func (_eeea *PdfAnnotationStamp )ToPdfObject ()_aef .PdfObject {_eeea .PdfAnnotation .ToPdfObject ();_dfggb :=_eeea ._edc ;_cbdc :=_dfggb .PdfObject .(*_aef .PdfObjectDictionary );_eeea .PdfAnnotationMarkup .appendToPdfDictionary (_cbdc );_cbdc .SetIfNotNil ("\u0053u\u0062\u0074\u0079\u0070\u0065",_aef .MakeName ("\u0053\u0074\u0061m\u0070"));_cbdc .SetIfNotNil ("\u004e\u0061\u006d\u0065",_eeea .Name );return _dfggb ;};
@@ -1366,7 +1370,7 @@ func (_ccccb *PdfAnnotationCircle )ToPdfObject ()_aef .PdfObject {_ccccb .PdfAnn
 
 // NewXObjectImageFromStream builds the image xobject from a stream object.
 // An image dictionary is the dictionary portion of a stream object representing an image XObject.
-func NewXObjectImageFromStream (stream *_aef .PdfObjectStream )(*XObjectImage ,error ){_gddbc :=&XObjectImage {};_gddbc ._dcafe =stream ;_fbeg :=*(stream .PdfObjectDictionary );_afee ,_ebdada :=_aef .NewEncoderFromStream (stream );if _ebdada !=nil {return nil ,_ebdada ;};_gddbc .Filter =_afee ;if _bgadf :=_aef .TraceToDirectObject (_fbeg .Get ("\u0057\u0069\u0064t\u0068"));_bgadf !=nil {_gfcde ,_agaeff :=_bgadf .(*_aef .PdfObjectInteger );if !_agaeff {return nil ,_fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0069\u006d\u0061g\u0065\u0020\u0077\u0069\u0064\u0074\u0068\u0020\u006f\u0062j\u0065\u0063\u0074");};_abeaf :=int64 (*_gfcde );_gddbc .Width =&_abeaf ;}else {return nil ,_fa .New ("\u0077\u0069\u0064\u0074\u0068\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067");};if _aaege :=_aef .TraceToDirectObject (_fbeg .Get ("\u0048\u0065\u0069\u0067\u0068\u0074"));_aaege !=nil {_eedfa ,_ddaeg :=_aaege .(*_aef .PdfObjectInteger );if !_ddaeg {return nil ,_fa .New ("i\u006e\u0076\u0061\u006c\u0069\u0064 \u0069\u006d\u0061\u0067\u0065\u0020\u0068\u0065\u0069g\u0068\u0074\u0020o\u0062j\u0065\u0063\u0074");};_gbdgf :=int64 (*_eedfa );_gddbc .Height =&_gbdgf ;}else {return nil ,_fa .New ("\u0068\u0065\u0069\u0067\u0068\u0074\u0020\u006d\u0069s\u0073\u0069\u006e\u0067");};if _dggf :=_aef .TraceToDirectObject (_fbeg .Get ("\u0043\u006f\u006c\u006f\u0072\u0053\u0070\u0061\u0063\u0065"));_dggf !=nil {_fagae ,_gdgae :=NewPdfColorspaceFromPdfObject (_dggf );if _gdgae !=nil {return nil ,_gdgae ;};_gddbc .ColorSpace =_fagae ;}else {_abe .Log .Debug ("\u0058\u004f\u0062\u006a\u0065\u0063t\u0020\u0049\u006d\u0061\u0067e\u0020\u0063\u006f\u006c\u006f\u0072s\u0070\u0061\u0063\u0065\u0020n\u006f\u0074\u0020\u0073\u0070\u0065\u0063\u0069\u0066\u0069\u0065\u0064 \u002d\u0020\u0061\u0073\u0073\u0075\u006d\u0069\u006e\u0067\u0020\u0031\u0020\u0063\u006fl\u006f\u0072\u0020\u0063\u006f\u006d\u0070\u006fn\u0065\u006e\u0074");_gddbc .ColorSpace =NewPdfColorspaceDeviceGray ();};if _afdfe :=_aef .TraceToDirectObject (_fbeg .Get ("\u0042\u0069t\u0073\u0050\u0065r\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074"));_afdfe !=nil {_eagfg ,_facgd :=_afdfe .(*_aef .PdfObjectInteger );if !_facgd {return nil ,_fa .New ("i\u006e\u0076\u0061\u006c\u0069\u0064 \u0069\u006d\u0061\u0067\u0065\u0020\u0068\u0065\u0069g\u0068\u0074\u0020o\u0062j\u0065\u0063\u0074");};_cddfd :=int64 (*_eagfg );_gddbc .BitsPerComponent =&_cddfd ;};_gddbc .Intent =_fbeg .Get ("\u0049\u006e\u0074\u0065\u006e\u0074");_gddbc .ImageMask =_fbeg .Get ("\u0049m\u0061\u0067\u0065\u004d\u0061\u0073k");_gddbc .Mask =_fbeg .Get ("\u004d\u0061\u0073\u006b");_gddbc .Decode =_fbeg .Get ("\u0044\u0065\u0063\u006f\u0064\u0065");_gddbc .Interpolate =_fbeg .Get ("I\u006e\u0074\u0065\u0072\u0070\u006f\u006c\u0061\u0074\u0065");_gddbc .Alternatives =_fbeg .Get ("\u0041\u006c\u0074e\u0072\u006e\u0061\u0074\u0069\u0076\u0065\u0073");_gddbc .SMask =_fbeg .Get ("\u0053\u004d\u0061s\u006b");_gddbc .SMaskInData =_fbeg .Get ("S\u004d\u0061\u0073\u006b\u0049\u006e\u0044\u0061\u0074\u0061");_gddbc .Matte =_fbeg .Get ("\u004d\u0061\u0074t\u0065");_gddbc .Name =_fbeg .Get ("\u004e\u0061\u006d\u0065");_gddbc .StructParent =_fbeg .Get ("\u0053\u0074\u0072u\u0063\u0074\u0050\u0061\u0072\u0065\u006e\u0074");_gddbc .ID =_fbeg .Get ("\u0049\u0044");_gddbc .OPI =_fbeg .Get ("\u004f\u0050\u0049");_gddbc .Metadata =_fbeg .Get ("\u004d\u0065\u0074\u0061\u0064\u0061\u0074\u0061");_gddbc .OC =_fbeg .Get ("\u004f\u0043");_gddbc .Stream =stream .Stream ;return _gddbc ,nil ;};func _acfce (_eegfc *fontCommon )*pdfCIDFontType0 {return &pdfCIDFontType0 {fontCommon :*_eegfc }};
+func NewXObjectImageFromStream (stream *_aef .PdfObjectStream )(*XObjectImage ,error ){_gddbc :=&XObjectImage {};_gddbc ._dcafe =stream ;_fbeg :=*(stream .PdfObjectDictionary );_afee ,_ebdada :=_aef .NewEncoderFromStream (stream );if _ebdada !=nil {return nil ,_ebdada ;};_gddbc .Filter =_afee ;if _bgadf :=_aef .TraceToDirectObject (_fbeg .Get ("\u0057\u0069\u0064t\u0068"));_bgadf !=nil {_gfcde ,_agaeff :=_bgadf .(*_aef .PdfObjectInteger );if !_agaeff {return nil ,_fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0069\u006d\u0061g\u0065\u0020\u0077\u0069\u0064\u0074\u0068\u0020\u006f\u0062j\u0065\u0063\u0074");};_abeaf :=int64 (*_gfcde );_gddbc .Width =&_abeaf ;}else {return nil ,_fa .New ("\u0077\u0069\u0064\u0074\u0068\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067");};if _aaege :=_aef .TraceToDirectObject (_fbeg .Get ("\u0048\u0065\u0069\u0067\u0068\u0074"));_aaege !=nil {_eedfa ,_ddaeg :=_aaege .(*_aef .PdfObjectInteger );if !_ddaeg {return nil ,_fa .New ("i\u006e\u0076\u0061\u006c\u0069\u0064 \u0069\u006d\u0061\u0067\u0065\u0020\u0068\u0065\u0069g\u0068\u0074\u0020o\u0062j\u0065\u0063\u0074");};_gbdgf :=int64 (*_eedfa );_gddbc .Height =&_gbdgf ;}else {return nil ,_fa .New ("\u0068\u0065\u0069\u0067\u0068\u0074\u0020\u006d\u0069s\u0073\u0069\u006e\u0067");};if _dggf :=_aef .TraceToDirectObject (_fbeg .Get ("\u0043\u006f\u006c\u006f\u0072\u0053\u0070\u0061\u0063\u0065"));_dggf !=nil {_fagae ,_gdgae :=NewPdfColorspaceFromPdfObject (_dggf );if _gdgae !=nil {return nil ,_gdgae ;};_gddbc .ColorSpace =_fagae ;}else {_abe .Log .Debug ("\u0058\u004f\u0062\u006a\u0065\u0063t\u0020\u0049\u006d\u0061\u0067e\u0020\u0063\u006f\u006c\u006f\u0072s\u0070\u0061\u0063\u0065\u0020n\u006f\u0074\u0020\u0073\u0070\u0065\u0063\u0069\u0066\u0069\u0065\u0064 \u002d\u0020\u0061\u0073\u0073\u0075\u006d\u0069\u006e\u0067\u0020\u0031\u0020\u0063\u006fl\u006f\u0072\u0020\u0063\u006f\u006d\u0070\u006fn\u0065\u006e\u0074");_gddbc .ColorSpace =NewPdfColorspaceDeviceGray ();};if _afdfe :=_aef .TraceToDirectObject (_fbeg .Get ("\u0042\u0069t\u0073\u0050\u0065r\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074"));_afdfe !=nil {_eagfg ,_facgd :=_afdfe .(*_aef .PdfObjectInteger );if !_facgd {return nil ,_fa .New ("i\u006e\u0076\u0061\u006c\u0069\u0064 \u0069\u006d\u0061\u0067\u0065\u0020\u0068\u0065\u0069g\u0068\u0074\u0020o\u0062j\u0065\u0063\u0074");};_cddfd :=int64 (*_eagfg );_gddbc .BitsPerComponent =&_cddfd ;};_gddbc .Intent =_fbeg .Get ("\u0049\u006e\u0074\u0065\u006e\u0074");_gddbc .ImageMask =_fbeg .Get ("\u0049m\u0061\u0067\u0065\u004d\u0061\u0073k");_gddbc .Mask =_fbeg .Get ("\u004d\u0061\u0073\u006b");_gddbc .Decode =_fbeg .Get ("\u0044\u0065\u0063\u006f\u0064\u0065");_gddbc .Interpolate =_fbeg .Get ("I\u006e\u0074\u0065\u0072\u0070\u006f\u006c\u0061\u0074\u0065");_gddbc .Alternatives =_fbeg .Get ("\u0041\u006c\u0074e\u0072\u006e\u0061\u0074\u0069\u0076\u0065\u0073");_gddbc .SMask =_fbeg .Get ("\u0053\u004d\u0061s\u006b");_gddbc .SMaskInData =_fbeg .Get ("S\u004d\u0061\u0073\u006b\u0049\u006e\u0044\u0061\u0074\u0061");_gddbc .Matte =_fbeg .Get ("\u004d\u0061\u0074t\u0065");_gddbc .Name =_fbeg .Get ("\u004e\u0061\u006d\u0065");_gddbc .StructParent =_fbeg .Get ("\u0053\u0074\u0072u\u0063\u0074\u0050\u0061\u0072\u0065\u006e\u0074");_gddbc .ID =_fbeg .Get ("\u0049\u0044");_gddbc .OPI =_fbeg .Get ("\u004f\u0050\u0049");_gddbc .Metadata =_fbeg .Get ("\u004d\u0065\u0074\u0061\u0064\u0061\u0074\u0061");_gddbc .OC =_fbeg .Get ("\u004f\u0043");_gddbc .Stream =stream .Stream ;_gddbc .setJPXDefaults (_fbeg );return _gddbc ,nil ;};func _acfce (_eegfc *fontCommon )*pdfCIDFontType0 {return &pdfCIDFontType0 {fontCommon :*_eegfc }};
 
 // CharcodeBytesToUnicode converts PDF character codes `data` to a Go unicode string.
 //
@@ -1446,7 +1450,7 @@ func NewPdfRectangle (arr _aef .PdfObjectArray )(*PdfRectangle ,error ){_ddgdgb
 // components. The slice should contain three elements representing the
 // red, green and blue components of the color. The values of the elements
 // should be between 0 and 1.
-func (_fdaag *PdfColorspaceDeviceRGB )ColorFromFloats (vals []float64 )(PdfColor ,error ){if len (vals )!=3{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_gbga :=vals [0];if _gbga < 0.0||_gbga > 1.0{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_fcfg :=vals [1];if _fcfg < 0.0||_fcfg > 1.0{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_ecdc :=vals [2];if _ecdc < 0.0||_ecdc > 1.0{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_facg :=NewPdfColorDeviceRGB (_gbga ,_fcfg ,_ecdc );return _facg ,nil ;};func _gbgga (_fgbae *PdfField ,_bbbef _aef .PdfObject ){for _ ,_cbfeg :=range _fgbae .Annotations {_cbfeg .AS =_bbbef ;_cbfeg .ToPdfObject ();};};
+func (_fdaag *PdfColorspaceDeviceRGB )ColorFromFloats (vals []float64 )(PdfColor ,error ){if len (vals )!=3{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_gbga :=vals [0];if _gbga < 0.0||_gbga > 1.0{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_fcfg :=vals [1];if _fcfg < 0.0||_fcfg > 1.0{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_ecdc :=vals [2];if _ecdc < 0.0||_ecdc > 1.0{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_facg :=NewPdfColorDeviceRGB (_gbga ,_fcfg ,_ecdc );return _facg ,nil ;};func _gbgga (_fgbae *PdfField ,_bbbef _aef .PdfObject ){for _ ,_cbfeg :=range _fgbae .Annotations {_bgcaf :=_bbbef ;if _dfcge ,_afgeb :=_aef .GetName (_bbbef );_afgeb {if _ecgad ,_cgfea :=_aef .GetDict (_cbfeg .AP );_cgfea {if _baedf ,_fgaec :=_aef .GetDict (_ecgad .Get ("\u004e"));_fgaec &&_baedf .Get (*_dfcge )==nil &&_baedf .Get ("\u004f\u0066\u0066")!=nil {_bgcaf =_aef .MakeName ("\u004f\u0066\u0066");};};};_cbfeg .AS =_bgcaf ;_cbfeg .ToPdfObject ();};};
 
 // C returns the value of the C component of the color.
 func (_cadb *PdfColorCalRGB )C ()float64 {return _cadb [2]};func (_gaef *PdfReader )newPdfAnnotationSquigglyFromDict (_eefa *_aef .PdfObjectDictionary )(*PdfAnnotationSquiggly ,error ){_cecd :=PdfAnnotationSquiggly {};_fedf ,_egfb :=_gaef .newPdfAnnotationMarkupFromDict (_eefa );if _egfb !=nil {return nil ,_egfb ;};_cecd .PdfAnnotationMarkup =_fedf ;_cecd .QuadPoints =_eefa .Get ("\u0051\u0075\u0061\u0064\u0050\u006f\u0069\u006e\u0074\u0073");return &_cecd ,nil ;};func _ecgdg ()string {_abdcd .Lock ();defer _abdcd .Unlock ();return _caceb };
@@ -1489,7 +1493,7 @@ func (_cdadb *PdfFunctionType3 )Evaluate (x []float64 )([]float64 ,error ){if le
 // AcroFormNeedsRepair returns true if the document contains widget annotations
 // linked to fields which are not referenced in the AcroForm. The AcroForm can
 // be repaired using the RepairAcroForm method of the reader.
-func (_cgbga *PdfReader )AcroFormNeedsRepair ()(bool ,error ){var _efab []*PdfField ;if _cgbga .AcroForm !=nil {_efab =_cgbga .AcroForm .AllFields ();};_abffg :=make (map[*PdfField ]struct{},len (_efab ));for _ ,_facfb :=range _efab {_abffg [_facfb ]=struct{}{};};for _ ,_bfbdb :=range _cgbga .PageList {_dcfgb ,_dddb :=_bfbdb .GetAnnotations ();if _dddb !=nil {return false ,_dddb ;};for _ ,_afggf :=range _dcfgb {_ccefc ,_baed :=_afggf .GetContext ().(*PdfAnnotationWidget );if !_baed {continue ;};_dgaff :=_ccefc .Field ();if _dgaff ==nil {return true ,nil ;};if _ ,_cfgge :=_abffg [_dgaff ];!_cfgge {return true ,nil ;};};};return false ,nil ;};
+func (_cgbga *PdfReader )AcroFormNeedsRepair ()(bool ,error ){if _cfdeg :=_cgbga .loadPages ();_cfdeg !=nil {return false ,_cfdeg ;};var _efab []*PdfField ;if _cgbga .AcroForm !=nil {_efab =_cgbga .AcroForm .AllFields ();};_abffg :=make (map[*PdfField ]struct{},len (_efab ));for _ ,_facfb :=range _efab {_abffg [_facfb ]=struct{}{};};for _ ,_bfbdb :=range _cgbga .PageList {_dcfgb ,_dddb :=_bfbdb .GetAnnotations ();if _dddb !=nil {return false ,_dddb ;};for _ ,_afggf :=range _dcfgb {_ccefc ,_baed :=_afggf .GetContext ().(*PdfAnnotationWidget );if !_baed {continue ;};_dgaff :=_ccefc .Field ();if _dgaff ==nil {return true ,nil ;};if _ ,_cfgge :=_abffg [_dgaff ];!_cfgge {return true ,nil ;};};};return false ,nil ;};
 
 // ToGray returns a PdfColorDeviceGray color based on the current RGB color.
 func (_gcdg *PdfColorDeviceRGB )ToGray ()*PdfColorDeviceGray {_fbdg :=0.3*_gcdg .R ()+0.59*_gcdg .G ()+0.11*_gcdg .B ();_fbdg =_ad .Min (_ad .Max (_fbdg ,0.0),1.0);return NewPdfColorDeviceGray (_fbdg );};func (_aeggb *PdfReader )resolveReference (_gfabe *_aef .PdfObjectReference )(_aef .PdfObject ,bool ,error ){_cdaee ,_gadba :=_aeggb ._gdbbd .ObjCache [int (_gfabe .ObjectNumber )];if !_gadba {_abe .Log .Trace ("R\u0065\u0061\u0064\u0065r \u004co\u006f\u006b\u0075\u0070\u0020r\u0065\u0066\u003a\u0020\u0025\u0073",_gfabe );_aagf ,_cbccb :=_aeggb ._gdbbd .LookupByReference (*_gfabe );if _cbccb !=nil {return nil ,false ,_cbccb ;};_aeggb ._gdbbd .ObjCache [int (_gfabe .ObjectNumber )]=_aagf ;return _aagf ,false ,nil ;};return _cdaee ,true ,nil ;};
@@ -1692,7 +1696,7 @@ type PdfAnnotationHighlight struct{*PdfAnnotation ;*PdfAnnotationMarkup ;QuadPoi
 type PdfActionNamed struct{*PdfAction ;N _aef .PdfObject ;};
 
 // Write writes out the PDF.
-func (_fafbd *PdfWriter )Write (writer _gfc .Writer )error {_abe .Log .Trace ("\u0057r\u0069\u0074\u0065\u0028\u0029");_aaged :=_cga .GetLicenseKey ();if (_aaged ==nil ||!_aaged .IsLicensed ())&&!_bbbga {_b .Printf ("\u0055\u006e\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0064\u0020c\u006f\u0070\u0079\u0020\u006f\u0066\u0020\u0055\u006e\u0069P\u0044\u0046\u000a");_b .Println ("-\u0020\u0047\u0065\u0074\u0020\u0061\u0020\u0066\u0072e\u0065\u0020\u0074\u0072\u0069\u0061\u006c l\u0069\u0063\u0065\u006es\u0065\u0020\u006f\u006e\u0020\u0068\u0074\u0074\u0070s:\u002f\u002fu\u006e\u0069\u0064\u006f\u0063\u002e\u0069\u006f");return _fa .New ("\u0075\u006e\u0069\u0070d\u0066\u0020\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0020c\u006fd\u0065\u0020\u0072\u0065\u0071\u0075\u0069r\u0065\u0064");};if _fafbd ._aabdg !=nil {_abe .Log .Trace ("\u004f\u0075t\u006c\u0069\u006ee\u0054\u0072\u0065\u0065\u003a\u0020\u0025\u002b\u0076",_fafbd ._aabdg );_bdadg :=_fafbd ._aabdg .ToPdfObject ();_abe .Log .Trace ("\u004fu\u0074\u006c\u0069\u006e\u0065\u0073\u003a\u0020\u0025\u002b\u0076 \u0028\u0025\u0054\u002c\u0020\u0070\u003a\u0025\u0070\u0029",_bdadg ,_bdadg ,_bdadg );_fafbd ._abebc .Set ("\u004f\u0075\u0074\u006c\u0069\u006e\u0065\u0073",_bdadg );_eacef :=_fafbd .addObjects (_bdadg );if _eacef !=nil {return _eacef ;};};if _fafbd ._feabe !=nil {_abe .Log .Trace ("\u0057r\u0069t\u0069\u006e\u0067\u0020\u0061c\u0072\u006f \u0066\u006f\u0072\u006d\u0073");_efdca :=_fafbd ._feabe .ToPdfObject ();_abe .Log .Trace ("\u0041\u0063\u0072\u006f\u0046\u006f\u0072\u006d\u003a\u0020\u0025\u002b\u0076",_efdca );_fafbd ._abebc .Set ("\u0041\u0063\u0072\u006f\u0046\u006f\u0072\u006d",_efdca );_bdcf :=_fafbd .addObjects (_efdca );if _bdcf !=nil {return _bdcf ;};};for _bbegf ,_bdadc :=range _fafbd ._eabcf {if !_fafbd .hasObject (_bbegf ){_abe .Log .Debug ("\u0057\u0041\u0052\u004e\u0020\u0050\u0065n\u0064\u0069\u006eg\u0020\u006f\u0062j\u0065\u0063t\u0020\u0025\u002b\u0076\u0020\u0025T\u0020(%\u0070\u0029\u0020\u006e\u0065\u0076\u0065\u0072\u0020\u0061\u0064\u0064\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0077\u0072\u0069\u0074\u0069\u006e\u0067",_bbegf ,_bbegf ,_bbegf );for _ ,_cgbcbd :=range _bdadc {for _ ,_gbcec :=range _cgbcbd .Keys (){_dcfdc :=_cgbcbd .Get (_gbcec );if _dcfdc ==_bbegf {_abe .Log .Debug ("\u0050e\u006e\u0064i\u006e\u0067\u0020\u006fb\u006a\u0065\u0063t\u0020\u0066\u006f\u0075\u006e\u0064\u0021\u0020\u0061nd\u0020\u0072\u0065p\u006c\u0061c\u0065\u0064\u0020\u0077\u0069\u0074h\u0020\u006eu\u006c\u006c");_cgbcbd .Set (_gbcec ,_aef .MakeNull ());break ;};};};};};_fafbd ._abebc .Set ("\u0056e\u0072\u0073\u0069\u006f\u006e",_aef .MakeName (_b .Sprintf ("\u0025\u0064\u002e%\u0064",_fafbd ._cfebb ,_fafbd ._gbcce )));_fafbd .copyObjects ();if _fafbd ._ceag !=nil {var _eeeac error ;_fafbd ._aage ,_eeeac =_fafbd ._ceag .Optimize (_fafbd ._aage );if _eeeac !=nil {return _eeeac ;};_bcaff :=make (map[_aef .PdfObject ]struct{},len (_fafbd ._aage ));for _ ,_edgce :=range _fafbd ._aage {_bcaff [_edgce ]=struct{}{};};_fafbd ._cafea =_bcaff ;};_fafbd ._dfdcd =_fafbd ._ccdaf ;_fafbd ._fcadd =_bc .NewWriter (writer );_abddf :=_fafbd ._cfebb > 1||(_fafbd ._cfebb ==1&&_fafbd ._gbcce > 4);if _fafbd ._ecfeg !=nil {_abddf =*_fafbd ._ecfeg ;};_dffea :=make (map[_aef .PdfObject ]bool );for _ ,_dfgab :=range _fafbd ._aage {if _ddffga ,_deff :=_dfgab .(*_aef .PdfObjectStreams );_deff {_abddf =true ;for _ ,_adbea :=range _ddffga .Elements (){_dffea [_adbea ]=true ;if _baegf ,_acccea :=_adbea .(*_aef .PdfIndirectObject );_acccea {_dffea [_baegf .PdfObject ]=true ;};};};};if _abddf &&_fafbd ._cfebb ==1&&_fafbd ._gbcce < 5{_fafbd ._gbcce =5;};if _fafbd ._bcdd {_fafbd .writeString ("\u000a");}else {_fafbd .writeString (_b .Sprintf ("\u0025\u0025\u0050D\u0046\u002d\u0025\u0064\u002e\u0025\u0064\u000a",_fafbd ._cfebb ,_fafbd ._gbcce ));_fafbd .writeString ("\u0025\u00e2\u00e3\u00cf\u00d3\u000a");};_fafbd .updateObjectNumbers ();_abe .Log .Trace ("\u0057\u0072\u0069\u0074\u0069\u006e\u0067\u0020\u0025d\u0020\u006f\u0062\u006a",len (_fafbd ._aage ));_fafbd ._ffede =make (map[int ]crossReference );_fafbd ._ffede [0]=crossReference {Type :0,ObjectNumber :0,Generation :0xFFFF};if _fafbd ._gdbde .ObjectMap !=nil {for _adbg ,_caafd :=range _fafbd ._gdbde .ObjectMap {if _adbg ==0{continue ;};if _caafd .XType ==_aef .XrefTypeObjectStream {_dfeaa :=crossReference {Type :2,ObjectNumber :_caafd .OsObjNumber ,Index :_caafd .OsObjIndex };_fafbd ._ffede [_adbg ]=_dfeaa ;};if _caafd .XType ==_aef .XrefTypeTableEntry {_befbc :=crossReference {Type :1,ObjectNumber :_caafd .ObjectNumber ,Offset :_caafd .Offset };_fafbd ._ffede [_adbg ]=_befbc ;};};};for _ ,_dgdad :=range _fafbd ._aage {if _aedee :=_dffea [_dgdad ];_aedee {continue ;};_egdd :=int64 (0);switch _ecead :=_dgdad .(type ){case *_aef .PdfIndirectObject :_egdd =_ecead .ObjectNumber ;case *_aef .PdfObjectStream :_egdd =_ecead .ObjectNumber ;case *_aef .PdfObjectStreams :_egdd =_ecead .ObjectNumber ;default:_abe .Log .Debug ("\u0045R\u0052\u004fR\u003a\u0020\u0055n\u0073\u0075\u0070\u0070\u006f\u0072\u0074e\u0064\u0020\u0074\u0079\u0070\u0065 \u0069\u006e\u0020\u0077\u0072\u0069\u0074\u0065\u0072\u0020\u006fb\u006a\u0065\u0063\u0074\u0073\u003a\u0020\u0025\u0054",_dgdad );return ErrTypeCheck ;};if _fafbd ._ecfag !=nil &&_dgdad !=_fafbd ._eebbg {_dbaef :=_fafbd ._ecfag .Encrypt (_dgdad ,_egdd ,0);if _dbaef !=nil {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u0065\u006e\u0063\u0072\u0079\u0070\u0074\u0069\u006e\u0067\u0020(%\u0073\u0029",_dbaef );return _dbaef ;};};_fafbd .writeObject (int (_egdd ),_dgdad );};_cbde :=_fafbd ._dfdcd ;var _bcbcg int ;for _dbacd :=range _fafbd ._ffede {if _dbacd > _bcbcg {_bcbcg =_dbacd ;};};if _abddf {_gece :=_bcbcg +1;_fafbd ._ffede [_gece ]=crossReference {Type :1,ObjectNumber :_gece ,Offset :_cbde };_gbebg :=_cg .NewBuffer (nil );_fdffbd :=_aef .MakeArray ();for _dceab :=0;_dceab <=_bcbcg ;{for ;_dceab <=_bcbcg ;_dceab ++{_bdafa ,_dffd :=_fafbd ._ffede [_dceab ];if _dffd &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_bdafa .Type ==1&&_bdafa .Offset >=_fafbd ._bfagd ||_bdafa .Type ==0)){break ;};};var _dgcce int ;for _dgcce =_dceab +1;_dgcce <=_bcbcg ;_dgcce ++{_dafc ,_bgcaga :=_fafbd ._ffede [_dgcce ];if _bgcaga &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_dafc .Type ==1&&_dafc .Offset > _fafbd ._bfagd )){continue ;};break ;};_fdffbd .Append (_aef .MakeInteger (int64 (_dceab )),_aef .MakeInteger (int64 (_dgcce -_dceab )));for _dcfaf :=_dceab ;_dcfaf < _dgcce ;_dcfaf ++{_bcdad :=_fafbd ._ffede [_dcfaf ];switch _bcdad .Type {case 0:_ab .Write (_gbebg ,_ab .BigEndian ,byte (0));_ab .Write (_gbebg ,_ab .BigEndian ,uint32 (0));_ab .Write (_gbebg ,_ab .BigEndian ,uint16 (0xFFFF));case 1:_ab .Write (_gbebg ,_ab .BigEndian ,byte (1));_ab .Write (_gbebg ,_ab .BigEndian ,uint32 (_bcdad .Offset ));_ab .Write (_gbebg ,_ab .BigEndian ,uint16 (_bcdad .Generation ));case 2:_ab .Write (_gbebg ,_ab .BigEndian ,byte (2));_ab .Write (_gbebg ,_ab .BigEndian ,uint32 (_bcdad .ObjectNumber ));_ab .Write (_gbebg ,_ab .BigEndian ,uint16 (_bcdad .Index ));};};_dceab =_dgcce +1;};_fcbf ,_efgb :=_aef .MakeStream (_gbebg .Bytes (),_aef .NewFlateEncoder ());if _efgb !=nil {return _efgb ;};_fcbf .ObjectNumber =int64 (_gece );_fcbf .PdfObjectDictionary .Set ("\u0054\u0079\u0070\u0065",_aef .MakeName ("\u0058\u0052\u0065\u0066"));_fcbf .PdfObjectDictionary .Set ("\u0057",_aef .MakeArray (_aef .MakeInteger (1),_aef .MakeInteger (4),_aef .MakeInteger (2)));_fcbf .PdfObjectDictionary .Set ("\u0049\u006e\u0064e\u0078",_fdffbd );_fcbf .PdfObjectDictionary .Set ("\u0053\u0069\u007a\u0065",_aef .MakeInteger (int64 (_gece +1)));_fcbf .PdfObjectDictionary .Set ("\u0049\u006e\u0066\u006f",_fafbd ._caefd );_fcbf .PdfObjectDictionary .Set ("\u0052\u006f\u006f\u0074",_fafbd ._bdbdfg );if _fafbd ._bcdd &&_fafbd ._bbbefb > 0{_fcbf .PdfObjectDictionary .Set ("\u0050\u0072\u0065\u0076",_aef .MakeInteger (_fafbd ._bbbefb ));};if _fafbd ._ecfag !=nil {_fcbf .Set ("\u0045n\u0063\u0072\u0079\u0070\u0074",_fafbd ._eebbg );_fcbf .Set ("\u0049\u0044",_fafbd ._acddd );_abe .Log .Trace ("\u0049d\u0073\u003a\u0020\u0025\u0073",_fafbd ._acddd );};_fafbd .writeObject (int (_fcbf .ObjectNumber ),_fcbf );}else {_fafbd .writeString ("\u0078\u0072\u0065\u0066\u000d\u000a");for _gcfa :=0;_gcfa <=_bcbcg ;{for ;_gcfa <=_bcbcg ;_gcfa ++{_bdcbf ,_gfcbg :=_fafbd ._ffede [_gcfa ];if _gfcbg &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_bdcbf .Type ==1&&_bdcbf .Offset >=_fafbd ._bfagd ||_bdcbf .Type ==0)){break ;};};var _ggbe int ;for _ggbe =_gcfa +1;_ggbe <=_bcbcg ;_ggbe ++{_aeddf ,_dgdab :=_fafbd ._ffede [_ggbe ];if _dgdab &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_aeddf .Type ==1&&_aeddf .Offset > _fafbd ._bfagd )){continue ;};break ;};_ecdcb :=_b .Sprintf ("\u0025d\u0020\u0025\u0064\u000d\u000a",_gcfa ,_ggbe -_gcfa );_fafbd .writeString (_ecdcb );for _afgeg :=_gcfa ;_afgeg < _ggbe ;_afgeg ++{_daeda :=_fafbd ._ffede [_afgeg ];switch _daeda .Type {case 0:_ecdcb =_b .Sprintf ("\u0025\u002e\u0031\u0030\u0064\u0020\u0025\u002e\u0035d\u0020\u0066\u000d\u000a",0,65535);_fafbd .writeString (_ecdcb );case 1:_ecdcb =_b .Sprintf ("\u0025\u002e\u0031\u0030\u0064\u0020\u0025\u002e\u0035d\u0020\u006e\u000d\u000a",_daeda .Offset ,0);_fafbd .writeString (_ecdcb );};};_gcfa =_ggbe +1;};_dbeag :=_aef .MakeDict ();_dbeag .Set ("\u0049\u006e\u0066\u006f",_fafbd ._caefd );_dbeag .Set ("\u0052\u006f\u006f\u0074",_fafbd ._bdbdfg );_dbeag .Set ("\u0053\u0069\u007a\u0065",_aef .MakeInteger (int64 (_bcbcg +1)));if _fafbd ._bcdd &&_fafbd ._bbbefb > 0{_dbeag .Set ("\u0050\u0072\u0065\u0076",_aef .MakeInteger (_fafbd ._bbbefb ));};if _fafbd ._ecfag !=nil {_dbeag .Set ("\u0045n\u0063\u0072\u0079\u0070\u0074",_fafbd ._eebbg );_dbeag .Set ("\u0049\u0044",_fafbd ._acddd );_abe .Log .Trace ("\u0049d\u0073\u003a\u0020\u0025\u0073",_fafbd ._acddd );};_fafbd .writeString ("\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u000a");_fafbd .writeString (_dbeag .WriteString ());_fafbd .writeString ("\u000a");};_geceb :=_b .Sprintf ("\u0073\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u000a\u0025\u0064\u000a",_cbde );_fafbd .writeString (_geceb );_fafbd .writeString ("\u0025\u0025\u0045\u004f\u0046\u000a");if _fafbd ._geac ==nil {_fafbd ._geac =_fafbd ._fcadd .Flush ();};return _fafbd ._geac ;};
+func (_fafbd *PdfWriter )Write (writer _gfc .Writer )error {_abe .Log .Trace ("\u0057r\u0069\u0074\u0065\u0028\u0029");_aaged :=_cga .GetLicenseKey ();if (_aaged ==nil ||!_aaged .IsLicensed ())&&!_bbbga {_b .Printf ("\u0055\u006e\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0064\u0020c\u006f\u0070\u0079\u0020\u006f\u0066\u0020\u0055\u006e\u0069P\u0044\u0046\u000a");_b .Println ("-\u0020\u0047\u0065\u0074\u0020\u0061\u0020\u0066\u0072e\u0065\u0020\u0074\u0072\u0069\u0061\u006c l\u0069\u0063\u0065\u006es\u0065\u0020\u006f\u006e\u0020\u0068\u0074\u0074\u0070s:\u002f\u002fu\u006e\u0069\u0064\u006f\u0063\u002e\u0069\u006f");return _fa .New ("\u0075\u006e\u0069\u0070d\u0066\u0020\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0020c\u006fd\u0065\u0020\u0072\u0065\u0071\u0075\u0069r\u0065\u0064");};if _fafbd ._aabdg !=nil {_abe .Log .Trace ("\u004f\u0075t\u006c\u0069\u006ee\u0054\u0072\u0065\u0065\u003a\u0020\u0025\u002b\u0076",_fafbd ._aabdg );_bdadg :=_fafbd ._aabdg .ToPdfObject ();_abe .Log .Trace ("\u004fu\u0074\u006c\u0069\u006e\u0065\u0073\u003a\u0020\u0025\u002b\u0076 \u0028\u0025\u0054\u002c\u0020\u0070\u003a\u0025\u0070\u0029",_bdadg ,_bdadg ,_bdadg );_fafbd ._abebc .Set ("\u004f\u0075\u0074\u006c\u0069\u006e\u0065\u0073",_bdadg );_eacef :=_fafbd .addObjects (_bdadg );if _eacef !=nil {return _eacef ;};};if _fafbd ._feabe !=nil {_abe .Log .Trace ("\u0057r\u0069t\u0069\u006e\u0067\u0020\u0061c\u0072\u006f \u0066\u006f\u0072\u006d\u0073");_efdca :=_fafbd ._feabe .ToPdfObject ();_abe .Log .Trace ("\u0041\u0063\u0072\u006f\u0046\u006f\u0072\u006d\u003a\u0020\u0025\u002b\u0076",_efdca );_fafbd ._abebc .Set ("\u0041\u0063\u0072\u006f\u0046\u006f\u0072\u006d",_efdca );_bdcf :=_fafbd .addObjects (_efdca );if _bdcf !=nil {return _bdcf ;};};for _bbegf ,_bdadc :=range _fafbd ._eabcf {if !_fafbd .hasObject (_bbegf ){_abe .Log .Debug ("\u0057\u0041\u0052\u004e\u0020\u0050\u0065n\u0064\u0069\u006eg\u0020\u006f\u0062j\u0065\u0063t\u0020\u0025\u002b\u0076\u0020\u0025T\u0020(%\u0070\u0029\u0020\u006e\u0065\u0076\u0065\u0072\u0020\u0061\u0064\u0064\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0077\u0072\u0069\u0074\u0069\u006e\u0067",_bbegf ,_bbegf ,_bbegf );for _ ,_cgbcbd :=range _bdadc {for _ ,_gbcec :=range _cgbcbd .Keys (){_dcfdc :=_cgbcbd .Get (_gbcec );if _dcfdc ==_bbegf {_abe .Log .Debug ("\u0050e\u006e\u0064i\u006e\u0067\u0020\u006fb\u006a\u0065\u0063t\u0020\u0066\u006f\u0075\u006e\u0064\u0021\u0020\u0061nd\u0020\u0072\u0065p\u006c\u0061c\u0065\u0064\u0020\u0077\u0069\u0074h\u0020\u006eu\u006c\u006c");_cgbcbd .Set (_gbcec ,_aef .MakeNull ());break ;};};};};};_fafbd ._abebc .Set ("\u0056e\u0072\u0073\u0069\u006f\u006e",_aef .MakeName (_b .Sprintf ("\u0025\u0064\u002e%\u0064",_fafbd ._cfebb ,_fafbd ._gbcce )));if _fafbd .stream !=nil {return _fafbd .finishStreaming ();};_fafbd .copyObjects ();if _fafbd ._ceag !=nil {var _eeeac error ;_fafbd ._aage ,_eeeac =_fafbd ._ceag .Optimize (_fafbd ._aage );if _eeeac !=nil {return _eeeac ;};_bcaff :=make (map[_aef .PdfObject ]struct{},len (_fafbd ._aage ));for _ ,_edgce :=range _fafbd ._aage {_bcaff [_edgce ]=struct{}{};};_fafbd ._cafea =_bcaff ;};_fafbd ._dfdcd =_fafbd ._ccdaf ;_fafbd ._fcadd =_bc .NewWriter (writer );_abddf :=_fafbd ._cfebb > 1||(_fafbd ._cfebb ==1&&_fafbd ._gbcce > 4);if _fafbd ._ecfeg !=nil {_abddf =*_fafbd ._ecfeg ;};if _fafbd .linearized {return _fafbd .writeLinearized (_abddf );};_dffea :=make (map[_aef .PdfObject ]bool );for _ ,_dfgab :=range _fafbd ._aage {if _ddffga ,_deff :=_dfgab .(*_aef .PdfObjectStreams );_deff {_abddf =true ;for _ ,_adbea :=range _ddffga .Elements (){_dffea [_adbea ]=true ;if _baegf ,_acccea :=_adbea .(*_aef .PdfIndirectObject );_acccea {_dffea [_baegf .PdfObject ]=true ;};};};};if _abddf &&_fafbd ._cfebb ==1&&_fafbd ._gbcce < 5{_fafbd ._gbcce =5;};if _fafbd ._bcdd {_fafbd .writeString ("\u000a");}else {_fafbd .writeString (_b .Sprintf ("\u0025\u0025\u0050D\u0046\u002d\u0025\u0064\u002e\u0025\u0064\u000a",_fafbd ._cfebb ,_fafbd ._gbcce ));_fafbd .writeString ("\u0025\u00e2\u00e3\u00cf\u00d3\u000a");};_fafbd .updateObjectNumbers ();_abe .Log .Trace ("\u0057\u0072\u0069\u0074\u0069\u006e\u0067\u0020\u0025d\u0020\u006f\u0062\u006a",len (_fafbd ._aage ));_fafbd ._ffede =make (map[int ]crossReference );_fafbd ._ffede [0]=crossReference {Type :0,ObjectNumber :0,Generation :0xFFFF};if _fafbd ._gdbde .ObjectMap !=nil {for _adbg ,_caafd :=range _fafbd ._gdbde .ObjectMap {if _adbg ==0{continue ;};if _caafd .XType ==_aef .XrefTypeObjectStream {_dfeaa :=crossReference {Type :2,ObjectNumber :_caafd .OsObjNumber ,Index :_caafd .OsObjIndex };_fafbd ._ffede [_adbg ]=_dfeaa ;};if _caafd .XType ==_aef .XrefTypeTableEntry {_befbc :=crossReference {Type :1,ObjectNumber :_caafd .ObjectNumber ,Offset :_caafd .Offset };_fafbd ._ffede [_adbg ]=_befbc ;};};};for _ ,_dgdad :=range _fafbd ._aage {if _aedee :=_dffea [_dgdad ];_aedee {continue ;};_egdd :=int64 (0);switch _ecead :=_dgdad .(type ){case *_aef .PdfIndirectObject :_egdd =_ecead .ObjectNumber ;case *_aef .PdfObjectStream :_egdd =_ecead .ObjectNumber ;case *_aef .PdfObjectStreams :_egdd =_ecead .ObjectNumber ;default:_abe .Log .Debug ("\u0045R\u0052\u004fR\u003a\u0020\u0055n\u0073\u0075\u0070\u0070\u006f\u0072\u0074e\u0064\u0020\u0074\u0079\u0070\u0065 \u0069\u006e\u0020\u0077\u0072\u0069\u0074\u0065\u0072\u0020\u006fb\u006a\u0065\u0063\u0074\u0073\u003a\u0020\u0025\u0054",_dgdad );return ErrTypeCheck ;};if _fafbd ._ecfag !=nil &&_dgdad !=_fafbd ._eebbg {_dbaef :=_fafbd ._ecfag .Encrypt (_dgdad ,_egdd ,0);if _dbaef !=nil {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u0065\u006e\u0063\u0072\u0079\u0070\u0074\u0069\u006e\u0067\u0020(%\u0073\u0029",_dbaef );return _dbaef ;};};_fafbd .writeObject (int (_egdd ),_dgdad );};_cbde :=_fafbd ._dfdcd ;var _bcbcg int ;for _dbacd :=range _fafbd ._ffede {if _dbacd > _bcbcg {_bcbcg =_dbacd ;};};if _abddf {_gece :=_bcbcg +1;_fafbd ._ffede [_gece ]=crossReference {Type :1,ObjectNumber :_gece ,Offset :_cbde };_gbebg :=_cg .NewBuffer (nil );_fdffbd :=_aef .MakeArray ();for _dceab :=0;_dceab <=_bcbcg ;{for ;_dceab <=_bcbcg ;_dceab ++{_bdafa ,_dffd :=_fafbd ._ffede [_dceab ];if _dffd &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_bdafa .Type ==1&&_bdafa .Offset >=_fafbd ._bfagd ||_bdafa .Type ==0)){break ;};};var _dgcce int ;for _dgcce =_dceab +1;_dgcce <=_bcbcg ;_dgcce ++{_dafc ,_bgcaga :=_fafbd ._ffede [_dgcce ];if _bgcaga &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_dafc .Type ==1&&_dafc .Offset > _fafbd ._bfagd )){continue ;};break ;};_fdffbd .Append (_aef .MakeInteger (int64 (_dceab )),_aef .MakeInteger (int64 (_dgcce -_dceab )));for _dcfaf :=_dceab ;_dcfaf < _dgcce ;_dcfaf ++{_bcdad :=_fafbd ._ffede [_dcfaf ];switch _bcdad .Type {case 0:_ab .Write (_gbebg ,_ab .BigEndian ,byte (0));_ab .Write (_gbebg ,_ab .BigEndian ,uint32 (0));_ab .Write (_gbebg ,_ab .BigEndian ,uint16 (0xFFFF));case 1:_ab .Write (_gbebg ,_ab .BigEndian ,byte (1));_ab .Write (_gbebg ,_ab .BigEndian ,uint32 (_bcdad .Offset ));_ab .Write (_gbebg ,_ab .BigEndian ,uint16 (_bcdad .Generation ));case 2:_ab .Write (_gbebg ,_ab .BigEndian ,byte (2));_ab .Write (_gbebg ,_ab .BigEndian ,uint32 (_bcdad .ObjectNumber ));_ab .Write (_gbebg ,_ab .BigEndian ,uint16 (_bcdad .Index ));};};_dceab =_dgcce +1;};_fcbf ,_efgb :=_aef .MakeStream (_gbebg .Bytes (),_aef .NewFlateEncoder ());if _efgb !=nil {return _efgb ;};_fcbf .ObjectNumber =int64 (_gece );_fcbf .PdfObjectDictionary .Set ("\u0054\u0079\u0070\u0065",_aef .MakeName ("\u0058\u0052\u0065\u0066"));_fcbf .PdfObjectDictionary .Set ("\u0057",_aef .MakeArray (_aef .MakeInteger (1),_aef .MakeInteger (4),_aef .MakeInteger (2)));_fcbf .PdfObjectDictionary .Set ("\u0049\u006e\u0064e\u0078",_fdffbd );_fcbf .PdfObjectDictionary .Set ("\u0053\u0069\u007a\u0065",_aef .MakeInteger (int64 (_gece +1)));_fcbf .PdfObjectDictionary .Set ("\u0049\u006e\u0066\u006f",_fafbd ._caefd );_fcbf .PdfObjectDictionary .Set ("\u0052\u006f\u006f\u0074",_fafbd ._bdbdfg );if _fafbd ._bcdd &&_fafbd ._bbbefb > 0{_fcbf .PdfObjectDictionary .Set ("\u0050\u0072\u0065\u0076",_aef .MakeInteger (_fafbd ._bbbefb ));};if _fafbd ._ecfag !=nil {_fcbf .Set ("\u0045n\u0063\u0072\u0079\u0070\u0074",_fafbd ._eebbg );_fcbf .Set ("\u0049\u0044",_fafbd ._acddd );_abe .Log .Trace ("\u0049d\u0073\u003a\u0020\u0025\u0073",_fafbd ._acddd );};_fafbd .writeObject (int (_fcbf .ObjectNumber ),_fcbf );}else {_fafbd .writeString ("\u0078\u0072\u0065\u0066\u000d\u000a");for _gcfa :=0;_gcfa <=_bcbcg ;{for ;_gcfa <=_bcbcg ;_gcfa ++{_bdcbf ,_gfcbg :=_fafbd ._ffede [_gcfa ];if _gfcbg &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_bdcbf .Type ==1&&_bdcbf .Offset >=_fafbd ._bfagd ||_bdcbf .Type ==0)){break ;};};var _ggbe int ;for _ggbe =_gcfa +1;_ggbe <=_bcbcg ;_ggbe ++{_aeddf ,_dgdab :=_fafbd ._ffede [_ggbe ];if _dgdab &&(!_fafbd ._bcdd ||_fafbd ._bcdd &&(_aeddf .Type ==1&&_aeddf .Offset > _fafbd ._bfagd )){continue ;};break ;};_ecdcb :=_b .Sprintf ("\u0025d\u0020\u0025\u0064\u000d\u000a",_gcfa ,_ggbe -_gcfa );_fafbd .writeString (_ecdcb );for _afgeg :=_gcfa ;_afgeg < _ggbe ;_afgeg ++{_daeda :=_fafbd ._ffede [_afgeg ];switch _daeda .Type {case 0:_ecdcb =_b .Sprintf ("\u0025\u002e\u0031\u0030\u0064\u0020\u0025\u002e\u0035d\u0020\u0066\u000d\u000a",0,65535);_fafbd .writeString (_ecdcb );case 1:_ecdcb =_b .Sprintf ("\u0025\u002e\u0031\u0030\u0064\u0020\u0025\u002e\u0035d\u0020\u006e\u000d\u000a",_daeda .Offset ,0);_fafbd .writeString (_ecdcb );};};_gcfa =_ggbe +1;};_dbeag :=_aef .MakeDict ();_dbeag .Set ("\u0049\u006e\u0066\u006f",_fafbd ._caefd );_dbeag .Set ("\u0052\u006f\u006f\u0074",_fafbd ._bdbdfg );_dbeag .Set ("\u0053\u0069\u007a\u0065",_aef .MakeInteger (int64 (_bcbcg +1)));if _fafbd ._bcdd &&_fafbd ._bbbefb > 0{_dbeag .Set ("\u0050\u0072\u0065\u0076",_aef .MakeInteger (_fafbd ._bbbefb ));};if _fafbd ._ecfag !=nil {_dbeag .Set ("\u0045n\u0063\u0072\u0079\u0070\u0074",_fafbd ._eebbg );_dbeag .Set ("\u0049\u0044",_fafbd ._acddd );_abe .Log .Trace ("\u0049d\u0073\u003a\u0020\u0025\u0073",_fafbd ._acddd );};_fafbd .writeString ("\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u000a");_fafbd .writeString (_dbeag .WriteString ());_fafbd .writeString ("\u000a");};_geceb :=_b .Sprintf ("\u0073\u0074\u0061\u0072\u0074\u0078\u0072\u0065\u0066\u000a\u0025\u0064\u000a",_cbde );_fafbd .writeString (_geceb );_fafbd .writeString ("\u0025\u0025\u0045\u004f\u0046\u000a");if _fafbd ._geac ==nil {_fafbd ._geac =_fafbd ._fcadd .Flush ();};return _fafbd ._geac ;};
 
 // NewCompositePdfFontFromTTFFile loads a composite font from a TTF font file. Composite fonts can
 // be used to represent unicode fonts which can have multi-byte character codes, representing a wide
@@ -1712,7 +1716,7 @@ type PdfAnnotationRichMedia struct{*PdfAnnotation ;RichMediaSettings _aef .PdfOb
 func (_ecec *PdfColorspaceLab )ColorToRGB (color PdfColor )(PdfColor ,error ){_gdce :=func (_fbbdd float64 )float64 {if _fbbdd >=6.0/29{return _fbbdd *_fbbdd *_fbbdd ;};return 108.0/841*(_fbbdd -4/29);};_dabgg ,_ccdg :=color .(*PdfColorLab );if !_ccdg {_abe .Log .Debug ("\u0069\u006e\u0070\u0075t \u0063\u006f\u006c\u006f\u0072\u0020\u006e\u006f\u0074\u0020\u006c\u0061\u0062");return nil ,_fa .New ("\u0074\u0079p\u0065\u0020\u0063h\u0065\u0063\u006b\u0020\u0065\u0072\u0072\u006f\u0072");};LStar :=_dabgg .L ();AStar :=_dabgg .A ();BStar :=_dabgg .B ();L :=(LStar +16)/116+AStar /500;M :=(LStar +16)/116;N :=(LStar +16)/116-BStar /200;X :=_ecec .WhitePoint [0]*_gdce (L );Y :=_ecec .WhitePoint [1]*_gdce (M );Z :=_ecec .WhitePoint [2]*_gdce (N );_cbgdd :=3.240479*X +-1.537150*Y +-0.498535*Z ;_bcbg :=-0.969256*X +1.875992*Y +0.041556*Z ;_gdde :=0.055648*X +-0.204043*Y +1.057311*Z ;_cbgdd =_ad .Min (_ad .Max (_cbgdd ,0),1.0);_bcbg =_ad .Min (_ad .Max (_bcbg ,0),1.0);_gdde =_ad .Min (_ad .Max (_gdde ,0),1.0);return NewPdfColorDeviceRGB (_cbgdd ,_bcbg ,_gdde ),nil ;};
 
 // GetPage returns the PdfPage model for the specified page number.
-func (_efgdb *PdfReader )GetPage (pageNumber int )(*PdfPage ,error ){if _efgdb ._gdbbd .GetCrypter ()!=nil &&!_efgdb ._gdbbd .IsAuthenticated (){return nil ,_b .Errorf ("\u0066\u0069\u006c\u0065\u0020\u006e\u0065\u0065\u0064\u0073\u0020\u0074\u006f\u0020\u0062e\u0020d\u0065\u0063\u0072\u0079\u0070\u0074\u0065\u0064\u0020\u0066\u0069\u0072\u0073\u0074");};if len (_efgdb ._dbdgb )< pageNumber {return nil ,_fa .New ("\u0069\u006e\u0076a\u006c\u0069\u0064\u0020\u0070\u0061\u0067\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0028\u0070\u0061\u0067\u0065\u0020\u0063\u006f\u0075\u006e\u0074\u0020\u0074o\u006f\u0020\u0073\u0068\u006f\u0072\u0074\u0029");};_deegg :=pageNumber -1;if _deegg < 0{return nil ,_b .Errorf ("\u0070\u0061\u0067\u0065\u0020\u006e\u0075\u006d\u0062\u0065r\u0069\u006e\u0067\u0020\u006d\u0075\u0073t\u0020\u0073\u0074\u0061\u0072\u0074\u0020\u0061\u0074\u0020\u0031");};_feeef :=_efgdb .PageList [_deegg ];return _feeef ,nil ;};
+func (_efgdb *PdfReader )GetPage (pageNumber int )(*PdfPage ,error ){if _efgdb ._gdbbd .GetCrypter ()!=nil &&!_efgdb ._gdbbd .IsAuthenticated (){return nil ,_b .Errorf ("\u0066\u0069\u006c\u0065\u0020\u006e\u0065\u0065\u0064\u0073\u0020\u0074\u006f\u0020\u0062e\u0020d\u0065\u0063\u0072\u0079\u0070\u0074\u0065\u0064\u0020\u0066\u0069\u0072\u0073\u0074");};if _efgdb .lazyPages {return _efgdb .lazyPage (pageNumber );};if len (_efgdb ._dbdgb )< pageNumber {return nil ,_fa .New ("\u0069\u006e\u0076a\u006c\u0069\u0064\u0020\u0070\u0061\u0067\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0028\u0070\u0061\u0067\u0065\u0020\u0063\u006f\u0075\u006e\u0074\u0020\u0074o\u006f\u0020\u0073\u0068\u006f\u0072\u0074\u0029");};_deegg :=pageNumber -1;if _deegg < 0{return nil ,_b .Errorf ("\u0070\u0061\u0067\u0065\u0020\u006e\u0075\u006d\u0062\u0065r\u0069\u006e\u0067\u0020\u006d\u0075\u0073t\u0020\u0073\u0074\u0061\u0072\u0074\u0020\u0061\u0074\u0020\u0031");};_feeef :=_efgdb .PageList [_deegg ];return _feeef ,nil ;};
 
 // NewPdfFontFromTTF loads a TTF font and returns a PdfFont type that can be
 // used in text styling functions.
@@ -1759,7 +1763,7 @@ func (_aabg *PdfColorspaceSpecialPattern )ColorFromPdfObjects (objects []_aef .P
 // NOTE: Currently, the opts parameter is declared in order to enable adding
 // future options, but passing nil will always result in the default options
 // being used.
-func (_aeebd *PdfReader )RepairAcroForm (opts *AcroFormRepairOptions )error {var _bcbde []*PdfField ;_decba :=map[*_aef .PdfIndirectObject ]struct{}{};for _ ,_fggeae :=range _aeebd .PageList {_fbeaf ,_ceccf :=_fggeae .GetAnnotations ();if _ceccf !=nil {return _ceccf ;};for _ ,_cbgcfa :=range _fbeaf {var _egaga *PdfField ;switch _edada :=_cbgcfa .GetContext ().(type ){case *PdfAnnotationWidget :if _edada ._ccc !=nil {_egaga =_edada ._ccc ;break ;};if _fafgb ,_fcdd :=_aef .GetIndirect (_edada .Parent );_fcdd {_egaga ,_ceccf =_aeebd .newPdfFieldFromIndirectObject (_fafgb ,nil );if _ceccf ==nil {break ;};_abe .Log .Debug ("W\u0041\u0052\u004e\u003a\u0020\u0063\u006f\u0075\u006c\u0064\u0020\u006e\u006f\u0074\u0020\u0070\u0061\u0072s\u0065\u0020\u0066\u006f\u0072\u006d\u0020\u0066\u0069\u0065ld\u0020\u0025\u002bv\u003a \u0025\u0076",_fafgb ,_ceccf );};if _edada ._edc !=nil {_egaga ,_ceccf =_aeebd .newPdfFieldFromIndirectObject (_edada ._edc ,nil );if _ceccf ==nil {break ;};_abe .Log .Debug ("W\u0041\u0052\u004e\u003a\u0020\u0063\u006f\u0075\u006c\u0064\u0020\u006e\u006f\u0074\u0020\u0070\u0061\u0072s\u0065\u0020\u0066\u006f\u0072\u006d\u0020\u0066\u0069\u0065ld\u0020\u0025\u002bv\u003a \u0025\u0076",_edada ._edc ,_ceccf );};};if _egaga ==nil {continue ;};if _ ,_abaddd :=_decba [_egaga ._abebg ];_abaddd {continue ;};_decba [_egaga ._abebg ]=struct{}{};_bcbde =append (_bcbde ,_egaga );};};if len (_bcbde )==0{return nil ;};if _aeebd .AcroForm ==nil {_aeebd .AcroForm =NewPdfAcroForm ();};_aeebd .AcroForm .Fields =&_bcbde ;return nil ;};
+func (_aeebd *PdfReader )RepairAcroForm (opts *AcroFormRepairOptions )error {if _cfdeg :=_aeebd .loadPages ();_cfdeg !=nil {return _cfdeg ;};var _bcbde []*PdfField ;_decba :=map[*_aef .PdfIndirectObject ]struct{}{};for _ ,_fggeae :=range _aeebd .PageList {_fbeaf ,_ceccf :=_fggeae .GetAnnotations ();if _ceccf !=nil {return _ceccf ;};for _ ,_cbgcfa :=range _fbeaf {var _egaga *PdfField ;switch _edada :=_cbgcfa .GetContext ().(type ){case *PdfAnnotationWidget :if _edada ._ccc !=nil {_egaga =_edada ._ccc ;break ;};if _fafgb ,_fcdd :=_aef .GetIndirect (_edada .Parent );_fcdd {_egaga ,_ceccf =_aeebd .newPdfFieldFromIndirectObject (_fafgb ,nil );if _ceccf ==nil {break ;};_abe .Log .Debug ("W\u0041\u0052\u004e\u003a\u0020\u0063\u006f\u0075\u006c\u0064\u0020\u006e\u006f\u0074\u0020\u0070\u0061\u0072s\u0065\u0020\u0066\u006f\u0072\u006d\u0020\u0066\u0069\u0065ld\u0020\u0025\u002bv\u003a \u0025\u0076",_fafgb ,_ceccf );};if _edada ._edc !=nil {_egaga ,_ceccf =_aeebd .newPdfFieldFromIndirectObject (_edada ._edc ,nil );if _ceccf ==nil {break ;};_abe .Log .Debug ("W\u0041\u0052\u004e\u003a\u0020\u0063\u006f\u0075\u006c\u0064\u0020\u006e\u006f\u0074\u0020\u0070\u0061\u0072s\u0065\u0020\u0066\u006f\u0072\u006d\u0020\u0066\u0069\u0065ld\u0020\u0025\u002bv\u003a \u0025\u0076",_edada ._edc ,_ceccf );};};if _egaga ==nil {continue ;};if _ ,_abaddd :=_decba [_egaga ._abebg ];_abaddd {continue ;};_decba [_egaga ._abebg ]=struct{}{};_bcbde =append (_bcbde ,_egaga );};};if len (_bcbde )==0{return nil ;};if _aeebd .AcroForm ==nil {_aeebd .AcroForm =NewPdfAcroForm ();};_aeebd .AcroForm .Fields =&_bcbde ;return nil ;};
 
 // ToPdfObject implements interface PdfModel.
 func (_abf *PdfAnnotationScreen )ToPdfObject ()_aef .PdfObject {_abf .PdfAnnotation .ToPdfObject ();_bfcec :=_abf ._edc ;_bfgc :=_bfcec .PdfObject .(*_aef .PdfObjectDictionary );_bfgc .SetIfNotNil ("\u0053u\u0062\u0074\u0079\u0070\u0065",_aef .MakeName ("\u0053\u0063\u0072\u0065\u0065\u006e"));_bfgc .SetIfNotNil ("\u0054",_abf .T );_bfgc .SetIfNotNil ("\u004d\u004b",_abf .MK );_bfgc .SetIfNotNil ("\u0041",_abf .A );_bfgc .SetIfNotNil ("\u0041\u0041",_abf .AA );return _bfcec ;};
@@ -1795,7 +1799,7 @@ type PdfAnnotationStamp struct{*PdfAnnotation ;*PdfAnnotationMarkup ;Name _aef .
 
 // Image interface is a basic representation of an image used in PDF.
 // The colorspace is not specified, but must be known when handling the image.
-type Image struct{Width int64 ;Height int64 ;BitsPerComponent int64 ;ColorComponents int ;Data []byte ;_afge []byte ;_fgafa []float64 ;};func _eaae (_agaf _aef .PdfObject ,_bfaa *PdfReader )(*OutlineDest ,error ){_ddef ,_bbfg :=_aef .GetArray (_agaf );if !_bbfg {return nil ,_fa .New ("\u006f\u0075\u0074\u006c\u0069\u006e\u0065 \u0064\u0065\u0073t\u0069\u006e\u0061\u0074i\u006f\u006e\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006d\u0075\u0073\u0074\u0020\u0062\u0065\u0020\u0061\u006e\u0020\u0061\u0072\u0072\u0061\u0079");};_fefa :=_ddef .Len ();if _fefa < 2{return nil ,_b .Errorf ("\u0069n\u0076\u0061l\u0069\u0064\u0020\u006fu\u0074\u006c\u0069n\u0065\u0020\u0064\u0065\u0073\u0074\u0069\u006e\u0061ti\u006f\u006e\u0020a\u0072\u0072a\u0079\u0020\u006c\u0065\u006e\u0067t\u0068\u003a \u0025\u0064",_fefa );};_gffba :=&OutlineDest {Mode :"\u0046\u0069\u0074"};_ecge :=_ddef .Get (0);if _ddba ,_gccca :=_aef .GetIndirect (_ecge );_gccca {if _ ,_eabfg ,_bbedf :=_bfaa .PageFromIndirectObject (_ddba );_bbedf ==nil {_gffba .Page =int64 (_eabfg -1);}else {_abe .Log .Debug ("\u0057\u0041\u0052\u004e\u003a\u0020\u0063o\u0075\u006c\u0064 \u006e\u006f\u0074\u0020g\u0065\u0074\u0020\u0070\u0061\u0067\u0065\u0020\u0069\u006e\u0064\u0065\u0078\u0020\u0066\u006f\u0072\u0020\u0070\u0061\u0067\u0065\u0020\u0025\u002b\u0076",_ddba );};_gffba .PageObj =_ddba ;}else if _ffggf ,_ageca :=_aef .GetIntVal (_ecge );_ageca {if _ffggf >=0&&_ffggf < len (_bfaa .PageList ){_gffba .PageObj =_bfaa .PageList [_ffggf ].GetPageAsIndirectObject ();}else {_abe .Log .Debug ("\u0057\u0041R\u004e\u003a\u0020\u0063\u006f\u0075\u006c\u0064\u0020\u006e\u006f\u0074\u0020\u0067\u0065\u0074\u0020\u0070\u0061\u0067\u0065\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0065\u0072\u0020\u0066\u006f\u0072\u0020\u0070\u0061\u0067\u0065\u0020\u0025\u0064",_ffggf );};_gffba .Page =int64 (_ffggf );}else {return nil ,_b .Errorf ("\u0069\u006eva\u006c\u0069\u0064 \u006f\u0075\u0074\u006cine\u0020de\u0073\u0074\u0069\u006e\u0061\u0074\u0069on\u0020\u0070\u0061\u0067\u0065\u003a\u0020%\u0054",_ecge );};_bcfcf ,_bbfg :=_aef .GetNameVal (_ddef .Get (1));if !_bbfg {_abe .Log .Debug ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u006f\u0075\u0074\u006c\u0069\u006e\u0065\u0020\u0064\u0065s\u0074\u0069\u006e\u0061\u0074\u0069\u006fn\u0020\u006d\u0061\u0067\u006e\u0069\u0066\u0069\u0063\u0061\u0074i\u006f\u006e\u0020\u006d\u006f\u0064\u0065\u003a\u0020\u0025\u0076",_ddef .Get (1));return _gffba ,nil ;};switch _bcfcf {case "\u0046\u0069\u0074","\u0046\u0069\u0074\u0042":case "\u0046\u0069\u0074\u0048","\u0046\u0069\u0074B\u0048":if _fefa > 2{_gffba .Y ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (2)));};case "\u0046\u0069\u0074\u0056","\u0046\u0069\u0074B\u0056":if _fefa > 2{_gffba .X ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (2)));};case "\u0058\u0059\u005a":if _fefa > 4{_gffba .X ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (2)));_gffba .Y ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (3)));_gffba .Zoom ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (4)));};default:_bcfcf ="\u0046\u0069\u0074";};_gffba .Mode =_bcfcf ;return _gffba ,nil ;};
+type Image struct{Width int64 ;Height int64 ;BitsPerComponent int64 ;ColorComponents int ;Data []byte ;_afge []byte ;_fgafa []float64 ;};func _eaae (_agaf _aef .PdfObject ,_bfaa *PdfReader )(*OutlineDest ,error ){_ddef ,_bbfg :=_aef .GetArray (_agaf );if !_bbfg {return nil ,_fa .New ("\u006f\u0075\u0074\u006c\u0069\u006e\u0065 \u0064\u0065\u0073t\u0069\u006e\u0061\u0074i\u006f\u006e\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u006d\u0075\u0073\u0074\u0020\u0062\u0065\u0020\u0061\u006e\u0020\u0061\u0072\u0072\u0061\u0079");};_fefa :=_ddef .Len ();if _fefa < 2{return nil ,_b .Errorf ("\u0069n\u0076\u0061l\u0069\u0064\u0020\u006fu\u0074\u006c\u0069n\u0065\u0020\u0064\u0065\u0073\u0074\u0069\u006e\u0061ti\u006f\u006e\u0020a\u0072\u0072a\u0079\u0020\u006c\u0065\u006e\u0067t\u0068\u003a \u0025\u0064",_fefa );};_gffba :=&OutlineDest {Mode :"\u0046\u0069\u0074"};_ecge :=_ddef .Get (0);if _ddba ,_gccca :=_aef .GetIndirect (_ecge );_gccca {if _ ,_eabfg ,_bbedf :=_bfaa .PageFromIndirectObject (_ddba );_bbedf ==nil {_gffba .Page =int64 (_eabfg -1);}else {_abe .Log .Debug ("\u0057\u0041\u0052\u004e\u003a\u0020\u0063o\u0075\u006c\u0064 \u006e\u006f\u0074\u0020g\u0065\u0074\u0020\u0070\u0061\u0067\u0065\u0020\u0069\u006e\u0064\u0065\u0078\u0020\u0066\u006f\u0072\u0020\u0070\u0061\u0067\u0065\u0020\u0025\u002b\u0076",_ddba );};_gffba .PageObj =_ddba ;}else if _ffggf ,_ageca :=_aef .GetIntVal (_ecge );_ageca {if _cfdeg :=_bfaa .loadPages ();_cfdeg !=nil {return nil ,_cfdeg ;};if _ffggf >=0&&_ffggf < len (_bfaa .PageList ){_gffba .PageObj =_bfaa .PageList [_ffggf ].GetPageAsIndirectObject ();}else {_abe .Log .Debug ("\u0057\u0041R\u004e\u003a\u0020\u0063\u006f\u0075\u006c\u0064\u0020\u006e\u006f\u0074\u0020\u0067\u0065\u0074\u0020\u0070\u0061\u0067\u0065\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0065\u0072\u0020\u0066\u006f\u0072\u0020\u0070\u0061\u0067\u0065\u0020\u0025\u0064",_ffggf );};_gffba .Page =int64 (_ffggf );}else {return nil ,_b .Errorf ("\u0069\u006eva\u006c\u0069\u0064 \u006f\u0075\u0074\u006cine\u0020de\u0073\u0074\u0069\u006e\u0061\u0074\u0069on\u0020\u0070\u0061\u0067\u0065\u003a\u0020%\u0054",_ecge );};_bcfcf ,_bbfg :=_aef .GetNameVal (_ddef .Get (1));if !_bbfg {_abe .Log .Debug ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u006f\u0075\u0074\u006c\u0069\u006e\u0065\u0020\u0064\u0065s\u0074\u0069\u006e\u0061\u0074\u0069\u006fn\u0020\u006d\u0061\u0067\u006e\u0069\u0066\u0069\u0063\u0061\u0074i\u006f\u006e\u0020\u006d\u006f\u0064\u0065\u003a\u0020\u0025\u0076",_ddef .Get (1));return _gffba ,nil ;};switch _bcfcf {case "\u0046\u0069\u0074","\u0046\u0069\u0074\u0042":case "\u0046\u0069\u0074\u0048","\u0046\u0069\u0074B\u0048":if _fefa > 2{_gffba .Y ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (2)));};case "\u0046\u0069\u0074\u0056","\u0046\u0069\u0074B\u0056":if _fefa > 2{_gffba .X ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (2)));};case "\u0058\u0059\u005a":if _fefa > 4{_gffba .X ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (2)));_gffba .Y ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (3)));_gffba .Zoom ,_ =_aef .GetNumberAsFloat (_aef .TraceToDirectObject (_ddef .Get (4)));};default:_bcfcf ="\u0046\u0069\u0074";};_gffba .Mode =_bcfcf ;return _gffba ,nil ;};
 
 // GetType returns the button field type which returns one of the following
 // - PdfFieldButtonPush for push button fields
@@ -1896,7 +1900,7 @@ func (_eefbf *PdfPage )SetContentStreams (cStreams []string ,encoder _aef .Strea
 // ColorFromPdfObjects returns a new PdfColor based on the input slice of color
 // components. The slice should contain three PdfObjectFloat elements representing
 // the A, B and C components of the color.
-func (_ffcd *PdfColorspaceCalRGB )ColorFromPdfObjects (objects []_aef .PdfObject )(PdfColor ,error ){if len (objects )!=3{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_dece ,_aafe :=_aef .GetNumbersAsFloat (objects );if _aafe !=nil {return nil ,_aafe ;};return _ffcd .ColorFromFloats (_dece );};func (_dcga *PdfReader )flattenFieldsWithOpts (_ggff bool ,_agge FieldAppearanceGenerator ,_dbeg *FieldFlattenOpts )error {if _dbeg ==nil {_dbeg =&FieldFlattenOpts {};};var _badd bool ;_eadfg :=map[*PdfAnnotation ]bool {};{var _dfaff []*PdfField ;_bbacd :=_dcga .AcroForm ;if _bbacd !=nil {if _dbeg .FilterFunc !=nil {_dfaff =_bbacd .filteredFields (_dbeg .FilterFunc ,true );_badd =_bbacd .Fields !=nil &&len (*_bbacd .Fields )> 0;}else {_dfaff =_bbacd .AllFields ();};};for _ ,_affbf :=range _dfaff {for _ ,_dcec :=range _affbf .Annotations {_eadfg [_dcec .PdfAnnotation ]=_affbf .V !=nil ;if _agge !=nil {_agfcc ,_dccad :=_agge .GenerateAppearanceDict (_bbacd ,_affbf ,_dcec );if _dccad !=nil {return _dccad ;};_dcec .AP =_agfcc ;};};};};if _ggff {for _ ,_gdfa :=range _dcga .PageList {_cacga ,_cfgd :=_gdfa .GetAnnotations ();if _cfgd !=nil {return _cfgd ;};for _ ,_cafge :=range _cacga {_eadfg [_cafge ]=true ;};};};for _ ,_bbbg :=range _dcga .PageList {var _cbccg []*PdfAnnotation ;if _agge !=nil {if _ecacb :=_agge .WrapContentStream (_bbbg );_ecacb !=nil {return _ecacb ;};};_aaeeb ,_aecba :=_bbbg .GetAnnotations ();if _aecba !=nil {return _aecba ;};for _ ,_cgddb :=range _aaeeb {_beac ,_badb :=_eadfg [_cgddb ];if !_badb {_cbccg =append (_cbccg ,_cgddb );continue ;};switch _cgddb .GetContext ().(type ){case *PdfAnnotationPopup :continue ;case *PdfAnnotationLink :continue ;case *PdfAnnotationProjection :continue ;};_gbbf ,_afgcd ,_gedba :=_fdef (_cgddb );if _gedba !=nil {if !_beac {_abe .Log .Trace ("\u0046\u0069\u0065\u006c\u0064\u0020\u0077\u0069\u0074h\u006f\u0075\u0074\u0020\u0056\u0020\u002d\u003e\u0020\u0061\u006e\u006e\u006f\u0074\u0061\u0074\u0069\u006f\u006e\u0020\u0077\u0069\u0074h\u006f\u0075t\u0020\u0061p\u0070\u0065\u0061\u0072\u0061\u006e\u0063\u0065\u0020\u0073\u0074\u0072\u0065am\u0020\u002d\u0020\u0073\u006b\u0069\u0070\u0070\u0069n\u0067\u0020\u006f\u0076\u0065\u0072");continue ;};_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0041\u006e\u006e\u006f\u0074\u0061\u0074\u0069\u006f\u006e\u0020\u0077\u0069\u0074h\u006f\u0075\u0074\u0020\u0061\u0070\u0070\u0065\u0061\u0072\u0061\u006e\u0063\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d,\u0020\u0065\u0072\u0072\u0020\u003a\u0020\u0025\u0076\u0020\u002d\u0020\u0073\u006bi\u0070\u0070\u0069n\u0067\u0020\u006f\u0076\u0065\u0072",_gedba );continue ;};if _gbbf ==nil {continue ;};_debba :=_bbbg .Resources .GenerateXObjectName ();_bbbg .Resources .SetXObjectFormByName (_debba ,_gbbf );_ccfdd :=_ad .Min (_afgcd .Llx ,_afgcd .Urx );_efcdg :=_ad .Min (_afgcd .Lly ,_afgcd .Ury );var _bdge []string ;_bdge =append (_bdge ,"\u0071");_bdge =append (_bdge ,_b .Sprintf ("\u0025\u002e\u0036\u0066\u0020\u0025\u002e\u0036\u0066\u0020\u0025\u002e\u0036\u0066\u0020%\u002e6\u0066\u0020\u0025\u002e\u0036\u0066\u0020\u0025\u002e\u0036\u0066\u0020\u0063\u006d",1.0,0.0,0.0,1.0,_ccfdd ,_efcdg ));_bdge =append (_bdge ,_b .Sprintf ("\u002f\u0025\u0073\u0020\u0044\u006f",_debba .String ()));_bdge =append (_bdge ,"\u0051");_egdag :=_dg .Join (_bdge ,"\u000a");_gedba =_bbbg .AppendContentStream (_egdag );if _gedba !=nil {return _gedba ;};if _gbbf .Resources !=nil {_cbceb ,_cdcd :=_aef .GetDict (_gbbf .Resources .Font );if _cdcd {for _ ,_eabgg :=range _cbceb .Keys (){if !_bbbg .Resources .HasFontByName (_eabgg ){_bbbg .Resources .SetFontByName (_eabgg ,_cbceb .Get (_eabgg ));};};};};};if len (_cbccg )> 0{_bbbg ._bcgfd =_cbccg ;}else {_bbbg ._bcgfd =[]*PdfAnnotation {};};};if !_badd {_dcga .AcroForm =nil ;};return nil ;};
+func (_ffcd *PdfColorspaceCalRGB )ColorFromPdfObjects (objects []_aef .PdfObject )(PdfColor ,error ){if len (objects )!=3{return nil ,_fa .New ("r\u0061\u006e\u0067\u0065\u0020\u0063\u0068\u0065\u0063\u006b");};_dece ,_aafe :=_aef .GetNumbersAsFloat (objects );if _aafe !=nil {return nil ,_aafe ;};return _ffcd .ColorFromFloats (_dece );};func (_dcga *PdfReader )flattenFieldsWithOpts (_ggff bool ,_agge FieldAppearanceGenerator ,_dbeg *FieldFlattenOpts )error {if _cfdeg :=_dcga .loadPages ();_cfdeg !=nil {return _cfdeg ;};if _dbeg ==nil {_dbeg =&FieldFlattenOpts {};};var _badd bool ;_eadfg :=map[*PdfAnnotation ]bool {};{var _dfaff []*PdfField ;_bbacd :=_dcga .AcroForm ;if _bbacd !=nil {if _dbeg .FilterFunc !=nil {_dfaff =_bbacd .filteredFields (_dbeg .FilterFunc ,true );_badd =_bbacd .Fields !=nil &&len (*_bbacd .Fields )> 0;}else {_dfaff =_bbacd .AllFields ();};};for _ ,_affbf :=range _dfaff {for _ ,_dcec :=range _affbf .Annotations {_eadfg [_dcec .PdfAnnotation ]=_affbf .V !=nil ;if _agge !=nil {_agfcc ,_dccad :=_agge .GenerateAppearanceDict (_bbacd ,_affbf ,_dcec );if _dccad !=nil {return _dccad ;};_dcec .AP =_agfcc ;};};};};if _ggff {for _ ,_gdfa :=range _dcga .PageList {_cacga ,_cfgd :=_gdfa .GetAnnotations ();if _cfgd !=nil {return _cfgd ;};for _ ,_cafge :=range _cacga {_eadfg [_cafge ]=true ;};};};for _ ,_bbbg :=range _dcga .PageList {var _cbccg []*PdfAnnotation ;if _agge !=nil {if _ecacb :=_agge .WrapContentStream (_bbbg );_ecacb !=nil {return _ecacb ;};};_aaeeb ,_aecba :=_bbbg .GetAnnotations ();if _aecba !=nil {return _aecba ;};for _ ,_cgddb :=range _aaeeb {_beac ,_badb :=_eadfg [_cgddb ];if !_badb {_cbccg =append (_cbccg ,_cgddb );continue ;};switch _cgddb .GetContext ().(type ){case *PdfAnnotationPopup :continue ;case *PdfAnnotationLink :continue ;case *PdfAnnotationProjection :continue ;};_gbbf ,_afgcd ,_gedba :=_fdef (_cgddb );if _gedba !=nil {if !_beac {_abe .Log .Trace ("\u0046\u0069\u0065\u006c\u0064\u0020\u0077\u0069\u0074h\u006f\u0075\u0074\u0020\u0056\u0020\u002d\u003e\u0020\u0061\u006e\u006e\u006f\u0074\u0061\u0074\u0069\u006f\u006e\u0020\u0077\u0069\u0074h\u006f\u0075t\u0020\u0061p\u0070\u0065\u0061\u0072\u0061\u006e\u0063\u0065\u0020\u0073\u0074\u0072\u0065am\u0020\u002d\u0020\u0073\u006b\u0069\u0070\u0070\u0069n\u0067\u0020\u006f\u0076\u0065\u0072");continue ;};_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u0020\u0041\u006e\u006e\u006f\u0074\u0061\u0074\u0069\u006f\u006e\u0020\u0077\u0069\u0074h\u006f\u0075\u0074\u0020\u0061\u0070\u0070\u0065\u0061\u0072\u0061\u006e\u0063\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d,\u0020\u0065\u0072\u0072\u0020\u003a\u0020\u0025\u0076\u0020\u002d\u0020\u0073\u006bi\u0070\u0070\u0069n\u0067\u0020\u006f\u0076\u0065\u0072",_gedba );continue ;};if _gbbf ==nil {continue ;};_debba :=_bbbg .Resources .GenerateXObjectName ();_bbbg .Resources .SetXObjectFormByName (_debba ,_gbbf );_ccfdd :=_ad .Min (_afgcd .Llx ,_afgcd .Urx );_efcdg :=_ad .Min (_afgcd .Lly ,_afgcd .Ury );var _bdge []string ;_bdge =append (_bdge ,"\u0071");_bdge =append (_bdge ,_b .Sprintf ("\u0025\u002e\u0036\u0066\u0020\u0025\u002e\u0036\u0066\u0020\u0025\u002e\u0036\u0066\u0020%\u002e6\u0066\u0020\u0025\u002e\u0036\u0066\u0020\u0025\u002e\u0036\u0066\u0020\u0063\u006d",1.0,0.0,0.0,1.0,_ccfdd ,_efcdg ));_bdge =append (_bdge ,_b .Sprintf ("\u002f\u0025\u0073\u0020\u0044\u006f",_debba .String ()));_bdge =append (_bdge ,"\u0051");_egdag :=_dg .Join (_bdge ,"\u000a");_gedba =_bbbg .AppendContentStream (_egdag );if _gedba !=nil {return _gedba ;};if _gbbf .Resources !=nil {_cbceb ,_cdcd :=_aef .GetDict (_gbbf .Resources .Font );if _cdcd {for _ ,_eabgg :=range _cbceb .Keys (){if !_bbbg .Resources .HasFontByName (_eabgg ){_bbbg .Resources .SetFontByName (_eabgg ,_cbceb .Get (_eabgg ));};};};};};if len (_cbccg )> 0{_bbbg ._bcgfd =_cbccg ;}else {_bbbg ._bcgfd =[]*PdfAnnotation {};};};if !_badd {_dcga .AcroForm =nil ;};return nil ;};
 
 // PdfOutlineItem represents an outline item dictionary (Table 153 - pp. 376 - 377).
 type PdfOutlineItem struct{PdfOutlineTreeNode ;Title *_aef .PdfObjectString ;Parent *PdfOutlineTreeNode ;Prev *PdfOutlineTreeNode ;Next *PdfOutlineTreeNode ;Count *int64 ;Dest _aef .PdfObject ;A _aef .PdfObject ;SE _aef .PdfObject ;C _aef .PdfObject ;F _aef .PdfObject ;_gdgba *_aef .PdfIndirectObject ;};func (_aegaf *PdfWriter )writeBytes (_egdffd []byte ){if _aegaf ._geac !=nil {return ;};_bgbfc ,_feccb :=_aegaf ._fcadd .Write (_egdffd );_aegaf ._dfdcd +=int64 (_bgbfc );_aegaf ._geac =_feccb ;};
@@ -2029,7 +2033,7 @@ func (_ebegce *PdfField )IsTerminal ()bool {return len (_ebegce .Kids )==0};
 func (_dddcc *PdfColorspaceDeviceN )ImageToRGB (img Image )(Image ,error ){_gcdgd :=_ea .NewReader (img .getBase ());_deec :=_gb .NewImageBase (int (img .Width ),int (img .Height ),int (img .BitsPerComponent ),img .ColorComponents ,nil ,img ._afge ,img ._fgafa );_fffe :=_ea .NewWriter (_deec );_ccda :=_ad .Pow (2,float64 (img .BitsPerComponent ))-1;_cbegec :=_dddcc .GetNumComponents ();_dfdda :=make ([]uint32 ,_cbegec );_ffcfg :=make ([]float64 ,_cbegec );for {_ggfg :=_gcdgd .ReadSamples (_dfdda );if _ggfg ==_gfc .EOF {break ;}else if _ggfg !=nil {return img ,_ggfg ;};for _fceb :=0;_fceb < _cbegec ;_fceb ++{_fcde :=float64 (_dfdda [_fceb ])/_ccda ;_ffcfg [_fceb ]=_fcde ;};_cafa ,_ggfg :=_dddcc .TintTransform .Evaluate (_ffcfg );if _ggfg !=nil {return img ,_ggfg ;};for _ ,_efdc :=range _cafa {_efdc =_ad .Min (_ad .Max (0,_efdc ),1.0);if _ggfg =_fffe .WriteSample (uint32 (_efdc *_ccda ));_ggfg !=nil {return img ,_ggfg ;};};};return _dddcc .AlternateSpace .ImageToRGB (_cbgdcg (&_deec ));};
 
 // IsColored specifies if the pattern is colored.
-func (_gfaeb *PdfTilingPattern )IsColored ()bool {if _gfaeb .PaintType !=nil &&*_gfaeb .PaintType ==1{return true ;};return false ;};func (_aggg *PdfReader )buildPageList (_gfaad *_aef .PdfIndirectObject ,_egffe *_aef .PdfIndirectObject ,_efcfg map[_aef .PdfObject ]struct{})error {if _gfaad ==nil {return nil ;};if _ ,_dfdg :=_efcfg [_gfaad ];_dfdg {_abe .Log .Debug ("\u0043\u0079\u0063l\u0069\u0063\u0020\u0072e\u0063\u0075\u0072\u0073\u0069\u006f\u006e,\u0020\u0073\u006b\u0069\u0070\u0070\u0069\u006e\u0067\u0020\u0028\u0025\u0076\u0029",_gfaad .ObjectNumber );return nil ;};_efcfg [_gfaad ]=struct{}{};_cfgfg ,_ecefea :=_gfaad .PdfObject .(*_aef .PdfObjectDictionary );if !_ecefea {return _fa .New ("n\u006f\u0064\u0065\u0020no\u0074 \u0061\u0020\u0064\u0069\u0063t\u0069\u006f\u006e\u0061\u0072\u0079");};_fgffc ,_ecefea :=(*_cfgfg ).Get ("\u0054\u0079\u0070\u0065").(*_aef .PdfObjectName );if !_ecefea {if _cfgfg .Get ("\u004b\u0069\u0064\u0073")==nil {return _fa .New ("\u006e\u006f\u0064\u0065 \u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0054\u0079p\u0065 \u0028\u0052\u0065\u0071\u0075\u0069\u0072e\u0064\u0029");};_abe .Log .Debug ("ER\u0052\u004fR\u003a\u0020\u006e\u006f\u0064\u0065\u0020\u006d\u0069s\u0073\u0069\u006e\u0067\u0020\u0054\u0079\u0070\u0065\u002c\u0020\u0062\u0075\u0074\u0020\u0068\u0061\u0073\u0020\u004b\u0069\u0064\u0073\u002e\u0020\u0041\u0073\u0073u\u006di\u006e\u0067\u0020\u0050\u0061\u0067\u0065\u0073 \u006eo\u0064\u0065.");_fgffc =_aef .MakeName ("\u0050\u0061\u0067e\u0073");_cfgfg .Set ("\u0054\u0079\u0070\u0065",_fgffc );};_abe .Log .Trace ("\u0062\u0075\u0069\u006c\u0064\u0050a\u0067\u0065\u004c\u0069\u0073\u0074\u0020\u006e\u006f\u0064\u0065\u0020\u0074y\u0070\u0065\u003a\u0020\u0025\u0073\u0020(\u0025\u002b\u0076\u0029",*_fgffc ,_gfaad );if *_fgffc =="\u0050\u0061\u0067\u0065"{_bdedcd ,_agabg :=_aggg .newPdfPageFromDict (_cfgfg );if _agabg !=nil {return _agabg ;};_bdedcd .setContainer (_gfaad );if _egffe !=nil {_cfgfg .Set ("\u0050\u0061\u0072\u0065\u006e\u0074",_egffe );};_aggg ._dbdgb =append (_aggg ._dbdgb ,_gfaad );_aggg .PageList =append (_aggg .PageList ,_bdedcd );return nil ;};if *_fgffc !="\u0050\u0061\u0067e\u0073"{_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0054\u0061\u0062\u006c\u0065\u0020\u006f\u0066\u0020\u0063\u006fnt\u0065n\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067 \u006e\u006f\u006e\u0020\u0050\u0061\u0067\u0065\u002f\u0050\u0061\u0067\u0065\u0073\u0020\u006f\u0062j\u0065\u0063\u0074\u0021\u0020\u0028\u0025\u0073\u0029",_fgffc );return _fa .New ("\u0074\u0061\u0062\u006c\u0065\u0020o\u0066\u0020\u0063\u006f\u006e\u0074\u0065\u006e\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067 \u006e\u006f\u006e\u0020\u0050\u0061\u0067\u0065\u002f\u0050\u0061\u0067\u0065\u0073 \u006fb\u006a\u0065\u0063\u0074");};if _egffe !=nil {_cfgfg .Set ("\u0050\u0061\u0072\u0065\u006e\u0074",_egffe );};if !_aggg ._afae {_dagdg :=_aggg .traverseObjectData (_gfaad );if _dagdg !=nil {return _dagdg ;};};_fcge ,_dabbc :=_aggg ._gdbbd .Resolve (_cfgfg .Get ("\u004b\u0069\u0064\u0073"));if _dabbc !=nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0046\u0061\u0069\u006c\u0065\u0064\u0020\u006c\u006f\u0061\u0064\u0069\u006eg\u0020\u004b\u0069\u0064\u0073\u0020\u006fb\u006a\u0065\u0063\u0074");return _dabbc ;};var _fdec *_aef .PdfObjectArray ;_fdec ,_ecefea =_fcge .(*_aef .PdfObjectArray );if !_ecefea {_dgagcd ,_ecgdc :=_fcge .(*_aef .PdfIndirectObject );if !_ecgdc {return _fa .New ("\u0069\u006e\u0076\u0061li\u0064\u0020\u004b\u0069\u0064\u0073\u0020\u006f\u0062\u006a\u0065\u0063\u0074");};_fdec ,_ecefea =_dgagcd .PdfObject .(*_aef .PdfObjectArray );if !_ecefea {return _fa .New ("\u0069\u006e\u0076\u0061l\u0069\u0064\u0020\u004b\u0069\u0064\u0073\u0020\u0069\u006ed\u0069r\u0065\u0063\u0074\u0020\u006f\u0062\u006ae\u0063\u0074");};};_abe .Log .Trace ("\u004b\u0069\u0064\u0073\u003a\u0020\u0025\u0073",_fdec );for _facgg ,_dabag :=range _fdec .Elements (){_bfgge ,_ggcfa :=_aef .GetIndirect (_dabag );if !_ggcfa {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0050\u0061\u0067\u0065\u0020\u006e\u006f\u0074\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074 \u006f\u0062\u006a\u0065\u0063t\u0020\u002d \u0028\u0025\u0073\u0029",_bfgge );return _fa .New ("\u0070a\u0067\u0065\u0020\u006e\u006f\u0074\u0020\u0069\u006e\u0064\u0069r\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074");};_fdec .Set (_facgg ,_bfgge );_dabbc =_aggg .buildPageList (_bfgge ,_gfaad ,_efcfg );if _dabbc !=nil {return _dabbc ;};};return nil ;};
+func (_gfaeb *PdfTilingPattern )IsColored ()bool {if _gfaeb .PaintType !=nil &&*_gfaeb .PaintType ==1{return true ;};return false ;};func (_aggg *PdfReader )buildPageList (_gfaad *_aef .PdfIndirectObject ,_egffe *_aef .PdfIndirectObject ,_efcfg map[_aef .PdfObject ]struct{})error {if _gfaad ==nil {return nil ;};if _ ,_dfdg :=_efcfg [_gfaad ];_dfdg {_abe .Log .Debug ("\u0043\u0079\u0063l\u0069\u0063\u0020\u0072e\u0063\u0075\u0072\u0073\u0069\u006f\u006e,\u0020\u0073\u006b\u0069\u0070\u0070\u0069\u006e\u0067\u0020\u0028\u0025\u0076\u0029",_gfaad .ObjectNumber );return nil ;};_efcfg [_gfaad ]=struct{}{};_cfgfg ,_ecefea :=_gfaad .PdfObject .(*_aef .PdfObjectDictionary );if !_ecefea {return _fa .New ("n\u006f\u0064\u0065\u0020no\u0074 \u0061\u0020\u0064\u0069\u0063t\u0069\u006f\u006e\u0061\u0072\u0079");};_fgffc ,_ecefea :=(*_cfgfg ).Get ("\u0054\u0079\u0070\u0065").(*_aef .PdfObjectName );if !_ecefea {if _cfgfg .Get ("\u004b\u0069\u0064\u0073")==nil {return _fa .New ("\u006e\u006f\u0064\u0065 \u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0054\u0079p\u0065 \u0028\u0052\u0065\u0071\u0075\u0069\u0072e\u0064\u0029");};_abe .Log .Debug ("ER\u0052\u004fR\u003a\u0020\u006e\u006f\u0064\u0065\u0020\u006d\u0069s\u0073\u0069\u006e\u0067\u0020\u0054\u0079\u0070\u0065\u002c\u0020\u0062\u0075\u0074\u0020\u0068\u0061\u0073\u0020\u004b\u0069\u0064\u0073\u002e\u0020\u0041\u0073\u0073u\u006di\u006e\u0067\u0020\u0050\u0061\u0067\u0065\u0073 \u006eo\u0064\u0065.");_fgffc =_aef .MakeName ("\u0050\u0061\u0067e\u0073");_cfgfg .Set ("\u0054\u0079\u0070\u0065",_fgffc );};_abe .Log .Trace ("\u0062\u0075\u0069\u006c\u0064\u0050a\u0067\u0065\u004c\u0069\u0073\u0074\u0020\u006e\u006f\u0064\u0065\u0020\u0074y\u0070\u0065\u003a\u0020\u0025\u0073\u0020(\u0025\u002b\u0076\u0029",*_fgffc ,_gfaad );if *_fgffc =="\u0050\u0061\u0067\u0065"{_bdedcd ,_agabg :=_aggg .pageFromNode (_gfaad ,_cfgfg );if _agabg !=nil {return _agabg ;};_bdedcd .setContainer (_gfaad );if _egffe !=nil {_cfgfg .Set ("\u0050\u0061\u0072\u0065\u006e\u0074",_egffe );};_aggg ._dbdgb =append (_aggg ._dbdgb ,_gfaad );_aggg .PageList =append (_aggg .PageList ,_bdedcd );return nil ;};if *_fgffc !="\u0050\u0061\u0067e\u0073"{_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0054\u0061\u0062\u006c\u0065\u0020\u006f\u0066\u0020\u0063\u006fnt\u0065n\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067 \u006e\u006f\u006e\u0020\u0050\u0061\u0067\u0065\u002f\u0050\u0061\u0067\u0065\u0073\u0020\u006f\u0062j\u0065\u0063\u0074\u0021\u0020\u0028\u0025\u0073\u0029",_fgffc );return _fa .New ("\u0074\u0061\u0062\u006c\u0065\u0020o\u0066\u0020\u0063\u006f\u006e\u0074\u0065\u006e\u0074\u0020\u0063\u006f\u006e\u0074\u0061\u0069\u006e\u0069\u006e\u0067 \u006e\u006f\u006e\u0020\u0050\u0061\u0067\u0065\u002f\u0050\u0061\u0067\u0065\u0073 \u006fb\u006a\u0065\u0063\u0074");};if _egffe !=nil {_cfgfg .Set ("\u0050\u0061\u0072\u0065\u006e\u0074",_egffe );};if !_aggg ._afae {_dagdg :=_aggg .traverseObjectData (_gfaad );if _dagdg !=nil {return _dagdg ;};};_fcge ,_dabbc :=_aggg ._gdbbd .Resolve (_cfgfg .Get ("\u004b\u0069\u0064\u0073"));if _dabbc !=nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0046\u0061\u0069\u006c\u0065\u0064\u0020\u006c\u006f\u0061\u0064\u0069\u006eg\u0020\u004b\u0069\u0064\u0073\u0020\u006fb\u006a\u0065\u0063\u0074");return _dabbc ;};var _fdec *_aef .PdfObjectArray ;_fdec ,_ecefea =_fcge .(*_aef .PdfObjectArray );if !_ecefea {_dgagcd ,_ecgdc :=_fcge .(*_aef .PdfIndirectObject );if !_ecgdc {return _fa .New ("\u0069\u006e\u0076\u0061li\u0064\u0020\u004b\u0069\u0064\u0073\u0020\u006f\u0062\u006a\u0065\u0063\u0074");};_fdec ,_ecefea =_dgagcd .PdfObject .(*_aef .PdfObjectArray );if !_ecefea {return _fa .New ("\u0069\u006e\u0076\u0061l\u0069\u0064\u0020\u004b\u0069\u0064\u0073\u0020\u0069\u006ed\u0069r\u0065\u0063\u0074\u0020\u006f\u0062\u006ae\u0063\u0074");};};_abe .Log .Trace ("\u004b\u0069\u0064\u0073\u003a\u0020\u0025\u0073",_fdec );for _facgg ,_dabag :=range _fdec .Elements (){_bfgge ,_ggcfa :=_aef .GetIndirect (_dabag );if !_ggcfa {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0050\u0061\u0067\u0065\u0020\u006e\u006f\u0074\u0020\u0069\u006e\u0064\u0069\u0072\u0065\u0063\u0074 \u006f\u0062\u006a\u0065\u0063t\u0020\u002d \u0028\u0025\u0073\u0029",_bfgge );return _fa .New ("\u0070a\u0067\u0065\u0020\u006e\u006f\u0074\u0020\u0069\u006e\u0064\u0069r\u0065\u0063\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074");};_fdec .Set (_facgg ,_bfgge );_dabbc =_aggg .buildPageList (_bfgge ,_gfaad ,_efcfg );if _dabbc !=nil {return _dabbc ;};};return nil ;};
 
 // GetContext returns the context of the outline tree node, which is either a
 // *PdfOutline or a *PdfOutlineItem. The method returns nil for uninitialized
@@ -2360,7 +2364,7 @@ func (_gbba *LTV )EnableChain (chain []*_c .Certificate )error {return _gbba .en
 
 // ColorFromPdfObjects returns a new PdfColor based on the input slice of color
 // component PDF objects.
-func (_ecgd *PdfColorspaceICCBased )ColorFromPdfObjects (objects []_aef .PdfObject )(PdfColor ,error ){if _ecgd .Alternate ==nil {if _ecgd .N ==1{_efcg :=NewPdfColorspaceDeviceGray ();return _efcg .ColorFromPdfObjects (objects );}else if _ecgd .N ==3{_bffd :=NewPdfColorspaceDeviceRGB ();return _bffd .ColorFromPdfObjects (objects );}else if _ecgd .N ==4{_eebe :=NewPdfColorspaceDeviceCMYK ();return _eebe .ColorFromPdfObjects (objects );}else {return nil ,_fa .New ("I\u0043\u0043\u0020\u0042\u0061\u0073\u0065\u0064\u0020\u0063\u006f\u006c\u006f\u0072\u0073\u0070\u0061\u0063e\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0061lt\u0065\u0072\u006ea\u0074i\u0076\u0065");};};return _ecgd .Alternate .ColorFromPdfObjects (objects );};func (_eedf *PdfReader )loadStructure ()error {if _eedf ._gdbbd .GetCrypter ()!=nil &&!_eedf ._gdbbd .IsAuthenticated (){return _b .Errorf ("\u0066\u0069\u006ce\u0020\u006e\u0065\u0065d\u0020\u0074\u006f\u0020\u0062\u0065\u0020d\u0065\u0063\u0072\u0079\u0070\u0074\u0065\u0064\u0020\u0066\u0069\u0072\u0073\u0074");};_cegg :=_eedf ._gdbbd .GetTrailer ();if _cegg ==nil {return _b .Errorf ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0074\u0072\u0061\u0069\u006c\u0065\u0072");};_fcdee ,_dabgb :=_cegg .Get ("\u0052\u006f\u006f\u0074").(*_aef .PdfObjectReference );if !_dabgb {return _b .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0052\u006f\u006ft\u0020\u0028\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u003a \u0025\u0073\u0029",_cegg );};_afefe ,_cceca :=_eedf ._gdbbd .LookupByReference (*_fcdee );if _cceca !=nil {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020\u0072\u0065\u0061\u0064\u0020\u0072\u006f\u006f\u0074\u0020\u0065\u006c\u0065\u006d\u0065\u006e\u0074\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0025\u0073",_cceca );return _cceca ;};_efaggb ,_dabgb :=_afefe .(*_aef .PdfIndirectObject );if !_dabgb {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u004d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0028\u0072\u006f\u006f\u0074\u0020\u0025\u0071\u0029\u0020\u0028\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u0020\u0025\u0073\u0029",_afefe ,*_cegg );return _fa .New ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067");};_edbf ,_dabgb :=(*_efaggb ).PdfObject .(*_aef .PdfObjectDictionary );if !_dabgb {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020I\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0063\u0061t\u0061\u006c\u006fg\u0020(\u0025\u0073\u0029",_efaggb .PdfObject );return _fa .New ("\u0069n\u0076a\u006c\u0069\u0064\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067");};_abe .Log .Trace ("C\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0025\u0073",_edbf );_fbac ,_dabgb :=_edbf .Get ("\u0050\u0061\u0067e\u0073").(*_aef .PdfObjectReference );if !_dabgb {return _fa .New ("\u0070\u0061\u0067\u0065\u0073\u0020\u0069\u006e\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020b\u0065\u0020\u0061\u0020\u0072e\u0066\u0065r\u0065\u006e\u0063\u0065");};_dgca ,_cceca :=_eedf ._gdbbd .LookupByReference (*_fbac );if _cceca !=nil {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020F\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020r\u0065\u0061\u0064 \u0070a\u0067\u0065\u0073");return _cceca ;};_beede ,_dabgb :=_dgca .(*_aef .PdfIndirectObject );if !_dabgb {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020P\u0061\u0067\u0065\u0073\u0020\u006f\u0062\u006a\u0065c\u0074\u0020\u0069n\u0076a\u006c\u0069\u0064");_abe .Log .Debug ("\u006f\u0070\u003a\u0020\u0025\u0070",_beede );return _fa .New ("p\u0061g\u0065\u0073\u0020\u006f\u0062\u006a\u0065\u0063t\u0020\u0069\u006e\u0076al\u0069\u0064");};_efda ,_dabgb :=_beede .PdfObject .(*_aef .PdfObjectDictionary );if !_dabgb {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0050\u0061\u0067\u0065\u0073\u0020\u006f\u0062j\u0065c\u0074\u0020\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0028\u0025\u0073\u0029",_beede );return _fa .New ("p\u0061g\u0065\u0073\u0020\u006f\u0062\u006a\u0065\u0063t\u0020\u0069\u006e\u0076al\u0069\u0064");};_fbccd ,_dabgb :=_aef .GetInt (_efda .Get ("\u0043\u006f\u0075n\u0074"));if !_dabgb {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0050\u0061\u0067\u0065\u0073\u0020\u0063\u006f\u0075\u006e\u0074\u0020\u006fb\u006a\u0065\u0063\u0074\u0020\u0069\u006ev\u0061\u006c\u0069\u0064");return _fa .New ("\u0070\u0061\u0067\u0065s \u0063\u006f\u0075\u006e\u0074\u0020\u0069\u006e\u0076\u0061\u006c\u0069\u0064");};if _ ,_dabgb =_aef .GetName (_efda .Get ("\u0054\u0079\u0070\u0065"));!_dabgb {_abe .Log .Debug ("\u0050\u0061\u0067\u0065\u0073\u0020\u0064\u0069\u0063\u0074\u0020T\u0079\u0070\u0065\u0020\u0066\u0069\u0065\u006cd\u0020n\u006f\u0074\u0020\u0073\u0065\u0074\u002e\u0020\u0053\u0065\u0074\u0074\u0069\u006e\u0067\u0020\u0054\u0079p\u0065\u0020\u0074\u006f\u0020\u0050\u0061\u0067\u0065\u0073\u002e");_efda .Set ("\u0054\u0079\u0070\u0065",_aef .MakeName ("\u0050\u0061\u0067e\u0073"));};_eedf ._eebff =_fcdee ;_eedf ._acae =_edbf ;_eedf ._fgbfc =_efda ;_eedf ._cbfdd =_beede ;_eedf ._bbccc =int (*_fbccd );_eedf ._dbdgb =[]*_aef .PdfIndirectObject {};_ffeeb :=map[_aef .PdfObject ]struct{}{};_cceca =_eedf .buildPageList (_beede ,nil ,_ffeeb );if _cceca !=nil {return _cceca ;};_abe .Log .Trace ("\u002d\u002d\u002d");_abe .Log .Trace ("\u0054\u004f\u0043");_abe .Log .Trace ("\u0050\u0061\u0067e\u0073");_abe .Log .Trace ("\u0025\u0064\u003a\u0020\u0025\u0073",len (_eedf ._dbdgb ),_eedf ._dbdgb );_eedf ._gaad ,_cceca =_eedf .loadOutlines ();if _cceca !=nil {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020\u0062\u0075i\u006c\u0064\u0020\u006f\u0075\u0074\u006c\u0069\u006e\u0065 t\u0072\u0065\u0065 \u0028%\u0073\u0029",_cceca );return _cceca ;};_eedf .AcroForm ,_cceca =_eedf .loadForms ();if _cceca !=nil {return _cceca ;};_eedf .DSS ,_cceca =_eedf .loadDSS ();if _cceca !=nil {return _cceca ;};return nil ;};var _ pdfFont =(*pdfCIDFontType2 )(nil );func _fgga (_cfadf ,_ebcg string )string {if _dg .Contains (_cfadf ,"\u002b"){_ggagd :=_dg .Split (_cfadf ,"\u002b");if len (_ggagd )==2{_cfadf =_ggagd [1];};};return _ebcg +"\u002b"+_cfadf ;};func (_afag *PdfReader )newPdfActionGotoEFromDict (_ffe *_aef .PdfObjectDictionary )(*PdfActionGoToE ,error ){_cfb ,_gab :=_bfc (_ffe .Get ("\u0046"));if _gab !=nil {return nil ,_gab ;};return &PdfActionGoToE {D :_ffe .Get ("\u0044"),NewWindow :_ffe .Get ("\u004ee\u0077\u0057\u0069\u006e\u0064\u006fw"),T :_ffe .Get ("\u0054"),F :_cfb },nil ;};
+func (_ecgd *PdfColorspaceICCBased )ColorFromPdfObjects (objects []_aef .PdfObject )(PdfColor ,error ){if _ecgd .Alternate ==nil {if _ecgd .N ==1{_efcg :=NewPdfColorspaceDeviceGray ();return _efcg .ColorFromPdfObjects (objects );}else if _ecgd .N ==3{_bffd :=NewPdfColorspaceDeviceRGB ();return _bffd .ColorFromPdfObjects (objects );}else if _ecgd .N ==4{_eebe :=NewPdfColorspaceDeviceCMYK ();return _eebe .ColorFromPdfObjects (objects );}else {return nil ,_fa .New ("I\u0043\u0043\u0020\u0042\u0061\u0073\u0065\u0064\u0020\u0063\u006f\u006c\u006f\u0072\u0073\u0070\u0061\u0063e\u0020\u006d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0061lt\u0065\u0072\u006ea\u0074i\u0076\u0065");};};return _ecgd .Alternate .ColorFromPdfObjects (objects );};func (_eedf *PdfReader )loadStructure ()error {if _eedf ._gdbbd .GetCrypter ()!=nil &&!_eedf ._gdbbd .IsAuthenticated (){return _b .Errorf ("\u0066\u0069\u006ce\u0020\u006e\u0065\u0065d\u0020\u0074\u006f\u0020\u0062\u0065\u0020d\u0065\u0063\u0072\u0079\u0070\u0074\u0065\u0064\u0020\u0066\u0069\u0072\u0073\u0074");};_cegg :=_eedf ._gdbbd .GetTrailer ();if _cegg ==nil {return _b .Errorf ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0074\u0072\u0061\u0069\u006c\u0065\u0072");};_fcdee ,_dabgb :=_cegg .Get ("\u0052\u006f\u006f\u0074").(*_aef .PdfObjectReference );if !_dabgb {return _b .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0052\u006f\u006ft\u0020\u0028\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u003a \u0025\u0073\u0029",_cegg );};_afefe ,_cceca :=_eedf ._gdbbd .LookupByReference (*_fcdee );if _cceca !=nil {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020\u0072\u0065\u0061\u0064\u0020\u0072\u006f\u006f\u0074\u0020\u0065\u006c\u0065\u006d\u0065\u006e\u0074\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0025\u0073",_cceca );return _cceca ;};_efaggb ,_dabgb :=_afefe .(*_aef .PdfIndirectObject );if !_dabgb {_abe .Log .Debug ("\u0045\u0052R\u004f\u0052\u003a\u0020\u004d\u0069\u0073\u0073\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0028\u0072\u006f\u006f\u0074\u0020\u0025\u0071\u0029\u0020\u0028\u0074\u0072\u0061\u0069\u006c\u0065\u0072\u0020\u0025\u0073\u0029",_afefe ,*_cegg );return _fa .New ("\u006di\u0073s\u0069\u006e\u0067\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067");};_edbf ,_dabgb :=(*_efaggb ).PdfObject .(*_aef .PdfObjectDictionary );if !_dabgb {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020I\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0063\u0061t\u0061\u006c\u006fg\u0020(\u0025\u0073\u0029",_efaggb .PdfObject );return _fa .New ("\u0069n\u0076a\u006c\u0069\u0064\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067");};_abe .Log .Trace ("C\u0061\u0074\u0061\u006c\u006f\u0067\u003a\u0020\u0025\u0073",_edbf );_fbac ,_dabgb :=_edbf .Get ("\u0050\u0061\u0067e\u0073").(*_aef .PdfObjectReference );if !_dabgb {return _fa .New ("\u0070\u0061\u0067\u0065\u0073\u0020\u0069\u006e\u0020\u0063\u0061\u0074\u0061\u006c\u006f\u0067\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020b\u0065\u0020\u0061\u0020\u0072e\u0066\u0065r\u0065\u006e\u0063\u0065");};_dgca ,_cceca :=_eedf ._gdbbd .LookupByReference (*_fbac );if _cceca !=nil {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020F\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020r\u0065\u0061\u0064 \u0070a\u0067\u0065\u0073");return _cceca ;};_beede ,_dabgb :=_dgca .(*_aef .PdfIndirectObject );if !_dabgb {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020P\u0061\u0067\u0065\u0073\u0020\u006f\u0062\u006a\u0065c\u0074\u0020\u0069n\u0076a\u006c\u0069\u0064");_abe .Log .Debug ("\u006f\u0070\u003a\u0020\u0025\u0070",_beede );return _fa .New ("p\u0061g\u0065\u0073\u0020\u006f\u0062\u006a\u0065\u0063t\u0020\u0069\u006e\u0076al\u0069\u0064");};_efda ,_dabgb :=_beede .PdfObject .(*_aef .PdfObjectDictionary );if !_dabgb {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0050\u0061\u0067\u0065\u0073\u0020\u006f\u0062j\u0065c\u0074\u0020\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0028\u0025\u0073\u0029",_beede );return _fa .New ("p\u0061g\u0065\u0073\u0020\u006f\u0062\u006a\u0065\u0063t\u0020\u0069\u006e\u0076al\u0069\u0064");};_fbccd ,_dabgb :=_aef .GetInt (_efda .Get ("\u0043\u006f\u0075n\u0074"));if !_dabgb {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0050\u0061\u0067\u0065\u0073\u0020\u0063\u006f\u0075\u006e\u0074\u0020\u006fb\u006a\u0065\u0063\u0074\u0020\u0069\u006ev\u0061\u006c\u0069\u0064");return _fa .New ("\u0070\u0061\u0067\u0065s \u0063\u006f\u0075\u006e\u0074\u0020\u0069\u006e\u0076\u0061\u006c\u0069\u0064");};if _ ,_dabgb =_aef .GetName (_efda .Get ("\u0054\u0079\u0070\u0065"));!_dabgb {_abe .Log .Debug ("\u0050\u0061\u0067\u0065\u0073\u0020\u0064\u0069\u0063\u0074\u0020T\u0079\u0070\u0065\u0020\u0066\u0069\u0065\u006cd\u0020n\u006f\u0074\u0020\u0073\u0065\u0074\u002e\u0020\u0053\u0065\u0074\u0074\u0069\u006e\u0067\u0020\u0054\u0079p\u0065\u0020\u0074\u006f\u0020\u0050\u0061\u0067\u0065\u0073\u002e");_efda .Set ("\u0054\u0079\u0070\u0065",_aef .MakeName ("\u0050\u0061\u0067e\u0073"));};_eedf ._eebff =_fcdee ;_eedf ._acae =_edbf ;_eedf ._fgbfc =_efda ;_eedf ._cbfdd =_beede ;_eedf ._bbccc =int (*_fbccd );_eedf ._dbdgb =[]*_aef .PdfIndirectObject {};_ffeeb :=map[_aef .PdfObject ]struct{}{};if !_eedf .lazyPages {_cceca =_eedf .buildPageList (_beede ,nil ,_ffeeb );if _cceca !=nil {return _cceca ;};};_abe .Log .Trace ("\u002d\u002d\u002d");_abe .Log .Trace ("\u0054\u004f\u0043");_abe .Log .Trace ("\u0050\u0061\u0067e\u0073");_abe .Log .Trace ("\u0025\u0064\u003a\u0020\u0025\u0073",len (_eedf ._dbdgb ),_eedf ._dbdgb );_eedf ._gaad ,_cceca =_eedf .loadOutlines ();if _cceca !=nil {_abe .Log .Debug ("E\u0052\u0052\u004f\u0052\u003a\u0020\u0046\u0061\u0069\u006c\u0065\u0064\u0020\u0074\u006f\u0020\u0062\u0075i\u006c\u0064\u0020\u006f\u0075\u0074\u006c\u0069\u006e\u0065 t\u0072\u0065\u0065 \u0028%\u0073\u0029",_cceca );return _cceca ;};_eedf .AcroForm ,_cceca =_eedf .loadForms ();if _cceca !=nil {return _cceca ;};_eedf .DSS ,_cceca =_eedf .loadDSS ();if _cceca !=nil {return _cceca ;};return nil ;};var _ pdfFont =(*pdfCIDFontType2 )(nil );func _fgga (_cfadf ,_ebcg string )string {if _dg .Contains (_cfadf ,"\u002b"){_ggagd :=_dg .Split (_cfadf ,"\u002b");if len (_ggagd )==2{_cfadf =_ggagd [1];};};return _ebcg +"\u002b"+_cfadf ;};func (_afag *PdfReader )newPdfActionGotoEFromDict (_ffe *_aef .PdfObjectDictionary )(*PdfActionGoToE ,error ){_cfb ,_gab :=_bfc (_ffe .Get ("\u0046"));if _gab !=nil {return nil ,_gab ;};return &PdfActionGoToE {D :_ffe .Get ("\u0044"),NewWindow :_ffe .Get ("\u004ee\u0077\u0057\u0069\u006e\u0064\u006fw"),T :_ffe .Get ("\u0054"),F :_cfb },nil ;};
 
 // SetFlag sets the flag for the field.
 func (_gagafe *PdfField )SetFlag (flag FieldFlag ){_gagafe .Ff =_aef .MakeInteger (int64 (flag ))};func _fbcg (_fbfcbe []byte )(_bcfcc ,_feaga string ,_cggf error ){_abe .Log .Trace ("g\u0065\u0074\u0041\u0053CI\u0049S\u0065\u0063\u0074\u0069\u006fn\u0073\u003a\u0020\u0025\u0064\u0020",len (_fbfcbe ));_dgec :=_dcfbb .FindIndex (_fbfcbe );if _dgec ==nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a \u0067\u0065\u0074\u0041\u0053\u0043\u0049\u0049\u0053\u0065\u0063\u0074\u0069o\u006e\u0073\u002e\u0020\u004e\u006f\u0020d\u0069\u0063\u0074\u002e");return "","",_aef .ErrTypeError ;};_gdgca :=_dgec [1];_dface :=_dg .Index (string (_fbfcbe [_gdgca :]),_gdee );if _dface < 0{_bcfcc =string (_fbfcbe [_gdgca :]);return _bcfcc ,"",nil ;};_faacf :=_gdgca +_dface ;_bcfcc =string (_fbfcbe [_gdgca :_faacf ]);_ccde :=_faacf ;_dface =_dg .Index (string (_fbfcbe [_ccde :]),_affbc );if _dface < 0{_abe .Log .Debug ("\u0045\u0052\u0052O\u0052\u003a\u0020\u0067e\u0074\u0041\u0053\u0043\u0049\u0049\u0053e\u0063\u0074\u0069\u006f\u006e\u0073\u002e\u0020\u0065\u0072\u0072\u003d\u0025\u0076",_cggf );return "","",_aef .ErrTypeError ;};_gabg :=_ccde +_dface ;_feaga =string (_fbfcbe [_ccde :_gabg ]);return _bcfcc ,_feaga ,nil ;};func _fdef (_bggf *PdfAnnotation )(*XObjectForm ,*PdfRectangle ,error ){_eebbb ,_agab :=_aef .GetDict (_bggf .AP );if !_agab {return nil ,nil ,_fa .New ("f\u0069\u0065\u006c\u0064\u0020\u006di\u0073\u0073\u0069\u006e\u0067\u0020\u0041\u0050\u0020d\u0069\u0063\u0074i\u006fn\u0061\u0072\u0079");};if _eebbb ==nil {return nil ,nil ,nil ;};_fbfcb ,_agab :=_aef .GetArray (_bggf .Rect );if !_agab ||_fbfcb .Len ()!=4{return nil ,nil ,_fa .New ("\u0072\u0065\u0063t\u0020\u0069\u006e\u0076\u0061\u006c\u0069\u0064");};_bggb ,_babcg :=NewPdfRectangle (*_fbfcb );if _babcg !=nil {return nil ,nil ,_babcg ;};_adaba :=_aef .TraceToDirectObject (_eebbb .Get ("\u004e"));switch _ecde :=_adaba .(type ){case *_aef .PdfObjectStream :_acdgf :=_ecde ;_agfg ,_aefda :=NewXObjectFormFromStream (_acdgf );return _agfg ,_bggb ,_aefda ;case *_aef .PdfObjectDictionary :_cfgf :=_ecde ;_eedg ,_bedbgb :=_aef .GetName (_bggf .AS );if !_bedbgb {return nil ,nil ,nil ;};if _cfgf .Get (*_eedg )==nil {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052:\u0020\u0041\u0053\u0020\u0073\u0074\u0061\u0074\u0065\u0020\u006e\u006f\u0074 \u0073\u0070\u0065\u0063\u0069\u0066\u0069\u0065\u0064\u0020\u0069\u006e\u0020\u0041\u0050\u0020\u0064\u0069\u0063\u0074\u0020\u002d\u0020\u0069\u0067\u006e\u006f\u0072\u0069\u006eg");return nil ,nil ,nil ;};_gcda ,_bedbgb :=_aef .GetStream (_cfgf .Get (*_eedg ));if !_bedbgb {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020\u0055n\u0061\u0062\u006ce \u0074\u006f\u0020\u0061\u0063\u0063e\u0073\u0073\u0020\u0061\u0070\u0070\u0065\u0061\u0072\u0061\u006e\u0063\u0065\u0020\u0073t\u0072\u0065\u0061\u006d\u0020\u0066\u006f\u0072 \u0025\u0076",_eedg );return nil ,nil ,_fa .New ("\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u006d\u0069s\u0073\u0069\u006e\u0067");};_abgef ,_egab :=NewXObjectFormFromStream (_gcda );return _abgef ,_bggb ,_egab ;};_abe .Log .Debug ("\u0049\u006e\u0076\u0061li\u0064\u0020\u0074\u0079\u0070\u0065\u0020\u0066\u006f\u0072\u0020\u004e\u003a\u0020%\u0054",_adaba );return nil ,nil ,_fa .New ("\u0074\u0079p\u0065\u0020\u0063h\u0065\u0063\u006b\u0020\u0065\u0072\u0072\u006f\u0072");};
@@ -2433,7 +2437,17 @@ func NewPdfActionTrans ()*PdfActionTrans {_dec :=NewPdfAction ();_acb :=&PdfActi
 func (_eddcg *PdfTilingPattern )GetContentStream ()([]byte ,error ){_fafg ,_ ,_ffga :=_eddcg .GetContentStreamWithEncoder ();return _fafg ,_ffga ;};
 
 // EncryptOptions represents encryption options for an output PDF.
-type EncryptOptions struct{Permissions _db .Permissions ;Algorithm EncryptionAlgorithm ;};
+type EncryptOptions struct{Permissions _db .Permissions ;Algorithm EncryptionAlgorithm ;
+
+// Recipients are the recipients of a document encrypted with the public-key security handler
+// (Adobe.PubSec). When set, the document is encrypted for their certificates, with their own
+// permissions, and the passwords and Permissions are ignored.
+Recipients []_db .PubKeyRecipient ;
+
+// CryptFilters defines which objects are encrypted: the streams, the strings, the embedded
+// files and the metadata, e.g. to leave the XMP metadata unencrypted or to only encrypt the
+// embedded files. All the objects are encrypted if nil.
+CryptFilters *_aef .CryptFilterOpts ;};
 
 // ToPdfObject returns an indirect object containing the signature field dictionary.
 func (_bfebg *PdfFieldSignature )ToPdfObject ()_aef .PdfObject {if _bfebg .PdfAnnotationWidget !=nil {_bfebg .PdfAnnotationWidget .ToPdfObject ();};_bfebg .PdfField .ToPdfObject ();_deddb :=_bfebg ._abebg ;_abcc :=_deddb .PdfObject .(*_aef .PdfObjectDictionary );_abcc .SetIfNotNil ("\u0046\u0054",_aef .MakeName ("\u0053\u0069\u0067"));_abcc .SetIfNotNil ("\u004c\u006f\u0063\u006b",_bfebg .Lock );_abcc .SetIfNotNil ("\u0053\u0056",_bfebg .SV );if _bfebg .V !=nil {_abcc .SetIfNotNil ("\u0056",_bfebg .V .ToPdfObject ());};return _deddb ;};func (_dgafb fontCommon )fontFlags ()int {if _dgafb ._bgfd ==nil {return 0;};return _dgafb ._bgfd ._eagd ;};
@@ -2553,7 +2567,7 @@ func (_dceaa DefaultImageHandler )NewImageFromGoImage (goimg _ae .Image )(*Image
 func (_fbdgg *Image )AlphaMap (mapFunc AlphaMapFunc ){for _ddfbg ,_dceee :=range _fbdgg ._afge {_fbdgg ._afge [_ddfbg ]=mapFunc (_dceee );};};func (_bdgbf *PdfWriter )copyObjects (){_aaegbe :=make (map[_aef .PdfObject ]_aef .PdfObject );_badc :=make ([]_aef .PdfObject ,0,len (_bdgbf ._aage ));_cabe :=make (map[_aef .PdfObject ]struct{},len (_bdgbf ._aage ));_cgbge :=make (map[_aef .PdfObject ]struct{});for _ ,_cadge :=range _bdgbf ._aage {_aacge :=_bdgbf .copyObject (_cadge ,_aaegbe ,_cgbge ,false );if _ ,_fegcc :=_cgbge [_cadge ];_fegcc {continue ;};_badc =append (_badc ,_aacge );_cabe [_aacge ]=struct{}{};};_bdgbf ._aage =_badc ;_bdgbf ._cafea =_cabe ;_bdgbf ._caefd =_bdgbf .copyObject (_bdgbf ._caefd ,_aaegbe ,nil ,false ).(*_aef .PdfIndirectObject );_bdgbf ._bdbdfg =_bdgbf .copyObject (_bdgbf ._bdbdfg ,_aaegbe ,nil ,false ).(*_aef .PdfIndirectObject );if _bdgbf ._eebbg !=nil {_bdgbf ._eebbg =_bdgbf .copyObject (_bdgbf ._eebbg ,_aaegbe ,nil ,false ).(*_aef .PdfIndirectObject );};if _bdgbf ._bcdd {_deebg :=make (map[_aef .PdfObject ]int64 );for _dgfed ,_caeffa :=range _bdgbf ._bfeac {if _geaf ,_afgef :=_aaegbe [_dgfed ];_afgef {_deebg [_geaf ]=_caeffa ;}else {_abe .Log .Debug ("\u0045\u0052\u0052\u004f\u0052\u003a\u0020a\u0070\u0070\u0065n\u0064\u0020\u006d\u006fd\u0065\u0020\u002d\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0063\u006f\u0070\u0079\u0020\u006e\u006f\u0074\u0020\u0069\u006e\u0020\u006d\u0061\u0070");};};_bdgbf ._bfeac =_deebg ;};};
 
 // PageFromIndirectObject returns the PdfPage and page number for a given indirect object.
-func (_gbbba *PdfReader )PageFromIndirectObject (ind *_aef .PdfIndirectObject )(*PdfPage ,int ,error ){if len (_gbbba .PageList )!=len (_gbbba ._dbdgb ){return nil ,0,_fa .New ("\u0070\u0061\u0067\u0065\u0020\u006c\u0069\u0073\u0074\u0020\u0069\u006ev\u0061\u006c\u0069\u0064");};for _cgec ,_gbfb :=range _gbbba ._dbdgb {if _gbfb ==ind {return _gbbba .PageList [_cgec ],_cgec +1,nil ;};};return nil ,0,_fa .New ("\u0070\u0061\u0067\u0065\u0020\u006e\u006f\u0074\u0020f\u006f\u0075\u006e\u0064");};func _cbbfg (_gbaag _aef .PdfObject )[]*_aef .PdfObjectStream {if _gbaag ==nil {return nil ;};_edgg ,_cbdag :=_aef .GetArray (_gbaag );if !_cbdag ||_edgg .Len ()==0{return nil ;};_cdab :=make ([]*_aef .PdfObjectStream ,0,_edgg .Len ());for _ ,_facfg :=range _edgg .Elements (){if _eadbcf ,_faabb :=_aef .GetStream (_facfg );_faabb {_cdab =append (_cdab ,_eadbcf );};};return _cdab ;};
+func (_gbbba *PdfReader )PageFromIndirectObject (ind *_aef .PdfIndirectObject )(*PdfPage ,int ,error ){if _gbbba .lazyPages {return _gbbba .lazyPageFromIndirectObject (ind );};if len (_gbbba .PageList )!=len (_gbbba ._dbdgb ){return nil ,0,_fa .New ("\u0070\u0061\u0067\u0065\u0020\u006c\u0069\u0073\u0074\u0020\u0069\u006ev\u0061\u006c\u0069\u0064");};for _cgec ,_gbfb :=range _gbbba ._dbdgb {if _gbfb ==ind {return _gbbba .PageList [_cgec ],_cgec +1,nil ;};};return nil ,0,_fa .New ("\u0070\u0061\u0067\u0065\u0020\u006e\u006f\u0074\u0020f\u006f\u0075\u006e\u0064");};func _cbbfg (_gbaag _aef .PdfObject )[]*_aef .PdfObjectStream {if _gbaag ==nil {return nil ;};_edgg ,_cbdag :=_aef .GetArray (_gbaag );if !_cbdag ||_edgg .Len ()==0{return nil ;};_cdab :=make ([]*_aef .PdfObjectStream ,0,_edgg .Len ());for _ ,_facfg :=range _edgg .Elements (){if _eadbcf ,_faabb :=_aef .GetStream (_facfg );_faabb {_cdab =append (_cdab ,_eadbcf );};};return _cdab ;};
 
 // AddPage adds a page to the PDF file. The new page should be an indirect object.
 func (_aggaf *PdfWriter )AddPage (page *PdfPage )error {_bedgc (page );_bdffc :=page .ToPdfObject ();_abe .Log .Trace ("\u003d\u003d\u003d\u003d\u003d\u003d\u003d\u003d\u003d\u003d");_abe .Log .Trace ("\u0041p\u0070\u0065\u006e\u0064i\u006e\u0067\u0020\u0074\u006f \u0070a\u0067e\u0020\u006c\u0069\u0073\u0074\u0020\u0025T",_bdffc );_ceeg ,_bbbd :=_aef .GetIndirect (_bdffc );if !_bbbd {return _fa .New ("\u0070\u0061\u0067\u0065\u0020\u0073h\u006f\u0075\u006c\u0064\u0020\u0062\u0065\u0020\u0061\u006e\u0020\u0069\u006ed\u0069\u0072\u0065\u0063\u0074\u0020\u006fb\u006a\u0065\u0063\u0074");};_abe .Log .Trace ("\u0025\u0073",_ceeg );_abe .Log .Trace ("\u0025\u0073",_ceeg .PdfObject );_ddgfg ,_bbbd :=_aef .GetDict (_ceeg .PdfObject );if !_bbbd {return _fa .New ("\u0070\u0061\u0067e \u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0073\u0068o\u0075l\u0064 \u0062e\u0020\u0061\u0020\u0064\u0069\u0063\u0074\u0069\u006f\u006e\u0061\u0072\u0079");};_adbbd ,_bbbd :=_aef .GetName (_ddgfg .Get ("\u0054\u0079\u0070\u0065"));if !_bbbd {return _b .Errorf ("\u0070\u0061\u0067\u0065\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0068\u0061\u0076\u0065\u0020\u0061\u0020\u0054y\u0070\u0065\u0020\u006b\u0065\u0079\u0020\u0077\u0069t\u0068\u0020\u0061\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u006f\u0066\u0020t\u0079\u0070\u0065\u0020\u006e\u0061m\u0065\u0020\u0028%\u0054\u0029",_ddgfg .Get ("\u0054\u0079\u0070\u0065"));};if _adbbd .String ()!="\u0050\u0061\u0067\u0065"{return _fa .New ("\u0066\u0069e\u006c\u0064\u0020\u0054\u0079\u0070\u0065\u0020\u0021\u003d\u0020\u0050\u0061\u0067\u0065\u0020\u0028\u0052\u0065\u0071\u0075\u0069re\u0064\u0029");};_cdcf :=[]_aef .PdfObjectName {"\u0052e\u0073\u006f\u0075\u0072\u0063\u0065s","\u004d\u0065\u0064\u0069\u0061\u0042\u006f\u0078","\u0043r\u006f\u0070\u0042\u006f\u0078","\u0052\u006f\u0074\u0061\u0074\u0065"};_dfbfb ,_eafae :=_aef .GetIndirect (_ddgfg .Get ("\u0050\u0061\u0072\u0065\u006e\u0074"));_abe .Log .Trace ("P\u0061g\u0065\u0020\u0050\u0061\u0072\u0065\u006e\u0074:\u0020\u0025\u0054\u0020(%\u0076\u0029",_ddgfg .Get ("\u0050\u0061\u0072\u0065\u006e\u0074"),_eafae );for _eafae {_abe .Log .Trace ("\u0050a\u0067e\u0020\u0050\u0061\u0072\u0065\u006e\u0074\u003a\u0020\u0025\u0054",_dfbfb );_eegea ,_fcccg :=_aef .GetDict (_dfbfb .PdfObject );if !_fcccg {return _fa .New ("i\u006e\u0076\u0061\u006cid\u0020P\u0061\u0072\u0065\u006e\u0074 \u006f\u0062\u006a\u0065\u0063\u0074");};for _ ,_ebgaea :=range _cdcf {_abe .Log .Trace ("\u0046\u0069\u0065\u006c\u0064\u0020\u0025\u0073",_ebgaea );if _ddgfg .Get (_ebgaea )!=nil {_abe .Log .Trace ("\u002d \u0070a\u0067\u0065\u0020\u0068\u0061s\u0020\u0061l\u0072\u0065\u0061\u0064\u0079");continue ;};if _bdda :=_eegea .Get (_ebgaea );_bdda !=nil {_abe .Log .Trace ("\u0049\u006e\u0068\u0065ri\u0074\u0069\u006e\u0067\u0020\u0066\u0069\u0065\u006c\u0064\u0020\u0025\u0073",_ebgaea );_ddgfg .Set (_ebgaea ,_bdda );};};_dfbfb ,_eafae =_aef .GetIndirect (_eegea .Get ("\u0050\u0061\u0072\u0065\u006e\u0074"));_abe .Log .Trace ("\u004ee\u0078t\u0020\u0070\u0061\u0072\u0065\u006e\u0074\u003a\u0020\u0025\u0054",_eegea .Get ("\u0050\u0061\u0072\u0065\u006e\u0074"));};_abe .Log .Trace ("\u0054\u0072\u0061\u0076\u0065\u0072\u0073\u0061\u006c \u0064\u006f\u006e\u0065");_ddgfg .Set ("\u0050\u0061\u0072\u0065\u006e\u0074",_aggaf ._gacae );_ceeg .PdfObject =_ddgfg ;_gabab ,_bbbd :=_aef .GetDict (_aggaf ._gacae .PdfObject );if !_bbbd {return _fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0050\u0061g\u0065\u0073\u0020\u006f\u0062\u006a\u0020(\u006e\u006f\u0074\u0020\u0061\u0020\u0064\u0069\u0063\u0074\u0029");};_deaeg ,_bbbd :=_aef .GetArray (_gabab .Get ("\u004b\u0069\u0064\u0073"));if !_bbbd {return _fa .New ("\u0069\u006ev\u0061\u006c\u0069\u0064 \u0050\u0061g\u0065\u0073\u0020\u004b\u0069\u0064\u0073\u0020o\u0062\u006a\u0020\u0028\u006e\u006f\u0074\u0020\u0061\u006e\u0020\u0061r\u0072\u0061\u0079\u0029");};_deaeg .Append (_ceeg );_aggaf ._cggg [_ddgfg ]=struct{}{};_eagb ,_bbbd :=_aef .GetInt (_gabab .Get ("\u0043\u006f\u0075n\u0074"));if !_bbbd {return _fa .New ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064 \u0050\u0061\u0067e\u0073\u0020\u0043\u006fu\u006e\u0074\u0020\u006f\u0062\u006a\u0065\u0063\u0074\u0020\u0028\u006e\u006f\u0074\u0020\u0061\u006e\u0020\u0069\u006e\u0074\u0065\u0067\u0065\u0072\u0029");};*_eagb =*_eagb +1;_aggaf .addObject (_ceeg );_ageg :=_aggaf .addObjects (_ddgfg );if _ageg !=nil {return _ageg ;};return nil ;};
@@ -2685,7 +2699,7 @@ type EncryptionAlgorithm int ;func (_ddgad fontCommon )coreString ()string {_eab
 type PdfColorDeviceRGB [3]float64 ;
 
 // PdfWriter handles outputing PDF content.
-type PdfWriter struct{_bdbdfg *_aef .PdfIndirectObject ;_gacae *_aef .PdfIndirectObject ;_cggg map[_aef .PdfObject ]struct{};_aage []_aef .PdfObject ;_cafea map[_aef .PdfObject ]struct{};_fgada []*_aef .PdfIndirectObject ;_aabdg *PdfOutlineTreeNode ;_abebc *_aef .PdfObjectDictionary ;_bacacb []_aef .PdfObject ;_caefd *_aef .PdfIndirectObject ;_fcadd *_bc .Writer ;_dfdcd int64 ;_geac error ;_ecfag *_aef .PdfCrypt ;_ebeb *_aef .PdfObjectDictionary ;_eebbg *_aef .PdfIndirectObject ;_acddd *_aef .PdfObjectArray ;_cfebb int ;_gbcce int ;_ecfeg *bool ;_eabcf map[_aef .PdfObject ][]*_aef .PdfObjectDictionary ;_feabe *PdfAcroForm ;_ceag Optimizer ;_ffede map[int ]crossReference ;_ccdaf int64 ;ObjNumOffset int ;_bcdd bool ;_gdbde _aef .XrefTable ;_bbbefb int64 ;_bfagd int64 ;_bfeac map[_aef .PdfObject ]int64 ;_dfdcb map[_aef .PdfObject ]struct{};};
+type PdfWriter struct{_bdbdfg *_aef .PdfIndirectObject ;_gacae *_aef .PdfIndirectObject ;_cggg map[_aef .PdfObject ]struct{};_aage []_aef .PdfObject ;_cafea map[_aef .PdfObject ]struct{};_fgada []*_aef .PdfIndirectObject ;_aabdg *PdfOutlineTreeNode ;_abebc *_aef .PdfObjectDictionary ;_bacacb []_aef .PdfObject ;_caefd *_aef .PdfIndirectObject ;_fcadd *_bc .Writer ;_dfdcd int64 ;_geac error ;_ecfag *_aef .PdfCrypt ;_ebeb *_aef .PdfObjectDictionary ;_eebbg *_aef .PdfIndirectObject ;_acddd *_aef .PdfObjectArray ;_cfebb int ;_gbcce int ;_ecfeg *bool ;_eabcf map[_aef .PdfObject ][]*_aef .PdfObjectDictionary ;_feabe *PdfAcroForm ;_ceag Optimizer ;_ffede map[int ]crossReference ;_ccdaf int64 ;ObjNumOffset int ;_bcdd bool ;_gdbde _aef .XrefTable ;_bbbefb int64 ;_bfagd int64 ;_bfeac map[_aef .PdfObject ]int64 ;_dfdcb map[_aef .PdfObject ]struct{};linearized bool ;stream *writerStream ;};
 
 // GetNumComponents returns the number of color components of the colorspace device.
 // Returns 1 for a CalGray device.
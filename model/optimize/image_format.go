@@ -0,0 +1,98 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package optimize
+
+import (
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// ImageFormat specifies the compression of the images rewritten by the Image optimizer.
+type ImageFormat int
+
+const (
+	// ImageFormatJPEG rewrites images with the DCTDecode filter (default).
+	ImageFormatJPEG ImageFormat = iota
+
+	// ImageFormatJPEG2000 rewrites images with the JPXDecode filter, using the lossy
+	// compression with the quality ImageQuality.
+	ImageFormatJPEG2000
+
+	// ImageFormatJPEG2000Lossless rewrites images with the JPXDecode filter, using the
+	// lossless compression. The ImageQuality is not used.
+	ImageFormatJPEG2000Lossless
+)
+
+// enabled returns true if the optimizer rewrites images with the specified quality and format.
+func (f ImageFormat) enabled(quality int) bool {
+	return quality > 0 || f == ImageFormatJPEG2000Lossless
+}
+
+// encoder returns the encoder of the images rewritten by the optimizer.
+func (i *Image) encoder(info *imageInfo) core.StreamEncoder {
+	if i.ImageFormat == ImageFormatJPEG2000 || i.ImageFormat == ImageFormatJPEG2000Lossless {
+		encoder := core.NewJPXEncoder()
+		encoder.ColorComponents = info.ColorComponents
+		encoder.BitsPerComponent = info.BitsPerComponent
+		encoder.Width = info.Width
+		encoder.Height = info.Height
+		encoder.Quality = i.ImageQuality
+		encoder.Lossless = i.ImageFormat == ImageFormatJPEG2000Lossless
+		return encoder
+	}
+	encoder := core.NewDCTEncoder()
+	encoder.ColorComponents = info.ColorComponents
+	encoder.Quality = i.ImageQuality
+	encoder.BitsPerComponent = info.BitsPerComponent
+	encoder.Width = info.Width
+	encoder.Height = info.Height
+	return encoder
+}
+
+// setImageDict sets the entries of the dictionary of a rewritten image which may be missing
+// from the dictionary of its JPX encoded source.
+func setImageDict(dict *core.PdfObjectDictionary, info *imageInfo) {
+	if dict.Get("ColorSpace") == nil {
+		dict.Set("ColorSpace", core.MakeName(string(info.ColorSpace)))
+	}
+	dict.Set("BitsPerComponent", core.MakeInteger(int64(info.BitsPerComponent)))
+}
+
+// readJPXInfo sets the properties of the JPX encoded image `info` from its JPEG 2000 header,
+// which take precedence over the entries of the image dictionary. Returns false if the image
+// cannot be rewritten, e.g. when its soft mask is contained in the JPEG 2000 data.
+func readJPXInfo(info *imageInfo) bool {
+	filter, ok := core.GetName(info.Stream.PdfObjectDictionary.Get("Filter"))
+	if !ok || *filter != core.StreamEncodingFilterNameJPX {
+		return true
+	}
+	if smaskInData, ok := core.GetIntVal(info.Stream.PdfObjectDictionary.Get("SMaskInData")); ok && smaskInData > 0 {
+		return false
+	}
+	jpx, err := core.DecodeJPXInfo(info.Stream.Stream)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to read JPX image header: %v", err)
+		return false
+	}
+	info.Width = jpx.Width
+	info.Height = jpx.Height
+	info.BitsPerComponent = jpx.BitsPerComponent
+	if info.ColorSpace == "" {
+		switch {
+		case jpx.ColorSpace != "":
+			info.ColorSpace = jpx.ColorSpace
+		case jpx.ColorComponents == 1:
+			info.ColorSpace = "DeviceGray"
+		case jpx.ColorComponents == 3:
+			info.ColorSpace = "DeviceRGB"
+		}
+	}
+	return true
+}
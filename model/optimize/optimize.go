@@ -23,10 +23,10 @@ func (_ba *CleanFonts )Optimize (objects []_d .PdfObject )(_eaf []_d .PdfObject
 // 1. Marked content operators are removed.
 // 2. Some operands are simplified (shorter form).
 // TODO: Add more reduction methods and improving the methods for identifying unnecessary operands.
-type CleanContentstream struct{};func _adgd (_dae []_d .PdfObject )[]*imageInfo {_eaff :=_d .PdfObjectName ("\u0053u\u0062\u0074\u0079\u0070\u0065");_fab :=make (map[*_d .PdfObjectStream ]struct{});var _cfcd error ;var _agdf []*imageInfo ;for _ ,_gffd :=range _dae {_dcgf ,_adda :=_d .GetStream (_gffd );if !_adda {continue ;};if _ ,_ffd :=_fab [_dcgf ];_ffd {continue ;};_fab [_dcgf ]=struct{}{};_efg :=_dcgf .PdfObjectDictionary .Get (_eaff );_cge ,_adda :=_d .GetName (_efg );if !_adda ||string (*_cge )!="\u0049\u006d\u0061g\u0065"{continue ;};_egd :=&imageInfo {BitsPerComponent :8,Stream :_dcgf };if _egd .ColorSpace ,_cfcd =_da .DetermineColorspaceNameFromPdfObject (_dcgf .PdfObjectDictionary .Get ("\u0043\u006f\u006c\u006f\u0072\u0053\u0070\u0061\u0063\u0065"));_cfcd !=nil {_ga .Log .Error ("\u0045\u0072\u0072\u006f\u0072\u0020\u0064\u0065\u0074\u0065r\u006d\u0069\u006e\u0065\u0020\u0063\u006fl\u006f\u0072\u0020\u0073\u0070\u0061\u0063\u0065\u0020\u0025\u0073",_cfcd );continue ;};if _gaef ,_fbd :=_d .GetIntVal (_dcgf .PdfObjectDictionary .Get ("\u0042\u0069t\u0073\u0050\u0065r\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074"));_fbd {_egd .BitsPerComponent =_gaef ;};if _ceb ,_dgf :=_d .GetIntVal (_dcgf .PdfObjectDictionary .Get ("\u0057\u0069\u0064t\u0068"));_dgf {_egd .Width =_ceb ;};if _gfbe ,_ffdc :=_d .GetIntVal (_dcgf .PdfObjectDictionary .Get ("\u0048\u0065\u0069\u0067\u0068\u0074"));_ffdc {_egd .Height =_gfbe ;};switch _egd .ColorSpace {case "\u0044e\u0076\u0069\u0063\u0065\u0052\u0047B":_egd .ColorComponents =3;case "\u0044\u0065\u0076\u0069\u0063\u0065\u0047\u0072\u0061\u0079":_egd .ColorComponents =1;default:_ga .Log .Warning ("\u004f\u0070\u0074\u0069\u006d\u0069\u007a\u0061t\u0069\u006f\u006e i\u0073\u0020\u006e\u006f\u0074\u0020s\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0063\u006fl\u006f\u0072\u0020\u0073\u0070\u0061\u0063\u0065 \u0025\u0073",_egd .ColorSpace );continue ;};_agdf =append (_agdf ,_egd );};return _agdf ;};func _fafc (_dagb []_d .PdfObject ){for _addc ,_dga :=range _dagb {switch _ddb :=_dga .(type ){case *_d .PdfIndirectObject :_ddb .ObjectNumber =int64 (_addc +1);_ddb .GenerationNumber =0;case *_d .PdfObjectStream :_ddb .ObjectNumber =int64 (_addc +1);_ddb .GenerationNumber =0;case *_d .PdfObjectStreams :_ddb .ObjectNumber =int64 (_addc +1);_ddb .GenerationNumber =0;};};};func _ee (_cfa *_df .ContentStreamOperations )*_df .ContentStreamOperations {if _cfa ==nil {return nil ;};_fa :=_df .ContentStreamOperations {};for _ ,_fd :=range *_cfa {switch _fd .Operand {case "\u0042\u0044\u0043","\u0042\u004d\u0043","\u0045\u004d\u0043":continue ;case "\u0054\u006d":if len (_fd .Params )==6{if _bg ,_gge :=_d .GetNumbersAsFloat (_fd .Params );_gge ==nil {if _bg [0]==1&&_bg [1]==0&&_bg [2]==0&&_bg [3]==1{_fd =&_df .ContentStreamOperation {Params :[]_d .PdfObject {_fd .Params [4],_fd .Params [5]},Operand :"\u0054\u0064"};};};};};_fa =append (_fa ,_fd );};return &_fa ;};func _bb (_ge *_d .PdfObjectStream )error {_acc ,_fc :=_d .DecodeStream (_ge );if _fc !=nil {return _fc ;};_ae :=_df .NewContentStreamParser (string (_acc ));_dd ,_fc :=_ae .Parse ();if _fc !=nil {return _fc ;};_dd =_ee (_dd );_bbe :=_dd .Bytes ();if len (_bbe )>=len (_acc ){return nil ;};_gff ,_fc :=_d .MakeStream (_dd .Bytes (),_d .NewFlateEncoder ());if _fc !=nil {return _fc ;};_ge .Stream =_gff .Stream ;_ge .Merge (_gff .PdfObjectDictionary );return nil ;};
+type CleanContentstream struct{};func _adgd (_dae []_d .PdfObject )[]*imageInfo {_eaff :=_d .PdfObjectName ("\u0053u\u0062\u0074\u0079\u0070\u0065");_fab :=make (map[*_d .PdfObjectStream ]struct{});var _cfcd error ;var _agdf []*imageInfo ;for _ ,_gffd :=range _dae {_dcgf ,_adda :=_d .GetStream (_gffd );if !_adda {continue ;};if _ ,_ffd :=_fab [_dcgf ];_ffd {continue ;};_fab [_dcgf ]=struct{}{};_efg :=_dcgf .PdfObjectDictionary .Get (_eaff );_cge ,_adda :=_d .GetName (_efg );if !_adda ||string (*_cge )!="\u0049\u006d\u0061g\u0065"{continue ;};_egd :=&imageInfo {BitsPerComponent :8,Stream :_dcgf };if _egd .ColorSpace ,_cfcd =_da .DetermineColorspaceNameFromPdfObject (_dcgf .PdfObjectDictionary .Get ("\u0043\u006f\u006c\u006f\u0072\u0053\u0070\u0061\u0063\u0065"));_cfcd !=nil {_ga .Log .Error ("\u0045\u0072\u0072\u006f\u0072\u0020\u0064\u0065\u0074\u0065r\u006d\u0069\u006e\u0065\u0020\u0063\u006fl\u006f\u0072\u0020\u0073\u0070\u0061\u0063\u0065\u0020\u0025\u0073",_cfcd );continue ;};if _gaef ,_fbd :=_d .GetIntVal (_dcgf .PdfObjectDictionary .Get ("\u0042\u0069t\u0073\u0050\u0065r\u0043\u006f\u006d\u0070\u006f\u006e\u0065\u006e\u0074"));_fbd {_egd .BitsPerComponent =_gaef ;};if _ceb ,_dgf :=_d .GetIntVal (_dcgf .PdfObjectDictionary .Get ("\u0057\u0069\u0064t\u0068"));_dgf {_egd .Width =_ceb ;};if _gfbe ,_ffdc :=_d .GetIntVal (_dcgf .PdfObjectDictionary .Get ("\u0048\u0065\u0069\u0067\u0068\u0074"));_ffdc {_egd .Height =_gfbe ;};if !readJPXInfo (_egd ){continue ;};switch _egd .ColorSpace {case "\u0044e\u0076\u0069\u0063\u0065\u0052\u0047B":_egd .ColorComponents =3;case "\u0044\u0065\u0076\u0069\u0063\u0065\u0047\u0072\u0061\u0079":_egd .ColorComponents =1;default:_ga .Log .Warning ("\u004f\u0070\u0074\u0069\u006d\u0069\u007a\u0061t\u0069\u006f\u006e i\u0073\u0020\u006e\u006f\u0074\u0020s\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0063\u006fl\u006f\u0072\u0020\u0073\u0070\u0061\u0063\u0065 \u0025\u0073",_egd .ColorSpace );continue ;};_agdf =append (_agdf ,_egd );};return _agdf ;};func _fafc (_dagb []_d .PdfObject ){for _addc ,_dga :=range _dagb {switch _ddb :=_dga .(type ){case *_d .PdfIndirectObject :_ddb .ObjectNumber =int64 (_addc +1);_ddb .GenerationNumber =0;case *_d .PdfObjectStream :_ddb .ObjectNumber =int64 (_addc +1);_ddb .GenerationNumber =0;case *_d .PdfObjectStreams :_ddb .ObjectNumber =int64 (_addc +1);_ddb .GenerationNumber =0;};};};func _ee (_cfa *_df .ContentStreamOperations )*_df .ContentStreamOperations {if _cfa ==nil {return nil ;};_fa :=_df .ContentStreamOperations {};for _ ,_fd :=range *_cfa {switch _fd .Operand {case "\u0042\u0044\u0043","\u0042\u004d\u0043","\u0045\u004d\u0043":continue ;case "\u0054\u006d":if len (_fd .Params )==6{if _bg ,_gge :=_d .GetNumbersAsFloat (_fd .Params );_gge ==nil {if _bg [0]==1&&_bg [1]==0&&_bg [2]==0&&_bg [3]==1{_fd =&_df .ContentStreamOperation {Params :[]_d .PdfObject {_fd .Params [4],_fd .Params [5]},Operand :"\u0054\u0064"};};};};};_fa =append (_fa ,_fd );};return &_fa ;};func _bb (_ge *_d .PdfObjectStream )error {_acc ,_fc :=_d .DecodeStream (_ge );if _fc !=nil {return _fc ;};_ae :=_df .NewContentStreamParser (string (_acc ));_dd ,_fc :=_ae .Parse ();if _fc !=nil {return _fc ;};_dd =_ee (_dd );_bbe :=_dd .Bytes ();if len (_bbe )>=len (_acc ){return nil ;};_gff ,_fc :=_d .MakeStream (_dd .Bytes (),_d .NewFlateEncoder ());if _fc !=nil {return _fc ;};_ge .Stream =_gff .Stream ;_ge .Merge (_gff .PdfObjectDictionary );return nil ;};
 
 // Optimize optimizes PDF objects to decrease PDF size.
-func (_cgee *Image )Optimize (objects []_d .PdfObject )(_dbaa []_d .PdfObject ,_bbd error ){if _cgee .ImageQuality <=0{return objects ,nil ;};_bbg :=_adgd (objects );if len (_bbg )==0{return objects ,nil ;};_agdb :=make (map[_d .PdfObject ]_d .PdfObject );_fed :=make (map[_d .PdfObject ]struct{});for _ ,_cegc :=range _bbg {_gda :=_cegc .Stream .PdfObjectDictionary .Get (_d .PdfObjectName ("\u0053\u004d\u0061s\u006b"));_fed [_gda ]=struct{}{};};for _bbec ,_deeb :=range _bbg {_dcf :=_deeb .Stream ;if _ ,_ecf :=_fed [_dcf ];_ecf {continue ;};_fec ,_dabb :=_d .NewEncoderFromStream (_dcf );if _dabb !=nil {_ga .Log .Warning ("\u0045\u0072\u0072\u006f\u0072 \u0067\u0065\u0074\u0020\u0065\u006e\u0063\u006f\u0064\u0065\u0072\u0020\u0066o\u0072\u0020\u0074\u0068\u0065\u0020\u0069\u006d\u0061\u0067\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0025\u0073");continue ;};_ggecb ,_dabb :=_fec .DecodeStream (_dcf );if _dabb !=nil {_ga .Log .Warning ("\u0045\u0072\u0072\u006f\u0072\u0020\u0064\u0065\u0063\u006f\u0064\u0065\u0020\u0074\u0068e\u0020i\u006d\u0061\u0067\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0025\u0073");continue ;};_fdc :=_d .NewDCTEncoder ();_fdc .ColorComponents =_deeb .ColorComponents ;_fdc .Quality =_cgee .ImageQuality ;_fdc .BitsPerComponent =_deeb .BitsPerComponent ;_fdc .Width =_deeb .Width ;_fdc .Height =_deeb .Height ;_dfg ,_dabb :=_fdc .EncodeBytes (_ggecb );if _dabb !=nil {_ga .Log .Debug ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_dabb );return nil ,_dabb ;};var _egb _d .StreamEncoder ;_egb =_fdc ;{_egfg :=_d .NewFlateEncoder ();_daa :=_d .NewMultiEncoder ();_daa .AddEncoder (_egfg );_daa .AddEncoder (_fdc );_abeb ,_edf :=_daa .EncodeBytes (_ggecb );if _edf !=nil {return nil ,_edf ;};if len (_abeb )< len (_dfg ){_ga .Log .Debug ("\u004d\u0075\u006c\u0074\u0069\u0020\u0065\u006e\u0063\u0020\u0069\u006d\u0070\u0072\u006f\u0076\u0065\u0073\u003a\u0020\u0025\u0064\u0020\u0074o\u0020\u0025\u0064\u0020\u0028o\u0072\u0069g\u0020\u0025\u0064\u0029",len (_dfg ),len (_abeb ),len (_dcf .Stream ));_dfg =_abeb ;_egb =_daa ;};};_ebc :=len (_dcf .Stream );if _ebc < len (_dfg ){continue ;};_gfa :=&_d .PdfObjectStream {Stream :_dfg };_gfa .PdfObjectReference =_dcf .PdfObjectReference ;_gfa .PdfObjectDictionary =_d .MakeDict ();_gfa .Merge (_dcf .PdfObjectDictionary );_gfa .Merge (_egb .MakeStreamDict ());_gfa .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",_d .MakeInteger (int64 (len (_dfg ))));_agdb [_dcf ]=_gfa ;_bbg [_bbec ].Stream =_gfa ;};_dbaa =make ([]_d .PdfObject ,len (objects ));copy (_dbaa ,objects );_ccdb (_dbaa ,_agdb );return _dbaa ,nil ;};
+func (_cgee *Image )Optimize (objects []_d .PdfObject )(_dbaa []_d .PdfObject ,_bbd error ){if !_cgee .ImageFormat .enabled (_cgee .ImageQuality ){return objects ,nil ;};_bbg :=_adgd (objects );if len (_bbg )==0{return objects ,nil ;};_agdb :=make (map[_d .PdfObject ]_d .PdfObject );_fed :=make (map[_d .PdfObject ]struct{});for _ ,_cegc :=range _bbg {_gda :=_cegc .Stream .PdfObjectDictionary .Get (_d .PdfObjectName ("\u0053\u004d\u0061s\u006b"));_fed [_gda ]=struct{}{};};for _bbec ,_deeb :=range _bbg {_dcf :=_deeb .Stream ;if _ ,_ecf :=_fed [_dcf ];_ecf {continue ;};_fec ,_dabb :=_d .NewEncoderFromStream (_dcf );if _dabb !=nil {_ga .Log .Warning ("\u0045\u0072\u0072\u006f\u0072 \u0067\u0065\u0074\u0020\u0065\u006e\u0063\u006f\u0064\u0065\u0072\u0020\u0066o\u0072\u0020\u0074\u0068\u0065\u0020\u0069\u006d\u0061\u0067\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0025\u0073");continue ;};_ggecb ,_dabb :=_fec .DecodeStream (_dcf );if _dabb !=nil {_ga .Log .Warning ("\u0045\u0072\u0072\u006f\u0072\u0020\u0064\u0065\u0063\u006f\u0064\u0065\u0020\u0074\u0068e\u0020i\u006d\u0061\u0067\u0065\u0020\u0073\u0074\u0072\u0065\u0061\u006d\u0020\u0025\u0073");continue ;};_fdc :=_cgee .encoder (_deeb );_dfg ,_dabb :=_fdc .EncodeBytes (_ggecb );if _dabb !=nil {_ga .Log .Debug ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_dabb );return nil ,_dabb ;};var _egb _d .StreamEncoder ;_egb =_fdc ;if _cgee .ImageFormat ==ImageFormatJPEG {_egfg :=_d .NewFlateEncoder ();_daa :=_d .NewMultiEncoder ();_daa .AddEncoder (_egfg );_daa .AddEncoder (_fdc );_abeb ,_edf :=_daa .EncodeBytes (_ggecb );if _edf !=nil {return nil ,_edf ;};if len (_abeb )< len (_dfg ){_ga .Log .Debug ("\u004d\u0075\u006c\u0074\u0069\u0020\u0065\u006e\u0063\u0020\u0069\u006d\u0070\u0072\u006f\u0076\u0065\u0073\u003a\u0020\u0025\u0064\u0020\u0074o\u0020\u0025\u0064\u0020\u0028o\u0072\u0069g\u0020\u0025\u0064\u0029",len (_dfg ),len (_abeb ),len (_dcf .Stream ));_dfg =_abeb ;_egb =_daa ;};};_ebc :=len (_dcf .Stream );if _ebc < len (_dfg ){continue ;};_gfa :=&_d .PdfObjectStream {Stream :_dfg };_gfa .PdfObjectReference =_dcf .PdfObjectReference ;_gfa .PdfObjectDictionary =_d .MakeDict ();_gfa .Merge (_dcf .PdfObjectDictionary );_gfa .Remove ("\u0044\u0065\u0063\u006f\u0064\u0065\u0050\u0061\u0072\u006d\u0073");_gfa .Merge (_egb .MakeStreamDict ());setImageDict (_gfa .PdfObjectDictionary ,_deeb );_gfa .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",_d .MakeInteger (int64 (len (_dfg ))));_agdb [_dcf ]=_gfa ;_bbg [_bbec ].Stream =_gfa ;};_dbaa =make ([]_d .PdfObject ,len (objects ));copy (_dbaa ,objects );_ccdb (_dbaa ,_agdb );return _dbaa ,nil ;};
 
 // Optimize optimizes PDF objects to decrease PDF size.
 func (_acgd *CompressStreams )Optimize (objects []_d .PdfObject )(_bad []_d .PdfObject ,_fbb error ){_bad =make ([]_d .PdfObject ,len (objects ));copy (_bad ,objects );for _ ,_fgb :=range objects {_fef ,_badb :=_d .GetStream (_fgb );if !_badb {continue ;};if _eb :=_fef .Get ("\u0046\u0069\u006c\u0074\u0065\u0072");_eb !=nil {if _ ,_bdf :=_d .GetName (_eb );_bdf {continue ;};if _ded ,_dde :=_d .GetArray (_eb );_dde &&_ded .Len ()> 0{continue ;};};_gce :=_d .NewFlateEncoder ();var _bdg []byte ;_bdg ,_fbb =_gce .EncodeBytes (_fef .Stream );if _fbb !=nil {return _bad ,_fbb ;};_cbf :=_gce .MakeStreamDict ();if len (_bdg )+len (_cbf .WriteString ())< len (_fef .Stream ){_fef .Stream =_bdg ;_fef .PdfObjectDictionary .Merge (_cbf );_fef .PdfObjectDictionary .Set ("\u004c\u0065\u006e\u0067\u0074\u0068",_d .MakeInteger (int64 (len (_fef .Stream ))));};};return _bad ,nil ;};func _ccdb (_ceacd []_d .PdfObject ,_adc map[_d .PdfObject ]_d .PdfObject ){if len (_adc )==0{return ;};for _gcfg ,_bcgc :=range _ceacd {if _gfc ,_acab :=_adc [_bcgc ];_acab {_ceacd [_gcfg ]=_gfc ;continue ;};_adc [_bcgc ]=_bcgc ;switch _bcfe :=_bcgc .(type ){case *_d .PdfObjectArray :_aec :=make ([]_d .PdfObject ,_bcfe .Len ());copy (_aec ,_bcfe .Elements ());_ccdb (_aec ,_adc );for _gad ,_dcda :=range _aec {_bcfe .Set (_gad ,_dcda );};case *_d .PdfObjectStreams :_ccdb (_bcfe .Elements (),_adc );case *_d .PdfObjectStream :_baead :=[]_d .PdfObject {_bcfe .PdfObjectDictionary };_ccdb (_baead ,_adc );_bcfe .PdfObjectDictionary =_baead [0].(*_d .PdfObjectDictionary );case *_d .PdfObjectDictionary :_edb :=_bcfe .Keys ();_gdfd :=make ([]_d .PdfObject ,len (_edb ));for _bed ,_dca :=range _edb {_gdfd [_bed ]=_bcfe .Get (_dca );};_ccdb (_gdfd ,_adc );for _cdcb ,_bfdg :=range _edb {_bcfe .Set (_bfdg ,_gdfd [_cdcb ]);};case *_d .PdfIndirectObject :_bfgdc :=[]_d .PdfObject {_bcfe .PdfObject };_ccdb (_bfgdc ,_adc );_bcfe .PdfObject =_bfgdc [0];};};};func _acdd (_fb _d .PdfObject )[]content {if _fb ==nil {return nil ;};_gec ,_gagg :=_d .GetArray (_fb );if !_gagg {_ga .Log .Debug ("\u0041\u006e\u006e\u006fts\u0020\u006e\u006f\u0074\u0020\u0061\u006e\u0020\u0061\u0072\u0072\u0061\u0079");return nil ;};var _dcd []content ;for _ ,_aeff :=range _gec .Elements (){_cfc ,_eec :=_d .GetDict (_aeff );if !_eec {_ga .Log .Debug ("I\u0067\u006e\u006f\u0072\u0069\u006eg\u0020\u006e\u006f\u006e\u002d\u0064i\u0063\u0074\u0020\u0065\u006c\u0065\u006de\u006e\u0074\u0020\u0069\u006e\u0020\u0041\u006e\u006e\u006ft\u0073");continue ;};_ecc ,_eec :=_d .GetDict (_cfc .Get ("\u0041\u0050"));if !_eec {_ga .Log .Debug ("\u004e\u006f\u0020\u0041P \u0065\u006e\u0074\u0072\u0079\u0020\u002d\u0020\u0073\u006b\u0069\u0070\u0070\u0069n\u0067");continue ;};_bbab :=_d .TraceToDirectObject (_ecc .Get ("\u004e"));if _bbab ==nil {_ga .Log .Debug ("N\u006f\u0020\u004e\u0020en\u0074r\u0079\u0020\u002d\u0020\u0073k\u0069\u0070\u0070\u0069\u006e\u0067");continue ;};var _gggabg *_d .PdfObjectStream ;switch _ffb :=_bbab .(type ){case *_d .PdfObjectDictionary :_dba ,_bbf :=_d .GetName (_cfc .Get ("\u0041\u0053"));if !_bbf {_ga .Log .Debug ("\u004e\u006f\u0020\u0041S \u0065\u006e\u0074\u0072\u0079\u0020\u002d\u0020\u0073\u006b\u0069\u0070\u0070\u0069n\u0067");continue ;};_gggabg ,_bbf =_d .GetStream (_ffb .Get (*_dba ));if !_bbf {_ga .Log .Debug ("\u0046o\u0072\u006d\u0020\u006eo\u0074\u0020\u0066\u006f\u0075n\u0064 \u002d \u0073\u006b\u0069\u0070\u0070\u0069\u006eg");continue ;};case *_d .PdfObjectStream :_gggabg =_ffb ;};if _gggabg ==nil {_ga .Log .Debug ("\u0046\u006f\u0072m\u0020\u006e\u006f\u0074 \u0066\u006f\u0075\u006e\u0064\u0020\u0028n\u0069\u006c\u0029\u0020\u002d\u0020\u0073\u006b\u0069\u0070\u0070\u0069\u006e\u0067");continue ;};_gcd ,_gagga :=_da .NewXObjectFormFromStream (_gggabg );if _gagga !=nil {_ga .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u0020l\u006f\u0061\u0064\u0069\u006e\u0067\u0020\u0066\u006f\u0072\u006d\u003a\u0020%\u0076\u0020\u002d\u0020\u0069\u0067\u006eo\u0072\u0069\u006e\u0067",_gagga );continue ;};_aeeg ,_gagga :=_gcd .GetContentStream ();if _gagga !=nil {_ga .Log .Debug ("E\u0072\u0072\u006f\u0072\u0020\u0064e\u0063\u006f\u0064\u0069\u006e\u0067\u0020\u0063\u006fn\u0074\u0065\u006et\u0073:\u0020\u0025\u0076",_gagga );continue ;};_dcd =append (_dcd ,content {_cce :string (_aeeg ),_baa :_gcd .Resources });};return _dcd ;};
@@ -59,7 +59,7 @@ func (_cfeg *ImagePPI )Optimize (objects []_d .PdfObject )(_fecb []_d .PdfObject
 type Chain struct{_bf []_da .Optimizer };
 
 // New creates a optimizers chain from options.
-func New (options Options )*Chain {_fcg :=new (Chain );if options .CleanFonts ||options .SubsetFonts {_fcg .Append (&CleanFonts {Subset :options .SubsetFonts });};if options .CleanContentstream {_fcg .Append (new (CleanContentstream ));};if options .ImageUpperPPI > 0{_fbf :=new (ImagePPI );_fbf .ImageUpperPPI =options .ImageUpperPPI ;_fcg .Append (_fbf );};if options .ImageQuality > 0{_bac :=new (Image );_bac .ImageQuality =options .ImageQuality ;_fcg .Append (_bac );};if options .CombineDuplicateDirectObjects {_fcg .Append (new (CombineDuplicateDirectObjects ));};if options .CombineDuplicateStreams {_fcg .Append (new (CombineDuplicateStreams ));};if options .CombineIdenticalIndirectObjects {_fcg .Append (new (CombineIdenticalIndirectObjects ));};if options .UseObjectStreams {_fcg .Append (new (ObjectStreams ));};if options .CompressStreams {_fcg .Append (new (CompressStreams ));};return _fcg ;};func _faag (_aad _d .PdfObject )(_ebb string ,_abebg []_d .PdfObject ){var _dgec _cfd .Buffer ;switch _dgb :=_aad .(type ){case *_d .PdfIndirectObject :_abebg =append (_abebg ,_dgb );_aad =_dgb .PdfObject ;};switch _gdab :=_aad .(type ){case *_d .PdfObjectStream :if _ecga ,_efcc :=_d .DecodeStream (_gdab );_efcc ==nil {_dgec .Write (_ecga );_abebg =append (_abebg ,_gdab );};case *_d .PdfObjectArray :for _ ,_cdcg :=range _gdab .Elements (){switch _eacgc :=_cdcg .(type ){case *_d .PdfObjectStream :if _eee ,_gbf :=_d .DecodeStream (_eacgc );_gbf ==nil {_dgec .Write (_eee );_abebg =append (_abebg ,_eacgc );};};};};return _dgec .String (),_abebg ;};
+func New (options Options )*Chain {_fcg :=new (Chain );if options .CleanFonts ||options .SubsetFonts {_fcg .Append (&CleanFonts {Subset :options .SubsetFonts });};if options .CleanContentstream {_fcg .Append (new (CleanContentstream ));};if options .ImageUpperPPI > 0{_fbf :=new (ImagePPI );_fbf .ImageUpperPPI =options .ImageUpperPPI ;_fcg .Append (_fbf );};if options .ImageFormat .enabled (options .ImageQuality ){_bac :=new (Image );_bac .ImageQuality =options .ImageQuality ;_bac .ImageFormat =options .ImageFormat ;_fcg .Append (_bac );};if options .CombineDuplicateDirectObjects {_fcg .Append (new (CombineDuplicateDirectObjects ));};if options .CombineDuplicateStreams {_fcg .Append (new (CombineDuplicateStreams ));};if options .CombineIdenticalIndirectObjects {_fcg .Append (new (CombineIdenticalIndirectObjects ));};if options .UseObjectStreams {_fcg .Append (new (ObjectStreams ));};if options .CompressStreams {_fcg .Append (new (CompressStreams ));};return _fcg ;};func _faag (_aad _d .PdfObject )(_ebb string ,_abebg []_d .PdfObject ){var _dgec _cfd .Buffer ;switch _dgb :=_aad .(type ){case *_d .PdfIndirectObject :_abebg =append (_abebg ,_dgb );_aad =_dgb .PdfObject ;};switch _gdab :=_aad .(type ){case *_d .PdfObjectStream :if _ecga ,_efcc :=_d .DecodeStream (_gdab );_efcc ==nil {_dgec .Write (_ecga );_abebg =append (_abebg ,_gdab );};case *_d .PdfObjectArray :for _ ,_cdcg :=range _gdab .Elements (){switch _eacgc :=_cdcg .(type ){case *_d .PdfObjectStream :if _eee ,_gbf :=_d .DecodeStream (_eacgc );_gbf ==nil {_dgec .Write (_eee );_abebg =append (_abebg ,_eacgc );};};};};return _dgec .String (),_abebg ;};
 
 // CombineIdenticalIndirectObjects combines identical indirect objects.
 // It implements interface model.Optimizer.
@@ -70,7 +70,7 @@ type CombineIdenticalIndirectObjects struct{};
 type CombineDuplicateDirectObjects struct{};
 
 // Options describes PDF optimization parameters.
-type Options struct{CombineDuplicateStreams bool ;CombineDuplicateDirectObjects bool ;ImageUpperPPI float64 ;ImageQuality int ;UseObjectStreams bool ;CombineIdenticalIndirectObjects bool ;CompressStreams bool ;CleanFonts bool ;SubsetFonts bool ;CleanContentstream bool ;};type content struct{_cce string ;_baa *_da .PdfPageResources ;};
+type Options struct{CombineDuplicateStreams bool ;CombineDuplicateDirectObjects bool ;ImageUpperPPI float64 ;ImageQuality int ;ImageFormat ImageFormat ;UseObjectStreams bool ;CombineIdenticalIndirectObjects bool ;CompressStreams bool ;CleanFonts bool ;SubsetFonts bool ;CleanContentstream bool ;};type content struct{_cce string ;_baa *_da .PdfPageResources ;};
 
 // CombineDuplicateStreams combines duplicated streams by its data hash.
 // It implements interface model.Optimizer.
@@ -93,7 +93,8 @@ func (_cab *CombineDuplicateDirectObjects )Optimize (objects []_d .PdfObject )(_
 // It implements interface model.Optimizer.
 type CompressStreams struct{};
 
-// Image optimizes images by rewrite images into JPEG format with quality equals to ImageQuality.
+// Image optimizes images by rewrite images into JPEG format with quality equals to ImageQuality,
+// or into the JPEG 2000 format specified by ImageFormat.
 // TODO(a5i): Add support for inline images.
 // It implements interface model.Optimizer.
-type Image struct{ImageQuality int ;};func _ffc (_eae *_d .PdfObjectStream ,_bce []rune ,_bda []_b .GlyphIndex )error {_eae ,_aee :=_d .GetStream (_eae );if !_aee {_ga .Log .Debug ("\u0045\u006d\u0062\u0065\u0064\u0064\u0065\u0064\u0020\u0066\u006f\u006e\u0074\u0020\u006f\u0062\u006a\u0065c\u0074\u0020\u006e\u006f\u0074\u0020\u0066o\u0075\u006e\u0064\u0020\u002d\u002d\u0020\u0041\u0042\u004f\u0052T\u0020\u0073\u0075\u0062\u0073\u0065\u0074\u0074\u0069\u006e\u0067");return _gf .New ("\u0066\u006f\u006e\u0074fi\u006c\u0065\u0032\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};_edgc ,_fda :=_d .DecodeStream (_eae );if _fda !=nil {_ga .Log .Debug ("\u0044\u0065c\u006f\u0064\u0065 \u0065\u0072\u0072\u006f\u0072\u003a\u0020\u0025\u0076",_fda );return _fda ;};_cdg ,_fda :=_b .Parse (_cfd .NewReader (_edgc ));if _fda !=nil {_ga .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u0020\u0070\u0061\u0072\u0073\u0069n\u0067\u0020\u0025\u0064\u0020\u0062\u0079\u0074\u0065\u0020f\u006f\u006e\u0074",len (_eae .Stream ));return _fda ;};_afd :=_bda ;if len (_bce )> 0{_dbd :=_cdg .LookupRunes (_bce );_afd =append (_afd ,_dbd ...);};_cdg ,_fda =_cdg .SubsetKeepIndices (_afd );if _fda !=nil {_ga .Log .Debug ("\u0045R\u0052\u004f\u0052\u0020s\u0075\u0062\u0073\u0065\u0074t\u0069n\u0067 \u0066\u006f\u006e\u0074\u003a\u0020\u0025v",_fda );return _fda ;};var _ada _cfd .Buffer ;_fda =_cdg .Write (&_ada );if _fda !=nil {_ga .Log .Debug ("\u0045\u0052\u0052\u004fR \u0057\u0072\u0069\u0074\u0069\u006e\u0067\u0020\u0066\u006f\u006e\u0074\u003a\u0020%\u0076",_fda );return _fda ;};if _ada .Len ()> len (_edgc ){_ga .Log .Debug ("\u0052\u0065-\u0077\u0072\u0069\u0074\u0074\u0065\u006e\u0020\u0066\u006f\u006e\u0074\u0020\u0069\u0073\u0020\u006c\u0061\u0072\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u006f\u0072\u0069\u0067\u0069\u006e\u0061\u006c\u0020\u002d\u0020\u0073\u006b\u0069\u0070");return nil ;};_cbb ,_fda :=_d .MakeStream (_ada .Bytes (),_d .NewFlateEncoder ());if _fda !=nil {_ga .Log .Debug ("\u0045\u0052\u0052\u004fR \u0057\u0072\u0069\u0074\u0069\u006e\u0067\u0020\u0066\u006f\u006e\u0074\u003a\u0020%\u0076",_fda );return _fda ;};*_eae =*_cbb ;_eae .Set ("\u004ce\u006e\u0067\u0074\u0068\u0031",_d .MakeInteger (int64 (_ada .Len ())));return nil ;};func _cfgd (_daf *_d .PdfObjectStream ,_cfce float64 )error {_dbf ,_cdc :=_da .NewXObjectImageFromStream (_daf );if _cdc !=nil {return _cdc ;};_egc ,_cdc :=_dbf .ToImage ();if _cdc !=nil {return _cdc ;};_fagc ,_cdc :=_egc .ToGoImage ();if _cdc !=nil {return _cdc ;};_gafb :=int (_cc .RoundToEven (float64 (_egc .Width )*_cfce ));_abda :=int (_cc .RoundToEven (float64 (_egc .Height )*_cfce ));_ffdce :=_ca .Rect (0,0,_gafb ,_abda );var _afdc _f .Image ;var _dge func (_ca .Image )(*_da .Image ,error );switch _dbf .ColorSpace .String (){case "\u0044e\u0076\u0069\u0063\u0065\u0052\u0047B":_afdc =_ca .NewRGBA (_ffdce );_dge =_da .ImageHandling .NewImageFromGoImage ;case "\u0044\u0065\u0076\u0069\u0063\u0065\u0047\u0072\u0061\u0079":_afdc =_ca .NewGray (_ffdce );_dge =_da .ImageHandling .NewGrayImageFromGoImage ;default:return _a .Errorf ("\u006f\u0070\u0074\u0069\u006d\u0069\u007a\u0061t\u0069\u006f\u006e i\u0073\u0020\u006e\u006f\u0074\u0020s\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0063\u006fl\u006f\u0072\u0020\u0073\u0070\u0061\u0063\u0065 \u0025\u0073",_dbf .ColorSpace .String ());};_f .CatmullRom .Scale (_afdc ,_afdc .Bounds (),_fagc ,_fagc .Bounds (),_f .Over ,&_f .Options {});if _egc ,_cdc =_dge (_afdc );_cdc !=nil {return _cdc ;};_gcda :=_d .MakeDict ();_gcda .Set ("\u0051u\u0061\u006c\u0069\u0074\u0079",_d .MakeInteger (100));_gcda .Set ("\u0050r\u0065\u0064\u0069\u0063\u0074\u006fr",_d .MakeInteger (1));_dbf .Filter .UpdateParams (_gcda );if _cdc =_dbf .SetImage (_egc ,nil );_cdc !=nil {return _cdc ;};_dbf .ToPdfObject ();return nil ;};
\ No newline at end of file
+type Image struct{ImageQuality int ;ImageFormat ImageFormat ;};func _ffc (_eae *_d .PdfObjectStream ,_bce []rune ,_bda []_b .GlyphIndex )error {_eae ,_aee :=_d .GetStream (_eae );if !_aee {_ga .Log .Debug ("\u0045\u006d\u0062\u0065\u0064\u0064\u0065\u0064\u0020\u0066\u006f\u006e\u0074\u0020\u006f\u0062\u006a\u0065c\u0074\u0020\u006e\u006f\u0074\u0020\u0066o\u0075\u006e\u0064\u0020\u002d\u002d\u0020\u0041\u0042\u004f\u0052T\u0020\u0073\u0075\u0062\u0073\u0065\u0074\u0074\u0069\u006e\u0067");return _gf .New ("\u0066\u006f\u006e\u0074fi\u006c\u0065\u0032\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064");};_edgc ,_fda :=_d .DecodeStream (_eae );if _fda !=nil {_ga .Log .Debug ("\u0044\u0065c\u006f\u0064\u0065 \u0065\u0072\u0072\u006f\u0072\u003a\u0020\u0025\u0076",_fda );return _fda ;};_cdg ,_fda :=_b .Parse (_cfd .NewReader (_edgc ));if _fda !=nil {_ga .Log .Debug ("\u0045\u0072\u0072\u006f\u0072\u0020\u0070\u0061\u0072\u0073\u0069n\u0067\u0020\u0025\u0064\u0020\u0062\u0079\u0074\u0065\u0020f\u006f\u006e\u0074",len (_eae .Stream ));return _fda ;};_afd :=_bda ;if len (_bce )> 0{_dbd :=_cdg .LookupRunes (_bce );_afd =append (_afd ,_dbd ...);};_cdg ,_fda =_cdg .SubsetKeepIndices (_afd );if _fda !=nil {_ga .Log .Debug ("\u0045R\u0052\u004f\u0052\u0020s\u0075\u0062\u0073\u0065\u0074t\u0069n\u0067 \u0066\u006f\u006e\u0074\u003a\u0020\u0025v",_fda );return _fda ;};var _ada _cfd .Buffer ;_fda =_cdg .Write (&_ada );if _fda !=nil {_ga .Log .Debug ("\u0045\u0052\u0052\u004fR \u0057\u0072\u0069\u0074\u0069\u006e\u0067\u0020\u0066\u006f\u006e\u0074\u003a\u0020%\u0076",_fda );return _fda ;};if _ada .Len ()> len (_edgc ){_ga .Log .Debug ("\u0052\u0065-\u0077\u0072\u0069\u0074\u0074\u0065\u006e\u0020\u0066\u006f\u006e\u0074\u0020\u0069\u0073\u0020\u006c\u0061\u0072\u0067\u0065\u0072\u0020\u0074\u0068\u0061\u006e\u0020\u006f\u0072\u0069\u0067\u0069\u006e\u0061\u006c\u0020\u002d\u0020\u0073\u006b\u0069\u0070");return nil ;};_cbb ,_fda :=_d .MakeStream (_ada .Bytes (),_d .NewFlateEncoder ());if _fda !=nil {_ga .Log .Debug ("\u0045\u0052\u0052\u004fR \u0057\u0072\u0069\u0074\u0069\u006e\u0067\u0020\u0066\u006f\u006e\u0074\u003a\u0020%\u0076",_fda );return _fda ;};*_eae =*_cbb ;_eae .Set ("\u004ce\u006e\u0067\u0074\u0068\u0031",_d .MakeInteger (int64 (_ada .Len ())));return nil ;};func _cfgd (_daf *_d .PdfObjectStream ,_cfce float64 )error {_dbf ,_cdc :=_da .NewXObjectImageFromStream (_daf );if _cdc !=nil {return _cdc ;};_egc ,_cdc :=_dbf .ToImage ();if _cdc !=nil {return _cdc ;};_fagc ,_cdc :=_egc .ToGoImage ();if _cdc !=nil {return _cdc ;};_gafb :=int (_cc .RoundToEven (float64 (_egc .Width )*_cfce ));_abda :=int (_cc .RoundToEven (float64 (_egc .Height )*_cfce ));_ffdce :=_ca .Rect (0,0,_gafb ,_abda );var _afdc _f .Image ;var _dge func (_ca .Image )(*_da .Image ,error );switch _dbf .ColorSpace .String (){case "\u0044e\u0076\u0069\u0063\u0065\u0052\u0047B":_afdc =_ca .NewRGBA (_ffdce );_dge =_da .ImageHandling .NewImageFromGoImage ;case "\u0044\u0065\u0076\u0069\u0063\u0065\u0047\u0072\u0061\u0079":_afdc =_ca .NewGray (_ffdce );_dge =_da .ImageHandling .NewGrayImageFromGoImage ;default:return _a .Errorf ("\u006f\u0070\u0074\u0069\u006d\u0069\u007a\u0061t\u0069\u006f\u006e i\u0073\u0020\u006e\u006f\u0074\u0020s\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0066\u006f\u0072\u0020\u0063\u006fl\u006f\u0072\u0020\u0073\u0070\u0061\u0063\u0065 \u0025\u0073",_dbf .ColorSpace .String ());};_f .CatmullRom .Scale (_afdc ,_afdc .Bounds (),_fagc ,_fagc .Bounds (),_f .Over ,&_f .Options {});if _egc ,_cdc =_dge (_afdc );_cdc !=nil {return _cdc ;};_gcda :=_d .MakeDict ();_gcda .Set ("\u0051u\u0061\u006c\u0069\u0074\u0079",_d .MakeInteger (100));_gcda .Set ("\u0050r\u0065\u0064\u0069\u0063\u0074\u006fr",_d .MakeInteger (1));_dbf .Filter .UpdateParams (_gcda );if _cdc =_dbf .SetImage (_egc ,nil );_cdc !=nil {return _cdc ;};_dbf .ToPdfObject ();return nil ;};
\ No newline at end of file
@@ -0,0 +1,287 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"io"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// ReaderOpts defines the options of the PdfReader.
+type ReaderOpts struct {
+	// Password is used to decrypt encrypted documents.
+	Password string
+
+	// Certificate and PrivateKey are used to decrypt the documents encrypted with the public-key
+	// security handler, see PdfReader.DecryptWithKey.
+	Certificate *x509.Certificate
+	PrivateKey  crypto.PrivateKey
+
+	// LazyLoad enables the lazy loading of the objects, see NewPdfReaderLazy.
+	LazyLoad bool
+
+	// Repair enables the repair mode of the parser, which loads damaged documents with broken
+	// cross-reference tables, missing trailers or wrong stream lengths. See core.ParserOpts.
+	Repair bool
+
+	// ObjectCacheLimit limits the memory used by the objects cached by the parser to about
+	// ObjectCacheLimit bytes, see core.PdfParser.SetObjectCacheLimit. It is intended for
+	// extracting the contents of huge documents with LazyLoad. 0 means no limit.
+	ObjectCacheLimit int64
+}
+
+// NewPdfReaderWithOpts creates a new PdfReader for the input ReadSeeker `rs` with the options
+// `opts`. Encrypted documents are decrypted with the password of `opts`, or with its
+// certificate and private key for the documents encrypted with the public-key security
+// handler. An error is returned if they are not valid. A nil `opts` is equivalent to
+// NewPdfReader.
+func NewPdfReaderWithOpts(rs io.ReadSeeker, opts *ReaderOpts) (*PdfReader, error) {
+	if opts == nil {
+		opts = &ReaderOpts{}
+	}
+	return newPdfReader(rs, opts, false)
+}
+
+// newPdfReader creates a new PdfReader for `rs` with the options `opts`, loading the page tree
+// lazily if `lazyPages` is true.
+func newPdfReader(rs io.ReadSeeker, opts *ReaderOpts, lazyPages bool) (*PdfReader, error) {
+	reader := &PdfReader{
+		_cced:     rs,
+		_cadgb:    map[core.PdfObject]struct{}{},
+		_fggbd:    _beed(),
+		_afae:     opts.LazyLoad,
+		lazyPages: lazyPages,
+		pageCache: map[int64]*PdfPage{},
+	}
+	parser, err := core.NewParserWithOpts(rs, &core.ParserOpts{Repair: opts.Repair})
+	if err != nil {
+		return nil, err
+	}
+	reader._gdbbd = parser
+	if opts.ObjectCacheLimit > 0 {
+		parser.SetObjectCacheLimit(opts.ObjectCacheLimit)
+	}
+	encrypted, err := reader.IsEncrypted()
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		if err = reader.loadStructure(); err != nil {
+			return nil, err
+		}
+		return reader, nil
+	}
+	if parser.IsPubKeyEncrypted() {
+		auth, err := reader.DecryptWithKey(opts.Certificate, opts.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		if !auth {
+			return nil, errors.New("unable to decrypt document with the given certificate")
+		}
+		return reader, nil
+	}
+	auth, err := reader.Decrypt([]byte(opts.Password))
+	if err != nil {
+		return nil, err
+	}
+	if !auth {
+		return nil, errors.New("unable to decrypt document with the given password")
+	}
+	return reader, nil
+}
+
+// pubKeyCredentials are the certificate and the private key used to decrypt a document
+// encrypted with the public-key security handler.
+type pubKeyCredentials struct {
+	cert *x509.Certificate
+	key  crypto.PrivateKey
+}
+
+// DecryptWithKey decrypts the document encrypted with the public-key security handler
+// (Adobe.PubSec) with the certificate `cert` and its private key `key`, typically an
+// *rsa.PrivateKey. Returns true if `cert` is a recipient of the document, false otherwise.
+// The access permissions of the document are the permissions granted to the recipient.
+func (r *PdfReader) DecryptWithKey(cert *x509.Certificate, key crypto.PrivateKey) (bool, error) {
+	if cert == nil {
+		return false, errors.New("certificate not specified")
+	}
+	auth, err := r._gdbbd.DecryptWithKey(cert, key)
+	if err != nil || !auth {
+		return false, err
+	}
+	r.pubKey = &pubKeyCredentials{cert: cert, key: key}
+	if err = r.loadStructure(); err != nil {
+		common.Log.Debug("ERROR: Fail to load structure (%s)", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// NewPdfReaderAt creates a new PdfReader reading the document of `size` bytes from `r`, with
+// the options `opts` (see NewPdfReaderWithOpts). Only the parts of the document needed to load
+// its objects are read: with opts.LazyLoad, opening the document reads the cross-reference
+// sections and the catalog, and the page tree nodes, contents, resources and other objects of
+// the pages are read when used. The reads are sized to the objects being parsed. The document
+// is not read into memory.
+//
+// `r` is typically an implementation of io.ReaderAt reading byte ranges of a remote file. As
+// the parser reads the document in many small parts, `r` can be wrapped in a core.ReadCache
+// reading and caching larger pages of the file:
+//
+//	cache := core.NewReadCache(fetcher, size, &core.ReadCacheOpts{PageSize: 256 * 1024})
+//	reader, err := model.NewPdfReaderAt(cache, size, &model.ReaderOpts{LazyLoad: true})
+//
+// With opts.LazyLoad, GetPage reads only the page tree nodes on the path to the page, while the
+// full page list is loaded by the operations which need it, e.g. the form flattening or an
+// appender.
+func NewPdfReaderAt(r io.ReaderAt, size int64, opts *ReaderOpts) (*PdfReader, error) {
+	if opts == nil {
+		opts = &ReaderOpts{}
+	}
+	return newPdfReader(&readerAtSeeker{r: r, size: size}, opts, opts.LazyLoad)
+}
+
+// readerAtSeeker is an io.ReadSeeker reading from an io.ReaderAt. The parser buffers its reads
+// after each seek, so the reads are sized to the objects being parsed: the first read after a
+// seek returns at most readerAtMinRead bytes and the following reads twice as many as the
+// previous one, up to the size requested.
+type readerAtSeeker struct {
+	r      io.ReaderAt
+	size   int64
+	offset int64
+
+	// next is the maximum size of the next read.
+	next int
+}
+
+// readerAtMinRead is the maximum size of the first read after a seek.
+const readerAtMinRead = 512
+
+// Read implements io.Reader.
+func (rs *readerAtSeeker) Read(p []byte) (int, error) {
+	if rs.offset >= rs.size {
+		return 0, io.EOF
+	}
+	if rs.next < readerAtMinRead {
+		rs.next = readerAtMinRead
+	}
+	if len(p) > rs.next {
+		p = p[:rs.next]
+	}
+	if remaining := rs.size - rs.offset; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := rs.r.ReadAt(p, rs.offset)
+	rs.offset += int64(n)
+	rs.next *= 2
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker.
+func (rs *readerAtSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += rs.offset
+	case io.SeekEnd:
+		offset += rs.size
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	if offset != rs.offset {
+		rs.offset = offset
+		rs.next = 0
+	}
+	return offset, nil
+}
+
+// RepairReport returns the repairs performed when loading the document, or nil if the reader
+// was not created in repair mode.
+func (r *PdfReader) RepairReport() *core.RepairReport {
+	return r._gdbbd.RepairReport()
+}
+
+// ToWriter returns a PdfWriter containing the pages, the document information and the
+// catalog entries of the document, e.g. to save a repaired document. The writer shares the
+// objects of the reader, which should not be used to output other documents afterwards.
+// The document is written unencrypted unless the writer is encrypted.
+func (r *PdfReader) ToWriter() (*PdfWriter, error) {
+	w := NewPdfWriter()
+	version := r.PdfVersion()
+	w.SetVersion(version.Major, version.Minor)
+
+	numPages, err := r.GetNumPages()
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i <= numPages; i++ {
+		page, err := r.GetPage(i)
+		if err != nil {
+			return nil, err
+		}
+		if err = w.AddPage(page); err != nil {
+			return nil, err
+		}
+	}
+
+	if info, err := r.GetPdfInfo(); err == nil {
+		w.SetDocInfo(info)
+	} else {
+		common.Log.Debug("ERROR: unable to load document information: %v", err)
+	}
+
+	for _, key := range r._acae.Keys() {
+		switch key {
+		case "Type", "Pages", "Version":
+			continue
+		}
+		val := r._acae.Get(key)
+		if ref, ok := val.(*core.PdfObjectReference); ok {
+			val = ref.Resolve()
+		}
+		w._abebc.Set(key, val)
+		if err = w.addObjects(val); err != nil {
+			return nil, err
+		}
+	}
+	return &w, nil
+}
+
+// Repair loads the document from `rs` in repair mode and writes the repaired document to `w`.
+// The other options of `opts` are used to load the document, which can be nil. Returns the
+// repairs performed.
+func Repair(rs io.ReadSeeker, w io.Writer, opts *ReaderOpts) (*core.RepairReport, error) {
+	readerOpts := ReaderOpts{}
+	if opts != nil {
+		readerOpts = *opts
+	}
+	readerOpts.Repair = true
+	reader, err := NewPdfReaderWithOpts(rs, &readerOpts)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := reader.ToWriter()
+	if err != nil {
+		return nil, err
+	}
+	if err = writer.Write(w); err != nil {
+		return nil, err
+	}
+	return reader.RepairReport(), nil
+}
@@ -0,0 +1,146 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// countingReaderAt is an io.ReaderAt counting the bytes read.
+type countingReaderAt struct {
+	r     io.ReaderAt
+	bytes int64
+	reads int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.r.ReadAt(p, off)
+	c.bytes += int64(n)
+	c.reads++
+	return n, err
+}
+
+// pageTreeTestDocument returns a document of `nodes` intermediate page tree nodes of `pages`
+// pages each. The content stream of each page shows its page number.
+func pageTreeTestDocument(nodes, pages int) []byte {
+	rev := testRevision{1: "<< /Type /Catalog /Pages 2 0 R >>"}
+	var nodeRefs []string
+	num := 3 + nodes
+	for i := 0; i < nodes; i++ {
+		nodeNum := 3 + i
+		nodeRefs = append(nodeRefs, fmt.Sprintf("%d 0 R", nodeNum))
+
+		var kids []string
+		for j := 0; j < pages; j++ {
+			content := fmt.Sprintf("BT /F1 12 Tf 10 10 Td (Page %d) Tj ET", i*pages+j+1)
+			rev[num] = fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 200 200] /Contents %d 0 R >>",
+				nodeNum, num+1)
+			rev[num+1] = fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)
+			kids = append(kids, fmt.Sprintf("%d 0 R", num))
+			num += 2
+		}
+		rev[nodeNum] = fmt.Sprintf("<< /Type /Pages /Parent 2 0 R /Kids [%s] /Count %d >>",
+			strings.Join(kids, " "), pages)
+	}
+	rev[2] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(nodeRefs, " "), nodes*pages)
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	writeTestRevision(&buf, rev, num, 0)
+	return buf.Bytes()
+}
+
+func TestNewPdfReaderAtLazyPages(t *testing.T) {
+	data := pageTreeTestDocument(20, 100)
+	counter := &countingReaderAt{r: bytes.NewReader(data)}
+	reader, err := NewPdfReaderAt(counter, int64(len(data)), &ReaderOpts{LazyLoad: true})
+	if err != nil {
+		t.Fatalf("unable to read document: %v", err)
+	}
+	if numPages, err := reader.GetNumPages(); err != nil || numPages != 2000 {
+		t.Fatalf("expected 2000 pages, got %d (%v)", numPages, err)
+	}
+
+	// Apart from the cross-reference table, only the objects of the page tree nodes on the path to
+	// the pages used and of their preceding kids are read.
+	xrefSize := int64(len(data) - bytes.LastIndex(data, []byte("\nxref\n")))
+	testcases := []struct {
+		pageNumber int
+		limit      int64
+	}{
+		{1, xrefSize + 8*1024},
+		{1234, xrefSize + 64*1024},
+	}
+	for _, tc := range testcases {
+		page, err := reader.GetPage(tc.pageNumber)
+		if err != nil {
+			t.Fatalf("unable to get page %d: %v", tc.pageNumber, err)
+		}
+		content, err := page.GetAllContentStreams()
+		if err != nil {
+			t.Fatalf("unable to get the contents of page %d: %v", tc.pageNumber, err)
+		}
+		if expected := fmt.Sprintf("(Page %d)", tc.pageNumber); !strings.Contains(content, expected) {
+			t.Fatalf("expected the contents of page %d, got %q", tc.pageNumber, content)
+		}
+		if _, num, err := reader.PageFromIndirectObject(page.GetPageAsIndirectObject()); err != nil || num != tc.pageNumber {
+			t.Fatalf("expected page number %d, got %d (%v)", tc.pageNumber, num, err)
+		}
+		if counter.bytes > tc.limit {
+			t.Fatalf("read %d bytes in %d reads up to page %d, expected at most %d of %d bytes",
+				counter.bytes, counter.reads, tc.pageNumber, tc.limit, len(data))
+		}
+	}
+
+	// The page list is loaded when needed, reusing the pages already loaded.
+	page, err := reader.GetPage(1234)
+	if err != nil {
+		t.Fatalf("unable to get page: %v", err)
+	}
+	if err = reader.loadPages(); err != nil {
+		t.Fatalf("unable to load the pages: %v", err)
+	}
+	if len(reader.PageList) != 2000 || reader.PageList[1233] != page {
+		t.Fatalf("invalid page list")
+	}
+}
+
+func TestNewPdfReaderAtConcurrentPages(t *testing.T) {
+	data := pageTreeTestDocument(4, 25)
+	reader, err := NewPdfReaderAt(bytes.NewReader(data), int64(len(data)), &ReaderOpts{LazyLoad: true})
+	if err != nil {
+		t.Fatalf("unable to read document: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 100)
+	for i := range errs {
+		wg.Add(1)
+		go func(pageNumber int) {
+			defer wg.Done()
+			page, err := reader.GetPage(pageNumber)
+			if err == nil {
+				_, err = page.GetAllContentStreams()
+			}
+			errs[pageNumber-1] = err
+		}(i + 1)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("unable to get page %d: %v", i+1, err)
+		}
+	}
+}
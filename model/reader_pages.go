@@ -0,0 +1,216 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// loadPages loads the page list of the document when its page tree is loaded lazily, i.e. the
+// reader was created with NewPdfReaderAt and LazyLoad. The pages already loaded by GetPage are
+// reused.
+func (r *PdfReader) loadPages() error {
+	r.pageMu.Lock()
+	defer r.pageMu.Unlock()
+	if !r.lazyPages {
+		return nil
+	}
+	r._dbdgb = []*core.PdfIndirectObject{}
+	r.PageList = nil
+	if err := r.buildPageList(r._cbfdd, nil, map[core.PdfObject]struct{}{}); err != nil {
+		return err
+	}
+	r.lazyPages = false
+	return nil
+}
+
+// pageFromNode returns the page of the page tree node `node` with dictionary `dict`, loading it
+// unless it was already loaded by GetPage.
+func (r *PdfReader) pageFromNode(node *core.PdfIndirectObject, dict *core.PdfObjectDictionary) (*PdfPage, error) {
+	if page, ok := r.pageCache[node.ObjectNumber]; ok {
+		return page, nil
+	}
+	return r.newPdfPageFromDict(dict)
+}
+
+// lazyPage returns the page `pageNumber` (starting at 1) of the document. Only the page tree
+// nodes on the path to the page and their kids preceding it are loaded. The pages can be
+// loaded concurrently, e.g. by extractor.ProcessPages.
+func (r *PdfReader) lazyPage(pageNumber int) (*PdfPage, error) {
+	if pageNumber < 1 {
+		return nil, fmt.Errorf("page numbering must start at 1")
+	}
+	if pageNumber > r._bbccc {
+		return nil, errors.New("invalid page number (page count too short)")
+	}
+
+	r.pageMu.Lock()
+	defer r.pageMu.Unlock()
+
+	index := pageNumber - 1
+	node := r._cbfdd
+	visited := map[*core.PdfIndirectObject]struct{}{}
+	for {
+		if _, ok := visited[node]; ok {
+			return nil, errors.New("cyclic page tree")
+		}
+		visited[node] = struct{}{}
+
+		dict, ok := core.GetDict(node)
+		if !ok {
+			return nil, errors.New("node not a dictionary")
+		}
+		if isPageTreeLeaf(dict) {
+			if index != 0 {
+				return nil, errors.New("invalid page number (page count too short)")
+			}
+			return r.lazyPageFromNode(node, dict)
+		}
+		kids, ok := core.GetArray(dict.Get("Kids"))
+		if !ok {
+			return nil, errors.New("invalid Kids object")
+		}
+
+		// When the count of the node equals its number of kids, the kids are typically all pages
+		// and the page is found without loading the preceding kids.
+		if count, _ := core.GetIntVal(dict.Get("Count")); count == kids.Len() && index < count {
+			if kidObj, ok := core.GetIndirect(kids.Get(index)); ok {
+				if kidDict, ok := core.GetDict(kidObj); ok && isPageTreeLeaf(kidDict) {
+					if kidDict.Get("Parent") != core.PdfObject(node) {
+						kidDict.Set("Parent", node)
+					}
+					return r.lazyPageFromNode(kidObj, kidDict)
+				}
+			}
+		}
+
+		var next *core.PdfIndirectObject
+		for _, kid := range kids.Elements() {
+			kidObj, ok := core.GetIndirect(kid)
+			if !ok {
+				return nil, errors.New("page not indirect object")
+			}
+			count := pageTreeCount(kidObj)
+			if index < count {
+				next = kidObj
+				break
+			}
+			index -= count
+		}
+		if next == nil {
+			return nil, errors.New("invalid page number (page count too short)")
+		}
+		// As when building the page list, the parent is referred to directly. It is set once,
+		// so that the nodes of the pages already loaded are not modified again.
+		if kidDict, ok := core.GetDict(next); ok && kidDict.Get("Parent") != core.PdfObject(node) {
+			kidDict.Set("Parent", node)
+		}
+		node = next
+	}
+}
+
+// lazyPageFromIndirectObject returns the page of the page object `ind` along with its page
+// number, computed from the kids preceding it in the page tree nodes above it.
+func (r *PdfReader) lazyPageFromIndirectObject(ind *core.PdfIndirectObject) (*PdfPage, int, error) {
+	dict, ok := core.GetDict(ind)
+	if !ok {
+		return nil, 0, errors.New("page not found")
+	}
+
+	r.pageMu.Lock()
+	defer r.pageMu.Unlock()
+
+	index := 0
+	node := ind
+	visited := map[*core.PdfIndirectObject]struct{}{}
+	for node != r._cbfdd {
+		if _, ok := visited[node]; ok {
+			return nil, 0, errors.New("page not found")
+		}
+		visited[node] = struct{}{}
+
+		nodeDict, _ := core.GetDict(node)
+		parent, ok := core.GetIndirect(nodeDict.Get("Parent"))
+		if !ok {
+			return nil, 0, errors.New("page not found")
+		}
+		parentDict, ok := core.GetDict(parent)
+		if !ok {
+			return nil, 0, errors.New("page not found")
+		}
+		kids, ok := core.GetArray(parentDict.Get("Kids"))
+		if !ok {
+			return nil, 0, errors.New("page not found")
+		}
+
+		found := false
+		for _, kid := range kids.Elements() {
+			kidObj, ok := core.GetIndirect(kid)
+			if !ok {
+				return nil, 0, errors.New("page not indirect object")
+			}
+			if kidObj == node {
+				found = true
+				break
+			}
+			index += pageTreeCount(kidObj)
+		}
+		if !found {
+			return nil, 0, errors.New("page not found")
+		}
+		node = parent
+	}
+
+	page, err := r.lazyPageFromNode(ind, dict)
+	if err != nil {
+		return nil, 0, err
+	}
+	return page, index + 1, nil
+}
+
+// lazyPageFromNode returns the page of the page object `node` with dictionary `dict`, loading
+// and caching it on first use.
+func (r *PdfReader) lazyPageFromNode(node *core.PdfIndirectObject, dict *core.PdfObjectDictionary) (*PdfPage, error) {
+	if page, ok := r.pageCache[node.ObjectNumber]; ok {
+		return page, nil
+	}
+	page, err := r.newPdfPageFromDict(dict)
+	if err != nil {
+		return nil, err
+	}
+	page.setContainer(node)
+	r.pageCache[node.ObjectNumber] = page
+	return page, nil
+}
+
+// isPageTreeLeaf returns true if the page tree node `dict` is a page rather than an intermediate
+// node. As when building the page list, the nodes without Type are intermediate nodes if they
+// have kids.
+func isPageTreeLeaf(dict *core.PdfObjectDictionary) bool {
+	typ, _ := core.GetNameVal(dict.Get("Type"))
+	return typ == "Page" || (typ == "" && dict.Get("Kids") == nil)
+}
+
+// pageTreeCount returns the number of pages below the page tree node `node`: its Count for an
+// intermediate node and 1 for a page.
+func pageTreeCount(node *core.PdfIndirectObject) int {
+	dict, ok := core.GetDict(node)
+	if !ok {
+		return 0
+	}
+	if isPageTreeLeaf(dict) {
+		return 1
+	}
+	count, _ := core.GetIntVal(dict.Get("Count"))
+	return count
+}
@@ -0,0 +1,843 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// GetRevisions returns the revisions of the document, ordered from the original document to
+// the latest incremental update, with their byte ranges and cross-reference offsets.
+func (r *PdfReader) GetRevisions() ([]core.Revision, error) {
+	return r._gdbbd.GetRevisions()
+}
+
+// GetRevision returns a reader of the document as of revision `number`, as returned by
+// GetRevisions. Encrypted documents are decrypted with the password, or the certificate and
+// the private key, used to decrypt the document of `r`.
+func (r *PdfReader) GetRevision(number int) (*PdfReader, error) {
+	revisions, err := r.GetRevisions()
+	if err != nil {
+		return nil, err
+	}
+	if number < 0 || number >= len(revisions) {
+		return nil, errors.New("revision number out of range")
+	}
+	data, err := r.revisionData(revisions[number])
+	if err != nil {
+		return nil, err
+	}
+	opts := &ReaderOpts{Password: string(r.password), LazyLoad: r._afae}
+	if r.pubKey != nil {
+		opts.Certificate, opts.PrivateKey = r.pubKey.cert, r.pubKey.key
+	}
+	return NewPdfReaderWithOpts(bytes.NewReader(data), opts)
+}
+
+// revisionData returns the bytes of the document as of revision `rev`.
+func (r *PdfReader) revisionData(rev core.Revision) ([]byte, error) {
+	offset, err := r._cced.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	defer r._cced.Seek(offset, io.SeekStart)
+
+	if _, err = r._cced.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data := make([]byte, rev.End)
+	if _, err = io.ReadFull(r._cced, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ObjectChangeType defines how an object was changed by incremental updates.
+type ObjectChangeType int
+
+const (
+	// ObjectAdded is used for objects which did not exist in the previous revision.
+	ObjectAdded ObjectChangeType = iota
+
+	// ObjectModified is used for objects whose contents were modified.
+	ObjectModified
+
+	// ObjectDeleted is used for objects which were freed.
+	ObjectDeleted
+)
+
+// String returns a string representation of the change type.
+func (t ObjectChangeType) String() string {
+	switch t {
+	case ObjectAdded:
+		return "added"
+	case ObjectModified:
+		return "modified"
+	case ObjectDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// ObjectChange represents an object changed between two revisions of a document.
+type ObjectChange struct {
+	ObjectNumber int
+	Type         ObjectChangeType
+
+	// Revision is the number of the last revision which changed the object.
+	Revision int
+}
+
+// RevisionDiff represents the objects changed between two revisions of a document.
+type RevisionDiff struct {
+	From int
+	To   int
+
+	// Changes are the changed objects, ordered by object number. The objects rewritten with
+	// the same contents are not included, nor the cross-reference and object streams.
+	Changes []ObjectChange
+}
+
+// DiffRevisions returns the objects added, modified and deleted by the revisions following
+// revision `from` up to revision `to`, as returned by GetRevisions.
+func (r *PdfReader) DiffRevisions(from, to int) (*RevisionDiff, error) {
+	revisions, err := r.GetRevisions()
+	if err != nil {
+		return nil, err
+	}
+	if from < 0 || from > to || to >= len(revisions) {
+		return nil, errors.New("revision number out of range")
+	}
+	oldReader, err := r.GetRevision(from)
+	if err != nil {
+		return nil, err
+	}
+	newReader, err := r.GetRevision(to)
+	if err != nil {
+		return nil, err
+	}
+	return diffRevisions(oldReader, newReader, revisions[from:to+1])
+}
+
+// diffRevisions returns the objects changed between `oldReader` and `newReader`, the readers of
+// the first and last of `revisions`.
+func diffRevisions(oldReader, newReader *PdfReader, revisions []core.Revision) (*RevisionDiff, error) {
+	diff := &RevisionDiff{From: revisions[0].Number, To: revisions[len(revisions)-1].Number}
+	changes := map[int]ObjectChange{}
+	for _, rev := range revisions[1:] {
+		defined, freed, err := newReader._gdbbd.GetRevisionObjects(rev)
+		if err != nil {
+			return nil, err
+		}
+		for _, num := range freed {
+			changes[num] = ObjectChange{ObjectNumber: num, Type: ObjectDeleted, Revision: rev.Number}
+		}
+		for _, num := range defined {
+			changes[num] = ObjectChange{ObjectNumber: num, Type: ObjectModified, Revision: rev.Number}
+		}
+	}
+
+	oldObjects := oldReader._gdbbd.GetXrefTable().ObjectMap
+	for num, change := range changes {
+		_, existed := oldObjects[num]
+		switch {
+		case change.Type == ObjectDeleted:
+			if !existed {
+				continue
+			}
+		case isStructureObject(newReader, num):
+			continue
+		case !existed:
+			change.Type = ObjectAdded
+		default:
+			oldObj, err1 := oldReader.GetIndirectObjectByNumber(num)
+			newObj, err2 := newReader.GetIndirectObjectByNumber(num)
+			if err1 == nil && err2 == nil && sameIndirectObjects(oldObj, newObj) {
+				continue
+			}
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].ObjectNumber < diff.Changes[j].ObjectNumber
+	})
+	return diff, nil
+}
+
+// isStructureObject returns true if the object `num` of `reader` is a cross-reference or an
+// object stream, which are part of the file structure rather than of the document.
+func isStructureObject(reader *PdfReader, num int) bool {
+	obj, err := reader.GetIndirectObjectByNumber(num)
+	if err != nil {
+		return false
+	}
+	stream, ok := obj.(*core.PdfObjectStream)
+	if !ok {
+		return false
+	}
+	name, _ := core.GetNameVal(stream.Get("Type"))
+	return name == "XRef" || name == "ObjStm"
+}
+
+// DocMDPPermission defines the changes allowed after a certification signature (see section
+// 12.8.2.2 "DocMDP" of PDF32000_2008).
+type DocMDPPermission int
+
+const (
+	// DocMDPNone is used for signatures which do not restrict the changes of the document.
+	DocMDPNone DocMDPPermission = 0
+
+	// DocMDPNoChanges does not allow any change of the document.
+	DocMDPNoChanges DocMDPPermission = 1
+
+	// DocMDPFillForms allows filling in forms, instantiating page templates and signing.
+	DocMDPFillForms DocMDPPermission = 2
+
+	// DocMDPAnnotate allows the changes of DocMDPFillForms and creating, deleting and modifying
+	// annotations.
+	DocMDPAnnotate DocMDPPermission = 3
+)
+
+// SignaturePermissionViolation represents a change made after a signature which is not
+// allowed by the DocMDP or FieldMDP permissions of the signature.
+type SignaturePermissionViolation struct {
+	Field     *PdfField
+	Signature *PdfSignature
+
+	// SignedRevision is the number of the revision signed by the signature.
+	SignedRevision int
+
+	// Permission is the DocMDP permission of the signature.
+	Permission DocMDPPermission
+
+	// Change is the change of the violation. ObjectNumber is 0 for violations which do not
+	// concern a single object.
+	Change ObjectChange
+
+	Reason string
+}
+
+// String returns a description of the violation.
+func (v SignaturePermissionViolation) String() string {
+	name := ""
+	if v.Field != nil {
+		name, _ = v.Field.FullName()
+	}
+	if v.Change.ObjectNumber == 0 {
+		return fmt.Sprintf("signature %q: %s", name, v.Reason)
+	}
+	return fmt.Sprintf("signature %q: object %d %s in revision %d: %s", name,
+		v.Change.ObjectNumber, v.Change.Type, v.Change.Revision, v.Reason)
+}
+
+// CheckSignaturePermissions returns the changes made after the signatures of the document
+// which are not allowed by their DocMDP or FieldMDP permissions, e.g. the contents of the
+// pages modified after a certification signature (shadow attacks). Adding document security
+// store data and document timestamps is always allowed.
+func (r *PdfReader) CheckSignaturePermissions() ([]SignaturePermissionViolation, error) {
+	if err := r.loadPages(); err != nil {
+		return nil, err
+	}
+	signatures, err := r.signatureMDPs()
+	if err != nil || len(signatures) == 0 {
+		return nil, err
+	}
+	revisions, err := r.GetRevisions()
+	if err != nil {
+		return nil, err
+	}
+	last := revisions[len(revisions)-1].Number
+	latest := newRevisionObjects(r)
+
+	var violations []SignaturePermissionViolation
+	for _, sig := range signatures {
+		violation := SignaturePermissionViolation{
+			Field:      sig.field,
+			Signature:  sig.signature,
+			Permission: sig.permission,
+		}
+		signed := -1
+		for _, rev := range revisions {
+			if rev.End >= sig.end {
+				signed = rev.Number
+				// The end-of-line marker following the end-of-file marker can be left out.
+				if rev.End-sig.end > 2 {
+					violation.SignedRevision = signed
+					violation.Reason = "signature does not cover the whole revision"
+					violations = append(violations, violation)
+				}
+				break
+			}
+		}
+		if signed < 0 {
+			violation.Reason = "signature byte range exceeds the document"
+			violations = append(violations, violation)
+			continue
+		}
+		violation.SignedRevision = signed
+		if signed == last {
+			continue
+		}
+
+		oldReader, err := r.GetRevision(signed)
+		if err != nil {
+			return nil, err
+		}
+		diff, err := diffRevisions(oldReader, r, revisions[signed:])
+		if err != nil {
+			return nil, err
+		}
+		review := &changeReview{signed: newRevisionObjects(oldReader), latest: latest}
+		for _, change := range diff.Changes {
+			if reason := review.check(sig, change); reason != "" {
+				violation.Change = change
+				violation.Reason = reason
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations, nil
+}
+
+// signatureMDP represents a signature of the document with its modification permissions.
+type signatureMDP struct {
+	field     *PdfField
+	signature *PdfSignature
+
+	// end is the end offset of the signed byte range.
+	end int64
+
+	permission DocMDPPermission
+	lock       *fieldLock
+}
+
+// fieldLock represents the fields locked by a FieldMDP transform or a signature field lock.
+type fieldLock struct {
+	action string
+	fields map[string]bool
+}
+
+// locks returns true if the field named `name` is locked.
+func (l *fieldLock) locks(name string) bool {
+	switch l.action {
+	case "All":
+		return true
+	case "Include":
+		return l.fields[name]
+	case "Exclude":
+		return !l.fields[name]
+	}
+	return false
+}
+
+// newFieldLock returns the fields locked according to the FieldMDP transform parameters or the
+// signature field lock dictionary `dict`.
+func newFieldLock(dict *core.PdfObjectDictionary) *fieldLock {
+	action, _ := core.GetNameVal(dict.Get("Action"))
+	lock := &fieldLock{action: action, fields: map[string]bool{}}
+	if fields, ok := core.GetArray(dict.Get("Fields")); ok {
+		for _, field := range fields.Elements() {
+			if name, ok := core.GetStringVal(field); ok {
+				lock.fields[name] = true
+			}
+		}
+	}
+	return lock
+}
+
+// signatureMDPs returns the signatures of the document with their modification permissions.
+func (r *PdfReader) signatureMDPs() ([]*signatureMDP, error) {
+	if r.AcroForm == nil {
+		return nil, nil
+	}
+	var signatures []*signatureMDP
+	for _, field := range r.AcroForm.AllFields() {
+		dict, ok := core.GetDict(field.V)
+		if !ok {
+			continue
+		}
+		if typ, _ := core.GetNameVal(dict.Get("Type")); typ != "Sig" && typ != "DocTimeStamp" {
+			continue
+		}
+		ind, ok := core.GetIndirect(field.V)
+		if !ok {
+			continue
+		}
+		signature, err := r.newPdfSignatureFromIndirect(ind)
+		if err != nil {
+			return nil, err
+		}
+		sig := &signatureMDP{field: field, signature: signature}
+		if signature.ByteRange == nil || signature.ByteRange.Len() < 2 {
+			common.Log.Debug("ERROR: signature without byte range")
+			continue
+		}
+		byteRange, err := signature.ByteRange.ToInt64Slice()
+		if err != nil {
+			return nil, err
+		}
+		sig.end = byteRange[len(byteRange)-2] + byteRange[len(byteRange)-1]
+
+		if signature.Reference != nil {
+			for _, obj := range signature.Reference.Elements() {
+				ref, ok := core.GetDict(obj)
+				if !ok {
+					continue
+				}
+				params, _ := core.GetDict(ref.Get("TransformParams"))
+				switch method, _ := core.GetNameVal(ref.Get("TransformMethod")); method {
+				case "DocMDP":
+					sig.permission = DocMDPFillForms
+					if params != nil {
+						if p, ok := core.GetIntVal(params.Get("P")); ok && p >= 1 && p <= 3 {
+							sig.permission = DocMDPPermission(p)
+						}
+					}
+				case "FieldMDP":
+					if params != nil {
+						sig.lock = newFieldLock(params)
+					}
+				}
+			}
+		}
+		if fieldDict, ok := core.GetDict(field.GetContainingPdfObject()); ok && sig.lock == nil {
+			if lock, ok := core.GetDict(fieldDict.Get("Lock")); ok {
+				sig.lock = newFieldLock(lock)
+			}
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, nil
+}
+
+// revisionObjects contains the numbers of the objects of a revision of the document, grouped
+// by the role they play in the document structure. The roles are determined by following the
+// references from the catalog, so that an incremental update cannot change the role of an
+// existing object by rewriting its Type or Subtype.
+type revisionObjects struct {
+	reader *PdfReader
+
+	// catalog and acroForm are the object numbers of the catalog and of the interactive form
+	// dictionary.
+	catalog  int
+	acroForm int
+
+	// dss contains the objects of the document security store.
+	dss map[int]bool
+
+	// fields contains the objects of the field tree of the interactive form, including the
+	// widget annotations, and signatures contains the signature dictionaries of the fields.
+	fields     map[int]bool
+	signatures map[int]bool
+
+	// pages contains the page objects and annotLists the Annots arrays of the pages which are
+	// indirect objects.
+	pages      map[int]bool
+	annotLists map[int]bool
+
+	// annots contains the annotations of the pages which are not part of the field tree.
+	annots map[int]bool
+
+	// appearances contains the objects of the appearance streams of the annotations and of the
+	// widgets.
+	appearances map[int]bool
+}
+
+// newRevisionObjects returns the objects of the document `reader`, grouped by role.
+func newRevisionObjects(reader *PdfReader) *revisionObjects {
+	objs := &revisionObjects{
+		reader:      reader,
+		dss:         map[int]bool{},
+		fields:      map[int]bool{},
+		signatures:  map[int]bool{},
+		pages:       map[int]bool{},
+		annotLists:  map[int]bool{},
+		annots:      map[int]bool{},
+		appearances: map[int]bool{},
+	}
+	if num, ok := indirectObjectNumber(reader._gdbbd.GetTrailer().Get("Root")); ok {
+		objs.catalog = int(num)
+	}
+	if catalog := reader._acae; catalog != nil {
+		objs.collectObjects(catalog.Get("DSS"), objs.dss)
+		if num, form := objs.resolve(catalog.Get("AcroForm")); form != nil {
+			objs.acroForm = num
+			if fields, ok := objs.resolveArray(form.Get("Fields")); ok {
+				visited := map[*core.PdfObjectDictionary]bool{}
+				for _, field := range fields.Elements() {
+					objs.addField(field, visited)
+				}
+			}
+		}
+	}
+
+	for _, page := range reader.PageList {
+		if num, ok := indirectObjectNumber(page._gfbbf); ok {
+			objs.pages[int(num)] = true
+		}
+		dict, ok := core.GetDict(page._gfbbf)
+		if !ok {
+			continue
+		}
+		annots := dict.Get("Annots")
+		if num, ok := indirectObjectNumber(annots); ok {
+			objs.annotLists[int(num)] = true
+		}
+		arr, ok := objs.resolveArray(annots)
+		if !ok {
+			continue
+		}
+		for _, annot := range arr.Elements() {
+			num, annotDict := objs.resolve(annot)
+			if annotDict == nil {
+				continue
+			}
+			if num > 0 && !objs.fields[num] {
+				objs.annots[num] = true
+			}
+			objs.collectObjects(annotDict.Get("AP"), objs.appearances)
+		}
+	}
+	return objs
+}
+
+// addField adds the objects of the field `obj`, of its widgets and of its descendants.
+func (objs *revisionObjects) addField(obj core.PdfObject, visited map[*core.PdfObjectDictionary]bool) {
+	num, dict := objs.resolve(obj)
+	if dict == nil || visited[dict] {
+		return
+	}
+	visited[dict] = true
+	if num > 0 {
+		objs.fields[num] = true
+	}
+	if sigNum, sig := objs.resolve(dict.Get("V")); sig != nil && sigNum > 0 {
+		if typ, _ := core.GetNameVal(sig.Get("Type")); typ == "Sig" || typ == "DocTimeStamp" {
+			objs.signatures[sigNum] = true
+		}
+	}
+	objs.collectObjects(dict.Get("AP"), objs.appearances)
+	if kids, ok := objs.resolveArray(dict.Get("Kids")); ok {
+		for _, kid := range kids.Elements() {
+			objs.addField(kid, visited)
+		}
+	}
+}
+
+// resolve returns the object number and the dictionary of `obj`, resolving references. The
+// dictionary is nil if `obj` is not a dictionary or a stream, and the number is 0 for direct
+// objects.
+func (objs *revisionObjects) resolve(obj core.PdfObject) (int, *core.PdfObjectDictionary) {
+	num := 0
+	if ref, ok := obj.(*core.PdfObjectReference); ok {
+		num = int(ref.ObjectNumber)
+		resolved, err := objs.reader.GetIndirectObjectByNumber(num)
+		if err != nil {
+			return num, nil
+		}
+		obj = resolved
+	}
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject:
+		dict, _ := t.PdfObject.(*core.PdfObjectDictionary)
+		return int(t.ObjectNumber), dict
+	case *core.PdfObjectStream:
+		return int(t.ObjectNumber), t.PdfObjectDictionary
+	case *core.PdfObjectDictionary:
+		return num, t
+	}
+	return num, nil
+}
+
+// resolveArray returns the array `obj`, resolving references.
+func (objs *revisionObjects) resolveArray(obj core.PdfObject) (*core.PdfObjectArray, bool) {
+	if ref, ok := obj.(*core.PdfObjectReference); ok {
+		resolved, err := objs.reader.GetIndirectObjectByNumber(int(ref.ObjectNumber))
+		if err != nil {
+			return nil, false
+		}
+		obj = resolved
+	}
+	return core.GetArray(obj)
+}
+
+// collectObjects adds the numbers of the indirect objects referenced by `obj` and by the
+// objects it references to `objects`.
+func (objs *revisionObjects) collectObjects(obj core.PdfObject, objects map[int]bool) {
+	if num, ok := indirectObjectNumber(obj); ok && num > 0 {
+		if objects[int(num)] {
+			return
+		}
+		objects[int(num)] = true
+	}
+	switch t := obj.(type) {
+	case *core.PdfObjectReference:
+		resolved, err := objs.reader.GetIndirectObjectByNumber(int(t.ObjectNumber))
+		if err != nil {
+			return
+		}
+		objs.collectObjects(resolved, objects)
+	case *core.PdfIndirectObject:
+		objs.collectObjects(t.PdfObject, objects)
+	case *core.PdfObjectStream:
+		objs.collectObjects(t.PdfObjectDictionary, objects)
+	case *core.PdfObjectDictionary:
+		for _, key := range t.Keys() {
+			objs.collectObjects(t.Get(key), objects)
+		}
+	case *core.PdfObjectArray:
+		for _, elem := range t.Elements() {
+			objs.collectObjects(elem, objects)
+		}
+	}
+}
+
+// changeReview checks the changes of the document made after a signature. The modified and
+// deleted objects are classified according to their role in the signed revision and the added
+// objects according to their role in the latest revision.
+type changeReview struct {
+	signed *revisionObjects
+	latest *revisionObjects
+}
+
+// objectDict returns the dictionary of the object `num` of `reader`, which is nil if it is
+// not a dictionary or a stream.
+func objectDict(reader *PdfReader, num int) *core.PdfObjectDictionary {
+	obj, err := reader.GetIndirectObjectByNumber(num)
+	if err != nil {
+		return nil
+	}
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject:
+		dict, _ := t.PdfObject.(*core.PdfObjectDictionary)
+		return dict
+	case *core.PdfObjectStream:
+		return t.PdfObjectDictionary
+	}
+	return nil
+}
+
+// objectKind returns a description of the kind of the object `num` of `reader`, made of its
+// Go type and of its Type, Subtype and FT entries.
+func objectKind(reader *PdfReader, num int) string {
+	obj, err := reader.GetIndirectObjectByNumber(num)
+	if err != nil {
+		return ""
+	}
+	kind := fmt.Sprintf("%T", obj)
+	if ind, ok := obj.(*core.PdfIndirectObject); ok {
+		kind = fmt.Sprintf("%T", ind.PdfObject)
+	}
+	if dict := objectDict(reader, num); dict != nil {
+		for _, key := range []core.PdfObjectName{"Type", "Subtype", "FT"} {
+			name, _ := core.GetNameVal(dict.Get(key))
+			kind += "/" + name
+		}
+	}
+	return kind
+}
+
+// check returns the reason why `change` is not allowed by the permissions of `sig`, or an
+// empty string if it is allowed.
+func (review *changeReview) check(sig *signatureMDP, change ObjectChange) string {
+	num := change.ObjectNumber
+	oldReader, newReader := review.signed.reader, review.latest.reader
+	oldDict := objectDict(oldReader, num)
+	newDict := objectDict(newReader, num)
+
+	if sig.lock != nil && change.Type != ObjectAdded && review.signed.fields[num] && oldDict != nil {
+		name := fieldFullName(oldDict)
+		if sig.lock.locks(name) &&
+			(newDict == nil || !sameObjectContents(oldDict.Get("V"), newDict.Get("V"))) {
+			return fmt.Sprintf("locked field %q modified", name)
+		}
+	}
+	if sig.permission == DocMDPNone {
+		return ""
+	}
+	if change.Type == ObjectModified && objectKind(oldReader, num) != objectKind(newReader, num) {
+		return "object type changed"
+	}
+
+	objs := review.signed
+	if change.Type == ObjectAdded {
+		objs = review.latest
+	}
+	switch {
+	case objs.dss[num]:
+		return ""
+	case num == objs.catalog || num == review.latest.catalog:
+		// The catalog can be written as a new object, it is compared to the catalog of the
+		// signed revision.
+		if change.Type == ObjectDeleted {
+			return ""
+		}
+		for _, key := range changedKeys(oldReader._acae, newReader._acae) {
+			switch key {
+			case "DSS", "Extensions", "Version":
+			case "AcroForm":
+				if sig.permission < DocMDPFillForms {
+					return "interactive form modified"
+				}
+			default:
+				return fmt.Sprintf("catalog entry %s modified", key)
+			}
+		}
+		return ""
+	case objs.signatures[num]:
+		if change.Type != ObjectAdded {
+			return "signature modified"
+		}
+		if typ, _ := core.GetNameVal(newDict.Get("Type")); typ == "DocTimeStamp" {
+			return ""
+		}
+		if sig.permission < DocMDPFillForms {
+			return "signing not permitted"
+		}
+		return ""
+	case num == objs.acroForm:
+		if sig.permission < DocMDPFillForms {
+			return "interactive form modified"
+		}
+		return ""
+	case objs.pages[num]:
+		if oldDict == nil || newDict == nil {
+			return "page " + change.Type.String()
+		}
+		for _, key := range changedKeys(oldDict, newDict) {
+			if key != "Annots" {
+				return fmt.Sprintf("page entry %s modified", key)
+			}
+		}
+		return review.checkAnnots(sig, oldDict.Get("Annots"), newDict.Get("Annots"))
+	case objs.annotLists[num]:
+		oldObj, _ := oldReader.GetIndirectObjectByNumber(num)
+		newObj, _ := newReader.GetIndirectObjectByNumber(num)
+		return review.checkAnnots(sig, oldObj, newObj)
+	case objs.fields[num]:
+		if change.Type == ObjectDeleted {
+			return "form field deleted"
+		}
+		if sig.permission < DocMDPFillForms {
+			return "form field " + change.Type.String()
+		}
+		return ""
+	case objs.annots[num]:
+		if sig.permission < DocMDPAnnotate {
+			return "annotation " + change.Type.String()
+		}
+		return ""
+	case objs.appearances[num] && change.Type != ObjectDeleted:
+		if sig.permission < DocMDPFillForms {
+			return "appearance stream " + change.Type.String()
+		}
+		return ""
+	}
+	return "object " + change.Type.String()
+}
+
+// checkAnnots returns the reason why the change of the annotations of a page from `oldAnnots`
+// to `newAnnots` is not allowed by the permissions of `sig`, or an empty string if it is
+// allowed.
+func (review *changeReview) checkAnnots(sig *signatureMDP, oldAnnots, newAnnots core.PdfObject) string {
+	annotNumbers := func(objs *revisionObjects, obj core.PdfObject) map[int64]bool {
+		numbers := map[int64]bool{}
+		if arr, ok := objs.resolveArray(obj); ok {
+			for _, elem := range arr.Elements() {
+				if num, ok := indirectObjectNumber(elem); ok {
+					numbers[num] = true
+				}
+			}
+		}
+		return numbers
+	}
+	oldNumbers := annotNumbers(review.signed, oldAnnots)
+	newNumbers := annotNumbers(review.latest, newAnnots)
+	checkAnnot := func(objs *revisionObjects, num int64, removed bool) string {
+		if objs.fields[int(num)] {
+			if removed {
+				return "form field removed from page"
+			}
+			if sig.permission < DocMDPFillForms {
+				return "form field added to page"
+			}
+			return ""
+		}
+		if sig.permission < DocMDPAnnotate {
+			if removed {
+				return "annotation removed from page"
+			}
+			return "annotation added to page"
+		}
+		return ""
+	}
+	for num := range newNumbers {
+		if !oldNumbers[num] {
+			if reason := checkAnnot(review.latest, num, false); reason != "" {
+				return reason
+			}
+		}
+	}
+	for num := range oldNumbers {
+		if !newNumbers[num] {
+			if reason := checkAnnot(review.signed, num, true); reason != "" {
+				return reason
+			}
+		}
+	}
+	return ""
+}
+
+// changedKeys returns the keys of the entries which differ between `dict1` and `dict2`.
+func changedKeys(dict1, dict2 *core.PdfObjectDictionary) []core.PdfObjectName {
+	var keys []core.PdfObjectName
+	for _, key := range dict1.Keys() {
+		if !sameObjectContents(dict1.Get(key), dict2.Get(key)) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range dict2.Keys() {
+		if dict1.Get(key) == nil && !isNullObject(dict2.Get(key)) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// isFormField returns true if `dict` is a form field or a widget annotation.
+func isFormField(dict *core.PdfObjectDictionary) bool {
+	if subtype, _ := core.GetNameVal(dict.Get("Subtype")); subtype == "Widget" {
+		return true
+	}
+	return dict.Get("FT") != nil || dict.Get("T") != nil && dict.Get("Rect") == nil
+}
+
+// fieldFullName returns the fully qualified name of the form field or widget annotation
+// `dict`.
+func fieldFullName(dict *core.PdfObjectDictionary) string {
+	var parts []string
+	visited := map[*core.PdfObjectDictionary]bool{}
+	for dict != nil && !visited[dict] {
+		visited[dict] = true
+		if name, ok := core.GetStringVal(dict.Get("T")); ok {
+			parts = append([]string{name}, parts...)
+		}
+		dict, _ = core.GetDict(dict.Get("Parent"))
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,227 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testRevision is an incremental update of a test document, containing the objects it
+// defines by object number.
+type testRevision map[int]string
+
+// certifiedTestDocument returns a one page document certified by a signature with the DocMDP
+// permission `p`, with the incremental updates `updates` appended to it. The object 4 is the
+// content stream of the page and the object 5 the signature field.
+func certifiedTestDocument(p int, updates ...testRevision) []byte {
+	content := "BT /F1 12 Tf 10 10 Td (Signed) Tj ET"
+	base := testRevision{
+		1: "<< /Type /Catalog /Pages 2 0 R /AcroForm << /Fields [5 0 R] /SigFlags 3 >> /Perms << /DocMDP 6 0 R >> >>",
+		2: "<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		3: "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 200 200] /Contents 4 0 R /Annots [5 0 R] >>",
+		4: fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		5: "<< /FT /Sig /T (Certification) /V 6 0 R /Type /Annot /Subtype /Widget /Rect [0 0 0 0] /P 3 0 R >>",
+		6: fmt.Sprintf("<< /Type /Sig /Filter /Adobe.PPKLite /SubFilter /adbe.pkcs7.detached /Contents <00> /ByteRange [0 0 0 0000000000] /Reference [<< /Type /SigRef /TransformMethod /DocMDP /TransformParams << /Type /TransformParams /P %d /V /1.2 >> >>] >>", p),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	xrefOffset := writeTestRevision(&buf, base, 7, 0)
+	// The signature covers the whole original document.
+	data := bytes.Replace(buf.Bytes(), []byte("0000000000]"), []byte(fmt.Sprintf("%010d]", buf.Len())), 1)
+
+	buf.Reset()
+	buf.Write(data)
+	for _, update := range updates {
+		xrefOffset = writeTestRevision(&buf, update, 9, xrefOffset)
+	}
+	return buf.Bytes()
+}
+
+// writeTestRevision writes the objects of `rev` followed by a cross-reference table and a
+// trailer to `buf`. Returns the offset of the cross-reference table.
+func writeTestRevision(buf *bytes.Buffer, rev testRevision, size int, prev int) int {
+	var numbers []int
+	for num := range rev {
+		numbers = append(numbers, num)
+	}
+	sort.Ints(numbers)
+
+	offsets := map[int]int{}
+	for _, num := range numbers {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", num, rev[num])
+	}
+
+	xrefOffset := buf.Len()
+	buf.WriteString("xref\n")
+	if prev == 0 {
+		fmt.Fprintf(buf, "0 %d\n0000000000 65535 f\r\n", size)
+		for num := 1; num < size; num++ {
+			if offset, ok := offsets[num]; ok {
+				fmt.Fprintf(buf, "%010d 00000 n\r\n", offset)
+			} else {
+				buf.WriteString("0000000000 00000 f\r\n")
+			}
+		}
+	} else {
+		for _, num := range numbers {
+			if strings.HasPrefix(rev[num], "<< /Type /XRef") {
+				continue
+			}
+			fmt.Fprintf(buf, "%d 1\n%010d 00000 n\r\n", num, offsets[num])
+		}
+	}
+
+	trailer := fmt.Sprintf("/Size %d /Root 1 0 R", size)
+	if prev > 0 {
+		trailer += fmt.Sprintf(" /Prev %d", prev)
+	}
+	for _, num := range numbers {
+		if strings.HasPrefix(rev[num], "<< /Type /XRef") {
+			trailer += fmt.Sprintf(" /XRefStm %d", offsets[num])
+		}
+	}
+	fmt.Fprintf(buf, "trailer\n<< %s >>\nstartxref\n%d\n%%%%EOF\n", trailer, xrefOffset)
+	return xrefOffset
+}
+
+// contentUpdate returns an update rewriting the content stream of the page of the certified
+// test document, with the additional dictionary entries `entries`.
+func contentUpdate(entries string) testRevision {
+	content := "BT /F1 12 Tf 10 10 Td (Forged) Tj ET"
+	return testRevision{
+		4: fmt.Sprintf("<< /Length %d %s >>\nstream\n%s\nendstream", len(content), entries, content),
+	}
+}
+
+// checkTestDocument returns the signature permission violations of the document `data`.
+func checkTestDocument(t *testing.T, data []byte) []SignaturePermissionViolation {
+	reader, err := NewPdfReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unable to read document: %v", err)
+	}
+	violations, err := reader.CheckSignaturePermissions()
+	if err != nil {
+		t.Fatalf("unable to check signature permissions: %v", err)
+	}
+	return violations
+}
+
+func TestCheckSignaturePermissionsContentModified(t *testing.T) {
+	violations := checkTestDocument(t, certifiedTestDocument(3, contentUpdate("")))
+	if len(violations) != 1 || violations[0].Change.ObjectNumber != 4 {
+		t.Fatalf("expected a violation for object 4, got %v", violations)
+	}
+}
+
+func TestCheckSignaturePermissionsAnnotationAdded(t *testing.T) {
+	update := testRevision{
+		3: "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 200 200] /Contents 4 0 R /Annots [5 0 R 7 0 R] >>",
+		7: "<< /Type /Annot /Subtype /Text /Rect [10 10 30 30] /Contents (Note) >>",
+	}
+	if violations := checkTestDocument(t, certifiedTestDocument(3, update)); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+	violations := checkTestDocument(t, certifiedTestDocument(2, update))
+	if len(violations) == 0 {
+		t.Fatalf("expected a violation for the annotation added with P=2")
+	}
+}
+
+// An approval signature field is added after the certification. The approval signature has no
+// byte range, so that only the certification signature is checked.
+func TestCheckSignaturePermissionsSignatureAdded(t *testing.T) {
+	update := testRevision{
+		1: "<< /Type /Catalog /Pages 2 0 R /AcroForm << /Fields [5 0 R 7 0 R] /SigFlags 3 >> /Perms << /DocMDP 6 0 R >> >>",
+		3: "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 200 200] /Contents 4 0 R /Annots [5 0 R 7 0 R] >>",
+		7: "<< /FT /Sig /T (Approval) /V 8 0 R /Type /Annot /Subtype /Widget /Rect [0 0 0 0] /P 3 0 R >>",
+		8: "<< /Type /Sig /Filter /Adobe.PPKLite /SubFilter /adbe.pkcs7.detached /Contents <00> >>",
+	}
+	if violations := checkTestDocument(t, certifiedTestDocument(2, update)); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+	violations := checkTestDocument(t, certifiedTestDocument(1, update))
+	if len(violations) == 0 {
+		t.Fatalf("expected a violation for the signature added with P=1")
+	}
+}
+
+// The content stream of the page is rewritten with dictionary entries which make it look like
+// an object whose modification is allowed.
+func TestCheckSignaturePermissionsSpoofedType(t *testing.T) {
+	testcases := []struct {
+		name    string
+		p       int
+		entries string
+	}{
+		{"catalog", 1, "/Type /Catalog /Pages 2 0 R"},
+		{"form field", 2, "/FT /Tx /T (Field)"},
+		{"field name", 2, "/T (Field)"},
+		{"annotation", 3, "/Type /Annot /Subtype /Text /Rect [0 0 10 10]"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			violations := checkTestDocument(t, certifiedTestDocument(tc.p, contentUpdate(tc.entries)))
+			if len(violations) != 1 || violations[0].Change.ObjectNumber != 4 {
+				t.Fatalf("expected a violation for object 4, got %v", violations)
+			}
+		})
+	}
+}
+
+// The content stream of the page is rewritten by an update which defines it only in the
+// cross-reference stream of its hybrid-reference trailer.
+func TestCheckSignaturePermissionsHybridXrefStream(t *testing.T) {
+	data := certifiedTestDocument(3)
+	content := "BT /F1 12 Tf 10 10 Td (Forged) Tj ET"
+	obj := fmt.Sprintf("4 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+
+	var buf bytes.Buffer
+	buf.Write(data)
+	prev := bytes.LastIndex(data, []byte("\nxref\n")) + 1
+	objOffset := buf.Len()
+	buf.WriteString(obj)
+
+	entry := []byte{1, byte(objOffset >> 24), byte(objOffset >> 16), byte(objOffset >> 8), byte(objOffset), 0, 0}
+	xrefStmOffset := buf.Len()
+	fmt.Fprintf(&buf, "7 0 obj\n<< /Type /XRef /Size 8 /W [1 4 2] /Index [4 1] /Length %d >>\nstream\n", len(entry))
+	buf.Write(entry)
+	buf.WriteString("\nendstream\nendobj\n")
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 0\ntrailer\n<< /Size 8 /Root 1 0 R /Prev %d /XRefStm %d >>\nstartxref\n%d\n%%%%EOF\n",
+		prev, xrefStmOffset, xrefOffset)
+
+	reader, err := NewPdfReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unable to read document: %v", err)
+	}
+	revisions, err := reader.GetRevisions()
+	if err != nil || len(revisions) != 2 {
+		t.Fatalf("expected 2 revisions, got %v (%v)", revisions, err)
+	}
+	defined, _, err := reader._gdbbd.GetRevisionObjects(revisions[1])
+	if err != nil {
+		t.Fatalf("unable to get revision objects: %v", err)
+	}
+	if len(defined) != 1 || defined[0] != 4 {
+		t.Fatalf("expected object 4 to be defined, got %v", defined)
+	}
+
+	violations := checkTestDocument(t, buf.Bytes())
+	if len(violations) != 1 || violations[0].Change.ObjectNumber != 4 {
+		t.Fatalf("expected a violation for object 4, got %v", violations)
+	}
+}
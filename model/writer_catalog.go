@@ -0,0 +1,36 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// SetStructTreeRoot sets the StructTreeRoot entry in the PDF catalog, i.e.
+// the root of the logical structure of tagged documents.
+// See section 14.7.2 "Structure Hierarchy" (PDF32000_2008).
+func (w *PdfWriter) SetStructTreeRoot(root core.PdfObject) error {
+	if root == nil {
+		return nil
+	}
+	w._abebc.Set("StructTreeRoot", root)
+	return w.addObjects(root)
+}
+
+// SetMarkInfo sets the MarkInfo entry in the PDF catalog, which indicates
+// whether the document is a tagged PDF document.
+// See section 14.7.1 "General" of "Logical Structure" (PDF32000_2008).
+func (w *PdfWriter) SetMarkInfo(markInfo core.PdfObject) error {
+	if markInfo == nil {
+		return nil
+	}
+	w._abebc.Set("MarkInfo", markInfo)
+	return w.addObjects(markInfo)
+}
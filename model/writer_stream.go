@@ -0,0 +1,427 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/common/license"
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// writerStream holds the state of the streaming write mode of PdfWriter.
+type writerStream struct {
+	// numbers holds the object numbers assigned to the objects written or referenced by
+	// written objects. The contents of written objects are released.
+	numbers map[core.PdfObject]int64
+	next    int64
+}
+
+// StartStreaming enables the streaming write mode, in which the document is written to
+// `out` progressively instead of being built in memory before Write. The pages added with
+// AddPage are written along with their resources when FlushPages is called. Embedded fonts,
+// which can be subset until the document is complete, are written once by Write, which
+// completes the document on `out` (the writer passed to Write is not used). Apart from
+// embedded fonts and objects not yet flushed, only the cross-reference offsets and the page
+// tree are kept in memory.
+//
+// The contents of the objects are released once written, objects added to the writer
+// must not be modified after the pages using them are flushed. The optimizer, if any, is
+// applied to each batch of flushed objects. Streaming is not supported for incremental
+// updates and linearized output.
+func (w *PdfWriter) StartStreaming(out io.Writer) error {
+	if lk := license.GetLicenseKey(); (lk == nil || !lk.IsLicensed()) && !_bbbga {
+		return errors.New("unipdf license code required")
+	}
+	if w._bcdd || w.linearized {
+		return errors.New("streaming is not supported for incremental updates and linearized output")
+	}
+	if w.stream != nil {
+		return errors.New("streaming already started")
+	}
+	w.stream = &writerStream{numbers: map[core.PdfObject]int64{}, next: 1}
+	w._fcadd = bufio.NewWriter(out)
+	w._dfdcd = w._ccdaf
+	w._ffede = map[int]crossReference{0: {Type: 0, ObjectNumber: 0, Generation: 0xFFFF}}
+	w.writeString(fmt.Sprintf("%%PDF-%d.%d\n", w._cfebb, w._gbcce))
+	w.writeString("%âãÏÓ\n")
+	if w._geac == nil {
+		w._geac = w._fcadd.Flush()
+	}
+	return w._geac
+}
+
+// FlushPages writes the pages added since the previous flush, along with the objects they
+// use, in streaming mode (see StartStreaming). Objects shared with pages added later are
+// only written once.
+func (w *PdfWriter) FlushPages() error {
+	if w.stream == nil {
+		return errors.New("streaming not started")
+	}
+	pending := make(map[core.PdfObject]bool, len(w._aage))
+	for _, obj := range w._aage {
+		pending[obj] = true
+	}
+	deferred := map[core.PdfObject]bool{w._bdbdfg: true, w._gacae: true, w._caefd: true}
+	if w._eebbg != nil {
+		deferred[w._eebbg] = true
+	}
+
+	// The batch consists of the pending pages and the objects they use, except the objects
+	// only reachable through Parent entries (e.g. form fields) and embedded fonts.
+	var batch []core.PdfObject
+	inBatch := map[core.PdfObject]bool{}
+	var visit func(obj core.PdfObject)
+	visit = func(obj core.PdfObject) {
+		if inBatch[obj] {
+			return
+		}
+		if !pending[obj] || deferred[obj] || isEmbeddedFont(obj) {
+			return
+		}
+		inBatch[obj] = true
+		batch = append(batch, obj)
+		walkStreamRefs(objectContents(obj), true, visit)
+	}
+	if pages, ok := core.GetDict(w._gacae); ok {
+		if kids, ok := core.GetArray(pages.Get("Kids")); ok {
+			for _, kid := range kids.Elements() {
+				visit(kid)
+			}
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := w.writeStreamObjects(batch); err != nil {
+		return err
+	}
+
+	var remaining []core.PdfObject
+	for _, obj := range w._aage {
+		if !inBatch[obj] {
+			remaining = append(remaining, obj)
+		}
+	}
+	w._aage = remaining
+
+	// The resources can be shared with the pages added later, whose resources are inspected
+	// when they are built. Only the data of the resource streams is released.
+	shared := map[core.PdfObject]bool{}
+	var mark func(obj core.PdfObject)
+	mark = func(obj core.PdfObject) {
+		if !shared[obj] {
+			shared[obj] = true
+			walkStreamRefs(objectContents(obj), true, mark)
+		}
+	}
+	for _, obj := range batch {
+		if dict, ok := core.GetDict(objectContents(obj)); ok {
+			walkStreamRefs(dict.Get("Resources"), true, mark)
+		}
+	}
+	for _, obj := range batch {
+		if shared[obj] {
+			if stream, ok := obj.(*core.PdfObjectStream); ok {
+				stream.Stream = nil
+			}
+			continue
+		}
+		// The objects with a parent other than the page tree (e.g. field widgets) are
+		// serialized again by their parent, their contents are kept.
+		if dict, ok := core.GetDict(objectContents(obj)); ok && dict.Get("Parent") != nil {
+			if name, ok := core.GetName(dict.Get("Type")); !ok || *name != "Page" {
+				continue
+			}
+		}
+		if ind, ok := obj.(*core.PdfIndirectObject); ok {
+			if dict, ok := ind.PdfObject.(*core.PdfObjectDictionary); ok {
+				delete(w._cggg, dict)
+			}
+		}
+		releaseObject(obj)
+	}
+	// The objects traversed when resolving the references of the added objects are only
+	// tracked to avoid traversing them again, the written objects can be forgotten.
+	w._dfdcb = map[core.PdfObject]struct{}{}
+	if w._geac == nil {
+		w._geac = w._fcadd.Flush()
+	}
+	return w._geac
+}
+
+// finishStreaming writes the remaining objects, the cross-reference section and the
+// trailer of a document written in streaming mode. It is called by Write.
+func (w *PdfWriter) finishStreaming() error {
+	if w._geac != nil {
+		return w._geac
+	}
+	// The catalog is written last, once the version needed by the cross-reference section is
+	// known. Objects referenced by the written objects but not added to the writer yet are
+	// added when the objects referencing them are written.
+	writePending := func(catalog bool) error {
+		for len(w._aage) > 0 {
+			var objects, held []core.PdfObject
+			for _, obj := range w._aage {
+				if obj == w._bdbdfg && !catalog {
+					held = append(held, obj)
+				} else {
+					objects = append(objects, obj)
+				}
+			}
+			if len(objects) == 0 {
+				return nil
+			}
+			w._aage = held
+			if err := w.writeStreamObjects(objects); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := writePending(false); err != nil {
+		return err
+	}
+	useXrefStream := w._cfebb > 1 || (w._cfebb == 1 && w._gbcce > 4)
+	if w._ecfeg != nil {
+		useXrefStream = *w._ecfeg
+	}
+	for _, ref := range w._ffede {
+		if ref.Type == 2 {
+			useXrefStream = true
+		}
+	}
+	if useXrefStream && w._cfebb == 1 && w._gbcce < 5 {
+		// The header is already written, the catalog specifies the version instead.
+		if catalog, ok := core.GetDict(w._bdbdfg); ok {
+			catalog.Set("Version", core.MakeName("1.5"))
+		}
+	}
+	if err := writePending(true); err != nil {
+		return err
+	}
+	size := int(w.stream.next)
+
+	trailer := core.MakeDict()
+	trailer.Set("Root", w._bdbdfg)
+	trailer.Set("Info", w._caefd)
+	if w._ecfag != nil {
+		trailer.Set("Encrypt", w._eebbg)
+		trailer.Set("ID", w._acddd)
+	}
+	xrefOffset := w._dfdcd
+	if useXrefStream {
+		num := size
+		size++
+		w._ffede[num] = crossReference{Type: 1, Offset: xrefOffset}
+		stream, err := core.MakeStream(w.linearXrefEntries(0, size), core.NewFlateEncoder())
+		if err != nil {
+			return err
+		}
+		stream.Set("Type", core.MakeName("XRef"))
+		stream.Set("W", core.MakeArray(core.MakeInteger(1), core.MakeInteger(4), core.MakeInteger(2)))
+		stream.Set("Size", core.MakeInteger(int64(size)))
+		for _, key := range trailer.Keys() {
+			stream.Set(key, trailer.Get(key))
+		}
+		stream.ObjectNumber = int64(num)
+		w.writeObject(num, stream)
+	} else {
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "xref\r\n0 %d\r\n", size)
+		for num := 0; num < size; num++ {
+			if ref, ok := w._ffede[num]; ok && ref.Type == 1 {
+				fmt.Fprintf(&buf, "%.10d %.5d n\r\n", ref.Offset, 0)
+			} else {
+				fmt.Fprintf(&buf, "%.10d %.5d f\r\n", 0, 65535)
+			}
+		}
+		trailer.Set("Size", core.MakeInteger(int64(size)))
+		fmt.Fprintf(&buf, "trailer\n%s\n", trailer.WriteString())
+		w.writeBytes(buf.Bytes())
+	}
+	w.writeString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOffset))
+	if w._geac == nil {
+		w._geac = w._fcadd.Flush()
+	}
+	return w._geac
+}
+
+// writeStreamObjects writes `objects` in streaming mode after applying the optimizer. The
+// objects referenced by the written objects are numbered and, if not added to the writer
+// yet, added so that they are written later.
+func (w *PdfWriter) writeStreamObjects(objects []core.PdfObject) error {
+	if w._ceag != nil {
+		// The encryption dictionary cannot be stored in an object stream.
+		var encrypt []core.PdfObject
+		var optimized []core.PdfObject
+		for _, obj := range objects {
+			if obj == w._eebbg {
+				encrypt = append(encrypt, obj)
+			} else {
+				optimized = append(optimized, obj)
+			}
+		}
+		var err error
+		if objects, err = w._ceag.Optimize(optimized); err != nil {
+			return err
+		}
+		objects = append(objects, encrypt...)
+	}
+	inStreams := map[core.PdfObject]bool{}
+	for _, obj := range objects {
+		w.streamNumber(obj)
+		if streams, ok := obj.(*core.PdfObjectStreams); ok {
+			for _, elem := range streams.Elements() {
+				inStreams[elem] = true
+			}
+		}
+		walkStreamRefs(objectContents(obj), false, func(ref core.PdfObject) {
+			w.streamNumber(ref)
+			if !w.hasObject(ref) {
+				w.addObject(ref)
+			}
+		})
+	}
+
+	for _, obj := range objects {
+		if inStreams[obj] {
+			continue
+		}
+		num := w.stream.numbers[obj]
+		if streams, ok := obj.(*core.PdfObjectStreams); ok && w._ecfag != nil {
+			if err := w.writeEncryptedObjectStreams(int(num), streams); err != nil {
+				return err
+			}
+			continue
+		}
+		if w._ecfag != nil && obj != w._eebbg {
+			if err := w._ecfag.Encrypt(obj, num, 0); err != nil {
+				common.Log.Debug("ERROR: Failed encrypting (%s)", err)
+				return err
+			}
+		}
+		w.writeObject(int(num), obj)
+	}
+	return w._geac
+}
+
+// writeEncryptedObjectStreams writes the object stream `streams` as object `num`. Unlike
+// the objects stored in it, the object stream is encrypted as a whole.
+func (w *PdfWriter) writeEncryptedObjectStreams(num int, streams *core.PdfObjectStreams) error {
+	var header, body bytes.Buffer
+	var count int64
+	for _, elem := range streams.Elements() {
+		ind, ok := elem.(*core.PdfIndirectObject)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&header, "%d %d ", ind.ObjectNumber, body.Len())
+		w._ffede[int(ind.ObjectNumber)] = crossReference{Type: 2, ObjectNumber: num, Index: int(count)}
+		body.WriteString(ind.PdfObject.WriteString())
+		body.WriteString(" ")
+		count++
+	}
+
+	stream, err := core.MakeStream(append(header.Bytes(), body.Bytes()...), core.NewFlateEncoder())
+	if err != nil {
+		return err
+	}
+	stream.Set("Type", core.MakeName("ObjStm"))
+	stream.Set("N", core.MakeInteger(count))
+	stream.Set("First", core.MakeInteger(int64(header.Len())))
+	stream.ObjectNumber = int64(num)
+	if err := w._ecfag.Encrypt(stream, int64(num), 0); err != nil {
+		common.Log.Debug("ERROR: Failed encrypting (%s)", err)
+		return err
+	}
+	w.writeObject(num, stream)
+	return w._geac
+}
+
+// streamNumber assigns the next object number to `obj` unless already numbered.
+func (w *PdfWriter) streamNumber(obj core.PdfObject) {
+	if _, ok := w.stream.numbers[obj]; ok {
+		return
+	}
+	num := w.stream.next
+	w.stream.next++
+	w.stream.numbers[obj] = num
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject:
+		t.ObjectNumber, t.GenerationNumber = num, 0
+	case *core.PdfObjectStream:
+		t.ObjectNumber, t.GenerationNumber = num, 0
+	case *core.PdfObjectStreams:
+		t.ObjectNumber, t.GenerationNumber = num, 0
+	}
+}
+
+// objectContents returns the direct contents of the top level object `obj`.
+func objectContents(obj core.PdfObject) core.PdfObject {
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject:
+		return t.PdfObject
+	case *core.PdfObjectStream:
+		return t.PdfObjectDictionary
+	case *core.PdfObjectStreams:
+		return core.MakeArray(t.Elements()...)
+	}
+	return nil
+}
+
+// walkStreamRefs calls `fn` for the indirect objects and streams referenced by the direct
+// object `obj`. Parent entries are skipped if `skipParent` is true.
+func walkStreamRefs(obj core.PdfObject, skipParent bool, fn func(core.PdfObject)) {
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject, *core.PdfObjectStream, *core.PdfObjectStreams:
+		fn(t)
+	case *core.PdfObjectDictionary:
+		for _, key := range t.Keys() {
+			if key == "Parent" && skipParent {
+				continue
+			}
+			walkStreamRefs(t.Get(key), skipParent, fn)
+		}
+	case *core.PdfObjectArray:
+		for _, elem := range t.Elements() {
+			walkStreamRefs(elem, skipParent, fn)
+		}
+	}
+}
+
+// isEmbeddedFont returns true if `obj` is a font dictionary with a font descriptor or
+// descendant fonts. Unlike the standard 14 fonts, such fonts can be subset.
+func isEmbeddedFont(obj core.PdfObject) bool {
+	dict, ok := core.GetDict(obj)
+	if !ok {
+		return false
+	}
+	if name, ok := core.GetName(dict.Get("Type")); !ok || *name != "Font" {
+		return false
+	}
+	return dict.Get("FontDescriptor") != nil || dict.Get("DescendantFonts") != nil
+}
+
+// releaseObject releases the contents of the written object `obj`.
+func releaseObject(obj core.PdfObject) {
+	switch t := obj.(type) {
+	case *core.PdfIndirectObject:
+		t.PdfObject = core.MakeNull()
+	case *core.PdfObjectStream:
+		t.PdfObjectDictionary = core.MakeDict()
+		t.Stream = nil
+	}
+}
@@ -0,0 +1,411 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package pdfutil
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/creator"
+	"github.com/unidoc/unipdf/v3/internal/annotutil"
+	"github.com/unidoc/unipdf/v3/model"
+	"github.com/unidoc/unipdf/v3/render"
+)
+
+// Comment represents a comment of a document, i.e. a markup annotation such
+// as a note, a highlight or a drawing, along with its review information.
+type Comment struct {
+	// PageNumber is the number of the page of the comment, starting from 1.
+	PageNumber int
+
+	// Type is the subtype of the annotation (e.g. Highlight).
+	Type string
+
+	Author   string
+	Subject  string
+	Contents string
+
+	// Date is the modification date of the comment, or its creation date if
+	// not set. Zero if unknown.
+	Date time.Time
+
+	// State is the review state set by the comment (e.g. Accepted), if any.
+	State string
+
+	// Rect is the rectangle of the annotation on the page.
+	Rect *model.PdfRectangle
+
+	// InReplyTo is the comment this comment replies to, if any.
+	InReplyTo *Comment
+
+	Annotation *model.PdfAnnotation
+}
+
+// Comments returns the comments of the pages, ordered by page and then from
+// the top to the bottom of the page. Replies follow the comments they reply
+// to, ordered by date. Popup, link and widget annotations are not comments.
+func Comments(pages []*model.PdfPage) ([]*Comment, error) {
+	var comments []*Comment
+	byObject := map[interface{}]*Comment{}
+	irts := map[*Comment]core.PdfObject{}
+	for i, page := range pages {
+		annots, err := page.GetAnnotations()
+		if err != nil {
+			return nil, err
+		}
+		for _, annot := range annots {
+			comment, irt := newComment(annot, i+1)
+			if comment == nil {
+				continue
+			}
+			comments = append(comments, comment)
+			byObject[annotutil.ObjectKey(annot.GetContainingPdfObject())] = comment
+			if irt != nil {
+				irts[comment] = irt
+			}
+		}
+	}
+
+	replies := map[*Comment][]*Comment{}
+	var roots []*Comment
+	for _, comment := range comments {
+		if irt, ok := irts[comment]; ok {
+			if parent, ok := byObject[annotutil.ObjectKey(irt)]; ok && parent != comment {
+				comment.InReplyTo = parent
+				replies[parent] = append(replies[parent], comment)
+				continue
+			}
+		}
+		roots = append(roots, comment)
+	}
+
+	sort.SliceStable(roots, func(i, j int) bool {
+		a, b := roots[i], roots[j]
+		if a.PageNumber != b.PageNumber {
+			return a.PageNumber < b.PageNumber
+		}
+		return a.Rect.Ury > b.Rect.Ury
+	})
+
+	ordered := make([]*Comment, 0, len(comments))
+	visited := map[*Comment]bool{}
+	var add func(c *Comment)
+	add = func(c *Comment) {
+		if visited[c] {
+			return
+		}
+		visited[c] = true
+		ordered = append(ordered, c)
+		children := replies[c]
+		sort.SliceStable(children, func(i, j int) bool {
+			return children[i].Date.Before(children[j].Date)
+		})
+		for _, child := range children {
+			add(child)
+		}
+	}
+	for _, c := range roots {
+		add(c)
+	}
+	// Replies in reply cycles are not reachable from the roots.
+	for _, c := range comments {
+		add(c)
+	}
+	return ordered, nil
+}
+
+// newComment returns the comment represented by the annotation, along with
+// the annotation it replies to (IRT). Returns nil if the annotation is not a
+// comment.
+func newComment(annot *model.PdfAnnotation, pageNumber int) (*Comment, core.PdfObject) {
+	markup := annotutil.Markup(annot)
+	if markup == nil {
+		return nil, nil
+	}
+
+	comment := &Comment{
+		PageNumber: pageNumber,
+		Annotation: annot,
+		Rect:       &model.PdfRectangle{},
+	}
+	if dict, ok := core.GetDict(annot.GetContainingPdfObject()); ok {
+		comment.Type, _ = core.GetNameVal(dict.Get("Subtype"))
+	}
+	if arr, ok := core.GetArray(annot.Rect); ok {
+		if rect, err := model.NewPdfRectangle(*arr); err == nil {
+			comment.Rect = rect
+		}
+	}
+	comment.Author = stringValue(markup.T)
+	comment.Subject = stringValue(markup.Subj)
+	comment.Contents = stringValue(annot.Contents)
+	for _, obj := range []core.PdfObject{annot.M, markup.CreationDate} {
+		if date, err := model.NewPdfDate(stringValue(obj)); err == nil {
+			comment.Date = date.ToGoTime()
+			break
+		}
+	}
+	if text, ok := annot.GetContext().(*model.PdfAnnotationText); ok {
+		comment.State = stringValue(text.State)
+	}
+	return comment, markup.IRT
+}
+
+// stringValue returns the decoded value of the string object, or an empty
+// string if it is not a string.
+func stringValue(obj core.PdfObject) string {
+	if s, ok := core.GetString(obj); ok {
+		return s.Decoded()
+	}
+	return ""
+}
+
+// CommentsReportOptions contains options for generating comments reports.
+type CommentsReportOptions struct {
+	// Title is the title of the report. Defaults to "Comments Summary".
+	Title string
+
+	// ThumbnailWidth is the width of the thumbnails of the commented areas
+	// in the report. Defaults to 160.
+	ThumbnailWidth float64
+
+	// ThumbnailMargin is the margin around the annotation rectangle
+	// included in the thumbnails, in page units. Defaults to 36.
+	ThumbnailMargin float64
+}
+
+// CommentsReport returns a creator containing a report listing the comments
+// of the pages (see Comments), with their page, type, author, date, review
+// state and contents. Each comment is shown along with a thumbnail of the
+// commented area of the page, rendered with render.ImageDevice and outlined
+// in the color of the annotation. Replies are shown after the comments they
+// reply to, without thumbnail.
+func CommentsReport(pages []*model.PdfPage, opts *CommentsReportOptions) (*creator.Creator, error) {
+	if opts == nil {
+		opts = &CommentsReportOptions{}
+	}
+	title := opts.Title
+	if title == "" {
+		title = "Comments Summary"
+	}
+	thumbWidth := opts.ThumbnailWidth
+	if thumbWidth <= 0 {
+		thumbWidth = 160
+	}
+	thumbMargin := opts.ThumbnailMargin
+	if thumbMargin <= 0 {
+		thumbMargin = 36
+	}
+
+	comments, err := Comments(pages)
+	if err != nil {
+		return nil, err
+	}
+
+	c := creator.New()
+	regular := c.NewTextStyle()
+	regular.FontSize = 10
+	bold := regular
+	if bold.Font, err = model.NewStandard14Font(model.HelveticaBoldName); err != nil {
+		return nil, err
+	}
+	muted := regular
+	muted.Color = creator.ColorRGBFrom8bit(100, 100, 100)
+
+	heading := c.NewStyledParagraph()
+	headingStyle := bold
+	headingStyle.FontSize = 18
+	heading.Append(title).Style = headingStyle
+	heading.SetMargins(0, 0, 0, 6)
+	if err := c.Draw(heading); err != nil {
+		return nil, err
+	}
+
+	commentedPages := map[int]bool{}
+	for _, comment := range comments {
+		commentedPages[comment.PageNumber] = true
+	}
+	summary := c.NewStyledParagraph()
+	summary.Append(fmt.Sprintf("%d comments on %d pages", len(comments), len(commentedPages))).Style = muted
+	summary.SetMargins(0, 0, 0, 12)
+	if err := c.Draw(summary); err != nil {
+		return nil, err
+	}
+
+	renderer := &pageRenderer{device: render.NewImageDevice(), images: map[int]image.Image{}}
+	for _, comment := range comments {
+		table := c.NewTable(2)
+		if err := table.SetColumnWidths(0.35, 0.65); err != nil {
+			return nil, err
+		}
+		table.SetMargins(0, 0, 0, 8)
+
+		thumbCell := table.NewCell()
+		thumbCell.SetBorder(creator.CellBorderSideAll, creator.CellBorderStyleSingle, 0.5)
+		thumbCell.SetBorderColor(creator.ColorRGBFrom8bit(200, 200, 200))
+		if comment.InReplyTo == nil {
+			thumb, err := renderer.thumbnail(pages[comment.PageNumber-1], comment, thumbMargin)
+			if err != nil {
+				common.Log.Debug("Unable to render comment thumbnail: %v", err)
+			} else {
+				img, err := c.NewImageFromGoImage(thumb)
+				if err != nil {
+					return nil, err
+				}
+				img.ScaleToWidth(thumbWidth)
+				img.SetMargins(4, 4, 4, 4)
+				if err := thumbCell.SetContent(img); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		textCell := table.NewCell()
+		textCell.SetBorder(creator.CellBorderSideAll, creator.CellBorderStyleSingle, 0.5)
+		textCell.SetBorderColor(creator.ColorRGBFrom8bit(200, 200, 200))
+		p := c.NewStyledParagraph()
+		p.SetMargins(6, 6, 4, 4)
+		header := fmt.Sprintf("Page %d: %s", comment.PageNumber, comment.Type)
+		if comment.InReplyTo != nil {
+			header = fmt.Sprintf("Page %d: Reply to %s", comment.PageNumber, commentAuthor(comment.InReplyTo))
+		}
+		p.Append(header + "\n").Style = bold
+		details := "Author: " + commentAuthor(comment)
+		if !comment.Date.IsZero() {
+			details += "    Date: " + comment.Date.Format("2006-01-02 15:04")
+		}
+		p.Append(details + "\n").Style = muted
+		if comment.Subject != "" {
+			p.Append("Subject: " + comment.Subject + "\n").Style = muted
+		}
+		if comment.State != "" {
+			p.Append("State: " + comment.State + "\n").Style = muted
+		}
+		if contents := strings.TrimSpace(comment.Contents); contents != "" {
+			p.Append(contents).Style = regular
+		}
+		if err := textCell.SetContent(p); err != nil {
+			return nil, err
+		}
+
+		if err := c.Draw(table); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// WriteCommentsReport writes the comments report of the pages to `w` as a
+// PDF document. See CommentsReport.
+func WriteCommentsReport(w io.Writer, pages []*model.PdfPage, opts *CommentsReportOptions) error {
+	c, err := CommentsReport(pages, opts)
+	if err != nil {
+		return err
+	}
+	return c.Write(w)
+}
+
+// commentAuthor returns the author of the comment, or "Unknown" if not
+// set.
+func commentAuthor(comment *Comment) string {
+	if comment.Author == "" {
+		return "Unknown"
+	}
+	return comment.Author
+}
+
+// pageRenderer renders pages to images, caching the rendered images by
+// page number.
+type pageRenderer struct {
+	device *render.ImageDevice
+	images map[int]image.Image
+}
+
+// thumbnail returns the area of the commented page around the annotation
+// rectangle, expanded by `margin`, with the annotation rectangle outlined
+// in the color of the annotation.
+func (r *pageRenderer) thumbnail(page *model.PdfPage, comment *Comment, margin float64) (image.Image, error) {
+	img, ok := r.images[comment.PageNumber]
+	if !ok {
+		var err error
+		if img, err = r.device.Render(page); err != nil {
+			return nil, err
+		}
+		r.images[comment.PageNumber] = img
+	}
+
+	// The rendered image starts at the left of the crop box, or at 0, and
+	// ends at the top of the crop box, or of the media box.
+	var left, top float64
+	if page.CropBox != nil {
+		left, top = page.CropBox.Llx, page.CropBox.Ury
+	} else {
+		mbox, err := page.GetMediaBox()
+		if err != nil {
+			return nil, err
+		}
+		top = mbox.Ury
+	}
+	toImage := func(x, y float64) image.Point {
+		return image.Pt(int(math.Round(x-left)), int(math.Round(top-y)))
+	}
+
+	rect := comment.Rect
+	annotRect := image.Rectangle{Min: toImage(rect.Llx, rect.Ury), Max: toImage(rect.Urx, rect.Lly)}.Canon()
+	area := image.Rectangle{
+		Min: toImage(rect.Llx-margin, rect.Ury+margin),
+		Max: toImage(rect.Urx+margin, rect.Lly-margin),
+	}.Canon().Intersect(img.Bounds())
+	if area.Empty() {
+		return nil, fmt.Errorf("comment outside of page %d", comment.PageNumber)
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, area.Dx(), area.Dy()))
+	draw.Draw(thumb, thumb.Bounds(), img, area.Min, draw.Src)
+	outline := annotRect.Sub(area.Min).Intersect(thumb.Bounds())
+	if !outline.Empty() {
+		drawOutline(thumb, outline, annotationColor(comment.Annotation))
+	}
+	return thumb, nil
+}
+
+// drawOutline draws a 2 pixels wide outline of the rectangle.
+func drawOutline(img *image.RGBA, r image.Rectangle, c color.Color) {
+	const w = 2
+	src := image.NewUniform(c)
+	for _, side := range []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+w),
+		image.Rect(r.Min.X, r.Max.Y-w, r.Max.X, r.Max.Y),
+		image.Rect(r.Min.X, r.Min.Y, r.Min.X+w, r.Max.Y),
+		image.Rect(r.Max.X-w, r.Min.Y, r.Max.X, r.Max.Y),
+	} {
+		draw.Draw(img, side.Intersect(r), src, image.Point{}, draw.Over)
+	}
+}
+
+// annotationColor returns the color of the annotation (C), defaulting to
+// red when not set.
+func annotationColor(annot *model.PdfAnnotation) color.Color {
+	if arr, ok := core.GetArray(annot.C); ok {
+		if vals, err := arr.ToFloat64Array(); err == nil && len(vals) == 3 {
+			return color.RGBA{R: uint8(vals[0] * 255), G: uint8(vals[1] * 255), B: uint8(vals[2] * 255), A: 255}
+		}
+	}
+	return color.RGBA{R: 255, A: 255}
+}
@@ -0,0 +1,659 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package pdfutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// Document represents a sequence of pages along with the document-level
+// objects referring to them: outlines (bookmarks), named destinations, form
+// fields, page labels, logical structure and optional content. It allows
+// merging, splitting, reordering, rotating and cropping pages without losing
+// these objects.
+//
+// Documents returned by operations such as Split and Extract share the page
+// objects of the original document. The document-level objects referring to
+// pages which are not part of a document are left out when it is written.
+type Document struct {
+	// Info is the document information dictionary written with the
+	// document. Defaults to the information dictionary of the first source
+	// document.
+	Info *model.PdfInfo
+
+	pages   []*model.PdfPage
+	labels  []*pageLabel
+	outline []*outlineItem
+	dests   *nameTree
+	names   map[core.PdfObjectName]*nameTree
+	fields  []*model.PdfField
+	form    *model.PdfAcroForm
+	co      []core.PdfObject
+	sources []*documentSource
+}
+
+// documentSource contains the objects of a source document which can only be
+// carried over when all the pages of the document are part of the written
+// document (logical structure and XFA forms), or which need to be combined
+// when merging documents (optional content).
+type documentSource struct {
+	pages        []*core.PdfIndirectObject
+	structRoot   *core.PdfObjectDictionary
+	markInfo     core.PdfObject
+	ocProperties *core.PdfObjectDictionary
+	xfa          core.PdfObject
+}
+
+// NewDocument returns a new document containing the pages and the
+// document-level objects of the document loaded by `reader`. The reader must
+// be decrypted if the document is encrypted.
+func NewDocument(reader *model.PdfReader) (*Document, error) {
+	trailer, err := reader.GetTrailer()
+	if err != nil {
+		return nil, err
+	}
+	catalog, ok := core.GetDict(trailer.Get("Root"))
+	if !ok {
+		return nil, errors.New("missing catalog")
+	}
+	numPages, err := reader.GetNumPages()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Document{dests: newNameTree(), names: map[core.PdfObjectName]*nameTree{}}
+	source := &documentSource{}
+	for i := 1; i <= numPages; i++ {
+		page, err := reader.GetPage(i)
+		if err != nil {
+			return nil, err
+		}
+		d.pages = append(d.pages, page)
+		source.pages = append(source.pages, page.GetPageAsIndirectObject())
+	}
+	d.labels = loadPageLabels(catalog.Get("PageLabels"), numPages)
+	if outlines, ok := reader.GetOutlineTree().GetContext().(*model.PdfOutline); ok && outlines.First != nil {
+		d.outline = loadOutlineItems(outlines.First)
+	}
+	d.loadNames(catalog)
+
+	if info, err := reader.GetPdfInfo(); err == nil {
+		d.Info = info
+	}
+	if form := reader.AcroForm; form != nil {
+		if form.Fields != nil {
+			d.fields = append(d.fields, *form.Fields...)
+		}
+		if form.CO != nil {
+			d.co = form.CO.Elements()
+		}
+		source.xfa = form.XFA
+		d.form = form
+	}
+
+	source.structRoot, _ = core.GetDict(catalog.Get("StructTreeRoot"))
+	source.markInfo = catalog.Get("MarkInfo")
+	source.ocProperties, _ = core.GetDict(catalog.Get("OCProperties"))
+	d.sources = []*documentSource{source}
+	return d, nil
+}
+
+// NumPages returns the number of pages of the document.
+func (d *Document) NumPages() int {
+	return len(d.pages)
+}
+
+// Pages returns the pages of the document.
+func (d *Document) Pages() []*model.PdfPage {
+	return append([]*model.PdfPage(nil), d.pages...)
+}
+
+// Extract returns a new document containing the specified pages of the
+// document, in the specified order. Page numbers start from 1.
+func (d *Document) Extract(pageNumbers ...int) (*Document, error) {
+	indices, err := d.pageIndices(pageNumbers, true)
+	if err != nil {
+		return nil, err
+	}
+	return d.subset(indices), nil
+}
+
+// Reorder rearranges the pages of the document in the specified order. The
+// pages which are not specified are removed from the document. Page numbers
+// start from 1.
+func (d *Document) Reorder(pageNumbers []int) error {
+	indices, err := d.pageIndices(pageNumbers, true)
+	if err != nil {
+		return err
+	}
+	*d = *d.subset(indices)
+	return nil
+}
+
+// RemovePages removes the specified pages from the document. Page numbers
+// start from 1.
+func (d *Document) RemovePages(pageNumbers ...int) error {
+	indices, err := d.pageIndices(pageNumbers, false)
+	if err != nil {
+		return err
+	}
+	removed := map[int]bool{}
+	for _, i := range indices {
+		removed[i] = true
+	}
+	var kept []int
+	for i := range d.pages {
+		if !removed[i] {
+			kept = append(kept, i)
+		}
+	}
+	*d = *d.subset(kept)
+	return nil
+}
+
+// RotatePages rotates the specified pages clockwise by `angle` degrees,
+// which must be a multiple of 90. All the pages are rotated if no page
+// numbers are specified. Page numbers start from 1.
+func (d *Document) RotatePages(angle int, pageNumbers ...int) error {
+	if angle%90 != 0 {
+		return fmt.Errorf("rotation angle must be a multiple of 90: %d", angle)
+	}
+	indices, err := d.pageIndices(pageNumbers, false)
+	if err != nil {
+		return err
+	}
+	for _, i := range indices {
+		page := d.pages[i]
+		rotate := int64(((pageRotation(page)+angle)%360 + 360) % 360)
+		page.Rotate = &rotate
+	}
+	return nil
+}
+
+// PageBoxes contains the page boundaries which can be set on pages. The
+// boundaries which are nil are left unchanged.
+type PageBoxes struct {
+	// CropBox is the region to which the contents of the page are clipped
+	// when displayed or printed.
+	CropBox *model.PdfRectangle
+
+	// BleedBox is the region to which the contents of the page are clipped
+	// when output in a production environment.
+	BleedBox *model.PdfRectangle
+
+	// TrimBox is the intended dimensions of the finished page after
+	// trimming.
+	TrimBox *model.PdfRectangle
+
+	// ArtBox is the extent of the meaningful content of the page.
+	ArtBox *model.PdfRectangle
+}
+
+// SetPageBoxes sets the page boundaries of the specified pages. All the
+// pages are modified if no page numbers are specified. Page numbers start
+// from 1.
+func (d *Document) SetPageBoxes(boxes PageBoxes, pageNumbers ...int) error {
+	indices, err := d.pageIndices(pageNumbers, false)
+	if err != nil {
+		return err
+	}
+	box := func(r *model.PdfRectangle) *model.PdfRectangle {
+		c := *r
+		c.Normalize()
+		return &c
+	}
+	for _, i := range indices {
+		page := d.pages[i]
+		if boxes.CropBox != nil {
+			page.CropBox = box(boxes.CropBox)
+		}
+		if boxes.BleedBox != nil {
+			page.BleedBox = box(boxes.BleedBox)
+		}
+		if boxes.TrimBox != nil {
+			page.TrimBox = box(boxes.TrimBox)
+		}
+		if boxes.ArtBox != nil {
+			page.ArtBox = box(boxes.ArtBox)
+		}
+	}
+	return nil
+}
+
+// pageIndices returns the indices of the pages with the specified numbers,
+// or of all the pages if no page numbers are specified and `required` is
+// false. Each page can only be specified once.
+func (d *Document) pageIndices(pageNumbers []int, required bool) ([]int, error) {
+	if len(pageNumbers) == 0 {
+		if required {
+			return nil, errors.New("no pages specified")
+		}
+		indices := make([]int, len(d.pages))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+	indices := make([]int, 0, len(pageNumbers))
+	seen := map[int]bool{}
+	for _, num := range pageNumbers {
+		if num < 1 || num > len(d.pages) {
+			return nil, fmt.Errorf("page number out of range: %d", num)
+		}
+		if seen[num] {
+			return nil, fmt.Errorf("page specified more than once: %d", num)
+		}
+		seen[num] = true
+		indices = append(indices, num-1)
+	}
+	return indices, nil
+}
+
+// subset returns a document containing the pages of the document at the
+// specified indices. The document-level objects are shared with the
+// document, and filtered when the document is written.
+func (d *Document) subset(indices []int) *Document {
+	s := *d
+	s.pages = make([]*model.PdfPage, len(indices))
+	s.labels = make([]*pageLabel, len(indices))
+	for i, index := range indices {
+		s.pages[i] = d.pages[index]
+		s.labels[i] = d.labels[index]
+	}
+	if d.Info != nil {
+		info := *d.Info
+		s.Info = &info
+	}
+	return &s
+}
+
+// pageRotation returns the rotation of the page, possibly inherited from
+// its parent nodes in the page tree.
+func pageRotation(page *model.PdfPage) int {
+	if page.Rotate != nil {
+		return int(*page.Rotate)
+	}
+	parent, _ := core.GetDict(page.Parent)
+	for depth := 0; parent != nil && depth < 32; depth++ {
+		if rotate, ok := core.GetIntVal(parent.Get("Rotate")); ok {
+			return rotate
+		}
+		parent, _ = core.GetDict(parent.Get("Parent"))
+	}
+	return 0
+}
+
+// PageRange represents a range of pages, from page number From to page
+// number To inclusive. Page numbers start from 1.
+type PageRange struct {
+	From int
+	To   int
+}
+
+// ParsePageRanges parses page ranges such as "1-3,5,8-" for a document with
+// `numPages` pages. Open-ended ranges extend to the last page.
+func ParsePageRanges(s string, numPages int) ([]PageRange, error) {
+	var ranges []PageRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to := part, part
+		if i := strings.Index(part, "-"); i >= 0 {
+			from, to = strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+		}
+		r := PageRange{From: 1, To: numPages}
+		var err error
+		if from != "" {
+			if r.From, err = strconv.Atoi(from); err != nil {
+				return nil, fmt.Errorf("invalid page range %q", part)
+			}
+		}
+		if to != "" {
+			if r.To, err = strconv.Atoi(to); err != nil {
+				return nil, fmt.Errorf("invalid page range %q", part)
+			}
+		}
+		if r.From < 1 || r.To > numPages || r.From > r.To {
+			return nil, fmt.Errorf("page range out of bounds: %q", part)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// WriteToFile writes the document to the file at `outputPath`.
+func (d *Document) WriteToFile(outputPath string) error {
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return d.Write(f)
+}
+
+// Write writes the document to `w`.
+func (d *Document) Write(w io.Writer) error {
+	if len(d.pages) == 0 {
+		return errors.New("document has no pages")
+	}
+
+	var p patches
+	defer p.restore()
+
+	included := map[*core.PdfIndirectObject]bool{}
+	for _, page := range d.pages {
+		included[page.GetPageAsIndirectObject()] = true
+	}
+	if err := d.pruneLinks(included, &p); err != nil {
+		return err
+	}
+
+	writer := model.NewPdfWriter()
+	for _, page := range d.pages {
+		if err := writer.AddPage(page); err != nil {
+			return err
+		}
+	}
+	if d.Info != nil {
+		writer.SetDocInfo(d.Info)
+	}
+
+	form, err := d.writtenForm(included, &p)
+	if err != nil {
+		return err
+	}
+	if form != nil {
+		if err := writer.SetForms(form); err != nil {
+			return err
+		}
+	}
+	if outline := d.writtenOutline(included); outline != nil {
+		writer.AddOutlineTree(&outline.PdfOutlineTreeNode)
+	}
+	if err := writer.SetNamedDestinations(d.writtenNames(included)); err != nil {
+		return err
+	}
+	if err := writer.SetPageLabels(writtenPageLabels(d.labels)); err != nil {
+		return err
+	}
+	if err := d.writeStructure(&writer, included, &p); err != nil {
+		return err
+	}
+	if err := writer.SetOCProperties(d.writtenOCProperties(included)); err != nil {
+		return err
+	}
+	return writer.Write(w)
+}
+
+// pruneLinks removes the destinations and GoTo actions of the link
+// annotations of the pages which target pages not included in the document.
+func (d *Document) pruneLinks(included map[*core.PdfIndirectObject]bool, p *patches) error {
+	for _, page := range d.pages {
+		annots, err := page.GetAnnotations()
+		if err != nil {
+			return err
+		}
+		for _, annot := range annots {
+			link, ok := annot.GetContext().(*model.PdfAnnotationLink)
+			if !ok {
+				continue
+			}
+			if target := d.destinationPage(link.Dest); target != nil && !included[target] {
+				dest := link.Dest
+				link.Dest = nil
+				p.add(func() { link.Dest = dest })
+			}
+			if target := d.actionPage(link.A); target != nil && !included[target] {
+				action, _ := link.GetAction()
+				a := link.A
+				link.SetAction(nil)
+				p.add(func() {
+					link.SetAction(action)
+					link.A = a
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// destinationPage returns the page targeted by the destination, which is
+// either an explicit destination or the name of a named destination.
+// Returns nil if the destination does not target a page of the document
+// sources.
+func (d *Document) destinationPage(dest core.PdfObject) *core.PdfIndirectObject {
+	for depth := 0; depth < 8; depth++ {
+		switch t := core.TraceToDirectObject(dest).(type) {
+		case *core.PdfObjectArray:
+			if t.Len() == 0 {
+				return nil
+			}
+			page, _ := core.GetIndirect(core.ResolveReference(t.Get(0)))
+			return page
+		case *core.PdfObjectDictionary:
+			dest = t.Get("D")
+		case *core.PdfObjectName:
+			dest = d.namedDestination(string(*t))
+		case *core.PdfObjectString:
+			dest = d.namedDestination(t.Str())
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// actionPage returns the page targeted by the action if it is a GoTo
+// action, or nil otherwise.
+func (d *Document) actionPage(action core.PdfObject) *core.PdfIndirectObject {
+	dict, ok := core.GetDict(action)
+	if !ok {
+		return nil
+	}
+	if s, _ := core.GetNameVal(dict.Get("S")); s != "GoTo" {
+		return nil
+	}
+	return d.destinationPage(dict.Get("D"))
+}
+
+// writtenForm returns the interactive form of the document, containing the
+// fields with widgets on the included pages. Returns nil if there is no
+// such field.
+func (d *Document) writtenForm(included map[*core.PdfIndirectObject]bool, p *patches) (*model.PdfAcroForm, error) {
+	if len(d.fields) == 0 {
+		return nil, nil
+	}
+	widgets := map[core.PdfObject]bool{}
+	for _, page := range d.pages {
+		annots, err := page.GetAnnotations()
+		if err != nil {
+			return nil, err
+		}
+		for _, annot := range annots {
+			if _, ok := annot.GetContext().(*model.PdfAnnotationWidget); ok {
+				widgets[annot.GetContainingPdfObject()] = true
+			}
+		}
+	}
+
+	written := map[core.PdfObject]bool{}
+	var keep func(field *model.PdfField) bool
+	keep = func(field *model.PdfField) bool {
+		var kids []*model.PdfField
+		for _, kid := range field.Kids {
+			if keep(kid) {
+				kids = append(kids, kid)
+			}
+		}
+		var annots []*model.PdfAnnotationWidget
+		for _, widget := range field.Annotations {
+			if widgets[widget.GetContainingPdfObject()] {
+				annots = append(annots, widget)
+			}
+		}
+		if len(kids) == 0 && len(annots) == 0 {
+			return false
+		}
+		if len(kids) != len(field.Kids) || len(annots) != len(field.Annotations) {
+			origKids, origAnnots := field.Kids, field.Annotations
+			field.Kids, field.Annotations = kids, annots
+			p.add(func() { field.Kids, field.Annotations = origKids, origAnnots })
+		}
+		written[field.GetContainingPdfObject()] = true
+		return true
+	}
+	var fields []*model.PdfField
+	for _, field := range d.fields {
+		if keep(field) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &fields
+	if d.form != nil {
+		form.NeedAppearances = d.form.NeedAppearances
+		form.SigFlags = d.form.SigFlags
+		form.DR = d.form.DR
+		form.DA = d.form.DA
+		form.Q = d.form.Q
+	}
+	var co []core.PdfObject
+	for _, obj := range d.co {
+		if ind, ok := core.GetIndirect(core.ResolveReference(obj)); ok && written[ind] {
+			co = append(co, ind)
+		}
+	}
+	if len(co) > 0 {
+		form.CO = core.MakeArray(co...)
+	}
+	if source := d.completeSource(included); source != nil {
+		form.XFA = source.xfa
+	}
+	return form, nil
+}
+
+// completeSources returns the sources of the document whose pages are all
+// included in the document.
+func (d *Document) completeSources(included map[*core.PdfIndirectObject]bool) []*documentSource {
+	var sources []*documentSource
+	for _, source := range d.sources {
+		complete := len(source.pages) > 0
+		for _, page := range source.pages {
+			if !included[page] {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// completeSource returns the source of the document if the document
+// consists of all the pages of a single source, or nil otherwise.
+func (d *Document) completeSource(included map[*core.PdfIndirectObject]bool) *documentSource {
+	sources := d.completeSources(included)
+	if len(sources) != 1 || len(sources[0].pages) != len(d.pages) {
+		return nil
+	}
+	return sources[0]
+}
+
+// writtenOCProperties returns the optional content properties of the
+// document. The optional content groups and configurations of the sources
+// with pages in the document are combined when there are several.
+func (d *Document) writtenOCProperties(included map[*core.PdfIndirectObject]bool) core.PdfObject {
+	var props []*core.PdfObjectDictionary
+	for _, source := range d.sources {
+		if source.ocProperties == nil {
+			continue
+		}
+		for _, page := range source.pages {
+			if included[page] {
+				props = append(props, source.ocProperties)
+				break
+			}
+		}
+	}
+	switch len(props) {
+	case 0:
+		return nil
+	case 1:
+		return props[0]
+	}
+
+	ocgs := core.MakeArray()
+	config := core.MakeDict()
+	for i, prop := range props {
+		if arr, ok := core.GetArray(prop.Get("OCGs")); ok {
+			ocgs.Append(arr.Elements()...)
+		}
+		d, ok := core.GetDict(prop.Get("D"))
+		if !ok {
+			continue
+		}
+		for _, key := range d.Keys() {
+			val := d.Get(key)
+			switch key {
+			case "ON", "OFF", "Order", "RBGroups", "Locked", "AS":
+				arr, ok := core.GetArray(val)
+				if !ok {
+					continue
+				}
+				merged, ok := config.Get(key).(*core.PdfObjectArray)
+				if !ok {
+					merged = core.MakeArray()
+					config.Set(key, merged)
+				}
+				merged.Append(arr.Elements()...)
+			default:
+				if i == 0 {
+					config.Set(key, val)
+				}
+			}
+		}
+	}
+	ocProperties := core.MakeDict()
+	ocProperties.Set("OCGs", ocgs)
+	ocProperties.Set("D", config)
+	return ocProperties
+}
+
+// patches records the changes made to shared objects while writing a
+// document, in order to restore them once written.
+type patches []func()
+
+// add records the function restoring a change.
+func (p *patches) add(restore func()) {
+	*p = append(*p, restore)
+}
+
+// restore reverts the recorded changes, in the reverse order.
+func (p patches) restore() {
+	for i := len(p) - 1; i >= 0; i-- {
+		p[i]()
+	}
+}
@@ -0,0 +1,344 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package pdfutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// MergeOptions contains options for merging documents.
+type MergeOptions struct {
+	// Titles are the titles of the outline items created for each of the
+	// merged documents, pointing to the first page of the document. The
+	// outline of the document is nested under the created item. No item is
+	// created for the documents without title.
+	Titles []string
+
+	// RenameField returns the new name of a top-level form field of the
+	// document at index `doc`, whose name is already used by a field of the
+	// previously merged documents. Defaults to appending an underscore and
+	// the lowest number making the name unique, starting from 2 (e.g.
+	// name_2).
+	RenameField func(doc int, name string) string
+}
+
+// Merge returns a document containing the pages of the documents, one after
+// the other. The outlines, named destinations, form fields, page labels and
+// logical structure of the documents are combined. The form fields and named
+// destinations whose names collide with those of the previous documents are
+// renamed, and the references to the renamed destinations are updated.
+//
+// The merged documents must not share pages, i.e. they must be loaded by
+// different readers, and should not be used once merged.
+func Merge(docs []*Document, opts *MergeOptions) (*Document, error) {
+	if len(docs) == 0 {
+		return nil, errors.New("no documents to merge")
+	}
+	if opts == nil {
+		opts = &MergeOptions{}
+	}
+	merged := &Document{dests: newNameTree(), names: map[core.PdfObjectName]*nameTree{}}
+	for i, doc := range docs {
+		if err := merged.insert(len(merged.pages), doc, i, opts); err != nil {
+			return nil, err
+		}
+	}
+	if docs[0].Info != nil {
+		info := *docs[0].Info
+		merged.Info = &info
+	}
+	return merged, nil
+}
+
+// Insert inserts the pages of the `other` document before the page with the
+// specified number, or after the last page if `pageNumber` is the number of
+// pages plus one. The document-level objects of the documents are combined
+// as when merging documents (see Merge), using the first title of the
+// options, if any, for the outline item created for the inserted document.
+func (d *Document) Insert(pageNumber int, other *Document, opts *MergeOptions) error {
+	if pageNumber < 1 || pageNumber > len(d.pages)+1 {
+		return fmt.Errorf("page number out of range: %d", pageNumber)
+	}
+	if opts == nil {
+		opts = &MergeOptions{}
+	}
+	return d.insert(pageNumber-1, other, 0, opts)
+}
+
+// insert inserts the pages of the `other` document at `index`, combining
+// the document-level objects of the documents. `docIndex` is the index of
+// the inserted document in the merge options.
+func (d *Document) insert(index int, other *Document, docIndex int, opts *MergeOptions) error {
+	existing := map[*core.PdfIndirectObject]bool{}
+	for _, page := range d.pages {
+		existing[page.GetPageAsIndirectObject()] = true
+	}
+	for _, page := range other.pages {
+		if existing[page.GetPageAsIndirectObject()] {
+			return errors.New("the document already contains pages of the inserted document")
+		}
+	}
+
+	renames, err := d.mergeDests(other)
+	if err != nil {
+		return err
+	}
+	outline := renameOutlineDests(other.outline, renames)
+	if docIndex < len(opts.Titles) && opts.Titles[docIndex] != "" && len(other.pages) > 0 {
+		first := other.pages[0].GetPageAsIndirectObject()
+		outline = []*outlineItem{{
+			title: core.MakeEncodedString(opts.Titles[docIndex], true),
+			dest:  core.MakeArray(first, core.MakeName("Fit")),
+			items: outline,
+		}}
+	}
+	pos := len(d.outline)
+	if index < len(d.pages) {
+		pageIndex := map[*core.PdfIndirectObject]int{}
+		for i, page := range d.pages {
+			pageIndex[page.GetPageAsIndirectObject()] = i
+		}
+		for i, item := range d.outline {
+			if target, ok := pageIndex[d.targetPage(item)]; ok && target >= index {
+				pos = i
+				break
+			}
+		}
+	}
+	d.outline = append(append(append([]*outlineItem(nil), d.outline[:pos]...), outline...), d.outline[pos:]...)
+
+	names := make(map[core.PdfObjectName]*nameTree, len(d.names))
+	for key, tree := range d.names {
+		names[key] = tree
+	}
+	for key, tree := range other.names {
+		if names[key] == nil {
+			names[key] = tree
+			continue
+		}
+		merged := names[key].clone()
+		for _, name := range tree.names {
+			merged.add(name, tree.values[name])
+		}
+		names[key] = merged
+	}
+	d.names = names
+
+	d.mergeFields(other, docIndex, opts)
+
+	d.pages = append(append(append([]*model.PdfPage(nil), d.pages[:index]...), other.pages...), d.pages[index:]...)
+	d.labels = append(append(append([]*pageLabel(nil), d.labels[:index]...), other.labels...), d.labels[index:]...)
+	for _, source := range other.sources {
+		found := false
+		for _, s := range d.sources {
+			found = found || s == source
+		}
+		if !found {
+			d.sources = append(d.sources, source)
+		}
+	}
+	if d.Info == nil && other.Info != nil {
+		info := *other.Info
+		d.Info = &info
+	}
+	return nil
+}
+
+// mergeDests adds the named destinations of the `other` document to the
+// named destinations of the document. The destinations whose names are
+// already used are renamed, along with the references to them in the link
+// annotations of the other document. Returns the renamed destinations.
+func (d *Document) mergeDests(other *Document) (map[string]string, error) {
+	renames := map[string]string{}
+	dests := d.dests.clone()
+	for _, name := range other.dests.names {
+		newName := name
+		if dests.has(name) {
+			newName = uniqueName(name, dests.has)
+			renames[name] = newName
+		}
+		dests.add(newName, other.dests.values[name])
+	}
+	d.dests = dests
+	if len(renames) == 0 {
+		return renames, nil
+	}
+
+	for _, page := range other.pages {
+		annots, err := page.GetAnnotations()
+		if err != nil {
+			return nil, err
+		}
+		for _, annot := range annots {
+			link, ok := annot.GetContext().(*model.PdfAnnotationLink)
+			if !ok {
+				continue
+			}
+			if dest, ok := renameDest(link.Dest, renames); ok {
+				link.Dest = dest
+			}
+			if action, ok := core.GetDict(link.A); ok {
+				if dest, ok := renameDest(action.Get("D"), renames); ok {
+					action.Set("D", dest)
+				}
+			}
+		}
+	}
+	return renames, nil
+}
+
+// renameOutlineDests returns a copy of the outline items in which the
+// references to the renamed named destinations are updated.
+func renameOutlineDests(items []*outlineItem, renames map[string]string) []*outlineItem {
+	if len(renames) == 0 {
+		return items
+	}
+	renamed := make([]*outlineItem, len(items))
+	for i, item := range items {
+		c := *item
+		if dest, ok := renameDest(item.dest, renames); ok {
+			c.dest = dest
+		}
+		if action, ok := core.GetDict(item.action); ok {
+			if dest, ok := renameDest(action.Get("D"), renames); ok {
+				a := core.MakeDict()
+				a.Merge(action)
+				a.Set("D", dest)
+				c.action = a
+			}
+		}
+		c.items = renameOutlineDests(item.items, renames)
+		renamed[i] = &c
+	}
+	return renamed
+}
+
+// renameDest returns the new name of the destination if it refers to a
+// renamed named destination, keeping the type of the name object.
+func renameDest(dest core.PdfObject, renames map[string]string) (core.PdfObject, bool) {
+	switch t := core.TraceToDirectObject(dest).(type) {
+	case *core.PdfObjectName:
+		if name, ok := renames[string(*t)]; ok {
+			return core.MakeName(name), true
+		}
+	case *core.PdfObjectString:
+		if name, ok := renames[t.Str()]; ok {
+			return core.MakeString(name), true
+		}
+	}
+	return nil, false
+}
+
+// mergeFields adds the form fields of the `other` document to the fields of
+// the document, renaming the top-level fields whose names are already used.
+// The form settings are combined: the default resources and appearance of
+// the document take precedence.
+func (d *Document) mergeFields(other *Document, docIndex int, opts *MergeOptions) {
+	if len(other.fields) == 0 {
+		return
+	}
+	used := map[string]bool{}
+	for _, field := range d.fields {
+		used[field.PartialName()] = true
+	}
+	exists := func(name string) bool { return used[name] }
+	for _, field := range other.fields {
+		name := field.PartialName()
+		if used[name] {
+			newName := ""
+			if opts.RenameField != nil {
+				newName = opts.RenameField(docIndex, name)
+			}
+			if newName == "" || used[newName] {
+				newName = uniqueName(name, exists)
+			}
+			field.T = core.MakeString(newName)
+			name = newName
+		}
+		used[name] = true
+	}
+	d.fields = append(append([]*model.PdfField(nil), d.fields...), other.fields...)
+	d.co = append(append([]core.PdfObject(nil), d.co...), other.co...)
+	d.form = mergeForms(d.form, other.form)
+}
+
+// mergeForms returns a form with the combined settings of forms `a` and
+// `b`, with `a` taking precedence.
+func mergeForms(a, b *model.PdfAcroForm) *model.PdfAcroForm {
+	if a == nil || b == nil {
+		if a == nil {
+			return b
+		}
+		return a
+	}
+	form := model.NewPdfAcroForm()
+	form.NeedAppearances = a.NeedAppearances
+	form.SigFlags = a.SigFlags
+	form.DR = a.DR
+	form.DA = a.DA
+	form.Q = a.Q
+	if b.NeedAppearances != nil && bool(*b.NeedAppearances) {
+		form.NeedAppearances = b.NeedAppearances
+	}
+	if b.SigFlags != nil {
+		flags := *b.SigFlags
+		if form.SigFlags != nil {
+			flags |= *form.SigFlags
+		}
+		form.SigFlags = core.MakeInteger(int64(flags))
+	}
+	if form.DA == nil {
+		form.DA = b.DA
+	}
+	if form.Q == nil {
+		form.Q = b.Q
+	}
+	if b.DR != nil {
+		if form.DR == nil {
+			form.DR = b.DR
+		} else {
+			dr := model.NewPdfPageResources()
+			dr.ExtGState = form.DR.ExtGState
+			dr.ColorSpace = form.DR.ColorSpace
+			dr.Pattern = form.DR.Pattern
+			dr.Shading = form.DR.Shading
+			dr.XObject = form.DR.XObject
+			dr.ProcSet = form.DR.ProcSet
+			dr.Properties = form.DR.Properties
+			fonts := core.MakeDict()
+			for _, res := range []*model.PdfPageResources{form.DR, b.DR} {
+				if src, ok := core.GetDict(res.Font); ok {
+					for _, key := range src.Keys() {
+						if fonts.Get(key) == nil {
+							fonts.Set(key, src.Get(key))
+						}
+					}
+				}
+			}
+			dr.Font = fonts
+			form.DR = dr
+		}
+	}
+	return form
+}
+
+// uniqueName returns the name made of `name`, an underscore and the lowest
+// number starting from 2 which is not used.
+func uniqueName(name string, used func(string) bool) string {
+	for i := 2; ; i++ {
+		if n := fmt.Sprintf("%s_%d", name, i); !used(n) {
+			return n
+		}
+	}
+}
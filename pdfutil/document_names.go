@@ -0,0 +1,386 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package pdfutil
+
+import (
+	"sort"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// maxTreeDepth is the maximum depth of the name and number trees and of the
+// outlines which are traversed, protecting against reference cycles.
+const maxTreeDepth = 32
+
+// nameTree represents the entries of a name tree, such as the named
+// destinations of a document, in insertion order.
+type nameTree struct {
+	names  []string
+	values map[string]core.PdfObject
+}
+
+// newNameTree returns a new empty name tree.
+func newNameTree() *nameTree {
+	return &nameTree{values: map[string]core.PdfObject{}}
+}
+
+// add adds the entry to the tree, unless the tree already contains an entry
+// with the same name.
+func (t *nameTree) add(name string, value core.PdfObject) {
+	if _, ok := t.values[name]; ok {
+		return
+	}
+	t.names = append(t.names, name)
+	t.values[name] = value
+}
+
+// get returns the value of the entry with the specified name, or nil if
+// there is no such entry.
+func (t *nameTree) get(name string) core.PdfObject {
+	return t.values[name]
+}
+
+// has returns true if the tree contains an entry with the specified name.
+func (t *nameTree) has(name string) bool {
+	_, ok := t.values[name]
+	return ok
+}
+
+// clone returns a copy of the tree.
+func (t *nameTree) clone() *nameTree {
+	c := newNameTree()
+	for _, name := range t.names {
+		c.add(name, t.values[name])
+	}
+	return c
+}
+
+// load adds the entries of the name tree whose root node is `obj`.
+func (t *nameTree) load(obj core.PdfObject, depth int) {
+	node, ok := core.GetDict(obj)
+	if !ok || depth > maxTreeDepth {
+		return
+	}
+	if names, ok := core.GetArray(node.Get("Names")); ok {
+		for i := 0; i+1 < names.Len(); i += 2 {
+			name, ok := core.GetString(names.Get(i))
+			if !ok {
+				common.Log.Debug("Invalid name tree key: %v", names.Get(i))
+				continue
+			}
+			t.add(name.Str(), names.Get(i+1))
+		}
+	}
+	if kids, ok := core.GetArray(node.Get("Kids")); ok {
+		for _, kid := range kids.Elements() {
+			t.load(kid, depth+1)
+		}
+	}
+}
+
+// toPdfObject returns the name tree as a single node containing the entries
+// accepted by `filter`, sorted by name. Returns nil if no entry is accepted.
+func (t *nameTree) toPdfObject(filter func(value core.PdfObject) bool) core.PdfObject {
+	var names []string
+	for _, name := range t.names {
+		if filter == nil || filter(t.values[name]) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	arr := core.MakeArray()
+	for _, name := range names {
+		arr.Append(core.MakeString(name), t.values[name])
+	}
+	node := core.MakeDict()
+	node.Set("Names", arr)
+	return node
+}
+
+// loadNames loads the name trees of the catalog. The named destinations,
+// defined either by the Dests name tree or by the Dests dictionary of older
+// documents, are loaded separately.
+func (d *Document) loadNames(catalog *core.PdfObjectDictionary) {
+	if names, ok := core.GetDict(catalog.Get("Names")); ok {
+		for _, key := range names.Keys() {
+			tree := d.dests
+			if key != "Dests" {
+				tree = newNameTree()
+				d.names[key] = tree
+			}
+			tree.load(names.Get(key), 0)
+		}
+	}
+	if dests, ok := core.GetDict(catalog.Get("Dests")); ok {
+		for _, key := range dests.Keys() {
+			d.dests.add(string(key), dests.Get(key))
+		}
+	}
+}
+
+// namedDestination returns the named destination with the specified name,
+// or nil if not defined.
+func (d *Document) namedDestination(name string) core.PdfObject {
+	if d.dests == nil {
+		return nil
+	}
+	return d.dests.get(name)
+}
+
+// writtenNames returns the name dictionary of the document, containing the
+// named destinations targeting the included pages. Returns nil if there are
+// no names.
+func (d *Document) writtenNames(included map[*core.PdfIndirectObject]bool) core.PdfObject {
+	dict := core.MakeDict()
+	dests := d.dests.toPdfObject(func(dest core.PdfObject) bool {
+		return included[d.destinationPage(dest)]
+	})
+	if dests != nil {
+		dict.Set("Dests", dests)
+	}
+	keys := make([]string, 0, len(d.names))
+	for key := range d.names {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if tree := d.names[core.PdfObjectName(key)].toPdfObject(nil); tree != nil {
+			dict.Set(core.PdfObjectName(key), tree)
+		}
+	}
+	if len(dict.Keys()) == 0 {
+		return nil
+	}
+	return dict
+}
+
+// outlineItem represents an outline item (bookmark) of a document.
+type outlineItem struct {
+	title  *core.PdfObjectString
+	dest   core.PdfObject
+	action core.PdfObject
+	color  core.PdfObject
+	flags  core.PdfObject
+	closed bool
+	items  []*outlineItem
+}
+
+// loadOutlineItems returns the outline items starting from `node` and
+// following their Next entries, along with their descendants.
+func loadOutlineItems(node *model.PdfOutlineTreeNode) []*outlineItem {
+	visited := map[*model.PdfOutlineItem]bool{}
+	var load func(node *model.PdfOutlineTreeNode, depth int) []*outlineItem
+	load = func(node *model.PdfOutlineTreeNode, depth int) []*outlineItem {
+		var items []*outlineItem
+		for node != nil && depth <= maxTreeDepth {
+			item, ok := node.GetContext().(*model.PdfOutlineItem)
+			if !ok || visited[item] {
+				break
+			}
+			visited[item] = true
+			title := item.Title
+			if title == nil {
+				title = core.MakeString("")
+			}
+			items = append(items, &outlineItem{
+				title:  title,
+				dest:   item.Dest,
+				action: item.A,
+				color:  item.C,
+				flags:  item.F,
+				closed: item.Count != nil && *item.Count < 0,
+				items:  load(item.First, depth+1),
+			})
+			node = item.Next
+		}
+		return items
+	}
+	return load(node, 0)
+}
+
+// targetPage returns the page targeted by the destination or the GoTo
+// action of the item, or nil if it does not target a page.
+func (d *Document) targetPage(item *outlineItem) *core.PdfIndirectObject {
+	if page := d.destinationPage(item.dest); page != nil {
+		return page
+	}
+	return d.actionPage(item.action)
+}
+
+// writtenOutline returns the outline of the document, in which the items
+// targeting pages which are not included in the document are left out,
+// unless they have descendants targeting included pages. Returns nil if the
+// document has no outline items.
+func (d *Document) writtenOutline(included map[*core.PdfIndirectObject]bool) *model.PdfOutline {
+	var filter func(items []*outlineItem) []*outlineItem
+	filter = func(items []*outlineItem) []*outlineItem {
+		var filtered []*outlineItem
+		for _, item := range items {
+			c := *item
+			c.items = filter(item.items)
+			if page := d.targetPage(item); page != nil && !included[page] {
+				if len(c.items) == 0 {
+					continue
+				}
+				c.dest, c.action = nil, nil
+			}
+			filtered = append(filtered, &c)
+		}
+		return filtered
+	}
+	items := filter(d.outline)
+	if len(items) == 0 {
+		return nil
+	}
+
+	outline := model.NewPdfOutline()
+	count := linkOutlineItems(&outline.PdfOutlineTreeNode, items)
+	outline.Count = &count
+	return outline
+}
+
+// linkOutlineItems creates the outline items as children of `parent`, and
+// returns the number of visible descendants of `parent` when it is open.
+func linkOutlineItems(parent *model.PdfOutlineTreeNode, items []*outlineItem) int64 {
+	var prev *model.PdfOutlineItem
+	var visible int64
+	for _, item := range items {
+		o := model.NewPdfOutlineItem()
+		o.Title = item.title
+		o.Dest = item.dest
+		o.A = item.action
+		o.C = item.color
+		o.F = item.flags
+		o.Parent = parent
+		if prev != nil {
+			prev.Next = &o.PdfOutlineTreeNode
+			o.Prev = &prev.PdfOutlineTreeNode
+		} else {
+			parent.First = &o.PdfOutlineTreeNode
+		}
+		parent.Last = &o.PdfOutlineTreeNode
+		prev = o
+
+		visible++
+		if len(item.items) == 0 {
+			continue
+		}
+		count := linkOutlineItems(&o.PdfOutlineTreeNode, item.items)
+		if item.closed {
+			closed := -count
+			o.Count = &closed
+		} else {
+			o.Count = &count
+			visible += count
+		}
+	}
+	return visible
+}
+
+// pageLabel represents the label of a page, made of a prefix and a number
+// in the specified numbering style (D, R, r, A or a). The label consists of
+// the prefix only when the style is not set.
+type pageLabel struct {
+	style  string
+	prefix string
+	number int
+}
+
+// loadPageLabels returns the labels of the pages of a document with
+// `numPages` pages, defined by the PageLabels number tree `obj`. The entries
+// of the returned slice are nil if the document has no page labels.
+func loadPageLabels(obj core.PdfObject, numPages int) []*pageLabel {
+	labels := make([]*pageLabel, numPages)
+	type labelRange struct {
+		start int
+		dict  *core.PdfObjectDictionary
+	}
+	var ranges []labelRange
+	for _, entry := range loadNumberTree(obj) {
+		dict, ok := core.GetDict(entry.value)
+		if !ok {
+			common.Log.Debug("Invalid page label: %v", entry.value)
+			continue
+		}
+		ranges = append(ranges, labelRange{start: entry.key, dict: dict})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+
+	for i, r := range ranges {
+		end := numPages
+		if i+1 < len(ranges) && ranges[i+1].start < end {
+			end = ranges[i+1].start
+		}
+		style, _ := core.GetNameVal(r.dict.Get("S"))
+		var prefix string
+		if p, ok := core.GetString(r.dict.Get("P")); ok {
+			prefix = p.Decoded()
+		}
+		start := 1
+		if st, ok := core.GetIntVal(r.dict.Get("St")); ok {
+			start = st
+		}
+		for page := r.start; page < end; page++ {
+			if page >= 0 {
+				labels[page] = &pageLabel{style: style, prefix: prefix, number: start + page - r.start}
+			}
+		}
+	}
+	return labels
+}
+
+// writtenPageLabels returns the PageLabels number tree of the pages with the
+// specified labels. The pages without labels are labelled with their page
+// number. Returns nil if none of the pages has a label.
+func writtenPageLabels(labels []*pageLabel) core.PdfObject {
+	hasLabels := false
+	for _, label := range labels {
+		if label != nil {
+			hasLabels = true
+			break
+		}
+	}
+	if !hasLabels {
+		return nil
+	}
+
+	nums := core.MakeArray()
+	var prev *pageLabel
+	for i, label := range labels {
+		if label == nil {
+			label = &pageLabel{style: "D", number: i + 1}
+		}
+		if prev != nil && label.style == prev.style && label.prefix == prev.prefix &&
+			(label.style == "" || label.number == prev.number+1) {
+			prev = label
+			continue
+		}
+		dict := core.MakeDict()
+		if label.style != "" {
+			dict.Set("S", core.MakeName(label.style))
+			if label.number != 1 {
+				dict.Set("St", core.MakeInteger(int64(label.number)))
+			}
+		}
+		if label.prefix != "" {
+			dict.Set("P", core.MakeEncodedString(label.prefix, true))
+		}
+		nums.Append(core.MakeInteger(int64(i)), dict)
+		prev = label
+	}
+	tree := core.MakeDict()
+	tree.Set("Nums", nums)
+	return tree
+}
@@ -0,0 +1,178 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package pdfutil
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// Split returns the documents containing the pages of each of the page
+// ranges.
+func (d *Document) Split(ranges []PageRange) ([]*Document, error) {
+	if len(ranges) == 0 {
+		return nil, errors.New("no page ranges specified")
+	}
+	docs := make([]*Document, 0, len(ranges))
+	for _, r := range ranges {
+		if r.From < 1 || r.To > len(d.pages) || r.From > r.To {
+			return nil, fmt.Errorf("page range out of bounds: %d-%d", r.From, r.To)
+		}
+		indices := make([]int, 0, r.To-r.From+1)
+		for i := r.From - 1; i < r.To; i++ {
+			indices = append(indices, i)
+		}
+		docs = append(docs, d.subset(indices))
+	}
+	return docs, nil
+}
+
+// SplitByBookmarks splits the document before each page targeted by the
+// outline items (bookmarks) at the specified level, starting from 1 for the
+// top-level items. The title of each document is set to the title of the
+// bookmark starting it. The pages preceding the first bookmark, if any, form
+// the first document.
+func (d *Document) SplitByBookmarks(level int) ([]*Document, error) {
+	if level < 1 {
+		return nil, fmt.Errorf("invalid outline level: %d", level)
+	}
+	pageIndex := map[*core.PdfIndirectObject]int{}
+	for i, page := range d.pages {
+		pageIndex[page.GetPageAsIndirectObject()] = i
+	}
+	titles := map[int]*core.PdfObjectString{}
+	var collect func(items []*outlineItem, depth int)
+	collect = func(items []*outlineItem, depth int) {
+		for _, item := range items {
+			if depth < level {
+				collect(item.items, depth+1)
+				continue
+			}
+			index, ok := pageIndex[d.targetPage(item)]
+			if _, found := titles[index]; ok && !found {
+				titles[index] = item.title
+			}
+		}
+	}
+	collect(d.outline, 1)
+
+	starts := []int{0}
+	for index := range titles {
+		if index > 0 {
+			starts = append(starts, index)
+		}
+	}
+	sort.Ints(starts)
+
+	docs := make([]*Document, 0, len(starts))
+	for i, start := range starts {
+		end := len(d.pages)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		indices := make([]int, 0, end-start)
+		for j := start; j < end; j++ {
+			indices = append(indices, j)
+		}
+		doc := d.subset(indices)
+		if title, ok := titles[start]; ok {
+			if doc.Info == nil {
+				doc.Info = &model.PdfInfo{}
+			}
+			doc.Info.Title = title
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// SplitBySize splits the document into documents whose size does not exceed
+// `maxSize` bytes. The size is estimated from the uncompressed size of the
+// objects used by the pages, the objects shared by several pages of a
+// document being counted once. A page exceeding the maximum size on its own
+// forms a separate document.
+func (d *Document) SplitBySize(maxSize int64) ([]*Document, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid maximum size: %d", maxSize)
+	}
+	var docs []*Document
+	var indices []int
+	var size int64
+	seen := map[core.PdfObject]bool{}
+	for i, page := range d.pages {
+		pageSize := pageObjectsSize(page, seen)
+		if len(indices) > 0 && size+pageSize > maxSize {
+			docs = append(docs, d.subset(indices))
+			indices, size = nil, 0
+			seen = map[core.PdfObject]bool{}
+			pageSize = pageObjectsSize(page, seen)
+		}
+		indices = append(indices, i)
+		size += pageSize
+	}
+	if len(indices) > 0 {
+		docs = append(docs, d.subset(indices))
+	}
+	return docs, nil
+}
+
+// pageObjectsSize returns the estimated size of the objects used by the
+// page which are not in `seen`, and adds them to `seen`. The other pages
+// referred to by the page, e.g. by link annotations, are not counted.
+func pageObjectsSize(page *model.PdfPage, seen map[core.PdfObject]bool) int64 {
+	start := page.GetPageAsIndirectObject()
+	page.ToPdfObject()
+	var size func(obj core.PdfObject) int64
+	size = func(obj core.PdfObject) int64 {
+		switch t := obj.(type) {
+		case *core.PdfObjectReference:
+			return size(t.Resolve())
+		case *core.PdfIndirectObject:
+			if seen[t] {
+				return 0
+			}
+			if dict, ok := t.PdfObject.(*core.PdfObjectDictionary); ok && t != start {
+				if typ, _ := core.GetNameVal(dict.Get("Type")); typ == "Page" {
+					return 0
+				}
+			}
+			seen[t] = true
+			// Object header, trailer and cross-reference entry.
+			return int64(len(t.PdfObject.WriteString())) + 40 + size(t.PdfObject)
+		case *core.PdfObjectStream:
+			if seen[t] {
+				return 0
+			}
+			seen[t] = true
+			return int64(len(t.PdfObjectDictionary.WriteString())+len(t.Stream)) + 60 +
+				size(t.PdfObjectDictionary)
+		case *core.PdfObjectDictionary:
+			var n int64
+			for _, key := range t.Keys() {
+				if key != "Parent" {
+					n += size(t.Get(key))
+				}
+			}
+			return n
+		case *core.PdfObjectArray:
+			var n int64
+			for _, elem := range t.Elements() {
+				n += size(elem)
+			}
+			return n
+		}
+		return 0
+	}
+	return size(start)
+}
@@ -0,0 +1,199 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package pdfutil
+
+import (
+	"sort"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// writeStructure sets the logical structure of the document to `writer`.
+// The structure trees of the sources whose pages are all included in the
+// document are carried over. When there are several, they are combined
+// under a new structure tree root, and the parent tree keys of the pages and
+// annotations of the sources are offset so that they do not collide.
+func (d *Document) writeStructure(writer *model.PdfWriter, included map[*core.PdfIndirectObject]bool, p *patches) error {
+	var sources []*documentSource
+	for _, source := range d.completeSources(included) {
+		if source.structRoot != nil {
+			sources = append(sources, source)
+		}
+	}
+	switch len(sources) {
+	case 0:
+		return nil
+	case 1:
+		if err := writer.SetStructTreeRoot(sources[0].structRoot); err != nil {
+			return err
+		}
+		return writer.SetMarkInfo(sources[0].markInfo)
+	}
+
+	rootDict := core.MakeDict()
+	root := core.MakeIndirectObject(rootDict)
+	kids := core.MakeArray()
+	roleMap := core.MakeDict()
+	classMap := core.MakeDict()
+	ids := newNameTree()
+	var parents []numberTreeEntry
+	offset := 0
+	for _, source := range sources {
+		structRoot := source.structRoot
+		next := 0
+		for _, entry := range loadNumberTree(structRoot.Get("ParentTree")) {
+			parents = append(parents, numberTreeEntry{key: entry.key + offset, value: entry.value})
+			if entry.key >= next {
+				next = entry.key + 1
+			}
+		}
+		if key, ok := core.GetIntVal(structRoot.Get("ParentTreeNextKey")); ok && key > next {
+			next = key
+		}
+		if offset > 0 {
+			if err := d.offsetStructParents(source, offset, p); err != nil {
+				return err
+			}
+		}
+		offset += next
+
+		var elems []core.PdfObject
+		switch k := core.TraceToDirectObject(structRoot.Get("K")).(type) {
+		case *core.PdfObjectArray:
+			elems = k.Elements()
+		case *core.PdfObjectDictionary:
+			elems = []core.PdfObject{structRoot.Get("K")}
+		}
+		for _, elem := range elems {
+			dict, ok := core.GetDict(elem)
+			if !ok {
+				continue
+			}
+			parent := dict.Get("P")
+			dict.Set("P", root)
+			p.add(func() { dict.Set("P", parent) })
+			kids.Append(elem)
+		}
+
+		for _, m := range []struct {
+			dict *core.PdfObjectDictionary
+			key  core.PdfObjectName
+		}{{roleMap, "RoleMap"}, {classMap, "ClassMap"}} {
+			src, ok := core.GetDict(structRoot.Get(m.key))
+			if !ok {
+				continue
+			}
+			for _, key := range src.Keys() {
+				if m.dict.Get(key) == nil {
+					m.dict.Set(key, src.Get(key))
+				}
+			}
+		}
+		ids.load(structRoot.Get("IDTree"), 0)
+	}
+
+	sort.SliceStable(parents, func(i, j int) bool { return parents[i].key < parents[j].key })
+	nums := core.MakeArray()
+	for _, entry := range parents {
+		nums.Append(core.MakeInteger(int64(entry.key)), entry.value)
+	}
+	parentTree := core.MakeDict()
+	parentTree.Set("Nums", nums)
+
+	rootDict.Set("Type", core.MakeName("StructTreeRoot"))
+	rootDict.Set("K", kids)
+	rootDict.Set("ParentTree", parentTree)
+	rootDict.Set("ParentTreeNextKey", core.MakeInteger(int64(offset)))
+	if len(roleMap.Keys()) > 0 {
+		rootDict.Set("RoleMap", roleMap)
+	}
+	if len(classMap.Keys()) > 0 {
+		rootDict.Set("ClassMap", classMap)
+	}
+	if idTree := ids.toPdfObject(nil); idTree != nil {
+		rootDict.Set("IDTree", idTree)
+	}
+	if err := writer.SetStructTreeRoot(root); err != nil {
+		return err
+	}
+	markInfo := core.MakeDict()
+	markInfo.Set("Marked", core.MakeBool(true))
+	return writer.SetMarkInfo(markInfo)
+}
+
+// offsetStructParents offsets the parent tree keys of the pages of the
+// source and of their annotations.
+func (d *Document) offsetStructParents(source *documentSource, offset int, p *patches) error {
+	pages := map[*core.PdfIndirectObject]bool{}
+	for _, page := range source.pages {
+		pages[page] = true
+	}
+	offsetKey := func(key *core.PdfObject) {
+		val, ok := core.GetIntVal(*key)
+		if !ok {
+			return
+		}
+		orig := *key
+		*key = core.MakeInteger(int64(val + offset))
+		p.add(func() { *key = orig })
+	}
+	for _, page := range d.pages {
+		if !pages[page.GetPageAsIndirectObject()] {
+			continue
+		}
+		offsetKey(&page.StructParents)
+		annots, err := page.GetAnnotations()
+		if err != nil {
+			return err
+		}
+		for _, annot := range annots {
+			offsetKey(&annot.StructParent)
+		}
+	}
+	return nil
+}
+
+// numberTreeEntry represents an entry of a number tree.
+type numberTreeEntry struct {
+	key   int
+	value core.PdfObject
+}
+
+// loadNumberTree returns the entries of the number tree whose root node is
+// `obj`.
+func loadNumberTree(obj core.PdfObject) []numberTreeEntry {
+	var entries []numberTreeEntry
+	var load func(obj core.PdfObject, depth int)
+	load = func(obj core.PdfObject, depth int) {
+		node, ok := core.GetDict(obj)
+		if !ok || depth > maxTreeDepth {
+			return
+		}
+		if nums, ok := core.GetArray(node.Get("Nums")); ok {
+			for i := 0; i+1 < nums.Len(); i += 2 {
+				key, ok := core.GetIntVal(nums.Get(i))
+				if !ok {
+					common.Log.Debug("Invalid number tree key: %v", nums.Get(i))
+					continue
+				}
+				entries = append(entries, numberTreeEntry{key: key, value: nums.Get(i + 1)})
+			}
+		}
+		if kids, ok := core.GetArray(node.Get("Kids")); ok {
+			for _, kid := range kids.Elements() {
+				load(kid, depth+1)
+			}
+		}
+	}
+	load(obj, 0)
+	return entries
+}
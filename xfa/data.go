@@ -0,0 +1,263 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package xfa
+
+import (
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unipdf/v3/core"
+)
+
+// dataNamespace is the namespace of the XFA data elements.
+const dataNamespace = "http://www.xfa.org/schema/xfa-data/1.0/"
+
+// dataElement returns the xfa:data element of the datasets, or nil if not
+// found.
+func (f *Form) dataElement() *Node {
+	if f.datasets == nil {
+		return nil
+	}
+	return f.datasets.Element("data")
+}
+
+// dataRoot returns the root element of the form data, or nil if the form
+// does not have data.
+func (f *Form) dataRoot() *Node {
+	data := f.dataElement()
+	if data == nil {
+		return nil
+	}
+	for _, elem := range data.Elements() {
+		if elem.Name.Space == "" || elem.Name.Space != data.Name.Space {
+			return elem
+		}
+	}
+	return nil
+}
+
+// ensureDataElement returns the xfa:data element of the datasets, creating
+// the datasets and data elements if they do not exist.
+func (f *Form) ensureDataElement() *Node {
+	if f.datasets == nil {
+		f.datasets = newElement("xfa", "datasets")
+		f.datasets.Attrs = append(f.datasets.Attrs, xmlnsAttr("xfa", dataNamespace))
+
+		if root := f.root(); root != nil {
+			var before *Node
+			for _, elem := range root.Elements() {
+				if elem.Local() == "form" {
+					before = elem
+					break
+				}
+			}
+			root.insertBefore(f.datasets, before)
+		}
+	}
+
+	data := f.dataElement()
+	if data == nil {
+		data = newElement(f.datasets.Name.Space, "data")
+		f.datasets.AppendChild(data)
+	}
+	return data
+}
+
+// Data returns the XML representation of the form data, i.e. the root
+// element contained by the xfa:data element of the datasets. Returns nil if
+// the form does not have data.
+func (f *Form) Data() []byte {
+	root := f.dataRoot()
+	if root == nil {
+		return nil
+	}
+	return root.Bytes()
+}
+
+// SetData replaces the form data with the XML document `data`, whose root
+// element becomes the data root. The data is saved to the document by
+// UpdateAcroForm.
+func (f *Form) SetData(data []byte) error {
+	elem, err := parseElement(data)
+	if err != nil {
+		return err
+	}
+
+	dataElem := f.ensureDataElement()
+	if root := f.dataRoot(); root != nil {
+		dataElem.replaceChild(root, elem)
+	} else {
+		dataElem.AppendChild(elem)
+	}
+	return nil
+}
+
+// Values returns the data values of the form, keyed by their path relative
+// to the data parent (e.g. form1.address.city). Repeated elements are
+// indexed, starting from 0 (e.g. form1.items.item[1].qty).
+func (f *Form) Values() map[string]string {
+	values := map[string]string{}
+	root := f.dataRoot()
+	if root == nil {
+		return values
+	}
+
+	var walk func(n *Node, path string)
+	walk = func(n *Node, path string) {
+		if !n.hasElements() {
+			values[path] = n.Text()
+			return
+		}
+
+		elems := n.Elements()
+		counts := map[string]int{}
+		for _, elem := range elems {
+			counts[elem.Local()]++
+		}
+		indices := map[string]int{}
+		for _, elem := range elems {
+			name := elem.Local()
+			if counts[name] > 1 {
+				name += "[" + strconv.Itoa(indices[elem.Local()]) + "]"
+				indices[elem.Local()]++
+			}
+			walk(elem, path+"."+name)
+		}
+	}
+	walk(root, root.Local())
+	return values
+}
+
+// parsePathPart splits a data path part into its name and index.
+func parsePathPart(part string) (string, int, error) {
+	i := strings.Index(part, "[")
+	if i < 0 {
+		return part, 0, nil
+	}
+	if !strings.HasSuffix(part, "]") {
+		return "", 0, errors.New("invalid data path: " + part)
+	}
+	idx, err := strconv.Atoi(part[i+1 : len(part)-1])
+	if err != nil || idx < 0 {
+		return "", 0, errors.New("invalid data path index: " + part)
+	}
+	return part[:i], idx, nil
+}
+
+// findData returns the data element at the specified path. The first part
+// of the path designates the data root, regardless of its name. When
+// `create` is true, the missing elements are created.
+func (f *Form) findData(path string, create bool) (*Node, error) {
+	parts := strings.Split(path, ".")
+	if len(parts) == 0 || parts[0] == "" {
+		return nil, errors.New("empty data path")
+	}
+
+	node := f.dataRoot()
+	if node == nil {
+		if !create {
+			return nil, nil
+		}
+		name, _, err := parsePathPart(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		node = newElement("", name)
+		f.ensureDataElement().AppendChild(node)
+	}
+
+	for _, part := range parts[1:] {
+		name, idx, err := parsePathPart(part)
+		if err != nil {
+			return nil, err
+		}
+		elems := node.ElementsNamed(name)
+		if idx < len(elems) {
+			node = elems[idx]
+			continue
+		}
+		if !create {
+			return nil, nil
+		}
+		var elem *Node
+		for i := len(elems); i <= idx; i++ {
+			elem = newElement("", name)
+			node.AppendChild(elem)
+		}
+		node = elem
+	}
+	return node, nil
+}
+
+// Value returns the data value at the specified path (e.g.
+// form1.address.city). The second return value is false if the form does
+// not have data at this path.
+func (f *Form) Value(path string) (string, bool) {
+	node, err := f.findData(path, false)
+	if err != nil || node == nil {
+		return "", false
+	}
+	return node.Text(), true
+}
+
+// SetValue sets the data value at the specified path (e.g.
+// form1.address.city), creating the missing data elements. The data is
+// saved to the document by UpdateAcroForm.
+func (f *Form) SetValue(path, value string) error {
+	node, err := f.findData(path, true)
+	if err != nil {
+		return err
+	}
+	if node.hasElements() {
+		return errors.New("data path designates a data group: " + path)
+	}
+	node.SetText(value)
+	return nil
+}
+
+// FieldValue returns the data value bound to the field. Returns the default
+// value of the field if it is not bound to data, or if the form does not
+// have data for it.
+func (f *Form) FieldValue(field *Field) string {
+	if field.DataPath != "" {
+		if value, ok := f.Value(field.DataPath); ok {
+			return value
+		}
+	}
+	return field.Default
+}
+
+// FieldValues implements model.FieldValueProvider interface. The values are
+// keyed by the SOM expressions of the fields, which static XFA forms use as
+// the full names of the equivalent AcroForm fields. This allows the XFA form
+// data to be transferred to the AcroForm fields with PdfAcroForm.Fill before
+// removing the XFA form.
+func (f *Form) FieldValues() (map[string]core.PdfObject, error) {
+	values := map[string]core.PdfObject{}
+	for _, field := range f.Fields() {
+		switch field.Type {
+		case FieldTypeButton, FieldTypeSignature, FieldTypeImage:
+			continue
+		}
+		value := f.FieldValue(field)
+		if value == "" {
+			continue
+		}
+		values[field.SOM] = core.MakeString(value)
+	}
+	return values, nil
+}
+
+// xmlnsAttr returns the declaration of the namespace prefix.
+func xmlnsAttr(prefix, url string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Space: "xmlns", Local: prefix}, Value: url}
+}
@@ -0,0 +1,300 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package xfa
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NodeKind represents the kind of an XML node.
+type NodeKind int
+
+// XML node kinds.
+const (
+	// ElementNode is an XML element.
+	ElementNode NodeKind = iota
+
+	// TextNode is character data.
+	TextNode
+
+	// RawNode is a comment, processing instruction or directive, which is
+	// preserved verbatim.
+	RawNode
+)
+
+// Node represents a node of an XFA XML document. Namespace prefixes are
+// preserved as is: the Space field of element and attribute names holds
+// the prefix, not the namespace URL. This allows the packets of the XFA
+// document to be written back unchanged, except for the modified nodes.
+type Node struct {
+	Kind     NodeKind
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Children []*Node
+
+	// Data holds the character data of text nodes, and the verbatim content
+	// of raw nodes.
+	Data string
+
+	parent *Node
+}
+
+// newElement returns a new element node with the specified prefixed name.
+func newElement(prefix, local string) *Node {
+	return &Node{Kind: ElementNode, Name: xml.Name{Space: prefix, Local: local}}
+}
+
+// parseNodes parses the XML nodes contained in `data`. The data does not
+// have to be a complete document: unclosed elements are closed at the end
+// of the data, as with the preamble of XFA packet arrays.
+func parseNodes(data []byte) ([]*Node, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	root := &Node{}
+	cur := root
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &Node{Kind: ElementNode, Name: t.Name, parent: cur}
+			n.Attrs = append(n.Attrs, t.Attr...)
+			cur.Children = append(cur.Children, n)
+			cur = n
+		case xml.EndElement:
+			if cur == root || cur.Name != t.Name {
+				return nil, fmt.Errorf("unexpected end element: %s", qualifiedName(t.Name))
+			}
+			cur = cur.parent
+		case xml.CharData:
+			cur.Children = append(cur.Children, &Node{Kind: TextNode, Data: string(t), parent: cur})
+		case xml.Comment:
+			cur.Children = append(cur.Children, &Node{Kind: RawNode, Data: "<!--" + string(t) + "-->", parent: cur})
+		case xml.ProcInst:
+			raw := "<?" + t.Target
+			if len(t.Inst) > 0 {
+				raw += " " + string(t.Inst)
+			}
+			cur.Children = append(cur.Children, &Node{Kind: RawNode, Data: raw + "?>", parent: cur})
+		case xml.Directive:
+			cur.Children = append(cur.Children, &Node{Kind: RawNode, Data: "<!" + string(t) + ">", parent: cur})
+		}
+	}
+
+	for _, n := range root.Children {
+		n.parent = nil
+	}
+	return root.Children, nil
+}
+
+// parseElement parses the XML document contained in `data` and returns its
+// root element.
+func parseElement(data []byte) (*Node, error) {
+	nodes, err := parseNodes(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.Kind == ElementNode {
+			return n, nil
+		}
+	}
+	return nil, errors.New("xml element not found")
+}
+
+// qualifiedName returns the prefixed name.
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// Local returns the local name of the element.
+func (n *Node) Local() string {
+	return n.Name.Local
+}
+
+// Parent returns the parent element of the node, or nil for root nodes.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Attr returns the value of the attribute with the specified local name.
+func (n *Node) Attr(local string) (string, bool) {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == local && attr.Name.Space != "xmlns" {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetAttr sets the value of the attribute with the specified local name.
+func (n *Node) SetAttr(local, value string) {
+	for i, attr := range n.Attrs {
+		if attr.Name.Local == local && attr.Name.Space != "xmlns" {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+	n.Attrs = append(n.Attrs, xml.Attr{Name: xml.Name{Local: local}, Value: value})
+}
+
+// Elements returns the child elements of the node.
+func (n *Node) Elements() []*Node {
+	var elems []*Node
+	for _, c := range n.Children {
+		if c.Kind == ElementNode {
+			elems = append(elems, c)
+		}
+	}
+	return elems
+}
+
+// Element returns the first child element with the specified local name.
+func (n *Node) Element(local string) *Node {
+	for _, c := range n.Children {
+		if c.Kind == ElementNode && c.Name.Local == local {
+			return c
+		}
+	}
+	return nil
+}
+
+// ElementsNamed returns the child elements with the specified local name.
+func (n *Node) ElementsNamed(local string) []*Node {
+	var elems []*Node
+	for _, c := range n.Children {
+		if c.Kind == ElementNode && c.Name.Local == local {
+			elems = append(elems, c)
+		}
+	}
+	return elems
+}
+
+// Text returns the concatenated character data of the node and of its
+// descendants.
+func (n *Node) Text() string {
+	if n.Kind == TextNode {
+		return n.Data
+	}
+
+	var sb strings.Builder
+	for _, c := range n.Children {
+		if c.Kind != RawNode {
+			sb.WriteString(c.Text())
+		}
+	}
+	return sb.String()
+}
+
+// SetText replaces the children of the element with character data.
+func (n *Node) SetText(text string) {
+	n.Children = nil
+	if text != "" {
+		n.Children = []*Node{{Kind: TextNode, Data: text, parent: n}}
+	}
+}
+
+// AppendChild appends `child` to the children of the element.
+func (n *Node) AppendChild(child *Node) {
+	child.parent = n
+	n.Children = append(n.Children, child)
+}
+
+// insertBefore inserts `child` before the child `ref` of the element, or
+// at the end if `ref` is nil or not a child of the element.
+func (n *Node) insertBefore(child, ref *Node) {
+	child.parent = n
+	for i, c := range n.Children {
+		if c == ref {
+			n.Children = append(n.Children[:i], append([]*Node{child}, n.Children[i:]...)...)
+			return
+		}
+	}
+	n.Children = append(n.Children, child)
+}
+
+// replaceChild replaces the child `old` of the element with `child`.
+func (n *Node) replaceChild(old, child *Node) {
+	for i, c := range n.Children {
+		if c == old {
+			child.parent = n
+			n.Children[i] = child
+			return
+		}
+	}
+	n.AppendChild(child)
+}
+
+// hasElements returns true if the node has child elements.
+func (n *Node) hasElements() bool {
+	for _, c := range n.Children {
+		if c.Kind == ElementNode {
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns the XML representation of the node.
+func (n *Node) Bytes() []byte {
+	var buf bytes.Buffer
+	n.write(&buf)
+	return buf.Bytes()
+}
+
+// write writes the XML representation of the node to `buf`.
+func (n *Node) write(buf *bytes.Buffer) {
+	switch n.Kind {
+	case TextNode:
+		buf.WriteString(textEscaper.Replace(n.Data))
+		return
+	case RawNode:
+		buf.WriteString(n.Data)
+		return
+	}
+
+	name := qualifiedName(n.Name)
+	buf.WriteString("<" + name)
+	for _, attr := range n.Attrs {
+		buf.WriteString(" " + qualifiedName(attr.Name) + "=\"" + attrEscaper.Replace(attr.Value) + "\"")
+	}
+	if len(n.Children) == 0 {
+		buf.WriteString("/>")
+		return
+	}
+	buf.WriteString(">")
+	for _, c := range n.Children {
+		c.write(buf)
+	}
+	buf.WriteString("</" + name + ">")
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", "\"", "&quot;", "\n", "&#xA;", "\r", "&#xD;", "\t", "&#x9;")
+)
@@ -0,0 +1,229 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+package xfa
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FieldType represents the type of the user interface of an XFA field.
+type FieldType string
+
+// XFA field types, named after the ui element of the template fields.
+const (
+	FieldTypeText      FieldType = "textEdit"
+	FieldTypeNumeric   FieldType = "numericEdit"
+	FieldTypeDateTime  FieldType = "dateTimeEdit"
+	FieldTypePassword  FieldType = "passwordEdit"
+	FieldTypeCheckbox  FieldType = "checkButton"
+	FieldTypeChoice    FieldType = "choiceList"
+	FieldTypeButton    FieldType = "button"
+	FieldTypeSignature FieldType = "signature"
+	FieldTypeImage     FieldType = "imageEdit"
+	FieldTypeBarcode   FieldType = "barcode"
+
+	// FieldTypeExclusiveGroup is a group of mutually exclusive check
+	// buttons (exclGroup), i.e. radio buttons.
+	FieldTypeExclusiveGroup FieldType = "exclGroup"
+)
+
+// Field represents a field of the XFA form template.
+type Field struct {
+	// Name is the name of the field.
+	Name string
+
+	// SOM is the fully qualified Scripting Object Model expression of the
+	// field (e.g. form1[0].#subform[0].Name[0]). Static XFA forms use it as
+	// the full name of the equivalent AcroForm field.
+	SOM string
+
+	// DataPath is the path of the data value bound to the field, relative to
+	// the data root (e.g. form1.address.city). Empty if the field is not
+	// bound to data.
+	DataPath string
+
+	// Type is the type of the user interface of the field.
+	Type FieldType
+
+	// Items are the values the field can take: the choices of choice lists,
+	// the on and off values of check buttons, or the on values of the
+	// buttons of exclusive groups.
+	Items []string
+
+	// Default is the default value of the field, specified by the template.
+	Default string
+
+	// Node is the template element of the field.
+	Node *Node
+}
+
+// containerElements are the template elements which contain fields.
+var containerElements = map[string]bool{
+	"subform":    true,
+	"subformSet": true,
+	"area":       true,
+	"pageSet":    true,
+	"pageArea":   true,
+}
+
+// Fields returns the fields of the form template, in document order.
+func (f *Form) Fields() []*Field {
+	if f.template == nil {
+		return nil
+	}
+
+	var fields []*Field
+	var walk func(n *Node, som []string, data []string)
+	walk = func(n *Node, som []string, data []string) {
+		counts := map[string]int{}
+		for _, elem := range n.Elements() {
+			local := elem.Local()
+			isField := local == "field" || local == "exclGroup"
+			if !isField && !containerElements[local] {
+				continue
+			}
+
+			name, _ := elem.Attr("name")
+			somName := name
+			if somName == "" {
+				somName = "#" + local
+			}
+			elemSOM := append(append([]string{}, som...), somName+"["+strconv.Itoa(counts[somName])+"]")
+			counts[somName]++
+
+			elemData := bindingPath(elem, data, name)
+			if isField {
+				fields = append(fields, newField(elem, name, elemSOM, elemData))
+				continue
+			}
+			walk(elem, elemSOM, elemData)
+		}
+	}
+	walk(f.template, nil, nil)
+	return fields
+}
+
+// bindingPath returns the data path of the template element, based on its
+// bind element. Unnamed elements and elements with global or no binding do
+// not contribute to the data path. Returns nil if the element is not bound
+// to data.
+func bindingPath(elem *Node, parent []string, name string) []string {
+	match := "once"
+	var ref string
+	if bind := elem.Element("bind"); bind != nil {
+		if m, ok := bind.Attr("match"); ok {
+			match = m
+		}
+		ref, _ = bind.Attr("ref")
+	}
+
+	switch match {
+	case "none":
+		return nil
+	case "dataRef":
+		return resolveDataRef(ref, parent)
+	case "global":
+		return []string{name}
+	}
+
+	path := append([]string{}, parent...)
+	if name != "" && elem.Local() != "pageSet" && elem.Local() != "pageArea" {
+		path = append(path, name)
+	}
+	return path
+}
+
+// resolveDataRef resolves the data reference of a dataRef binding (e.g.
+// $.address.city or $record.city) relative to the data path of the parent.
+func resolveDataRef(ref string, parent []string) []string {
+	var path []string
+	switch {
+	case strings.HasPrefix(ref, "$record."), strings.HasPrefix(ref, "$data."):
+		if len(parent) > 0 {
+			path = append(path, parent[0])
+		}
+		ref = ref[strings.Index(ref, ".")+1:]
+	case strings.HasPrefix(ref, "$."):
+		path = append(path, parent...)
+		ref = ref[2:]
+	case ref == "$":
+		return append(path, parent...)
+	default:
+		path = append(path, parent...)
+	}
+
+	for _, part := range strings.Split(ref, ".") {
+		if i := strings.Index(part, "["); i >= 0 {
+			part = part[:i]
+		}
+		if part != "" {
+			path = append(path, part)
+		}
+	}
+	return path
+}
+
+// newField returns the field represented by the template element.
+func newField(elem *Node, name string, som, data []string) *Field {
+	field := &Field{
+		Name:     name,
+		SOM:      strings.Join(som, "."),
+		DataPath: strings.Join(data, "."),
+		Node:     elem,
+	}
+
+	if elem.Local() == "exclGroup" {
+		field.Type = FieldTypeExclusiveGroup
+		for _, button := range elem.ElementsNamed("field") {
+			if items := itemValues(button); len(items) > 0 {
+				field.Items = append(field.Items, items[0])
+			}
+		}
+	} else {
+		if ui := elem.Element("ui"); ui != nil {
+			for _, e := range ui.Elements() {
+				if e.Local() != "extras" && e.Local() != "picture" {
+					field.Type = FieldType(e.Local())
+					break
+				}
+			}
+		}
+		if field.Type == "" {
+			field.Type = FieldTypeText
+		}
+		field.Items = itemValues(elem)
+	}
+
+	if value := elem.Element("value"); value != nil {
+		for _, e := range value.Elements() {
+			field.Default = strings.TrimSpace(e.Text())
+			break
+		}
+	}
+	return field
+}
+
+// itemValues returns the values of the items of the template element. When
+// an element has both displayed and saved items, the saved values are
+// returned.
+func itemValues(elem *Node) []string {
+	var items []string
+	for _, list := range elem.ElementsNamed("items") {
+		var values []string
+		for _, e := range list.Elements() {
+			values = append(values, strings.TrimSpace(e.Text()))
+		}
+		if save, _ := list.Attr("save"); save == "1" || items == nil {
+			items = values
+		}
+	}
+	return items
+}
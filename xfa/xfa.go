@@ -0,0 +1,358 @@
+//
+// Copyright 2020 FoxyUtils ehf. All rights reserved.
+//
+// This is a commercial product and requires a license to operate.
+// A trial license can be obtained at https://unidoc.io
+//
+// Use of this source code is governed by the UniDoc End User License Agreement
+// terms that can be accessed at https://unidoc.io/eula/
+
+// Package xfa provides support for XML Forms Architecture (XFA) forms: detection of static and
+// dynamic XFA forms, parsing of the template and datasets packets, extraction and update of the
+// form data, and removal of the XFA form from static forms, so that the AcroForm fields can be
+// filled instead.
+package xfa
+
+import (
+	"errors"
+
+	"github.com/unidoc/unipdf/v3/common"
+	"github.com/unidoc/unipdf/v3/core"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// Type represents the type of an XFA form.
+type Type int
+
+// XFA form types.
+const (
+	// TypeNone indicates that the document does not contain an XFA form.
+	TypeNone Type = iota
+
+	// TypeStatic is a static XFA form, whose layout is fixed and which
+	// contains equivalent AcroForm fields.
+	TypeStatic
+
+	// TypeDynamic is a dynamic XFA form, whose pages are generated by the
+	// viewer from the template (NeedsRendering). The AcroForm fields, if
+	// any, are not usable.
+	TypeDynamic
+)
+
+// String returns the name of the XFA form type.
+func (t Type) String() string {
+	switch t {
+	case TypeStatic:
+		return "static"
+	case TypeDynamic:
+		return "dynamic"
+	}
+	return "none"
+}
+
+// ErrNoXFA is returned when loading the XFA form of a document which does
+// not contain one.
+var ErrNoXFA = errors.New("document does not contain an XFA form")
+
+// ErrDynamicXFA is returned when removing the XFA form of a dynamic form,
+// which does not have an AcroForm equivalent.
+var ErrDynamicXFA = errors.New("dynamic XFA form cannot be converted to AcroForm")
+
+// Packet represents a packet of the XFA form: an XML element of the XDP
+// document, such as the template or the datasets.
+type Packet struct {
+	Name string
+	Data []byte
+}
+
+// Form represents the XFA form of a PDF document.
+type Form struct {
+	typ     Type
+	packets []*Packet
+
+	// doc holds the nodes of the XDP document, when the XFA entry is a
+	// single stream rather than an array of packets.
+	doc []*Node
+
+	template *Node
+	datasets *Node
+}
+
+// Detect returns the type of the XFA form of the document read by `reader`.
+// Returns TypeNone if the document does not contain an XFA form.
+func Detect(reader *model.PdfReader) Type {
+	if reader == nil || reader.AcroForm == nil || reader.AcroForm.XFA == nil {
+		return TypeNone
+	}
+	return detectType(reader.AcroForm, needsRendering(reader))
+}
+
+// needsRendering returns the NeedsRendering entry of the document catalog.
+func needsRendering(reader *model.PdfReader) bool {
+	trailer, err := reader.GetTrailer()
+	if err != nil {
+		return false
+	}
+	catalog, ok := core.GetDict(trailer.Get("Root"))
+	if !ok {
+		return false
+	}
+	val, ok := core.GetBoolVal(catalog.Get("NeedsRendering"))
+	return ok && val
+}
+
+// detectType returns the type of the XFA form of `form`. Forms which need
+// to be rendered by the viewer, or without terminal AcroForm fields having
+// widgets, are dynamic.
+func detectType(form *model.PdfAcroForm, needsRendering bool) Type {
+	if form == nil || form.XFA == nil {
+		return TypeNone
+	}
+	if needsRendering {
+		return TypeDynamic
+	}
+	for _, field := range form.AllFields() {
+		if field.IsTerminal() && len(field.Annotations) > 0 {
+			return TypeStatic
+		}
+	}
+	return TypeDynamic
+}
+
+// Load loads the XFA form of the document read by `reader`. Returns ErrNoXFA
+// if the document does not contain an XFA form.
+func Load(reader *model.PdfReader) (*Form, error) {
+	if reader == nil {
+		return nil, ErrNoXFA
+	}
+	f, err := LoadFromAcroForm(reader.AcroForm)
+	if err != nil {
+		return nil, err
+	}
+	f.typ = detectType(reader.AcroForm, needsRendering(reader))
+	return f, nil
+}
+
+// LoadFromAcroForm loads the XFA form contained in the XFA entry of `form`.
+// As the document catalog is not available, the type of the form is
+// detected from the AcroForm fields only.
+func LoadFromAcroForm(form *model.PdfAcroForm) (*Form, error) {
+	if form == nil || form.XFA == nil {
+		return nil, ErrNoXFA
+	}
+
+	f := &Form{typ: detectType(form, false)}
+	switch t := core.TraceToDirectObject(form.XFA).(type) {
+	case *core.PdfObjectStream:
+		data, err := core.DecodeStream(t)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.loadDocument(data); err != nil {
+			return nil, err
+		}
+	case *core.PdfObjectArray:
+		if err := f.loadPackets(t); err != nil {
+			return nil, err
+		}
+	default:
+		common.Log.Debug("ERROR: invalid XFA entry type: %T", t)
+		return nil, core.ErrTypeError
+	}
+	return f, nil
+}
+
+// loadPackets loads the packets of an XFA entry consisting of an array of
+// packet names and streams.
+func (f *Form) loadPackets(arr *core.PdfObjectArray) error {
+	elems := arr.Elements()
+	if len(elems)%2 != 0 {
+		return errors.New("invalid XFA packet array length")
+	}
+
+	for i := 0; i < len(elems); i += 2 {
+		name, ok := core.GetStringVal(elems[i])
+		if !ok {
+			return core.ErrTypeError
+		}
+		stream, ok := core.GetStream(elems[i+1])
+		if !ok {
+			return core.ErrTypeError
+		}
+		data, err := core.DecodeStream(stream)
+		if err != nil {
+			return err
+		}
+		f.packets = append(f.packets, &Packet{Name: name, Data: data})
+	}
+
+	for _, p := range f.packets {
+		var target **Node
+		switch p.Name {
+		case "template":
+			target = &f.template
+		case "datasets":
+			target = &f.datasets
+		default:
+			continue
+		}
+		elem, err := parseElement(p.Data)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to parse XFA %s packet: %v", p.Name, err)
+			return err
+		}
+		*target = elem
+	}
+	return nil
+}
+
+// loadDocument loads the packets of an XFA entry consisting of a single
+// stream containing the complete XDP document.
+func (f *Form) loadDocument(data []byte) error {
+	nodes, err := parseNodes(data)
+	if err != nil {
+		return err
+	}
+	f.doc = nodes
+
+	root := f.root()
+	if root == nil {
+		return errors.New("XDP root element not found")
+	}
+	for _, elem := range root.Elements() {
+		f.packets = append(f.packets, &Packet{Name: elem.Local(), Data: elem.Bytes()})
+		switch elem.Local() {
+		case "template":
+			f.template = elem
+		case "datasets":
+			f.datasets = elem
+		}
+	}
+	return nil
+}
+
+// root returns the root element of the XDP document, when the XFA entry is
+// a single stream.
+func (f *Form) root() *Node {
+	for _, n := range f.doc {
+		if n.Kind == ElementNode {
+			return n
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the XFA form.
+func (f *Form) Type() Type {
+	return f.typ
+}
+
+// Packets returns the packets of the XFA form, in document order.
+func (f *Form) Packets() []*Packet {
+	return f.packets
+}
+
+// Packet returns the data of the packet with the specified name (e.g.
+// "template", "datasets" or "config"), or nil if not found.
+func (f *Form) Packet(name string) []byte {
+	for _, p := range f.packets {
+		if p.Name == name {
+			return p.Data
+		}
+	}
+	return nil
+}
+
+// Template returns the root element of the template packet, which defines
+// the structure of the form. Returns nil if the form does not have a
+// template packet.
+func (f *Form) Template() *Node {
+	return f.template
+}
+
+// Datasets returns the root element of the datasets packet, which contains
+// the form data. Returns nil if the form does not have a datasets packet.
+func (f *Form) Datasets() *Node {
+	return f.datasets
+}
+
+// UpdateAcroForm writes the datasets of the XFA form back to the XFA entry of
+// `form`, so that the updated data is saved along with the document.
+func (f *Form) UpdateAcroForm(form *model.PdfAcroForm) error {
+	if form == nil {
+		return errors.New("AcroForm not specified")
+	}
+	if f.datasets == nil {
+		return nil
+	}
+	data := f.datasets.Bytes()
+
+	if len(f.doc) > 0 {
+		var buf []byte
+		for _, n := range f.doc {
+			buf = append(buf, n.Bytes()...)
+		}
+		stream, err := core.MakeStream(buf, core.NewFlateEncoder())
+		if err != nil {
+			return err
+		}
+		form.XFA = stream
+		f.setPacket("datasets", data)
+		return nil
+	}
+
+	f.setPacket("datasets", data)
+	arr := core.MakeArray()
+	for _, p := range f.packets {
+		stream, err := core.MakeStream(p.Data, core.NewFlateEncoder())
+		if err != nil {
+			return err
+		}
+		arr.Append(core.MakeString(p.Name), stream)
+	}
+	form.XFA = arr
+	return nil
+}
+
+// setPacket sets the data of the packet with the specified name. Packets
+// which do not exist are inserted before the form packet, or before the
+// postamble if there is no form packet.
+func (f *Form) setPacket(name string, data []byte) {
+	for _, p := range f.packets {
+		if p.Name == name {
+			p.Data = data
+			return
+		}
+	}
+
+	pos := len(f.packets)
+	for i, p := range f.packets {
+		if p.Name == "form" || p.Name == "postamble" {
+			pos = i
+			break
+		}
+	}
+	f.packets = append(f.packets, nil)
+	copy(f.packets[pos+1:], f.packets[pos:])
+	f.packets[pos] = &Packet{Name: name, Data: data}
+}
+
+// RemoveXFA removes the XFA form of `form`, so that viewers and processors
+// use the AcroForm fields instead. Returns ErrDynamicXFA if the form is a
+// dynamic XFA form, whose AcroForm fields are not usable, unless `force` is
+// true. The form data can be transferred to the AcroForm fields beforehand
+// by filling the AcroForm with the XFA form as field value provider.
+func RemoveXFA(form *model.PdfAcroForm, force bool) error {
+	if form == nil || form.XFA == nil {
+		return nil
+	}
+	if !force && detectType(form, false) == TypeDynamic {
+		return ErrDynamicXFA
+	}
+
+	form.XFA = nil
+	if dict, ok := core.GetDict(form.GetContainingPdfObject()); ok {
+		dict.Remove("XFA")
+	}
+	return nil
+}